@@ -38,10 +38,16 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+const (
+	// serverSideApplyConflictMessage is reported by kubectl when the
+	// server-side apply fails because of the field ownership conflicts
+	serverSideApplyConflictMessage = "Apply failed with"
+)
+
 var (
 	kubectlApplyScript = heredoc.Doc(`
 		sudo KUBECONFIG=/etc/kubernetes/admin.conf \
-		kubectl apply -f - --prune -l "%s=%s"
+		kubectl apply -f - --prune -l "%s=%s"%s
 	`)
 
 	kubectlDeleteScript = heredoc.Doc(`
@@ -178,6 +184,38 @@ func newAddonsApplier(s *state.State) (*applier, error) {
 		Params:    params,
 	}
 
+	// Client certs for scraping the etcd metrics
+	if s.Cluster.Features.EtcdMetrics.ServiceMonitorEnabled() {
+		etcdCAPrivateKey, etcdCACert, err := certificate.EtcdCAKeyPair(s.Configuration)
+		if err != nil {
+			return nil, err
+		}
+
+		etcdMetricsCertsMap, err := certificate.NewSignedClientCert(resources.EtcdMetricsClientName, etcdCAPrivateKey, etcdCACert)
+		if err != nil {
+			return nil, err
+		}
+		data.Certificates["EtcdMetricsClientCert"] = etcdMetricsCertsMap[resources.TLSCertName]
+		data.Certificates["EtcdMetricsClientKey"] = etcdMetricsCertsMap[resources.TLSKeyName]
+		data.Certificates["EtcdCA"] = etcdMetricsCertsMap[resources.KubernetesCACertName]
+	}
+
+	// Certs for prometheus-adapter
+	if s.Cluster.Features.PrometheusAdapter.Enabled() {
+		paCertsMap, err := certificate.NewSignedTLSCert(
+			resources.PrometheusAdapterName,
+			resources.PrometheusAdapterNamespace,
+			s.Cluster.ClusterNetwork.ServiceDomainName,
+			kubeCAPrivateKey,
+			kubeCACert,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data.Certificates["PrometheusAdapterCert"] = paCertsMap[resources.TLSCertName]
+		data.Certificates["PrometheusAdapterKey"] = paCertsMap[resources.TLSKeyName]
+	}
+
 	// Certs for CSI plugins
 	switch {
 	// Certs for vsphere-csi-webhook (deployed only if CSIMigration is enabled)
@@ -380,7 +418,7 @@ func (a *applier) loadAndDeleteAddon(s *state.State, fsys fs.FS, addonName strin
 func runKubectlApply(s *state.State, manifest string, addonName string) error {
 	return s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
 		var (
-			cmd            = fmt.Sprintf(kubectlApplyScript, addonLabel, addonName)
+			cmd            = fmt.Sprintf(kubectlApplyScript, addonLabel, addonName, kubectlApplyFlags(s.Cluster.Addons, addonName))
 			stdin          = strings.NewReader(manifest)
 			stdout, stderr strings.Builder
 		)
@@ -392,10 +430,35 @@ func runKubectlApply(s *state.State, manifest string, addonName string) error {
 			fmt.Printf("%s", stdout.String())
 		}
 
+		if err != nil && strings.Contains(stderr.String(), serverSideApplyConflictMessage) {
+			return fail.KubeClient(err, "applying addon %q: fields are owned by another field manager, "+
+				"enable forceConflicts for the addon to take over their ownership\n%s", addonName, stderr.String())
+		}
+
 		return err
 	})
 }
 
+// kubectlApplyFlags returns additional kubectl apply flags used to apply the
+// addon using the server-side apply, if the field manager is configured
+func kubectlApplyFlags(addons *kubeoneapi.Addons, addonName string) string {
+	if !addons.Enabled() || addons.FieldManager == "" {
+		return ""
+	}
+
+	flags := fmt.Sprintf(" --server-side --field-manager=%q", addons.FieldManager)
+
+	for _, addon := range addons.Addons {
+		if addon.Name == addonName && addon.ForceConflicts {
+			flags += " --force-conflicts"
+
+			break
+		}
+	}
+
+	return flags
+}
+
 // runKubectlDelete runs kubectl delete command
 func runKubectlDelete(s *state.State, manifest string, addonName string) error {
 	return s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
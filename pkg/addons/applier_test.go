@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestKubectlApplyFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		addons    *kubeoneapi.Addons
+		addonName string
+		expected  string
+	}{
+		{
+			name:      "addons not configured",
+			addons:    nil,
+			addonName: "cluster-autoscaler",
+			expected:  "",
+		},
+		{
+			name:      "field manager not configured",
+			addons:    &kubeoneapi.Addons{Enable: true, Path: "./addons"},
+			addonName: "cluster-autoscaler",
+			expected:  "",
+		},
+		{
+			name:      "addons disabled",
+			addons:    &kubeoneapi.Addons{FieldManager: "kubeone"},
+			addonName: "cluster-autoscaler",
+			expected:  "",
+		},
+		{
+			name:      "server-side apply",
+			addons:    &kubeoneapi.Addons{Enable: true, FieldManager: "kubeone"},
+			addonName: "cluster-autoscaler",
+			expected:  ` --server-side --field-manager="kubeone"`,
+		},
+		{
+			name: "server-side apply with forced conflicts",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				FieldManager: "kubeone",
+				Addons: []kubeoneapi.Addon{
+					{Name: "unattended-upgrades"},
+					{Name: "cluster-autoscaler", ForceConflicts: true},
+				},
+			},
+			addonName: "cluster-autoscaler",
+			expected:  ` --server-side --field-manager="kubeone" --force-conflicts`,
+		},
+		{
+			name: "forced conflicts for other addon",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				FieldManager: "kubeone",
+				Addons: []kubeoneapi.Addon{
+					{Name: "unattended-upgrades", ForceConflicts: true},
+				},
+			},
+			addonName: "cluster-autoscaler",
+			expected:  ` --server-side --field-manager="kubeone"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := kubectlApplyFlags(tt.addons, tt.addonName); got != tt.expected {
+				t.Errorf("kubectlApplyFlags() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
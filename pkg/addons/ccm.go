@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/templates/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	ccmWorkloadSuffix = "cloud-controller-manager"
+	ccmLeaderElectArg = "leader-elect"
+)
+
+// ccmAddons are the embedded addons deploying the cloud-controller-manager
+var ccmAddons = map[string]bool{
+	resources.AddonCCMAws:          true,
+	resources.AddonCCMAzure:        true,
+	resources.AddonCCMDigitalOcean: true,
+	resources.AddonCCMEquinixMetal: true,
+	resources.AddonCCMHetzner:      true,
+	resources.AddonCCMOpenStack:    true,
+	resources.AddonCCMPacket:       true,
+	resources.AddonCCMVsphere:      true,
+}
+
+// customizeCCM applies the user provided flags, resources and replicas to the
+// cloud-controller-manager Deployment or DaemonSet. Any change of the pod
+// template rolls the cloud-controller-manager pods.
+func customizeCCM(manifests []runtime.RawExtension, ccm *kubeoneapi.CCMConfig) ([]runtime.RawExtension, error) {
+	if ccm == nil {
+		return manifests, nil
+	}
+
+	for i, m := range manifests {
+		obj := &metav1unstructured.Unstructured{}
+		if _, _, err := metav1unstructured.UnstructuredJSONScheme.Decode(m.Raw, nil, obj); err != nil {
+			return nil, fail.Runtime(err, "parsing unstructured fields")
+		}
+
+		if !strings.HasSuffix(obj.GetName(), ccmWorkloadSuffix) {
+			continue
+		}
+
+		var workload interface{}
+		switch obj.GetKind() {
+		case "Deployment":
+			dep := &appsv1.Deployment{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, dep); err != nil {
+				return nil, fail.Runtime(err, "converting %s %s", obj.GetKind(), obj.GetName())
+			}
+			if ccm.Replicas != nil {
+				dep.Spec.Replicas = ccm.Replicas
+			}
+			customizeCCMPodSpec(&dep.Spec.Template.Spec, ccm, ccm.Replicas != nil && *ccm.Replicas > 1)
+			workload = dep
+		case "DaemonSet":
+			ds := &appsv1.DaemonSet{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, ds); err != nil {
+				return nil, fail.Runtime(err, "converting %s %s", obj.GetKind(), obj.GetName())
+			}
+			customizeCCMPodSpec(&ds.Spec.Template.Spec, ccm, false)
+			workload = ds
+		default:
+			continue
+		}
+
+		raw, err := json.Marshal(workload)
+		if err != nil {
+			return nil, fail.Runtime(err, "marshalling %s %s", obj.GetKind(), obj.GetName())
+		}
+		manifests[i] = runtime.RawExtension{Raw: raw}
+	}
+
+	return manifests, nil
+}
+
+func customizeCCMPodSpec(podSpec *corev1.PodSpec, ccm *kubeoneapi.CCMConfig, leaderElect bool) {
+	if len(podSpec.Containers) == 0 {
+		return
+	}
+
+	// the cloud-controller-manager is always the first container
+	container := &podSpec.Containers[0]
+
+	if ccm.Resources != nil {
+		container.Resources = *ccm.Resources
+	}
+
+	extraArgs := map[string]string{}
+	// multiple replicas must not run the controllers at the same time
+	if leaderElect {
+		extraArgs[ccmLeaderElectArg] = "true"
+	}
+	for k, v := range ccm.ExtraArgs {
+		extraArgs[k] = v
+	}
+
+	// some manifests pass the flags in the command instead of args
+	if len(container.Args) > 0 || len(container.Command) == 0 {
+		container.Args = setFlags(container.Args, extraArgs)
+	} else {
+		container.Command = setFlags(container.Command, extraArgs)
+	}
+}
+
+// setFlags sets the given flags, replacing existing flags with the same name
+// and appending the new ones in the alphabetical order
+func setFlags(args []string, flags map[string]string) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := fmt.Sprintf("--%s=%s", name, flags[name])
+
+		var found bool
+		for i, arg := range args {
+			if arg == "--"+name || strings.HasPrefix(arg, "--"+name+"=") {
+				args[i] = flag
+				found = true
+			}
+		}
+		if !found {
+			args = append(args, flag)
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const testCCMDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: hcloud-cloud-controller-manager
+  namespace: kube-system
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        - name: hcloud-cloud-controller-manager
+          command:
+            - "/bin/hcloud-cloud-controller-manager"
+            - "--cloud-provider=hcloud"
+            - "--leader-elect=false"
+            - "--allow-untagged-cloud"
+`
+
+func TestCustomizeCCM(t *testing.T) {
+	replicas := int32(2)
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("200m"),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		ccm              *kubeoneapi.CCMConfig
+		expectedReplicas int32
+		expectedCommand  []string
+		expectedRes      corev1.ResourceRequirements
+	}{
+		{
+			name:             "no customization",
+			expectedReplicas: 1,
+			expectedCommand: []string{
+				"/bin/hcloud-cloud-controller-manager",
+				"--cloud-provider=hcloud",
+				"--leader-elect=false",
+				"--allow-untagged-cloud",
+			},
+		},
+		{
+			name: "extra args overriding existing flags",
+			ccm: &kubeoneapi.CCMConfig{
+				ExtraArgs: map[string]string{
+					"allow-untagged-cloud":     "false",
+					"concurrent-service-syncs": "5",
+				},
+			},
+			expectedReplicas: 1,
+			expectedCommand: []string{
+				"/bin/hcloud-cloud-controller-manager",
+				"--cloud-provider=hcloud",
+				"--leader-elect=false",
+				"--allow-untagged-cloud=false",
+				"--concurrent-service-syncs=5",
+			},
+		},
+		{
+			name: "replicas and resources",
+			ccm: &kubeoneapi.CCMConfig{
+				Replicas:  &replicas,
+				Resources: resources,
+			},
+			expectedReplicas: 2,
+			expectedCommand: []string{
+				"/bin/hcloud-cloud-controller-manager",
+				"--cloud-provider=hcloud",
+				"--leader-elect=true",
+				"--allow-untagged-cloud",
+			},
+			expectedRes: *resources,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := kyaml.ToJSON([]byte(testCCMDeployment))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			manifests, err := customizeCCM([]runtime.RawExtension{{Raw: raw}}, tt.ccm)
+			if err != nil {
+				t.Fatalf("customizeCCM() error = %v", err)
+			}
+
+			dep := appsv1.Deployment{}
+			if err = yaml.Unmarshal(manifests[0].Raw, &dep); err != nil {
+				t.Fatal(err)
+			}
+
+			if *dep.Spec.Replicas != tt.expectedReplicas {
+				t.Errorf("expected %d replicas, got %d", tt.expectedReplicas, *dep.Spec.Replicas)
+			}
+
+			container := dep.Spec.Template.Spec.Containers[0]
+			if !reflect.DeepEqual(container.Command, tt.expectedCommand) {
+				t.Errorf("expected command %v, got %v", tt.expectedCommand, container.Command)
+			}
+
+			if !reflect.DeepEqual(container.Resources, tt.expectedRes) {
+				t.Errorf("expected resources %v, got %v", tt.expectedRes, container.Resources)
+			}
+		})
+	}
+}
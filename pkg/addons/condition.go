@@ -0,0 +1,378 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+const (
+	conditionIdentProvider = "provider"
+	conditionIdentK8s      = "k8s"
+)
+
+// conditionProviders is a list of the cloud provider names that can be used
+// in the addon conditions
+var conditionProviders = []string{
+	"aws",
+	"azure",
+	"digitalocean",
+	"equinixmetal",
+	"gce",
+	"hetzner",
+	"none",
+	"nutanix",
+	"openstack",
+	"vmwareCloudDirector",
+	"vsphere",
+}
+
+// Condition is a parsed addon enablement condition (the addon's enabledWhen).
+//
+// The condition is a boolean expression consisting of comparisons joined with
+// && and || operators, optionally negated with ! and grouped with parentheses,
+// e.g.:
+//
+//	provider == aws && k8s >= 1.23
+//
+// Supported comparisons are:
+//   - provider == <name>, provider != <name>, where name is a cloud provider
+//     name as used in the cloudProvider structure (e.g. aws, hetzner, vsphere)
+//   - k8s <op> <version>, where op is one of ==, !=, <, <=, >, >= and version
+//     is compared with the Kubernetes version of the cluster
+type Condition struct {
+	root conditionNode
+}
+
+type conditionEnv struct {
+	provider string
+	k8s      *semver.Version
+}
+
+type conditionNode interface {
+	eval(env conditionEnv) bool
+}
+
+type conditionNot struct {
+	node conditionNode
+}
+
+func (n conditionNot) eval(env conditionEnv) bool {
+	return !n.node.eval(env)
+}
+
+type conditionAnd struct {
+	left, right conditionNode
+}
+
+func (n conditionAnd) eval(env conditionEnv) bool {
+	return n.left.eval(env) && n.right.eval(env)
+}
+
+type conditionOr struct {
+	left, right conditionNode
+}
+
+func (n conditionOr) eval(env conditionEnv) bool {
+	return n.left.eval(env) || n.right.eval(env)
+}
+
+type conditionProvider struct {
+	op       string
+	provider string
+}
+
+func (n conditionProvider) eval(env conditionEnv) bool {
+	if n.op == "!=" {
+		return env.provider != n.provider
+	}
+
+	return env.provider == n.provider
+}
+
+type conditionK8s struct {
+	op      string
+	version *semver.Version
+}
+
+func (n conditionK8s) eval(env conditionEnv) bool {
+	cmp := env.k8s.Compare(n.version)
+
+	switch n.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // >=
+		return cmp >= 0
+	}
+}
+
+// ParseCondition parses the addon enablement condition
+func ParseCondition(expr string) (*Condition, error) {
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("condition is empty")
+	}
+
+	p := &conditionParser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, errors.Errorf("unexpected %q", p.peek())
+	}
+
+	return &Condition{root: root}, nil
+}
+
+// Evaluate evaluates the condition against the given cluster configuration
+func (c *Condition) Evaluate(cluster *kubeoneapi.KubeOneCluster) (bool, error) {
+	k8sVersion, err := semver.NewVersion(cluster.Versions.Kubernetes)
+	if err != nil {
+		return false, errors.Wrapf(err, "parsing kubernetes version %q", cluster.Versions.Kubernetes)
+	}
+
+	return c.root.eval(conditionEnv{
+		provider: cluster.CloudProvider.CloudProviderName(),
+		k8s:      k8sVersion,
+	}), nil
+}
+
+// addonEnabled returns false if the addon has an enablement condition which
+// is not satisfied by the given cluster configuration
+func addonEnabled(cluster *kubeoneapi.KubeOneCluster, addon kubeoneapi.Addon) (bool, error) {
+	if addon.EnabledWhen == "" {
+		return true, nil
+	}
+
+	cond, err := ParseCondition(addon.EnabledWhen)
+	if err != nil {
+		return false, errors.Wrapf(err, "parsing enabledWhen condition of the addon %q", addon.Name)
+	}
+
+	enabled, err := cond.Evaluate(cluster)
+	if err != nil {
+		return false, errors.Wrapf(err, "evaluating enabledWhen condition of the addon %q", addon.Name)
+	}
+
+	return enabled, nil
+}
+
+func tokenizeCondition(expr string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"),
+			strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="),
+			strings.HasPrefix(expr[i:], ">="), strings.HasPrefix(expr[i:], "<="):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case strings.ContainsRune("!()<>", c):
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexRune(expr[i+1:], c)
+			if end < 0 {
+				return nil, errors.Errorf("unterminated quoted value at position %d", i)
+			}
+			// keep the quotes to distinguish values from identifiers and operators
+			tokens = append(tokens, expr[i:i+end+2])
+			i += end + 2
+		case isConditionWordChar(c):
+			start := i
+			for i < len(expr) && isConditionWordChar(rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, expr[start:i])
+		default:
+			return nil, errors.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isConditionWordChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune(".-_+", c)
+}
+
+type conditionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *conditionParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *conditionParser) peek() string {
+	if p.done() {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() (string, error) {
+	if p.done() {
+		return "", errors.New("unexpected end of condition")
+	}
+	p.pos++
+
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "||" {
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = conditionOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "&&" {
+		p.pos++
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = conditionAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	switch p.peek() {
+	case "!":
+		p.pos++
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return conditionNot{node: node}, nil
+	case "(":
+		p.pos++
+
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok, err := p.next(); err != nil || tok != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+
+		return node, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	ident, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value = strings.Trim(value, `"'`)
+
+	switch ident {
+	case conditionIdentProvider:
+		if op != "==" && op != "!=" {
+			return nil, errors.Errorf("unsupported operator %q for %q, only == and != are supported", op, ident)
+		}
+
+		for _, provider := range conditionProviders {
+			if provider == value {
+				return conditionProvider{op: op, provider: value}, nil
+			}
+		}
+
+		return nil, errors.Errorf("unknown provider %q, supported providers are: %s", value, strings.Join(conditionProviders, ", "))
+	case conditionIdentK8s:
+		switch op {
+		case "==", "!=", "<", "<=", ">", ">=":
+		default:
+			return nil, errors.Errorf("unsupported operator %q for %q", op, ident)
+		}
+
+		version, err := semver.NewVersion(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing version %q", value)
+		}
+
+		return conditionK8s{op: op, version: version}, nil
+	}
+
+	return nil, fmt.Errorf("unknown identifier %q, supported identifiers are: %s, %s", ident, conditionIdentProvider, conditionIdentK8s)
+}
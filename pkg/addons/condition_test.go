@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestCondition(t *testing.T) {
+	t.Parallel()
+
+	awsCluster := &kubeoneapi.KubeOneCluster{
+		CloudProvider: kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}},
+		Versions:      kubeoneapi.VersionConfig{Kubernetes: "1.23.5"},
+	}
+
+	tests := []struct {
+		name        string
+		expr        string
+		cluster     *kubeoneapi.KubeOneCluster
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name:     "provider matches",
+			expr:     "provider == aws",
+			cluster:  awsCluster,
+			expected: true,
+		},
+		{
+			name:     "provider doesn't match",
+			expr:     `provider == "hetzner"`,
+			cluster:  awsCluster,
+			expected: false,
+		},
+		{
+			name:     "provider and version match",
+			expr:     "provider == aws && k8s >= 1.23",
+			cluster:  awsCluster,
+			expected: true,
+		},
+		{
+			name:     "version doesn't match",
+			expr:     "provider == aws && k8s >= 1.24",
+			cluster:  awsCluster,
+			expected: false,
+		},
+		{
+			name:     "or with parentheses and negation",
+			expr:     "!(provider != aws) && (k8s < 1.22 || k8s == 1.23.5)",
+			cluster:  awsCluster,
+			expected: true,
+		},
+		{
+			name:     "and takes precedence over or",
+			expr:     "provider == gce && k8s > 1.20 || k8s <= 1.23.5",
+			cluster:  awsCluster,
+			expected: true,
+		},
+		{
+			name:        "empty condition",
+			expr:        " ",
+			expectedErr: true,
+		},
+		{
+			name:        "unknown identifier",
+			expr:        "os == ubuntu",
+			expectedErr: true,
+		},
+		{
+			name:        "unknown provider",
+			expr:        "provider == amazon",
+			expectedErr: true,
+		},
+		{
+			name:        "unsupported provider operator",
+			expr:        "provider >= aws",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid version",
+			expr:        "k8s >= latest",
+			expectedErr: true,
+		},
+		{
+			name:        "missing closing parenthesis",
+			expr:        "(provider == aws",
+			expectedErr: true,
+		},
+		{
+			name:        "trailing operator",
+			expr:        "provider == aws &&",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid operator",
+			expr:        "provider = aws",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cond, err := ParseCondition(tt.expr)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("ParseCondition() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr {
+				return
+			}
+
+			got, err := cond.Evaluate(tt.cluster)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Evaluate() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -60,11 +60,15 @@ var (
 		resources.AddonCSIHetzner:             "",
 		resources.AddonCSINutanix:             "",
 		resources.AddonCSIOpenStackCinder:     "",
+		resources.AddonCSISnapshotController:  "",
 		resources.AddonCSIVMwareCloudDirector: "",
 		resources.AddonCSIVsphere:             "",
+		resources.AddonEtcdBackup:             "",
+		resources.AddonEtcdMetrics:            "",
 		resources.AddonMachineController:      "",
 		resources.AddonMetricsServer:          "",
 		resources.AddonNodeLocalDNS:           "",
+		resources.AddonPrometheusAdapter:      "",
 	}
 
 	greaterThan23 = semverutil.MustParseConstraint(greaterThan23Constraint)
@@ -83,6 +87,24 @@ func collectAddons(s *state.State) (addonsToDeploy []addonAction) {
 		})
 	}
 
+	if s.Cluster.Features.PrometheusAdapter.Enabled() {
+		addonsToDeploy = append(addonsToDeploy, addonAction{
+			name: resources.AddonPrometheusAdapter,
+		})
+	}
+
+	if s.Cluster.Features.EtcdMetrics.ServiceMonitorEnabled() {
+		addonsToDeploy = append(addonsToDeploy, addonAction{
+			name: resources.AddonEtcdMetrics,
+		})
+	}
+
+	if s.Cluster.Features.EtcdBackup.Enabled() {
+		addonsToDeploy = append(addonsToDeploy, addonAction{
+			name: resources.AddonEtcdBackup,
+		})
+	}
+
 	switch {
 	case s.Cluster.ClusterNetwork.CNI.Canal != nil:
 		addonsToDeploy = append(addonsToDeploy, addonAction{
@@ -119,6 +141,12 @@ func collectAddons(s *state.State) (addonsToDeploy []addonAction) {
 
 	addonsToDeploy = ensureCSIAddons(s, addonsToDeploy)
 
+	if s.Cluster.CloudProvider.CSISnapshotController.Enabled() {
+		addonsToDeploy = append(addonsToDeploy, addonAction{
+			name: resources.AddonCSISnapshotController,
+		})
+	}
+
 	if s.Cluster.CloudProvider.External {
 		addonsToDeploy = ensureCCMAddons(s, addonsToDeploy)
 	}
@@ -154,6 +182,11 @@ func EnsureUserAddons(s *state.State) error {
 	s.Logger.Infof("Applying user provided addons...")
 	combinedAddons := map[string]string{}
 
+	disabledAddons, err := disabledUserAddons(s)
+	if err != nil {
+		return err
+	}
+
 	if applier.LocalFS != nil {
 		customAddons, err := fs.ReadDir(applier.LocalFS, ".")
 		if err != nil {
@@ -169,6 +202,10 @@ func EnsureUserAddons(s *state.State) error {
 				continue
 			}
 
+			if disabledAddons[useraddon.Name()] {
+				continue
+			}
+
 			if _, ok := combinedAddons[useraddon.Name()]; !ok {
 				combinedAddons[useraddon.Name()] = ""
 			}
@@ -180,6 +217,10 @@ func EnsureUserAddons(s *state.State) error {
 			continue
 		}
 
+		if disabledAddons[embeddedAddon.Name] {
+			continue
+		}
+
 		if embeddedAddon.Delete {
 			if err := applier.loadAndDeleteAddon(s, applier.EmbededFS, embeddedAddon.Name); err != nil {
 				return err
@@ -209,6 +250,26 @@ func EnsureUserAddons(s *state.State) error {
 	return nil
 }
 
+// disabledUserAddons returns names of the addons with the enabledWhen
+// condition not satisfied by the cluster configuration
+func disabledUserAddons(s *state.State) (map[string]bool, error) {
+	disabledAddons := map[string]bool{}
+
+	for _, addon := range s.Cluster.Addons.Addons {
+		enabled, err := addonEnabled(s.Cluster, addon)
+		if err != nil {
+			return nil, fail.Config(err, "checking addon enablement condition")
+		}
+
+		if !enabled {
+			s.Logger.Infof("Skipping addon %q, enabledWhen condition %q is not satisfied", addon.Name, addon.EnabledWhen)
+			disabledAddons[addon.Name] = true
+		}
+	}
+
+	return disabledAddons, nil
+}
+
 // EnsureAddonByName deploys an addon by its name. If the addon is not found
 // in the addons directory, or if the addons are not enabled, it will search
 // for the embedded addons.
@@ -119,6 +119,18 @@ func List(s *state.State, outputFormat string) error {
 					Status: addonStatusDelete,
 				}
 			}
+
+			enabled, err := addonEnabled(s.Cluster, embeddedAddon)
+			if err != nil {
+				return fail.Config(err, "checking addon enablement condition")
+			}
+
+			if !enabled {
+				combinedAddons[embeddedAddon.Name] = addonItem{
+					Name:   embeddedAddon.Name,
+					Status: addonStatusInactive,
+				}
+			}
 		}
 	}
 
@@ -70,6 +70,13 @@ func (a *applier) getManifestsFromDirectory(s *state.State, fsys fs.FS, addonNam
 		return "", err
 	}
 
+	if ccmAddons[addonName] {
+		manifests, err = customizeCCM(manifests, s.Cluster.CloudProvider.CCM)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	rawManifests, err := ensureAddonsLabelsOnResources(manifests, addonName)
 	if err != nil {
 		return "", err
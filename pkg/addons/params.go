@@ -0,0 +1,275 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	embeddedaddons "k8c.io/kubeone/addons"
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ValidateRequiredParams checks that all params the user addons mark as
+// required (e.g. {{ required "msg" .Params.foo }}) are set either in the
+// addons.globalParams or in the params of the addon itself. This allows
+// catching missing params before any change is made to the cluster.
+func ValidateRequiredParams(s *state.State) error {
+	if !s.Cluster.Addons.Enabled() {
+		return nil
+	}
+
+	localFS, err := addonsLocalFS(s.Cluster.Addons, s.ManifestFilePath)
+	if err != nil {
+		return err
+	}
+
+	return validateRequiredParams(s.Cluster, localFS, embeddedaddons.FS)
+}
+
+func validateRequiredParams(cluster *kubeoneapi.KubeOneCluster, localFS, embeddedFS fs.FS) error {
+	addonsParams := map[string]map[string]string{}
+	disabledAddons := map[string]bool{}
+
+	for _, addon := range cluster.Addons.Addons {
+		enabled, err := addonEnabled(cluster, addon)
+		if err != nil {
+			return fail.Config(err, "checking addon enablement condition")
+		}
+
+		if !enabled || addon.Delete {
+			disabledAddons[addon.Name] = true
+
+			continue
+		}
+
+		addonsParams[addon.Name] = addon.Params
+	}
+
+	// addonsFS maps the addon name to the file system the addon is going
+	// to be loaded from, the same way as EnsureUserAddons does it
+	addonsFS := map[string]fs.FS{}
+
+	if localFS != nil {
+		localAddons, err := fs.ReadDir(localFS, ".")
+		if err != nil {
+			return fail.Runtime(err, "reading local addons directory")
+		}
+
+		for _, addon := range localAddons {
+			if !addon.IsDir() || disabledAddons[addon.Name()] {
+				continue
+			}
+
+			if _, ok := embeddedAddons[addon.Name()]; ok {
+				continue
+			}
+
+			addonsFS[addon.Name()] = localFS
+		}
+
+		// Manifests in the root of the addons directory are applied as well
+		addonsFS[""] = localFS
+	}
+
+	for addonName := range addonsParams {
+		if _, ok := embeddedAddons[addonName]; ok {
+			continue
+		}
+
+		if _, ok := addonsFS[addonName]; ok {
+			continue
+		}
+
+		if _, err := fs.Stat(embeddedFS, addonName); err == nil {
+			addonsFS[addonName] = embeddedFS
+		}
+	}
+
+	addonNames := []string{}
+	for addonName := range addonsFS {
+		addonNames = append(addonNames, addonName)
+	}
+	sort.Strings(addonNames)
+
+	var errs []error
+
+	for _, addonName := range addonNames {
+		required, err := addonRequiredParams(addonsFS[addonName], addonName)
+		if err != nil {
+			return err
+		}
+
+		var missing []string
+		for _, param := range required {
+			value, ok := addonsParams[addonName][param]
+			if !ok {
+				value = cluster.Addons.GlobalParams[param]
+			}
+
+			if value == "" {
+				missing = append(missing, param)
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		displayName := addonName
+		if displayName == "" {
+			displayName = "root directory"
+		}
+
+		errs = append(errs, fmt.Errorf("addon %q requires params that are set neither in addons.globalParams nor in the addon params: %s",
+			displayName, strings.Join(missing, ", ")))
+	}
+
+	if len(errs) > 0 {
+		return fail.ConfigValidation(utilerrors.NewAggregate(errs))
+	}
+
+	return nil
+}
+
+// addonRequiredParams returns sorted names of the params that are passed to
+// the required template function by the manifests of the given addon
+func addonRequiredParams(fsys fs.FS, addonName string) ([]string, error) {
+	files, err := fs.ReadDir(fsys, filepath.Join(".", addonName))
+	if err != nil {
+		return nil, fail.Runtime(err, "reading addons directory")
+	}
+
+	params := map[string]bool{}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(file.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		manifestBytes, err := fs.ReadFile(fsys, filepath.Join(addonName, file.Name()))
+		if err != nil {
+			return nil, fail.Runtime(err, "reading addon")
+		}
+
+		tpl, err := template.New("addons-base").Funcs(txtFuncMap("")).Parse(string(manifestBytes))
+		if err != nil {
+			return nil, fail.Runtime(err, fmt.Sprintf("parsing addons manifest template %q", file.Name()))
+		}
+
+		for _, t := range tpl.Templates() {
+			if t.Tree != nil {
+				collectRequiredParams(t.Tree.Root, params)
+			}
+		}
+	}
+
+	names := []string{}
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// collectRequiredParams walks the template parse tree and records names of
+// the params used as {{ required "msg" .Params.foo }} or as
+// {{ .Params.foo | required "msg" }}
+func collectRequiredParams(node parse.Node, params map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectRequiredParams(child, params)
+		}
+	case *parse.ActionNode:
+		collectRequiredParams(n.Pipe, params)
+	case *parse.IfNode:
+		collectRequiredParams(&n.BranchNode, params)
+	case *parse.RangeNode:
+		collectRequiredParams(&n.BranchNode, params)
+	case *parse.WithNode:
+		collectRequiredParams(&n.BranchNode, params)
+	case *parse.BranchNode:
+		collectRequiredParams(n.Pipe, params)
+		collectRequiredParams(n.List, params)
+		collectRequiredParams(n.ElseList, params)
+	case *parse.TemplateNode:
+		collectRequiredParams(n.Pipe, params)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for i, cmd := range n.Cmds {
+			if isRequiredCommand(cmd) {
+				switch {
+				case len(cmd.Args) > 2:
+					if name, ok := paramName(cmd.Args[len(cmd.Args)-1]); ok {
+						params[name] = true
+					}
+				case i > 0 && len(n.Cmds[i-1].Args) == 1:
+					if name, ok := paramName(n.Cmds[i-1].Args[0]); ok {
+						params[name] = true
+					}
+				}
+			}
+			collectRequiredParams(cmd, params)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectRequiredParams(arg, params)
+		}
+	}
+}
+
+func isRequiredCommand(cmd *parse.CommandNode) bool {
+	if len(cmd.Args) == 0 {
+		return false
+	}
+
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+
+	return ok && ident.Ident == "required"
+}
+
+// paramName returns the name of the param if the node is .Params.name
+func paramName(node parse.Node) (string, bool) {
+	field, ok := node.(*parse.FieldNode)
+	if !ok || len(field.Ident) != 2 || field.Ident[0] != "Params" {
+		return "", false
+	}
+
+	return field.Ident[1], true
+}
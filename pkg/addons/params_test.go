@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestAddonRequiredParams(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"foo/deployment.yaml": &fstest.MapFile{Data: []byte(`
+env:
+- name: PASSWORD
+  value: {{ required "Please provide password" .Params.password }}
+- name: REGION
+  value: {{ .Params.region | required "Please provide region" | quote }}
+{{ if .Params.enabled }}
+- name: TOKEN
+  value: {{ required "Please provide token" .Params.token }}
+{{ end }}
+- name: OPTIONAL
+  value: {{ default "none" .Params.optional }}
+- name: OTHER
+  value: {{ required "Please provide version" .Config.Versions.Kubernetes }}
+`)},
+		"foo/README.md": &fstest.MapFile{Data: []byte(`{{ required "ignored" .Params.readme }}`)},
+	}
+
+	got, err := addonRequiredParams(fsys, "foo")
+	if err != nil {
+		t.Fatalf("addonRequiredParams() error = %v", err)
+	}
+
+	expected := []string{"password", "region", "token"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("addonRequiredParams() = %v, expected %v", got, expected)
+	}
+}
+
+func TestValidateRequiredParams(t *testing.T) {
+	t.Parallel()
+
+	localFS := fstest.MapFS{
+		"foo/manifest.yaml": &fstest.MapFile{Data: []byte(`{{ required "msg" .Params.environment }}`)},
+		"root.yaml":         &fstest.MapFile{Data: []byte(`{{ required "msg" .Params.cluster }}`)},
+	}
+	embeddedFS := fstest.MapFS{
+		"bar/manifest.yaml": &fstest.MapFile{Data: []byte(`{{ required "msg" .Params.password }}`)},
+	}
+
+	tests := []struct {
+		name        string
+		addons      *kubeoneapi.Addons
+		expectedErr bool
+	}{
+		{
+			name: "params provided globally",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"environment": "prod", "cluster": "test"},
+			},
+		},
+		{
+			name: "global param missing",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"environment": "prod"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "param provided by the addon",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"cluster": "test"},
+				Addons: []kubeoneapi.Addon{
+					{Name: "foo", Params: map[string]string{"environment": "prod"}},
+				},
+			},
+		},
+		{
+			name: "addon param overrides global param with empty value",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"environment": "prod", "cluster": "test"},
+				Addons: []kubeoneapi.Addon{
+					{Name: "foo", Params: map[string]string{"environment": ""}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "embedded addon param missing",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"environment": "prod", "cluster": "test"},
+				Addons: []kubeoneapi.Addon{
+					{Name: "bar"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "deleted embedded addon is not validated",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"environment": "prod", "cluster": "test"},
+				Addons: []kubeoneapi.Addon{
+					{Name: "bar", Delete: true},
+				},
+			},
+		},
+		{
+			name: "disabled addon is not validated",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"cluster": "test"},
+				Addons: []kubeoneapi.Addon{
+					{Name: "foo", EnabledWhen: "provider == aws"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := &kubeoneapi.KubeOneCluster{
+				CloudProvider: kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+				Versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.4"},
+				Addons:        tt.addons,
+			}
+
+			err := validateRequiredParams(cluster, localFS, embeddedFS)
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("validateRequiredParams() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -17,10 +17,10 @@ limitations under the License.
 package config
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -33,6 +33,7 @@ import (
 	kubeonevalidation "k8c.io/kubeone/pkg/apis/kubeone/validation"
 	"k8c.io/kubeone/pkg/containerruntime"
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/terraform"
 	terraformv1beta1 "k8c.io/kubeone/pkg/terraform/v1beta1"
 	terraformv1beta2 "k8c.io/kubeone/pkg/terraform/v1beta2"
 
@@ -59,8 +60,9 @@ var (
 )
 
 // LoadKubeOneCluster returns the internal representation of the KubeOneCluster object
-// parsed from the versioned KubeOneCluster manifest, Terraform output and credentials file
-func LoadKubeOneCluster(clusterCfgPath, tfOutputPath, credentialsFilePath string, logger logrus.FieldLogger) (*kubeoneapi.KubeOneCluster, error) {
+// parsed from the versioned KubeOneCluster manifest, Terraform output and credentials file.
+// tfOpts configure retries and timeouts of `terraform output` if tfOutputPath is a directory.
+func LoadKubeOneCluster(clusterCfgPath, tfOutputPath, credentialsFilePath string, tfOpts terraform.Options, logger logrus.FieldLogger) (*kubeoneapi.KubeOneCluster, error) {
 	if len(clusterCfgPath) == 0 {
 		return nil, fail.Runtime(fmt.Errorf("is not provided"), "cluster configuration path")
 	}
@@ -78,10 +80,8 @@ func LoadKubeOneCluster(clusterCfgPath, tfOutputPath, credentialsFilePath string
 			return nil, fail.Runtime(err, "reading terraform output from stdin")
 		}
 	case isDir(tfOutputPath):
-		cmd := exec.Command("terraform", "output", "-json")
-		cmd.Dir = tfOutputPath
-		if tfOutput, err = cmd.Output(); err != nil {
-			return nil, fail.Runtime(err, "reading terraform output")
+		if tfOutput, err = terraform.Output(context.Background(), tfOutputPath, tfOpts, logger); err != nil {
+			return nil, err
 		}
 	case len(tfOutputPath) != 0:
 		if tfOutput, err = os.ReadFile(tfOutputPath); err != nil {
@@ -20,9 +20,12 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
+	"net"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
@@ -33,6 +36,11 @@ import (
 
 const (
 	credentialSecretName = "kube-system/kubeone-registry-credentials" //nolint:gosec
+
+	defaultAPIServerHealthCheckPort = 6443
+	defaultAPIServerHealthCheckPath = "/healthz"
+
+	defaultBootstrapTokenTTL = time.Hour
 )
 
 // Leader returns the first configured host. Only call this after
@@ -82,6 +90,155 @@ func (c *KubeOneCluster) IsManagedNode(nodename string) bool {
 	return false
 }
 
+// SchedulerConfigEnabled reports whether the kube-scheduler should use the
+// configuration file generated by KubeOne
+func (c *KubeOneCluster) SchedulerConfigEnabled() bool {
+	return c.ControlPlaneComponents != nil && c.ControlPlaneComponents.Scheduler != nil
+}
+
+// AdmissionConfigEnabled reports whether any admission plugin configured via
+// the AdmissionConfiguration file is enabled
+func (c *KubeOneCluster) AdmissionConfigEnabled() bool {
+	f := c.Features
+
+	return (f.PodNodeSelector != nil && f.PodNodeSelector.Enable) ||
+		f.PodTolerationRestriction.Enabled()
+}
+
+// APIServerConfigEnabled reports whether the kube-apiserver options are
+// configured via the control plane components
+func (c *KubeOneCluster) APIServerConfigEnabled() bool {
+	return c.ControlPlaneComponents != nil && c.ControlPlaneComponents.APIServer != nil
+}
+
+// APIServerFlags returns kube-apiserver command-line flags (without leading
+// dashes) configured via the control plane components, except the feature
+// gates which must be merged with the feature gates set by KubeOne
+func (c *KubeOneCluster) APIServerFlags() map[string]string {
+	flags := map[string]string{}
+
+	if !c.APIServerConfigEnabled() {
+		return flags
+	}
+
+	config := c.ControlPlaneComponents.APIServer
+
+	if config.EndpointReconcilerType != "" {
+		flags["endpoint-reconciler-type"] = config.EndpointReconcilerType
+	}
+	if d := config.ServiceAccountMaxTokenExpiration.Duration; d > 0 {
+		flags["service-account-max-token-expiration"] = d.String()
+	}
+	if config.ServiceAccountExtendTokenExpiration != nil {
+		flags["service-account-extend-token-expiration"] = strconv.FormatBool(*config.ServiceAccountExtendTokenExpiration)
+	}
+
+	return flags
+}
+
+// ControllerManagerConfigEnabled reports whether the kube-controller-manager
+// options are configured via the control plane components
+func (c *KubeOneCluster) ControllerManagerConfigEnabled() bool {
+	return c.ControlPlaneComponents != nil && c.ControlPlaneComponents.ControllerManager != nil
+}
+
+// ControllerManagerFlags returns kube-controller-manager command-line flags
+// (without leading dashes) configured via the control plane components
+func (c *KubeOneCluster) ControllerManagerFlags() map[string]string {
+	flags := map[string]string{}
+
+	if !c.ControllerManagerConfigEnabled() {
+		return flags
+	}
+
+	if d := c.ControlPlaneComponents.ControllerManager.ClusterSigningDuration.Duration; d > 0 {
+		flags["cluster-signing-duration"] = d.String()
+	}
+
+	return flags
+}
+
+// CertificateAlternativeNames returns the Subject Alternative Names for the
+// API Server signing cert, including the internal and standby API endpoint hosts
+func (a APIEndpoint) CertificateAlternativeNames() []string {
+	altNames := append([]string{}, a.AlternativeNames...)
+
+	if a.InternalHost != "" {
+		altNames = append(altNames, a.InternalHost)
+	}
+
+	for _, standby := range a.StandbyEndpoints {
+		altNames = append(altNames, standby.Host)
+	}
+
+	return altNames
+}
+
+// StandbyServerURLs returns the URLs of the standby API endpoints
+func (a APIEndpoint) StandbyServerURLs() []string {
+	urls := []string{}
+
+	for _, standby := range a.StandbyEndpoints {
+		port := a.Port
+		if standby.Port != 0 {
+			port = standby.Port
+		}
+
+		urls = append(urls, fmt.Sprintf("https://%s", net.JoinHostPort(standby.Host, strconv.Itoa(port))))
+	}
+
+	return urls
+}
+
+// ServerURL returns the URL of the external API endpoint, or of the internal
+// API endpoint if internal is true
+func (a APIEndpoint) ServerURL(internal bool) string {
+	host, port := a.Host, a.Port
+
+	if internal {
+		host = a.InternalHost
+		if a.InternalPort != 0 {
+			port = a.InternalPort
+		}
+	}
+
+	return fmt.Sprintf("https://%s", net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// HealthCheckURL returns the URL of the API server health check endpoint on
+// the given control plane node address
+func (a APIEndpoint) HealthCheckURL(nodeAddress string) string {
+	port := defaultAPIServerHealthCheckPort
+	path := defaultAPIServerHealthCheckPath
+
+	if a.HealthCheck != nil {
+		if a.HealthCheck.Port != 0 {
+			port = a.HealthCheck.Port
+		}
+		if a.HealthCheck.Path != "" {
+			path = a.HealthCheck.Path
+		}
+	}
+
+	return fmt.Sprintf("https://%s%s", net.JoinHostPort(nodeAddress, strconv.Itoa(port)), path)
+}
+
+// RuntimeClassesEnabled reports whether any of the additional containerd
+// runtimes requires a RuntimeClass object
+func (c *ContainerRuntimeContainerd) RuntimeClassesEnabled() bool {
+	if c == nil {
+		return false
+	}
+
+	for _, runtime := range c.Runtimes {
+		if runtime.RuntimeClass != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 // SetHostname sets the hostname for the given host
 func (h *HostConfig) SetHostname(hostname string) {
 	h.Hostname = hostname
@@ -89,13 +246,13 @@ func (h *HostConfig) SetHostname(hostname string) {
 
 // SetOperatingSystem sets the operating system for the given host
 func (h *HostConfig) SetOperatingSystem(os OperatingSystemName) error {
-	if h.OperatingSystem.IsValid() {
+	if os.IsValid() {
 		h.OperatingSystem = os
 
 		return nil
 	}
 
-	return fail.ConfigValidation(fmt.Errorf("unknown operating system %q", os))
+	return fail.ConfigValidation(fmt.Errorf("unknown operating system %q, use operatingSystem in the host config to override the detection", os))
 }
 
 func (osName OperatingSystemName) IsValid() bool {
@@ -105,6 +262,7 @@ func (osName OperatingSystemName) IsValid() bool {
 	case OperatingSystemNameDebian:
 	case OperatingSystemNameCentOS:
 	case OperatingSystemNameRHEL:
+	case OperatingSystemNameRocky:
 	case OperatingSystemNameAmazon:
 	case OperatingSystemNameFlatcar:
 	case OperatingSystemNameUnknown:
@@ -120,6 +278,96 @@ func (h *HostConfig) SetLeader(leader bool) {
 	h.IsLeader = leader
 }
 
+// NodeIP returns the address the host is advertised on, i.e. the private
+// address, or the public address if the private address is not set
+func (h *HostConfig) NodeIP() string {
+	if h.PrivateAddress != "" {
+		return h.PrivateAddress
+	}
+
+	return h.PublicAddress
+}
+
+// Addresses returns the configured bind addresses
+func (ba *BindAddresses) Addresses() []string {
+	if ba == nil {
+		return nil
+	}
+
+	addrs := []string{}
+	for _, addr := range []string{ba.APIServer, ba.Etcd, ba.ControllerManager, ba.Scheduler} {
+		if addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs
+}
+
+// BindAddressesConfigured reports whether the bind addresses are configured
+// for any control plane host
+func (c *KubeOneCluster) BindAddressesConfigured() bool {
+	for _, host := range c.ControlPlane.Hosts {
+		if len(host.BindAddresses.Addresses()) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ControlPlaneDNSNames returns the stable DNS names of the control plane
+// hosts that have them configured
+func (c *KubeOneCluster) ControlPlaneDNSNames() []string {
+	names := []string{}
+	for _, host := range c.ControlPlane.Hosts {
+		if host.DNSName != "" {
+			names = append(names, strings.ToLower(host.DNSName))
+		}
+	}
+
+	return names
+}
+
+// BootstrapTokenTTL returns the TTL of the kubeadm bootstrap tokens used to
+// join the control plane and static worker nodes
+func (c *KubeOneCluster) BootstrapTokenTTL() time.Duration {
+	if c.BootstrapToken == nil || c.BootstrapToken.TTL.Duration == 0 {
+		return defaultBootstrapTokenTTL
+	}
+
+	return c.BootstrapToken.TTL.Duration
+}
+
+// ControlPlaneCount returns the number of the control plane hosts
+func (c *KubeOneCluster) ControlPlaneCount() int {
+	return len(c.ControlPlane.Hosts)
+}
+
+// StaticWorkerCount returns the number of the static worker hosts
+func (c *KubeOneCluster) StaticWorkerCount() int {
+	return len(c.StaticWorkers.Hosts)
+}
+
+// DynamicWorkerReplicas returns the sum of the replicas of all dynamic worker
+// pools. Pools without replicas set are counted as zero replicas.
+func (c *KubeOneCluster) DynamicWorkerReplicas() int {
+	replicas := 0
+	for _, worker := range c.DynamicWorkers {
+		if worker.Replicas != nil {
+			replicas += *worker.Replicas
+		}
+	}
+
+	return replicas
+}
+
+// ExpectedNodeCount returns the number of nodes the cluster consists of once
+// all hosts joined and all dynamic worker replicas are provisioned
+func (c *KubeOneCluster) ExpectedNodeCount() int {
+	return c.ControlPlaneCount() + c.StaticWorkerCount() + c.DynamicWorkerReplicas()
+}
+
 func (c KubeOneCluster) OperatingSystemManagerEnabled() bool {
 	if c.Addons.Enabled() {
 		for _, embeddedAddon := range c.Addons.Addons {
@@ -419,6 +667,84 @@ func (r *RegistryConfiguration) InsecureRegistryAddress() string {
 	return insecureRegistry
 }
 
+// ClientCABundleEnabled returns true if an additional client CA bundle for
+// the API server is provided
+func (sa *StaticAuth) ClientCABundleEnabled() bool {
+	return sa != nil && (sa.ClientCABundle != "" || sa.ClientCABundleFilePath != "")
+}
+
+// AnonymousAuthDisabled returns true if anonymous requests to the API server
+// are explicitly disabled
+func (sa *StaticAuth) AnonymousAuthDisabled() bool {
+	return sa != nil && sa.AnonymousAuth != nil && !*sa.AnonymousAuth
+}
+
+// APIServerFlags returns the kube-apiserver flags set by the static auth
+// hardening options
+func (sa *StaticAuth) APIServerFlags() map[string]string {
+	flags := map[string]string{}
+	if sa == nil {
+		return flags
+	}
+
+	if sa.AnonymousAuth != nil {
+		flags["anonymous-auth"] = strconv.FormatBool(*sa.AnonymousAuth)
+	}
+	if sa.Profiling != nil {
+		flags["profiling"] = strconv.FormatBool(*sa.Profiling)
+	}
+
+	return flags
+}
+
+// Enabled returns true if the kubelet serving certificates rotation is enabled
+func (r *KubeletServingCertRotation) Enabled() bool {
+	return r != nil && r.Enable
+}
+
+// Enabled returns true if etcd should expose metrics on a dedicated port
+func (em *EtcdMetrics) Enabled() bool {
+	return em != nil && em.Enable
+}
+
+// ServiceMonitorEnabled returns true if the etcd metrics ServiceMonitor should be deployed
+func (em *EtcdMetrics) ServiceMonitorEnabled() bool {
+	return em.Enabled() && em.ServiceMonitor
+}
+
+// Enabled returns true if the PodDisruptionBudgets for the system addons should be created
+func (pdb *PodDisruptionBudgets) Enabled() bool {
+	return pdb != nil && pdb.Enable
+}
+
+// Enabled returns true if the Prometheus adapter should be deployed
+func (pa *PrometheusAdapter) Enabled() bool {
+	return pa != nil && pa.Enable
+}
+
+// Enabled returns true if the etcd backup CronJob should be deployed
+func (eb *EtcdBackup) Enabled() bool {
+	return eb != nil && eb.Enable
+}
+
+// Enabled returns true if the PodTolerationRestriction admission plugin
+// should be enabled
+func (ptr *PodTolerationRestriction) Enabled() bool {
+	return ptr != nil && ptr.Enable
+}
+
+// Enabled returns true if the cluster CA certificate should be injected into
+// the webhook configurations
+func (w *WebhookCABundleInjection) Enabled() bool {
+	return w != nil && w.Enable
+}
+
+// Enabled returns true if the CSI external snapshot-controller should be
+// deployed
+func (sc *CSISnapshotControllerConfig) Enabled() bool {
+	return sc != nil && sc.Enable
+}
+
 func (ads *Addons) Enabled() bool {
 	return ads != nil && ads.Enable
 }
@@ -476,6 +802,86 @@ func defaults(input, defaultValue string) string {
 	return defaultValue
 }
 
+// NodeCIDRMaskSizeFlags returns kube-controller-manager command-line flags
+// (without leading dashes) used to allocate node pod CIDRs of the configured size
+func (c ClusterNetworkConfig) NodeCIDRMaskSizeFlags() map[string]string {
+	flags := map[string]string{}
+
+	if c.NodeCIDRMaskSize == nil {
+		return flags
+	}
+
+	flags["allocate-node-cidrs"] = "true"
+
+	if c.NodeCIDRMaskSize.IPv4 != nil {
+		flags["node-cidr-mask-size-ipv4"] = strconv.Itoa(int(*c.NodeCIDRMaskSize.IPv4))
+	}
+
+	if c.NodeCIDRMaskSize.IPv6 != nil {
+		flags["node-cidr-mask-size-ipv6"] = strconv.Itoa(int(*c.NodeCIDRMaskSize.IPv6))
+	}
+
+	return flags
+}
+
+// NodePortRangeBounds parses the "min-max" NodePortRange and returns its
+// lower and upper bound (both inclusive)
+func (c ClusterNetworkConfig) NodePortRangeBounds() (int, int, error) {
+	bounds := strings.Split(c.NodePortRange, "-")
+	if len(bounds) != 2 {
+		return 0, 0, fail.ConfigValidation(fmt.Errorf("node port range %q must be in the min-max format", c.NodePortRange))
+	}
+
+	low, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return 0, 0, fail.ConfigValidation(fmt.Errorf("invalid lower bound of the node port range %q", c.NodePortRange))
+	}
+
+	high, err := strconv.Atoi(bounds[1])
+	if err != nil {
+		return 0, 0, fail.ConfigValidation(fmt.Errorf("invalid upper bound of the node port range %q", c.NodePortRange))
+	}
+
+	if low < 1 || high > 65535 || low > high {
+		return 0, 0, fail.ConfigValidation(fmt.Errorf("node port range %q must be within 1-65535 with min not greater than max", c.NodePortRange))
+	}
+
+	return low, high, nil
+}
+
+// NodeAllocatableFlags returns kubelet command-line flags (without leading
+// dashes) used to reserve resources for system and Kubernetes daemons and to
+// enforce node allocatable. Only flags for configured options are returned.
+func (kc KubeletConfig) NodeAllocatableFlags() map[string]string {
+	flags := map[string]string{}
+
+	if m := kc.SystemReserved; m != nil {
+		flags["system-reserved"] = MapStringStringToString(m, "=")
+	}
+
+	if m := kc.KubeReserved; m != nil {
+		flags["kube-reserved"] = MapStringStringToString(m, "=")
+	}
+
+	if m := kc.EvictionHard; m != nil {
+		flags["eviction-hard"] = MapStringStringToString(m, "<")
+	}
+
+	if len(kc.EnforceNodeAllocatable) > 0 {
+		flags["enforce-node-allocatable"] = strings.Join(kc.EnforceNodeAllocatable, ",")
+	}
+
+	if kc.SystemReservedCgroup != "" {
+		flags["system-reserved-cgroup"] = kc.SystemReservedCgroup
+	}
+
+	if kc.KubeReservedCgroup != "" {
+		flags["kube-reserved-cgroup"] = kc.KubeReservedCgroup
+	}
+
+	return flags
+}
+
 func MapStringStringToString(m1 map[string]string, pairSeparator string) string {
 	var pairs []string
 	for k, v := range m1 {
@@ -177,3 +177,333 @@ func TestMapStringStringToString(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeletConfig_NodeAllocatableFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		kubelet KubeletConfig
+		want    map[string]string
+	}{
+		{
+			name:    "empty",
+			kubelet: KubeletConfig{},
+			want:    map[string]string{},
+		},
+		{
+			name: "reserved only",
+			kubelet: KubeletConfig{
+				SystemReserved: map[string]string{"cpu": "200m", "memory": "200Mi"},
+				EvictionHard:   map[string]string{"memory.available": "100Mi"},
+			},
+			want: map[string]string{
+				"system-reserved": "cpu=200m,memory=200Mi",
+				"eviction-hard":   "memory.available<100Mi",
+			},
+		},
+		{
+			name: "enforced",
+			kubelet: KubeletConfig{
+				SystemReserved:         map[string]string{"cpu": "200m"},
+				KubeReserved:           map[string]string{"memory": "300Mi"},
+				EnforceNodeAllocatable: []string{"pods", "system-reserved", "kube-reserved"},
+				SystemReservedCgroup:   "/system.slice",
+				KubeReservedCgroup:     "/kube.slice",
+			},
+			want: map[string]string{
+				"system-reserved":          "cpu=200m",
+				"kube-reserved":            "memory=300Mi",
+				"enforce-node-allocatable": "pods,system-reserved,kube-reserved",
+				"system-reserved-cgroup":   "/system.slice",
+				"kube-reserved-cgroup":     "/kube.slice",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.kubelet.NodeAllocatableFlags(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KubeletConfig.NodeAllocatableFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterNetworkConfig_NodeCIDRMaskSizeFlags(t *testing.T) {
+	ipv4, ipv6 := int32(23), int32(80)
+
+	tests := []struct {
+		name     string
+		maskSize *NodeCIDRMaskSize
+		want     map[string]string
+	}{
+		{
+			name:     "not configured",
+			maskSize: nil,
+			want:     map[string]string{},
+		},
+		{
+			name:     "ipv4 only",
+			maskSize: &NodeCIDRMaskSize{IPv4: &ipv4},
+			want: map[string]string{
+				"allocate-node-cidrs":      "true",
+				"node-cidr-mask-size-ipv4": "23",
+			},
+		},
+		{
+			name:     "ipv4 and ipv6",
+			maskSize: &NodeCIDRMaskSize{IPv4: &ipv4, IPv6: &ipv6},
+			want: map[string]string{
+				"allocate-node-cidrs":      "true",
+				"node-cidr-mask-size-ipv4": "23",
+				"node-cidr-mask-size-ipv6": "80",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := ClusterNetworkConfig{NodeCIDRMaskSize: tt.maskSize}
+			if got := c.NodeCIDRMaskSizeFlags(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterNetworkConfig.NodeCIDRMaskSizeFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIEndpoint_HealthCheckURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthCheck *APIEndpointHealthCheck
+		nodeAddress string
+		want        string
+	}{
+		{
+			name:        "not configured",
+			healthCheck: nil,
+			nodeAddress: "192.168.1.1",
+			want:        "https://192.168.1.1:6443/healthz",
+		},
+		{
+			name:        "custom port",
+			healthCheck: &APIEndpointHealthCheck{Port: 8443},
+			nodeAddress: "192.168.1.1",
+			want:        "https://192.168.1.1:8443/healthz",
+		},
+		{
+			name:        "custom port and path",
+			healthCheck: &APIEndpointHealthCheck{Port: 8443, Path: "/k8s/livez"},
+			nodeAddress: "192.168.1.1",
+			want:        "https://192.168.1.1:8443/k8s/livez",
+		},
+		{
+			name:        "ipv6 node address",
+			healthCheck: &APIEndpointHealthCheck{Path: "/readyz"},
+			nodeAddress: "fd00::1",
+			want:        "https://[fd00::1]:6443/readyz",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			a := APIEndpoint{HealthCheck: tt.healthCheck}
+			if got := a.HealthCheckURL(tt.nodeAddress); got != tt.want {
+				t.Errorf("APIEndpoint.HealthCheckURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIEndpoint_ServerURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiEndpoint APIEndpoint
+		internal    bool
+		want        string
+	}{
+		{
+			name:        "external endpoint",
+			apiEndpoint: APIEndpoint{Host: "api.example.com", Port: 6443, InternalHost: "10.0.0.10"},
+			internal:    false,
+			want:        "https://api.example.com:6443",
+		},
+		{
+			name:        "internal endpoint with the external port",
+			apiEndpoint: APIEndpoint{Host: "api.example.com", Port: 6443, InternalHost: "10.0.0.10"},
+			internal:    true,
+			want:        "https://10.0.0.10:6443",
+		},
+		{
+			name:        "internal endpoint with the internal port",
+			apiEndpoint: APIEndpoint{Host: "api.example.com", Port: 443, InternalHost: "fd00::10", InternalPort: 6443},
+			internal:    true,
+			want:        "https://[fd00::10]:6443",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.apiEndpoint.ServerURL(tt.internal); got != tt.want {
+				t.Errorf("APIEndpoint.ServerURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIEndpoint_CertificateAlternativeNames(t *testing.T) {
+	a := APIEndpoint{
+		Host:             "api.example.com",
+		AlternativeNames: []string{"k8s.example.com"},
+		InternalHost:     "10.0.0.10",
+		StandbyEndpoints: []APIEndpointStandby{{Host: "api-standby.example.com"}},
+	}
+
+	want := []string{"k8s.example.com", "10.0.0.10", "api-standby.example.com"}
+	if got := a.CertificateAlternativeNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("APIEndpoint.CertificateAlternativeNames() = %v, want %v", got, want)
+	}
+	if len(a.AlternativeNames) != 1 {
+		t.Errorf("expected AlternativeNames not to be modified, got %v", a.AlternativeNames)
+	}
+}
+
+func TestAPIEndpoint_StandbyServerURLs(t *testing.T) {
+	a := APIEndpoint{
+		Host: "api.example.com",
+		Port: 6443,
+		StandbyEndpoints: []APIEndpointStandby{
+			{Host: "api-standby.example.com"},
+			{Host: "fd00::20", Port: 443},
+		},
+	}
+
+	want := []string{"https://api-standby.example.com:6443", "https://[fd00::20]:443"}
+	if got := a.StandbyServerURLs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("APIEndpoint.StandbyServerURLs() = %v, want %v", got, want)
+	}
+}
+
+func TestHostConfig_SetOperatingSystem(t *testing.T) {
+	tests := []struct {
+		name    string
+		current OperatingSystemName
+		os      OperatingSystemName
+		want    OperatingSystemName
+		wantErr bool
+	}{
+		{
+			name: "ubuntu",
+			os:   OperatingSystemNameUbuntu,
+			want: OperatingSystemNameUbuntu,
+		},
+		{
+			name: "rocky",
+			os:   OperatingSystemNameRocky,
+			want: OperatingSystemNameRocky,
+		},
+		{
+			name:    "unsupported operating system",
+			os:      OperatingSystemName("almalinux"),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported operating system keeps the current value",
+			current: OperatingSystemNameCentOS,
+			os:      OperatingSystemName("almalinux"),
+			want:    OperatingSystemNameCentOS,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			h := HostConfig{OperatingSystem: tt.current}
+			err := h.SetOperatingSystem(tt.os)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HostConfig.SetOperatingSystem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if h.OperatingSystem != tt.want {
+				t.Errorf("HostConfig.OperatingSystem = %q, want %q", h.OperatingSystem, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeOneCluster_NodeCounts(t *testing.T) {
+	replicas := func(n int) *int { return &n }
+
+	tests := []struct {
+		name                  string
+		cluster               KubeOneCluster
+		wantControlPlane      int
+		wantStaticWorkers     int
+		wantDynamicWorkers    int
+		wantExpectedNodeCount int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "control plane and static workers",
+			cluster: KubeOneCluster{
+				ControlPlane:  ControlPlaneConfig{Hosts: []HostConfig{{}, {}, {}}},
+				StaticWorkers: StaticWorkersConfig{Hosts: []HostConfig{{}, {}}},
+			},
+			wantControlPlane:      3,
+			wantStaticWorkers:     2,
+			wantExpectedNodeCount: 5,
+		},
+		{
+			name: "dynamic workers with nil replicas",
+			cluster: KubeOneCluster{
+				ControlPlane: ControlPlaneConfig{Hosts: []HostConfig{{}}},
+				DynamicWorkers: []DynamicWorkerConfig{
+					{Name: "pool-1"},
+					{Name: "pool-2", Replicas: replicas(2)},
+				},
+			},
+			wantControlPlane:      1,
+			wantDynamicWorkers:    2,
+			wantExpectedNodeCount: 3,
+		},
+		{
+			name: "mixed worker pools",
+			cluster: KubeOneCluster{
+				ControlPlane:  ControlPlaneConfig{Hosts: []HostConfig{{}, {}, {}}},
+				StaticWorkers: StaticWorkersConfig{Hosts: []HostConfig{{}}},
+				DynamicWorkers: []DynamicWorkerConfig{
+					{Name: "pool-1", Replicas: replicas(3)},
+					{Name: "pool-2", Replicas: replicas(0)},
+					{Name: "pool-3"},
+					{Name: "pool-4", Replicas: replicas(1)},
+				},
+			},
+			wantControlPlane:      3,
+			wantStaticWorkers:     1,
+			wantDynamicWorkers:    4,
+			wantExpectedNodeCount: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cluster.ControlPlaneCount(); got != tt.wantControlPlane {
+				t.Errorf("KubeOneCluster.ControlPlaneCount() = %d, want %d", got, tt.wantControlPlane)
+			}
+			if got := tt.cluster.StaticWorkerCount(); got != tt.wantStaticWorkers {
+				t.Errorf("KubeOneCluster.StaticWorkerCount() = %d, want %d", got, tt.wantStaticWorkers)
+			}
+			if got := tt.cluster.DynamicWorkerReplicas(); got != tt.wantDynamicWorkers {
+				t.Errorf("KubeOneCluster.DynamicWorkerReplicas() = %d, want %d", got, tt.wantDynamicWorkers)
+			}
+			if got := tt.cluster.ExpectedNodeCount(); got != tt.wantExpectedNodeCount {
+				t.Errorf("KubeOneCluster.ExpectedNodeCount() = %d, want %d", got, tt.wantExpectedNodeCount)
+			}
+		})
+	}
+}
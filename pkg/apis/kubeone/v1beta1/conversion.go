@@ -24,6 +24,16 @@ import (
 	conversion "k8s.io/apimachinery/pkg/conversion"
 )
 
+func Convert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(in *kubeoneapi.APIEndpoint, out *APIEndpoint, s conversion.Scope) error {
+	// InternalHost, InternalPort, StandbyEndpoints and HealthCheck were introduced only in new v1beta2 API, so we skip them here
+	return autoConvert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(in, out, s)
+}
+
+func Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in *kubeoneapi.ClusterNetworkConfig, out *ClusterNetworkConfig, s conversion.Scope) error {
+	// NodeCIDRMaskSize and NodeConnectivityCheck were introduced only in new v1beta2 API, so we skip them here
+	return autoConvert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in, out, s)
+}
+
 func Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(*kubeoneapi.ContainerRuntimeContainerd, *ContainerRuntimeContainerd, conversion.Scope) error {
 	// Skip conversion
 	return nil
@@ -43,6 +53,24 @@ func Convert_v1beta1_Features_To_kubeone_Features(in *Features, out *kubeoneapi.
 	return nil
 }
 
+func Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(in *kubeoneapi.DynamicWorkerConfig, out *DynamicWorkerConfig, s conversion.Scope) error {
+	// Autoscaler was introduced only in new v1beta2 API, so we skip it here
+	return autoConvert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(in, out, s)
+}
+
+func Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(in *kubeoneapi.ContainerRuntimeConfig, out *ContainerRuntimeConfig, s conversion.Scope) error {
+	// Cgroups and PreloadImages were introduced only in new v1beta2 API, so we skip them here
+	return autoConvert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(in, out, s)
+}
+
+func Convert_kubeone_Features_To_v1beta1_Features(in *kubeoneapi.Features, out *Features, s conversion.Scope) error {
+	// StaticAuth, KubeletServingCertRotation, EtcdMetrics, PodDisruptionBudgets,
+	// PrometheusAdapter, EtcdBackup, PodTolerationRestriction and
+	// WebhookCABundleInjection were introduced only in new v1beta2 API, so we
+	// skip them here
+	return autoConvert_kubeone_Features_To_v1beta1_Features(in, out, s)
+}
+
 // Convert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec is an autogenerated conversion function.
 func Convert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec(in *CloudProviderSpec, out *kubeoneapi.CloudProviderSpec, s conversion.Scope) error {
 	if err := autoConvert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec(in, out, s); err != nil {
@@ -64,20 +92,43 @@ func Convert_kubeone_CloudProviderSpec_To_v1beta1_CloudProviderSpec(in *kubeonea
 	// PacketSpec has been renamed to EquinixMetalSpec
 	out.Packet = (*PacketSpec)(unsafe.Pointer(in.EquinixMetal))
 
+	// CCM and CSISnapshotController were introduced only in new v1beta2 API, so we skip them here
+
 	return nil
 }
 
+func Convert_kubeone_Addon_To_v1beta1_Addon(in *kubeoneapi.Addon, out *Addon, s conversion.Scope) error {
+	// EnabledWhen and ForceConflicts were introduced only in new v1beta2 API, so we skip them here
+	return autoConvert_kubeone_Addon_To_v1beta1_Addon(in, out, s)
+}
+
+func Convert_kubeone_Addons_To_v1beta1_Addons(in *kubeoneapi.Addons, out *Addons, s conversion.Scope) error {
+	// FieldManager was introduced only in new v1beta2 API, so we skip it here
+	return autoConvert_kubeone_Addons_To_v1beta1_Addons(in, out, s)
+}
+
 func Convert_kubeone_HostConfig_To_v1beta1_HostConfig(in *kubeoneapi.HostConfig, out *HostConfig, scope conversion.Scope) error {
 	// explicitly skip kubelet conversion omitted in autoConvert_kubeone_HostConfig_To_v1beta1_HostConfig
+	// BindAddresses, DNSName, Labels and Annotations were introduced only in new v1beta2 API, so we skip them here
 	return autoConvert_kubeone_HostConfig_To_v1beta1_HostConfig(in, out, scope)
 }
 
 func Convert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(in *kubeoneapi.KubeOneCluster, out *KubeOneCluster, s conversion.Scope) error {
-	// LoggingConfig was introduced only in new v1beta2 API, so we skip it here
+	// LoggingConfig, CertificateAuthority, ControlPlaneComponents, PostApplyValidation, BootstrapToken, Namespaces and Firewall were introduced only in new v1beta2 API, so we skip them here
 	return autoConvert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(in, out, s)
 }
 
 func Convert_kubeone_ProviderSpec_To_v1beta1_ProviderSpec(in *kubeoneapi.ProviderSpec, out *ProviderSpec, s conversion.Scope) error {
-	// NodeAnnotations and MachineObjectAnnotations were introduced only in new v1beta2 API, so we skip them here
+	// NodeAnnotations, MachineObjectAnnotations, InstanceProfile, CloudNetwork, BootstrapCommands and Disks were introduced only in new v1beta2 API, so we skip them here
 	return autoConvert_kubeone_ProviderSpec_To_v1beta1_ProviderSpec(in, out, s)
 }
+
+func Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in *kubeoneapi.StaticAuditLogConfig, out *StaticAuditLogConfig, s conversion.Scope) error {
+	// PolicyPreset was introduced only in new v1beta2 API, so we skip it here
+	return autoConvert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in, out, s)
+}
+
+func Convert_kubeone_VersionConfig_To_v1beta1_VersionConfig(in *kubeoneapi.VersionConfig, out *VersionConfig, s conversion.Scope) error {
+	// KubeadmConfigAPIVersion was introduced only in new v1beta2 API, so we skip it here
+	return autoConvert_kubeone_VersionConfig_To_v1beta1_VersionConfig(in, out, s)
+}
@@ -85,6 +85,7 @@ const (
 	OperatingSystemNameDebian  OperatingSystemName = "debian"
 	OperatingSystemNameCentOS  OperatingSystemName = "centos"
 	OperatingSystemNameRHEL    OperatingSystemName = "rhel"
+	OperatingSystemNameRocky   OperatingSystemName = "rocky"
 	OperatingSystemNameAmazon  OperatingSystemName = "amzn"
 	OperatingSystemNameFlatcar OperatingSystemName = "flatcar"
 	OperatingSystemNameUnknown OperatingSystemName = ""
@@ -43,11 +43,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.APIEndpoint)(nil), (*APIEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(a.(*kubeone.APIEndpoint), b.(*APIEndpoint), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AWSSpec)(nil), (*kubeone.AWSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AWSSpec_To_kubeone_AWSSpec(a.(*AWSSpec), b.(*kubeone.AWSSpec), scope)
 	}); err != nil {
@@ -63,21 +58,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.Addon)(nil), (*Addon)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_Addon_To_v1beta1_Addon(a.(*kubeone.Addon), b.(*Addon), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Addons)(nil), (*kubeone.Addons)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Addons_To_kubeone_Addons(a.(*Addons), b.(*kubeone.Addons), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.Addons)(nil), (*Addons)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_Addons_To_v1beta1_Addons(a.(*kubeone.Addons), b.(*Addons), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AssetConfiguration)(nil), (*kubeone.AssetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AssetConfiguration_To_kubeone_AssetConfiguration(a.(*AssetConfiguration), b.(*kubeone.AssetConfiguration), scope)
 	}); err != nil {
@@ -143,21 +128,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ClusterNetworkConfig)(nil), (*ClusterNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(a.(*kubeone.ClusterNetworkConfig), b.(*ClusterNetworkConfig), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeConfig)(nil), (*kubeone.ContainerRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(a.(*ContainerRuntimeConfig), b.(*kubeone.ContainerRuntimeConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeConfig)(nil), (*ContainerRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(a.(*kubeone.ContainerRuntimeConfig), b.(*ContainerRuntimeConfig), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeContainerd)(nil), (*kubeone.ContainerRuntimeContainerd)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeContainerd(a.(*ContainerRuntimeContainerd), b.(*kubeone.ContainerRuntimeContainerd), scope)
 	}); err != nil {
@@ -213,11 +188,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.DynamicWorkerConfig)(nil), (*DynamicWorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(a.(*kubeone.DynamicWorkerConfig), b.(*DynamicWorkerConfig), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*EncryptionProviders)(nil), (*kubeone.EncryptionProviders)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_EncryptionProviders_To_kubeone_EncryptionProviders(a.(*EncryptionProviders), b.(*kubeone.EncryptionProviders), scope)
 	}); err != nil {
@@ -238,11 +208,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.Features)(nil), (*Features)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_Features_To_v1beta1_Features(a.(*kubeone.Features), b.(*Features), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*GCESpec)(nil), (*kubeone.GCESpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_GCESpec_To_kubeone_GCESpec(a.(*GCESpec), b.(*kubeone.GCESpec), scope)
 	}); err != nil {
@@ -453,11 +418,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.StaticAuditLogConfig)(nil), (*StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(a.(*kubeone.StaticAuditLogConfig), b.(*StaticAuditLogConfig), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*StaticWorkersConfig)(nil), (*kubeone.StaticWorkersConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(a.(*StaticWorkersConfig), b.(*kubeone.StaticWorkersConfig), scope)
 	}); err != nil {
@@ -483,11 +443,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.VersionConfig)(nil), (*VersionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_VersionConfig_To_v1beta1_VersionConfig(a.(*kubeone.VersionConfig), b.(*VersionConfig), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VsphereSpec)(nil), (*kubeone.VsphereSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VsphereSpec_To_kubeone_VsphereSpec(a.(*VsphereSpec), b.(*kubeone.VsphereSpec), scope)
 	}); err != nil {
@@ -508,11 +463,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*kubeone.APIEndpoint)(nil), (*APIEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(a.(*kubeone.APIEndpoint), b.(*APIEndpoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*kubeone.Addon)(nil), (*Addon)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Addon_To_v1beta1_Addon(a.(*kubeone.Addon), b.(*Addon), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*kubeone.Addons)(nil), (*Addons)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Addons_To_v1beta1_Addons(a.(*kubeone.Addons), b.(*Addons), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*kubeone.CloudProviderSpec)(nil), (*CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_kubeone_CloudProviderSpec_To_v1beta1_CloudProviderSpec(a.(*kubeone.CloudProviderSpec), b.(*CloudProviderSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*kubeone.ClusterNetworkConfig)(nil), (*ClusterNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(a.(*kubeone.ClusterNetworkConfig), b.(*ClusterNetworkConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*kubeone.ContainerRuntimeConfig)(nil), (*ContainerRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(a.(*kubeone.ContainerRuntimeConfig), b.(*ContainerRuntimeConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*kubeone.ContainerRuntimeContainerd)(nil), (*ContainerRuntimeContainerd)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(a.(*kubeone.ContainerRuntimeContainerd), b.(*ContainerRuntimeContainerd), scope)
 	}); err != nil {
@@ -523,6 +503,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*kubeone.DynamicWorkerConfig)(nil), (*DynamicWorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(a.(*kubeone.DynamicWorkerConfig), b.(*DynamicWorkerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*kubeone.Features)(nil), (*Features)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Features_To_v1beta1_Features(a.(*kubeone.Features), b.(*Features), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*kubeone.HostConfig)(nil), (*HostConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_kubeone_HostConfig_To_v1beta1_HostConfig(a.(*kubeone.HostConfig), b.(*HostConfig), scope)
 	}); err != nil {
@@ -538,6 +528,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*kubeone.StaticAuditLogConfig)(nil), (*StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(a.(*kubeone.StaticAuditLogConfig), b.(*StaticAuditLogConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*kubeone.VersionConfig)(nil), (*VersionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_VersionConfig_To_v1beta1_VersionConfig(a.(*kubeone.VersionConfig), b.(*VersionConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*CloudProviderSpec)(nil), (*kubeone.CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec(a.(*CloudProviderSpec), b.(*kubeone.CloudProviderSpec), scope)
 	}); err != nil {
@@ -567,14 +567,13 @@ func autoConvert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(in *kubeone.APIEndpo
 	out.Host = in.Host
 	out.Port = in.Port
 	out.AlternativeNames = *(*[]string)(unsafe.Pointer(&in.AlternativeNames))
+	// WARNING: in.InternalHost requires manual conversion: does not exist in peer-type
+	// WARNING: in.InternalPort requires manual conversion: does not exist in peer-type
+	// WARNING: in.StandbyEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthCheck requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint is an autogenerated conversion function.
-func Convert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(in *kubeone.APIEndpoint, out *APIEndpoint, s conversion.Scope) error {
-	return autoConvert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(in, out, s)
-}
-
 func autoConvert_v1beta1_AWSSpec_To_kubeone_AWSSpec(in *AWSSpec, out *kubeone.AWSSpec, s conversion.Scope) error {
 	return nil
 }
@@ -609,19 +608,26 @@ func autoConvert_kubeone_Addon_To_v1beta1_Addon(in *kubeone.Addon, out *Addon, s
 	out.Name = in.Name
 	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	out.Delete = in.Delete
+	// WARNING: in.EnabledWhen requires manual conversion: does not exist in peer-type
+	// WARNING: in.ForceConflicts requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_Addon_To_v1beta1_Addon is an autogenerated conversion function.
-func Convert_kubeone_Addon_To_v1beta1_Addon(in *kubeone.Addon, out *Addon, s conversion.Scope) error {
-	return autoConvert_kubeone_Addon_To_v1beta1_Addon(in, out, s)
-}
-
 func autoConvert_v1beta1_Addons_To_kubeone_Addons(in *Addons, out *kubeone.Addons, s conversion.Scope) error {
 	out.Enable = in.Enable
 	out.Path = in.Path
 	out.GlobalParams = *(*map[string]string)(unsafe.Pointer(&in.GlobalParams))
-	out.Addons = *(*[]kubeone.Addon)(unsafe.Pointer(&in.Addons))
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]kubeone.Addon, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Addon_To_kubeone_Addon(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Addons = nil
+	}
 	return nil
 }
 
@@ -634,15 +640,21 @@ func autoConvert_kubeone_Addons_To_v1beta1_Addons(in *kubeone.Addons, out *Addon
 	out.Enable = in.Enable
 	out.Path = in.Path
 	out.GlobalParams = *(*map[string]string)(unsafe.Pointer(&in.GlobalParams))
-	out.Addons = *(*[]Addon)(unsafe.Pointer(&in.Addons))
+	// WARNING: in.FieldManager requires manual conversion: does not exist in peer-type
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]Addon, len(*in))
+		for i := range *in {
+			if err := Convert_kubeone_Addon_To_v1beta1_Addon(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Addons = nil
+	}
 	return nil
 }
 
-// Convert_kubeone_Addons_To_v1beta1_Addons is an autogenerated conversion function.
-func Convert_kubeone_Addons_To_v1beta1_Addons(in *kubeone.Addons, out *Addons, s conversion.Scope) error {
-	return autoConvert_kubeone_Addons_To_v1beta1_Addons(in, out, s)
-}
-
 func autoConvert_v1beta1_AssetConfiguration_To_kubeone_AssetConfiguration(in *AssetConfiguration, out *kubeone.AssetConfiguration, s conversion.Scope) error {
 	if err := Convert_v1beta1_ImageAsset_To_kubeone_ImageAsset(&in.Kubernetes, &out.Kubernetes, s); err != nil {
 		return err
@@ -835,6 +847,8 @@ func autoConvert_kubeone_CloudProviderSpec_To_v1beta1_CloudProviderSpec(in *kube
 	out.External = in.External
 	out.CloudConfig = in.CloudConfig
 	out.CSIConfig = in.CSIConfig
+	// WARNING: in.CCM requires manual conversion: does not exist in peer-type
+	// WARNING: in.CSISnapshotController requires manual conversion: does not exist in peer-type
 	out.AWS = (*AWSSpec)(unsafe.Pointer(in.AWS))
 	out.Azure = (*AzureSpec)(unsafe.Pointer(in.Azure))
 	out.DigitalOcean = (*DigitalOceanSpec)(unsafe.Pointer(in.DigitalOcean))
@@ -869,16 +883,13 @@ func autoConvert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in
 	out.ServiceSubnet = in.ServiceSubnet
 	out.ServiceDomainName = in.ServiceDomainName
 	out.NodePortRange = in.NodePortRange
+	// WARNING: in.NodeCIDRMaskSize requires manual conversion: does not exist in peer-type
 	out.CNI = (*CNI)(unsafe.Pointer(in.CNI))
 	out.KubeProxy = (*KubeProxyConfig)(unsafe.Pointer(in.KubeProxy))
+	// WARNING: in.NodeConnectivityCheck requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig is an autogenerated conversion function.
-func Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in *kubeone.ClusterNetworkConfig, out *ClusterNetworkConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in, out, s)
-}
-
 func autoConvert_v1beta1_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(in *ContainerRuntimeConfig, out *kubeone.ContainerRuntimeConfig, s conversion.Scope) error {
 	if in.Docker != nil {
 		in, out := &in.Docker, &out.Docker
@@ -925,14 +936,11 @@ func autoConvert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfi
 	} else {
 		out.Containerd = nil
 	}
+	// WARNING: in.Cgroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.PreloadImages requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig is an autogenerated conversion function.
-func Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(in *kubeone.ContainerRuntimeConfig, out *ContainerRuntimeConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(in, out, s)
-}
-
 func autoConvert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeContainerd(in *ContainerRuntimeContainerd, out *kubeone.ContainerRuntimeContainerd, s conversion.Scope) error {
 	return nil
 }
@@ -944,6 +952,10 @@ func Convert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeConta
 
 func autoConvert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(in *kubeone.ContainerRuntimeContainerd, out *ContainerRuntimeContainerd, s conversion.Scope) error {
 	// WARNING: in.Registries requires manual conversion: does not exist in peer-type
+	// WARNING: in.StreamServerAddress requires manual conversion: does not exist in peer-type
+	// WARNING: in.StreamServerPort requires manual conversion: does not exist in peer-type
+	// WARNING: in.StreamIdleTimeout requires manual conversion: does not exist in peer-type
+	// WARNING: in.Runtimes requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -958,6 +970,8 @@ func Convert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in
 
 func autoConvert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(in *kubeone.ContainerRuntimeDocker, out *ContainerRuntimeDocker, s conversion.Scope) error {
 	// WARNING: in.RegistryMirrors requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogDriver requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogOpts requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1079,14 +1093,10 @@ func autoConvert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(in *
 	if err := Convert_kubeone_ProviderSpec_To_v1beta1_ProviderSpec(&in.Config, &out.Config, s); err != nil {
 		return err
 	}
+	// WARNING: in.Autoscaler requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig is an autogenerated conversion function.
-func Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(in *kubeone.DynamicWorkerConfig, out *DynamicWorkerConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(in, out, s)
-}
-
 func autoConvert_v1beta1_EncryptionProviders_To_kubeone_EncryptionProviders(in *EncryptionProviders, out *kubeone.EncryptionProviders, s conversion.Scope) error {
 	out.Enable = in.Enable
 	out.CustomEncryptionConfiguration = in.CustomEncryptionConfiguration
@@ -1147,14 +1157,17 @@ func autoConvert_kubeone_Features_To_v1beta1_Features(in *kubeone.Features, out
 	out.MetricsServer = (*MetricsServer)(unsafe.Pointer(in.MetricsServer))
 	out.OpenIDConnect = (*OpenIDConnect)(unsafe.Pointer(in.OpenIDConnect))
 	out.EncryptionProviders = (*EncryptionProviders)(unsafe.Pointer(in.EncryptionProviders))
+	// WARNING: in.StaticAuth requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletServingCertRotation requires manual conversion: does not exist in peer-type
+	// WARNING: in.EtcdMetrics requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodDisruptionBudgets requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrometheusAdapter requires manual conversion: does not exist in peer-type
+	// WARNING: in.EtcdBackup requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodTolerationRestriction requires manual conversion: does not exist in peer-type
+	// WARNING: in.WebhookCABundleInjection requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_Features_To_v1beta1_Features is an autogenerated conversion function.
-func Convert_kubeone_Features_To_v1beta1_Features(in *kubeone.Features, out *Features, s conversion.Scope) error {
-	return autoConvert_kubeone_Features_To_v1beta1_Features(in, out, s)
-}
-
 func autoConvert_v1beta1_GCESpec_To_kubeone_GCESpec(in *GCESpec, out *kubeone.GCESpec, s conversion.Scope) error {
 	return nil
 }
@@ -1232,6 +1245,10 @@ func autoConvert_kubeone_HostConfig_To_v1beta1_HostConfig(in *kubeone.HostConfig
 	out.Taints = *(*[]v1.Taint)(unsafe.Pointer(&in.Taints))
 	// WARNING: in.Kubelet requires manual conversion: does not exist in peer-type
 	out.OperatingSystem = OperatingSystemName(in.OperatingSystem)
+	// WARNING: in.BindAddresses requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSName requires manual conversion: does not exist in peer-type
+	// WARNING: in.Labels requires manual conversion: does not exist in peer-type
+	// WARNING: in.Annotations requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1347,7 +1364,15 @@ func autoConvert_v1beta1_KubeOneCluster_To_kubeone_KubeOneCluster(in *KubeOneClu
 	if err := Convert_v1beta1_Features_To_kubeone_Features(&in.Features, &out.Features, s); err != nil {
 		return err
 	}
-	out.Addons = (*kubeone.Addons)(unsafe.Pointer(in.Addons))
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(kubeone.Addons)
+		if err := Convert_v1beta1_Addons_To_kubeone_Addons(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Addons = nil
+	}
 	out.SystemPackages = (*kubeone.SystemPackages)(unsafe.Pointer(in.SystemPackages))
 	if err := Convert_v1beta1_AssetConfiguration_To_kubeone_AssetConfiguration(&in.AssetConfiguration, &out.AssetConfiguration, s); err != nil {
 		return err
@@ -1400,16 +1425,30 @@ func autoConvert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(in *kubeone.Ku
 	}
 	out.MachineController = (*MachineControllerConfig)(unsafe.Pointer(in.MachineController))
 	out.CABundle = in.CABundle
+	// WARNING: in.CertificateAuthority requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneComponents requires manual conversion: does not exist in peer-type
+	// WARNING: in.PostApplyValidation requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapToken requires manual conversion: does not exist in peer-type
 	if err := Convert_kubeone_Features_To_v1beta1_Features(&in.Features, &out.Features, s); err != nil {
 		return err
 	}
-	out.Addons = (*Addons)(unsafe.Pointer(in.Addons))
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(Addons)
+		if err := Convert_kubeone_Addons_To_v1beta1_Addons(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Addons = nil
+	}
 	out.SystemPackages = (*SystemPackages)(unsafe.Pointer(in.SystemPackages))
 	if err := Convert_kubeone_AssetConfiguration_To_v1beta1_AssetConfiguration(&in.AssetConfiguration, &out.AssetConfiguration, s); err != nil {
 		return err
 	}
 	out.RegistryConfiguration = (*RegistryConfiguration)(unsafe.Pointer(in.RegistryConfiguration))
 	// WARNING: in.LoggingConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.Namespaces requires manual conversion: does not exist in peer-type
+	// WARNING: in.Firewall requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1673,6 +1712,10 @@ func autoConvert_kubeone_ProviderSpec_To_v1beta1_ProviderSpec(in *kubeone.Provid
 	out.OperatingSystemSpec = *(*json.RawMessage)(unsafe.Pointer(&in.OperatingSystemSpec))
 	out.Network = (*ProviderStaticNetworkConfig)(unsafe.Pointer(in.Network))
 	out.OverwriteCloudConfig = (*string)(unsafe.Pointer(in.OverwriteCloudConfig))
+	// WARNING: in.InstanceProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.CloudNetwork requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapCommands requires manual conversion: does not exist in peer-type
+	// WARNING: in.Disks requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1792,6 +1835,7 @@ func Convert_v1beta1_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(in *St
 
 func autoConvert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in *kubeone.StaticAuditLogConfig, out *StaticAuditLogConfig, s conversion.Scope) error {
 	out.PolicyFilePath = in.PolicyFilePath
+	// WARNING: in.PolicyPreset requires manual conversion: does not exist in peer-type
 	out.LogPath = in.LogPath
 	out.LogMaxAge = in.LogMaxAge
 	out.LogMaxBackup = in.LogMaxBackup
@@ -1799,11 +1843,6 @@ func autoConvert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in
 	return nil
 }
 
-// Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig is an autogenerated conversion function.
-func Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in *kubeone.StaticAuditLogConfig, out *StaticAuditLogConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in, out, s)
-}
-
 func autoConvert_v1beta1_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(in *StaticWorkersConfig, out *kubeone.StaticWorkersConfig, s conversion.Scope) error {
 	if in.Hosts != nil {
 		in, out := &in.Hosts, &out.Hosts
@@ -1876,14 +1915,10 @@ func Convert_v1beta1_VersionConfig_To_kubeone_VersionConfig(in *VersionConfig, o
 
 func autoConvert_kubeone_VersionConfig_To_v1beta1_VersionConfig(in *kubeone.VersionConfig, out *VersionConfig, s conversion.Scope) error {
 	out.Kubernetes = in.Kubernetes
+	// WARNING: in.KubeadmConfigAPIVersion requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_VersionConfig_To_v1beta1_VersionConfig is an autogenerated conversion function.
-func Convert_kubeone_VersionConfig_To_v1beta1_VersionConfig(in *kubeone.VersionConfig, out *VersionConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_VersionConfig_To_v1beta1_VersionConfig(in, out, s)
-}
-
 func autoConvert_v1beta1_VsphereSpec_To_kubeone_VsphereSpec(in *VsphereSpec, out *kubeone.VsphereSpec, s conversion.Scope) error {
 	return nil
 }
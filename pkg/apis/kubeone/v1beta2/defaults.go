@@ -18,11 +18,13 @@ package v1beta2
 
 import (
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -38,6 +40,8 @@ const (
 	DefaultStaticNoProxy = "127.0.0.1/8,localhost"
 	// DefaultCanalMTU defines default VXLAN MTU for Canal CNI
 	DefaultCanalMTU = 1450
+	// DefaultPostApplyValidationWebhookTimeout defines the default timeout of the post-apply validation webhook call
+	DefaultPostApplyValidationWebhookTimeout = 30 * time.Second
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -54,6 +58,8 @@ func SetDefaults_KubeOneCluster(obj *KubeOneCluster) {
 	SetDefaults_MachineController(obj)
 	SetDefaults_SystemPackages(obj)
 	SetDefaults_Features(obj)
+	SetDefaults_PostApplyValidation(obj)
+	SetDefaults_Firewall(obj)
 }
 
 func SetDefaults_Hosts(obj *KubeOneCluster) {
@@ -210,6 +216,27 @@ func SetDefaults_SystemPackages(obj *KubeOneCluster) {
 	}
 }
 
+func SetDefaults_PostApplyValidation(obj *KubeOneCluster) {
+	if obj.PostApplyValidation == nil || obj.PostApplyValidation.Webhook == nil {
+		return
+	}
+
+	if obj.PostApplyValidation.Webhook.Timeout.Duration == 0 {
+		obj.PostApplyValidation.Webhook.Timeout.Duration = DefaultPostApplyValidationWebhookTimeout
+	}
+}
+
+func SetDefaults_Firewall(obj *KubeOneCluster) {
+	if obj.Firewall == nil {
+		return
+	}
+
+	obj.Firewall.Backend = defaults(obj.Firewall.Backend, "nftables")
+	for i := range obj.Firewall.Rules {
+		obj.Firewall.Rules[i].Protocol = defaults(obj.Firewall.Rules[i].Protocol, "tcp")
+	}
+}
+
 func SetDefaults_Features(obj *KubeOneCluster) {
 	if obj.Features.MetricsServer == nil {
 		obj.Features.MetricsServer = &MetricsServer{
@@ -222,6 +249,18 @@ func SetDefaults_Features(obj *KubeOneCluster) {
 	if obj.Features.OpenIDConnect != nil && obj.Features.OpenIDConnect.Enable {
 		defaultOpenIDConnect(&obj.Features.OpenIDConnect.Config)
 	}
+	if obj.Features.EtcdMetrics != nil && obj.Features.EtcdMetrics.Enable {
+		obj.Features.EtcdMetrics.Port = defaulti(obj.Features.EtcdMetrics.Port, 2382)
+	}
+	if obj.Features.PodDisruptionBudgets != nil && obj.Features.PodDisruptionBudgets.Enable {
+		if obj.Features.PodDisruptionBudgets.MinAvailable == (intstr.IntOrString{}) {
+			obj.Features.PodDisruptionBudgets.MinAvailable = intstr.FromInt(1)
+		}
+	}
+	if obj.Features.EtcdBackup != nil && obj.Features.EtcdBackup.Enable {
+		obj.Features.EtcdBackup.Schedule = defaults(obj.Features.EtcdBackup.Schedule, "@every 30m")
+		obj.Features.EtcdBackup.KeepLast = defaulti(obj.Features.EtcdBackup.KeepLast, 48)
+	}
 }
 
 func defaultOpenIDConnect(config *OpenIDConnectConfig) {
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -53,6 +54,16 @@ type KubeOneCluster struct {
 	MachineController *MachineControllerConfig `json:"machineController,omitempty"`
 	// CABundle PEM encoded global CA
 	CABundle string `json:"caBundle,omitempty"`
+	// CertificateAuthority configures the user provided cluster certificate authorities
+	// used instead of the CAs generated by kubeadm.
+	CertificateAuthority *CertificateAuthority `json:"certificateAuthority,omitempty"`
+	// ControlPlaneComponents configures the Kubernetes control plane components.
+	ControlPlaneComponents *ControlPlaneComponents `json:"controlPlaneComponents,omitempty"`
+	// PostApplyValidation configures the validation of the cluster run at the
+	// end of the apply command.
+	PostApplyValidation *PostApplyValidation `json:"postApplyValidation,omitempty"`
+	// BootstrapToken configures the bootstrap tokens used to join the nodes.
+	BootstrapToken *BootstrapTokenConfig `json:"bootstrapToken,omitempty"`
 	// Features enables and configures additional cluster features.
 	Features Features `json:"features,omitempty"`
 	// Addons are used to deploy additional manifests.
@@ -63,6 +74,195 @@ type KubeOneCluster struct {
 	RegistryConfiguration *RegistryConfiguration `json:"registryConfiguration,omitempty"`
 	// LoggingConfig configures the Kubelet's log configuration
 	LoggingConfig LoggingConfig `json:"loggingConfig,omitempty"`
+	// Namespaces is a list of namespaces created and reconciled by KubeOne
+	Namespaces []NamespaceConfig `json:"namespaces,omitempty"`
+	// Firewall configures the host firewall of the control plane and static
+	// worker nodes.
+	Firewall *FirewallConfig `json:"firewall,omitempty"`
+}
+
+// FirewallConfig configures the host firewall of the control plane and static
+// worker nodes. Incoming traffic is allowed only on the ports required by the
+// cluster, which are managed by KubeOne, and on the ports of the additional
+// rules. All other incoming traffic is denied. The firewall is reconciled on
+// every apply.
+type FirewallConfig struct {
+	// Backend is the firewall used to apply the rules.
+	// Possible values are "nftables" and "firewalld".
+	// The nftables backend manages a dedicated "kubeone" table and requires
+	// the nft binary on the nodes. The firewalld backend manages a "kubeone"
+	// service and rich rules in the default zone, it should be used on the
+	// nodes running firewalld.
+	// Default value is "nftables".
+	Backend string `json:"backend,omitempty"`
+	// Rules are additional rules allowing incoming traffic.
+	Rules []FirewallRule `json:"rules,omitempty"`
+	// KubeletSources is a list of additional CIDRs allowed to reach the
+	// kubelet API (port 10250). The control plane and static worker nodes are
+	// always allowed. CNIs like Canal and Cilium masquerade the traffic of the
+	// pods reaching other nodes (e.g. metrics-server or Prometheus scraping
+	// the kubelets) to the node address, so the subnet of the worker nodes
+	// created by machine-controller should be listed here.
+	KubeletSources []string `json:"kubeletSources,omitempty"`
+}
+
+// FirewallRule allows incoming traffic to a port or a port range
+type FirewallRule struct {
+	// Ports is a port (e.g. "8080") or a port range (e.g. "8000-8080").
+	Ports string `json:"ports"`
+	// Protocol is the protocol of the traffic.
+	// Possible values are "tcp" and "udp".
+	// Default value is "tcp".
+	Protocol string `json:"protocol,omitempty"`
+	// Sources is a list of CIDRs the traffic is allowed from.
+	// Traffic from all sources is allowed if empty.
+	Sources []string `json:"sources,omitempty"`
+	// Roles is a list of node roles the rule is applied to.
+	// Possible values are "controlPlane" and "staticWorker".
+	// The rule is applied to all nodes if empty.
+	Roles []string `json:"roles,omitempty"`
+}
+
+// NamespaceConfig describes a namespace created and reconciled by KubeOne.
+// Namespaces removed from the list are not deleted, KubeOne only warns about
+// them.
+type NamespaceConfig struct {
+	// Name is the name of the namespace.
+	Name string `json:"name"`
+	// Labels are set on the namespace, e.g. the Pod Security Standards
+	// labels. Other labels of the namespace are kept.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are set on the namespace. Other annotations of the
+	// namespace are kept.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// CertificateAuthority configures the user provided (bring-your-own) certificate
+// authorities. The CAs are distributed to the control plane nodes before
+// kubeadm generates the cluster certificates, so all certificates are signed by
+// them. CAs not provided here are generated by kubeadm.
+// The CAs are only used while provisioning the cluster, replacing the CAs of
+// an existing cluster is not supported.
+type CertificateAuthority struct {
+	// Kubernetes is the cluster CA (/etc/kubernetes/pki/ca.crt)
+	Kubernetes *CAKeyPair `json:"kubernetes,omitempty"`
+	// FrontProxy is the front-proxy CA used by the API aggregation layer
+	// (/etc/kubernetes/pki/front-proxy-ca.crt)
+	FrontProxy *CAKeyPair `json:"frontProxy,omitempty"`
+	// Etcd is the etcd CA (/etc/kubernetes/pki/etcd/ca.crt)
+	Etcd *CAKeyPair `json:"etcd,omitempty"`
+}
+
+// CAKeyPair is a CA certificate and its private key. The key is required
+// because the CA is used to sign the cluster and kubelet certificates.
+// Certificate-only CAs with an external signer are not supported, as they
+// require all leaf certificates and kubeconfigs of every node to be signed
+// upfront, and break the certificate renewal and the kubelet bootstrapping.
+type CAKeyPair struct {
+	// CertFilePath is a path on the local file system to the PEM-encoded CA
+	// certificate. Intermediate CAs can be followed by the certificates of
+	// their issuers up to the root CA, the chain is verified.
+	// Relative paths are relative to the KubeOne configuration file.
+	CertFilePath string `json:"certFilePath"`
+	// KeyFilePath is a path on the local file system to the PEM-encoded
+	// private key of the CA certificate.
+	// Relative paths are relative to the KubeOne configuration file.
+	KeyFilePath string `json:"keyFilePath"`
+}
+
+// BootstrapTokenConfig configures the kubeadm bootstrap tokens used to join
+// the control plane and static worker nodes. Worker nodes created by
+// machine-controller are joined using the bootstrap tokens managed by
+// machine-controller itself, which are valid for 1h and are refreshed
+// until the node joins the cluster.
+type BootstrapTokenConfig struct {
+	// TTL is the duration after which the bootstrap token expires.
+	// It must be a whole number of seconds, not longer than 24h.
+	// Default value: 1h
+	TTL metav1.Duration `json:"ttl,omitempty"`
+}
+
+// PostApplyValidation configures the validation of the cluster run at the end
+// of the apply command, after all tasks have succeeded
+type PostApplyValidation struct {
+	// Webhook is an external webhook validating the cluster. The apply
+	// command fails unless the webhook approves the cluster.
+	Webhook *PostApplyValidationWebhook `json:"webhook,omitempty"`
+}
+
+// PostApplyValidationWebhook is an external webhook called with a JSON
+// summary of the cluster (name, versions, nodes and machine deployments).
+// The webhook approves the cluster by responding with a 2xx status code and
+// the {"approved": true} JSON body, an optional "message" is reported to the
+// user.
+type PostApplyValidationWebhook struct {
+	// URL is the http(s) URL the cluster summary is POSTed to
+	URL string `json:"url"`
+	// Timeout is the maximum duration of the webhook call
+	// Default value: 30s
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ControlPlaneComponents configures the Kubernetes control plane components
+type ControlPlaneComponents struct {
+	// Scheduler configures the kube-scheduler
+	Scheduler *SchedulerConfig `json:"scheduler,omitempty"`
+	// APIServer configures the kube-apiserver
+	APIServer *APIServerConfig `json:"apiServer,omitempty"`
+	// ControllerManager configures the kube-controller-manager
+	ControllerManager *ControllerManagerConfig `json:"controllerManager,omitempty"`
+}
+
+// ControllerManagerConfig configures the kube-controller-manager. The options
+// are rendered into the kubeadm configuration and applied to the
+// kube-controller-manager static pods on all control plane nodes, which are
+// restarted when the options change.
+type ControllerManagerConfig struct {
+	// ClusterSigningDuration is the max duration of certificates signed by
+	// the kube-controller-manager, e.g. kubelet client and serving
+	// certificates. Individual CSRs may request shorter certificates.
+	// Default value: 8760h0m0s (1 year)
+	ClusterSigningDuration metav1.Duration `json:"clusterSigningDuration,omitempty"`
+}
+
+// APIServerConfig configures the kube-apiserver. The options are rendered
+// into the kubeadm configuration and applied to the kube-apiserver static
+// pods on all control plane nodes, which are restarted when the options change.
+type APIServerConfig struct {
+	// EndpointReconcilerType is the endpoint reconciler used by the
+	// kube-apiserver to manage the kubernetes service endpoints.
+	// Possible values: lease, master-count, none
+	// Default value: lease
+	EndpointReconcilerType string `json:"endpointReconcilerType,omitempty"`
+	// FeatureGates is a map of kube-apiserver feature gates to enable or
+	// disable, e.g. APIServerIdentity or StorageVersionAPI.
+	// Feature gates that are not available in the used Kubernetes version
+	// are rejected.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// ServiceAccountMaxTokenExpiration is the maximum validity duration of
+	// the service account tokens issued by the kube-apiserver. Tokens
+	// requested with a longer validity are issued with this validity.
+	// Must be at least 1h.
+	// Default value: unlimited
+	ServiceAccountMaxTokenExpiration metav1.Duration `json:"serviceAccountMaxTokenExpiration,omitempty"`
+	// ServiceAccountExtendTokenExpiration makes the kube-apiserver extend
+	// the validity of the projected service account tokens to 1 year, while
+	// still rejecting them after their original expiration if they are
+	// used by legacy clients. Set it to false to enforce short-lived tokens.
+	// Default value: true
+	ServiceAccountExtendTokenExpiration *bool `json:"serviceAccountExtendTokenExpiration,omitempty"`
+}
+
+// SchedulerConfig configures the kube-scheduler. The options are rendered
+// into the KubeSchedulerConfiguration file used by the kube-scheduler on all
+// control plane nodes, which is restarted when the configuration changes.
+type SchedulerConfig struct {
+	// PercentageOfNodesToScore is the percentage of all nodes that, once found
+	// feasible for running a pod, makes the scheduler stop searching for more
+	// feasible nodes. Lower values reduce the scheduling latency on large
+	// clusters. 0 means the adaptive default based on the cluster size,
+	// 100 means all nodes are scored.
+	PercentageOfNodesToScore *int32 `json:"percentageOfNodesToScore,omitempty"`
 }
 
 // LoggingConfig configures the Kubelet's log rotation
@@ -82,18 +282,86 @@ type ContainerRuntimeConfig struct {
 
 	// Containerd related configurations
 	Containerd *ContainerRuntimeContainerd `json:"containerd,omitempty"`
+
+	// Cgroups configures the cgroup version required on the nodes
+	Cgroups *CgroupsConfig `json:"cgroups,omitempty"`
+
+	// PreloadImages is a list of images pulled on all nodes once the
+	// container runtime is configured. Images already present on the node
+	// are not pulled again.
+	PreloadImages []string `json:"preloadImages,omitempty"`
+}
+
+// CgroupsConfig configures the cgroup version required on the nodes. The
+// cgroup version of each node is detected and verified before provisioning.
+type CgroupsConfig struct {
+	// Version is the cgroup version required on all nodes.
+	// Possible values are "v1" and "v2".
+	Version string `json:"version"`
+	// ConfigureKernelCommandLine enables cgroup v2 on the nodes booted with
+	// cgroup v1 by adding systemd.unified_cgroup_hierarchy=1 to the kernel
+	// command line and rebooting them. Only the nodes that are not
+	// provisioned yet are rebooted, the verification fails for other nodes.
+	// It can be enabled only for version "v2".
+	// Default value is false.
+	ConfigureKernelCommandLine bool `json:"configureKernelCommandLine,omitempty"`
 }
 
 // ContainerRuntimeDocker defines docker container runtime
 type ContainerRuntimeDocker struct {
 	// Configures dockerd with "registry-mirrors"
 	RegistryMirrors []string `json:"registryMirrors"`
+	// LogDriver configures dockerd with "log-driver"
+	// Default value is "json-file"
+	LogDriver string `json:"logDriver,omitempty"`
+	// LogOpts configures dockerd with "log-opts"
+	// The "max-size" and "max-file" options are by default set to the
+	// loggingConfig.containerLogMaxSize and loggingConfig.containerLogMaxFiles values
+	// for the "json-file" and "local" log drivers, and can be overridden here.
+	LogOpts map[string]string `json:"logOpts,omitempty"`
 }
 
 // ContainerRuntimeContainerd defines docker container runtime
 type ContainerRuntimeContainerd struct {
 	// A map of registries to use to render configs and mirrors for containerd registries
 	Registries map[string]ContainerdRegistry `json:"registries,omitempty"`
+	// StreamServerAddress configures the CRI plugin with "stream_server_address",
+	// the address the streaming server (exec, attach, port-forward) listens on
+	StreamServerAddress string `json:"streamServerAddress,omitempty"`
+	// StreamServerPort configures the CRI plugin with "stream_server_port",
+	// the port the streaming server listens on. 0 means a random free port.
+	StreamServerPort int `json:"streamServerPort,omitempty"`
+	// StreamIdleTimeout configures the CRI plugin with "stream_idle_timeout",
+	// the maximum time a streaming connection can be idle before it's closed
+	StreamIdleTimeout string `json:"streamIdleTimeout,omitempty"`
+	// Runtimes is a map of additional containerd runtime handlers, e.g. gVisor
+	// or Kata Containers, registered in the CRI plugin besides the default runc
+	// runtime. The map key is the runtime handler name.
+	Runtimes map[string]ContainerdRuntime `json:"runtimes,omitempty"`
+}
+
+// ContainerdRuntime defines an additional containerd runtime handler
+type ContainerdRuntime struct {
+	// RuntimeType is the containerd shim used by the runtime, e.g.
+	// io.containerd.runsc.v1 for gVisor or io.containerd.kata.v2 for Kata
+	// Containers. Runtimes using the io.containerd.runc.v2 shim are configured
+	// to use the systemd cgroup driver.
+	RuntimeType string `json:"runtimeType"`
+	// BinaryName is the path to the OCI runtime binary used by the
+	// io.containerd.runc.v2 shim, e.g. /usr/local/bin/crun. Required for the
+	// io.containerd.runc.v2 shim.
+	BinaryName string `json:"binaryName,omitempty"`
+	// RuntimeClass configures the RuntimeClass object created for the
+	// runtime handler. The RuntimeClass is not created if not set.
+	RuntimeClass *ContainerdRuntimeClass `json:"runtimeClass,omitempty"`
+}
+
+// ContainerdRuntimeClass configures the RuntimeClass object of the containerd
+// runtime handler
+type ContainerdRuntimeClass struct {
+	// NodeSelector schedules pods using the RuntimeClass only to nodes with
+	// the matching labels, e.g. nodes designated for sandboxed workloads.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // ContainerdRegistry defines endpoints and security for given container registry
@@ -130,6 +398,7 @@ const (
 	OperatingSystemNameDebian  OperatingSystemName = "debian"
 	OperatingSystemNameCentOS  OperatingSystemName = "centos"
 	OperatingSystemNameRHEL    OperatingSystemName = "rhel"
+	OperatingSystemNameRocky   OperatingSystemName = "rocky"
 	OperatingSystemNameAmazon  OperatingSystemName = "amzn"
 	OperatingSystemNameFlatcar OperatingSystemName = "flatcar"
 	OperatingSystemNameUnknown OperatingSystemName = ""
@@ -170,8 +439,10 @@ type HostConfig struct {
 	// IsLeader indicates this host as a session leader.
 	// Default value is populated at the runtime.
 	IsLeader bool `json:"isLeader,omitempty"`
-	// Taints are taints applied to nodes. If not provided (i.e. nil) for control plane nodes,
-	// it defaults to:
+	// Taints are taints applied to nodes. Those taints are only applied when the node is being provisioned,
+	// or when reconciled by `kubeone apply --reconcile-labels`. The reconciliation removes the taints
+	// removed from the manifest from the Node as well, other taints of the Node are kept as they are.
+	// If not provided (i.e. nil) for control plane nodes, it defaults to:
 	//   * For Kubernetes 1.23 and older: TaintEffectNoSchedule with key node-role.kubernetes.io/master
 	//   * For Kubernetes 1.24 and newer: TaintEffectNoSchedule with keys
 	//     node-role.kubernetes.io/control-plane and node-role.kubernetes.io/master
@@ -179,8 +450,59 @@ type HostConfig struct {
 	Taints []corev1.Taint `json:"taints,omitempty"`
 	// Kubelet
 	Kubelet KubeletConfig `json:"kubelet,omitempty"`
-	// OperatingSystem information, can be populated at the runtime.
+	// OperatingSystem is the operating system family of the host. If set, it
+	// overrides the operating system detected from /etc/os-release.
+	// Possible values: ubuntu, debian, centos, rhel, rocky, amzn, flatcar
+	// Default: detected at the runtime
 	OperatingSystem OperatingSystemName `json:"operatingSystem,omitempty"`
+	// BindAddresses configures the addresses the control plane components
+	// listen on. It can be set only for the control plane hosts.
+	BindAddresses *BindAddresses `json:"bindAddresses,omitempty"`
+	// DNSName is a stable DNS name of the control plane host, resolving to its
+	// private address (or public address if the private address is not set).
+	// If set, it's used for the etcd peer URL of the host and for reaching
+	// the control plane components running on the host, and it's added to
+	// the API server and etcd certificates SANs. The etcd client URLs and the
+	// API server advertise address keep using the host address, so the name
+	// doesn't make the host survive an address change. It can be set only
+	// for the control plane hosts.
+	DNSName string `json:"dnsName,omitempty"`
+	// Labels are set on the Node object of the host by
+	// `kubeone apply --reconcile-labels`. Labels removed from the manifest
+	// are removed from the Node as well, other labels of the Node are kept
+	// as they are.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are set on the Node object of the host by
+	// `kubeone apply --reconcile-labels`. Annotations removed from the
+	// manifest are removed from the Node as well, other annotations of the
+	// Node are kept as they are.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// BindAddresses configures the addresses the control plane components listen
+// on. Empty addresses keep the defaults. The addresses must be present on the
+// host. Changing the addresses restarts the affected components.
+type BindAddresses struct {
+	// APIServer is the address kube-apiserver listens on (--bind-address).
+	// It must be the address the kube-apiserver is advertised on, i.e. the
+	// private address of the host, or the public address if the private
+	// address is not set.
+	// Default value is 0.0.0.0 (all interfaces).
+	APIServer string `json:"apiServer,omitempty"`
+	// Etcd is the address etcd listens on for the client, peer and metrics
+	// connections, in addition to the loopback address. It must be the
+	// address etcd is advertised on, i.e. the private address of the host,
+	// or the public address if the private address is not set.
+	// Default value is the advertised address for the client and peer
+	// connections and 0.0.0.0 (all interfaces) for the metrics.
+	Etcd string `json:"etcd,omitempty"`
+	// ControllerManager is the address kube-controller-manager listens on
+	// (--bind-address).
+	// Default value is 127.0.0.1.
+	ControllerManager string `json:"controllerManager,omitempty"`
+	// Scheduler is the address kube-scheduler listens on (--bind-address).
+	// Default value is 127.0.0.1.
+	Scheduler string `json:"scheduler,omitempty"`
 }
 
 // ControlPlaneConfig defines control plane nodes
@@ -206,6 +528,18 @@ type KubeletConfig struct {
 	// EvictionHard configure --eviction-hard command-line flag of the kubelet.
 	// See more at: https://kubernetes.io/docs/tasks/administer-cluster/reserve-compute-resources/
 	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+	// EnforceNodeAllocatable configure --enforce-node-allocatable command-line flag of the kubelet.
+	// Possible values are "pods", "system-reserved", "kube-reserved" and "none".
+	// Enforcing system-reserved or kube-reserved requires SystemReservedCgroup
+	// or KubeReservedCgroup to be set accordingly.
+	// See more at: https://kubernetes.io/docs/tasks/administer-cluster/reserve-compute-resources/
+	EnforceNodeAllocatable []string `json:"enforceNodeAllocatable,omitempty"`
+	// SystemReservedCgroup configure --system-reserved-cgroup command-line flag of the kubelet.
+	// It must be an absolute path of an existing cgroup, e.g. /system.slice
+	SystemReservedCgroup string `json:"systemReservedCgroup,omitempty"`
+	// KubeReservedCgroup configure --kube-reserved-cgroup command-line flag of the kubelet.
+	// It must be an absolute path of an existing cgroup, e.g. /kube.slice
+	KubeReservedCgroup string `json:"kubeReservedCgroup,omitempty"`
 	// MaxPods configures maximum number of pods per node.
 	// If not provided, default value provided by kubelet will be used
 	// (max. 110 pods per node)
@@ -221,6 +555,44 @@ type APIEndpoint struct {
 	Port int `json:"port,omitempty"`
 	// AlternativeNames is a list of Subject Alternative Names for the API Server signing cert.
 	AlternativeNames []string `json:"alternativeNames,omitempty"`
+	// InternalHost is the hostname or IP of the internal API endpoint, e.g. an
+	// internal load balancer reachable only from within the private network.
+	// The internal host is added to the API Server signing cert. On existing
+	// clusters, the cert is regenerated by apply when the host is missing.
+	InternalHost string `json:"internalHost,omitempty"`
+	// InternalPort is the port used to reach to the API via the internal host.
+	// Default value is the port of the external API endpoint.
+	InternalPort int `json:"internalPort,omitempty"`
+	// StandbyEndpoints are additional API endpoints, e.g. load balancers in a
+	// standby region used for disaster recovery. The standby hosts are added
+	// to the API Server signing cert, so the DNS can be failed over to them
+	// without certificate errors. On existing clusters, the cert is
+	// regenerated by apply when any standby host is missing. KubeOne doesn't
+	// manage the load balancers.
+	StandbyEndpoints []APIEndpointStandby `json:"standbyEndpoints,omitempty"`
+	// HealthCheck configures the endpoint used by KubeOne to probe the health
+	// of the API server instances on the control plane nodes.
+	HealthCheck *APIEndpointHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// APIEndpointStandby is an additional (standby) API endpoint
+type APIEndpointStandby struct {
+	// Host is the hostname or IP of the standby API endpoint.
+	Host string `json:"host"`
+	// Port is the port used to reach to the API via the standby host.
+	// Default value is the port of the primary API endpoint.
+	Port int `json:"port,omitempty"`
+}
+
+// APIEndpointHealthCheck configures the API server health check endpoint
+type APIEndpointHealthCheck struct {
+	// Port is the port on which the API server health check is served on the
+	// control plane nodes.
+	// Default value is 6443.
+	Port int `json:"port,omitempty"`
+	// Path is the HTTP path of the API server health check.
+	// Default value is /healthz.
+	Path string `json:"path,omitempty"`
 }
 
 // CloudProviderSpec describes the cloud provider that is running the machines.
@@ -232,6 +604,12 @@ type CloudProviderSpec struct {
 	CloudConfig string `json:"cloudConfig,omitempty"`
 	// CSIConfig
 	CSIConfig string `json:"csiConfig,omitempty"`
+	// CCM configures the cloud-controller-manager deployed by KubeOne
+	// when External is enabled
+	CCM *CCMConfig `json:"ccm,omitempty"`
+	// CSISnapshotController deploys the CSI external snapshot-controller and
+	// the VolumeSnapshot CRDs
+	CSISnapshotController *CSISnapshotControllerConfig `json:"csiSnapshotController,omitempty"`
 	// AWS
 	AWS *AWSSpec `json:"aws,omitempty"`
 	// Azure
@@ -256,6 +634,30 @@ type CloudProviderSpec struct {
 	None *NoneSpec `json:"none,omitempty"`
 }
 
+// CCMConfig configures the cloud-controller-manager workload
+type CCMConfig struct {
+	// ExtraArgs is a map of additional flags passed to the cloud-controller-manager,
+	// overriding flags with the same name set by KubeOne.
+	// Flags are specified without the leading dashes (e.g. "concurrent-service-syncs": "5").
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// Resources are the resource requests and limits of the cloud-controller-manager container
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Replicas is the number of cloud-controller-manager replicas.
+	// Only supported by the providers running the cloud-controller-manager as
+	// a Deployment (Azure, DigitalOcean, EquinixMetal and Hetzner).
+	// Leader election is enabled if more than one replica is requested.
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// CSISnapshotControllerConfig configures the CSI external snapshot-controller
+type CSISnapshotControllerConfig struct {
+	// Enable deploys the snapshot-controller and the VolumeSnapshot CRDs.
+	// It's needed only for the bundled CSI drivers not shipping them (OpenStack
+	// Cinder) and for the CSI drivers deployed by the user. Other bundled CSI
+	// drivers deploy their own snapshot-controller.
+	Enable bool `json:"enable,omitempty"`
+}
+
 // AWSSpec defines the AWS cloud provider
 type AWSSpec struct{}
 
@@ -301,6 +703,17 @@ type NoneSpec struct{}
 // VersionConfig describes the versions of components that are installed on the machines
 type VersionConfig struct {
 	Kubernetes string `json:"kubernetes"`
+	// KubeadmConfigAPIVersion overrides the kubeadm configuration API
+	// version used by KubeOne to generate the kubeadm configuration files.
+	// This is an advanced option meant for testing the upcoming kubeadm
+	// behavior. The chosen API version must be supported by the kubeadm
+	// version matching the Kubernetes version.
+	// Possible values: "kubeadm.k8s.io/v1beta2" (Kubernetes < 1.26),
+	// "kubeadm.k8s.io/v1beta3" (Kubernetes 1.22+).
+	// Default value is the kubeadm configuration API version matching the
+	// Kubernetes version ("kubeadm.k8s.io/v1beta2" for Kubernetes < 1.22,
+	// "kubeadm.k8s.io/v1beta3" otherwise).
+	KubeadmConfigAPIVersion string `json:"kubeadmConfigAPIVersion,omitempty"`
 }
 
 // ClusterNetworkConfig describes the cluster network
@@ -314,14 +727,45 @@ type ClusterNetworkConfig struct {
 	// ServiceDomainName
 	// default value is "cluster.local"
 	ServiceDomainName string `json:"serviceDomainName,omitempty"`
-	// NodePortRange
+	// NodePortRange is the range of ports reserved for NodePort Services in
+	// the "min-max" format. It's set as the kube-apiserver
+	// --service-node-port-range flag.
 	// default value is "30000-32767"
 	NodePortRange string `json:"nodePortRange,omitempty"`
+	// NodeCIDRMaskSize configures the size of the pod CIDR allocated to each
+	// node by the kube-controller-manager. It must be consistent with the CNI
+	// configuration and large enough to fit the kubelet maxPods.
+	NodeCIDRMaskSize *NodeCIDRMaskSize `json:"nodeCIDRMaskSize,omitempty"`
 	// CNI
 	// default value is {canal: {mtu: 1450}}
 	CNI *CNI `json:"cni,omitempty"`
 	// KubeProxy config
 	KubeProxy *KubeProxyConfig `json:"kubeProxy,omitempty"`
+	// NodeConnectivityCheck enables the preflight verification of the
+	// node-to-node connectivity before provisioning the cluster.
+	NodeConnectivityCheck *NodeConnectivityCheck `json:"nodeConnectivityCheck,omitempty"`
+}
+
+// NodeConnectivityCheck configures the preflight verification of the
+// node-to-node connectivity. The TCP ports required by the control plane
+// components, kubelet and the CNI plugin (depending on the node roles) and the
+// path MTU are verified between the nodes, and the provisioning fails with a
+// list of the blocked paths. UDP ports (e.g. VXLAN overlays) can't be verified.
+type NodeConnectivityCheck struct {
+	// MTU is the path MTU that must be available between the nodes.
+	// Default value is the Canal MTU increased by the VXLAN overhead (50) if
+	// Canal is used, otherwise the MTU is not verified.
+	MTU int `json:"mtu,omitempty"`
+}
+
+// NodeCIDRMaskSize configures the mask size of the node pod CIDRs
+type NodeCIDRMaskSize struct {
+	// IPv4 is the mask size of the IPv4 node pod CIDR
+	// default value is 24
+	IPv4 *int32 `json:"ipv4,omitempty"`
+	// IPv6 is the mask size of the IPv6 node pod CIDR
+	// default value is 64
+	IPv6 *int32 `json:"ipv6,omitempty"`
 }
 
 // KubeProxyConfig defines configured kube-proxy mode, default is iptables mode
@@ -440,6 +884,21 @@ type DynamicWorkerConfig struct {
 	Replicas *int `json:"replicas"`
 	// Config
 	Config ProviderSpec `json:"providerSpec"`
+	// Autoscaler sets the cluster-autoscaler annotations on the generated
+	// MachineDeployment, so the cluster-autoscaler scales the worker pool
+	// between the given minimum and maximum number of replicas.
+	Autoscaler *DynamicWorkerAutoscaler `json:"autoscaler,omitempty"`
+}
+
+// DynamicWorkerAutoscaler configures the cluster-autoscaler node group size
+// of the worker pool
+type DynamicWorkerAutoscaler struct {
+	// MinReplicas is the minimum number of replicas the cluster-autoscaler
+	// scales the worker pool down to. Must be greater than zero.
+	MinReplicas int `json:"minReplicas"`
+	// MaxReplicas is the maximum number of replicas the cluster-autoscaler
+	// scales the worker pool up to. Must be greater or equal to minReplicas.
+	MaxReplicas int `json:"maxReplicas"`
 }
 
 // ProviderSpec describes a worker node
@@ -474,6 +933,28 @@ type ProviderSpec struct {
 	Network *ProviderStaticNetworkConfig `json:"network,omitempty"`
 	// OverwriteCloudConfig
 	OverwriteCloudConfig *string `json:"overwriteCloudConfig,omitempty"`
+	// InstanceProfile is the name of the AWS IAM instance profile to be
+	// attached to the worker nodes of this worker pool. It overrides the
+	// instanceProfile set in the cloudProviderSpec, including the one
+	// populated from the Terraform output.
+	// Only supported on AWS.
+	InstanceProfile string `json:"instanceProfile,omitempty"`
+	// CloudNetwork overrides the cloud provider networking of this worker
+	// pool set in the cloudProviderSpec, including the one populated from
+	// the Terraform output.
+	// Only supported on AWS, Azure, GCE and OpenStack.
+	CloudNetwork *CloudNetworkConfig `json:"cloudNetwork,omitempty"`
+	// BootstrapCommands are shell commands injected into the worker
+	// user-data of this worker pool. They run early in the boot process,
+	// before the node joins the cluster. Each command must be a single line.
+	// Requires operating-system-manager to be enabled.
+	BootstrapCommands []string `json:"bootstrapCommands,omitempty"`
+	// Disks overrides the disk sizes and types of this worker pool set in
+	// the cloudProviderSpec, including the ones populated from the Terraform
+	// output.
+	// Only supported on AWS, Azure, GCE, Nutanix, OpenStack, VMware Cloud
+	// Director and vSphere.
+	Disks *DiskConfig `json:"disks,omitempty"`
 }
 
 // DNSConfig contains a machine's DNS configuration
@@ -482,6 +963,52 @@ type DNSConfig struct {
 	Servers []string `json:"servers"`
 }
 
+// CloudNetworkConfig configures the cloud provider networking of the worker
+// pool machines
+type CloudNetworkConfig struct {
+	// SubnetID is the subnet the machines are created in. It's used as the
+	// subnetId on AWS, subnetName on Azure, subnetwork on GCE, and subnet on
+	// OpenStack.
+	SubnetID string `json:"subnetID,omitempty"`
+	// SecurityGroupIDs are the security groups attached to the machines.
+	// They're used as the securityGroupIDs on AWS and securityGroups on
+	// OpenStack. Azure supports only one security group used as the
+	// securityGroupName. Not supported on GCE.
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+	// AssignPublicIP controls whether the machines get a public IP address.
+	// It's used as the assignPublicIP on AWS and Azure, and
+	// assignPublicIPAddress on GCE. Not supported on OpenStack.
+	AssignPublicIP *bool `json:"assignPublicIP,omitempty"`
+}
+
+// DiskConfig configures the disks of the worker pool machines
+type DiskConfig struct {
+	// Size is the size of the root disk in GiB. It's used as the diskSize on
+	// AWS, GCE and Nutanix, osDiskSize on Azure, rootDiskSizeGB on OpenStack,
+	// and diskSizeGB on VMware Cloud Director and vSphere.
+	Size int `json:"size,omitempty"`
+	// Type is the type of the root disk. It's used as the diskType on AWS
+	// (standard, gp2, gp3, io1, st1 or sc1) and GCE (pd-standard or pd-ssd),
+	// osDiskSKU on Azure (Standard_LRS, StandardSSD_LRS or Premium_LRS),
+	// rootDiskVolumeType on OpenStack, and storageProfile on VMware Cloud
+	// Director. Not supported on Nutanix and vSphere.
+	Type string `json:"type,omitempty"`
+	// DataVolumes are the additional data disks attached to the machines.
+	// Only supported on Azure, which supports a single data disk.
+	DataVolumes []DataVolumeConfig `json:"dataVolumes,omitempty"`
+}
+
+// DataVolumeConfig configures an additional data disk of the worker pool
+// machines
+type DataVolumeConfig struct {
+	// Size is the size of the data disk in GiB. It's used as the
+	// dataDiskSize on Azure.
+	Size int `json:"size"`
+	// Type is the type of the data disk. It's used as the dataDiskSKU on
+	// Azure (Standard_LRS, StandardSSD_LRS, Premium_LRS or UltraSSD_LRS).
+	Type string `json:"type,omitempty"`
+}
+
 // ProviderStaticNetworkConfig contains a machine's static network configuration
 type ProviderStaticNetworkConfig struct {
 	// CIDR
@@ -515,6 +1042,22 @@ type Features struct {
 	OpenIDConnect *OpenIDConnect `json:"openidConnect,omitempty"`
 	// Encryption Providers
 	EncryptionProviders *EncryptionProviders `json:"encryptionProviders,omitempty"`
+	// StaticAuth
+	StaticAuth *StaticAuth `json:"staticAuth,omitempty"`
+	// KubeletServingCertRotation
+	KubeletServingCertRotation *KubeletServingCertRotation `json:"kubeletServingCertRotation,omitempty"`
+	// EtcdMetrics
+	EtcdMetrics *EtcdMetrics `json:"etcdMetrics,omitempty"`
+	// PodDisruptionBudgets
+	PodDisruptionBudgets *PodDisruptionBudgets `json:"podDisruptionBudgets,omitempty"`
+	// PrometheusAdapter
+	PrometheusAdapter *PrometheusAdapter `json:"prometheusAdapter,omitempty"`
+	// EtcdBackup
+	EtcdBackup *EtcdBackup `json:"etcdBackup,omitempty"`
+	// PodTolerationRestriction
+	PodTolerationRestriction *PodTolerationRestriction `json:"podTolerationRestriction,omitempty"`
+	// WebhookCABundleInjection
+	WebhookCABundleInjection *WebhookCABundleInjection `json:"webhookCABundleInjection,omitempty"`
 }
 
 // SystemPackages controls configurations of APT/YUM
@@ -572,6 +1115,56 @@ type PodNodeSelectorConfig struct {
 	ConfigFilePath string `json:"configFilePath"`
 }
 
+// PodTolerationRestriction configures the PodTolerationRestriction admission
+// plugin, which adds default tolerations to pods and rejects pods with
+// tolerations that are not whitelisted.
+// More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#podtolerationrestriction
+type PodTolerationRestriction struct {
+	// Enable enables the PodTolerationRestriction admission plugin.
+	// Default value is false.
+	Enable bool `json:"enable,omitempty"`
+	// DefaultTolerations are added to pods in namespaces that don't have
+	// own default tolerations.
+	// Changing the cluster-wide tolerations requires the API server to be
+	// restarted, e.g. by running kubeone apply with --force-upgrade.
+	DefaultTolerations []corev1.Toleration `json:"defaultTolerations,omitempty"`
+	// Whitelist is a list of tolerations allowed for pods in namespaces that
+	// don't have own whitelist. All tolerations are allowed if empty.
+	Whitelist []corev1.Toleration `json:"whitelist,omitempty"`
+	// Namespaces configures the default and whitelisted tolerations of
+	// namespaces. KubeOne reconciles them as the namespace annotations, so
+	// they can be changed without restarting the API server. Namespaces that
+	// don't exist are created.
+	Namespaces []NamespaceTolerations `json:"namespaces,omitempty"`
+}
+
+// NamespaceTolerations configures the default and whitelisted tolerations of
+// a namespace
+type NamespaceTolerations struct {
+	// Name is the name of the namespace.
+	Name string `json:"name"`
+	// DefaultTolerations are added to pods in the namespace.
+	DefaultTolerations []corev1.Toleration `json:"defaultTolerations,omitempty"`
+	// Whitelist is a list of tolerations allowed for pods in the namespace.
+	Whitelist []corev1.Toleration `json:"whitelist,omitempty"`
+}
+
+// WebhookCABundleInjection configures injecting the cluster CA certificate
+// into the caBundle of the admission webhook configurations
+type WebhookCABundleInjection struct {
+	// Enable makes KubeOne set the caBundle of all webhooks in the listed
+	// webhook configurations to the cluster CA certificate on every apply,
+	// e.g. after the cluster CA is rotated. All listed webhook configurations
+	// must exist in the cluster.
+	Enable bool `json:"enable,omitempty"`
+	// ValidatingWebhookConfigurations is a list of names of the
+	// ValidatingWebhookConfigurations to inject the caBundle into.
+	ValidatingWebhookConfigurations []string `json:"validatingWebhookConfigurations,omitempty"`
+	// MutatingWebhookConfigurations is a list of names of the
+	// MutatingWebhookConfigurations to inject the caBundle into.
+	MutatingWebhookConfigurations []string `json:"mutatingWebhookConfigurations,omitempty"`
+}
+
 // PodSecurityPolicy feature flag
 // This feature is deprecated and will be removed from the API once
 // Kubernetes 1.24 reaches EOL.
@@ -592,9 +1185,19 @@ type StaticAuditLog struct {
 type StaticAuditLogConfig struct {
 	// PolicyFilePath is a path on local file system to the audit policy manifest
 	// which defines what events should be recorded and what data they should include.
-	// PolicyFilePath is a required field.
+	// The policy must record at least the Metadata level for all requests not
+	// matched by other rules.
+	// Only one of PolicyFilePath and PolicyPreset can be set.
 	// More info: https://kubernetes.io/docs/tasks/debug-application-cluster/audit/#audit-policy
-	PolicyFilePath string `json:"policyFilePath"`
+	PolicyFilePath string `json:"policyFilePath,omitempty"`
+	// PolicyPreset is the name of the audit policy shipped with KubeOne to be used
+	// instead of the policy manifest. Supported values:
+	// * minimal - records all requests at the Metadata level
+	// * compliance - records requests to Secrets and RBAC objects at the
+	//   RequestResponse level (including the Secrets data) and all other
+	//   requests at the Metadata level
+	// Only one of PolicyFilePath and PolicyPreset can be set.
+	PolicyPreset string `json:"policyPreset,omitempty"`
 	// LogPath is path on control plane instances where audit log files are stored.
 	// Default value is /var/log/kubernetes/audit.log
 	LogPath string `json:"logPath,omitempty"`
@@ -609,6 +1212,117 @@ type StaticAuditLogConfig struct {
 	LogMaxSize int `json:"logMaxSize,omitempty"`
 }
 
+// StaticAuth configures additional static authentication methods for the
+// API server
+type StaticAuth struct {
+	// ClientCABundle is an inline PEM-encoded bundle of additional CA
+	// certificates trusted by the API server to authenticate clients using
+	// client certificates. The bundle is appended to the cluster CA certificate
+	// managed by kubeadm and used as the API server's --client-ca-file.
+	// Only one of ClientCABundle and ClientCABundleFilePath can be set.
+	ClientCABundle string `json:"clientCABundle,omitempty"`
+	// ClientCABundleFilePath is a path on the local file system to the
+	// PEM-encoded bundle of additional client CA certificates.
+	// Only one of ClientCABundle and ClientCABundleFilePath can be set.
+	ClientCABundleFilePath string `json:"clientCABundleFilePath,omitempty"`
+	// AnonymousAuth controls whether the API server accepts anonymous
+	// requests. If disabled, KubeOne switches the kube-apiserver probes to
+	// TCP probes, authenticates its own health checks with a client
+	// certificate, and joins nodes using a discovery kubeconfig instead of
+	// the anonymously readable cluster-info ConfigMap.
+	// Default value: kube-apiserver default (true)
+	AnonymousAuth *bool `json:"anonymousAuth,omitempty"`
+	// Profiling controls whether the API server serves the profiling
+	// endpoints under /debug/pprof.
+	// Default value: kube-apiserver default (true)
+	Profiling *bool `json:"profiling,omitempty"`
+}
+
+// KubeletServingCertRotation configures rotation of the kubelet serving
+// certificates
+type KubeletServingCertRotation struct {
+	// Enable makes KubeOne approve pending kubelet serving certificate signing
+	// requests (CSRs) of the control plane and static worker nodes on every
+	// apply. CSRs of the machine-controller managed nodes are approved by
+	// machine-controller.
+	Enable bool `json:"enable,omitempty"`
+}
+
+// EtcdMetrics configures exposing the etcd metrics on a dedicated port
+type EtcdMetrics struct {
+	// Enable makes etcd serve metrics over HTTPS on all interfaces on the
+	// given port, in addition to the plain HTTP endpoint on localhost used
+	// for the health checks. Scraping the endpoint requires a client
+	// certificate signed by the etcd CA.
+	Enable bool `json:"enable,omitempty"`
+	// Port is the port used to serve the etcd metrics over HTTPS.
+	// It must not conflict with other ports used on the control plane nodes.
+	// Default value is 2382.
+	Port int `json:"port,omitempty"`
+	// ServiceMonitor deploys a headless Service selecting the etcd static
+	// pods, a Secret with a client certificate signed by the etcd CA, and a
+	// Prometheus Operator ServiceMonitor scraping the metrics endpoint.
+	// The Prometheus Operator CRDs must be installed in the cluster.
+	ServiceMonitor bool `json:"serviceMonitor,omitempty"`
+}
+
+// PodDisruptionBudgets configures PodDisruptionBudgets for the system addons
+type PodDisruptionBudgets struct {
+	// Enable creates PodDisruptionBudgets for the multi-replica system addons
+	// not shipping their own PodDisruptionBudget (e.g. CoreDNS), so node
+	// drains don't evict all of their pods at once.
+	Enable bool `json:"enable,omitempty"`
+	// MinAvailable is the number (e.g. 1) or the percentage (e.g. "50%") of
+	// pods of each addon that must stay available during node drains. It's
+	// capped to the number of replicas minus one, so the
+	// PodDisruptionBudgets never block drains.
+	// Default value is 1.
+	MinAvailable intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+// PrometheusAdapter configures the Prometheus adapter serving the custom and
+// external metrics APIs used by the HorizontalPodAutoscalers
+type PrometheusAdapter struct {
+	// Enable deploys the Prometheus adapter and registers the
+	// custom.metrics.k8s.io and external.metrics.k8s.io APIs.
+	// Default value is false.
+	Enable bool `json:"enable,omitempty"`
+	// PrometheusURL is the URL of the Prometheus server queried by the
+	// adapter, e.g. http://prometheus.monitoring.svc:9090.
+	// The Prometheus server is not deployed by KubeOne.
+	PrometheusURL string `json:"prometheusURL"`
+	// Rules is the adapter metrics discovery configuration in YAML format,
+	// containing the rules and externalRules lists. If not set, all pods
+	// metrics with the namespace and pod labels are exposed via the custom
+	// metrics API.
+	// See https://github.com/kubernetes-sigs/prometheus-adapter/blob/master/docs/config.md
+	Rules string `json:"rules,omitempty"`
+}
+
+// EtcdBackup configures periodic etcd backups using a CronJob running on the
+// control plane nodes. The backups are created the same way as by the
+// backups-restic addon: the etcd snapshot and the cluster CA certificates are
+// encrypted and uploaded to the S3 bucket using restic. The AWS credentials
+// used for uploading are taken from the credentials file or the environment.
+type EtcdBackup struct {
+	// Enable deploys the etcd backup CronJob.
+	// Default value is false.
+	Enable bool `json:"enable,omitempty"`
+	// Schedule is the schedule of the backups in the cron format,
+	// e.g. "0 */6 * * *" or "@every 30m".
+	// Default value is "@every 30m".
+	Schedule string `json:"schedule,omitempty"`
+	// S3Bucket is the restic repository the backups are uploaded to,
+	// e.g. s3:s3.amazonaws.com/my-etcd-backups.
+	S3Bucket string `json:"s3Bucket"`
+	// AWSDefaultRegion is the AWS region of the S3 bucket.
+	AWSDefaultRegion string `json:"awsDefaultRegion"`
+	// KeepLast is the number of the most recent backups to retain, older
+	// backups are pruned after every backup.
+	// Default value is 48.
+	KeepLast int `json:"keepLast,omitempty"`
+}
+
 // DynamicAuditLog feature flag
 type DynamicAuditLog struct {
 	// Enable
@@ -663,6 +1377,19 @@ type Addon struct {
 
 	// Delete flag to ensure the named addon with all its contents to be deleted
 	Delete bool `json:"delete,omitempty"`
+
+	// EnabledWhen is a condition that must be satisfied for the addon to be
+	// deployed, e.g. "provider == aws && k8s >= 1.23". The condition consists
+	// of comparisons joined with && and || operators, optionally negated with !
+	// and grouped with parentheses. Supported comparisons are "provider == name",
+	// "provider != name", and "k8s <op> version", where op is one of ==, !=, <,
+	// <=, >, >=. The addon is always deployed if the condition is empty.
+	EnabledWhen string `json:"enabledWhen,omitempty"`
+
+	// ForceConflicts makes the addon take over ownership of fields managed by
+	// other field managers when applying it using the server-side apply.
+	// It requires addons.fieldManager to be set.
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
 }
 
 // Addons config
@@ -676,6 +1403,12 @@ type Addons struct {
 	// GlobalParams to the addon, to render all addons using text/template
 	GlobalParams map[string]string `json:"globalParams,omitempty"`
 
+	// FieldManager is the name of the field manager used to apply addons using
+	// the server-side apply. If not set, addons are applied using the
+	// client-side apply. Fields managed by other field managers are not
+	// overwritten, unless forceConflicts is enabled for the addon.
+	FieldManager string `json:"fieldManager,omitempty"`
+
 	// Addons is a list of config options for named addon
 	Addons []Addon `json:"addons,omitempty"`
 }
@@ -48,6 +48,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*APIEndpointHealthCheck)(nil), (*kubeone.APIEndpointHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_APIEndpointHealthCheck_To_kubeone_APIEndpointHealthCheck(a.(*APIEndpointHealthCheck), b.(*kubeone.APIEndpointHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.APIEndpointHealthCheck)(nil), (*APIEndpointHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_APIEndpointHealthCheck_To_v1beta2_APIEndpointHealthCheck(a.(*kubeone.APIEndpointHealthCheck), b.(*APIEndpointHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*APIEndpointStandby)(nil), (*kubeone.APIEndpointStandby)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_APIEndpointStandby_To_kubeone_APIEndpointStandby(a.(*APIEndpointStandby), b.(*kubeone.APIEndpointStandby), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.APIEndpointStandby)(nil), (*APIEndpointStandby)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_APIEndpointStandby_To_v1beta2_APIEndpointStandby(a.(*kubeone.APIEndpointStandby), b.(*APIEndpointStandby), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*APIServerConfig)(nil), (*kubeone.APIServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_APIServerConfig_To_kubeone_APIServerConfig(a.(*APIServerConfig), b.(*kubeone.APIServerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.APIServerConfig)(nil), (*APIServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_APIServerConfig_To_v1beta2_APIServerConfig(a.(*kubeone.APIServerConfig), b.(*APIServerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AWSSpec)(nil), (*kubeone.AWSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_AWSSpec_To_kubeone_AWSSpec(a.(*AWSSpec), b.(*kubeone.AWSSpec), scope)
 	}); err != nil {
@@ -98,6 +128,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BindAddresses)(nil), (*kubeone.BindAddresses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_BindAddresses_To_kubeone_BindAddresses(a.(*BindAddresses), b.(*kubeone.BindAddresses), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.BindAddresses)(nil), (*BindAddresses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_BindAddresses_To_v1beta2_BindAddresses(a.(*kubeone.BindAddresses), b.(*BindAddresses), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BootstrapTokenConfig)(nil), (*kubeone.BootstrapTokenConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_BootstrapTokenConfig_To_kubeone_BootstrapTokenConfig(a.(*BootstrapTokenConfig), b.(*kubeone.BootstrapTokenConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.BootstrapTokenConfig)(nil), (*BootstrapTokenConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_BootstrapTokenConfig_To_v1beta2_BootstrapTokenConfig(a.(*kubeone.BootstrapTokenConfig), b.(*BootstrapTokenConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CAKeyPair)(nil), (*kubeone.CAKeyPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CAKeyPair_To_kubeone_CAKeyPair(a.(*CAKeyPair), b.(*kubeone.CAKeyPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.CAKeyPair)(nil), (*CAKeyPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CAKeyPair_To_v1beta2_CAKeyPair(a.(*kubeone.CAKeyPair), b.(*CAKeyPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CCMConfig)(nil), (*kubeone.CCMConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CCMConfig_To_kubeone_CCMConfig(a.(*CCMConfig), b.(*kubeone.CCMConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.CCMConfig)(nil), (*CCMConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CCMConfig_To_v1beta2_CCMConfig(a.(*kubeone.CCMConfig), b.(*CCMConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CNI)(nil), (*kubeone.CNI)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_CNI_To_kubeone_CNI(a.(*CNI), b.(*kubeone.CNI), scope)
 	}); err != nil {
@@ -108,6 +178,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CSISnapshotControllerConfig)(nil), (*kubeone.CSISnapshotControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CSISnapshotControllerConfig_To_kubeone_CSISnapshotControllerConfig(a.(*CSISnapshotControllerConfig), b.(*kubeone.CSISnapshotControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.CSISnapshotControllerConfig)(nil), (*CSISnapshotControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CSISnapshotControllerConfig_To_v1beta2_CSISnapshotControllerConfig(a.(*kubeone.CSISnapshotControllerConfig), b.(*CSISnapshotControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CanalSpec)(nil), (*kubeone.CanalSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_CanalSpec_To_kubeone_CanalSpec(a.(*CanalSpec), b.(*kubeone.CanalSpec), scope)
 	}); err != nil {
@@ -118,6 +198,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateAuthority)(nil), (*kubeone.CertificateAuthority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CertificateAuthority_To_kubeone_CertificateAuthority(a.(*CertificateAuthority), b.(*kubeone.CertificateAuthority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.CertificateAuthority)(nil), (*CertificateAuthority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CertificateAuthority_To_v1beta2_CertificateAuthority(a.(*kubeone.CertificateAuthority), b.(*CertificateAuthority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CgroupsConfig)(nil), (*kubeone.CgroupsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CgroupsConfig_To_kubeone_CgroupsConfig(a.(*CgroupsConfig), b.(*kubeone.CgroupsConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.CgroupsConfig)(nil), (*CgroupsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CgroupsConfig_To_v1beta2_CgroupsConfig(a.(*kubeone.CgroupsConfig), b.(*CgroupsConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CiliumSpec)(nil), (*kubeone.CiliumSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_CiliumSpec_To_kubeone_CiliumSpec(a.(*CiliumSpec), b.(*kubeone.CiliumSpec), scope)
 	}); err != nil {
@@ -128,6 +228,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudNetworkConfig)(nil), (*kubeone.CloudNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CloudNetworkConfig_To_kubeone_CloudNetworkConfig(a.(*CloudNetworkConfig), b.(*kubeone.CloudNetworkConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.CloudNetworkConfig)(nil), (*CloudNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CloudNetworkConfig_To_v1beta2_CloudNetworkConfig(a.(*kubeone.CloudNetworkConfig), b.(*CloudNetworkConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudProviderSpec)(nil), (*kubeone.CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_CloudProviderSpec_To_kubeone_CloudProviderSpec(a.(*CloudProviderSpec), b.(*kubeone.CloudProviderSpec), scope)
 	}); err != nil {
@@ -198,6 +308,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRuntime)(nil), (*kubeone.ContainerdRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ContainerdRuntime_To_kubeone_ContainerdRuntime(a.(*ContainerdRuntime), b.(*kubeone.ContainerdRuntime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerdRuntime)(nil), (*ContainerdRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ContainerdRuntime_To_v1beta2_ContainerdRuntime(a.(*kubeone.ContainerdRuntime), b.(*ContainerdRuntime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRuntimeClass)(nil), (*kubeone.ContainerdRuntimeClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ContainerdRuntimeClass_To_kubeone_ContainerdRuntimeClass(a.(*ContainerdRuntimeClass), b.(*kubeone.ContainerdRuntimeClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerdRuntimeClass)(nil), (*ContainerdRuntimeClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ContainerdRuntimeClass_To_v1beta2_ContainerdRuntimeClass(a.(*kubeone.ContainerdRuntimeClass), b.(*ContainerdRuntimeClass), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ContainerdTLSConfig)(nil), (*kubeone.ContainerdTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_ContainerdTLSConfig_To_kubeone_ContainerdTLSConfig(a.(*ContainerdTLSConfig), b.(*kubeone.ContainerdTLSConfig), scope)
 	}); err != nil {
@@ -208,6 +338,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ControlPlaneComponents)(nil), (*kubeone.ControlPlaneComponents)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(a.(*ControlPlaneComponents), b.(*kubeone.ControlPlaneComponents), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ControlPlaneComponents)(nil), (*ControlPlaneComponents)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ControlPlaneComponents_To_v1beta2_ControlPlaneComponents(a.(*kubeone.ControlPlaneComponents), b.(*ControlPlaneComponents), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*kubeone.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*kubeone.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -218,6 +358,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ControllerManagerConfig)(nil), (*kubeone.ControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ControllerManagerConfig_To_kubeone_ControllerManagerConfig(a.(*ControllerManagerConfig), b.(*kubeone.ControllerManagerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ControllerManagerConfig)(nil), (*ControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ControllerManagerConfig_To_v1beta2_ControllerManagerConfig(a.(*kubeone.ControllerManagerConfig), b.(*ControllerManagerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSConfig)(nil), (*kubeone.DNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_DNSConfig_To_kubeone_DNSConfig(a.(*DNSConfig), b.(*kubeone.DNSConfig), scope)
 	}); err != nil {
@@ -228,6 +378,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DataVolumeConfig)(nil), (*kubeone.DataVolumeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_DataVolumeConfig_To_kubeone_DataVolumeConfig(a.(*DataVolumeConfig), b.(*kubeone.DataVolumeConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.DataVolumeConfig)(nil), (*DataVolumeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DataVolumeConfig_To_v1beta2_DataVolumeConfig(a.(*kubeone.DataVolumeConfig), b.(*DataVolumeConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DigitalOceanSpec)(nil), (*kubeone.DigitalOceanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_DigitalOceanSpec_To_kubeone_DigitalOceanSpec(a.(*DigitalOceanSpec), b.(*kubeone.DigitalOceanSpec), scope)
 	}); err != nil {
@@ -238,6 +398,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DiskConfig)(nil), (*kubeone.DiskConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_DiskConfig_To_kubeone_DiskConfig(a.(*DiskConfig), b.(*kubeone.DiskConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.DiskConfig)(nil), (*DiskConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DiskConfig_To_v1beta2_DiskConfig(a.(*kubeone.DiskConfig), b.(*DiskConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DynamicAuditLog)(nil), (*kubeone.DynamicAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_DynamicAuditLog_To_kubeone_DynamicAuditLog(a.(*DynamicAuditLog), b.(*kubeone.DynamicAuditLog), scope)
 	}); err != nil {
@@ -248,6 +418,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DynamicWorkerAutoscaler)(nil), (*kubeone.DynamicWorkerAutoscaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_DynamicWorkerAutoscaler_To_kubeone_DynamicWorkerAutoscaler(a.(*DynamicWorkerAutoscaler), b.(*kubeone.DynamicWorkerAutoscaler), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.DynamicWorkerAutoscaler)(nil), (*DynamicWorkerAutoscaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DynamicWorkerAutoscaler_To_v1beta2_DynamicWorkerAutoscaler(a.(*kubeone.DynamicWorkerAutoscaler), b.(*DynamicWorkerAutoscaler), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DynamicWorkerConfig)(nil), (*kubeone.DynamicWorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_DynamicWorkerConfig_To_kubeone_DynamicWorkerConfig(a.(*DynamicWorkerConfig), b.(*kubeone.DynamicWorkerConfig), scope)
 	}); err != nil {
@@ -278,6 +458,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EtcdBackup)(nil), (*kubeone.EtcdBackup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdBackup_To_kubeone_EtcdBackup(a.(*EtcdBackup), b.(*kubeone.EtcdBackup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EtcdBackup)(nil), (*EtcdBackup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EtcdBackup_To_v1beta2_EtcdBackup(a.(*kubeone.EtcdBackup), b.(*EtcdBackup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdMetrics)(nil), (*kubeone.EtcdMetrics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdMetrics_To_kubeone_EtcdMetrics(a.(*EtcdMetrics), b.(*kubeone.EtcdMetrics), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EtcdMetrics)(nil), (*EtcdMetrics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EtcdMetrics_To_v1beta2_EtcdMetrics(a.(*kubeone.EtcdMetrics), b.(*EtcdMetrics), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExternalCNISpec)(nil), (*kubeone.ExternalCNISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_ExternalCNISpec_To_kubeone_ExternalCNISpec(a.(*ExternalCNISpec), b.(*kubeone.ExternalCNISpec), scope)
 	}); err != nil {
@@ -298,6 +498,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FirewallConfig)(nil), (*kubeone.FirewallConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_FirewallConfig_To_kubeone_FirewallConfig(a.(*FirewallConfig), b.(*kubeone.FirewallConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.FirewallConfig)(nil), (*FirewallConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_FirewallConfig_To_v1beta2_FirewallConfig(a.(*kubeone.FirewallConfig), b.(*FirewallConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FirewallRule)(nil), (*kubeone.FirewallRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_FirewallRule_To_kubeone_FirewallRule(a.(*FirewallRule), b.(*kubeone.FirewallRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.FirewallRule)(nil), (*FirewallRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_FirewallRule_To_v1beta2_FirewallRule(a.(*kubeone.FirewallRule), b.(*FirewallRule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GCESpec)(nil), (*kubeone.GCESpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_GCESpec_To_kubeone_GCESpec(a.(*GCESpec), b.(*kubeone.GCESpec), scope)
 	}); err != nil {
@@ -383,6 +603,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KubeletServingCertRotation)(nil), (*kubeone.KubeletServingCertRotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_KubeletServingCertRotation_To_kubeone_KubeletServingCertRotation(a.(*KubeletServingCertRotation), b.(*kubeone.KubeletServingCertRotation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubeletServingCertRotation)(nil), (*KubeletServingCertRotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubeletServingCertRotation_To_v1beta2_KubeletServingCertRotation(a.(*kubeone.KubeletServingCertRotation), b.(*KubeletServingCertRotation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoggingConfig)(nil), (*kubeone.LoggingConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_LoggingConfig_To_kubeone_LoggingConfig(a.(*LoggingConfig), b.(*kubeone.LoggingConfig), scope)
 	}); err != nil {
@@ -413,6 +643,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceConfig)(nil), (*kubeone.NamespaceConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_NamespaceConfig_To_kubeone_NamespaceConfig(a.(*NamespaceConfig), b.(*kubeone.NamespaceConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NamespaceConfig)(nil), (*NamespaceConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NamespaceConfig_To_v1beta2_NamespaceConfig(a.(*kubeone.NamespaceConfig), b.(*NamespaceConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceTolerations)(nil), (*kubeone.NamespaceTolerations)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_NamespaceTolerations_To_kubeone_NamespaceTolerations(a.(*NamespaceTolerations), b.(*kubeone.NamespaceTolerations), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NamespaceTolerations)(nil), (*NamespaceTolerations)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NamespaceTolerations_To_v1beta2_NamespaceTolerations(a.(*kubeone.NamespaceTolerations), b.(*NamespaceTolerations), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeCIDRMaskSize)(nil), (*kubeone.NodeCIDRMaskSize)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_NodeCIDRMaskSize_To_kubeone_NodeCIDRMaskSize(a.(*NodeCIDRMaskSize), b.(*kubeone.NodeCIDRMaskSize), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NodeCIDRMaskSize)(nil), (*NodeCIDRMaskSize)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NodeCIDRMaskSize_To_v1beta2_NodeCIDRMaskSize(a.(*kubeone.NodeCIDRMaskSize), b.(*NodeCIDRMaskSize), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeConnectivityCheck)(nil), (*kubeone.NodeConnectivityCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_NodeConnectivityCheck_To_kubeone_NodeConnectivityCheck(a.(*NodeConnectivityCheck), b.(*kubeone.NodeConnectivityCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NodeConnectivityCheck)(nil), (*NodeConnectivityCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NodeConnectivityCheck_To_v1beta2_NodeConnectivityCheck(a.(*kubeone.NodeConnectivityCheck), b.(*NodeConnectivityCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NoneSpec)(nil), (*kubeone.NoneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_NoneSpec_To_kubeone_NoneSpec(a.(*NoneSpec), b.(*kubeone.NoneSpec), scope)
 	}); err != nil {
@@ -463,6 +733,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PodDisruptionBudgets)(nil), (*kubeone.PodDisruptionBudgets)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_PodDisruptionBudgets_To_kubeone_PodDisruptionBudgets(a.(*PodDisruptionBudgets), b.(*kubeone.PodDisruptionBudgets), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodDisruptionBudgets)(nil), (*PodDisruptionBudgets)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodDisruptionBudgets_To_v1beta2_PodDisruptionBudgets(a.(*kubeone.PodDisruptionBudgets), b.(*PodDisruptionBudgets), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PodNodeSelector)(nil), (*kubeone.PodNodeSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_PodNodeSelector_To_kubeone_PodNodeSelector(a.(*PodNodeSelector), b.(*kubeone.PodNodeSelector), scope)
 	}); err != nil {
@@ -493,6 +773,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PodTolerationRestriction)(nil), (*kubeone.PodTolerationRestriction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_PodTolerationRestriction_To_kubeone_PodTolerationRestriction(a.(*PodTolerationRestriction), b.(*kubeone.PodTolerationRestriction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodTolerationRestriction)(nil), (*PodTolerationRestriction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodTolerationRestriction_To_v1beta2_PodTolerationRestriction(a.(*kubeone.PodTolerationRestriction), b.(*PodTolerationRestriction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PostApplyValidation)(nil), (*kubeone.PostApplyValidation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_PostApplyValidation_To_kubeone_PostApplyValidation(a.(*PostApplyValidation), b.(*kubeone.PostApplyValidation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PostApplyValidation)(nil), (*PostApplyValidation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PostApplyValidation_To_v1beta2_PostApplyValidation(a.(*kubeone.PostApplyValidation), b.(*PostApplyValidation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PostApplyValidationWebhook)(nil), (*kubeone.PostApplyValidationWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_PostApplyValidationWebhook_To_kubeone_PostApplyValidationWebhook(a.(*PostApplyValidationWebhook), b.(*kubeone.PostApplyValidationWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PostApplyValidationWebhook)(nil), (*PostApplyValidationWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PostApplyValidationWebhook_To_v1beta2_PostApplyValidationWebhook(a.(*kubeone.PostApplyValidationWebhook), b.(*PostApplyValidationWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrometheusAdapter)(nil), (*kubeone.PrometheusAdapter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_PrometheusAdapter_To_kubeone_PrometheusAdapter(a.(*PrometheusAdapter), b.(*kubeone.PrometheusAdapter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PrometheusAdapter)(nil), (*PrometheusAdapter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PrometheusAdapter_To_v1beta2_PrometheusAdapter(a.(*kubeone.PrometheusAdapter), b.(*PrometheusAdapter), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ProviderSpec)(nil), (*kubeone.ProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_ProviderSpec_To_kubeone_ProviderSpec(a.(*ProviderSpec), b.(*kubeone.ProviderSpec), scope)
 	}); err != nil {
@@ -533,6 +853,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SchedulerConfig)(nil), (*kubeone.SchedulerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_SchedulerConfig_To_kubeone_SchedulerConfig(a.(*SchedulerConfig), b.(*kubeone.SchedulerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.SchedulerConfig)(nil), (*SchedulerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_SchedulerConfig_To_v1beta2_SchedulerConfig(a.(*kubeone.SchedulerConfig), b.(*SchedulerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StaticAuditLog)(nil), (*kubeone.StaticAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_StaticAuditLog_To_kubeone_StaticAuditLog(a.(*StaticAuditLog), b.(*kubeone.StaticAuditLog), scope)
 	}); err != nil {
@@ -553,6 +883,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StaticAuth)(nil), (*kubeone.StaticAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_StaticAuth_To_kubeone_StaticAuth(a.(*StaticAuth), b.(*kubeone.StaticAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.StaticAuth)(nil), (*StaticAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_StaticAuth_To_v1beta2_StaticAuth(a.(*kubeone.StaticAuth), b.(*StaticAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StaticWorkersConfig)(nil), (*kubeone.StaticWorkersConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(a.(*StaticWorkersConfig), b.(*kubeone.StaticWorkersConfig), scope)
 	}); err != nil {
@@ -613,6 +953,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WebhookCABundleInjection)(nil), (*kubeone.WebhookCABundleInjection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_WebhookCABundleInjection_To_kubeone_WebhookCABundleInjection(a.(*WebhookCABundleInjection), b.(*kubeone.WebhookCABundleInjection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.WebhookCABundleInjection)(nil), (*WebhookCABundleInjection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_WebhookCABundleInjection_To_v1beta2_WebhookCABundleInjection(a.(*kubeone.WebhookCABundleInjection), b.(*WebhookCABundleInjection), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*kubeone.KubeOneCluster)(nil), (*KubeOneCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_kubeone_KubeOneCluster_To_v1beta2_KubeOneCluster(a.(*kubeone.KubeOneCluster), b.(*KubeOneCluster), scope)
 	}); err != nil {
@@ -625,6 +975,10 @@ func autoConvert_v1beta2_APIEndpoint_To_kubeone_APIEndpoint(in *APIEndpoint, out
 	out.Host = in.Host
 	out.Port = in.Port
 	out.AlternativeNames = *(*[]string)(unsafe.Pointer(&in.AlternativeNames))
+	out.InternalHost = in.InternalHost
+	out.InternalPort = in.InternalPort
+	out.StandbyEndpoints = *(*[]kubeone.APIEndpointStandby)(unsafe.Pointer(&in.StandbyEndpoints))
+	out.HealthCheck = (*kubeone.APIEndpointHealthCheck)(unsafe.Pointer(in.HealthCheck))
 	return nil
 }
 
@@ -637,6 +991,10 @@ func autoConvert_kubeone_APIEndpoint_To_v1beta2_APIEndpoint(in *kubeone.APIEndpo
 	out.Host = in.Host
 	out.Port = in.Port
 	out.AlternativeNames = *(*[]string)(unsafe.Pointer(&in.AlternativeNames))
+	out.InternalHost = in.InternalHost
+	out.InternalPort = in.InternalPort
+	out.StandbyEndpoints = *(*[]APIEndpointStandby)(unsafe.Pointer(&in.StandbyEndpoints))
+	out.HealthCheck = (*APIEndpointHealthCheck)(unsafe.Pointer(in.HealthCheck))
 	return nil
 }
 
@@ -645,6 +1003,76 @@ func Convert_kubeone_APIEndpoint_To_v1beta2_APIEndpoint(in *kubeone.APIEndpoint,
 	return autoConvert_kubeone_APIEndpoint_To_v1beta2_APIEndpoint(in, out, s)
 }
 
+func autoConvert_v1beta2_APIEndpointHealthCheck_To_kubeone_APIEndpointHealthCheck(in *APIEndpointHealthCheck, out *kubeone.APIEndpointHealthCheck, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1beta2_APIEndpointHealthCheck_To_kubeone_APIEndpointHealthCheck is an autogenerated conversion function.
+func Convert_v1beta2_APIEndpointHealthCheck_To_kubeone_APIEndpointHealthCheck(in *APIEndpointHealthCheck, out *kubeone.APIEndpointHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1beta2_APIEndpointHealthCheck_To_kubeone_APIEndpointHealthCheck(in, out, s)
+}
+
+func autoConvert_kubeone_APIEndpointHealthCheck_To_v1beta2_APIEndpointHealthCheck(in *kubeone.APIEndpointHealthCheck, out *APIEndpointHealthCheck, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kubeone_APIEndpointHealthCheck_To_v1beta2_APIEndpointHealthCheck is an autogenerated conversion function.
+func Convert_kubeone_APIEndpointHealthCheck_To_v1beta2_APIEndpointHealthCheck(in *kubeone.APIEndpointHealthCheck, out *APIEndpointHealthCheck, s conversion.Scope) error {
+	return autoConvert_kubeone_APIEndpointHealthCheck_To_v1beta2_APIEndpointHealthCheck(in, out, s)
+}
+
+func autoConvert_v1beta2_APIEndpointStandby_To_kubeone_APIEndpointStandby(in *APIEndpointStandby, out *kubeone.APIEndpointStandby, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1beta2_APIEndpointStandby_To_kubeone_APIEndpointStandby is an autogenerated conversion function.
+func Convert_v1beta2_APIEndpointStandby_To_kubeone_APIEndpointStandby(in *APIEndpointStandby, out *kubeone.APIEndpointStandby, s conversion.Scope) error {
+	return autoConvert_v1beta2_APIEndpointStandby_To_kubeone_APIEndpointStandby(in, out, s)
+}
+
+func autoConvert_kubeone_APIEndpointStandby_To_v1beta2_APIEndpointStandby(in *kubeone.APIEndpointStandby, out *APIEndpointStandby, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_kubeone_APIEndpointStandby_To_v1beta2_APIEndpointStandby is an autogenerated conversion function.
+func Convert_kubeone_APIEndpointStandby_To_v1beta2_APIEndpointStandby(in *kubeone.APIEndpointStandby, out *APIEndpointStandby, s conversion.Scope) error {
+	return autoConvert_kubeone_APIEndpointStandby_To_v1beta2_APIEndpointStandby(in, out, s)
+}
+
+func autoConvert_v1beta2_APIServerConfig_To_kubeone_APIServerConfig(in *APIServerConfig, out *kubeone.APIServerConfig, s conversion.Scope) error {
+	out.EndpointReconcilerType = in.EndpointReconcilerType
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.ServiceAccountMaxTokenExpiration = in.ServiceAccountMaxTokenExpiration
+	out.ServiceAccountExtendTokenExpiration = (*bool)(unsafe.Pointer(in.ServiceAccountExtendTokenExpiration))
+	return nil
+}
+
+// Convert_v1beta2_APIServerConfig_To_kubeone_APIServerConfig is an autogenerated conversion function.
+func Convert_v1beta2_APIServerConfig_To_kubeone_APIServerConfig(in *APIServerConfig, out *kubeone.APIServerConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_APIServerConfig_To_kubeone_APIServerConfig(in, out, s)
+}
+
+func autoConvert_kubeone_APIServerConfig_To_v1beta2_APIServerConfig(in *kubeone.APIServerConfig, out *APIServerConfig, s conversion.Scope) error {
+	out.EndpointReconcilerType = in.EndpointReconcilerType
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.ServiceAccountMaxTokenExpiration = in.ServiceAccountMaxTokenExpiration
+	out.ServiceAccountExtendTokenExpiration = (*bool)(unsafe.Pointer(in.ServiceAccountExtendTokenExpiration))
+	return nil
+}
+
+// Convert_kubeone_APIServerConfig_To_v1beta2_APIServerConfig is an autogenerated conversion function.
+func Convert_kubeone_APIServerConfig_To_v1beta2_APIServerConfig(in *kubeone.APIServerConfig, out *APIServerConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_APIServerConfig_To_v1beta2_APIServerConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_AWSSpec_To_kubeone_AWSSpec(in *AWSSpec, out *kubeone.AWSSpec, s conversion.Scope) error {
 	return nil
 }
@@ -667,6 +1095,8 @@ func autoConvert_v1beta2_Addon_To_kubeone_Addon(in *Addon, out *kubeone.Addon, s
 	out.Name = in.Name
 	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	out.Delete = in.Delete
+	out.EnabledWhen = in.EnabledWhen
+	out.ForceConflicts = in.ForceConflicts
 	return nil
 }
 
@@ -679,6 +1109,8 @@ func autoConvert_kubeone_Addon_To_v1beta2_Addon(in *kubeone.Addon, out *Addon, s
 	out.Name = in.Name
 	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	out.Delete = in.Delete
+	out.EnabledWhen = in.EnabledWhen
+	out.ForceConflicts = in.ForceConflicts
 	return nil
 }
 
@@ -691,6 +1123,7 @@ func autoConvert_v1beta2_Addons_To_kubeone_Addons(in *Addons, out *kubeone.Addon
 	out.Enable = in.Enable
 	out.Path = in.Path
 	out.GlobalParams = *(*map[string]string)(unsafe.Pointer(&in.GlobalParams))
+	out.FieldManager = in.FieldManager
 	out.Addons = *(*[]kubeone.Addon)(unsafe.Pointer(&in.Addons))
 	return nil
 }
@@ -704,6 +1137,7 @@ func autoConvert_kubeone_Addons_To_v1beta2_Addons(in *kubeone.Addons, out *Addon
 	out.Enable = in.Enable
 	out.Path = in.Path
 	out.GlobalParams = *(*map[string]string)(unsafe.Pointer(&in.GlobalParams))
+	out.FieldManager = in.FieldManager
 	out.Addons = *(*[]Addon)(unsafe.Pointer(&in.Addons))
 	return nil
 }
@@ -751,6 +1185,98 @@ func Convert_kubeone_BinaryAsset_To_v1beta2_BinaryAsset(in *kubeone.BinaryAsset,
 	return autoConvert_kubeone_BinaryAsset_To_v1beta2_BinaryAsset(in, out, s)
 }
 
+func autoConvert_v1beta2_BindAddresses_To_kubeone_BindAddresses(in *BindAddresses, out *kubeone.BindAddresses, s conversion.Scope) error {
+	out.APIServer = in.APIServer
+	out.Etcd = in.Etcd
+	out.ControllerManager = in.ControllerManager
+	out.Scheduler = in.Scheduler
+	return nil
+}
+
+// Convert_v1beta2_BindAddresses_To_kubeone_BindAddresses is an autogenerated conversion function.
+func Convert_v1beta2_BindAddresses_To_kubeone_BindAddresses(in *BindAddresses, out *kubeone.BindAddresses, s conversion.Scope) error {
+	return autoConvert_v1beta2_BindAddresses_To_kubeone_BindAddresses(in, out, s)
+}
+
+func autoConvert_kubeone_BindAddresses_To_v1beta2_BindAddresses(in *kubeone.BindAddresses, out *BindAddresses, s conversion.Scope) error {
+	out.APIServer = in.APIServer
+	out.Etcd = in.Etcd
+	out.ControllerManager = in.ControllerManager
+	out.Scheduler = in.Scheduler
+	return nil
+}
+
+// Convert_kubeone_BindAddresses_To_v1beta2_BindAddresses is an autogenerated conversion function.
+func Convert_kubeone_BindAddresses_To_v1beta2_BindAddresses(in *kubeone.BindAddresses, out *BindAddresses, s conversion.Scope) error {
+	return autoConvert_kubeone_BindAddresses_To_v1beta2_BindAddresses(in, out, s)
+}
+
+func autoConvert_v1beta2_BootstrapTokenConfig_To_kubeone_BootstrapTokenConfig(in *BootstrapTokenConfig, out *kubeone.BootstrapTokenConfig, s conversion.Scope) error {
+	out.TTL = in.TTL
+	return nil
+}
+
+// Convert_v1beta2_BootstrapTokenConfig_To_kubeone_BootstrapTokenConfig is an autogenerated conversion function.
+func Convert_v1beta2_BootstrapTokenConfig_To_kubeone_BootstrapTokenConfig(in *BootstrapTokenConfig, out *kubeone.BootstrapTokenConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_BootstrapTokenConfig_To_kubeone_BootstrapTokenConfig(in, out, s)
+}
+
+func autoConvert_kubeone_BootstrapTokenConfig_To_v1beta2_BootstrapTokenConfig(in *kubeone.BootstrapTokenConfig, out *BootstrapTokenConfig, s conversion.Scope) error {
+	out.TTL = in.TTL
+	return nil
+}
+
+// Convert_kubeone_BootstrapTokenConfig_To_v1beta2_BootstrapTokenConfig is an autogenerated conversion function.
+func Convert_kubeone_BootstrapTokenConfig_To_v1beta2_BootstrapTokenConfig(in *kubeone.BootstrapTokenConfig, out *BootstrapTokenConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_BootstrapTokenConfig_To_v1beta2_BootstrapTokenConfig(in, out, s)
+}
+
+func autoConvert_v1beta2_CAKeyPair_To_kubeone_CAKeyPair(in *CAKeyPair, out *kubeone.CAKeyPair, s conversion.Scope) error {
+	out.CertFilePath = in.CertFilePath
+	out.KeyFilePath = in.KeyFilePath
+	return nil
+}
+
+// Convert_v1beta2_CAKeyPair_To_kubeone_CAKeyPair is an autogenerated conversion function.
+func Convert_v1beta2_CAKeyPair_To_kubeone_CAKeyPair(in *CAKeyPair, out *kubeone.CAKeyPair, s conversion.Scope) error {
+	return autoConvert_v1beta2_CAKeyPair_To_kubeone_CAKeyPair(in, out, s)
+}
+
+func autoConvert_kubeone_CAKeyPair_To_v1beta2_CAKeyPair(in *kubeone.CAKeyPair, out *CAKeyPair, s conversion.Scope) error {
+	out.CertFilePath = in.CertFilePath
+	out.KeyFilePath = in.KeyFilePath
+	return nil
+}
+
+// Convert_kubeone_CAKeyPair_To_v1beta2_CAKeyPair is an autogenerated conversion function.
+func Convert_kubeone_CAKeyPair_To_v1beta2_CAKeyPair(in *kubeone.CAKeyPair, out *CAKeyPair, s conversion.Scope) error {
+	return autoConvert_kubeone_CAKeyPair_To_v1beta2_CAKeyPair(in, out, s)
+}
+
+func autoConvert_v1beta2_CCMConfig_To_kubeone_CCMConfig(in *CCMConfig, out *kubeone.CCMConfig, s conversion.Scope) error {
+	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	return nil
+}
+
+// Convert_v1beta2_CCMConfig_To_kubeone_CCMConfig is an autogenerated conversion function.
+func Convert_v1beta2_CCMConfig_To_kubeone_CCMConfig(in *CCMConfig, out *kubeone.CCMConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_CCMConfig_To_kubeone_CCMConfig(in, out, s)
+}
+
+func autoConvert_kubeone_CCMConfig_To_v1beta2_CCMConfig(in *kubeone.CCMConfig, out *CCMConfig, s conversion.Scope) error {
+	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	return nil
+}
+
+// Convert_kubeone_CCMConfig_To_v1beta2_CCMConfig is an autogenerated conversion function.
+func Convert_kubeone_CCMConfig_To_v1beta2_CCMConfig(in *kubeone.CCMConfig, out *CCMConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_CCMConfig_To_v1beta2_CCMConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_CNI_To_kubeone_CNI(in *CNI, out *kubeone.CNI, s conversion.Scope) error {
 	out.Canal = (*kubeone.CanalSpec)(unsafe.Pointer(in.Canal))
 	out.Cilium = (*kubeone.CiliumSpec)(unsafe.Pointer(in.Cilium))
@@ -777,6 +1303,26 @@ func Convert_kubeone_CNI_To_v1beta2_CNI(in *kubeone.CNI, out *CNI, s conversion.
 	return autoConvert_kubeone_CNI_To_v1beta2_CNI(in, out, s)
 }
 
+func autoConvert_v1beta2_CSISnapshotControllerConfig_To_kubeone_CSISnapshotControllerConfig(in *CSISnapshotControllerConfig, out *kubeone.CSISnapshotControllerConfig, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta2_CSISnapshotControllerConfig_To_kubeone_CSISnapshotControllerConfig is an autogenerated conversion function.
+func Convert_v1beta2_CSISnapshotControllerConfig_To_kubeone_CSISnapshotControllerConfig(in *CSISnapshotControllerConfig, out *kubeone.CSISnapshotControllerConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_CSISnapshotControllerConfig_To_kubeone_CSISnapshotControllerConfig(in, out, s)
+}
+
+func autoConvert_kubeone_CSISnapshotControllerConfig_To_v1beta2_CSISnapshotControllerConfig(in *kubeone.CSISnapshotControllerConfig, out *CSISnapshotControllerConfig, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_CSISnapshotControllerConfig_To_v1beta2_CSISnapshotControllerConfig is an autogenerated conversion function.
+func Convert_kubeone_CSISnapshotControllerConfig_To_v1beta2_CSISnapshotControllerConfig(in *kubeone.CSISnapshotControllerConfig, out *CSISnapshotControllerConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_CSISnapshotControllerConfig_To_v1beta2_CSISnapshotControllerConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_CanalSpec_To_kubeone_CanalSpec(in *CanalSpec, out *kubeone.CanalSpec, s conversion.Scope) error {
 	out.MTU = in.MTU
 	return nil
@@ -797,6 +1343,52 @@ func Convert_kubeone_CanalSpec_To_v1beta2_CanalSpec(in *kubeone.CanalSpec, out *
 	return autoConvert_kubeone_CanalSpec_To_v1beta2_CanalSpec(in, out, s)
 }
 
+func autoConvert_v1beta2_CertificateAuthority_To_kubeone_CertificateAuthority(in *CertificateAuthority, out *kubeone.CertificateAuthority, s conversion.Scope) error {
+	out.Kubernetes = (*kubeone.CAKeyPair)(unsafe.Pointer(in.Kubernetes))
+	out.FrontProxy = (*kubeone.CAKeyPair)(unsafe.Pointer(in.FrontProxy))
+	out.Etcd = (*kubeone.CAKeyPair)(unsafe.Pointer(in.Etcd))
+	return nil
+}
+
+// Convert_v1beta2_CertificateAuthority_To_kubeone_CertificateAuthority is an autogenerated conversion function.
+func Convert_v1beta2_CertificateAuthority_To_kubeone_CertificateAuthority(in *CertificateAuthority, out *kubeone.CertificateAuthority, s conversion.Scope) error {
+	return autoConvert_v1beta2_CertificateAuthority_To_kubeone_CertificateAuthority(in, out, s)
+}
+
+func autoConvert_kubeone_CertificateAuthority_To_v1beta2_CertificateAuthority(in *kubeone.CertificateAuthority, out *CertificateAuthority, s conversion.Scope) error {
+	out.Kubernetes = (*CAKeyPair)(unsafe.Pointer(in.Kubernetes))
+	out.FrontProxy = (*CAKeyPair)(unsafe.Pointer(in.FrontProxy))
+	out.Etcd = (*CAKeyPair)(unsafe.Pointer(in.Etcd))
+	return nil
+}
+
+// Convert_kubeone_CertificateAuthority_To_v1beta2_CertificateAuthority is an autogenerated conversion function.
+func Convert_kubeone_CertificateAuthority_To_v1beta2_CertificateAuthority(in *kubeone.CertificateAuthority, out *CertificateAuthority, s conversion.Scope) error {
+	return autoConvert_kubeone_CertificateAuthority_To_v1beta2_CertificateAuthority(in, out, s)
+}
+
+func autoConvert_v1beta2_CgroupsConfig_To_kubeone_CgroupsConfig(in *CgroupsConfig, out *kubeone.CgroupsConfig, s conversion.Scope) error {
+	out.Version = in.Version
+	out.ConfigureKernelCommandLine = in.ConfigureKernelCommandLine
+	return nil
+}
+
+// Convert_v1beta2_CgroupsConfig_To_kubeone_CgroupsConfig is an autogenerated conversion function.
+func Convert_v1beta2_CgroupsConfig_To_kubeone_CgroupsConfig(in *CgroupsConfig, out *kubeone.CgroupsConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_CgroupsConfig_To_kubeone_CgroupsConfig(in, out, s)
+}
+
+func autoConvert_kubeone_CgroupsConfig_To_v1beta2_CgroupsConfig(in *kubeone.CgroupsConfig, out *CgroupsConfig, s conversion.Scope) error {
+	out.Version = in.Version
+	out.ConfigureKernelCommandLine = in.ConfigureKernelCommandLine
+	return nil
+}
+
+// Convert_kubeone_CgroupsConfig_To_v1beta2_CgroupsConfig is an autogenerated conversion function.
+func Convert_kubeone_CgroupsConfig_To_v1beta2_CgroupsConfig(in *kubeone.CgroupsConfig, out *CgroupsConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_CgroupsConfig_To_v1beta2_CgroupsConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_CiliumSpec_To_kubeone_CiliumSpec(in *CiliumSpec, out *kubeone.CiliumSpec, s conversion.Scope) error {
 	out.KubeProxyReplacement = kubeone.KubeProxyReplacementType(in.KubeProxyReplacement)
 	out.EnableHubble = in.EnableHubble
@@ -819,10 +1411,36 @@ func Convert_kubeone_CiliumSpec_To_v1beta2_CiliumSpec(in *kubeone.CiliumSpec, ou
 	return autoConvert_kubeone_CiliumSpec_To_v1beta2_CiliumSpec(in, out, s)
 }
 
+func autoConvert_v1beta2_CloudNetworkConfig_To_kubeone_CloudNetworkConfig(in *CloudNetworkConfig, out *kubeone.CloudNetworkConfig, s conversion.Scope) error {
+	out.SubnetID = in.SubnetID
+	out.SecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SecurityGroupIDs))
+	out.AssignPublicIP = (*bool)(unsafe.Pointer(in.AssignPublicIP))
+	return nil
+}
+
+// Convert_v1beta2_CloudNetworkConfig_To_kubeone_CloudNetworkConfig is an autogenerated conversion function.
+func Convert_v1beta2_CloudNetworkConfig_To_kubeone_CloudNetworkConfig(in *CloudNetworkConfig, out *kubeone.CloudNetworkConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_CloudNetworkConfig_To_kubeone_CloudNetworkConfig(in, out, s)
+}
+
+func autoConvert_kubeone_CloudNetworkConfig_To_v1beta2_CloudNetworkConfig(in *kubeone.CloudNetworkConfig, out *CloudNetworkConfig, s conversion.Scope) error {
+	out.SubnetID = in.SubnetID
+	out.SecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SecurityGroupIDs))
+	out.AssignPublicIP = (*bool)(unsafe.Pointer(in.AssignPublicIP))
+	return nil
+}
+
+// Convert_kubeone_CloudNetworkConfig_To_v1beta2_CloudNetworkConfig is an autogenerated conversion function.
+func Convert_kubeone_CloudNetworkConfig_To_v1beta2_CloudNetworkConfig(in *kubeone.CloudNetworkConfig, out *CloudNetworkConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_CloudNetworkConfig_To_v1beta2_CloudNetworkConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_CloudProviderSpec_To_kubeone_CloudProviderSpec(in *CloudProviderSpec, out *kubeone.CloudProviderSpec, s conversion.Scope) error {
 	out.External = in.External
 	out.CloudConfig = in.CloudConfig
 	out.CSIConfig = in.CSIConfig
+	out.CCM = (*kubeone.CCMConfig)(unsafe.Pointer(in.CCM))
+	out.CSISnapshotController = (*kubeone.CSISnapshotControllerConfig)(unsafe.Pointer(in.CSISnapshotController))
 	out.AWS = (*kubeone.AWSSpec)(unsafe.Pointer(in.AWS))
 	out.Azure = (*kubeone.AzureSpec)(unsafe.Pointer(in.Azure))
 	out.DigitalOcean = (*kubeone.DigitalOceanSpec)(unsafe.Pointer(in.DigitalOcean))
@@ -846,6 +1464,8 @@ func autoConvert_kubeone_CloudProviderSpec_To_v1beta2_CloudProviderSpec(in *kube
 	out.External = in.External
 	out.CloudConfig = in.CloudConfig
 	out.CSIConfig = in.CSIConfig
+	out.CCM = (*CCMConfig)(unsafe.Pointer(in.CCM))
+	out.CSISnapshotController = (*CSISnapshotControllerConfig)(unsafe.Pointer(in.CSISnapshotController))
 	out.AWS = (*AWSSpec)(unsafe.Pointer(in.AWS))
 	out.Azure = (*AzureSpec)(unsafe.Pointer(in.Azure))
 	out.DigitalOcean = (*DigitalOceanSpec)(unsafe.Pointer(in.DigitalOcean))
@@ -870,8 +1490,10 @@ func autoConvert_v1beta2_ClusterNetworkConfig_To_kubeone_ClusterNetworkConfig(in
 	out.ServiceSubnet = in.ServiceSubnet
 	out.ServiceDomainName = in.ServiceDomainName
 	out.NodePortRange = in.NodePortRange
+	out.NodeCIDRMaskSize = (*kubeone.NodeCIDRMaskSize)(unsafe.Pointer(in.NodeCIDRMaskSize))
 	out.CNI = (*kubeone.CNI)(unsafe.Pointer(in.CNI))
 	out.KubeProxy = (*kubeone.KubeProxyConfig)(unsafe.Pointer(in.KubeProxy))
+	out.NodeConnectivityCheck = (*kubeone.NodeConnectivityCheck)(unsafe.Pointer(in.NodeConnectivityCheck))
 	return nil
 }
 
@@ -885,8 +1507,10 @@ func autoConvert_kubeone_ClusterNetworkConfig_To_v1beta2_ClusterNetworkConfig(in
 	out.ServiceSubnet = in.ServiceSubnet
 	out.ServiceDomainName = in.ServiceDomainName
 	out.NodePortRange = in.NodePortRange
+	out.NodeCIDRMaskSize = (*NodeCIDRMaskSize)(unsafe.Pointer(in.NodeCIDRMaskSize))
 	out.CNI = (*CNI)(unsafe.Pointer(in.CNI))
 	out.KubeProxy = (*KubeProxyConfig)(unsafe.Pointer(in.KubeProxy))
+	out.NodeConnectivityCheck = (*NodeConnectivityCheck)(unsafe.Pointer(in.NodeConnectivityCheck))
 	return nil
 }
 
@@ -898,6 +1522,8 @@ func Convert_kubeone_ClusterNetworkConfig_To_v1beta2_ClusterNetworkConfig(in *ku
 func autoConvert_v1beta2_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(in *ContainerRuntimeConfig, out *kubeone.ContainerRuntimeConfig, s conversion.Scope) error {
 	out.Docker = (*kubeone.ContainerRuntimeDocker)(unsafe.Pointer(in.Docker))
 	out.Containerd = (*kubeone.ContainerRuntimeContainerd)(unsafe.Pointer(in.Containerd))
+	out.Cgroups = (*kubeone.CgroupsConfig)(unsafe.Pointer(in.Cgroups))
+	out.PreloadImages = *(*[]string)(unsafe.Pointer(&in.PreloadImages))
 	return nil
 }
 
@@ -909,6 +1535,8 @@ func Convert_v1beta2_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(in
 func autoConvert_kubeone_ContainerRuntimeConfig_To_v1beta2_ContainerRuntimeConfig(in *kubeone.ContainerRuntimeConfig, out *ContainerRuntimeConfig, s conversion.Scope) error {
 	out.Docker = (*ContainerRuntimeDocker)(unsafe.Pointer(in.Docker))
 	out.Containerd = (*ContainerRuntimeContainerd)(unsafe.Pointer(in.Containerd))
+	out.Cgroups = (*CgroupsConfig)(unsafe.Pointer(in.Cgroups))
+	out.PreloadImages = *(*[]string)(unsafe.Pointer(&in.PreloadImages))
 	return nil
 }
 
@@ -919,6 +1547,10 @@ func Convert_kubeone_ContainerRuntimeConfig_To_v1beta2_ContainerRuntimeConfig(in
 
 func autoConvert_v1beta2_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeContainerd(in *ContainerRuntimeContainerd, out *kubeone.ContainerRuntimeContainerd, s conversion.Scope) error {
 	out.Registries = *(*map[string]kubeone.ContainerdRegistry)(unsafe.Pointer(&in.Registries))
+	out.StreamServerAddress = in.StreamServerAddress
+	out.StreamServerPort = in.StreamServerPort
+	out.StreamIdleTimeout = in.StreamIdleTimeout
+	out.Runtimes = *(*map[string]kubeone.ContainerdRuntime)(unsafe.Pointer(&in.Runtimes))
 	return nil
 }
 
@@ -929,6 +1561,10 @@ func Convert_v1beta2_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeConta
 
 func autoConvert_kubeone_ContainerRuntimeContainerd_To_v1beta2_ContainerRuntimeContainerd(in *kubeone.ContainerRuntimeContainerd, out *ContainerRuntimeContainerd, s conversion.Scope) error {
 	out.Registries = *(*map[string]ContainerdRegistry)(unsafe.Pointer(&in.Registries))
+	out.StreamServerAddress = in.StreamServerAddress
+	out.StreamServerPort = in.StreamServerPort
+	out.StreamIdleTimeout = in.StreamIdleTimeout
+	out.Runtimes = *(*map[string]ContainerdRuntime)(unsafe.Pointer(&in.Runtimes))
 	return nil
 }
 
@@ -939,6 +1575,8 @@ func Convert_kubeone_ContainerRuntimeContainerd_To_v1beta2_ContainerRuntimeConta
 
 func autoConvert_v1beta2_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in *ContainerRuntimeDocker, out *kubeone.ContainerRuntimeDocker, s conversion.Scope) error {
 	out.RegistryMirrors = *(*[]string)(unsafe.Pointer(&in.RegistryMirrors))
+	out.LogDriver = in.LogDriver
+	out.LogOpts = *(*map[string]string)(unsafe.Pointer(&in.LogOpts))
 	return nil
 }
 
@@ -949,6 +1587,8 @@ func Convert_v1beta2_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in
 
 func autoConvert_kubeone_ContainerRuntimeDocker_To_v1beta2_ContainerRuntimeDocker(in *kubeone.ContainerRuntimeDocker, out *ContainerRuntimeDocker, s conversion.Scope) error {
 	out.RegistryMirrors = *(*[]string)(unsafe.Pointer(&in.RegistryMirrors))
+	out.LogDriver = in.LogDriver
+	out.LogOpts = *(*map[string]string)(unsafe.Pointer(&in.LogOpts))
 	return nil
 }
 
@@ -1002,9 +1642,53 @@ func autoConvert_kubeone_ContainerdRegistryAuthConfig_To_v1beta2_ContainerdRegis
 	return nil
 }
 
-// Convert_kubeone_ContainerdRegistryAuthConfig_To_v1beta2_ContainerdRegistryAuthConfig is an autogenerated conversion function.
-func Convert_kubeone_ContainerdRegistryAuthConfig_To_v1beta2_ContainerdRegistryAuthConfig(in *kubeone.ContainerdRegistryAuthConfig, out *ContainerdRegistryAuthConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_ContainerdRegistryAuthConfig_To_v1beta2_ContainerdRegistryAuthConfig(in, out, s)
+// Convert_kubeone_ContainerdRegistryAuthConfig_To_v1beta2_ContainerdRegistryAuthConfig is an autogenerated conversion function.
+func Convert_kubeone_ContainerdRegistryAuthConfig_To_v1beta2_ContainerdRegistryAuthConfig(in *kubeone.ContainerdRegistryAuthConfig, out *ContainerdRegistryAuthConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_ContainerdRegistryAuthConfig_To_v1beta2_ContainerdRegistryAuthConfig(in, out, s)
+}
+
+func autoConvert_v1beta2_ContainerdRuntime_To_kubeone_ContainerdRuntime(in *ContainerdRuntime, out *kubeone.ContainerdRuntime, s conversion.Scope) error {
+	out.RuntimeType = in.RuntimeType
+	out.BinaryName = in.BinaryName
+	out.RuntimeClass = (*kubeone.ContainerdRuntimeClass)(unsafe.Pointer(in.RuntimeClass))
+	return nil
+}
+
+// Convert_v1beta2_ContainerdRuntime_To_kubeone_ContainerdRuntime is an autogenerated conversion function.
+func Convert_v1beta2_ContainerdRuntime_To_kubeone_ContainerdRuntime(in *ContainerdRuntime, out *kubeone.ContainerdRuntime, s conversion.Scope) error {
+	return autoConvert_v1beta2_ContainerdRuntime_To_kubeone_ContainerdRuntime(in, out, s)
+}
+
+func autoConvert_kubeone_ContainerdRuntime_To_v1beta2_ContainerdRuntime(in *kubeone.ContainerdRuntime, out *ContainerdRuntime, s conversion.Scope) error {
+	out.RuntimeType = in.RuntimeType
+	out.BinaryName = in.BinaryName
+	out.RuntimeClass = (*ContainerdRuntimeClass)(unsafe.Pointer(in.RuntimeClass))
+	return nil
+}
+
+// Convert_kubeone_ContainerdRuntime_To_v1beta2_ContainerdRuntime is an autogenerated conversion function.
+func Convert_kubeone_ContainerdRuntime_To_v1beta2_ContainerdRuntime(in *kubeone.ContainerdRuntime, out *ContainerdRuntime, s conversion.Scope) error {
+	return autoConvert_kubeone_ContainerdRuntime_To_v1beta2_ContainerdRuntime(in, out, s)
+}
+
+func autoConvert_v1beta2_ContainerdRuntimeClass_To_kubeone_ContainerdRuntimeClass(in *ContainerdRuntimeClass, out *kubeone.ContainerdRuntimeClass, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	return nil
+}
+
+// Convert_v1beta2_ContainerdRuntimeClass_To_kubeone_ContainerdRuntimeClass is an autogenerated conversion function.
+func Convert_v1beta2_ContainerdRuntimeClass_To_kubeone_ContainerdRuntimeClass(in *ContainerdRuntimeClass, out *kubeone.ContainerdRuntimeClass, s conversion.Scope) error {
+	return autoConvert_v1beta2_ContainerdRuntimeClass_To_kubeone_ContainerdRuntimeClass(in, out, s)
+}
+
+func autoConvert_kubeone_ContainerdRuntimeClass_To_v1beta2_ContainerdRuntimeClass(in *kubeone.ContainerdRuntimeClass, out *ContainerdRuntimeClass, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	return nil
+}
+
+// Convert_kubeone_ContainerdRuntimeClass_To_v1beta2_ContainerdRuntimeClass is an autogenerated conversion function.
+func Convert_kubeone_ContainerdRuntimeClass_To_v1beta2_ContainerdRuntimeClass(in *kubeone.ContainerdRuntimeClass, out *ContainerdRuntimeClass, s conversion.Scope) error {
+	return autoConvert_kubeone_ContainerdRuntimeClass_To_v1beta2_ContainerdRuntimeClass(in, out, s)
 }
 
 func autoConvert_v1beta2_ContainerdTLSConfig_To_kubeone_ContainerdTLSConfig(in *ContainerdTLSConfig, out *kubeone.ContainerdTLSConfig, s conversion.Scope) error {
@@ -1027,6 +1711,30 @@ func Convert_kubeone_ContainerdTLSConfig_To_v1beta2_ContainerdTLSConfig(in *kube
 	return autoConvert_kubeone_ContainerdTLSConfig_To_v1beta2_ContainerdTLSConfig(in, out, s)
 }
 
+func autoConvert_v1beta2_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(in *ControlPlaneComponents, out *kubeone.ControlPlaneComponents, s conversion.Scope) error {
+	out.Scheduler = (*kubeone.SchedulerConfig)(unsafe.Pointer(in.Scheduler))
+	out.APIServer = (*kubeone.APIServerConfig)(unsafe.Pointer(in.APIServer))
+	out.ControllerManager = (*kubeone.ControllerManagerConfig)(unsafe.Pointer(in.ControllerManager))
+	return nil
+}
+
+// Convert_v1beta2_ControlPlaneComponents_To_kubeone_ControlPlaneComponents is an autogenerated conversion function.
+func Convert_v1beta2_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(in *ControlPlaneComponents, out *kubeone.ControlPlaneComponents, s conversion.Scope) error {
+	return autoConvert_v1beta2_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(in, out, s)
+}
+
+func autoConvert_kubeone_ControlPlaneComponents_To_v1beta2_ControlPlaneComponents(in *kubeone.ControlPlaneComponents, out *ControlPlaneComponents, s conversion.Scope) error {
+	out.Scheduler = (*SchedulerConfig)(unsafe.Pointer(in.Scheduler))
+	out.APIServer = (*APIServerConfig)(unsafe.Pointer(in.APIServer))
+	out.ControllerManager = (*ControllerManagerConfig)(unsafe.Pointer(in.ControllerManager))
+	return nil
+}
+
+// Convert_kubeone_ControlPlaneComponents_To_v1beta2_ControlPlaneComponents is an autogenerated conversion function.
+func Convert_kubeone_ControlPlaneComponents_To_v1beta2_ControlPlaneComponents(in *kubeone.ControlPlaneComponents, out *ControlPlaneComponents, s conversion.Scope) error {
+	return autoConvert_kubeone_ControlPlaneComponents_To_v1beta2_ControlPlaneComponents(in, out, s)
+}
+
 func autoConvert_v1beta2_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(in *ControlPlaneConfig, out *kubeone.ControlPlaneConfig, s conversion.Scope) error {
 	out.Hosts = *(*[]kubeone.HostConfig)(unsafe.Pointer(&in.Hosts))
 	return nil
@@ -1047,6 +1755,26 @@ func Convert_kubeone_ControlPlaneConfig_To_v1beta2_ControlPlaneConfig(in *kubeon
 	return autoConvert_kubeone_ControlPlaneConfig_To_v1beta2_ControlPlaneConfig(in, out, s)
 }
 
+func autoConvert_v1beta2_ControllerManagerConfig_To_kubeone_ControllerManagerConfig(in *ControllerManagerConfig, out *kubeone.ControllerManagerConfig, s conversion.Scope) error {
+	out.ClusterSigningDuration = in.ClusterSigningDuration
+	return nil
+}
+
+// Convert_v1beta2_ControllerManagerConfig_To_kubeone_ControllerManagerConfig is an autogenerated conversion function.
+func Convert_v1beta2_ControllerManagerConfig_To_kubeone_ControllerManagerConfig(in *ControllerManagerConfig, out *kubeone.ControllerManagerConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_ControllerManagerConfig_To_kubeone_ControllerManagerConfig(in, out, s)
+}
+
+func autoConvert_kubeone_ControllerManagerConfig_To_v1beta2_ControllerManagerConfig(in *kubeone.ControllerManagerConfig, out *ControllerManagerConfig, s conversion.Scope) error {
+	out.ClusterSigningDuration = in.ClusterSigningDuration
+	return nil
+}
+
+// Convert_kubeone_ControllerManagerConfig_To_v1beta2_ControllerManagerConfig is an autogenerated conversion function.
+func Convert_kubeone_ControllerManagerConfig_To_v1beta2_ControllerManagerConfig(in *kubeone.ControllerManagerConfig, out *ControllerManagerConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_ControllerManagerConfig_To_v1beta2_ControllerManagerConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_DNSConfig_To_kubeone_DNSConfig(in *DNSConfig, out *kubeone.DNSConfig, s conversion.Scope) error {
 	out.Servers = *(*[]string)(unsafe.Pointer(&in.Servers))
 	return nil
@@ -1067,6 +1795,28 @@ func Convert_kubeone_DNSConfig_To_v1beta2_DNSConfig(in *kubeone.DNSConfig, out *
 	return autoConvert_kubeone_DNSConfig_To_v1beta2_DNSConfig(in, out, s)
 }
 
+func autoConvert_v1beta2_DataVolumeConfig_To_kubeone_DataVolumeConfig(in *DataVolumeConfig, out *kubeone.DataVolumeConfig, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Type = in.Type
+	return nil
+}
+
+// Convert_v1beta2_DataVolumeConfig_To_kubeone_DataVolumeConfig is an autogenerated conversion function.
+func Convert_v1beta2_DataVolumeConfig_To_kubeone_DataVolumeConfig(in *DataVolumeConfig, out *kubeone.DataVolumeConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_DataVolumeConfig_To_kubeone_DataVolumeConfig(in, out, s)
+}
+
+func autoConvert_kubeone_DataVolumeConfig_To_v1beta2_DataVolumeConfig(in *kubeone.DataVolumeConfig, out *DataVolumeConfig, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Type = in.Type
+	return nil
+}
+
+// Convert_kubeone_DataVolumeConfig_To_v1beta2_DataVolumeConfig is an autogenerated conversion function.
+func Convert_kubeone_DataVolumeConfig_To_v1beta2_DataVolumeConfig(in *kubeone.DataVolumeConfig, out *DataVolumeConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_DataVolumeConfig_To_v1beta2_DataVolumeConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_DigitalOceanSpec_To_kubeone_DigitalOceanSpec(in *DigitalOceanSpec, out *kubeone.DigitalOceanSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1085,6 +1835,30 @@ func Convert_kubeone_DigitalOceanSpec_To_v1beta2_DigitalOceanSpec(in *kubeone.Di
 	return autoConvert_kubeone_DigitalOceanSpec_To_v1beta2_DigitalOceanSpec(in, out, s)
 }
 
+func autoConvert_v1beta2_DiskConfig_To_kubeone_DiskConfig(in *DiskConfig, out *kubeone.DiskConfig, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Type = in.Type
+	out.DataVolumes = *(*[]kubeone.DataVolumeConfig)(unsafe.Pointer(&in.DataVolumes))
+	return nil
+}
+
+// Convert_v1beta2_DiskConfig_To_kubeone_DiskConfig is an autogenerated conversion function.
+func Convert_v1beta2_DiskConfig_To_kubeone_DiskConfig(in *DiskConfig, out *kubeone.DiskConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_DiskConfig_To_kubeone_DiskConfig(in, out, s)
+}
+
+func autoConvert_kubeone_DiskConfig_To_v1beta2_DiskConfig(in *kubeone.DiskConfig, out *DiskConfig, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Type = in.Type
+	out.DataVolumes = *(*[]DataVolumeConfig)(unsafe.Pointer(&in.DataVolumes))
+	return nil
+}
+
+// Convert_kubeone_DiskConfig_To_v1beta2_DiskConfig is an autogenerated conversion function.
+func Convert_kubeone_DiskConfig_To_v1beta2_DiskConfig(in *kubeone.DiskConfig, out *DiskConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_DiskConfig_To_v1beta2_DiskConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_DynamicAuditLog_To_kubeone_DynamicAuditLog(in *DynamicAuditLog, out *kubeone.DynamicAuditLog, s conversion.Scope) error {
 	out.Enable = in.Enable
 	return nil
@@ -1105,12 +1879,35 @@ func Convert_kubeone_DynamicAuditLog_To_v1beta2_DynamicAuditLog(in *kubeone.Dyna
 	return autoConvert_kubeone_DynamicAuditLog_To_v1beta2_DynamicAuditLog(in, out, s)
 }
 
+func autoConvert_v1beta2_DynamicWorkerAutoscaler_To_kubeone_DynamicWorkerAutoscaler(in *DynamicWorkerAutoscaler, out *kubeone.DynamicWorkerAutoscaler, s conversion.Scope) error {
+	out.MinReplicas = in.MinReplicas
+	out.MaxReplicas = in.MaxReplicas
+	return nil
+}
+
+// Convert_v1beta2_DynamicWorkerAutoscaler_To_kubeone_DynamicWorkerAutoscaler is an autogenerated conversion function.
+func Convert_v1beta2_DynamicWorkerAutoscaler_To_kubeone_DynamicWorkerAutoscaler(in *DynamicWorkerAutoscaler, out *kubeone.DynamicWorkerAutoscaler, s conversion.Scope) error {
+	return autoConvert_v1beta2_DynamicWorkerAutoscaler_To_kubeone_DynamicWorkerAutoscaler(in, out, s)
+}
+
+func autoConvert_kubeone_DynamicWorkerAutoscaler_To_v1beta2_DynamicWorkerAutoscaler(in *kubeone.DynamicWorkerAutoscaler, out *DynamicWorkerAutoscaler, s conversion.Scope) error {
+	out.MinReplicas = in.MinReplicas
+	out.MaxReplicas = in.MaxReplicas
+	return nil
+}
+
+// Convert_kubeone_DynamicWorkerAutoscaler_To_v1beta2_DynamicWorkerAutoscaler is an autogenerated conversion function.
+func Convert_kubeone_DynamicWorkerAutoscaler_To_v1beta2_DynamicWorkerAutoscaler(in *kubeone.DynamicWorkerAutoscaler, out *DynamicWorkerAutoscaler, s conversion.Scope) error {
+	return autoConvert_kubeone_DynamicWorkerAutoscaler_To_v1beta2_DynamicWorkerAutoscaler(in, out, s)
+}
+
 func autoConvert_v1beta2_DynamicWorkerConfig_To_kubeone_DynamicWorkerConfig(in *DynamicWorkerConfig, out *kubeone.DynamicWorkerConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Replicas = (*int)(unsafe.Pointer(in.Replicas))
 	if err := Convert_v1beta2_ProviderSpec_To_kubeone_ProviderSpec(&in.Config, &out.Config, s); err != nil {
 		return err
 	}
+	out.Autoscaler = (*kubeone.DynamicWorkerAutoscaler)(unsafe.Pointer(in.Autoscaler))
 	return nil
 }
 
@@ -1125,6 +1922,7 @@ func autoConvert_kubeone_DynamicWorkerConfig_To_v1beta2_DynamicWorkerConfig(in *
 	if err := Convert_kubeone_ProviderSpec_To_v1beta2_ProviderSpec(&in.Config, &out.Config, s); err != nil {
 		return err
 	}
+	out.Autoscaler = (*DynamicWorkerAutoscaler)(unsafe.Pointer(in.Autoscaler))
 	return nil
 }
 
@@ -1173,6 +1971,58 @@ func Convert_kubeone_EquinixMetalSpec_To_v1beta2_EquinixMetalSpec(in *kubeone.Eq
 	return autoConvert_kubeone_EquinixMetalSpec_To_v1beta2_EquinixMetalSpec(in, out, s)
 }
 
+func autoConvert_v1beta2_EtcdBackup_To_kubeone_EtcdBackup(in *EtcdBackup, out *kubeone.EtcdBackup, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Schedule = in.Schedule
+	out.S3Bucket = in.S3Bucket
+	out.AWSDefaultRegion = in.AWSDefaultRegion
+	out.KeepLast = in.KeepLast
+	return nil
+}
+
+// Convert_v1beta2_EtcdBackup_To_kubeone_EtcdBackup is an autogenerated conversion function.
+func Convert_v1beta2_EtcdBackup_To_kubeone_EtcdBackup(in *EtcdBackup, out *kubeone.EtcdBackup, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdBackup_To_kubeone_EtcdBackup(in, out, s)
+}
+
+func autoConvert_kubeone_EtcdBackup_To_v1beta2_EtcdBackup(in *kubeone.EtcdBackup, out *EtcdBackup, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Schedule = in.Schedule
+	out.S3Bucket = in.S3Bucket
+	out.AWSDefaultRegion = in.AWSDefaultRegion
+	out.KeepLast = in.KeepLast
+	return nil
+}
+
+// Convert_kubeone_EtcdBackup_To_v1beta2_EtcdBackup is an autogenerated conversion function.
+func Convert_kubeone_EtcdBackup_To_v1beta2_EtcdBackup(in *kubeone.EtcdBackup, out *EtcdBackup, s conversion.Scope) error {
+	return autoConvert_kubeone_EtcdBackup_To_v1beta2_EtcdBackup(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdMetrics_To_kubeone_EtcdMetrics(in *EtcdMetrics, out *kubeone.EtcdMetrics, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Port = in.Port
+	out.ServiceMonitor = in.ServiceMonitor
+	return nil
+}
+
+// Convert_v1beta2_EtcdMetrics_To_kubeone_EtcdMetrics is an autogenerated conversion function.
+func Convert_v1beta2_EtcdMetrics_To_kubeone_EtcdMetrics(in *EtcdMetrics, out *kubeone.EtcdMetrics, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdMetrics_To_kubeone_EtcdMetrics(in, out, s)
+}
+
+func autoConvert_kubeone_EtcdMetrics_To_v1beta2_EtcdMetrics(in *kubeone.EtcdMetrics, out *EtcdMetrics, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Port = in.Port
+	out.ServiceMonitor = in.ServiceMonitor
+	return nil
+}
+
+// Convert_kubeone_EtcdMetrics_To_v1beta2_EtcdMetrics is an autogenerated conversion function.
+func Convert_kubeone_EtcdMetrics_To_v1beta2_EtcdMetrics(in *kubeone.EtcdMetrics, out *EtcdMetrics, s conversion.Scope) error {
+	return autoConvert_kubeone_EtcdMetrics_To_v1beta2_EtcdMetrics(in, out, s)
+}
+
 func autoConvert_v1beta2_ExternalCNISpec_To_kubeone_ExternalCNISpec(in *ExternalCNISpec, out *kubeone.ExternalCNISpec, s conversion.Scope) error {
 	return nil
 }
@@ -1199,6 +2049,14 @@ func autoConvert_v1beta2_Features_To_kubeone_Features(in *Features, out *kubeone
 	out.MetricsServer = (*kubeone.MetricsServer)(unsafe.Pointer(in.MetricsServer))
 	out.OpenIDConnect = (*kubeone.OpenIDConnect)(unsafe.Pointer(in.OpenIDConnect))
 	out.EncryptionProviders = (*kubeone.EncryptionProviders)(unsafe.Pointer(in.EncryptionProviders))
+	out.StaticAuth = (*kubeone.StaticAuth)(unsafe.Pointer(in.StaticAuth))
+	out.KubeletServingCertRotation = (*kubeone.KubeletServingCertRotation)(unsafe.Pointer(in.KubeletServingCertRotation))
+	out.EtcdMetrics = (*kubeone.EtcdMetrics)(unsafe.Pointer(in.EtcdMetrics))
+	out.PodDisruptionBudgets = (*kubeone.PodDisruptionBudgets)(unsafe.Pointer(in.PodDisruptionBudgets))
+	out.PrometheusAdapter = (*kubeone.PrometheusAdapter)(unsafe.Pointer(in.PrometheusAdapter))
+	out.EtcdBackup = (*kubeone.EtcdBackup)(unsafe.Pointer(in.EtcdBackup))
+	out.PodTolerationRestriction = (*kubeone.PodTolerationRestriction)(unsafe.Pointer(in.PodTolerationRestriction))
+	out.WebhookCABundleInjection = (*kubeone.WebhookCABundleInjection)(unsafe.Pointer(in.WebhookCABundleInjection))
 	return nil
 }
 
@@ -1215,6 +2073,14 @@ func autoConvert_kubeone_Features_To_v1beta2_Features(in *kubeone.Features, out
 	out.MetricsServer = (*MetricsServer)(unsafe.Pointer(in.MetricsServer))
 	out.OpenIDConnect = (*OpenIDConnect)(unsafe.Pointer(in.OpenIDConnect))
 	out.EncryptionProviders = (*EncryptionProviders)(unsafe.Pointer(in.EncryptionProviders))
+	out.StaticAuth = (*StaticAuth)(unsafe.Pointer(in.StaticAuth))
+	out.KubeletServingCertRotation = (*KubeletServingCertRotation)(unsafe.Pointer(in.KubeletServingCertRotation))
+	out.EtcdMetrics = (*EtcdMetrics)(unsafe.Pointer(in.EtcdMetrics))
+	out.PodDisruptionBudgets = (*PodDisruptionBudgets)(unsafe.Pointer(in.PodDisruptionBudgets))
+	out.PrometheusAdapter = (*PrometheusAdapter)(unsafe.Pointer(in.PrometheusAdapter))
+	out.EtcdBackup = (*EtcdBackup)(unsafe.Pointer(in.EtcdBackup))
+	out.PodTolerationRestriction = (*PodTolerationRestriction)(unsafe.Pointer(in.PodTolerationRestriction))
+	out.WebhookCABundleInjection = (*WebhookCABundleInjection)(unsafe.Pointer(in.WebhookCABundleInjection))
 	return nil
 }
 
@@ -1223,6 +2089,56 @@ func Convert_kubeone_Features_To_v1beta2_Features(in *kubeone.Features, out *Fea
 	return autoConvert_kubeone_Features_To_v1beta2_Features(in, out, s)
 }
 
+func autoConvert_v1beta2_FirewallConfig_To_kubeone_FirewallConfig(in *FirewallConfig, out *kubeone.FirewallConfig, s conversion.Scope) error {
+	out.Backend = in.Backend
+	out.Rules = *(*[]kubeone.FirewallRule)(unsafe.Pointer(&in.Rules))
+	out.KubeletSources = *(*[]string)(unsafe.Pointer(&in.KubeletSources))
+	return nil
+}
+
+// Convert_v1beta2_FirewallConfig_To_kubeone_FirewallConfig is an autogenerated conversion function.
+func Convert_v1beta2_FirewallConfig_To_kubeone_FirewallConfig(in *FirewallConfig, out *kubeone.FirewallConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_FirewallConfig_To_kubeone_FirewallConfig(in, out, s)
+}
+
+func autoConvert_kubeone_FirewallConfig_To_v1beta2_FirewallConfig(in *kubeone.FirewallConfig, out *FirewallConfig, s conversion.Scope) error {
+	out.Backend = in.Backend
+	out.Rules = *(*[]FirewallRule)(unsafe.Pointer(&in.Rules))
+	out.KubeletSources = *(*[]string)(unsafe.Pointer(&in.KubeletSources))
+	return nil
+}
+
+// Convert_kubeone_FirewallConfig_To_v1beta2_FirewallConfig is an autogenerated conversion function.
+func Convert_kubeone_FirewallConfig_To_v1beta2_FirewallConfig(in *kubeone.FirewallConfig, out *FirewallConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_FirewallConfig_To_v1beta2_FirewallConfig(in, out, s)
+}
+
+func autoConvert_v1beta2_FirewallRule_To_kubeone_FirewallRule(in *FirewallRule, out *kubeone.FirewallRule, s conversion.Scope) error {
+	out.Ports = in.Ports
+	out.Protocol = in.Protocol
+	out.Sources = *(*[]string)(unsafe.Pointer(&in.Sources))
+	out.Roles = *(*[]string)(unsafe.Pointer(&in.Roles))
+	return nil
+}
+
+// Convert_v1beta2_FirewallRule_To_kubeone_FirewallRule is an autogenerated conversion function.
+func Convert_v1beta2_FirewallRule_To_kubeone_FirewallRule(in *FirewallRule, out *kubeone.FirewallRule, s conversion.Scope) error {
+	return autoConvert_v1beta2_FirewallRule_To_kubeone_FirewallRule(in, out, s)
+}
+
+func autoConvert_kubeone_FirewallRule_To_v1beta2_FirewallRule(in *kubeone.FirewallRule, out *FirewallRule, s conversion.Scope) error {
+	out.Ports = in.Ports
+	out.Protocol = in.Protocol
+	out.Sources = *(*[]string)(unsafe.Pointer(&in.Sources))
+	out.Roles = *(*[]string)(unsafe.Pointer(&in.Roles))
+	return nil
+}
+
+// Convert_kubeone_FirewallRule_To_v1beta2_FirewallRule is an autogenerated conversion function.
+func Convert_kubeone_FirewallRule_To_v1beta2_FirewallRule(in *kubeone.FirewallRule, out *FirewallRule, s conversion.Scope) error {
+	return autoConvert_kubeone_FirewallRule_To_v1beta2_FirewallRule(in, out, s)
+}
+
 func autoConvert_v1beta2_GCESpec_To_kubeone_GCESpec(in *GCESpec, out *kubeone.GCESpec, s conversion.Scope) error {
 	return nil
 }
@@ -1279,6 +2195,10 @@ func autoConvert_v1beta2_HostConfig_To_kubeone_HostConfig(in *HostConfig, out *k
 		return err
 	}
 	out.OperatingSystem = kubeone.OperatingSystemName(in.OperatingSystem)
+	out.BindAddresses = (*kubeone.BindAddresses)(unsafe.Pointer(in.BindAddresses))
+	out.DNSName = in.DNSName
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	return nil
 }
 
@@ -1305,6 +2225,10 @@ func autoConvert_kubeone_HostConfig_To_v1beta2_HostConfig(in *kubeone.HostConfig
 		return err
 	}
 	out.OperatingSystem = OperatingSystemName(in.OperatingSystem)
+	out.BindAddresses = (*BindAddresses)(unsafe.Pointer(in.BindAddresses))
+	out.DNSName = in.DNSName
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	return nil
 }
 
@@ -1412,6 +2336,10 @@ func autoConvert_v1beta2_KubeOneCluster_To_kubeone_KubeOneCluster(in *KubeOneClu
 	out.DynamicWorkers = *(*[]kubeone.DynamicWorkerConfig)(unsafe.Pointer(&in.DynamicWorkers))
 	out.MachineController = (*kubeone.MachineControllerConfig)(unsafe.Pointer(in.MachineController))
 	out.CABundle = in.CABundle
+	out.CertificateAuthority = (*kubeone.CertificateAuthority)(unsafe.Pointer(in.CertificateAuthority))
+	out.ControlPlaneComponents = (*kubeone.ControlPlaneComponents)(unsafe.Pointer(in.ControlPlaneComponents))
+	out.PostApplyValidation = (*kubeone.PostApplyValidation)(unsafe.Pointer(in.PostApplyValidation))
+	out.BootstrapToken = (*kubeone.BootstrapTokenConfig)(unsafe.Pointer(in.BootstrapToken))
 	if err := Convert_v1beta2_Features_To_kubeone_Features(&in.Features, &out.Features, s); err != nil {
 		return err
 	}
@@ -1421,6 +2349,8 @@ func autoConvert_v1beta2_KubeOneCluster_To_kubeone_KubeOneCluster(in *KubeOneClu
 	if err := Convert_v1beta2_LoggingConfig_To_kubeone_LoggingConfig(&in.LoggingConfig, &out.LoggingConfig, s); err != nil {
 		return err
 	}
+	out.Namespaces = *(*[]kubeone.NamespaceConfig)(unsafe.Pointer(&in.Namespaces))
+	out.Firewall = (*kubeone.FirewallConfig)(unsafe.Pointer(in.Firewall))
 	return nil
 }
 
@@ -1458,6 +2388,10 @@ func autoConvert_kubeone_KubeOneCluster_To_v1beta2_KubeOneCluster(in *kubeone.Ku
 	out.DynamicWorkers = *(*[]DynamicWorkerConfig)(unsafe.Pointer(&in.DynamicWorkers))
 	out.MachineController = (*MachineControllerConfig)(unsafe.Pointer(in.MachineController))
 	out.CABundle = in.CABundle
+	out.CertificateAuthority = (*CertificateAuthority)(unsafe.Pointer(in.CertificateAuthority))
+	out.ControlPlaneComponents = (*ControlPlaneComponents)(unsafe.Pointer(in.ControlPlaneComponents))
+	out.PostApplyValidation = (*PostApplyValidation)(unsafe.Pointer(in.PostApplyValidation))
+	out.BootstrapToken = (*BootstrapTokenConfig)(unsafe.Pointer(in.BootstrapToken))
 	if err := Convert_kubeone_Features_To_v1beta2_Features(&in.Features, &out.Features, s); err != nil {
 		return err
 	}
@@ -1468,6 +2402,8 @@ func autoConvert_kubeone_KubeOneCluster_To_v1beta2_KubeOneCluster(in *kubeone.Ku
 	if err := Convert_kubeone_LoggingConfig_To_v1beta2_LoggingConfig(&in.LoggingConfig, &out.LoggingConfig, s); err != nil {
 		return err
 	}
+	out.Namespaces = *(*[]NamespaceConfig)(unsafe.Pointer(&in.Namespaces))
+	out.Firewall = (*FirewallConfig)(unsafe.Pointer(in.Firewall))
 	return nil
 }
 
@@ -1499,6 +2435,9 @@ func autoConvert_v1beta2_KubeletConfig_To_kubeone_KubeletConfig(in *KubeletConfi
 	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
 	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
 	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	out.EnforceNodeAllocatable = *(*[]string)(unsafe.Pointer(&in.EnforceNodeAllocatable))
+	out.SystemReservedCgroup = in.SystemReservedCgroup
+	out.KubeReservedCgroup = in.KubeReservedCgroup
 	out.MaxPods = (*int32)(unsafe.Pointer(in.MaxPods))
 	return nil
 }
@@ -1512,6 +2451,9 @@ func autoConvert_kubeone_KubeletConfig_To_v1beta2_KubeletConfig(in *kubeone.Kube
 	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
 	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
 	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	out.EnforceNodeAllocatable = *(*[]string)(unsafe.Pointer(&in.EnforceNodeAllocatable))
+	out.SystemReservedCgroup = in.SystemReservedCgroup
+	out.KubeReservedCgroup = in.KubeReservedCgroup
 	out.MaxPods = (*int32)(unsafe.Pointer(in.MaxPods))
 	return nil
 }
@@ -1521,6 +2463,26 @@ func Convert_kubeone_KubeletConfig_To_v1beta2_KubeletConfig(in *kubeone.KubeletC
 	return autoConvert_kubeone_KubeletConfig_To_v1beta2_KubeletConfig(in, out, s)
 }
 
+func autoConvert_v1beta2_KubeletServingCertRotation_To_kubeone_KubeletServingCertRotation(in *KubeletServingCertRotation, out *kubeone.KubeletServingCertRotation, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta2_KubeletServingCertRotation_To_kubeone_KubeletServingCertRotation is an autogenerated conversion function.
+func Convert_v1beta2_KubeletServingCertRotation_To_kubeone_KubeletServingCertRotation(in *KubeletServingCertRotation, out *kubeone.KubeletServingCertRotation, s conversion.Scope) error {
+	return autoConvert_v1beta2_KubeletServingCertRotation_To_kubeone_KubeletServingCertRotation(in, out, s)
+}
+
+func autoConvert_kubeone_KubeletServingCertRotation_To_v1beta2_KubeletServingCertRotation(in *kubeone.KubeletServingCertRotation, out *KubeletServingCertRotation, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_KubeletServingCertRotation_To_v1beta2_KubeletServingCertRotation is an autogenerated conversion function.
+func Convert_kubeone_KubeletServingCertRotation_To_v1beta2_KubeletServingCertRotation(in *kubeone.KubeletServingCertRotation, out *KubeletServingCertRotation, s conversion.Scope) error {
+	return autoConvert_kubeone_KubeletServingCertRotation_To_v1beta2_KubeletServingCertRotation(in, out, s)
+}
+
 func autoConvert_v1beta2_LoggingConfig_To_kubeone_LoggingConfig(in *LoggingConfig, out *kubeone.LoggingConfig, s conversion.Scope) error {
 	out.ContainerLogMaxSize = in.ContainerLogMaxSize
 	out.ContainerLogMaxFiles = in.ContainerLogMaxFiles
@@ -1583,6 +2545,96 @@ func Convert_kubeone_MetricsServer_To_v1beta2_MetricsServer(in *kubeone.MetricsS
 	return autoConvert_kubeone_MetricsServer_To_v1beta2_MetricsServer(in, out, s)
 }
 
+func autoConvert_v1beta2_NamespaceConfig_To_kubeone_NamespaceConfig(in *NamespaceConfig, out *kubeone.NamespaceConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_v1beta2_NamespaceConfig_To_kubeone_NamespaceConfig is an autogenerated conversion function.
+func Convert_v1beta2_NamespaceConfig_To_kubeone_NamespaceConfig(in *NamespaceConfig, out *kubeone.NamespaceConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_NamespaceConfig_To_kubeone_NamespaceConfig(in, out, s)
+}
+
+func autoConvert_kubeone_NamespaceConfig_To_v1beta2_NamespaceConfig(in *kubeone.NamespaceConfig, out *NamespaceConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_kubeone_NamespaceConfig_To_v1beta2_NamespaceConfig is an autogenerated conversion function.
+func Convert_kubeone_NamespaceConfig_To_v1beta2_NamespaceConfig(in *kubeone.NamespaceConfig, out *NamespaceConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_NamespaceConfig_To_v1beta2_NamespaceConfig(in, out, s)
+}
+
+func autoConvert_v1beta2_NamespaceTolerations_To_kubeone_NamespaceTolerations(in *NamespaceTolerations, out *kubeone.NamespaceTolerations, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DefaultTolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.DefaultTolerations))
+	out.Whitelist = *(*[]v1.Toleration)(unsafe.Pointer(&in.Whitelist))
+	return nil
+}
+
+// Convert_v1beta2_NamespaceTolerations_To_kubeone_NamespaceTolerations is an autogenerated conversion function.
+func Convert_v1beta2_NamespaceTolerations_To_kubeone_NamespaceTolerations(in *NamespaceTolerations, out *kubeone.NamespaceTolerations, s conversion.Scope) error {
+	return autoConvert_v1beta2_NamespaceTolerations_To_kubeone_NamespaceTolerations(in, out, s)
+}
+
+func autoConvert_kubeone_NamespaceTolerations_To_v1beta2_NamespaceTolerations(in *kubeone.NamespaceTolerations, out *NamespaceTolerations, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DefaultTolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.DefaultTolerations))
+	out.Whitelist = *(*[]v1.Toleration)(unsafe.Pointer(&in.Whitelist))
+	return nil
+}
+
+// Convert_kubeone_NamespaceTolerations_To_v1beta2_NamespaceTolerations is an autogenerated conversion function.
+func Convert_kubeone_NamespaceTolerations_To_v1beta2_NamespaceTolerations(in *kubeone.NamespaceTolerations, out *NamespaceTolerations, s conversion.Scope) error {
+	return autoConvert_kubeone_NamespaceTolerations_To_v1beta2_NamespaceTolerations(in, out, s)
+}
+
+func autoConvert_v1beta2_NodeCIDRMaskSize_To_kubeone_NodeCIDRMaskSize(in *NodeCIDRMaskSize, out *kubeone.NodeCIDRMaskSize, s conversion.Scope) error {
+	out.IPv4 = (*int32)(unsafe.Pointer(in.IPv4))
+	out.IPv6 = (*int32)(unsafe.Pointer(in.IPv6))
+	return nil
+}
+
+// Convert_v1beta2_NodeCIDRMaskSize_To_kubeone_NodeCIDRMaskSize is an autogenerated conversion function.
+func Convert_v1beta2_NodeCIDRMaskSize_To_kubeone_NodeCIDRMaskSize(in *NodeCIDRMaskSize, out *kubeone.NodeCIDRMaskSize, s conversion.Scope) error {
+	return autoConvert_v1beta2_NodeCIDRMaskSize_To_kubeone_NodeCIDRMaskSize(in, out, s)
+}
+
+func autoConvert_kubeone_NodeCIDRMaskSize_To_v1beta2_NodeCIDRMaskSize(in *kubeone.NodeCIDRMaskSize, out *NodeCIDRMaskSize, s conversion.Scope) error {
+	out.IPv4 = (*int32)(unsafe.Pointer(in.IPv4))
+	out.IPv6 = (*int32)(unsafe.Pointer(in.IPv6))
+	return nil
+}
+
+// Convert_kubeone_NodeCIDRMaskSize_To_v1beta2_NodeCIDRMaskSize is an autogenerated conversion function.
+func Convert_kubeone_NodeCIDRMaskSize_To_v1beta2_NodeCIDRMaskSize(in *kubeone.NodeCIDRMaskSize, out *NodeCIDRMaskSize, s conversion.Scope) error {
+	return autoConvert_kubeone_NodeCIDRMaskSize_To_v1beta2_NodeCIDRMaskSize(in, out, s)
+}
+
+func autoConvert_v1beta2_NodeConnectivityCheck_To_kubeone_NodeConnectivityCheck(in *NodeConnectivityCheck, out *kubeone.NodeConnectivityCheck, s conversion.Scope) error {
+	out.MTU = in.MTU
+	return nil
+}
+
+// Convert_v1beta2_NodeConnectivityCheck_To_kubeone_NodeConnectivityCheck is an autogenerated conversion function.
+func Convert_v1beta2_NodeConnectivityCheck_To_kubeone_NodeConnectivityCheck(in *NodeConnectivityCheck, out *kubeone.NodeConnectivityCheck, s conversion.Scope) error {
+	return autoConvert_v1beta2_NodeConnectivityCheck_To_kubeone_NodeConnectivityCheck(in, out, s)
+}
+
+func autoConvert_kubeone_NodeConnectivityCheck_To_v1beta2_NodeConnectivityCheck(in *kubeone.NodeConnectivityCheck, out *NodeConnectivityCheck, s conversion.Scope) error {
+	out.MTU = in.MTU
+	return nil
+}
+
+// Convert_kubeone_NodeConnectivityCheck_To_v1beta2_NodeConnectivityCheck is an autogenerated conversion function.
+func Convert_kubeone_NodeConnectivityCheck_To_v1beta2_NodeConnectivityCheck(in *kubeone.NodeConnectivityCheck, out *NodeConnectivityCheck, s conversion.Scope) error {
+	return autoConvert_kubeone_NodeConnectivityCheck_To_v1beta2_NodeConnectivityCheck(in, out, s)
+}
+
 func autoConvert_v1beta2_NoneSpec_To_kubeone_NoneSpec(in *NoneSpec, out *kubeone.NoneSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1699,6 +2751,28 @@ func Convert_kubeone_OpenstackSpec_To_v1beta2_OpenstackSpec(in *kubeone.Openstac
 	return autoConvert_kubeone_OpenstackSpec_To_v1beta2_OpenstackSpec(in, out, s)
 }
 
+func autoConvert_v1beta2_PodDisruptionBudgets_To_kubeone_PodDisruptionBudgets(in *PodDisruptionBudgets, out *kubeone.PodDisruptionBudgets, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.MinAvailable = in.MinAvailable
+	return nil
+}
+
+// Convert_v1beta2_PodDisruptionBudgets_To_kubeone_PodDisruptionBudgets is an autogenerated conversion function.
+func Convert_v1beta2_PodDisruptionBudgets_To_kubeone_PodDisruptionBudgets(in *PodDisruptionBudgets, out *kubeone.PodDisruptionBudgets, s conversion.Scope) error {
+	return autoConvert_v1beta2_PodDisruptionBudgets_To_kubeone_PodDisruptionBudgets(in, out, s)
+}
+
+func autoConvert_kubeone_PodDisruptionBudgets_To_v1beta2_PodDisruptionBudgets(in *kubeone.PodDisruptionBudgets, out *PodDisruptionBudgets, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.MinAvailable = in.MinAvailable
+	return nil
+}
+
+// Convert_kubeone_PodDisruptionBudgets_To_v1beta2_PodDisruptionBudgets is an autogenerated conversion function.
+func Convert_kubeone_PodDisruptionBudgets_To_v1beta2_PodDisruptionBudgets(in *kubeone.PodDisruptionBudgets, out *PodDisruptionBudgets, s conversion.Scope) error {
+	return autoConvert_kubeone_PodDisruptionBudgets_To_v1beta2_PodDisruptionBudgets(in, out, s)
+}
+
 func autoConvert_v1beta2_PodNodeSelector_To_kubeone_PodNodeSelector(in *PodNodeSelector, out *kubeone.PodNodeSelector, s conversion.Scope) error {
 	out.Enable = in.Enable
 	if err := Convert_v1beta2_PodNodeSelectorConfig_To_kubeone_PodNodeSelectorConfig(&in.Config, &out.Config, s); err != nil {
@@ -1765,6 +2839,98 @@ func Convert_kubeone_PodSecurityPolicy_To_v1beta2_PodSecurityPolicy(in *kubeone.
 	return autoConvert_kubeone_PodSecurityPolicy_To_v1beta2_PodSecurityPolicy(in, out, s)
 }
 
+func autoConvert_v1beta2_PodTolerationRestriction_To_kubeone_PodTolerationRestriction(in *PodTolerationRestriction, out *kubeone.PodTolerationRestriction, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.DefaultTolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.DefaultTolerations))
+	out.Whitelist = *(*[]v1.Toleration)(unsafe.Pointer(&in.Whitelist))
+	out.Namespaces = *(*[]kubeone.NamespaceTolerations)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1beta2_PodTolerationRestriction_To_kubeone_PodTolerationRestriction is an autogenerated conversion function.
+func Convert_v1beta2_PodTolerationRestriction_To_kubeone_PodTolerationRestriction(in *PodTolerationRestriction, out *kubeone.PodTolerationRestriction, s conversion.Scope) error {
+	return autoConvert_v1beta2_PodTolerationRestriction_To_kubeone_PodTolerationRestriction(in, out, s)
+}
+
+func autoConvert_kubeone_PodTolerationRestriction_To_v1beta2_PodTolerationRestriction(in *kubeone.PodTolerationRestriction, out *PodTolerationRestriction, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.DefaultTolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.DefaultTolerations))
+	out.Whitelist = *(*[]v1.Toleration)(unsafe.Pointer(&in.Whitelist))
+	out.Namespaces = *(*[]NamespaceTolerations)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_kubeone_PodTolerationRestriction_To_v1beta2_PodTolerationRestriction is an autogenerated conversion function.
+func Convert_kubeone_PodTolerationRestriction_To_v1beta2_PodTolerationRestriction(in *kubeone.PodTolerationRestriction, out *PodTolerationRestriction, s conversion.Scope) error {
+	return autoConvert_kubeone_PodTolerationRestriction_To_v1beta2_PodTolerationRestriction(in, out, s)
+}
+
+func autoConvert_v1beta2_PostApplyValidation_To_kubeone_PostApplyValidation(in *PostApplyValidation, out *kubeone.PostApplyValidation, s conversion.Scope) error {
+	out.Webhook = (*kubeone.PostApplyValidationWebhook)(unsafe.Pointer(in.Webhook))
+	return nil
+}
+
+// Convert_v1beta2_PostApplyValidation_To_kubeone_PostApplyValidation is an autogenerated conversion function.
+func Convert_v1beta2_PostApplyValidation_To_kubeone_PostApplyValidation(in *PostApplyValidation, out *kubeone.PostApplyValidation, s conversion.Scope) error {
+	return autoConvert_v1beta2_PostApplyValidation_To_kubeone_PostApplyValidation(in, out, s)
+}
+
+func autoConvert_kubeone_PostApplyValidation_To_v1beta2_PostApplyValidation(in *kubeone.PostApplyValidation, out *PostApplyValidation, s conversion.Scope) error {
+	out.Webhook = (*PostApplyValidationWebhook)(unsafe.Pointer(in.Webhook))
+	return nil
+}
+
+// Convert_kubeone_PostApplyValidation_To_v1beta2_PostApplyValidation is an autogenerated conversion function.
+func Convert_kubeone_PostApplyValidation_To_v1beta2_PostApplyValidation(in *kubeone.PostApplyValidation, out *PostApplyValidation, s conversion.Scope) error {
+	return autoConvert_kubeone_PostApplyValidation_To_v1beta2_PostApplyValidation(in, out, s)
+}
+
+func autoConvert_v1beta2_PostApplyValidationWebhook_To_kubeone_PostApplyValidationWebhook(in *PostApplyValidationWebhook, out *kubeone.PostApplyValidationWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Timeout = in.Timeout
+	return nil
+}
+
+// Convert_v1beta2_PostApplyValidationWebhook_To_kubeone_PostApplyValidationWebhook is an autogenerated conversion function.
+func Convert_v1beta2_PostApplyValidationWebhook_To_kubeone_PostApplyValidationWebhook(in *PostApplyValidationWebhook, out *kubeone.PostApplyValidationWebhook, s conversion.Scope) error {
+	return autoConvert_v1beta2_PostApplyValidationWebhook_To_kubeone_PostApplyValidationWebhook(in, out, s)
+}
+
+func autoConvert_kubeone_PostApplyValidationWebhook_To_v1beta2_PostApplyValidationWebhook(in *kubeone.PostApplyValidationWebhook, out *PostApplyValidationWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Timeout = in.Timeout
+	return nil
+}
+
+// Convert_kubeone_PostApplyValidationWebhook_To_v1beta2_PostApplyValidationWebhook is an autogenerated conversion function.
+func Convert_kubeone_PostApplyValidationWebhook_To_v1beta2_PostApplyValidationWebhook(in *kubeone.PostApplyValidationWebhook, out *PostApplyValidationWebhook, s conversion.Scope) error {
+	return autoConvert_kubeone_PostApplyValidationWebhook_To_v1beta2_PostApplyValidationWebhook(in, out, s)
+}
+
+func autoConvert_v1beta2_PrometheusAdapter_To_kubeone_PrometheusAdapter(in *PrometheusAdapter, out *kubeone.PrometheusAdapter, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.PrometheusURL = in.PrometheusURL
+	out.Rules = in.Rules
+	return nil
+}
+
+// Convert_v1beta2_PrometheusAdapter_To_kubeone_PrometheusAdapter is an autogenerated conversion function.
+func Convert_v1beta2_PrometheusAdapter_To_kubeone_PrometheusAdapter(in *PrometheusAdapter, out *kubeone.PrometheusAdapter, s conversion.Scope) error {
+	return autoConvert_v1beta2_PrometheusAdapter_To_kubeone_PrometheusAdapter(in, out, s)
+}
+
+func autoConvert_kubeone_PrometheusAdapter_To_v1beta2_PrometheusAdapter(in *kubeone.PrometheusAdapter, out *PrometheusAdapter, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.PrometheusURL = in.PrometheusURL
+	out.Rules = in.Rules
+	return nil
+}
+
+// Convert_kubeone_PrometheusAdapter_To_v1beta2_PrometheusAdapter is an autogenerated conversion function.
+func Convert_kubeone_PrometheusAdapter_To_v1beta2_PrometheusAdapter(in *kubeone.PrometheusAdapter, out *PrometheusAdapter, s conversion.Scope) error {
+	return autoConvert_kubeone_PrometheusAdapter_To_v1beta2_PrometheusAdapter(in, out, s)
+}
+
 func autoConvert_v1beta2_ProviderSpec_To_kubeone_ProviderSpec(in *ProviderSpec, out *kubeone.ProviderSpec, s conversion.Scope) error {
 	out.CloudProviderSpec = *(*json.RawMessage)(unsafe.Pointer(&in.CloudProviderSpec))
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
@@ -1778,6 +2944,10 @@ func autoConvert_v1beta2_ProviderSpec_To_kubeone_ProviderSpec(in *ProviderSpec,
 	out.OperatingSystemSpec = *(*json.RawMessage)(unsafe.Pointer(&in.OperatingSystemSpec))
 	out.Network = (*kubeone.ProviderStaticNetworkConfig)(unsafe.Pointer(in.Network))
 	out.OverwriteCloudConfig = (*string)(unsafe.Pointer(in.OverwriteCloudConfig))
+	out.InstanceProfile = in.InstanceProfile
+	out.CloudNetwork = (*kubeone.CloudNetworkConfig)(unsafe.Pointer(in.CloudNetwork))
+	out.BootstrapCommands = *(*[]string)(unsafe.Pointer(&in.BootstrapCommands))
+	out.Disks = (*kubeone.DiskConfig)(unsafe.Pointer(in.Disks))
 	return nil
 }
 
@@ -1799,6 +2969,10 @@ func autoConvert_kubeone_ProviderSpec_To_v1beta2_ProviderSpec(in *kubeone.Provid
 	out.OperatingSystemSpec = *(*json.RawMessage)(unsafe.Pointer(&in.OperatingSystemSpec))
 	out.Network = (*ProviderStaticNetworkConfig)(unsafe.Pointer(in.Network))
 	out.OverwriteCloudConfig = (*string)(unsafe.Pointer(in.OverwriteCloudConfig))
+	out.InstanceProfile = in.InstanceProfile
+	out.CloudNetwork = (*CloudNetworkConfig)(unsafe.Pointer(in.CloudNetwork))
+	out.BootstrapCommands = *(*[]string)(unsafe.Pointer(&in.BootstrapCommands))
+	out.Disks = (*DiskConfig)(unsafe.Pointer(in.Disks))
 	return nil
 }
 
@@ -1881,6 +3055,26 @@ func Convert_kubeone_RegistryConfiguration_To_v1beta2_RegistryConfiguration(in *
 	return autoConvert_kubeone_RegistryConfiguration_To_v1beta2_RegistryConfiguration(in, out, s)
 }
 
+func autoConvert_v1beta2_SchedulerConfig_To_kubeone_SchedulerConfig(in *SchedulerConfig, out *kubeone.SchedulerConfig, s conversion.Scope) error {
+	out.PercentageOfNodesToScore = (*int32)(unsafe.Pointer(in.PercentageOfNodesToScore))
+	return nil
+}
+
+// Convert_v1beta2_SchedulerConfig_To_kubeone_SchedulerConfig is an autogenerated conversion function.
+func Convert_v1beta2_SchedulerConfig_To_kubeone_SchedulerConfig(in *SchedulerConfig, out *kubeone.SchedulerConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_SchedulerConfig_To_kubeone_SchedulerConfig(in, out, s)
+}
+
+func autoConvert_kubeone_SchedulerConfig_To_v1beta2_SchedulerConfig(in *kubeone.SchedulerConfig, out *SchedulerConfig, s conversion.Scope) error {
+	out.PercentageOfNodesToScore = (*int32)(unsafe.Pointer(in.PercentageOfNodesToScore))
+	return nil
+}
+
+// Convert_kubeone_SchedulerConfig_To_v1beta2_SchedulerConfig is an autogenerated conversion function.
+func Convert_kubeone_SchedulerConfig_To_v1beta2_SchedulerConfig(in *kubeone.SchedulerConfig, out *SchedulerConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_SchedulerConfig_To_v1beta2_SchedulerConfig(in, out, s)
+}
+
 func autoConvert_v1beta2_StaticAuditLog_To_kubeone_StaticAuditLog(in *StaticAuditLog, out *kubeone.StaticAuditLog, s conversion.Scope) error {
 	out.Enable = in.Enable
 	if err := Convert_v1beta2_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(&in.Config, &out.Config, s); err != nil {
@@ -1909,6 +3103,7 @@ func Convert_kubeone_StaticAuditLog_To_v1beta2_StaticAuditLog(in *kubeone.Static
 
 func autoConvert_v1beta2_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(in *StaticAuditLogConfig, out *kubeone.StaticAuditLogConfig, s conversion.Scope) error {
 	out.PolicyFilePath = in.PolicyFilePath
+	out.PolicyPreset = in.PolicyPreset
 	out.LogPath = in.LogPath
 	out.LogMaxAge = in.LogMaxAge
 	out.LogMaxBackup = in.LogMaxBackup
@@ -1923,6 +3118,7 @@ func Convert_v1beta2_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(in *St
 
 func autoConvert_kubeone_StaticAuditLogConfig_To_v1beta2_StaticAuditLogConfig(in *kubeone.StaticAuditLogConfig, out *StaticAuditLogConfig, s conversion.Scope) error {
 	out.PolicyFilePath = in.PolicyFilePath
+	out.PolicyPreset = in.PolicyPreset
 	out.LogPath = in.LogPath
 	out.LogMaxAge = in.LogMaxAge
 	out.LogMaxBackup = in.LogMaxBackup
@@ -1935,6 +3131,32 @@ func Convert_kubeone_StaticAuditLogConfig_To_v1beta2_StaticAuditLogConfig(in *ku
 	return autoConvert_kubeone_StaticAuditLogConfig_To_v1beta2_StaticAuditLogConfig(in, out, s)
 }
 
+func autoConvert_v1beta2_StaticAuth_To_kubeone_StaticAuth(in *StaticAuth, out *kubeone.StaticAuth, s conversion.Scope) error {
+	out.ClientCABundle = in.ClientCABundle
+	out.ClientCABundleFilePath = in.ClientCABundleFilePath
+	out.AnonymousAuth = (*bool)(unsafe.Pointer(in.AnonymousAuth))
+	out.Profiling = (*bool)(unsafe.Pointer(in.Profiling))
+	return nil
+}
+
+// Convert_v1beta2_StaticAuth_To_kubeone_StaticAuth is an autogenerated conversion function.
+func Convert_v1beta2_StaticAuth_To_kubeone_StaticAuth(in *StaticAuth, out *kubeone.StaticAuth, s conversion.Scope) error {
+	return autoConvert_v1beta2_StaticAuth_To_kubeone_StaticAuth(in, out, s)
+}
+
+func autoConvert_kubeone_StaticAuth_To_v1beta2_StaticAuth(in *kubeone.StaticAuth, out *StaticAuth, s conversion.Scope) error {
+	out.ClientCABundle = in.ClientCABundle
+	out.ClientCABundleFilePath = in.ClientCABundleFilePath
+	out.AnonymousAuth = (*bool)(unsafe.Pointer(in.AnonymousAuth))
+	out.Profiling = (*bool)(unsafe.Pointer(in.Profiling))
+	return nil
+}
+
+// Convert_kubeone_StaticAuth_To_v1beta2_StaticAuth is an autogenerated conversion function.
+func Convert_kubeone_StaticAuth_To_v1beta2_StaticAuth(in *kubeone.StaticAuth, out *StaticAuth, s conversion.Scope) error {
+	return autoConvert_kubeone_StaticAuth_To_v1beta2_StaticAuth(in, out, s)
+}
+
 func autoConvert_v1beta2_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(in *StaticWorkersConfig, out *kubeone.StaticWorkersConfig, s conversion.Scope) error {
 	out.Hosts = *(*[]kubeone.HostConfig)(unsafe.Pointer(&in.Hosts))
 	return nil
@@ -1999,6 +3221,7 @@ func Convert_kubeone_VMwareCloudDirectorSpec_To_v1beta2_VMwareCloudDirectorSpec(
 
 func autoConvert_v1beta2_VersionConfig_To_kubeone_VersionConfig(in *VersionConfig, out *kubeone.VersionConfig, s conversion.Scope) error {
 	out.Kubernetes = in.Kubernetes
+	out.KubeadmConfigAPIVersion = in.KubeadmConfigAPIVersion
 	return nil
 }
 
@@ -2009,6 +3232,7 @@ func Convert_v1beta2_VersionConfig_To_kubeone_VersionConfig(in *VersionConfig, o
 
 func autoConvert_kubeone_VersionConfig_To_v1beta2_VersionConfig(in *kubeone.VersionConfig, out *VersionConfig, s conversion.Scope) error {
 	out.Kubernetes = in.Kubernetes
+	out.KubeadmConfigAPIVersion = in.KubeadmConfigAPIVersion
 	return nil
 }
 
@@ -2054,3 +3278,27 @@ func autoConvert_kubeone_WeaveNetSpec_To_v1beta2_WeaveNetSpec(in *kubeone.WeaveN
 func Convert_kubeone_WeaveNetSpec_To_v1beta2_WeaveNetSpec(in *kubeone.WeaveNetSpec, out *WeaveNetSpec, s conversion.Scope) error {
 	return autoConvert_kubeone_WeaveNetSpec_To_v1beta2_WeaveNetSpec(in, out, s)
 }
+
+func autoConvert_v1beta2_WebhookCABundleInjection_To_kubeone_WebhookCABundleInjection(in *WebhookCABundleInjection, out *kubeone.WebhookCABundleInjection, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.ValidatingWebhookConfigurations = *(*[]string)(unsafe.Pointer(&in.ValidatingWebhookConfigurations))
+	out.MutatingWebhookConfigurations = *(*[]string)(unsafe.Pointer(&in.MutatingWebhookConfigurations))
+	return nil
+}
+
+// Convert_v1beta2_WebhookCABundleInjection_To_kubeone_WebhookCABundleInjection is an autogenerated conversion function.
+func Convert_v1beta2_WebhookCABundleInjection_To_kubeone_WebhookCABundleInjection(in *WebhookCABundleInjection, out *kubeone.WebhookCABundleInjection, s conversion.Scope) error {
+	return autoConvert_v1beta2_WebhookCABundleInjection_To_kubeone_WebhookCABundleInjection(in, out, s)
+}
+
+func autoConvert_kubeone_WebhookCABundleInjection_To_v1beta2_WebhookCABundleInjection(in *kubeone.WebhookCABundleInjection, out *WebhookCABundleInjection, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.ValidatingWebhookConfigurations = *(*[]string)(unsafe.Pointer(&in.ValidatingWebhookConfigurations))
+	out.MutatingWebhookConfigurations = *(*[]string)(unsafe.Pointer(&in.MutatingWebhookConfigurations))
+	return nil
+}
+
+// Convert_kubeone_WebhookCABundleInjection_To_v1beta2_WebhookCABundleInjection is an autogenerated conversion function.
+func Convert_kubeone_WebhookCABundleInjection_To_v1beta2_WebhookCABundleInjection(in *kubeone.WebhookCABundleInjection, out *WebhookCABundleInjection, s conversion.Scope) error {
+	return autoConvert_kubeone_WebhookCABundleInjection_To_v1beta2_WebhookCABundleInjection(in, out, s)
+}
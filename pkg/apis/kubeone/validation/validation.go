@@ -19,19 +19,37 @@ package validation
 import (
 	"bytes"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/url"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/distribution/distribution/v3/reference"
+	"github.com/pkg/errors"
 
 	"k8c.io/kubeone/pkg/addons"
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/containerruntime"
 	"k8c.io/kubeone/pkg/semverutil"
+	"k8c.io/kubeone/pkg/templates/auditpolicy"
+	"k8c.io/kubeone/pkg/templates/resources"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -39,11 +57,110 @@ const (
 	lowerVersionConstraint = ">= 1.20"
 	// upperVersionConstraint defines a semver constraint that validates Kubernetes versions against an upper bound
 	upperVersionConstraint = "<= 1.24"
+	// fieldManagerMaxLength is the maximum length of the server-side apply field manager name
+	fieldManagerMaxLength = 128
+	// defaultKubeletMaxPods is the maximum number of pods per node used by kubelet by default
+	defaultKubeletMaxPods = 110
+	// nodeCIDRMaskSizeMaxDiff is the maximum difference between the pod subnet
+	// prefix length and the node CIDR mask size supported by kube-controller-manager
+	nodeCIDRMaskSizeMaxDiff = 16
+	// maxBootstrapTokenTTL is the maximum TTL of the bootstrap tokens, the
+	// same as the default TTL of the tokens created by kubeadm
+	maxBootstrapTokenTTL = 24 * time.Hour
+	// minServiceAccountMaxTokenExpiration and maxServiceAccountMaxTokenExpiration
+	// are the bounds of the service account max token expiration accepted by
+	// the kube-apiserver
+	minServiceAccountMaxTokenExpiration = time.Hour
+	maxServiceAccountMaxTokenExpiration = (1 << 32) * time.Second
+	// kubeoneNodeMetadataPrefix is the prefix of the Node labels and
+	// annotations managed by KubeOne
+	kubeoneNodeMetadataPrefix = "v1.kubeone.io/"
 )
 
 var (
 	lowerConstraint = semverutil.MustParseConstraint(lowerVersionConstraint)
 	upperConstraint = semverutil.MustParseConstraint(upperVersionConstraint)
+
+	// kubeadmConfigAPIVersionConstraints defines the Kubernetes versions supporting the given kubeadm configuration API version
+	kubeadmConfigAPIVersionConstraints = map[string]string{
+		kubeoneapi.KubeadmConfigAPIVersionV1Beta2: "< 1.26",
+		kubeoneapi.KubeadmConfigAPIVersionV1Beta3: ">= 1.22",
+	}
+
+	// dockerLogDrivers is a list of log drivers built into dockerd
+	dockerLogDrivers = []string{
+		"awslogs",
+		"fluentd",
+		"gcplogs",
+		"gelf",
+		"journald",
+		containerruntime.DockerLogDriverJSONFile,
+		containerruntime.DockerLogDriverLocal,
+		"logentries",
+		"none",
+		"splunk",
+		"syslog",
+	}
+	dockerLogSizeRegexp = regexp.MustCompile(`^[1-9][0-9]*[kmg]?$`)
+
+	// apiServerEndpointReconcilerTypes is a set of endpoint reconcilers supported by the kube-apiserver
+	apiServerEndpointReconcilerTypes = sets.NewString("lease", "master-count", "none")
+	// apiServerFeatureGateConstraints defines the Kubernetes versions supporting the given kube-apiserver feature gate
+	apiServerFeatureGateConstraints = map[string]string{
+		"APIServerIdentity":         ">= 1.20",
+		"StorageVersionAPI":         ">= 1.20",
+		"APIServerTracing":          ">= 1.22",
+		"OpenAPIV3":                 ">= 1.23",
+		"ServerSideFieldValidation": ">= 1.23",
+	}
+	featureGateNameRegexp = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+	// kubeletReservableResources is a set of resources that can be reserved for system and Kubernetes daemons
+	kubeletReservableResources = sets.NewString("cpu", "memory", "ephemeral-storage", "pid")
+	// kubeletEvictionSignals is a set of signals supported by the kubelet hard eviction
+	kubeletEvictionSignals = sets.NewString("memory.available", "allocatableMemory.available", "nodefs.available", "nodefs.inodesFree", "imagefs.available", "imagefs.inodesFree", "pid.available")
+	// kubeletEnforceNodeAllocatableKeys is a set of values supported by the kubelet --enforce-node-allocatable flag
+	kubeletEnforceNodeAllocatableKeys = sets.NewString("pods", "system-reserved", "kube-reserved", "none")
+
+	ccmFlagNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+	// fieldManagerRegexp restricts the server-side apply field manager name
+	// to characters safe to be passed to kubectl
+	fieldManagerRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.:/-]*[a-zA-Z0-9])?$`)
+	// ccmKnownFlags are the commonly tuned cloud-controller-manager flags
+	// with a known value format
+	ccmKnownFlags = map[string]func(string) error{
+		"allocate-node-cidrs":             validateBoolFlag,
+		"concurrent-service-syncs":        validateIntFlag,
+		"configure-cloud-routes":          validateBoolFlag,
+		"kube-api-burst":                  validateIntFlag,
+		"kube-api-qps":                    validateFloatFlag,
+		"leader-elect":                    validateBoolFlag,
+		"leader-elect-lease-duration":     validateDurationFlag,
+		"leader-elect-renew-deadline":     validateDurationFlag,
+		"leader-elect-retry-period":       validateDurationFlag,
+		"min-resync-period":               validateDurationFlag,
+		"node-monitor-period":             validateDurationFlag,
+		"node-status-update-frequency":    validateDurationFlag,
+		"route-reconciliation-period":     validateDurationFlag,
+		"use-service-account-credentials": validateBoolFlag,
+		"v":                               validateIntFlag,
+	}
+
+	// controlPlanePorts is a list of ports used by the components running on
+	// the control plane nodes that the etcd metrics endpoint must not use
+	controlPlanePorts = map[int]string{
+		2379:  "etcd client",
+		2380:  "etcd peer",
+		2381:  "etcd local metrics and health checks",
+		6443:  "kube-apiserver",
+		10248: "kubelet healthz",
+		10249: "kube-proxy metrics",
+		10250: "kubelet",
+		10256: "kube-proxy healthz",
+		10257: "kube-controller-manager",
+		10259: "kube-scheduler",
+	}
 )
 
 // ValidateKubeOneCluster validates the KubeOneCluster object
@@ -54,21 +171,44 @@ func ValidateKubeOneCluster(c kubeoneapi.KubeOneCluster) field.ErrorList {
 	allErrs = append(allErrs, ValidateControlPlaneConfig(c.ControlPlane, field.NewPath("controlPlane"))...)
 	allErrs = append(allErrs, ValidateAPIEndpoint(c.APIEndpoint, field.NewPath("apiEndpoint"))...)
 	allErrs = append(allErrs, ValidateCloudProviderSpec(c.CloudProvider, field.NewPath("provider"))...)
+	if c.CloudProvider.CSISnapshotController != nil {
+		allErrs = append(allErrs, ValidateCSISnapshotController(c, field.NewPath("provider", "csiSnapshotController"))...)
+	}
 	allErrs = append(allErrs, ValidateVersionConfig(c.Versions, field.NewPath("versions"))...)
 	allErrs = append(allErrs, ValidateKubernetesSupport(c, field.NewPath(""))...)
 	allErrs = append(allErrs, ValidateContainerRuntimeConfig(c.ContainerRuntime, c.Versions, field.NewPath("containerRuntime"))...)
 	allErrs = append(allErrs, ValidateClusterNetworkConfig(c.ClusterNetwork, field.NewPath("clusterNetwork"))...)
+	allErrs = append(allErrs, ValidateNodeCIDRMaskSize(c, field.NewPath("clusterNetwork", "nodeCIDRMaskSize"))...)
 	allErrs = append(allErrs, ValidateStaticWorkersConfig(c.StaticWorkers, field.NewPath("staticWorkers"))...)
 
 	if c.MachineController != nil && c.MachineController.Deploy {
-		allErrs = append(allErrs, ValidateDynamicWorkerConfig(c.DynamicWorkers, field.NewPath("dynamicWorkers"))...)
+		allErrs = append(allErrs, ValidateDynamicWorkerConfig(c.DynamicWorkers, c.CloudProvider, field.NewPath("dynamicWorkers"))...)
+		if !c.OperatingSystemManagerEnabled() {
+			for _, w := range c.DynamicWorkers {
+				if len(w.Config.BootstrapCommands) > 0 {
+					allErrs = append(allErrs, field.Forbidden(field.NewPath("dynamicWorkers").Child("providerSpec", "bootstrapCommands"),
+						"bootstrapCommands require operating-system-manager to be enabled"))
+
+					break
+				}
+			}
+		}
 	} else if len(c.DynamicWorkers) > 0 {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("dynamicWorkers"),
 			"machine-controller deployment is disabled, but the configuration still contains dynamic workers"))
 	}
 
 	allErrs = append(allErrs, ValidateCABundle(c.CABundle, field.NewPath("caBundle"))...)
+	allErrs = append(allErrs, ValidateCertificateAuthority(c.CertificateAuthority, field.NewPath("certificateAuthority"))...)
+	allErrs = append(allErrs, ValidateControlPlaneComponents(c.ControlPlaneComponents, c.Versions, field.NewPath("controlPlaneComponents"))...)
+	allErrs = append(allErrs, ValidatePostApplyValidation(c.PostApplyValidation, field.NewPath("postApplyValidation"))...)
+	allErrs = append(allErrs, ValidateBootstrapTokenConfig(c.BootstrapToken, field.NewPath("bootstrapToken"))...)
+	allErrs = append(allErrs, ValidateNamespaces(c.Namespaces, field.NewPath("namespaces"))...)
+	if c.Firewall != nil {
+		allErrs = append(allErrs, ValidateFirewallConfig(*c.Firewall, field.NewPath("firewall"))...)
+	}
 	allErrs = append(allErrs, ValidateFeatures(c.Features, c.Versions, field.NewPath("features"))...)
+	allErrs = append(allErrs, ValidateAnonymousAuth(c, field.NewPath("features", "staticAuth", "anonymousAuth"))...)
 	allErrs = append(allErrs, ValidateAddons(c.Addons, field.NewPath("addons"))...)
 	allErrs = append(allErrs, ValidateRegistryConfiguration(c.RegistryConfiguration, field.NewPath("registryConfiguration"))...)
 	allErrs = append(allErrs,
@@ -129,6 +269,22 @@ func ValidateControlPlaneConfig(c kubeoneapi.ControlPlaneConfig, fldPath *field.
 
 	if len(c.Hosts) > 0 {
 		allErrs = append(allErrs, ValidateHostConfig(c.Hosts, fldPath.Child("hosts"))...)
+		dnsNames := map[string]bool{}
+		for i, h := range c.Hosts {
+			if h.BindAddresses != nil {
+				allErrs = append(allErrs, ValidateBindAddresses(h, fldPath.Child("hosts").Index(i).Child("bindAddresses"))...)
+			}
+			if h.DNSName != "" {
+				dnsNamePath := fldPath.Child("hosts").Index(i).Child("dnsName")
+				for _, msg := range validation.IsDNS1123Subdomain(strings.ToLower(h.DNSName)) {
+					allErrs = append(allErrs, field.Invalid(dnsNamePath, h.DNSName, msg))
+				}
+				if dnsNames[strings.ToLower(h.DNSName)] {
+					allErrs = append(allErrs, field.Duplicate(dnsNamePath, h.DNSName))
+				}
+				dnsNames[strings.ToLower(h.DNSName)] = true
+			}
+		}
 	} else {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("hosts"), "",
 			".controlPlane.Hosts is a required field. There must be at least one control plane instance in the cluster."))
@@ -162,6 +318,55 @@ func ValidateAPIEndpoint(a kubeoneapi.APIEndpoint, fldPath *field.Path) field.Er
 		}
 	}
 
+	if a.InternalPort < 0 || a.InternalPort > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("internalPort"), a.InternalPort, "apiEndpoint.internalPort must be between 1 and 65535"))
+	}
+	if a.InternalPort != 0 && a.InternalHost == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("internalPort"), "apiEndpoint.internalPort requires apiEndpoint.internalHost to be set"))
+	}
+
+	visitedStandby := map[string]bool{}
+	for i, standby := range a.StandbyEndpoints {
+		standbyPath := fldPath.Child("standbyEndpoints").Index(i)
+		if standby.Host == "" {
+			allErrs = append(allErrs, field.Required(standbyPath.Child("host"), "standby endpoint host is required"))
+		} else if standby.Host == a.Host || visitedStandby[standby.Host] {
+			allErrs = append(allErrs, field.Duplicate(standbyPath.Child("host"), standby.Host))
+		}
+		visitedStandby[standby.Host] = true
+
+		if standby.Port < 0 || standby.Port > 65535 {
+			allErrs = append(allErrs, field.Invalid(standbyPath.Child("port"), standby.Port, "standby endpoint port must be between 1 and 65535"))
+		}
+	}
+
+	if a.HealthCheck != nil {
+		allErrs = append(allErrs, ValidateAPIEndpointHealthCheck(*a.HealthCheck, fldPath.Child("healthCheck"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateAPIEndpointHealthCheck validates the APIEndpointHealthCheck structure
+func ValidateAPIEndpointHealthCheck(h kubeoneapi.APIEndpointHealthCheck, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if h.Port < 0 || h.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), h.Port, "health check port must be between 1 and 65535"))
+	}
+
+	if h.Path != "" {
+		u, err := url.Parse(h.Path)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), h.Path, fmt.Sprintf("invalid health check path: %v", err)))
+		case !strings.HasPrefix(h.Path, "/"):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), h.Path, "health check path must be an absolute path"))
+		case u.Path != h.Path:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), h.Path, "health check path must not contain a host, query or fragment"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -262,6 +467,87 @@ func ValidateCloudProviderSpec(p kubeoneapi.CloudProviderSpec, fldPath *field.Pa
 		}
 	}
 
+	if p.CCM != nil {
+		allErrs = append(allErrs, ValidateCCMConfig(p, fldPath.Child("ccm"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateCSISnapshotController validates that the CSI external
+// snapshot-controller is compatible with the CSI driver deployed by KubeOne,
+// i.e. the driver supports snapshots and doesn't ship its own controller
+func ValidateCSISnapshotController(c kubeoneapi.KubeOneCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !c.CloudProvider.CSISnapshotController.Enabled() {
+		return allErrs
+	}
+
+	p := c.CloudProvider
+	provider := p.CloudProviderName()
+
+	// AWS and Azure CSI drivers are deployed for the external cloud provider
+	// and for Kubernetes 1.23+ regardless of the cloud provider
+	csiRequired := p.External
+	if v, err := semver.NewVersion(c.Versions.Kubernetes); err == nil && v.Minor() >= 23 {
+		csiRequired = true
+	}
+
+	switch {
+	case p.AWS != nil && csiRequired,
+		p.Azure != nil && csiRequired,
+		p.DigitalOcean != nil,
+		p.Nutanix != nil,
+		p.Vsphere != nil && p.External:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enable"),
+			fmt.Sprintf("the CSI driver deployed by KubeOne for %q already ships the snapshot-controller", provider)))
+	case p.Hetzner != nil, p.VMwareCloudDirector != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enable"),
+			fmt.Sprintf("the CSI driver deployed by KubeOne for %q doesn't support volume snapshots", provider)))
+	}
+
+	return allErrs
+}
+
+// ValidateCCMConfig validates the CCMConfig structure
+func ValidateCCMConfig(p kubeoneapi.CloudProviderSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !p.External {
+		allErrs = append(allErrs, field.Forbidden(fldPath, ".cloudProvider.ccm is supported only for clusters using external cloud provider (.cloudProvider.external)"))
+	}
+
+	if p.CCM.Replicas != nil {
+		switch {
+		case p.AWS != nil, p.Openstack != nil, p.Vsphere != nil:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("replicas"), "cloud-controller-manager for this provider runs as a DaemonSet on the control plane nodes"))
+		case *p.CCM.Replicas < 1:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *p.CCM.Replicas, "replicas must be at least 1"))
+		}
+	}
+
+	argsPath := fldPath.Child("extraArgs")
+	for name, value := range p.CCM.ExtraArgs {
+		if !ccmFlagNameRegexp.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(argsPath.Key(name), name, "flag name must consist of lower case alphanumeric characters and '-', without the leading dashes"))
+
+			continue
+		}
+
+		if name == "cloud-provider" {
+			allErrs = append(allErrs, field.Forbidden(argsPath.Key(name), "cloud-provider flag is managed by KubeOne"))
+
+			continue
+		}
+
+		if validate, ok := ccmKnownFlags[name]; ok {
+			if err := validate(value); err != nil {
+				allErrs = append(allErrs, field.Invalid(argsPath.Key(name), value, err.Error()))
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -292,6 +578,35 @@ func ValidateVersionConfig(version kubeoneapi.VersionConfig, fldPath *field.Path
 		}
 	}
 
+	if version.KubeadmConfigAPIVersion != "" {
+		allErrs = append(allErrs, ValidateKubeadmConfigAPIVersion(version.KubeadmConfigAPIVersion, v, fldPath.Child("kubeadmConfigAPIVersion"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateKubeadmConfigAPIVersion validates that the kubeadm configuration API version is supported by the Kubernetes version
+func ValidateKubeadmConfigAPIVersion(apiVersion string, kubeVersion *semver.Version, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	constraint, ok := kubeadmConfigAPIVersionConstraints[apiVersion]
+	if !ok {
+		supported := []string{}
+		for v := range kubeadmConfigAPIVersionConstraints {
+			supported = append(supported, v)
+		}
+		sort.Strings(supported)
+
+		allErrs = append(allErrs, field.NotSupported(fldPath, apiVersion, supported))
+
+		return allErrs
+	}
+
+	if !semverutil.MustParseConstraint(constraint).Check(kubeVersion) {
+		allErrs = append(allErrs, field.Invalid(fldPath, apiVersion,
+			fmt.Sprintf("kubeadm configuration API version %s requires Kubernetes %s", apiVersion, constraint)))
+	}
+
 	return allErrs
 }
 
@@ -338,11 +653,188 @@ func ValidateContainerRuntimeConfig(cr kubeoneapi.ContainerRuntimeConfig, versio
 		if gteKube124Condition.Check(kubeVer) {
 			allErrs = append(allErrs, field.Invalid(fldPath, cr.Docker, "kubernetes v1.24+ requires containerd container runtime"))
 		}
+
+		allErrs = append(allErrs, ValidateDockerLogConfig(*cr.Docker, fldPath.Child("docker"))...)
+	}
+
+	if cr.Containerd != nil {
+		allErrs = append(allErrs, ValidateContainerdCRIConfig(*cr.Containerd, fldPath.Child("containerd"))...)
+	}
+
+	if cr.Cgroups != nil {
+		allErrs = append(allErrs, ValidateCgroupsConfig(*cr.Cgroups, fldPath.Child("cgroups"))...)
+	}
+
+	allErrs = append(allErrs, ValidatePreloadImages(cr.PreloadImages, fldPath.Child("preloadImages"))...)
+
+	return allErrs
+}
+
+// ValidatePreloadImages validates the images pulled on all nodes
+func ValidatePreloadImages(images []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, img := range images {
+		if _, err := reference.ParseNormalizedNamed(img); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), img, fmt.Sprintf("invalid image reference: %v", err)))
+
+			continue
+		}
+		if seen.Has(img) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), img))
+		}
+		seen.Insert(img)
+	}
+
+	return allErrs
+}
+
+// ValidateContainerdCRIConfig validates the containerd CRI plugin settings
+func ValidateContainerdCRIConfig(c kubeoneapi.ContainerRuntimeContainerd, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.StreamIdleTimeout != "" {
+		if dur, err := time.ParseDuration(c.StreamIdleTimeout); err != nil || dur <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("streamIdleTimeout"), c.StreamIdleTimeout, "must be a positive duration (e.g. 5m)"))
+		}
+	}
+
+	if c.StreamServerAddress != "" && net.ParseIP(c.StreamServerAddress) == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("streamServerAddress"), c.StreamServerAddress, "must be a valid IP address"))
+	}
+
+	if c.StreamServerPort < 0 || c.StreamServerPort > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("streamServerPort"), c.StreamServerPort, "must be a valid port number"))
+	}
+
+	for name, runtime := range c.Runtimes {
+		runtimePath := fldPath.Child("runtimes").Key(name)
+
+		if name == "runc" {
+			allErrs = append(allErrs, field.Forbidden(runtimePath, "runc runtime is configured by KubeOne and can't be overridden"))
+		}
+		for _, msg := range validation.IsDNS1123Label(name) {
+			allErrs = append(allErrs, field.Invalid(runtimePath, name, msg))
+		}
+		if runtime.RuntimeType == "" {
+			allErrs = append(allErrs, field.Required(runtimePath.Child("runtimeType"), "runtimeType is a required field"))
+		}
+		if runtime.RuntimeType == containerruntime.RuncRuntimeType && runtime.BinaryName == "" {
+			allErrs = append(allErrs, field.Required(runtimePath.Child("binaryName"), fmt.Sprintf("binaryName is required for the %s runtime type", containerruntime.RuncRuntimeType)))
+		}
+		if runtime.BinaryName != "" && !strings.HasPrefix(runtime.BinaryName, "/") {
+			allErrs = append(allErrs, field.Invalid(runtimePath.Child("binaryName"), runtime.BinaryName, "binaryName must be an absolute path"))
+		}
+		if runtime.RuntimeClass != nil {
+			nodeSelectorPath := runtimePath.Child("runtimeClass", "nodeSelector")
+			for k, v := range runtime.RuntimeClass.NodeSelector {
+				for _, msg := range validation.IsQualifiedName(k) {
+					allErrs = append(allErrs, field.Invalid(nodeSelectorPath, k, msg))
+				}
+				for _, msg := range validation.IsValidLabelValue(v) {
+					allErrs = append(allErrs, field.Invalid(nodeSelectorPath.Key(k), v, msg))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCgroupsConfig validates the CgroupsConfig structure
+func ValidateCgroupsConfig(c kubeoneapi.CgroupsConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch c.Version {
+	case kubeoneapi.CgroupVersionV1, kubeoneapi.CgroupVersionV2:
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), "cgroup version is required"))
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("version"), c.Version, []string{kubeoneapi.CgroupVersionV1, kubeoneapi.CgroupVersionV2}))
+	}
+
+	if c.ConfigureKernelCommandLine && c.Version != kubeoneapi.CgroupVersionV2 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("configureKernelCommandLine"), "kernel command line can be configured only to enable cgroup v2"))
+	}
+
+	return allErrs
+}
+
+// ValidateDockerLogConfig validates the docker log driver and log options
+func ValidateDockerLogConfig(d kubeoneapi.ContainerRuntimeDocker, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	logDriver := d.LogDriver
+	if logDriver == "" {
+		logDriver = containerruntime.DockerLogDriverJSONFile
+	}
+
+	var supported bool
+	for _, ld := range dockerLogDrivers {
+		if ld == logDriver {
+			supported = true
+
+			break
+		}
+	}
+	if !supported {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("logDriver"), d.LogDriver, dockerLogDrivers))
+	}
+
+	logOptsPath := fldPath.Child("logOpts")
+	for _, opt := range []string{"max-size", "max-file"} {
+		if _, ok := d.LogOpts[opt]; ok && !containerruntime.DockerLogDriverSupportsRotation(logDriver) {
+			allErrs = append(allErrs, field.Forbidden(logOptsPath.Key(opt),
+				fmt.Sprintf("only supported by the %q and %q log drivers", containerruntime.DockerLogDriverJSONFile, containerruntime.DockerLogDriverLocal)))
+		}
+	}
+
+	if maxSize, ok := d.LogOpts["max-size"]; ok && !dockerLogSizeRegexp.MatchString(containerruntime.DockerLogSize(maxSize)) {
+		allErrs = append(allErrs, field.Invalid(logOptsPath.Key("max-size"), maxSize, "must be a positive size with an optional k, m or g unit (e.g. 100m)"))
+	}
+
+	if maxFile, ok := d.LogOpts["max-file"]; ok {
+		if n, err := strconv.Atoi(maxFile); err != nil || n < 1 {
+			allErrs = append(allErrs, field.Invalid(logOptsPath.Key("max-file"), maxFile, "must be a positive integer"))
+		}
 	}
 
 	return allErrs
 }
 
+func validateBoolFlag(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("must be a boolean")
+	}
+
+	return nil
+}
+
+func validateIntFlag(value string) error {
+	if _, err := strconv.Atoi(value); err != nil {
+		return fmt.Errorf("must be an integer")
+	}
+
+	return nil
+}
+
+func validateFloatFlag(value string) error {
+	if _, err := strconv.ParseFloat(value, 32); err != nil {
+		return fmt.Errorf("must be a number")
+	}
+
+	return nil
+}
+
+func validateDurationFlag(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("must be a duration (e.g. 30s)")
+	}
+
+	return nil
+}
+
 // ValidateClusterNetworkConfig validates the ClusterNetworkConfig structure
 func ValidateClusterNetworkConfig(c kubeoneapi.ClusterNetworkConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -357,6 +849,11 @@ func ValidateClusterNetworkConfig(c kubeoneapi.ClusterNetworkConfig, fldPath *fi
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceSubnet"), c.ServiceSubnet, ".clusterNetwork.serviceSubnet must be a valid CIDR string"))
 		}
 	}
+	if len(c.NodePortRange) > 0 {
+		if _, _, err := c.NodePortRangeBounds(); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodePortRange"), c.NodePortRange, err.Error()))
+		}
+	}
 
 	if c.CNI != nil {
 		allErrs = append(allErrs, ValidateCNI(c.CNI, fldPath.Child("cni"))...)
@@ -369,6 +866,90 @@ func ValidateClusterNetworkConfig(c kubeoneapi.ClusterNetworkConfig, fldPath *fi
 	if c.KubeProxy != nil {
 		allErrs = append(allErrs, ValidateKubeProxy(c.KubeProxy, fldPath.Child("kubeProxy"))...)
 	}
+	if c.NodeConnectivityCheck != nil {
+		if mtu := c.NodeConnectivityCheck.MTU; mtu != 0 && (mtu < 576 || mtu > 9216) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeConnectivityCheck", "mtu"), mtu, "mtu must be between 576 and 9216"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateNodeCIDRMaskSize validates that the node pod CIDRs fit into the pod
+// subnet and provide enough addresses for the kubelet maxPods on all nodes
+func ValidateNodeCIDRMaskSize(cluster kubeoneapi.KubeOneCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	c := cluster.ClusterNetwork
+	if c.NodeCIDRMaskSize == nil {
+		return allErrs
+	}
+
+	// the largest maxPods of all nodes, nodes without maxPods use the kubelet default
+	maxPods := int32(0)
+	for _, hosts := range [][]kubeoneapi.HostConfig{cluster.ControlPlane.Hosts, cluster.StaticWorkers.Hosts} {
+		for _, h := range hosts {
+			hostMaxPods := int32(defaultKubeletMaxPods)
+			if h.Kubelet.MaxPods != nil {
+				hostMaxPods = *h.Kubelet.MaxPods
+			}
+			if hostMaxPods > maxPods {
+				maxPods = hostMaxPods
+			}
+		}
+	}
+	if maxPods == 0 {
+		maxPods = defaultKubeletMaxPods
+	}
+
+	// invalid pod subnet is reported by ValidateClusterNetworkConfig
+	var podSubnet *net.IPNet
+	if c.PodSubnet != "" {
+		_, podSubnet, _ = net.ParseCIDR(c.PodSubnet)
+	}
+
+	for _, family := range []struct {
+		name     string
+		maskSize *int32
+		bits     int
+	}{
+		{name: "ipv4", maskSize: c.NodeCIDRMaskSize.IPv4, bits: net.IPv4len * 8},
+		{name: "ipv6", maskSize: c.NodeCIDRMaskSize.IPv6, bits: net.IPv6len * 8},
+	} {
+		if family.maskSize == nil {
+			continue
+		}
+
+		maskSize := int(*family.maskSize)
+		if maskSize < 1 || maskSize > family.bits {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(family.name), maskSize, fmt.Sprintf("mask size must be between 1 and %d", family.bits)))
+
+			continue
+		}
+
+		if podSubnet != nil {
+			ones, bits := podSubnet.Mask.Size()
+			switch {
+			case bits != family.bits:
+				// kube-controller-manager refuses to start with the mask size
+				// of the family not present in the cluster CIDR
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child(family.name), fmt.Sprintf("mask size can't be set, the pod subnet %s has no %s addresses", c.PodSubnet, family.name)))
+
+				continue
+			case maskSize < ones:
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(family.name), maskSize, fmt.Sprintf("mask size can't be smaller than the pod subnet %s prefix length", c.PodSubnet)))
+			case maskSize-ones > nodeCIDRMaskSizeMaxDiff:
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(family.name), maskSize, fmt.Sprintf("mask size can be at most %d bits larger than the pod subnet %s prefix length", nodeCIDRMaskSizeMaxDiff, c.PodSubnet)))
+			}
+		}
+
+		// node CIDRs with 31 or more host bits are always large enough
+		if hostBits := family.bits - maskSize; hostBits < 31 {
+			if podAddresses := (1 << hostBits) - 2; podAddresses < int(maxPods) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(family.name), maskSize, fmt.Sprintf("node CIDR provides only %d pod addresses, but kubelet maxPods is %d", podAddresses, maxPods)))
+			}
+		}
+	}
 
 	return allErrs
 }
@@ -436,13 +1017,21 @@ func ValidateStaticWorkersConfig(staticWorkers kubeoneapi.StaticWorkersConfig, f
 
 	if len(staticWorkers.Hosts) > 0 {
 		allErrs = append(allErrs, ValidateHostConfig(staticWorkers.Hosts, fldPath.Child("hosts"))...)
+		for i, h := range staticWorkers.Hosts {
+			if h.BindAddresses != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("hosts").Index(i).Child("bindAddresses"), "bindAddresses can be set only for the control plane hosts"))
+			}
+			if h.DNSName != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("hosts").Index(i).Child("dnsName"), "dnsName can be set only for the control plane hosts"))
+			}
+		}
 	}
 
 	return allErrs
 }
 
 // ValidateDynamicWorkerConfig validates the DynamicWorkerConfig structure
-func ValidateDynamicWorkerConfig(workerset []kubeoneapi.DynamicWorkerConfig, fldPath *field.Path) field.ErrorList {
+func ValidateDynamicWorkerConfig(workerset []kubeoneapi.DynamicWorkerConfig, provider kubeoneapi.CloudProviderSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	for _, w := range workerset {
@@ -455,80 +1044,979 @@ func ValidateDynamicWorkerConfig(workerset []kubeoneapi.DynamicWorkerConfig, fld
 		if len(w.Config.MachineAnnotations) > 0 && len(w.Config.NodeAnnotations) > 0 {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("machineAnnotations"), w.Config.MachineAnnotations, "machineAnnotations has been replaced with nodeAnnotations, only one of those two can be set"))
 		}
+		if w.Config.InstanceProfile != "" && provider.AWS == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("providerSpec", "instanceProfile"), "instanceProfile is supported only on AWS"))
+		}
+		if w.Config.CloudNetwork != nil {
+			allErrs = append(allErrs, validateCloudNetworkConfig(w.Config.CloudNetwork, provider, fldPath.Child("providerSpec", "cloudNetwork"))...)
+		}
+		if len(w.Config.BootstrapCommands) > 0 {
+			allErrs = append(allErrs, validateBootstrapCommands(w.Config.BootstrapCommands, fldPath.Child("providerSpec", "bootstrapCommands"))...)
+		}
+		if w.Config.Disks != nil {
+			allErrs = append(allErrs, validateDiskConfig(w.Config.Disks, provider, fldPath.Child("providerSpec", "disks"))...)
+		}
+		if w.Autoscaler != nil {
+			allErrs = append(allErrs, validateDynamicWorkerAutoscaler(w.Autoscaler, fldPath.Child("autoscaler"))...)
+			if w.Replicas != nil && (*w.Replicas < w.Autoscaler.MinReplicas || *w.Replicas > w.Autoscaler.MaxReplicas) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *w.Replicas, "replicas must be between autoscaler minReplicas and maxReplicas"))
+			}
+		}
 	}
 
 	return allErrs
 }
 
-func ValidateCABundle(caBundle string, fldPath *field.Path) field.ErrorList {
+// validateDynamicWorkerAutoscaler validates the DynamicWorkerAutoscaler structure
+func validateDynamicWorkerAutoscaler(autoscaler *kubeoneapi.DynamicWorkerAutoscaler, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	caPEM := bytes.TrimSpace([]byte(caBundle))
-	if len(caPEM) == 0 {
-		return allErrs
+	if autoscaler.MinReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), autoscaler.MinReplicas, "minReplicas must be greater than 0"))
 	}
-
-	pool := x509.NewCertPool()
-	if ok := pool.AppendCertsFromPEM(caPEM); !ok {
-		allErrs = append(allErrs, field.Invalid(fldPath, "", "can't parse caBundle"))
+	if autoscaler.MaxReplicas < autoscaler.MinReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), autoscaler.MaxReplicas, "maxReplicas must be greater or equal to minReplicas"))
 	}
 
 	return allErrs
 }
 
-// ValidateFeatures validates the Features structure
-func ValidateFeatures(f kubeoneapi.Features, versions kubeoneapi.VersionConfig, fldPath *field.Path) field.ErrorList {
+// validateBootstrapCommands validates that the bootstrap commands are
+// single-line shell commands with balanced quotes
+func validateBootstrapCommands(commands []string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if f.PodNodeSelector != nil && f.PodNodeSelector.Enable {
-		allErrs = append(allErrs, ValidatePodNodeSelectorConfig(f.PodNodeSelector.Config, fldPath.Child("podNodeSelector"))...)
-	}
-	if f.StaticAuditLog != nil && f.StaticAuditLog.Enable {
-		allErrs = append(allErrs, ValidateStaticAuditLogConfig(f.StaticAuditLog.Config, fldPath.Child("staticAuditLog"))...)
-	}
-	if f.OpenIDConnect != nil && f.OpenIDConnect.Enable {
-		allErrs = append(allErrs, ValidateOIDCConfig(f.OpenIDConnect.Config, fldPath.Child("openidConnect"))...)
+	for i, cmd := range commands {
+		switch {
+		case strings.TrimSpace(cmd) == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cmd, "bootstrap command can't be empty"))
+		case strings.IndexFunc(cmd, func(r rune) bool { return unicode.IsControl(r) && r != '\t' }) >= 0:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cmd, "bootstrap command must be a single line without control characters"))
+		case !shellQuotesBalanced(cmd):
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cmd, "bootstrap command has unbalanced quotes"))
+		}
 	}
 
 	return allErrs
 }
 
-// ValidatePodNodeSelectorConfig validates the PodNodeSelectorConfig structure
-func ValidatePodNodeSelectorConfig(n kubeoneapi.PodNodeSelectorConfig, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-
-	if len(n.ConfigFilePath) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("configFilePath"), ".podNodeSelector.config.configFilePath is a required field"))
+// shellQuotesBalanced checks if all single and double quotes in the command
+// are closed, taking backslash escaping into account
+func shellQuotesBalanced(cmd string) bool {
+	var quote rune
+	escaped := false
+
+	for _, r := range cmd {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote == 0 && (r == '\'' || r == '"'):
+			quote = r
+		case r == quote:
+			quote = 0
+		}
 	}
 
-	return allErrs
+	return quote == 0 && !escaped
 }
 
-// ValidateStaticAuditLogConfig validates the StaticAuditLogConfig structure
-func ValidateStaticAuditLogConfig(s kubeoneapi.StaticAuditLogConfig, fldPath *field.Path) field.ErrorList {
+// validateCloudNetworkConfig validates the CloudNetworkConfig structure against the used provider
+func validateCloudNetworkConfig(cloudNetwork *kubeoneapi.CloudNetworkConfig, provider kubeoneapi.CloudProviderSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if len(s.PolicyFilePath) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("policyFilePath"), ".staticAuditLog.config.policyFilePath is a required field"))
-	}
-	if len(s.LogPath) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("logPath"), ".staticAuditLog.config.logPath is a required field"))
-	}
-	if s.LogMaxAge <= 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxAge"), s.LogMaxAge, ".staticAuditLog.config.logMaxAge must be greater than 0"))
+	if provider.AWS == nil && provider.Azure == nil && provider.GCE == nil && provider.Openstack == nil {
+		return append(allErrs, field.Forbidden(fldPath, "cloudNetwork is supported only on AWS, Azure, GCE and OpenStack"))
 	}
-	if s.LogMaxBackup <= 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxBackup"), s.LogMaxBackup, ".staticAuditLog.config.logMaxBackup must be greater than 0"))
+
+	for i, sg := range cloudNetwork.SecurityGroupIDs {
+		if strings.TrimSpace(sg) == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("securityGroupIDs").Index(i), sg, "security group ID can't be empty"))
+		}
 	}
-	if s.LogMaxSize <= 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxSize"), s.LogMaxSize, ".staticAuditLog.config.logMaxSize must be greater than 0"))
+
+	switch {
+	case provider.AWS != nil:
+		if cloudNetwork.SubnetID != "" && !strings.HasPrefix(cloudNetwork.SubnetID, "subnet-") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnetID"), cloudNetwork.SubnetID, "AWS subnet ID must start with \"subnet-\""))
+		}
+		for i, sg := range cloudNetwork.SecurityGroupIDs {
+			if sg != "" && !strings.HasPrefix(sg, "sg-") {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("securityGroupIDs").Index(i), sg, "AWS security group ID must start with \"sg-\""))
+			}
+		}
+	case provider.Azure != nil:
+		if len(cloudNetwork.SecurityGroupIDs) > 1 {
+			allErrs = append(allErrs, field.TooMany(fldPath.Child("securityGroupIDs"), len(cloudNetwork.SecurityGroupIDs), 1))
+		}
+	case provider.GCE != nil:
+		if len(cloudNetwork.SecurityGroupIDs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("securityGroupIDs"), "security groups are not supported on GCE"))
+		}
+	case provider.Openstack != nil:
+		if cloudNetwork.AssignPublicIP != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("assignPublicIP"), "assignPublicIP is not supported on OpenStack, use floatingIPPool in cloudProviderSpec instead"))
+		}
 	}
 
 	return allErrs
 }
 
-// ValidateOIDCConfig validates the OpenIDConnectConfig structure
-func ValidateOIDCConfig(o kubeoneapi.OpenIDConnectConfig, fldPath *field.Path) field.ErrorList {
+// validateDiskConfig validates the DiskConfig structure against the used provider
+func validateDiskConfig(disks *kubeoneapi.DiskConfig, provider kubeoneapi.CloudProviderSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var rootDiskTypes, dataDiskTypes sets.String
+	switch {
+	case provider.AWS != nil:
+		rootDiskTypes = sets.NewString("standard", "gp2", "gp3", "io1", "st1", "sc1")
+	case provider.Azure != nil:
+		rootDiskTypes = sets.NewString("Standard_LRS", "StandardSSD_LRS", "Premium_LRS")
+		dataDiskTypes = sets.NewString("Standard_LRS", "StandardSSD_LRS", "Premium_LRS", "UltraSSD_LRS")
+	case provider.GCE != nil:
+		rootDiskTypes = sets.NewString("pd-standard", "pd-ssd")
+	case provider.Openstack != nil, provider.VMwareCloudDirector != nil:
+		// volume types and storage profiles are defined by the operator of the cloud
+	case provider.Nutanix != nil, provider.Vsphere != nil:
+		if disks.Type != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "disk type is not supported on Nutanix and vSphere"))
+		}
+	default:
+		return append(allErrs, field.Forbidden(fldPath, "disks is supported only on AWS, Azure, GCE, Nutanix, OpenStack, VMware Cloud Director and vSphere"))
+	}
+
+	if disks.Size < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), disks.Size, "disk size can't be negative"))
+	}
+	if rootDiskTypes != nil && disks.Type != "" && !rootDiskTypes.Has(disks.Type) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), disks.Type, rootDiskTypes.List()))
+	}
+
+	if len(disks.DataVolumes) == 0 {
+		return allErrs
+	}
+	if provider.Azure == nil {
+		return append(allErrs, field.Forbidden(fldPath.Child("dataVolumes"), "dataVolumes is supported only on Azure"))
+	}
+	if len(disks.DataVolumes) > 1 {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("dataVolumes"), len(disks.DataVolumes), 1))
+	}
+	for i, volume := range disks.DataVolumes {
+		if volume.Size <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dataVolumes").Index(i).Child("size"), volume.Size, "data volume size must be greater than 0"))
+		}
+		if volume.Type != "" && !dataDiskTypes.Has(volume.Type) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("dataVolumes").Index(i).Child("type"), volume.Type, dataDiskTypes.List()))
+		}
+	}
+
+	return allErrs
+}
+
+func ValidateCABundle(caBundle string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	caPEM := bytes.TrimSpace([]byte(caBundle))
+	if len(caPEM) == 0 {
+		return allErrs
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(caPEM); !ok {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "can't parse caBundle"))
+	}
+
+	return allErrs
+}
+
+// ValidateCertificateAuthority validates the CertificateAuthority structure
+func ValidateCertificateAuthority(ca *kubeoneapi.CertificateAuthority, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ca == nil {
+		return allErrs
+	}
+
+	if ca.Kubernetes == nil && ca.FrontProxy == nil && ca.Etcd == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one certificate authority must be provided"))
+	}
+
+	allErrs = append(allErrs, validateCAKeyPair(ca.Kubernetes, fldPath.Child("kubernetes"))...)
+	allErrs = append(allErrs, validateCAKeyPair(ca.FrontProxy, fldPath.Child("frontProxy"))...)
+	allErrs = append(allErrs, validateCAKeyPair(ca.Etcd, fldPath.Child("etcd"))...)
+
+	return allErrs
+}
+
+func validateCAKeyPair(keyPair *kubeoneapi.CAKeyPair, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if keyPair == nil {
+		return allErrs
+	}
+
+	if keyPair.CertFilePath == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("certFilePath"), "path to the CA certificate is required"))
+	}
+	if keyPair.KeyFilePath == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keyFilePath"), "path to the CA key is required, certificate-only CAs are not supported"))
+	}
+
+	return allErrs
+}
+
+// ValidateControlPlaneComponents validates the ControlPlaneComponents structure
+func ValidateControlPlaneComponents(c *kubeoneapi.ControlPlaneComponents, versions kubeoneapi.VersionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c == nil {
+		return allErrs
+	}
+
+	if c.Scheduler != nil {
+		if p := c.Scheduler.PercentageOfNodesToScore; p != nil && (*p < 0 || *p > 100) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("scheduler", "percentageOfNodesToScore"), *p, "percentageOfNodesToScore must be between 0 and 100"))
+		}
+	}
+
+	if c.APIServer != nil {
+		allErrs = append(allErrs, ValidateAPIServerConfig(*c.APIServer, versions, fldPath.Child("apiServer"))...)
+	}
+
+	if c.ControllerManager != nil {
+		if d := c.ControllerManager.ClusterSigningDuration.Duration; d < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("controllerManager", "clusterSigningDuration"), d.String(), "clusterSigningDuration must be a positive duration"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateNamespaces validates the list of namespaces reconciled by KubeOne
+func ValidateNamespaces(namespaces []kubeoneapi.NamespaceConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, ns := range namespaces {
+		nsPath := fldPath.Index(i)
+
+		switch {
+		case ns.Name == "":
+			allErrs = append(allErrs, field.Required(nsPath.Child("name"), "namespace name is required"))
+		case names.Has(ns.Name):
+			allErrs = append(allErrs, field.Duplicate(nsPath.Child("name"), ns.Name))
+		default:
+			for _, msg := range validation.IsDNS1123Label(ns.Name) {
+				allErrs = append(allErrs, field.Invalid(nsPath.Child("name"), ns.Name, msg))
+			}
+		}
+		names.Insert(ns.Name)
+
+		for k, v := range ns.Labels {
+			for _, msg := range validation.IsQualifiedName(k) {
+				allErrs = append(allErrs, field.Invalid(nsPath.Child("labels"), k, msg))
+			}
+			for _, msg := range validation.IsValidLabelValue(v) {
+				allErrs = append(allErrs, field.Invalid(nsPath.Child("labels").Key(k), v, msg))
+			}
+		}
+		for k := range ns.Annotations {
+			for _, msg := range validation.IsQualifiedName(strings.ToLower(k)) {
+				allErrs = append(allErrs, field.Invalid(nsPath.Child("annotations"), k, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateFirewallConfig validates the FirewallConfig structure
+func ValidateFirewallConfig(f kubeoneapi.FirewallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch f.Backend {
+	case kubeoneapi.FirewallBackendNftables, kubeoneapi.FirewallBackendFirewalld:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("backend"), f.Backend, []string{kubeoneapi.FirewallBackendNftables, kubeoneapi.FirewallBackendFirewalld}))
+	}
+
+	for i, rule := range f.Rules {
+		rulePath := fldPath.Child("rules").Index(i)
+
+		if err := validateFirewallPorts(rule.Ports); err != nil {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("ports"), rule.Ports, err.Error()))
+		}
+
+		switch rule.Protocol {
+		case "tcp", "udp":
+		default:
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("protocol"), rule.Protocol, []string{"tcp", "udp"}))
+		}
+
+		for j, source := range rule.Sources {
+			if _, _, err := net.ParseCIDR(source); err != nil {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("sources").Index(j), source, "source must be a valid CIDR"))
+			}
+		}
+
+		roles := sets.NewString()
+		for j, role := range rule.Roles {
+			switch {
+			case role != kubeoneapi.FirewallRoleControlPlane && role != kubeoneapi.FirewallRoleStaticWorker:
+				allErrs = append(allErrs, field.NotSupported(rulePath.Child("roles").Index(j), role, []string{kubeoneapi.FirewallRoleControlPlane, kubeoneapi.FirewallRoleStaticWorker}))
+			case roles.Has(role):
+				allErrs = append(allErrs, field.Duplicate(rulePath.Child("roles").Index(j), role))
+			}
+			roles.Insert(role)
+		}
+	}
+
+	for i, source := range f.KubeletSources {
+		if _, _, err := net.ParseCIDR(source); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kubeletSources").Index(i), source, "source must be a valid CIDR"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateFirewallPorts validates a port (e.g. "8080") or a port range
+// (e.g. "8000-8080")
+func validateFirewallPorts(ports string) error {
+	bounds := strings.Split(ports, "-")
+	if len(bounds) > 2 {
+		return errors.New("ports must be a port or a port range in the min-max format")
+	}
+
+	var values []int
+	for _, bound := range bounds {
+		port, err := strconv.Atoi(bound)
+		if err != nil {
+			return errors.New("ports must be a port or a port range in the min-max format")
+		}
+		if port < 1 || port > 65535 {
+			return errors.New("ports must be within 1-65535")
+		}
+		values = append(values, port)
+	}
+
+	if len(values) == 2 && values[0] > values[1] {
+		return errors.New("the lower bound of the port range must not be greater than the upper bound")
+	}
+
+	return nil
+}
+
+// ValidatePostApplyValidation validates the PostApplyValidation structure
+func ValidatePostApplyValidation(p *kubeoneapi.PostApplyValidation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if p == nil || p.Webhook == nil {
+		return allErrs
+	}
+
+	webhookPath := fldPath.Child("webhook")
+
+	if p.Webhook.URL == "" {
+		allErrs = append(allErrs, field.Required(webhookPath.Child("url"), "url is required"))
+	} else if u, err := url.Parse(p.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(webhookPath.Child("url"), p.Webhook.URL, "url must be a valid http or https URL"))
+	}
+
+	if p.Webhook.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(webhookPath.Child("timeout"), p.Webhook.Timeout.Duration.String(), "timeout must be a positive duration"))
+	}
+
+	return allErrs
+}
+
+// ValidateBootstrapTokenConfig validates the BootstrapTokenConfig structure
+func ValidateBootstrapTokenConfig(b *kubeoneapi.BootstrapTokenConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if b == nil {
+		return allErrs
+	}
+
+	// kubeadm treats a zero TTL as a token that never expires, while KubeOne
+	// uses zero for the default TTL, so only positive values can be set
+	ttl := b.TTL.Duration
+	switch {
+	case ttl < 0:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), ttl.String(), "ttl must be a positive duration"))
+	case ttl > maxBootstrapTokenTTL:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), ttl.String(), fmt.Sprintf("ttl must not be longer than %s", maxBootstrapTokenTTL)))
+	case ttl%time.Second != 0:
+		// the token expiration is stored with the precision of seconds
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), ttl.String(), "ttl must be a whole number of seconds"))
+	}
+
+	return allErrs
+}
+
+// ValidateAPIServerConfig validates the APIServerConfig structure
+func ValidateAPIServerConfig(a kubeoneapi.APIServerConfig, versions kubeoneapi.VersionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if a.EndpointReconcilerType != "" && !apiServerEndpointReconcilerTypes.Has(a.EndpointReconcilerType) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("endpointReconcilerType"), a.EndpointReconcilerType, apiServerEndpointReconcilerTypes.List()))
+	}
+
+	if d := a.ServiceAccountMaxTokenExpiration.Duration; d != 0 && (d < minServiceAccountMaxTokenExpiration || d > maxServiceAccountMaxTokenExpiration) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceAccountMaxTokenExpiration"), d.String(),
+			fmt.Sprintf("serviceAccountMaxTokenExpiration must be between %s and %s", minServiceAccountMaxTokenExpiration, maxServiceAccountMaxTokenExpiration)))
+	}
+
+	// invalid version is reported by ValidateVersionConfig
+	kubeVer, verErr := semver.NewVersion(versions.Kubernetes)
+
+	for name := range a.FeatureGates {
+		if !featureGateNameRegexp.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("featureGates").Key(name), name, "feature gate name must be in the CamelCase format"))
+
+			continue
+		}
+
+		constraint, ok := apiServerFeatureGateConstraints[name]
+		if !ok || verErr != nil {
+			continue
+		}
+
+		if !semverutil.MustParseConstraint(constraint).Check(kubeVer) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("featureGates").Key(name),
+				fmt.Sprintf("%s feature gate requires Kubernetes %s", name, constraint)))
+		}
+	}
+
+	if a.FeatureGates["StorageVersionAPI"] && !a.FeatureGates["APIServerIdentity"] {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("featureGates").Key("StorageVersionAPI"),
+			"StorageVersionAPI feature gate requires the APIServerIdentity feature gate to be enabled"))
+	}
+
+	return allErrs
+}
+
+// ValidateFeatures validates the Features structure
+func ValidateFeatures(f kubeoneapi.Features, versions kubeoneapi.VersionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if f.PodNodeSelector != nil && f.PodNodeSelector.Enable {
+		allErrs = append(allErrs, ValidatePodNodeSelectorConfig(f.PodNodeSelector.Config, fldPath.Child("podNodeSelector"))...)
+	}
+	if f.StaticAuditLog != nil && f.StaticAuditLog.Enable {
+		allErrs = append(allErrs, ValidateStaticAuditLogConfig(f.StaticAuditLog.Config, fldPath.Child("staticAuditLog"))...)
+	}
+	if f.OpenIDConnect != nil && f.OpenIDConnect.Enable {
+		allErrs = append(allErrs, ValidateOIDCConfig(f.OpenIDConnect.Config, fldPath.Child("openidConnect"))...)
+	}
+	if f.StaticAuth != nil {
+		allErrs = append(allErrs, ValidateStaticAuth(*f.StaticAuth, fldPath.Child("staticAuth"))...)
+	}
+	if f.EtcdMetrics.Enabled() {
+		allErrs = append(allErrs, ValidateEtcdMetrics(*f.EtcdMetrics, fldPath.Child("etcdMetrics"))...)
+	}
+	if f.PrometheusAdapter.Enabled() {
+		allErrs = append(allErrs, ValidatePrometheusAdapter(*f.PrometheusAdapter, fldPath.Child("prometheusAdapter"))...)
+	}
+	if f.EtcdBackup.Enabled() {
+		allErrs = append(allErrs, ValidateEtcdBackup(*f.EtcdBackup, fldPath.Child("etcdBackup"))...)
+	}
+	if f.PodTolerationRestriction.Enabled() {
+		allErrs = append(allErrs, ValidatePodTolerationRestriction(*f.PodTolerationRestriction, fldPath.Child("podTolerationRestriction"))...)
+	}
+	if f.WebhookCABundleInjection.Enabled() {
+		allErrs = append(allErrs, ValidateWebhookCABundleInjection(*f.WebhookCABundleInjection, fldPath.Child("webhookCABundleInjection"))...)
+	}
+	if f.PodDisruptionBudgets.Enabled() {
+		allErrs = append(allErrs, validatePDBMinAvailable(f.PodDisruptionBudgets.MinAvailable, fldPath.Child("podDisruptionBudgets", "minAvailable"))...)
+	}
+
+	return allErrs
+}
+
+// validatePDBMinAvailable validates minAvailable is a number of at least 1 or
+// a percentage between 1% and 100%
+func validatePDBMinAvailable(minAvailable intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if minAvailable.Type == intstr.Int {
+		if minAvailable.IntValue() < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath, minAvailable.IntValue(), "minAvailable must be at least 1"))
+		}
+
+		return allErrs
+	}
+
+	percent, err := intstr.GetScaledValueFromIntOrPercent(&minAvailable, 100, true)
+	if err != nil || percent < 1 || percent > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath, minAvailable.String(), "minAvailable must be a percentage between 1% and 100%"))
+	}
+
+	return allErrs
+}
+
+// ValidateWebhookCABundleInjection validates the WebhookCABundleInjection structure
+func ValidateWebhookCABundleInjection(w kubeoneapi.WebhookCABundleInjection, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(w.ValidatingWebhookConfigurations) == 0 && len(w.MutatingWebhookConfigurations) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one validating or mutating webhook configuration is required"))
+	}
+
+	allErrs = append(allErrs, validateObjectNames(w.ValidatingWebhookConfigurations, fldPath.Child("validatingWebhookConfigurations"))...)
+	allErrs = append(allErrs, validateObjectNames(w.MutatingWebhookConfigurations, fldPath.Child("mutatingWebhookConfigurations"))...)
+
+	return allErrs
+}
+
+// validateObjectNames validates a list of unique names of cluster-scoped objects
+func validateObjectNames(names []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, name := range names {
+		if seen.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), name))
+
+			continue
+		}
+		seen.Insert(name)
+
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), name, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateEtcdMetrics validates the EtcdMetrics structure
+func ValidateEtcdMetrics(em kubeoneapi.EtcdMetrics, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if em.Port <= 0 || em.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), em.Port, "port must be between 1 and 65535"))
+
+		return allErrs
+	}
+
+	if component, ok := controlPlanePorts[em.Port]; ok {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), em.Port, fmt.Sprintf("port conflicts with the %s port", component)))
+	}
+
+	return allErrs
+}
+
+// ValidateEtcdBackup validates the EtcdBackup structure
+func ValidateEtcdBackup(eb kubeoneapi.EtcdBackup, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if err := validateCronSchedule(eb.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), eb.Schedule, err.Error()))
+	}
+	if eb.S3Bucket == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("s3Bucket"), "s3Bucket is required"))
+	}
+	if eb.AWSDefaultRegion == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("awsDefaultRegion"), "awsDefaultRegion is required"))
+	}
+	if eb.KeepLast < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keepLast"), eb.KeepLast, "keepLast must be at least 1"))
+	}
+
+	return allErrs
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+	// anyValue allows "?" as an alias for "*", which the CronJob parser
+	// accepts only in the day of month and day of week fields
+	anyValue bool
+}
+
+var (
+	cronFields = []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31, anyValue: true},
+		{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+		{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}, anyValue: true},
+	}
+
+	cronDescriptors = sets.NewString("@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly")
+)
+
+// validateCronSchedule validates the schedule in the format supported by the
+// Kubernetes CronJobs, i.e. five fields or one of the predefined descriptors
+func validateCronSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if schedule == "" {
+		return errors.New("schedule is required")
+	}
+
+	if strings.HasPrefix(schedule, "@") {
+		if cronDescriptors.Has(schedule) {
+			return nil
+		}
+		if every := strings.TrimPrefix(schedule, "@every "); every != schedule {
+			d, err := time.ParseDuration(strings.TrimSpace(every))
+			if err != nil || d <= 0 {
+				return errors.Errorf("invalid duration %q", every)
+			}
+
+			return nil
+		}
+
+		return errors.Errorf("unknown descriptor %q", schedule)
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return errors.Errorf("expected %d fields, found %d", len(cronFields), len(fields))
+	}
+
+	for i, f := range fields {
+		if err := cronFields[i].validate(f); err != nil {
+			return errors.Wrapf(err, "invalid %s field %q", cronFields[i].name, f)
+		}
+	}
+
+	return nil
+}
+
+func (cf cronField) validate(expr string) error {
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return errors.Errorf("invalid step %q", step)
+			}
+		}
+
+		if rangeExpr == "*" || (cf.anyValue && rangeExpr == "?") {
+			continue
+		}
+
+		low, high, isRange := strings.Cut(rangeExpr, "-")
+		lowValue, err := cf.value(low)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+
+		highValue, err := cf.value(high)
+		if err != nil {
+			return err
+		}
+		if lowValue > highValue {
+			return errors.Errorf("invalid range %q", rangeExpr)
+		}
+	}
+
+	return nil
+}
+
+func (cf cronField) value(v string) (int, error) {
+	for i, name := range cf.names {
+		if strings.EqualFold(v, name) {
+			if cf.min == 1 {
+				return i + 1, nil
+			}
+
+			return i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, errors.Errorf("invalid value %q", v)
+	}
+	if n < cf.min || n > cf.max {
+		return 0, errors.Errorf("value %d out of range [%d, %d]", n, cf.min, cf.max)
+	}
+
+	return n, nil
+}
+
+// prometheusAdapterConfig is the subset of the Prometheus adapter
+// configuration validated by KubeOne
+type prometheusAdapterConfig struct {
+	Rules         []prometheusAdapterRule `json:"rules,omitempty"`
+	ExternalRules []prometheusAdapterRule `json:"externalRules,omitempty"`
+	ResourceRules json.RawMessage         `json:"resourceRules,omitempty"`
+}
+
+type prometheusAdapterRule struct {
+	SeriesQuery   string `json:"seriesQuery"`
+	SeriesFilters []struct {
+		Is    string `json:"is,omitempty"`
+		IsNot string `json:"isNot,omitempty"`
+	} `json:"seriesFilters,omitempty"`
+	Resources struct {
+		Template  string `json:"template,omitempty"`
+		Overrides map[string]struct {
+			Group    string `json:"group,omitempty"`
+			Resource string `json:"resource"`
+		} `json:"overrides,omitempty"`
+		Namespaced *bool `json:"namespaced,omitempty"`
+	} `json:"resources,omitempty"`
+	Name struct {
+		Matches string `json:"matches,omitempty"`
+		As      string `json:"as,omitempty"`
+	} `json:"name,omitempty"`
+	MetricsQuery string `json:"metricsQuery"`
+}
+
+// ValidatePrometheusAdapter validates the PrometheusAdapter structure
+func ValidatePrometheusAdapter(pa kubeoneapi.PrometheusAdapter, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if pa.PrometheusURL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("prometheusURL"), "prometheusURL is required"))
+	} else if u, err := url.Parse(pa.PrometheusURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prometheusURL"), pa.PrometheusURL, "prometheusURL must be a valid http or https URL"))
+	}
+
+	if pa.Rules == "" {
+		return allErrs
+	}
+
+	rulesPath := fldPath.Child("rules")
+	var config prometheusAdapterConfig
+	if err := yaml.UnmarshalStrict([]byte(pa.Rules), &config); err != nil {
+		allErrs = append(allErrs, field.Invalid(rulesPath, "", fmt.Sprintf("can't parse rules: %v", err)))
+
+		return allErrs
+	}
+
+	if len(config.ResourceRules) > 0 {
+		allErrs = append(allErrs, field.Forbidden(rulesPath, "resourceRules are not supported, the resource metrics API is served by metrics-server"))
+	}
+	if len(config.Rules) == 0 && len(config.ExternalRules) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath, "at least one rule or external rule is required"))
+	}
+
+	for i, rule := range config.Rules {
+		allErrs = append(allErrs, validatePrometheusAdapterRule(rule, rulesPath.Child("rules").Index(i))...)
+	}
+	for i, rule := range config.ExternalRules {
+		allErrs = append(allErrs, validatePrometheusAdapterRule(rule, rulesPath.Child("externalRules").Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validatePrometheusAdapterRule(rule prometheusAdapterRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rule.SeriesQuery == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("seriesQuery"), "seriesQuery is required"))
+	}
+
+	for i, filter := range rule.SeriesFilters {
+		if _, err := regexp.Compile(filter.Is); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("seriesFilters").Index(i).Child("is"), filter.Is, err.Error()))
+		}
+		if _, err := regexp.Compile(filter.IsNot); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("seriesFilters").Index(i).Child("isNot"), filter.IsNot, err.Error()))
+		}
+	}
+
+	if _, err := regexp.Compile(rule.Name.Matches); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name", "matches"), rule.Name.Matches, err.Error()))
+	}
+
+	if rule.Resources.Template != "" {
+		if _, err := template.New("").Delims("<<", ">>").Parse(rule.Resources.Template); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("resources", "template"), rule.Resources.Template, err.Error()))
+		}
+	}
+
+	if rule.MetricsQuery == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("metricsQuery"), "metricsQuery is required"))
+	} else if _, err := template.New("").Delims("<<", ">>").Parse(rule.MetricsQuery); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("metricsQuery"), rule.MetricsQuery, err.Error()))
+	}
+
+	return allErrs
+}
+
+// ValidatePodNodeSelectorConfig validates the PodNodeSelectorConfig structure
+func ValidatePodNodeSelectorConfig(n kubeoneapi.PodNodeSelectorConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(n.ConfigFilePath) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("configFilePath"), ".podNodeSelector.config.configFilePath is a required field"))
+	}
+
+	return allErrs
+}
+
+// ValidatePodTolerationRestriction validates the PodTolerationRestriction structure
+func ValidatePodTolerationRestriction(ptr kubeoneapi.PodTolerationRestriction, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateTolerationsWhitelist(ptr.DefaultTolerations, ptr.Whitelist, fldPath)...)
+
+	namespaces := sets.NewString()
+	for i, ns := range ptr.Namespaces {
+		nsPath := fldPath.Child("namespaces").Index(i)
+
+		switch {
+		case ns.Name == "":
+			allErrs = append(allErrs, field.Required(nsPath.Child("name"), "namespace name is required"))
+		case namespaces.Has(ns.Name):
+			allErrs = append(allErrs, field.Duplicate(nsPath.Child("name"), ns.Name))
+		default:
+			for _, msg := range validation.IsDNS1123Label(ns.Name) {
+				allErrs = append(allErrs, field.Invalid(nsPath.Child("name"), ns.Name, msg))
+			}
+		}
+		namespaces.Insert(ns.Name)
+
+		allErrs = append(allErrs, validateTolerationsWhitelist(ns.DefaultTolerations, ns.Whitelist, nsPath)...)
+	}
+
+	return allErrs
+}
+
+// validateTolerationsWhitelist validates the default and whitelisted
+// tolerations. The PodTolerationRestriction plugin rejects all pods if the
+// default tolerations are not whitelisted, so such configuration is rejected
+// as well.
+func validateTolerationsWhitelist(defaults, whitelist []corev1.Toleration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateTolerations(defaults, fldPath.Child("defaultTolerations"))...)
+	allErrs = append(allErrs, validateTolerations(whitelist, fldPath.Child("whitelist"))...)
+
+	if len(whitelist) == 0 {
+		return allErrs
+	}
+
+	for i := range defaults {
+		whitelisted := false
+		for _, w := range whitelist {
+			if tolerationCovers(w, defaults[i]) {
+				whitelisted = true
+
+				break
+			}
+		}
+		if !whitelisted {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultTolerations").Index(i), defaults[i], "default toleration is not whitelisted"))
+		}
+	}
+
+	return allErrs
+}
+
+// tolerationCovers reports whether the whitelisted toleration w allows the
+// toleration t
+func tolerationCovers(w, t corev1.Toleration) bool {
+	if w.Key != t.Key && (w.Key != "" || w.Operator != corev1.TolerationOpExists) {
+		return false
+	}
+	if w.Effect != "" && w.Effect != t.Effect {
+		return false
+	}
+	if w.TolerationSeconds != nil && (t.TolerationSeconds == nil || *t.TolerationSeconds > *w.TolerationSeconds) {
+		return false
+	}
+	if w.Operator == corev1.TolerationOpExists {
+		return true
+	}
+
+	return t.Operator != corev1.TolerationOpExists && t.Value == w.Value
+}
+
+// validateTolerations validates the tolerations the same way as the
+// Kubernetes API server validates the pod tolerations
+func validateTolerations(tolerations []corev1.Toleration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, t := range tolerations {
+		idxPath := fldPath.Index(i)
+
+		if t.Key != "" {
+			for _, msg := range validation.IsQualifiedName(t.Key) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("key"), t.Key, msg))
+			}
+		}
+
+		switch t.Operator {
+		case corev1.TolerationOpEqual, "":
+			if t.Key == "" {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("operator"), t.Operator, "operator must be Exists when key is empty"))
+			}
+			for _, msg := range validation.IsValidLabelValue(t.Value) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), t.Value, msg))
+			}
+		case corev1.TolerationOpExists:
+			if t.Value != "" {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), t.Value, "value must be empty when operator is Exists"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("operator"), t.Operator, []string{string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists)}))
+		}
+
+		switch t.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute, "":
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("effect"), t.Effect, []string{
+				string(corev1.TaintEffectNoSchedule),
+				string(corev1.TaintEffectPreferNoSchedule),
+				string(corev1.TaintEffectNoExecute),
+			}))
+		}
+
+		if t.TolerationSeconds != nil && t.Effect != corev1.TaintEffectNoExecute {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("effect"), t.Effect, "effect must be NoExecute when tolerationSeconds is set"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateStaticAuditLogConfig validates the StaticAuditLogConfig structure
+func ValidateStaticAuditLogConfig(s kubeoneapi.StaticAuditLogConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case len(s.PolicyFilePath) == 0 && len(s.PolicyPreset) == 0:
+		allErrs = append(allErrs, field.Required(fldPath.Child("policyFilePath"), ".staticAuditLog.config.policyFilePath or .staticAuditLog.config.policyPreset is required"))
+	case len(s.PolicyFilePath) != 0 && len(s.PolicyPreset) != 0:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("policyPreset"), s.PolicyPreset, ".staticAuditLog.config.policyFilePath and .staticAuditLog.config.policyPreset are mutually exclusive"))
+	case len(s.PolicyPreset) != 0:
+		presets := auditpolicy.Presets()
+		if !sets.NewString(presets...).Has(s.PolicyPreset) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("policyPreset"), s.PolicyPreset, presets))
+		}
+	}
+	if len(s.LogPath) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("logPath"), ".staticAuditLog.config.logPath is a required field"))
+	}
+	if s.LogMaxAge <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxAge"), s.LogMaxAge, ".staticAuditLog.config.logMaxAge must be greater than 0"))
+	}
+	if s.LogMaxBackup <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxBackup"), s.LogMaxBackup, ".staticAuditLog.config.logMaxBackup must be greater than 0"))
+	}
+	if s.LogMaxSize <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxSize"), s.LogMaxSize, ".staticAuditLog.config.logMaxSize must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// ValidateOIDCConfig validates the OpenIDConnectConfig structure
+func ValidateOIDCConfig(o kubeoneapi.OpenIDConnectConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if len(o.IssuerURL) == 0 {
@@ -541,6 +2029,53 @@ func ValidateOIDCConfig(o kubeoneapi.OpenIDConnectConfig, fldPath *field.Path) f
 	return allErrs
 }
 
+// ValidateStaticAuth validates the StaticAuth structure
+func ValidateStaticAuth(a kubeoneapi.StaticAuth, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if a.ClientCABundle != "" && a.ClientCABundleFilePath != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clientCABundleFilePath"), a.ClientCABundleFilePath, ".staticAuth.clientCABundle and .staticAuth.clientCABundleFilePath are mutually exclusive"))
+	}
+	if a.ClientCABundle != "" {
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM([]byte(a.ClientCABundle)); !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clientCABundle"), "", "can't parse clientCABundle, a valid PEM-encoded bundle is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateAnonymousAuth validates that disabling the API server anonymous
+// auth doesn't break the API server health checks. With anonymous auth
+// disabled, KubeOne authenticates its health checks using the kube-apiserver
+// client certificate, so they must target the kube-apiserver health endpoints.
+func ValidateAnonymousAuth(c kubeoneapi.KubeOneCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !c.Features.StaticAuth.AnonymousAuthDisabled() {
+		return allErrs
+	}
+
+	if hc := c.APIEndpoint.HealthCheck; hc != nil && hc.Path != "" {
+		healthEndpoint := false
+		for _, endpoint := range []string{"/healthz", "/livez", "/readyz"} {
+			if hc.Path == endpoint || strings.HasPrefix(hc.Path, endpoint+"/") {
+				healthEndpoint = true
+
+				break
+			}
+		}
+
+		if !healthEndpoint {
+			allErrs = append(allErrs, field.Invalid(fldPath, false,
+				fmt.Sprintf("apiEndpoint.healthCheck.path %q must be a kube-apiserver health endpoint (/healthz, /livez or /readyz) if anonymous auth is disabled", hc.Path)))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateAddons validates the Addons configuration
 func ValidateAddons(o *kubeoneapi.Addons, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -563,6 +2098,35 @@ func ValidateAddons(o *kubeoneapi.Addons, fldPath *field.Path) field.ErrorList {
 		}
 	}
 
+	if o.FieldManager != "" {
+		if len(o.FieldManager) > fieldManagerMaxLength {
+			allErrs = append(allErrs, field.TooLong(fldPath.Child("fieldManager"), o.FieldManager, fieldManagerMaxLength))
+		}
+		if !fieldManagerRegexp.MatchString(o.FieldManager) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("fieldManager"), o.FieldManager, "field manager name must consist of alphanumeric characters, '-', '_', '.', ':' or '/', and must start and end with an alphanumeric character"))
+		}
+	}
+
+	for i, addon := range o.Addons {
+		if addon.ForceConflicts && o.FieldManager == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("addons").Index(i).Child("forceConflicts"), addon.ForceConflicts, "forceConflicts requires .addons.fieldManager to be set"))
+		}
+
+		if addon.EnabledWhen != "" {
+			if _, err := addons.ParseCondition(addon.EnabledWhen); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("addons").Index(i).Child("enabledWhen"), addon.EnabledWhen, fmt.Sprintf("invalid condition: %v", err)))
+			}
+		}
+
+		if addon.Name != resources.AddonNodeProblemDetector {
+			continue
+		}
+
+		if cfg, ok := addon.Params[resources.NodeProblemDetectorCustomMonitorConfigParam]; ok && !json.Valid([]byte(cfg)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("addons").Index(i).Child("params").Key(resources.NodeProblemDetectorCustomMonitorConfigParam), cfg, "node-problem-detector custom monitor config must be a valid JSON"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -592,11 +2156,169 @@ func ValidateHostConfig(hosts []kubeoneapi.HostConfig, fldPath *field.Path) fiel
 			allErrs = append(allErrs, field.Required(fldPath, "no SSH username given"))
 		}
 		if !h.OperatingSystem.IsValid() {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("operatingSystem"), h.OperatingSystem, "invalid operatingSystem provided"))
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("operatingSystem"), h.OperatingSystem, "invalid operatingSystem provided, supported values are: ubuntu, debian, centos, rhel, rocky, amzn, flatcar"))
 		}
 		if h.Kubelet.MaxPods != nil && *h.Kubelet.MaxPods <= 0 {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("kubelet").Child("maxPods"), h.Kubelet.MaxPods, "maxPods must be a positive number"))
 		}
+		allErrs = append(allErrs, ValidateKubeletConfig(h.Kubelet, fldPath.Child("kubelet"))...)
+		allErrs = append(allErrs, validateNodeMetadata(h, fldPath)...)
+	}
+
+	return allErrs
+}
+
+// validateNodeMetadata validates the labels and annotations set on the Node
+// object of the host. The keys with the KubeOne prefix are reserved for the
+// labels and annotations managed by KubeOne itself.
+func validateNodeMetadata(h kubeoneapi.HostConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for k, v := range h.Labels {
+		for _, msg := range validation.IsQualifiedName(k) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("labels"), k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(v) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("labels").Key(k), v, msg))
+		}
+		if strings.HasPrefix(k, kubeoneNodeMetadataPrefix) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("labels").Key(k), fmt.Sprintf("%q prefix is reserved for KubeOne", kubeoneNodeMetadataPrefix)))
+		}
+	}
+	for k := range h.Annotations {
+		for _, msg := range validation.IsQualifiedName(strings.ToLower(k)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotations"), k, msg))
+		}
+		if strings.HasPrefix(k, kubeoneNodeMetadataPrefix) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("annotations").Key(k), fmt.Sprintf("%q prefix is reserved for KubeOne", kubeoneNodeMetadataPrefix)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateBindAddresses validates the BindAddresses structure of the control
+// plane host. Whether the addresses are present on the host is verified at
+// the runtime.
+func ValidateBindAddresses(h kubeoneapi.HostConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	ba := h.BindAddresses
+	for _, addr := range []struct {
+		name  string
+		value string
+	}{
+		{name: "apiServer", value: ba.APIServer},
+		{name: "etcd", value: ba.Etcd},
+		{name: "controllerManager", value: ba.ControllerManager},
+		{name: "scheduler", value: ba.Scheduler},
+	} {
+		if addr.value != "" && net.ParseIP(addr.value) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(addr.name), addr.value, "bind address must be a valid IP address"))
+		}
+	}
+
+	// kube-apiserver and etcd must listen on the address they're advertised
+	// on, otherwise the kubernetes Service endpoints and the etcd members
+	// are not reachable
+	if ba.APIServer != "" && ba.APIServer != h.NodeIP() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiServer"), ba.APIServer, fmt.Sprintf("bind address must be the advertised address %q", h.NodeIP())))
+	}
+	if ba.Etcd != "" && ba.Etcd != h.NodeIP() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("etcd"), ba.Etcd, fmt.Sprintf("bind address must be the advertised address %q", h.NodeIP())))
+	}
+
+	return allErrs
+}
+
+// ValidateKubeletConfig validates the reserved resources and the node allocatable
+// enforcement of the KubeletConfig structure
+func ValidateKubeletConfig(k kubeoneapi.KubeletConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateReservedResources(k.SystemReserved, fldPath.Child("systemReserved"))...)
+	allErrs = append(allErrs, validateReservedResources(k.KubeReserved, fldPath.Child("kubeReserved"))...)
+
+	for signal, threshold := range k.EvictionHard {
+		if !kubeletEvictionSignals.Has(signal) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("evictionHard").Key(signal), signal, kubeletEvictionSignals.List()))
+
+			continue
+		}
+		if strings.HasSuffix(threshold, "%") {
+			pct, err := strconv.ParseFloat(strings.TrimSuffix(threshold, "%"), 64)
+			if err != nil || pct < 0 || pct > 100 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("evictionHard").Key(signal), threshold, "percentage must be between 0% and 100%"))
+			}
+
+			continue
+		}
+		if q, err := resource.ParseQuantity(threshold); err != nil || q.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("evictionHard").Key(signal), threshold, "threshold must be a non-negative quantity or a percentage"))
+		}
+	}
+
+	for _, cg := range []struct {
+		name  string
+		value string
+	}{
+		{name: "systemReservedCgroup", value: k.SystemReservedCgroup},
+		{name: "kubeReservedCgroup", value: k.KubeReservedCgroup},
+	} {
+		if cg.value != "" && !strings.HasPrefix(cg.value, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(cg.name), cg.value, "cgroup must be an absolute path"))
+		}
+	}
+
+	enforced := sets.NewString()
+	enforcePath := fldPath.Child("enforceNodeAllocatable")
+	for i, key := range k.EnforceNodeAllocatable {
+		if !kubeletEnforceNodeAllocatableKeys.Has(key) {
+			allErrs = append(allErrs, field.NotSupported(enforcePath.Index(i), key, kubeletEnforceNodeAllocatableKeys.List()))
+
+			continue
+		}
+		if enforced.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(enforcePath.Index(i), key))
+		}
+		enforced.Insert(key)
+	}
+
+	if enforced.Has("none") && enforced.Len() > 1 {
+		allErrs = append(allErrs, field.Invalid(enforcePath, k.EnforceNodeAllocatable, "none can't be combined with other values"))
+	}
+	if enforced.Has("system-reserved") {
+		if len(k.SystemReserved) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("systemReserved"), "systemReserved is required when enforcing system-reserved"))
+		}
+		if k.SystemReservedCgroup == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("systemReservedCgroup"), "systemReservedCgroup is required when enforcing system-reserved"))
+		}
+	}
+	if enforced.Has("kube-reserved") {
+		if len(k.KubeReserved) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("kubeReserved"), "kubeReserved is required when enforcing kube-reserved"))
+		}
+		if k.KubeReservedCgroup == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("kubeReservedCgroup"), "kubeReservedCgroup is required when enforcing kube-reserved"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateReservedResources(reserved map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, value := range reserved {
+		if !kubeletReservableResources.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Key(name), name, kubeletReservableResources.List()))
+
+			continue
+		}
+		if q, err := resource.ParseQuantity(value); err != nil || q.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(name), value, "reserved resource must be a non-negative quantity"))
+		}
 	}
 
 	return allErrs
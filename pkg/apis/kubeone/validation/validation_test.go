@@ -17,13 +17,18 @@ limitations under the License.
 package validation
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc/v2"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/templates/resources"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 )
@@ -361,6 +366,65 @@ func TestValidateControlPlaneConfig(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "valid dnsName",
+			controlPlaneConfig: kubeoneapi.ControlPlaneConfig{
+				Hosts: []kubeoneapi.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						DNSName:        "cp-1.example.com",
+					},
+					{
+						PublicAddress:  "1.1.1.2",
+						PrivateAddress: "10.0.0.2",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						DNSName:        "cp-2.example.com",
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid dnsName",
+			controlPlaneConfig: kubeoneapi.ControlPlaneConfig{
+				Hosts: []kubeoneapi.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						DNSName:        "cp_1.example.com",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate dnsName",
+			controlPlaneConfig: kubeoneapi.ControlPlaneConfig{
+				Hosts: []kubeoneapi.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						DNSName:        "cp.example.com",
+					},
+					{
+						PublicAddress:  "1.1.1.2",
+						PrivateAddress: "10.0.0.2",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						DNSName:        "CP.example.com",
+					},
+				},
+			},
+			expectedError: true,
+		},
 		{
 			name: "no hosts provided",
 			controlPlaneConfig: kubeoneapi.ControlPlaneConfig{
@@ -437,6 +501,131 @@ func TestValidateAPIEndpoint(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "valid internal endpoint",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host:         "localhost",
+				Port:         6443,
+				InternalHost: "10.0.0.10",
+				InternalPort: 8443,
+			},
+			expectedError: false,
+		},
+		{
+			name: "internal port without internal host",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host:         "localhost",
+				Port:         6443,
+				InternalPort: 8443,
+			},
+			expectedError: true,
+		},
+		{
+			name: "internal port greater than 65535",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host:         "localhost",
+				Port:         6443,
+				InternalHost: "10.0.0.10",
+				InternalPort: 65536,
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid standby endpoints",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host: "api.example.com",
+				Port: 6443,
+				StandbyEndpoints: []kubeoneapi.APIEndpointStandby{
+					{Host: "api-standby.example.com"},
+					{Host: "10.1.0.10", Port: 443},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "standby endpoint without host",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host:             "api.example.com",
+				Port:             6443,
+				StandbyEndpoints: []kubeoneapi.APIEndpointStandby{{Port: 443}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "standby endpoint same as the primary endpoint",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host:             "api.example.com",
+				Port:             6443,
+				StandbyEndpoints: []kubeoneapi.APIEndpointStandby{{Host: "api.example.com"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicated standby endpoints",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host: "api.example.com",
+				Port: 6443,
+				StandbyEndpoints: []kubeoneapi.APIEndpointStandby{
+					{Host: "api-standby.example.com"},
+					{Host: "api-standby.example.com", Port: 443},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "standby endpoint port greater than 65535",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host:             "api.example.com",
+				Port:             6443,
+				StandbyEndpoints: []kubeoneapi.APIEndpointStandby{{Host: "api-standby.example.com", Port: 65536}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid health check",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host: "localhost",
+				Port: 6443,
+				HealthCheck: &kubeoneapi.APIEndpointHealthCheck{
+					Port: 8443,
+					Path: "/k8s/healthz",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "health check port greater than 65535",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host: "localhost",
+				Port: 6443,
+				HealthCheck: &kubeoneapi.APIEndpointHealthCheck{
+					Port: 65536,
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "relative health check path",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host: "localhost",
+				Port: 6443,
+				HealthCheck: &kubeoneapi.APIEndpointHealthCheck{
+					Path: "healthz",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "health check path with query",
+			apiEndpoint: kubeoneapi.APIEndpoint{
+				Host: "localhost",
+				Port: 6443,
+				HealthCheck: &kubeoneapi.APIEndpointHealthCheck{
+					Path: "/healthz?verbose",
+				},
+			},
+			expectedError: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -704,6 +893,60 @@ func TestValidateCloudProviderSpec(t *testing.T) {
 			providerConfig: kubeoneapi.CloudProviderSpec{},
 			expectedError:  true,
 		},
+		{
+			name: "Hetzner provider config with CCM configuration",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				Hetzner:  &kubeoneapi.HetznerSpec{},
+				External: true,
+				CCM: &kubeoneapi.CCMConfig{
+					ExtraArgs: map[string]string{
+						"concurrent-service-syncs": "5",
+						"node-monitor-period":      "30s",
+					},
+					Replicas: int32Ptr(2),
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "CCM configuration without external cloud provider",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				Hetzner: &kubeoneapi.HetznerSpec{},
+				CCM:     &kubeoneapi.CCMConfig{Replicas: int32Ptr(2)},
+			},
+			expectedError: true,
+		},
+		{
+			name: "CCM replicas with AWS provider",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				AWS:      &kubeoneapi.AWSSpec{},
+				External: true,
+				CCM:      &kubeoneapi.CCMConfig{Replicas: int32Ptr(2)},
+			},
+			expectedError: true,
+		},
+		{
+			name: "CCM extra arg with leading dashes",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				AWS:      &kubeoneapi.AWSSpec{},
+				External: true,
+				CCM: &kubeoneapi.CCMConfig{
+					ExtraArgs: map[string]string{"--v": "4"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "CCM extra arg with invalid value format",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				AWS:      &kubeoneapi.AWSSpec{},
+				External: true,
+				CCM: &kubeoneapi.CCMConfig{
+					ExtraArgs: map[string]string{"node-monitor-period": "30"},
+				},
+			},
+			expectedError: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -729,6 +972,38 @@ func TestValidateVersionConfig(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "kubeadm config API v1beta2 with Kubernetes 1.22",
+			versionConfig: kubeoneapi.VersionConfig{
+				Kubernetes:              "1.22.1",
+				KubeadmConfigAPIVersion: "kubeadm.k8s.io/v1beta2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "kubeadm config API v1beta3 with Kubernetes 1.23",
+			versionConfig: kubeoneapi.VersionConfig{
+				Kubernetes:              "1.23.1",
+				KubeadmConfigAPIVersion: "kubeadm.k8s.io/v1beta3",
+			},
+			expectedError: false,
+		},
+		{
+			name: "kubeadm config API v1beta3 with Kubernetes 1.21",
+			versionConfig: kubeoneapi.VersionConfig{
+				Kubernetes:              "1.21.4",
+				KubeadmConfigAPIVersion: "kubeadm.k8s.io/v1beta3",
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported kubeadm config API version",
+			versionConfig: kubeoneapi.VersionConfig{
+				Kubernetes:              "1.22.1",
+				KubeadmConfigAPIVersion: "kubeadm.k8s.io/v1beta1",
+			},
+			expectedError: true,
+		},
 		{
 			name: "valid version config (1.22.1)",
 			versionConfig: kubeoneapi.VersionConfig{
@@ -914,58 +1189,89 @@ func TestValidateKubernetesSupport(t *testing.T) {
 	}
 }
 
-func TestValidateContainerRuntimeConfig(t *testing.T) {
+func TestValidateCSISnapshotController(t *testing.T) {
+	enabled := &kubeoneapi.CSISnapshotControllerConfig{Enable: true}
+
 	tests := []struct {
-		name             string
-		containerRuntime kubeoneapi.ContainerRuntimeConfig
-		versions         kubeoneapi.VersionConfig
-		expectedError    bool
+		name           string
+		providerConfig kubeoneapi.CloudProviderSpec
+		version        string
+		expectedError  bool
 	}{
 		{
-			name:             "only docker defined",
-			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{}},
-			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.20"},
-			expectedError:    false,
+			name: "OpenStack cluster",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				Openstack:             &kubeoneapi.OpenstackSpec{},
+				CSISnapshotController: enabled,
+			},
+			version:       "1.24.2",
+			expectedError: false,
 		},
 		{
-			name:             "docker with kubernetes 1.24+",
-			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{}},
-			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.24"},
-			expectedError:    true,
+			name: "None cluster with user provided CSI driver",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				None:                  &kubeoneapi.NoneSpec{},
+				CSISnapshotController: enabled,
+			},
+			version:       "1.24.2",
+			expectedError: false,
 		},
 		{
-			name:             "only containerd defined",
-			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{}},
-			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.20"},
-			expectedError:    false,
+			name: "AWS 1.22 cluster with in-tree cloud provider",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				AWS:                   &kubeoneapi.AWSSpec{},
+				CSISnapshotController: enabled,
+			},
+			version:       "1.22.4",
+			expectedError: false,
 		},
 		{
-			name: "both defined",
-			containerRuntime: kubeoneapi.ContainerRuntimeConfig{
-				Docker:     &kubeoneapi.ContainerRuntimeDocker{},
-				Containerd: &kubeoneapi.ContainerRuntimeContainerd{},
+			name: "AWS 1.23 cluster ships snapshot-controller",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				AWS:                   &kubeoneapi.AWSSpec{},
+				CSISnapshotController: enabled,
 			},
-			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			version:       "1.23.8",
 			expectedError: true,
 		},
 		{
-			name:             "non defined",
-			containerRuntime: kubeoneapi.ContainerRuntimeConfig{},
-			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.20"},
-			expectedError:    false,
+			name: "vSphere cluster with external cloud provider ships snapshot-controller",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				External:              true,
+				Vsphere:               &kubeoneapi.VsphereSpec{},
+				CSISnapshotController: enabled,
+			},
+			version:       "1.24.2",
+			expectedError: true,
 		},
 		{
-			name:             "non defined, 1.21+",
-			containerRuntime: kubeoneapi.ContainerRuntimeConfig{},
-			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.21"},
-			expectedError:    false,
+			name: "Hetzner CSI driver doesn't support snapshots",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				Hetzner:               &kubeoneapi.HetznerSpec{},
+				CSISnapshotController: enabled,
+			},
+			version:       "1.24.2",
+			expectedError: true,
+		},
+		{
+			name: "disabled snapshot-controller",
+			providerConfig: kubeoneapi.CloudProviderSpec{
+				Hetzner:               &kubeoneapi.HetznerSpec{},
+				CSISnapshotController: &kubeoneapi.CSISnapshotControllerConfig{},
+			},
+			version:       "1.24.2",
+			expectedError: false,
 		},
 	}
-
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateContainerRuntimeConfig(tc.containerRuntime, tc.versions, &field.Path{})
+			c := kubeoneapi.KubeOneCluster{
+				CloudProvider: tc.providerConfig,
+				Versions:      kubeoneapi.VersionConfig{Kubernetes: tc.version},
+			}
+
+			errs := ValidateCSISnapshotController(c, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -973,16 +1279,230 @@ func TestValidateContainerRuntimeConfig(t *testing.T) {
 	}
 }
 
-func TestValidateClusterNetworkConfig(t *testing.T) {
+func TestValidateContainerRuntimeConfig(t *testing.T) {
 	tests := []struct {
-		name                 string
-		clusterNetworkConfig kubeoneapi.ClusterNetworkConfig
-		expectedError        bool
+		name             string
+		containerRuntime kubeoneapi.ContainerRuntimeConfig
+		versions         kubeoneapi.VersionConfig
+		expectedError    bool
 	}{
 		{
-			name: "valid network config",
-			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
-				PodSubnet:     "192.168.1.0/24",
+			name:             "only docker defined",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{}},
+			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError:    false,
+		},
+		{
+			name:             "docker with kubernetes 1.24+",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{}},
+			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError:    true,
+		},
+		{
+			name: "docker with valid log config",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{
+				LogDriver: "json-file",
+				LogOpts:   map[string]string{"max-size": "50Mi", "max-file": "3"},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError: false,
+		},
+		{
+			name:             "docker with unsupported log driver",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{LogDriver: "unknown"}},
+			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError:    true,
+		},
+		{
+			name: "docker with rotation log options for journald log driver",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{
+				LogDriver: "journald",
+				LogOpts:   map[string]string{"max-size": "50m"},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError: true,
+		},
+		{
+			name: "docker with invalid max-size log option",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{
+				LogOpts: map[string]string{"max-size": "50MB"},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError: true,
+		},
+		{
+			name: "docker with invalid max-file log option",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{
+				LogOpts: map[string]string{"max-file": "0"},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError: true,
+		},
+		{
+			name:             "only containerd defined",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{}},
+			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError:    false,
+		},
+		{
+			name: "containerd with valid CRI settings",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				StreamServerAddress: "127.0.0.1",
+				StreamServerPort:    10010,
+				StreamIdleTimeout:   "4h",
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: false,
+		},
+		{
+			name: "containerd with negative stream idle timeout",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				StreamIdleTimeout: "-1h",
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "containerd with invalid stream server address",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				StreamServerAddress: "localhost",
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "containerd with invalid stream server port",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				StreamServerPort: 70000,
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "containerd with additional runtimes",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+					"gvisor": {
+						RuntimeType: "io.containerd.runsc.v1",
+						RuntimeClass: &kubeoneapi.ContainerdRuntimeClass{
+							NodeSelector: map[string]string{"sandbox": "gvisor"},
+						},
+					},
+					"crun": {
+						RuntimeType: "io.containerd.runc.v2",
+						BinaryName:  "/usr/local/bin/crun",
+					},
+				},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: false,
+		},
+		{
+			name: "containerd runtime without runtime type",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+					"gvisor": {},
+				},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "containerd runc runtime without binary name",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+					"crun": {
+						RuntimeType: "io.containerd.runc.v2",
+					},
+				},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "containerd runtime overriding runc",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+					"runc": {
+						RuntimeType: "io.containerd.runc.v2",
+						BinaryName:  "/usr/bin/runc",
+					},
+				},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "containerd runtime with invalid name",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+					"gVisor": {
+						RuntimeType: "io.containerd.runsc.v1",
+					},
+				},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "containerd runtime class with invalid node selector",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+					"gvisor": {
+						RuntimeType: "io.containerd.runsc.v1",
+						RuntimeClass: &kubeoneapi.ContainerdRuntimeClass{
+							NodeSelector: map[string]string{"sandbox": "not valid"},
+						},
+					},
+				},
+			}},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24"},
+			expectedError: true,
+		},
+		{
+			name: "both defined",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{
+				Docker:     &kubeoneapi.ContainerRuntimeDocker{},
+				Containerd: &kubeoneapi.ContainerRuntimeContainerd{},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError: true,
+		},
+		{
+			name:             "non defined",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{},
+			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.20"},
+			expectedError:    false,
+		},
+		{
+			name:             "non defined, 1.21+",
+			containerRuntime: kubeoneapi.ContainerRuntimeConfig{},
+			versions:         kubeoneapi.VersionConfig{Kubernetes: "1.21"},
+			expectedError:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateContainerRuntimeConfig(tc.containerRuntime, tc.versions, &field.Path{})
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateClusterNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name                 string
+		clusterNetworkConfig kubeoneapi.ClusterNetworkConfig
+		expectedError        bool
+	}{
+		{
+			name: "valid network config",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				PodSubnet:     "192.168.1.0/24",
 				ServiceSubnet: "192.168.0.0/24",
 			},
 			expectedError: false,
@@ -1019,6 +1539,62 @@ func TestValidateClusterNetworkConfig(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "valid node port range",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodePortRange: "30000-32767",
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid single port node port range",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodePortRange: "30000-30000",
+			},
+			expectedError: false,
+		},
+		{
+			name: "node port range not in min-max format",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodePortRange: "30000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "node port range with non-numeric bound",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodePortRange: "30000-abc",
+			},
+			expectedError: true,
+		},
+		{
+			name: "node port range with min greater than max",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodePortRange: "32767-30000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "node port range out of bounds",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodePortRange: "0-70000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid node connectivity check",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodeConnectivityCheck: &kubeoneapi.NodeConnectivityCheck{MTU: 9001},
+			},
+			expectedError: false,
+		},
+		{
+			name: "node connectivity check mtu too small",
+			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
+				NodeConnectivityCheck: &kubeoneapi.NodeConnectivityCheck{MTU: 500},
+			},
+			expectedError: true,
+		},
 		{
 			name: "invalid cni config",
 			clusterNetworkConfig: kubeoneapi.ClusterNetworkConfig{
@@ -1041,6 +1617,109 @@ func TestValidateClusterNetworkConfig(t *testing.T) {
 	}
 }
 
+func TestValidateNodeCIDRMaskSize(t *testing.T) {
+	tests := []struct {
+		name             string
+		podSubnet        string
+		nodeCIDRMaskSize *kubeoneapi.NodeCIDRMaskSize
+		maxPods          *int32
+		expectedError    bool
+	}{
+		{
+			name:             "not configured",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: nil,
+			expectedError:    false,
+		},
+		{
+			name:             "valid ipv4 mask size",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv4: int32Ptr(23)},
+			maxPods:          int32Ptr(250),
+			expectedError:    false,
+		},
+		{
+			name:             "valid ipv6 mask size",
+			podSubnet:        "fd00::/48",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv6: int32Ptr(64)},
+			expectedError:    false,
+		},
+		{
+			name:             "ipv4 mask size smaller than pod subnet",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv4: int32Ptr(15)},
+			expectedError:    true,
+		},
+		{
+			name:             "ipv6 mask size too far from pod subnet",
+			podSubnet:        "fd00::/40",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv6: int32Ptr(64)},
+			expectedError:    true,
+		},
+		{
+			name:             "ipv6 mask size with ipv4 pod subnet",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv6: int32Ptr(64)},
+			expectedError:    true,
+		},
+		{
+			name:             "ipv4 mask size with ipv6 pod subnet",
+			podSubnet:        "fd00::/48",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv4: int32Ptr(24)},
+			expectedError:    true,
+		},
+		{
+			name:             "ipv4 mask size out of range",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv4: int32Ptr(33)},
+			expectedError:    true,
+		},
+		{
+			name:             "node cidr too small for the default maxPods",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv4: int32Ptr(26)},
+			expectedError:    true,
+		},
+		{
+			name:             "node cidr large enough for lowered maxPods",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv4: int32Ptr(26)},
+			maxPods:          int32Ptr(60),
+			expectedError:    false,
+		},
+		{
+			name:             "node cidr too small for maxPods",
+			podSubnet:        "10.244.0.0/16",
+			nodeCIDRMaskSize: &kubeoneapi.NodeCIDRMaskSize{IPv4: int32Ptr(24)},
+			maxPods:          int32Ptr(300),
+			expectedError:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := kubeoneapi.KubeOneCluster{
+				ClusterNetwork: kubeoneapi.ClusterNetworkConfig{
+					PodSubnet:        tc.podSubnet,
+					NodeCIDRMaskSize: tc.nodeCIDRMaskSize,
+				},
+				ControlPlane: kubeoneapi.ControlPlaneConfig{
+					Hosts: []kubeoneapi.HostConfig{
+						{
+							Kubelet: kubeoneapi.KubeletConfig{MaxPods: tc.maxPods},
+						},
+					},
+				},
+			}
+			errs := ValidateNodeCIDRMaskSize(cluster, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
 func TestValidateCNIConfig(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1144,6 +1823,21 @@ func TestValidateStaticWorkersConfig(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "dnsName is forbidden",
+			staticWorkersConfig: kubeoneapi.StaticWorkersConfig{
+				Hosts: []kubeoneapi.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						DNSName:        "worker-1.example.com",
+					},
+				},
+			},
+			expectedError: true,
+		},
 		{
 			name: "no hosts provided",
 			staticWorkersConfig: kubeoneapi.StaticWorkersConfig{
@@ -1192,6 +1886,7 @@ func TestValidateDynamicWorkerConfig(t *testing.T) {
 	tests := []struct {
 		name                string
 		dynamicWorkerConfig []kubeoneapi.DynamicWorkerConfig
+		provider            kubeoneapi.CloudProviderSpec
 		expectedError       bool
 	}{
 		{
@@ -1279,69 +1974,1432 @@ func TestValidateDynamicWorkerConfig(t *testing.T) {
 			},
 			expectedError: true,
 		},
-	}
-
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateDynamicWorkerConfig(tc.dynamicWorkerConfig, nil)
-			if (len(errs) == 0) == tc.expectedError {
-				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
-			}
-		})
-	}
-}
-
-func TestValidateCABundle(t *testing.T) {
-	tests := []struct {
-		name          string
-		caBundle      string
-		expectedError bool
-	}{
 		{
-			name:          "empty",
-			caBundle:      "",
-			expectedError: false,
+			name: "instanceProfile set on AWS",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						InstanceProfile: "workers-profile",
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				AWS: &kubeoneapi.AWSSpec{},
+			},
+			expectedError: false,
+		},
+		{
+			name: "instanceProfile set on non-AWS provider",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						InstanceProfile: "workers-profile",
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Hetzner: &kubeoneapi.HetznerSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid cloudNetwork on AWS",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SubnetID:         "subnet-0123",
+							SecurityGroupIDs: []string{"sg-0123", "sg-4567"},
+							AssignPublicIP:   boolPtr(false),
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				AWS: &kubeoneapi.AWSSpec{},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid subnet ID on AWS",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SubnetID: "0123",
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				AWS: &kubeoneapi.AWSSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid security group ID on AWS",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SecurityGroupIDs: []string{"default"},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				AWS: &kubeoneapi.AWSSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "empty security group ID",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SecurityGroupIDs: []string{""},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Openstack: &kubeoneapi.OpenstackSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid cloudNetwork on Azure",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SubnetID:         "workers",
+							SecurityGroupIDs: []string{"workers-nsg"},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Azure: &kubeoneapi.AzureSpec{},
+			},
+			expectedError: false,
+		},
+		{
+			name: "multiple security groups on Azure",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SecurityGroupIDs: []string{"workers-nsg", "other-nsg"},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Azure: &kubeoneapi.AzureSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "security groups on GCE",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SecurityGroupIDs: []string{"workers"},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				GCE: &kubeoneapi.GCESpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "assignPublicIP on OpenStack",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							AssignPublicIP: boolPtr(true),
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Openstack: &kubeoneapi.OpenstackSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "cloudNetwork on unsupported provider",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						CloudNetwork: &kubeoneapi.CloudNetworkConfig{
+							SubnetID: "workers",
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Hetzner: &kubeoneapi.HetznerSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid disks on AWS",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							Size: 100,
+							Type: "gp3",
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				AWS: &kubeoneapi.AWSSpec{},
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid disks with data volume on Azure",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							Size: 50,
+							Type: "Premium_LRS",
+							DataVolumes: []kubeoneapi.DataVolumeConfig{
+								{Size: 200, Type: "UltraSSD_LRS"},
+							},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Azure: &kubeoneapi.AzureSpec{},
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid disks on OpenStack",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							Size: 100,
+							Type: "ssd",
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Openstack: &kubeoneapi.OpenstackSpec{},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid disk type on GCE",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							Type: "gp3",
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				GCE: &kubeoneapi.GCESpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative disk size",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							Size: -1,
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				AWS: &kubeoneapi.AWSSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "disk type on vSphere",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							Size: 100,
+							Type: "thin",
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Vsphere: &kubeoneapi.VsphereSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "data volumes on AWS",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							DataVolumes: []kubeoneapi.DataVolumeConfig{
+								{Size: 100, Type: "gp3"},
+							},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				AWS: &kubeoneapi.AWSSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid data volume size on Azure",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							DataVolumes: []kubeoneapi.DataVolumeConfig{
+								{Size: 0, Type: "Premium_LRS"},
+							},
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Azure: &kubeoneapi.AzureSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "disks on unsupported provider",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						Disks: &kubeoneapi.DiskConfig{
+							Size: 100,
+						},
+					},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{
+				Hetzner: &kubeoneapi.HetznerSpec{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid bootstrapCommands",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						BootstrapCommands: []string{
+							"mkdir -p /mnt/data && mount /dev/sdb /mnt/data",
+							`echo 'registered' > /var/log/agent.log`,
+						},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "empty bootstrap command",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						BootstrapCommands: []string{
+							"  ",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "multi-line bootstrap command",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						BootstrapCommands: []string{
+							"mount /dev/sdb /mnt/data\nreboot",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "bootstrap command with unbalanced quotes",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Config: kubeoneapi.ProviderSpec{
+						BootstrapCommands: []string{
+							`echo "registered > /var/log/agent.log`,
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid autoscaler",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Autoscaler: &kubeoneapi.DynamicWorkerAutoscaler{
+						MinReplicas: 1,
+						MaxReplicas: 5,
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "autoscaler minReplicas greater than maxReplicas",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+					Autoscaler: &kubeoneapi.DynamicWorkerAutoscaler{
+						MinReplicas: 5,
+						MaxReplicas: 3,
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "autoscaler minReplicas set to zero",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(1),
+					Autoscaler: &kubeoneapi.DynamicWorkerAutoscaler{
+						MinReplicas: 0,
+						MaxReplicas: 3,
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "replicas out of autoscaler range",
+			dynamicWorkerConfig: []kubeoneapi.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(6),
+					Autoscaler: &kubeoneapi.DynamicWorkerAutoscaler{
+						MinReplicas: 1,
+						MaxReplicas: 5,
+					},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateDynamicWorkerConfig(tc.dynamicWorkerConfig, tc.provider, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateCABundle(t *testing.T) {
+	tests := []struct {
+		name          string
+		caBundle      string
+		expectedError bool
+	}{
+		{
+			name:          "empty",
+			caBundle:      "",
+			expectedError: false,
+		},
+		{
+			name: "correct",
+			caBundle: heredoc.Doc(`
+				## some comments
+
+				GlobalSign Root CA
+				==================
+				-----BEGIN CERTIFICATE-----
+				MIIDdTCCAl2gAwIBAgILBAAAAAABFUtaw5QwDQYJKoZIhvcNAQEFBQAwVzELMAkGA1UEBhMCQkUx
+				GTAXBgNVBAoTEEdsb2JhbFNpZ24gbnYtc2ExEDAOBgNVBAsTB1Jvb3QgQ0ExGzAZBgNVBAMTEkds
+				b2JhbFNpZ24gUm9vdCBDQTAeFw05ODA5MDExMjAwMDBaFw0yODAxMjgxMjAwMDBaMFcxCzAJBgNV
+				BAYTAkJFMRkwFwYDVQQKExBHbG9iYWxTaWduIG52LXNhMRAwDgYDVQQLEwdSb290IENBMRswGQYD
+				VQQDExJHbG9iYWxTaWduIFJvb3QgQ0EwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQDa
+				DuaZjc6j40+Kfvvxi4Mla+pIH/EqsLmVEQS98GPR4mdmzxzdzxtIK+6NiY6arymAZavpxy0Sy6sc
+				THAHoT0KMM0VjU/43dSMUBUc71DuxC73/OlS8pF94G3VNTCOXkNz8kHp1Wrjsok6Vjk4bwY8iGlb
+				Kk3Fp1S4bInMm/k8yuX9ifUSPJJ4ltbcdG6TRGHRjcdGsnUOhugZitVtbNV4FpWi6cgKOOvyJBNP
+				c1STE4U6G7weNLWLBYy5d4ux2x8gkasJU26Qzns3dLlwR5EiUWMWea6xrkEmCMgZK9FGqkjWZCrX
+				gzT/LCrBbBlDSgeF59N89iFo7+ryUp9/k5DPAgMBAAGjQjBAMA4GA1UdDwEB/wQEAwIBBjAPBgNV
+				HRMBAf8EBTADAQH/MB0GA1UdDgQWBBRge2YaRQ2XyolQL30EzTSo//z9SzANBgkqhkiG9w0BAQUF
+				AAOCAQEA1nPnfE920I2/7LqivjTFKDK1fPxsnCwrvQmeU79rXqoRSLblCKOzyj1hTdNGCbM+w6Dj
+				Y1Ub8rrvrTnhQ7k4o+YviiY776BQVvnGCv04zcQLcFGUl5gE38NflNUVyRRBnMRddWQVDf9VMOyG
+				j/8N7yy5Y0b2qvzfvGn9LhJIZJrglfCm7ymPAbEVtQwdpf5pLGkkeB6zpxxxYu7KyJesF12KwvhH
+				hm4qxFYxldBniYUr+WymXUadDKqC5JlR3XC321Y9YeRq4VzW9v493kHMB65jUr9TU/Qr6cf9tveC
+				X4XSQRjbgbMEHMUfpIBvFSDJ3gyICh3WZlXi/EjJKSZp4A==
+				-----END CERTIFICATE-----
+			`),
+			expectedError: false,
+		},
+		{
+			name: "no certs but with comments",
+			caBundle: heredoc.Doc(`
+				# leading comment
+				## additional comment
+			`),
+			expectedError: true,
+		},
+		{
+			name:          "incorrect",
+			caBundle:      "garbadge",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateCABundle(tc.caBundle, field.NewPath("caBundle"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Logf("failed value:\n%q", tc.caBundle)
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateCertificateAuthority(t *testing.T) {
+	tests := []struct {
+		name          string
+		ca            *kubeoneapi.CertificateAuthority
+		expectedError bool
+	}{
+		{
+			name:          "not set",
+			ca:            nil,
+			expectedError: false,
+		},
+		{
+			name: "all CAs",
+			ca: &kubeoneapi.CertificateAuthority{
+				Kubernetes: &kubeoneapi.CAKeyPair{CertFilePath: "ca.crt", KeyFilePath: "ca.key"},
+				FrontProxy: &kubeoneapi.CAKeyPair{CertFilePath: "front-proxy-ca.crt", KeyFilePath: "front-proxy-ca.key"},
+				Etcd:       &kubeoneapi.CAKeyPair{CertFilePath: "etcd/ca.crt", KeyFilePath: "etcd/ca.key"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "only etcd CA",
+			ca: &kubeoneapi.CertificateAuthority{
+				Etcd: &kubeoneapi.CAKeyPair{CertFilePath: "etcd/ca.crt", KeyFilePath: "etcd/ca.key"},
+			},
+			expectedError: false,
+		},
+		{
+			name:          "no CAs",
+			ca:            &kubeoneapi.CertificateAuthority{},
+			expectedError: true,
+		},
+		{
+			name: "certificate without key",
+			ca: &kubeoneapi.CertificateAuthority{
+				Kubernetes: &kubeoneapi.CAKeyPair{CertFilePath: "ca.crt"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "key without certificate",
+			ca: &kubeoneapi.CertificateAuthority{
+				FrontProxy: &kubeoneapi.CAKeyPair{KeyFilePath: "front-proxy-ca.key"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateCertificateAuthority(tc.ca, field.NewPath("certificateAuthority"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneComponents(t *testing.T) {
+	tests := []struct {
+		name          string
+		components    *kubeoneapi.ControlPlaneComponents
+		expectedError bool
+	}{
+		{
+			name:          "not configured",
+			components:    nil,
+			expectedError: false,
+		},
+		{
+			name: "scheduler not configured",
+			components: &kubeoneapi.ControlPlaneComponents{
+				Scheduler: nil,
+			},
+			expectedError: false,
+		},
+		{
+			name: "adaptive percentageOfNodesToScore",
+			components: &kubeoneapi.ControlPlaneComponents{
+				Scheduler: &kubeoneapi.SchedulerConfig{PercentageOfNodesToScore: int32Ptr(0)},
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid percentageOfNodesToScore",
+			components: &kubeoneapi.ControlPlaneComponents{
+				Scheduler: &kubeoneapi.SchedulerConfig{PercentageOfNodesToScore: int32Ptr(100)},
+			},
+			expectedError: false,
+		},
+		{
+			name: "negative percentageOfNodesToScore",
+			components: &kubeoneapi.ControlPlaneComponents{
+				Scheduler: &kubeoneapi.SchedulerConfig{PercentageOfNodesToScore: int32Ptr(-1)},
+			},
+			expectedError: true,
+		},
+		{
+			name: "percentageOfNodesToScore over 100",
+			components: &kubeoneapi.ControlPlaneComponents{
+				Scheduler: &kubeoneapi.SchedulerConfig{PercentageOfNodesToScore: int32Ptr(101)},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid controllerManager clusterSigningDuration",
+			components: &kubeoneapi.ControlPlaneComponents{
+				ControllerManager: &kubeoneapi.ControllerManagerConfig{
+					ClusterSigningDuration: metav1.Duration{Duration: 5 * 365 * 24 * time.Hour},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "negative controllerManager clusterSigningDuration",
+			components: &kubeoneapi.ControlPlaneComponents{
+				ControllerManager: &kubeoneapi.ControllerManagerConfig{
+					ClusterSigningDuration: metav1.Duration{Duration: -time.Hour},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid apiServer config",
+			components: &kubeoneapi.ControlPlaneComponents{
+				APIServer: &kubeoneapi.APIServerConfig{
+					EndpointReconcilerType: "lease",
+					FeatureGates: map[string]bool{
+						"APIServerIdentity": true,
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid apiServer config",
+			components: &kubeoneapi.ControlPlaneComponents{
+				APIServer: &kubeoneapi.APIServerConfig{
+					EndpointReconcilerType: "invalid",
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateControlPlaneComponents(tc.components, kubeoneapi.VersionConfig{Kubernetes: "1.24.0"}, field.NewPath("controlPlaneComponents"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v: %v", tc.expectedError, (len(errs) != 0), errs)
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        kubeoneapi.APIServerConfig
+		versions      kubeoneapi.VersionConfig
+		expectedError bool
+	}{
+		{
+			name:          "empty config",
+			config:        kubeoneapi.APIServerConfig{},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: false,
+		},
+		{
+			name: "lease endpoint reconciler",
+			config: kubeoneapi.APIServerConfig{
+				EndpointReconcilerType: "lease",
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: false,
+		},
+		{
+			name: "none endpoint reconciler",
+			config: kubeoneapi.APIServerConfig{
+				EndpointReconcilerType: "none",
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: false,
+		},
+		{
+			name: "unsupported endpoint reconciler",
+			config: kubeoneapi.APIServerConfig{
+				EndpointReconcilerType: "dynamic",
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: true,
+		},
+		{
+			name: "valid service account max token expiration",
+			config: kubeoneapi.APIServerConfig{
+				ServiceAccountMaxTokenExpiration: metav1.Duration{Duration: 2 * time.Hour},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: false,
+		},
+		{
+			name: "service account max token expiration too short",
+			config: kubeoneapi.APIServerConfig{
+				ServiceAccountMaxTokenExpiration: metav1.Duration{Duration: 30 * time.Minute},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: true,
+		},
+		{
+			name: "negative service account max token expiration",
+			config: kubeoneapi.APIServerConfig{
+				ServiceAccountMaxTokenExpiration: metav1.Duration{Duration: -time.Hour},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: true,
+		},
+		{
+			name: "APIServerIdentity and StorageVersionAPI",
+			config: kubeoneapi.APIServerConfig{
+				FeatureGates: map[string]bool{
+					"APIServerIdentity": true,
+					"StorageVersionAPI": true,
+				},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.20.0"},
+			expectedError: false,
+		},
+		{
+			name: "StorageVersionAPI without APIServerIdentity",
+			config: kubeoneapi.APIServerConfig{
+				FeatureGates: map[string]bool{
+					"StorageVersionAPI": true,
+				},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: true,
+		},
+		{
+			name: "StorageVersionAPI with disabled APIServerIdentity",
+			config: kubeoneapi.APIServerConfig{
+				FeatureGates: map[string]bool{
+					"APIServerIdentity": false,
+					"StorageVersionAPI": true,
+				},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: true,
+		},
+		{
+			name: "feature gate supported by the Kubernetes version",
+			config: kubeoneapi.APIServerConfig{
+				FeatureGates: map[string]bool{
+					"OpenAPIV3": true,
+				},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.23.5"},
+			expectedError: false,
+		},
+		{
+			name: "feature gate not supported by the Kubernetes version",
+			config: kubeoneapi.APIServerConfig{
+				FeatureGates: map[string]bool{
+					"OpenAPIV3": true,
+				},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.22.9"},
+			expectedError: true,
+		},
+		{
+			name: "unknown feature gate",
+			config: kubeoneapi.APIServerConfig{
+				FeatureGates: map[string]bool{
+					"SomeFutureFeature": true,
+				},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: false,
+		},
+		{
+			name: "invalid feature gate name",
+			config: kubeoneapi.APIServerConfig{
+				FeatureGates: map[string]bool{
+					"APIServerIdentity=true": true,
+				},
+			},
+			versions:      kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateAPIServerConfig(tc.config, tc.versions, field.NewPath("apiServer"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v: %v", tc.expectedError, (len(errs) != 0), errs)
+			}
+		})
+	}
+}
+
+func TestValidateFeatures(t *testing.T) {
+	tests := []struct {
+		name          string
+		features      kubeoneapi.Features
+		versions      kubeoneapi.VersionConfig
+		expectedError bool
+	}{
+		{
+			name: "psp and auditing enabled",
+			features: kubeoneapi.Features{
+				PodSecurityPolicy: &kubeoneapi.PodSecurityPolicy{
+					Enable: true,
+				},
+				DynamicAuditLog: &kubeoneapi.DynamicAuditLog{
+					Enable: true,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "metrics server disabled",
+			features: kubeoneapi.Features{
+				MetricsServer: &kubeoneapi.MetricsServer{
+					Enable: false,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "kubelet serving cert rotation enabled",
+			features: kubeoneapi.Features{
+				KubeletServingCertRotation: &kubeoneapi.KubeletServingCertRotation{
+					Enable: true,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name:     "no feature configured",
+			features: kubeoneapi.Features{},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "oidc enabled",
+			features: kubeoneapi.Features{
+				OpenIDConnect: &kubeoneapi.OpenIDConnect{
+					Enable: true,
+					Config: kubeoneapi.OpenIDConnectConfig{
+						IssuerURL:     "test.cluster.local",
+						ClientID:      "123",
+						RequiredClaim: "test",
+					},
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid staticAudit config",
+			features: kubeoneapi.Features{
+				StaticAuditLog: &kubeoneapi.StaticAuditLog{
+					Enable: true,
+					Config: kubeoneapi.StaticAuditLogConfig{},
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid oidc config",
+			features: kubeoneapi.Features{
+				OpenIDConnect: &kubeoneapi.OpenIDConnect{
+					Enable: true,
+					Config: kubeoneapi.OpenIDConnectConfig{},
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid podNodeSelector config",
+			features: kubeoneapi.Features{
+				PodNodeSelector: &kubeoneapi.PodNodeSelector{
+					Enable: true,
+					Config: kubeoneapi.PodNodeSelectorConfig{},
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "staticAuth with clientCABundle file path",
+			features: kubeoneapi.Features{
+				StaticAuth: &kubeoneapi.StaticAuth{
+					ClientCABundleFilePath: "./client-ca.crt",
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "staticAuth with invalid clientCABundle",
+			features: kubeoneapi.Features{
+				StaticAuth: &kubeoneapi.StaticAuth{
+					ClientCABundle: "not a PEM bundle",
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "staticAuth with both clientCABundle and clientCABundleFilePath",
+			features: kubeoneapi.Features{
+				StaticAuth: &kubeoneapi.StaticAuth{
+					ClientCABundle:         "not a PEM bundle",
+					ClientCABundleFilePath: "./client-ca.crt",
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "pod disruption budgets enabled",
+			features: kubeoneapi.Features{
+				PodDisruptionBudgets: &kubeoneapi.PodDisruptionBudgets{
+					Enable:       true,
+					MinAvailable: intstr.FromInt(1),
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: false,
+		},
+		{
+			name: "pod disruption budgets with invalid minAvailable",
+			features: kubeoneapi.Features{
+				PodDisruptionBudgets: &kubeoneapi.PodDisruptionBudgets{
+					Enable:       true,
+					MinAvailable: intstr.FromInt(0),
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "pod disruption budgets with percentage minAvailable",
+			features: kubeoneapi.Features{
+				PodDisruptionBudgets: &kubeoneapi.PodDisruptionBudgets{
+					Enable:       true,
+					MinAvailable: intstr.FromString("50%"),
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: false,
+		},
+		{
+			name: "pod disruption budgets with invalid percentage minAvailable",
+			features: kubeoneapi.Features{
+				PodDisruptionBudgets: &kubeoneapi.PodDisruptionBudgets{
+					Enable:       true,
+					MinAvailable: intstr.FromString("0%"),
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "pod disruption budgets with malformed minAvailable",
+			features: kubeoneapi.Features{
+				PodDisruptionBudgets: &kubeoneapi.PodDisruptionBudgets{
+					Enable:       true,
+					MinAvailable: intstr.FromString("half"),
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "etcd metrics enabled on a dedicated port",
+			features: kubeoneapi.Features{
+				EtcdMetrics: &kubeoneapi.EtcdMetrics{
+					Enable: true,
+					Port:   2382,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: false,
+		},
+		{
+			name: "etcd metrics port conflicting with etcd client port",
+			features: kubeoneapi.Features{
+				EtcdMetrics: &kubeoneapi.EtcdMetrics{
+					Enable: true,
+					Port:   2379,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "etcd metrics port conflicting with kubelet port",
+			features: kubeoneapi.Features{
+				EtcdMetrics: &kubeoneapi.EtcdMetrics{
+					Enable: true,
+					Port:   10250,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "etcd metrics port out of range",
+			features: kubeoneapi.Features{
+				EtcdMetrics: &kubeoneapi.EtcdMetrics{
+					Enable: true,
+					Port:   70000,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "etcd metrics port not set",
+			features: kubeoneapi.Features{
+				EtcdMetrics: &kubeoneapi.EtcdMetrics{
+					Enable: true,
+					Port:   0,
+				},
+			},
+			versions: kubeoneapi.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateFeatures(tc.features, tc.versions, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidatePrometheusAdapter(t *testing.T) {
+	tests := []struct {
+		name          string
+		adapter       kubeoneapi.PrometheusAdapter
+		expectedError bool
+		expectedField string
+	}{
+		{
+			name: "default rules",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+			},
+			expectedError: false,
+		},
+		{
+			name: "custom rules",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "https://prometheus.example.com",
+				Rules: heredoc.Doc(`
+					rules:
+					- seriesQuery: 'http_requests_total{namespace!="",pod!=""}'
+					  seriesFilters:
+					  - isNot: "^.*_bucket$"
+					  resources:
+					    overrides:
+					      namespace: {resource: "namespace"}
+					      pod: {resource: "pod"}
+					  name:
+					    matches: "^(.*)_total$"
+					    as: "${1}_per_second"
+					  metricsQuery: 'sum(rate(<<.Series>>{<<.LabelMatchers>>}[2m])) by (<<.GroupBy>>)'
+					externalRules:
+					- seriesQuery: 'queue_length{queue!=""}'
+					  resources:
+					    template: <<.Resource>>
+					  metricsQuery: 'max(<<.Series>>{<<.LabelMatchers>>})'
+				`),
+			},
+			expectedError: false,
+		},
+		{
+			name: "missing prometheus URL",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable: true,
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid prometheus URL",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "prometheus:9090",
+			},
+			expectedError: true,
+		},
+		{
+			name: "unparsable rules",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules:         "rules: {",
+			},
+			expectedError: true,
+		},
+		{
+			name: "unknown rule field",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules: heredoc.Doc(`
+					rules:
+					- seriesQuery: 'up'
+					  metricQuery: 'up'
+				`),
+			},
+			expectedError: true,
+		},
+		{
+			name: "no rules",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules:         "rules: []",
+			},
+			expectedError: true,
+		},
+		{
+			name: "resource rules",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules: heredoc.Doc(`
+					rules:
+					- seriesQuery: 'up'
+					  metricsQuery: 'up'
+					resourceRules:
+					  window: 5m
+				`),
+			},
+			expectedError: true,
+		},
+		{
+			name: "missing metrics query",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules: heredoc.Doc(`
+					rules:
+					- seriesQuery: 'up'
+				`),
+			},
+			expectedError: true,
+			expectedField: "prometheusAdapter.rules.rules[0].metricsQuery",
+		},
+		{
+			name: "missing external rule series query",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules: heredoc.Doc(`
+					externalRules:
+					- metricsQuery: 'up'
+				`),
+			},
+			expectedError: true,
+			expectedField: "prometheusAdapter.rules.externalRules[0].seriesQuery",
+		},
+		{
+			name: "invalid metrics query template",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules: heredoc.Doc(`
+					rules:
+					- seriesQuery: 'up'
+					  metricsQuery: 'sum(<<.Series>>{<<.LabelMatchers>>}) by (<<.GroupBy)'
+				`),
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid name regexp",
+			adapter: kubeoneapi.PrometheusAdapter{
+				Enable:        true,
+				PrometheusURL: "http://prometheus.monitoring.svc:9090",
+				Rules: heredoc.Doc(`
+					rules:
+					- seriesQuery: 'up'
+					  name:
+					    matches: "^(.*_total$"
+					  metricsQuery: 'up'
+				`),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidatePrometheusAdapter(tc.adapter, field.NewPath("prometheusAdapter"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v: %v", tc.expectedError, (len(errs) != 0), errs)
+			}
+			if tc.expectedField != "" && (len(errs) == 0 || errs[0].Field != tc.expectedField) {
+				t.Errorf("test case failed: expected error for field %q, but got %v", tc.expectedField, errs)
+			}
+		})
+	}
+}
+
+func TestValidateEtcdBackup(t *testing.T) {
+	validBackup := func(mod func(*kubeoneapi.EtcdBackup)) kubeoneapi.EtcdBackup {
+		eb := kubeoneapi.EtcdBackup{
+			Enable:           true,
+			Schedule:         "@every 30m",
+			S3Bucket:         "s3:s3.amazonaws.com/etcd-backups",
+			AWSDefaultRegion: "eu-central-1",
+			KeepLast:         48,
+		}
+		if mod != nil {
+			mod(&eb)
+		}
+
+		return eb
+	}
+
+	tests := []struct {
+		name          string
+		backup        kubeoneapi.EtcdBackup
+		expectedError bool
+	}{
+		{
+			name:          "valid config",
+			backup:        validBackup(nil),
+			expectedError: false,
 		},
 		{
-			name: "correct",
-			caBundle: heredoc.Doc(`
-				## some comments
-
-				GlobalSign Root CA
-				==================
-				-----BEGIN CERTIFICATE-----
-				MIIDdTCCAl2gAwIBAgILBAAAAAABFUtaw5QwDQYJKoZIhvcNAQEFBQAwVzELMAkGA1UEBhMCQkUx
-				GTAXBgNVBAoTEEdsb2JhbFNpZ24gbnYtc2ExEDAOBgNVBAsTB1Jvb3QgQ0ExGzAZBgNVBAMTEkds
-				b2JhbFNpZ24gUm9vdCBDQTAeFw05ODA5MDExMjAwMDBaFw0yODAxMjgxMjAwMDBaMFcxCzAJBgNV
-				BAYTAkJFMRkwFwYDVQQKExBHbG9iYWxTaWduIG52LXNhMRAwDgYDVQQLEwdSb290IENBMRswGQYD
-				VQQDExJHbG9iYWxTaWduIFJvb3QgQ0EwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQDa
-				DuaZjc6j40+Kfvvxi4Mla+pIH/EqsLmVEQS98GPR4mdmzxzdzxtIK+6NiY6arymAZavpxy0Sy6sc
-				THAHoT0KMM0VjU/43dSMUBUc71DuxC73/OlS8pF94G3VNTCOXkNz8kHp1Wrjsok6Vjk4bwY8iGlb
-				Kk3Fp1S4bInMm/k8yuX9ifUSPJJ4ltbcdG6TRGHRjcdGsnUOhugZitVtbNV4FpWi6cgKOOvyJBNP
-				c1STE4U6G7weNLWLBYy5d4ux2x8gkasJU26Qzns3dLlwR5EiUWMWea6xrkEmCMgZK9FGqkjWZCrX
-				gzT/LCrBbBlDSgeF59N89iFo7+ryUp9/k5DPAgMBAAGjQjBAMA4GA1UdDwEB/wQEAwIBBjAPBgNV
-				HRMBAf8EBTADAQH/MB0GA1UdDgQWBBRge2YaRQ2XyolQL30EzTSo//z9SzANBgkqhkiG9w0BAQUF
-				AAOCAQEA1nPnfE920I2/7LqivjTFKDK1fPxsnCwrvQmeU79rXqoRSLblCKOzyj1hTdNGCbM+w6Dj
-				Y1Ub8rrvrTnhQ7k4o+YviiY776BQVvnGCv04zcQLcFGUl5gE38NflNUVyRRBnMRddWQVDf9VMOyG
-				j/8N7yy5Y0b2qvzfvGn9LhJIZJrglfCm7ymPAbEVtQwdpf5pLGkkeB6zpxxxYu7KyJesF12KwvhH
-				hm4qxFYxldBniYUr+WymXUadDKqC5JlR3XC321Y9YeRq4VzW9v493kHMB65jUr9TU/Qr6cf9tveC
-				X4XSQRjbgbMEHMUfpIBvFSDJ3gyICh3WZlXi/EjJKSZp4A==
-				-----END CERTIFICATE-----
-			`),
+			name:          "valid cron expression",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "*/15 1-5,22 * jan-jun mon-fri" }),
 			expectedError: false,
 		},
 		{
-			name: "no certs but with comments",
-			caBundle: heredoc.Doc(`
-				# leading comment
-				## additional comment
-			`),
+			name:          "valid descriptor",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "@daily" }),
+			expectedError: false,
+		},
+		{
+			name:          "missing schedule",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "" }),
 			expectedError: true,
 		},
 		{
-			name:          "incorrect",
-			caBundle:      "garbadge",
+			name:          "too few cron fields",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "0 * * *" }),
+			expectedError: true,
+		},
+		{
+			name:          "cron value out of range",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "0 24 * * *" }),
+			expectedError: true,
+		},
+		{
+			name:          "invalid cron step",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "*/0 * * * *" }),
+			expectedError: true,
+		},
+		{
+			name:          "question mark in day fields",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "0 3 ? * ?" }),
+			expectedError: false,
+		},
+		{
+			name:          "question mark in minute field",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "? 3 * * *" }),
+			expectedError: true,
+		},
+		{
+			name:          "invalid cron range",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "0 0 * * fri-mon" }),
+			expectedError: true,
+		},
+		{
+			name:          "unknown descriptor",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "@sometimes" }),
+			expectedError: true,
+		},
+		{
+			name:          "invalid every duration",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.Schedule = "@every 30" }),
+			expectedError: true,
+		},
+		{
+			name:          "missing s3Bucket",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.S3Bucket = "" }),
+			expectedError: true,
+		},
+		{
+			name:          "missing awsDefaultRegion",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.AWSDefaultRegion = "" }),
+			expectedError: true,
+		},
+		{
+			name:          "invalid keepLast",
+			backup:        validBackup(func(eb *kubeoneapi.EtcdBackup) { eb.KeepLast = 0 }),
 			expectedError: true,
 		},
 	}
@@ -1349,151 +3407,329 @@ func TestValidateCABundle(t *testing.T) {
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateCABundle(tc.caBundle, field.NewPath("caBundle"))
+			errs := ValidateEtcdBackup(tc.backup, field.NewPath("etcdBackup"))
 			if (len(errs) == 0) == tc.expectedError {
-				t.Logf("failed value:\n%q", tc.caBundle)
-				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+				t.Errorf("test case failed: expected %v, but got %v: %v", tc.expectedError, (len(errs) != 0), errs)
 			}
 		})
 	}
 }
 
-func TestValidateFeatures(t *testing.T) {
+func TestValidatePodNodeSelectorConfig(t *testing.T) {
 	tests := []struct {
-		name          string
-		features      kubeoneapi.Features
-		versions      kubeoneapi.VersionConfig
-		expectedError bool
+		name                  string
+		podNodeSelectorConfig kubeoneapi.PodNodeSelectorConfig
+		expectedError         bool
 	}{
 		{
-			name: "psp and auditing enabled",
-			features: kubeoneapi.Features{
-				PodSecurityPolicy: &kubeoneapi.PodSecurityPolicy{
-					Enable: true,
-				},
-				DynamicAuditLog: &kubeoneapi.DynamicAuditLog{
-					Enable: true,
-				},
-			},
-			versions: kubeoneapi.VersionConfig{
-				Kubernetes: "1.20.2",
+			name: "valid podNodeSelector config",
+			podNodeSelectorConfig: kubeoneapi.PodNodeSelectorConfig{
+				ConfigFilePath: "./podnodeselector.yaml",
 			},
 			expectedError: false,
 		},
 		{
-			name: "metrics server disabled",
-			features: kubeoneapi.Features{
-				MetricsServer: &kubeoneapi.MetricsServer{
-					Enable: false,
+			name:                  "invalid podNodeSelector config",
+			podNodeSelectorConfig: kubeoneapi.PodNodeSelectorConfig{},
+			expectedError:         true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidatePodNodeSelectorConfig(tc.podNodeSelectorConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidatePodTolerationRestriction(t *testing.T) {
+	gpuToleration := corev1.Toleration{
+		Key:      "nvidia.com/gpu",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
+	tests := []struct {
+		name                     string
+		podTolerationRestriction kubeoneapi.PodTolerationRestriction
+		expectedError            bool
+	}{
+		{
+			name: "valid cluster-wide tolerations",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:             true,
+				DefaultTolerations: []corev1.Toleration{gpuToleration},
+				Whitelist: []corev1.Toleration{
+					{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists},
 				},
 			},
-			versions: kubeoneapi.VersionConfig{
-				Kubernetes: "1.20.2",
-			},
 			expectedError: false,
 		},
 		{
-			name:     "no feature configured",
-			features: kubeoneapi.Features{},
-			versions: kubeoneapi.VersionConfig{
-				Kubernetes: "1.20.2",
+			name: "valid namespace tolerations",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable: true,
+				Namespaces: []kubeoneapi.NamespaceTolerations{
+					{
+						Name: "ml",
+						DefaultTolerations: []corev1.Toleration{
+							{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ml", Effect: corev1.TaintEffectNoExecute, TolerationSeconds: pointer.Int64(300)},
+						},
+						Whitelist: []corev1.Toleration{
+							{Key: "dedicated", Value: "ml"},
+						},
+					},
+					{
+						Name:      "batch",
+						Whitelist: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					},
+				},
 			},
 			expectedError: false,
 		},
 		{
-			name: "oidc enabled",
-			features: kubeoneapi.Features{
-				OpenIDConnect: &kubeoneapi.OpenIDConnect{
-					Enable: true,
-					Config: kubeoneapi.OpenIDConnectConfig{
-						IssuerURL:     "test.cluster.local",
-						ClientID:      "123",
-						RequiredClaim: "test",
-					},
+			name: "default toleration not whitelisted",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:             true,
+				DefaultTolerations: []corev1.Toleration{gpuToleration},
+				Whitelist: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpExists},
 				},
 			},
-			versions: kubeoneapi.VersionConfig{
-				Kubernetes: "1.20.2",
+			expectedError: true,
+		},
+		{
+			name: "whitelisted value doesn't cover Exists operator",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:             true,
+				DefaultTolerations: []corev1.Toleration{gpuToleration},
+				Whitelist: []corev1.Toleration{
+					{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpEqual, Value: "true"},
+				},
 			},
-			expectedError: false,
+			expectedError: true,
 		},
 		{
-			name: "invalid staticAudit config",
-			features: kubeoneapi.Features{
-				StaticAuditLog: &kubeoneapi.StaticAuditLog{
-					Enable: true,
-					Config: kubeoneapi.StaticAuditLogConfig{},
+			name: "value with Exists operator",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable: true,
+				DefaultTolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "ml"},
 				},
 			},
-			versions: kubeoneapi.VersionConfig{
-				Kubernetes: "1.20.2",
+			expectedError: true,
+		},
+		{
+			name: "empty key with Equal operator",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:    true,
+				Whitelist: []corev1.Toleration{{Operator: corev1.TolerationOpEqual}},
 			},
 			expectedError: true,
 		},
 		{
-			name: "invalid oidc config",
-			features: kubeoneapi.Features{
-				OpenIDConnect: &kubeoneapi.OpenIDConnect{
-					Enable: true,
-					Config: kubeoneapi.OpenIDConnectConfig{},
+			name: "unsupported operator",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:    true,
+				Whitelist: []corev1.Toleration{{Key: "dedicated", Operator: "In"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported effect",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:    true,
+				Whitelist: []corev1.Toleration{{Key: "dedicated", Effect: "NoEvict"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "tolerationSeconds without NoExecute effect",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable: true,
+				DefaultTolerations: []corev1.Toleration{
+					{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule, TolerationSeconds: pointer.Int64(300)},
 				},
 			},
-			versions: kubeoneapi.VersionConfig{
-				Kubernetes: "1.20.2",
+			expectedError: true,
+		},
+		{
+			name: "empty namespace name",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:     true,
+				Namespaces: []kubeoneapi.NamespaceTolerations{{Whitelist: []corev1.Toleration{gpuToleration}}},
 			},
 			expectedError: true,
 		},
 		{
-			name: "invalid podNodeSelector config",
-			features: kubeoneapi.Features{
-				PodNodeSelector: &kubeoneapi.PodNodeSelector{
-					Enable: true,
-					Config: kubeoneapi.PodNodeSelectorConfig{},
+			name: "invalid namespace name",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable:     true,
+				Namespaces: []kubeoneapi.NamespaceTolerations{{Name: "Machine_Learning"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicated namespace",
+			podTolerationRestriction: kubeoneapi.PodTolerationRestriction{
+				Enable: true,
+				Namespaces: []kubeoneapi.NamespaceTolerations{
+					{Name: "ml"},
+					{Name: "ml"},
 				},
 			},
-			versions: kubeoneapi.VersionConfig{
-				Kubernetes: "1.20.2",
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidatePodTolerationRestriction(tc.podTolerationRestriction, field.NewPath("podTolerationRestriction"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
+			}
+		})
+	}
+}
+
+func TestValidateBindAddresses(t *testing.T) {
+	tests := []struct {
+		name          string
+		bindAddresses kubeoneapi.BindAddresses
+		expectedError bool
+	}{
+		{
+			name: "all addresses set",
+			bindAddresses: kubeoneapi.BindAddresses{
+				APIServer:         "10.0.0.5",
+				Etcd:              "10.0.0.5",
+				ControllerManager: "10.0.0.5",
+				Scheduler:         "0.0.0.0",
 			},
+			expectedError: false,
+		},
+		{
+			name:          "no addresses set",
+			bindAddresses: kubeoneapi.BindAddresses{},
+			expectedError: false,
+		},
+		{
+			name:          "invalid address",
+			bindAddresses: kubeoneapi.BindAddresses{ControllerManager: "eth0"},
+			expectedError: true,
+		},
+		{
+			name:          "apiServer not on the advertised address",
+			bindAddresses: kubeoneapi.BindAddresses{APIServer: "192.168.1.5"},
+			expectedError: true,
+		},
+		{
+			name:          "etcd on all interfaces",
+			bindAddresses: kubeoneapi.BindAddresses{Etcd: "0.0.0.0"},
 			expectedError: true,
 		},
 	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bindAddresses := tc.bindAddresses
+			host := kubeoneapi.HostConfig{
+				PublicAddress:  "203.0.113.5",
+				PrivateAddress: "10.0.0.5",
+				BindAddresses:  &bindAddresses,
+			}
+			errs := ValidateBindAddresses(host, field.NewPath("bindAddresses"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
+			}
+		})
+	}
+}
 
+func TestValidateCgroupsConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		cgroupsConfig kubeoneapi.CgroupsConfig
+		expectedError bool
+	}{
+		{
+			name:          "cgroup v2 with kernel command line configuration",
+			cgroupsConfig: kubeoneapi.CgroupsConfig{Version: "v2", ConfigureKernelCommandLine: true},
+			expectedError: false,
+		},
+		{
+			name:          "cgroup v1",
+			cgroupsConfig: kubeoneapi.CgroupsConfig{Version: "v1"},
+			expectedError: false,
+		},
+		{
+			name:          "missing version",
+			cgroupsConfig: kubeoneapi.CgroupsConfig{},
+			expectedError: true,
+		},
+		{
+			name:          "unsupported version",
+			cgroupsConfig: kubeoneapi.CgroupsConfig{Version: "2"},
+			expectedError: true,
+		},
+		{
+			name:          "kernel command line configuration with cgroup v1",
+			cgroupsConfig: kubeoneapi.CgroupsConfig{Version: "v1", ConfigureKernelCommandLine: true},
+			expectedError: true,
+		},
+	}
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateFeatures(tc.features, tc.versions, nil)
+			errs := ValidateCgroupsConfig(tc.cgroupsConfig, field.NewPath("cgroups"))
 			if (len(errs) == 0) == tc.expectedError {
-				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
 			}
 		})
 	}
 }
 
-func TestValidatePodNodeSelectorConfig(t *testing.T) {
+func TestValidatePreloadImages(t *testing.T) {
 	tests := []struct {
-		name                  string
-		podNodeSelectorConfig kubeoneapi.PodNodeSelectorConfig
-		expectedError         bool
+		name          string
+		images        []string
+		expectedError bool
 	}{
 		{
-			name: "valid podNodeSelector config",
-			podNodeSelectorConfig: kubeoneapi.PodNodeSelectorConfig{
-				ConfigFilePath: "./podnodeselector.yaml",
-			},
+			name:          "valid images",
+			images:        []string{"nginx", "docker.io/library/python:3.10", "quay.io/org/image@sha256:" + strings.Repeat("a", 64)},
 			expectedError: false,
 		},
 		{
-			name:                  "invalid podNodeSelector config",
-			podNodeSelectorConfig: kubeoneapi.PodNodeSelectorConfig{},
-			expectedError:         true,
+			name:          "no images",
+			images:        nil,
+			expectedError: false,
+		},
+		{
+			name:          "empty image",
+			images:        []string{""},
+			expectedError: true,
+		},
+		{
+			name:          "invalid image",
+			images:        []string{"Docker.io/Nginx:latest"},
+			expectedError: true,
+		},
+		{
+			name:          "duplicate image",
+			images:        []string{"nginx:1.23", "nginx:1.23"},
+			expectedError: true,
 		},
 	}
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidatePodNodeSelectorConfig(tc.podNodeSelectorConfig, nil)
+			errs := ValidatePreloadImages(tc.images, field.NewPath("preloadImages"))
 			if (len(errs) == 0) == tc.expectedError {
-				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
 			}
 		})
 	}
@@ -1526,6 +3762,40 @@ func TestValidateStaticAuditLogConfig(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "valid policy preset",
+			staticAuditLogConfig: kubeoneapi.StaticAuditLogConfig{
+				PolicyPreset: "compliance",
+				LogPath:      "/var/log/kubernetes",
+				LogMaxAge:    10,
+				LogMaxBackup: 10,
+				LogMaxSize:   100,
+			},
+			expectedError: false,
+		},
+		{
+			name: "unknown policy preset",
+			staticAuditLogConfig: kubeoneapi.StaticAuditLogConfig{
+				PolicyPreset: "verbose",
+				LogPath:      "/var/log/kubernetes",
+				LogMaxAge:    10,
+				LogMaxBackup: 10,
+				LogMaxSize:   100,
+			},
+			expectedError: true,
+		},
+		{
+			name: "both policy file path and policy preset",
+			staticAuditLogConfig: kubeoneapi.StaticAuditLogConfig{
+				PolicyFilePath: "/etc/kubernetes/policy.yaml",
+				PolicyPreset:   "minimal",
+				LogPath:        "/var/log/kubernetes",
+				LogMaxAge:      10,
+				LogMaxBackup:   10,
+				LogMaxSize:     100,
+			},
+			expectedError: true,
+		},
 		{
 			name: "log file path missing",
 			staticAuditLogConfig: kubeoneapi.StaticAuditLogConfig{
@@ -1656,6 +3926,107 @@ func TestValidateAddons(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "node-problem-detector addon with valid custom monitor config",
+			addons: &kubeoneapi.Addons{
+				Enable: true,
+				Addons: []kubeoneapi.Addon{
+					{
+						Name: resources.AddonNodeProblemDetector,
+						Params: map[string]string{
+							resources.NodeProblemDetectorCustomMonitorConfigParam: `{"plugin": "kmsg", "rules": []}`,
+						},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "node-problem-detector addon with invalid custom monitor config",
+			addons: &kubeoneapi.Addons{
+				Enable: true,
+				Addons: []kubeoneapi.Addon{
+					{
+						Name: resources.AddonNodeProblemDetector,
+						Params: map[string]string{
+							resources.NodeProblemDetectorCustomMonitorConfigParam: `{"plugin": "kmsg",`,
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "addon with valid enabledWhen condition",
+			addons: &kubeoneapi.Addons{
+				Enable: true,
+				Addons: []kubeoneapi.Addon{
+					{
+						Name:        resources.AddonNodeProblemDetector,
+						EnabledWhen: "provider == aws && k8s >= 1.23",
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "addon with invalid enabledWhen condition",
+			addons: &kubeoneapi.Addons{
+				Enable: true,
+				Addons: []kubeoneapi.Addon{
+					{
+						Name:        resources.AddonNodeProblemDetector,
+						EnabledWhen: "provider = aws",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "addon with forced conflicts using field manager",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				FieldManager: "kubeone.io/addons",
+				Addons: []kubeoneapi.Addon{
+					{
+						Name:           resources.AddonNodeProblemDetector,
+						ForceConflicts: true,
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "addon with forced conflicts without field manager",
+			addons: &kubeoneapi.Addons{
+				Enable: true,
+				Addons: []kubeoneapi.Addon{
+					{
+						Name:           resources.AddonNodeProblemDetector,
+						ForceConflicts: true,
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid field manager name",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				Path:         "./addons",
+				FieldManager: "kubeone $(id)",
+			},
+			expectedError: true,
+		},
+		{
+			name: "too long field manager name",
+			addons: &kubeoneapi.Addons{
+				Enable:       true,
+				Path:         "./addons",
+				FieldManager: strings.Repeat("k", 129),
+			},
+			expectedError: true,
+		},
 		{
 			name: "valid addons config (disabled)",
 			addons: &kubeoneapi.Addons{
@@ -1826,6 +4197,77 @@ func TestValidateHostConfig(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "valid OS override to rocky",
+			hostConfig: []kubeoneapi.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					OperatingSystem:   kubeoneapi.OperatingSystemNameRocky,
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid labels and annotations",
+			hostConfig: []kubeoneapi.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					Labels:            map[string]string{"example.com/zone": "a"},
+					Annotations:       map[string]string{"example.com/owner": "team a"},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid label value",
+			hostConfig: []kubeoneapi.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					Labels:            map[string]string{"zone": "a b"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "reserved label prefix",
+			hostConfig: []kubeoneapi.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					Labels:            map[string]string{"v1.kubeone.io/operating-system": "ubuntu"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "reserved annotation prefix",
+			hostConfig: []kubeoneapi.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					Annotations:       map[string]string{"v1.kubeone.io/managed-labels": ""},
+				},
+			},
+			expectedError: true,
+		},
 		{
 			name: "invalid OS",
 			hostConfig: []kubeoneapi.HostConfig{
@@ -1893,7 +4335,146 @@ func TestValidateHostConfig(t *testing.T) {
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateHostConfig(tc.hostConfig, nil)
+			errs := ValidateHostConfig(tc.hostConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateKubeletConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		kubelet       kubeoneapi.KubeletConfig
+		expectedError bool
+	}{
+		{
+			name:          "empty kubelet config",
+			kubelet:       kubeoneapi.KubeletConfig{},
+			expectedError: false,
+		},
+		{
+			name: "valid reserved resources and eviction thresholds",
+			kubelet: kubeoneapi.KubeletConfig{
+				SystemReserved: map[string]string{"cpu": "200m", "memory": "200Mi", "ephemeral-storage": "1Gi"},
+				KubeReserved:   map[string]string{"cpu": "100m", "pid": "1000"},
+				EvictionHard:   map[string]string{"memory.available": "100Mi", "nodefs.available": "10%"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "allocatable memory eviction threshold",
+			kubelet: kubeoneapi.KubeletConfig{
+				EvictionHard: map[string]string{"allocatableMemory.available": "200Mi", "pid.available": "5%"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "enforce system-reserved and kube-reserved",
+			kubelet: kubeoneapi.KubeletConfig{
+				SystemReserved:         map[string]string{"cpu": "200m"},
+				KubeReserved:           map[string]string{"cpu": "100m"},
+				EnforceNodeAllocatable: []string{"pods", "system-reserved", "kube-reserved"},
+				SystemReservedCgroup:   "/system.slice",
+				KubeReservedCgroup:     "/kube.slice",
+			},
+			expectedError: false,
+		},
+		{
+			name: "enforce none",
+			kubelet: kubeoneapi.KubeletConfig{
+				EnforceNodeAllocatable: []string{"none"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "unsupported reserved resource",
+			kubelet: kubeoneapi.KubeletConfig{
+				SystemReserved: map[string]string{"gpu": "1"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid reserved quantity",
+			kubelet: kubeoneapi.KubeletConfig{
+				KubeReserved: map[string]string{"memory": "lots"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative reserved quantity",
+			kubelet: kubeoneapi.KubeletConfig{
+				KubeReserved: map[string]string{"memory": "-100Mi"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported eviction signal",
+			kubelet: kubeoneapi.KubeletConfig{
+				EvictionHard: map[string]string{"memory.free": "100Mi"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid eviction percentage",
+			kubelet: kubeoneapi.KubeletConfig{
+				EvictionHard: map[string]string{"nodefs.available": "110%"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported enforce node allocatable value",
+			kubelet: kubeoneapi.KubeletConfig{
+				EnforceNodeAllocatable: []string{"pods", "everything"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate enforce node allocatable value",
+			kubelet: kubeoneapi.KubeletConfig{
+				EnforceNodeAllocatable: []string{"pods", "pods"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "none combined with other values",
+			kubelet: kubeoneapi.KubeletConfig{
+				EnforceNodeAllocatable: []string{"none", "pods"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "enforce system-reserved without cgroup",
+			kubelet: kubeoneapi.KubeletConfig{
+				SystemReserved:         map[string]string{"cpu": "200m"},
+				EnforceNodeAllocatable: []string{"pods", "system-reserved"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "enforce kube-reserved without reserved resources",
+			kubelet: kubeoneapi.KubeletConfig{
+				EnforceNodeAllocatable: []string{"pods", "kube-reserved"},
+				KubeReservedCgroup:     "/kube.slice",
+			},
+			expectedError: true,
+		},
+		{
+			name: "relative cgroup path",
+			kubelet: kubeoneapi.KubeletConfig{
+				SystemReserved:         map[string]string{"cpu": "200m"},
+				EnforceNodeAllocatable: []string{"pods", "system-reserved"},
+				SystemReservedCgroup:   "system.slice",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateKubeletConfig(tc.kubelet, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -2146,3 +4727,417 @@ func TestValidateAssetConfiguration(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestValidatePostApplyValidation(t *testing.T) {
+	tests := []struct {
+		name                string
+		postApplyValidation *kubeoneapi.PostApplyValidation
+		expectedError       bool
+	}{
+		{
+			name:                "not configured",
+			postApplyValidation: nil,
+			expectedError:       false,
+		},
+		{
+			name:                "webhook not configured",
+			postApplyValidation: &kubeoneapi.PostApplyValidation{},
+			expectedError:       false,
+		},
+		{
+			name: "valid webhook",
+			postApplyValidation: &kubeoneapi.PostApplyValidation{
+				Webhook: &kubeoneapi.PostApplyValidationWebhook{
+					URL:     "https://compliance.example.com/validate",
+					Timeout: metav1.Duration{Duration: time.Minute},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "missing webhook url",
+			postApplyValidation: &kubeoneapi.PostApplyValidation{
+				Webhook: &kubeoneapi.PostApplyValidationWebhook{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "webhook url without http(s) scheme",
+			postApplyValidation: &kubeoneapi.PostApplyValidation{
+				Webhook: &kubeoneapi.PostApplyValidationWebhook{
+					URL: "compliance.example.com/validate",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative webhook timeout",
+			postApplyValidation: &kubeoneapi.PostApplyValidation{
+				Webhook: &kubeoneapi.PostApplyValidationWebhook{
+					URL:     "https://compliance.example.com/validate",
+					Timeout: metav1.Duration{Duration: -time.Second},
+				},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidatePostApplyValidation(tc.postApplyValidation, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateNamespaces(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespaces    []kubeoneapi.NamespaceConfig
+		expectedError bool
+	}{
+		{
+			name: "valid namespaces",
+			namespaces: []kubeoneapi.NamespaceConfig{
+				{
+					Name:        "team-a",
+					Labels:      map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+					Annotations: map[string]string{"example.com/Owner": "Team A"},
+				},
+				{
+					Name: "team-b",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name:          "no namespaces",
+			namespaces:    nil,
+			expectedError: false,
+		},
+		{
+			name:          "missing name",
+			namespaces:    []kubeoneapi.NamespaceConfig{{Labels: map[string]string{"team": "a"}}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid name",
+			namespaces:    []kubeoneapi.NamespaceConfig{{Name: "Team_A"}},
+			expectedError: true,
+		},
+		{
+			name:          "duplicate name",
+			namespaces:    []kubeoneapi.NamespaceConfig{{Name: "team-a"}, {Name: "team-a"}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid label key",
+			namespaces:    []kubeoneapi.NamespaceConfig{{Name: "team-a", Labels: map[string]string{"-team": "a"}}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid label value",
+			namespaces:    []kubeoneapi.NamespaceConfig{{Name: "team-a", Labels: map[string]string{"team": "team a"}}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid annotation key",
+			namespaces:    []kubeoneapi.NamespaceConfig{{Name: "team-a", Annotations: map[string]string{"example.com/owner/team": "a"}}},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateNamespaces(tc.namespaces, field.NewPath("namespaces"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
+			}
+		})
+	}
+}
+
+func TestValidateBootstrapTokenConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		bootstrapToken *kubeoneapi.BootstrapTokenConfig
+		expectedError  bool
+	}{
+		{
+			name:           "bootstrap token not configured",
+			bootstrapToken: nil,
+			expectedError:  false,
+		},
+		{
+			name:           "default ttl",
+			bootstrapToken: &kubeoneapi.BootstrapTokenConfig{},
+			expectedError:  false,
+		},
+		{
+			name:           "short ttl",
+			bootstrapToken: &kubeoneapi.BootstrapTokenConfig{TTL: metav1.Duration{Duration: 15 * time.Minute}},
+			expectedError:  false,
+		},
+		{
+			name:           "maximum ttl",
+			bootstrapToken: &kubeoneapi.BootstrapTokenConfig{TTL: metav1.Duration{Duration: 24 * time.Hour}},
+			expectedError:  false,
+		},
+		{
+			name:           "negative ttl",
+			bootstrapToken: &kubeoneapi.BootstrapTokenConfig{TTL: metav1.Duration{Duration: -time.Minute}},
+			expectedError:  true,
+		},
+		{
+			name:           "ttl too long",
+			bootstrapToken: &kubeoneapi.BootstrapTokenConfig{TTL: metav1.Duration{Duration: 48 * time.Hour}},
+			expectedError:  true,
+		},
+		{
+			name:           "ttl with sub-second precision",
+			bootstrapToken: &kubeoneapi.BootstrapTokenConfig{TTL: metav1.Duration{Duration: 90*time.Second + 500*time.Millisecond}},
+			expectedError:  true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateBootstrapTokenConfig(tc.bootstrapToken, field.NewPath("bootstrapToken"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookCABundleInjection(t *testing.T) {
+	tests := []struct {
+		name          string
+		injection     kubeoneapi.WebhookCABundleInjection
+		expectedError bool
+	}{
+		{
+			name: "validating and mutating webhook configurations",
+			injection: kubeoneapi.WebhookCABundleInjection{
+				Enable:                          true,
+				ValidatingWebhookConfigurations: []string{"policy.example.com"},
+				MutatingWebhookConfigurations:   []string{"injector", "defaults.example.com"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "only mutating webhook configurations",
+			injection: kubeoneapi.WebhookCABundleInjection{
+				Enable:                        true,
+				MutatingWebhookConfigurations: []string{"injector"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "no webhook configurations",
+			injection: kubeoneapi.WebhookCABundleInjection{
+				Enable: true,
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid name",
+			injection: kubeoneapi.WebhookCABundleInjection{
+				Enable:                          true,
+				ValidatingWebhookConfigurations: []string{"Policy_Webhook"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate name",
+			injection: kubeoneapi.WebhookCABundleInjection{
+				Enable:                        true,
+				MutatingWebhookConfigurations: []string{"injector", "injector"},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateWebhookCABundleInjection(tc.injection, field.NewPath("webhookCABundleInjection"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
+			}
+		})
+	}
+}
+
+func TestValidateFirewallConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		firewall      kubeoneapi.FirewallConfig
+		expectedError bool
+	}{
+		{
+			name: "valid rules",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules: []kubeoneapi.FirewallRule{
+					{Ports: "9100", Protocol: "tcp", Sources: []string{"10.0.0.0/8", "fd00::/64"}},
+					{Ports: "8000-8080", Protocol: "udp", Roles: []string{"controlPlane", "staticWorker"}},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name:          "firewalld backend without rules",
+			firewall:      kubeoneapi.FirewallConfig{Backend: "firewalld"},
+			expectedError: false,
+		},
+		{
+			name:          "unsupported backend",
+			firewall:      kubeoneapi.FirewallConfig{Backend: "iptables"},
+			expectedError: true,
+		},
+		{
+			name: "invalid port",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules:   []kubeoneapi.FirewallRule{{Ports: "http", Protocol: "tcp"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "port out of range",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules:   []kubeoneapi.FirewallRule{{Ports: "70000", Protocol: "tcp"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "inverted port range",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules:   []kubeoneapi.FirewallRule{{Ports: "8080-8000", Protocol: "tcp"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported protocol",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules:   []kubeoneapi.FirewallRule{{Ports: "8080", Protocol: "sctp"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid source",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules:   []kubeoneapi.FirewallRule{{Ports: "8080", Protocol: "tcp", Sources: []string{"10.0.0.1"}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid kubelet sources",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend:        "nftables",
+				KubeletSources: []string{"192.168.0.0/16", "fd00::/64"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid kubelet source",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend:        "nftables",
+				KubeletSources: []string{"192.168.0.1"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported role",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules:   []kubeoneapi.FirewallRule{{Ports: "8080", Protocol: "tcp", Roles: []string{"worker"}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate role",
+			firewall: kubeoneapi.FirewallConfig{
+				Backend: "nftables",
+				Rules:   []kubeoneapi.FirewallRule{{Ports: "8080", Protocol: "tcp", Roles: []string{"controlPlane", "controlPlane"}}},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateFirewallConfig(tc.firewall, field.NewPath("firewall"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, errs)
+			}
+		})
+	}
+}
+
+func TestValidateAnonymousAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		anonymousAuth *bool
+		healthCheck   *kubeoneapi.APIEndpointHealthCheck
+		expectedError bool
+	}{
+		{
+			name:          "anonymous auth not configured",
+			healthCheck:   &kubeoneapi.APIEndpointHealthCheck{Path: "/status"},
+			expectedError: false,
+		},
+		{
+			name:          "anonymous auth enabled with custom health check path",
+			anonymousAuth: boolPtr(true),
+			healthCheck:   &kubeoneapi.APIEndpointHealthCheck{Path: "/status"},
+			expectedError: false,
+		},
+		{
+			name:          "anonymous auth disabled with default health check",
+			anonymousAuth: boolPtr(false),
+			expectedError: false,
+		},
+		{
+			name:          "anonymous auth disabled with readyz health check",
+			anonymousAuth: boolPtr(false),
+			healthCheck:   &kubeoneapi.APIEndpointHealthCheck{Path: "/readyz/etcd"},
+			expectedError: false,
+		},
+		{
+			name:          "anonymous auth disabled with custom health check path",
+			anonymousAuth: boolPtr(false),
+			healthCheck:   &kubeoneapi.APIEndpointHealthCheck{Path: "/status"},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := kubeoneapi.KubeOneCluster{
+				APIEndpoint: kubeoneapi.APIEndpoint{HealthCheck: tc.healthCheck},
+				Features: kubeoneapi.Features{
+					StaticAuth: &kubeoneapi.StaticAuth{AnonymousAuth: tc.anonymousAuth},
+				},
+			}
+			errs := ValidateAnonymousAuth(c, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
@@ -36,6 +36,16 @@ func (in *APIEndpoint) DeepCopyInto(out *APIEndpoint) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StandbyEndpoints != nil {
+		in, out := &in.StandbyEndpoints, &out.StandbyEndpoints
+		*out = make([]APIEndpointStandby, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(APIEndpointHealthCheck)
+		**out = **in
+	}
 	return
 }
 
@@ -49,6 +59,66 @@ func (in *APIEndpoint) DeepCopy() *APIEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIEndpointHealthCheck) DeepCopyInto(out *APIEndpointHealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIEndpointHealthCheck.
+func (in *APIEndpointHealthCheck) DeepCopy() *APIEndpointHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(APIEndpointHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIEndpointStandby) DeepCopyInto(out *APIEndpointStandby) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIEndpointStandby.
+func (in *APIEndpointStandby) DeepCopy() *APIEndpointStandby {
+	if in == nil {
+		return nil
+	}
+	out := new(APIEndpointStandby)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerConfig) DeepCopyInto(out *APIServerConfig) {
+	*out = *in
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAccountExtendTokenExpiration != nil {
+		in, out := &in.ServiceAccountExtendTokenExpiration, &out.ServiceAccountExtendTokenExpiration
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerConfig.
+func (in *APIServerConfig) DeepCopy() *APIServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSSpec) DeepCopyInto(out *AWSSpec) {
 	*out = *in
@@ -174,6 +244,87 @@ func (in *BinaryAsset) DeepCopy() *BinaryAsset {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindAddresses) DeepCopyInto(out *BindAddresses) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindAddresses.
+func (in *BindAddresses) DeepCopy() *BindAddresses {
+	if in == nil {
+		return nil
+	}
+	out := new(BindAddresses)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapTokenConfig) DeepCopyInto(out *BootstrapTokenConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapTokenConfig.
+func (in *BootstrapTokenConfig) DeepCopy() *BootstrapTokenConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapTokenConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAKeyPair) DeepCopyInto(out *CAKeyPair) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAKeyPair.
+func (in *CAKeyPair) DeepCopy() *CAKeyPair {
+	if in == nil {
+		return nil
+	}
+	out := new(CAKeyPair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CCMConfig) DeepCopyInto(out *CCMConfig) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CCMConfig.
+func (in *CCMConfig) DeepCopy() *CCMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CCMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CNI) DeepCopyInto(out *CNI) {
 	*out = *in
@@ -210,6 +361,22 @@ func (in *CNI) DeepCopy() *CNI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSISnapshotControllerConfig) DeepCopyInto(out *CSISnapshotControllerConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSISnapshotControllerConfig.
+func (in *CSISnapshotControllerConfig) DeepCopy() *CSISnapshotControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CSISnapshotControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CanalSpec) DeepCopyInto(out *CanalSpec) {
 	*out = *in
@@ -226,6 +393,53 @@ func (in *CanalSpec) DeepCopy() *CanalSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthority) DeepCopyInto(out *CertificateAuthority) {
+	*out = *in
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(CAKeyPair)
+		**out = **in
+	}
+	if in.FrontProxy != nil {
+		in, out := &in.FrontProxy, &out.FrontProxy
+		*out = new(CAKeyPair)
+		**out = **in
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(CAKeyPair)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthority.
+func (in *CertificateAuthority) DeepCopy() *CertificateAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CgroupsConfig) DeepCopyInto(out *CgroupsConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CgroupsConfig.
+func (in *CgroupsConfig) DeepCopy() *CgroupsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CgroupsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumSpec) DeepCopyInto(out *CiliumSpec) {
 	*out = *in
@@ -242,9 +456,45 @@ func (in *CiliumSpec) DeepCopy() *CiliumSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudNetworkConfig) DeepCopyInto(out *CloudNetworkConfig) {
+	*out = *in
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AssignPublicIP != nil {
+		in, out := &in.AssignPublicIP, &out.AssignPublicIP
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNetworkConfig.
+func (in *CloudNetworkConfig) DeepCopy() *CloudNetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudNetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudProviderSpec) DeepCopyInto(out *CloudProviderSpec) {
 	*out = *in
+	if in.CCM != nil {
+		in, out := &in.CCM, &out.CCM
+		*out = new(CCMConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CSISnapshotController != nil {
+		in, out := &in.CSISnapshotController, &out.CSISnapshotController
+		*out = new(CSISnapshotControllerConfig)
+		**out = **in
+	}
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
 		*out = new(AWSSpec)
@@ -316,6 +566,11 @@ func (in *CloudProviderSpec) DeepCopy() *CloudProviderSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetworkConfig) DeepCopyInto(out *ClusterNetworkConfig) {
 	*out = *in
+	if in.NodeCIDRMaskSize != nil {
+		in, out := &in.NodeCIDRMaskSize, &out.NodeCIDRMaskSize
+		*out = new(NodeCIDRMaskSize)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CNI != nil {
 		in, out := &in.CNI, &out.CNI
 		*out = new(CNI)
@@ -326,6 +581,11 @@ func (in *ClusterNetworkConfig) DeepCopyInto(out *ClusterNetworkConfig) {
 		*out = new(KubeProxyConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeConnectivityCheck != nil {
+		in, out := &in.NodeConnectivityCheck, &out.NodeConnectivityCheck
+		*out = new(NodeConnectivityCheck)
+		**out = **in
+	}
 	return
 }
 
@@ -352,6 +612,16 @@ func (in *ContainerRuntimeConfig) DeepCopyInto(out *ContainerRuntimeConfig) {
 		*out = new(ContainerRuntimeContainerd)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cgroups != nil {
+		in, out := &in.Cgroups, &out.Cgroups
+		*out = new(CgroupsConfig)
+		**out = **in
+	}
+	if in.PreloadImages != nil {
+		in, out := &in.PreloadImages, &out.PreloadImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -375,6 +645,13 @@ func (in *ContainerRuntimeContainerd) DeepCopyInto(out *ContainerRuntimeContaine
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]ContainerdRuntime, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -396,6 +673,13 @@ func (in *ContainerRuntimeDocker) DeepCopyInto(out *ContainerRuntimeDocker) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LogOpts != nil {
+		in, out := &in.LogOpts, &out.LogOpts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -456,6 +740,50 @@ func (in *ContainerdRegistryAuthConfig) DeepCopy() *ContainerdRegistryAuthConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdRuntime) DeepCopyInto(out *ContainerdRuntime) {
+	*out = *in
+	if in.RuntimeClass != nil {
+		in, out := &in.RuntimeClass, &out.RuntimeClass
+		*out = new(ContainerdRuntimeClass)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdRuntime.
+func (in *ContainerdRuntime) DeepCopy() *ContainerdRuntime {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdRuntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdRuntimeClass) DeepCopyInto(out *ContainerdRuntimeClass) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdRuntimeClass.
+func (in *ContainerdRuntimeClass) DeepCopy() *ContainerdRuntimeClass {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdRuntimeClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerdTLSConfig) DeepCopyInto(out *ContainerdTLSConfig) {
 	*out = *in
@@ -472,6 +800,37 @@ func (in *ContainerdTLSConfig) DeepCopy() *ContainerdTLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponents) DeepCopyInto(out *ControlPlaneComponents) {
+	*out = *in
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(SchedulerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(APIServerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ControllerManagerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponents.
+func (in *ControlPlaneComponents) DeepCopy() *ControlPlaneComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 	*out = *in
@@ -495,6 +854,22 @@ func (in *ControlPlaneConfig) DeepCopy() *ControlPlaneConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerConfig) DeepCopyInto(out *ControllerManagerConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerConfig.
+func (in *ControllerManagerConfig) DeepCopy() *ControllerManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
 	*out = *in
@@ -516,6 +891,22 @@ func (in *DNSConfig) DeepCopy() *DNSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeConfig) DeepCopyInto(out *DataVolumeConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeConfig.
+func (in *DataVolumeConfig) DeepCopy() *DataVolumeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DigitalOceanSpec) DeepCopyInto(out *DigitalOceanSpec) {
 	*out = *in
@@ -532,6 +923,27 @@ func (in *DigitalOceanSpec) DeepCopy() *DigitalOceanSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskConfig) DeepCopyInto(out *DiskConfig) {
+	*out = *in
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolumeConfig, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskConfig.
+func (in *DiskConfig) DeepCopy() *DiskConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicAuditLog) DeepCopyInto(out *DynamicAuditLog) {
 	*out = *in
@@ -548,6 +960,22 @@ func (in *DynamicAuditLog) DeepCopy() *DynamicAuditLog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicWorkerAutoscaler) DeepCopyInto(out *DynamicWorkerAutoscaler) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicWorkerAutoscaler.
+func (in *DynamicWorkerAutoscaler) DeepCopy() *DynamicWorkerAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicWorkerAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicWorkerConfig) DeepCopyInto(out *DynamicWorkerConfig) {
 	*out = *in
@@ -557,6 +985,11 @@ func (in *DynamicWorkerConfig) DeepCopyInto(out *DynamicWorkerConfig) {
 		**out = **in
 	}
 	in.Config.DeepCopyInto(&out.Config)
+	if in.Autoscaler != nil {
+		in, out := &in.Autoscaler, &out.Autoscaler
+		*out = new(DynamicWorkerAutoscaler)
+		**out = **in
+	}
 	return
 }
 
@@ -602,6 +1035,38 @@ func (in *EquinixMetalSpec) DeepCopy() *EquinixMetalSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackup) DeepCopyInto(out *EtcdBackup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackup.
+func (in *EtcdBackup) DeepCopy() *EtcdBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdMetrics) DeepCopyInto(out *EtcdMetrics) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdMetrics.
+func (in *EtcdMetrics) DeepCopy() *EtcdMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalCNISpec) DeepCopyInto(out *ExternalCNISpec) {
 	*out = *in
@@ -656,15 +1121,109 @@ func (in *Features) DeepCopyInto(out *Features) {
 		*out = new(EncryptionProviders)
 		**out = **in
 	}
+	if in.StaticAuth != nil {
+		in, out := &in.StaticAuth, &out.StaticAuth
+		*out = new(StaticAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletServingCertRotation != nil {
+		in, out := &in.KubeletServingCertRotation, &out.KubeletServingCertRotation
+		*out = new(KubeletServingCertRotation)
+		**out = **in
+	}
+	if in.EtcdMetrics != nil {
+		in, out := &in.EtcdMetrics, &out.EtcdMetrics
+		*out = new(EtcdMetrics)
+		**out = **in
+	}
+	if in.PodDisruptionBudgets != nil {
+		in, out := &in.PodDisruptionBudgets, &out.PodDisruptionBudgets
+		*out = new(PodDisruptionBudgets)
+		**out = **in
+	}
+	if in.PrometheusAdapter != nil {
+		in, out := &in.PrometheusAdapter, &out.PrometheusAdapter
+		*out = new(PrometheusAdapter)
+		**out = **in
+	}
+	if in.EtcdBackup != nil {
+		in, out := &in.EtcdBackup, &out.EtcdBackup
+		*out = new(EtcdBackup)
+		**out = **in
+	}
+	if in.PodTolerationRestriction != nil {
+		in, out := &in.PodTolerationRestriction, &out.PodTolerationRestriction
+		*out = new(PodTolerationRestriction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebhookCABundleInjection != nil {
+		in, out := &in.WebhookCABundleInjection, &out.WebhookCABundleInjection
+		*out = new(WebhookCABundleInjection)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Features.
+func (in *Features) DeepCopy() *Features {
+	if in == nil {
+		return nil
+	}
+	out := new(Features)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallConfig) DeepCopyInto(out *FirewallConfig) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubeletSources != nil {
+		in, out := &in.KubeletSources, &out.KubeletSources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallConfig.
+func (in *FirewallConfig) DeepCopy() *FirewallConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Features.
-func (in *Features) DeepCopy() *Features {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
 	if in == nil {
 		return nil
 	}
-	out := new(Features)
+	out := new(FirewallRule)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -712,6 +1271,25 @@ func (in *HostConfig) DeepCopyInto(out *HostConfig) {
 		}
 	}
 	in.Kubelet.DeepCopyInto(&out.Kubelet)
+	if in.BindAddresses != nil {
+		in, out := &in.BindAddresses, &out.BindAddresses
+		*out = new(BindAddresses)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -805,6 +1383,26 @@ func (in *KubeOneCluster) DeepCopyInto(out *KubeOneCluster) {
 		*out = new(MachineControllerConfig)
 		**out = **in
 	}
+	if in.CertificateAuthority != nil {
+		in, out := &in.CertificateAuthority, &out.CertificateAuthority
+		*out = new(CertificateAuthority)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneComponents != nil {
+		in, out := &in.ControlPlaneComponents, &out.ControlPlaneComponents
+		*out = new(ControlPlaneComponents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostApplyValidation != nil {
+		in, out := &in.PostApplyValidation, &out.PostApplyValidation
+		*out = new(PostApplyValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootstrapToken != nil {
+		in, out := &in.BootstrapToken, &out.BootstrapToken
+		*out = new(BootstrapTokenConfig)
+		**out = **in
+	}
 	in.Features.DeepCopyInto(&out.Features)
 	if in.Addons != nil {
 		in, out := &in.Addons, &out.Addons
@@ -823,6 +1421,18 @@ func (in *KubeOneCluster) DeepCopyInto(out *KubeOneCluster) {
 		**out = **in
 	}
 	out.LoggingConfig = in.LoggingConfig
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(FirewallConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -894,6 +1504,11 @@ func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.EnforceNodeAllocatable != nil {
+		in, out := &in.EnforceNodeAllocatable, &out.EnforceNodeAllocatable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.MaxPods != nil {
 		in, out := &in.MaxPods, &out.MaxPods
 		*out = new(int32)
@@ -912,6 +1527,22 @@ func (in *KubeletConfig) DeepCopy() *KubeletConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletServingCertRotation) DeepCopyInto(out *KubeletServingCertRotation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletServingCertRotation.
+func (in *KubeletServingCertRotation) DeepCopy() *KubeletServingCertRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletServingCertRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggingConfig) DeepCopyInto(out *LoggingConfig) {
 	*out = *in
@@ -960,6 +1591,108 @@ func (in *MetricsServer) DeepCopy() *MetricsServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceConfig) DeepCopyInto(out *NamespaceConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceConfig.
+func (in *NamespaceConfig) DeepCopy() *NamespaceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceTolerations) DeepCopyInto(out *NamespaceTolerations) {
+	*out = *in
+	if in.DefaultTolerations != nil {
+		in, out := &in.DefaultTolerations, &out.DefaultTolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Whitelist != nil {
+		in, out := &in.Whitelist, &out.Whitelist
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTolerations.
+func (in *NamespaceTolerations) DeepCopy() *NamespaceTolerations {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceTolerations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCIDRMaskSize) DeepCopyInto(out *NodeCIDRMaskSize) {
+	*out = *in
+	if in.IPv4 != nil {
+		in, out := &in.IPv4, &out.IPv4
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCIDRMaskSize.
+func (in *NodeCIDRMaskSize) DeepCopy() *NodeCIDRMaskSize {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCIDRMaskSize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConnectivityCheck) DeepCopyInto(out *NodeConnectivityCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConnectivityCheck.
+func (in *NodeConnectivityCheck) DeepCopy() *NodeConnectivityCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConnectivityCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NoneSpec) DeepCopyInto(out *NoneSpec) {
 	*out = *in
@@ -1041,6 +1774,22 @@ func (in *OpenstackSpec) DeepCopy() *OpenstackSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgets) DeepCopyInto(out *PodDisruptionBudgets) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgets.
+func (in *PodDisruptionBudgets) DeepCopy() *PodDisruptionBudgets {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodNodeSelector) DeepCopyInto(out *PodNodeSelector) {
 	*out = *in
@@ -1090,6 +1839,96 @@ func (in *PodSecurityPolicy) DeepCopy() *PodSecurityPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTolerationRestriction) DeepCopyInto(out *PodTolerationRestriction) {
+	*out = *in
+	if in.DefaultTolerations != nil {
+		in, out := &in.DefaultTolerations, &out.DefaultTolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Whitelist != nil {
+		in, out := &in.Whitelist, &out.Whitelist
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceTolerations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTolerationRestriction.
+func (in *PodTolerationRestriction) DeepCopy() *PodTolerationRestriction {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTolerationRestriction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostApplyValidation) DeepCopyInto(out *PostApplyValidation) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(PostApplyValidationWebhook)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostApplyValidation.
+func (in *PostApplyValidation) DeepCopy() *PostApplyValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(PostApplyValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostApplyValidationWebhook) DeepCopyInto(out *PostApplyValidationWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostApplyValidationWebhook.
+func (in *PostApplyValidationWebhook) DeepCopy() *PostApplyValidationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(PostApplyValidationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAdapter) DeepCopyInto(out *PrometheusAdapter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAdapter.
+func (in *PrometheusAdapter) DeepCopy() *PrometheusAdapter {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAdapter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	*out = *in
@@ -1160,6 +1999,21 @@ func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CloudNetwork != nil {
+		in, out := &in.CloudNetwork, &out.CloudNetwork
+		*out = new(CloudNetworkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootstrapCommands != nil {
+		in, out := &in.BootstrapCommands, &out.BootstrapCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = new(DiskConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1222,6 +2076,27 @@ func (in *RegistryConfiguration) DeepCopy() *RegistryConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerConfig) DeepCopyInto(out *SchedulerConfig) {
+	*out = *in
+	if in.PercentageOfNodesToScore != nil {
+		in, out := &in.PercentageOfNodesToScore, &out.PercentageOfNodesToScore
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerConfig.
+func (in *SchedulerConfig) DeepCopy() *SchedulerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticAuditLog) DeepCopyInto(out *StaticAuditLog) {
 	*out = *in
@@ -1255,6 +2130,32 @@ func (in *StaticAuditLogConfig) DeepCopy() *StaticAuditLogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAuth) DeepCopyInto(out *StaticAuth) {
+	*out = *in
+	if in.AnonymousAuth != nil {
+		in, out := &in.AnonymousAuth, &out.AnonymousAuth
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Profiling != nil {
+		in, out := &in.Profiling, &out.Profiling
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAuth.
+func (in *StaticAuth) DeepCopy() *StaticAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticWorkersConfig) DeepCopyInto(out *StaticWorkersConfig) {
 	*out = *in
@@ -1357,3 +2258,29 @@ func (in *WeaveNetSpec) DeepCopy() *WeaveNetSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookCABundleInjection) DeepCopyInto(out *WebhookCABundleInjection) {
+	*out = *in
+	if in.ValidatingWebhookConfigurations != nil {
+		in, out := &in.ValidatingWebhookConfigurations, &out.ValidatingWebhookConfigurations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MutatingWebhookConfigurations != nil {
+		in, out := &in.MutatingWebhookConfigurations, &out.MutatingWebhookConfigurations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookCABundleInjection.
+func (in *WebhookCABundleInjection) DeepCopy() *WebhookCABundleInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookCABundleInjection)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -32,6 +32,10 @@ import (
 const (
 	KubernetesCACertPath = "/etc/kubernetes/pki/ca.crt"
 	KubernetesCAKeyPath  = "/etc/kubernetes/pki/ca.key"
+	FrontProxyCACertPath = "/etc/kubernetes/pki/front-proxy-ca.crt"
+	FrontProxyCAKeyPath  = "/etc/kubernetes/pki/front-proxy-ca.key"
+	EtcdCACertPath       = "/etc/kubernetes/pki/etcd/ca.crt"
+	EtcdCAKeyPath        = "/etc/kubernetes/pki/etcd/ca.key"
 )
 
 func kubernetesPKIFiles() []string {
@@ -40,10 +44,10 @@ func kubernetesPKIFiles() []string {
 		KubernetesCAKeyPath,
 		"/etc/kubernetes/pki/sa.key",
 		"/etc/kubernetes/pki/sa.pub",
-		"/etc/kubernetes/pki/front-proxy-ca.crt",
-		"/etc/kubernetes/pki/front-proxy-ca.key",
-		"/etc/kubernetes/pki/etcd/ca.crt",
-		"/etc/kubernetes/pki/etcd/ca.key",
+		FrontProxyCACertPath,
+		FrontProxyCAKeyPath,
+		EtcdCACertPath,
+		EtcdCAKeyPath,
 	}
 }
 
@@ -81,29 +85,35 @@ func UploadKubePKI(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection
 			return fmt.Errorf("file %q found found in PKI", fname)
 		}
 
-		if err := sshfs.MkdirAll(path.Dir(fname), 0700); err != nil {
+		if err := uploadPKIFile(sshfs, fname, buf); err != nil {
 			return err
 		}
+	}
 
-		f, err := sshfs.Open(fname)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		fw, _ := f.(sshiofs.ExtendedFile)
+	return nil
+}
 
-		if err = fw.Truncate(0); err != nil {
-			return err
-		}
+func uploadPKIFile(sshfs sshiofs.MkdirFS, fname string, buf []byte) error {
+	if err := sshfs.MkdirAll(path.Dir(fname), 0700); err != nil {
+		return err
+	}
 
-		if err = fw.Chmod(0600); err != nil {
-			return err
-		}
+	f, err := sshfs.Open(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fw, _ := f.(sshiofs.ExtendedFile)
 
-		if _, err = io.Copy(fw, bytes.NewBuffer(buf)); err != nil {
-			return err
-		}
+	if err = fw.Truncate(0); err != nil {
+		return err
 	}
 
-	return nil
+	if err = fw.Chmod(0600); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fw, bytes.NewBuffer(buf))
+
+	return err
 }
@@ -36,25 +36,34 @@ import (
 
 // CAKeyPair parses generated PKI CA certificate and key
 func CAKeyPair(config *configupload.Configuration) (*rsa.PrivateKey, *x509.Certificate, error) {
-	caCert, found := config.KubernetesPKI[KubernetesCACertPath]
+	return caKeyPair(config, "kubernetes", KubernetesCACertPath, KubernetesCAKeyPath)
+}
+
+// EtcdCAKeyPair parses generated PKI etcd CA certificate and key
+func EtcdCAKeyPair(config *configupload.Configuration) (*rsa.PrivateKey, *x509.Certificate, error) {
+	return caKeyPair(config, "etcd", EtcdCACertPath, EtcdCAKeyPath)
+}
+
+func caKeyPair(config *configupload.Configuration, name, certPath, keyPath string) (*rsa.PrivateKey, *x509.Certificate, error) {
+	caCert, found := config.KubernetesPKI[certPath]
 	if !found {
 		return nil, nil, fail.RuntimeError{
-			Op: "getting CA certificate from internal kubernetes PKI",
+			Op: fmt.Sprintf("getting %s CA certificate from internal kubernetes PKI", name),
 			Err: errors.WithStack(&os.PathError{
 				Op:   "read",
-				Path: KubernetesCACertPath,
+				Path: certPath,
 				Err:  fmt.Errorf("not found"),
 			}),
 		}
 	}
 
-	caKey, found := config.KubernetesPKI[KubernetesCAKeyPath]
+	caKey, found := config.KubernetesPKI[keyPath]
 	if !found {
 		return nil, nil, fail.RuntimeError{
-			Op: "getting CA key from internal kubernetes PKI",
+			Op: fmt.Sprintf("getting %s CA key from internal kubernetes PKI", name),
 			Err: errors.WithStack(&os.PathError{
 				Op:   "read",
-				Path: KubernetesCAKeyPath,
+				Path: keyPath,
 				Err:  fmt.Errorf("not found"),
 			}),
 		}
@@ -62,21 +71,21 @@ func CAKeyPair(config *configupload.Configuration) (*rsa.PrivateKey, *x509.Certi
 
 	certs, err := certutil.ParseCertsPEM(caCert)
 	if err != nil {
-		return nil, nil, fail.Runtime(err, "parsing kubernetes CA certificate PEM")
+		return nil, nil, fail.Runtime(err, "parsing %s CA certificate PEM", name)
 	}
 
 	if len(certs) == 0 {
-		return nil, nil, fail.Runtime(fmt.Errorf("does not contain at least one valid certificate"), "parsing kubernetes CA certificate PEM")
+		return nil, nil, fail.Runtime(fmt.Errorf("does not contain at least one valid certificate"), "parsing %s CA certificate PEM", name)
 	}
 
 	possibleKey, err := keyutil.ParsePrivateKeyPEM(caKey)
 	if err != nil {
-		return nil, nil, fail.Runtime(err, "parsing kubernetes CA key")
+		return nil, nil, fail.Runtime(err, "parsing %s CA key", name)
 	}
 
 	rsaKey, ok := possibleKey.(*rsa.PrivateKey)
 	if !ok {
-		return nil, nil, fail.Runtime(fmt.Errorf("private key is not a RSA private key"), "parsing kubernetes CA key")
+		return nil, nil, fail.Runtime(fmt.Errorf("private key is not a RSA private key"), "parsing %s CA key", name)
 	}
 
 	return rsaKey, certs[0], nil
@@ -116,6 +125,31 @@ func NewSignedTLSCert(name, namespace, domain string, caKey crypto.Signer, caCer
 	}, nil
 }
 
+// NewSignedClientCert generates a client certificate with the given common name
+// signed by the given CA
+func NewSignedClientCert(commonName string, caKey crypto.Signer, caCert *x509.Certificate) (map[string]string, error) {
+	newKPKey, err := newPrivateKey()
+	if err != nil {
+		return nil, fail.Runtime(err, "generating RSA private key")
+	}
+
+	certCfg := certutil.Config{
+		CommonName: commonName,
+		Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	newKPCert, err := newSignedCert(&certCfg, newKPKey, caCert, caKey)
+	if err != nil {
+		return nil, fail.Runtime(err, "generating certificate")
+	}
+
+	return map[string]string{
+		resources.TLSCertName:          string(encodeCertPEM(newKPCert)),
+		resources.TLSKeyName:           string(encodePrivateKeyPEM(newKPKey)),
+		resources.KubernetesCACertName: string(encodeCertPEM(caCert)),
+	}, nil
+}
+
 // GetCertificateSANs combines host name and subject alternative names into a list of SANs after transformation
 func GetCertificateSANs(host string, alternativeNames []string) []string {
 	certSANS := []string{strings.ToLower(host)}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/configupload"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+type userCA struct {
+	name     string
+	keyPair  *kubeoneapi.CAKeyPair
+	certPath string
+	keyPath  string
+}
+
+func userCAs(ca *kubeoneapi.CertificateAuthority) []userCA {
+	if ca == nil {
+		return nil
+	}
+
+	return []userCA{
+		{name: "kubernetes", keyPair: ca.Kubernetes, certPath: KubernetesCACertPath, keyPath: KubernetesCAKeyPath},
+		{name: "front-proxy", keyPair: ca.FrontProxy, certPath: FrontProxyCACertPath, keyPath: FrontProxyCAKeyPath},
+		{name: "etcd", keyPair: ca.Etcd, certPath: EtcdCACertPath, keyPath: EtcdCAKeyPath},
+	}
+}
+
+// LoadCertificateAuthorities reads and verifies the user provided certificate
+// authorities and stores them in the Kubernetes PKI
+func LoadCertificateAuthorities(s *state.State) error {
+	for _, ca := range userCAs(s.Cluster.CertificateAuthority) {
+		if ca.keyPair == nil {
+			continue
+		}
+
+		certPEM, err := configupload.ReadFile(ca.keyPair.CertFilePath, s.ManifestFilePath)
+		if err != nil {
+			return err
+		}
+
+		keyPEM, err := configupload.ReadFile(ca.keyPair.KeyFilePath, s.ManifestFilePath)
+		if err != nil {
+			return err
+		}
+
+		if err = VerifyCAKeyPair(certPEM, keyPEM, time.Now()); err != nil {
+			return fail.ConfigValidation(errors.Wrapf(err, "verifying .certificateAuthority %s CA", ca.name))
+		}
+
+		s.Configuration.KubernetesPKI[ca.certPath] = certPEM
+		s.Configuration.KubernetesPKI[ca.keyPath] = keyPEM
+	}
+
+	return nil
+}
+
+// UploadCertificateAuthorities uploads the user provided certificate
+// authorities, so kubeadm signs the cluster certificates using them
+func UploadCertificateAuthorities(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	sshfs := s.Runner.NewFS()
+
+	for _, ca := range userCAs(s.Cluster.CertificateAuthority) {
+		if ca.keyPair == nil {
+			continue
+		}
+
+		for _, fname := range []string{ca.certPath, ca.keyPath} {
+			buf, found := s.Configuration.KubernetesPKI[fname]
+			if !found {
+				return fmt.Errorf("file %q not found in PKI", fname)
+			}
+
+			if err := uploadPKIFile(sshfs, fname, buf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// VerifyCAKeyPair verifies that the first certificate is a valid CA
+// certificate, that it's signed by the following certificates up to the root
+// CA if provided, and that the private key belongs to it.
+func VerifyCAKeyPair(certPEM, keyPEM []byte, now time.Time) error {
+	certs, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil {
+		return errors.Wrap(err, "parsing CA certificate")
+	}
+
+	caCert := certs[0]
+	if !caCert.IsCA || caCert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return errors.New("certificate is not a CA certificate allowed to sign certificates")
+	}
+
+	if now.Before(caCert.NotBefore) || now.After(caCert.NotAfter) {
+		return errors.Errorf("certificate is valid only from %s to %s", caCert.NotBefore, caCert.NotAfter)
+	}
+
+	if len(certs) > 1 {
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1 : len(certs)-1] {
+			intermediates.AddCert(cert)
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(certs[len(certs)-1])
+
+		_, err = caCert.Verify(x509.VerifyOptions{
+			Intermediates: intermediates,
+			Roots:         roots,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			return errors.Wrap(err, "verifying certificate chain")
+		}
+	}
+
+	key, err := keyutil.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return errors.Wrap(err, "parsing CA key")
+	}
+
+	// KubeOne signs the addons certificates using RSA CA keys only
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return errors.New("CA key is not a RSA private key")
+	}
+
+	if !rsaKey.PublicKey.Equal(caCert.PublicKey) {
+		return errors.New("CA key doesn't match the CA certificate")
+	}
+
+	return nil
+}
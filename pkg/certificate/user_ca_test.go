@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/keyutil"
+)
+
+func newTestCA(t *testing.T, name string, parent *x509.Certificate, parentKey *rsa.PrivateKey, notAfter time.Time) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key
+}
+
+func encodeCerts(certs ...*x509.Certificate) []byte {
+	var buf []byte
+	for _, cert := range certs {
+		buf = append(buf, encodeCertPEM(cert)...)
+	}
+
+	return buf
+}
+
+func TestVerifyCAKeyPair(t *testing.T) {
+	validUntil := time.Now().Add(24 * time.Hour)
+
+	rootCert, rootKey := newTestCA(t, "root", nil, nil, validUntil)
+	intermediateCert, intermediateKey := newTestCA(t, "intermediate", rootCert, rootKey, validUntil)
+	_, otherKey := newTestCA(t, "other", nil, nil, validUntil)
+	expiredCert, expiredKey := newTestCA(t, "expired", nil, nil, time.Now().Add(-time.Minute))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyPEM, err := keyutil.MarshalPrivateKeyToPEM(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		certPEM []byte
+		keyPEM  []byte
+		wantErr bool
+	}{
+		{
+			name:    "self-signed CA",
+			certPEM: encodeCerts(rootCert),
+			keyPEM:  encodePrivateKeyPEM(rootKey),
+		},
+		{
+			name:    "intermediate CA with chain",
+			certPEM: encodeCerts(intermediateCert, rootCert),
+			keyPEM:  encodePrivateKeyPEM(intermediateKey),
+		},
+		{
+			name:    "intermediate CA with wrong chain",
+			certPEM: encodeCerts(intermediateCert, expiredCert),
+			keyPEM:  encodePrivateKeyPEM(intermediateKey),
+			wantErr: true,
+		},
+		{
+			name:    "mismatched key",
+			certPEM: encodeCerts(rootCert),
+			keyPEM:  encodePrivateKeyPEM(otherKey),
+			wantErr: true,
+		},
+		{
+			name:    "expired CA",
+			certPEM: encodeCerts(expiredCert),
+			keyPEM:  encodePrivateKeyPEM(expiredKey),
+			wantErr: true,
+		},
+		{
+			name:    "non-RSA key",
+			certPEM: encodeCerts(rootCert),
+			keyPEM:  ecKeyPEM,
+			wantErr: true,
+		},
+		{
+			name:    "invalid certificate",
+			certPEM: []byte("not a certificate"),
+			keyPEM:  encodePrivateKeyPEM(rootKey),
+			wantErr: true,
+		},
+		{
+			name:    "invalid key",
+			certPEM: encodeCerts(rootCert),
+			keyPEM:  []byte("not a key"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyCAKeyPair(tt.certPEM, tt.keyPEM, time.Now()); (err != nil) != tt.wantErr {
+				t.Errorf("VerifyCAKeyPair() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -19,27 +19,43 @@ package apiserverstatus
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/ssh/sshiofs"
 	"k8c.io/kubeone/pkg/ssh/sshtunnel"
 	"k8c.io/kubeone/pkg/state"
 )
 
 const (
-	healthzEndpoint = "https://%s:6443/healthz"
+	clientCertPath = "/etc/kubernetes/pki/apiserver-kubelet-client.crt"
+	clientKeyPath  = "/etc/kubernetes/pki/apiserver-kubelet-client.key"
 )
 
 type Report struct {
 	Health bool `json:"health,omitempty"`
 }
 
-// Get uses the /healthz endpoint to check are all API server instances healthy
+// Get uses the API server health check endpoint (/healthz by default) to check
+// are all API server instances healthy
 func Get(s *state.State, node kubeoneapi.HostConfig) (*Report, error) {
 	insecureTLSConfig := &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+
+	// health endpoints are not readable anonymously if anonymous auth is
+	// disabled, authenticate using the kube-apiserver kubelet client certificate
+	if s.Cluster.Features.StaticAuth.AnonymousAuthDisabled() {
+		cert, err := loadClientCertificate(s, node)
+		if err != nil {
+			return &Report{
+				Health: false,
+			}, err
+		}
+		insecureTLSConfig.Certificates = []tls.Certificate{cert}
+	}
+
 	roundTripper, err := sshtunnel.NewHTTPTransport(s.Connector, node, insecureTLSConfig)
 	if err != nil {
 		return &Report{
@@ -47,7 +63,7 @@ func Get(s *state.State, node kubeoneapi.HostConfig) (*Report, error) {
 		}, err
 	}
 
-	health, err := apiserverHealth(s.Context, roundTripper, node.PrivateAddress)
+	health, err := apiserverHealth(s.Context, roundTripper, s.Cluster.APIEndpoint.HealthCheckURL(node.PrivateAddress))
 	if err != nil {
 		return &Report{
 			Health: false,
@@ -57,9 +73,33 @@ func Get(s *state.State, node kubeoneapi.HostConfig) (*Report, error) {
 	return &Report{Health: health}, nil
 }
 
+// loadClientCertificate downloads the kube-apiserver kubelet client
+// certificate and key from the node over SSH
+func loadClientCertificate(s *state.State, node kubeoneapi.HostConfig) (tls.Certificate, error) {
+	conn, err := s.Connector.Connect(node)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	sshfs := sshiofs.New(conn)
+
+	certPem, err := fs.ReadFile(sshfs, clientCertPath)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	keyPem, err := fs.ReadFile(sshfs, clientKeyPath)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+
+	return cert, fail.Runtime(err, "x509 certificate keypair parsing")
+}
+
 // apiserverHealth checks is API server healthy
-func apiserverHealth(ctx context.Context, t http.RoundTripper, nodeAddress string) (bool, error) {
-	endpoint := fmt.Sprintf(healthzEndpoint, nodeAddress)
+func apiserverHealth(ctx context.Context, t http.RoundTripper, endpoint string) (bool, error) {
 	request, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return false, fail.Runtime(err, "apiserver status request")
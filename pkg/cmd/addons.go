@@ -49,6 +49,9 @@ func addonsListCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		Short:         "List addons",
 		SilenceErrors: true,
 		Example:       `kubeone -m mycluster.yaml -t terraformoutput.json addons list`,
+		Annotations: map[string]string{
+			externalKubeconfigAnnotation: "",
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			gopts, err := persistentGlobalOptions(rootFlags)
 			if err != nil {
@@ -18,9 +18,11 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"time"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/Masterminds/semver/v3"
@@ -28,7 +30,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8c.io/kubeone/pkg/addons"
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/metrics"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/tasks"
 
@@ -43,11 +48,17 @@ type applyOpts struct {
 	BackupFile   string `longflag:"backup" shortflag:"b"`
 	NoInit       bool   `longflag:"no-init"`
 	ForceInstall bool   `longflag:"force-install"`
+	SkipCNI      bool   `longflag:"skip-cni"`
 	// Upgrade flags
-	ForceUpgrade              bool `longflag:"force-upgrade"`
-	UpgradeMachineDeployments bool `longflag:"upgrade-machine-deployments"`
-	CreateMachineDeployments  bool `longflag:"create-machine-deployments"`
-	RotateEncryptionKey       bool `longflag:"rotate-encryption-key"`
+	ForceUpgrade              bool          `longflag:"force-upgrade"`
+	UpgradeMachineDeployments bool          `longflag:"upgrade-machine-deployments"`
+	CreateMachineDeployments  bool          `longflag:"create-machine-deployments"`
+	RotateEncryptionKey       bool          `longflag:"rotate-encryption-key"`
+	PostUpgradeWait           time.Duration `longflag:"post-upgrade-wait"`
+	// Observability flags
+	MetricsPush string `longflag:"metrics-push"`
+	// Node labels flags
+	ReconcileLabels bool `longflag:"reconcile-labels"`
 }
 
 func (opts *applyOpts) BuildState() (*state.State, error) {
@@ -62,6 +73,13 @@ func (opts *applyOpts) BuildState() (*state.State, error) {
 	s.UpgradeMachineDeployments = opts.UpgradeMachineDeployments
 	s.CreateMachineDeployments = opts.CreateMachineDeployments
 
+	if opts.SkipCNI {
+		// CNI is managed outside of KubeOne, same as clusterNetwork.cni.external
+		s.Cluster.ClusterNetwork.CNI = &kubeoneapi.CNI{
+			External: &kubeoneapi.ExternalCNISpec{},
+		}
+	}
+
 	if s.BackupFile == "" {
 		fullPath, _ := filepath.Abs(opts.ManifestFile)
 		clusterName := s.Cluster.Name
@@ -143,6 +161,12 @@ func applyCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		false,
 		"use force to install new binary versions (!dangerous!)")
 
+	cmd.Flags().BoolVar(
+		&opts.SkipCNI,
+		longFlagName(opts, "SkipCNI"),
+		false,
+		"don't install or reconcile the CNI plugin, it's expected to be provided externally (same as clusterNetwork.cni.external)")
+
 	cmd.Flags().BoolVar(
 		&opts.ForceUpgrade,
 		longFlagName(opts, "ForceUpgrade"),
@@ -167,25 +191,94 @@ func applyCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		false,
 		"rotate Encryption Provider encryption key")
 
+	cmd.Flags().DurationVar(
+		&opts.PostUpgradeWait,
+		longFlagName(opts, "PostUpgradeWait"),
+		0,
+		"after the upgrade, wait up to the given duration for the system workloads and etcd to stabilize (disabled if 0)")
+
+	cmd.Flags().BoolVar(
+		&opts.ReconcileLabels,
+		longFlagName(opts, "ReconcileLabels"),
+		false,
+		"only reconcile labels, annotations and taints of the control plane and static worker nodes, skipping all other tasks")
+
+	cmd.Flags().StringVar(
+		&opts.MetricsPush,
+		longFlagName(opts, "MetricsPush"),
+		"",
+		"URL of the Prometheus Pushgateway to push the operation metrics (duration per phase, result, node counts) to at the end of the run")
+
 	return cmd
 }
 
 func runApply(opts *applyOpts) error {
+	if opts.PostUpgradeWait < 0 {
+		return fail.ConfigValidation(errors.Errorf("--%s can't be negative", longFlagName(opts, "PostUpgradeWait")))
+	}
+
+	if opts.MetricsPush != "" {
+		if u, err := url.Parse(opts.MetricsPush); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fail.ConfigValidation(errors.Errorf("--%s must be a http(s) URL", longFlagName(opts, "MetricsPush")))
+		}
+	}
+
 	s, err := opts.BuildState()
 	if err != nil {
 		return err
 	}
 
+	if opts.MetricsPush == "" {
+		return runApplyWithState(s, opts)
+	}
+
+	s.Metrics = metrics.NewRecorder("apply")
+	err = runApplyWithState(s, opts)
+
+	if pushErr := s.Metrics.Push(s.Context, opts.MetricsPush, s.Cluster.Name, err, clusterNodeCounts(s.Cluster)); pushErr != nil {
+		s.Logger.Warnf("Failed to push metrics: %v", pushErr)
+	}
+
+	return err
+}
+
+// clusterNodeCounts returns the number of nodes per role in the cluster
+// configuration. Dynamic workers are counted by the MachineDeployment replicas.
+func clusterNodeCounts(cluster *kubeoneapi.KubeOneCluster) metrics.NodeCounts {
+	counts := metrics.NodeCounts{
+		ControlPlane:  len(cluster.ControlPlane.Hosts),
+		StaticWorkers: len(cluster.StaticWorkers.Hosts),
+	}
+
+	for _, workerset := range cluster.DynamicWorkers {
+		if workerset.Replicas != nil {
+			counts.DynamicWorkers += *workerset.Replicas
+		}
+	}
+
+	return counts
+}
+
+func runApplyWithState(s *state.State, opts *applyOpts) error {
+	if opts.ReconcileLabels {
+		return tasks.WithNodeLabels(nil).Run(s)
+	}
+
 	// Validate credentials
 	if vErr := validateCredentials(s, opts.CredentialsFile); vErr != nil {
 		return vErr
 	}
 
+	// Validate that params required by the addons are provided
+	if vErr := addons.ValidateRequiredParams(s); vErr != nil {
+		return vErr
+	}
+
 	// Probe the cluster for the actual state and the needed tasks.
 	probbing := tasks.WithHostnameOS(nil)
 	probbing = tasks.WithProbesAndSafeguard(probbing)
 
-	if err = probbing.Run(s); err != nil {
+	if err := probbing.Run(s); err != nil {
 		return err
 	}
 
@@ -345,7 +438,7 @@ func runApplyInstall(s *state.State, opts *applyOpts) error { // Print the expec
 		return tasks.WithBinariesOnly(nil).Run(s)
 	}
 
-	return tasks.WithFullInstall(nil).Run(s)
+	return tasks.WithPostApplyValidation(tasks.WithFullInstall(nil)).Run(s)
 }
 
 func runApplyUpgradeIfNeeded(s *state.State, opts *applyOpts) error {
@@ -422,10 +515,16 @@ func runApplyUpgradeIfNeeded(s *state.State, opts *applyOpts) error {
 					node.Kubelet.Version,
 					s.Cluster.Versions.Kubernetes))
 		}
+
+		if opts.PostUpgradeWait > 0 {
+			tasksToRun = tasks.WithPostUpgradeWait(tasksToRun, opts.PostUpgradeWait)
+		}
 	} else {
 		tasksToRun = tasks.WithResources(nil)
 	}
 
+	tasksToRun = tasks.WithPostApplyValidation(tasksToRun)
+
 	fmt.Println()
 	for _, op := range operations {
 		fmt.Printf("\t~ %s\n", op)
@@ -47,6 +47,9 @@ func configDumpCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		Short:         "Merge the KubeOneCluster manifest with the Terraform state and dump it to the stdout",
 		SilenceErrors: true,
 		Example:       `kubeone config dump -m kubeone.yaml -t tf.json`,
+		Annotations: map[string]string{
+			externalKubeconfigAnnotation: "",
+		},
 		RunE: func(*cobra.Command, []string) error {
 			gopts, err := persistentGlobalOptions(rootFlags)
 			if err != nil {
@@ -84,7 +87,7 @@ func dumpConfig(opts *configDumpOpts) error {
 	// This merges the provided manifest with the Terraform output, defaults
 	// the merged manifest, converts it to the internal representations, and
 	// then validates it.
-	cluster, err := config.LoadKubeOneCluster(opts.ManifestFile, opts.TerraformState, opts.CredentialsFile, logger)
+	cluster, err := config.LoadKubeOneCluster(opts.ManifestFile, opts.TerraformState, opts.CredentialsFile, opts.TerraformOptions(), logger)
 	if err != nil {
 		return err
 	}
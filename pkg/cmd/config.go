@@ -522,6 +522,11 @@ name: {{ .ClusterName }}
 
 versions:
   kubernetes: "{{ .KubernetesVersion }}"
+  # Advanced: override the kubeadm configuration API version used to generate
+  # the kubeadm configuration files. Must be supported by the kubeadm version
+  # matching the Kubernetes version. Defaults to the API version matching the
+  # Kubernetes version.
+  # kubeadmConfigAPIVersion: "kubeadm.k8s.io/v1beta3"
 
 clusterNetwork:
   # the subnet used for pods (default: 10.244.0.0/16)
@@ -530,8 +535,18 @@ clusterNetwork:
   serviceSubnet: "{{ .ServiceSubnet }}"
   # the domain name used for services (default: cluster.local)
   serviceDomainName: "{{ .ServiceDNS }}"
-  # a nodePort range to reserve for services (default: 30000-32767)
+  # a nodePort range to reserve for services (default: 30000-32767), set as the
+  # kube-apiserver --service-node-port-range flag. Changing it on an existing
+  # cluster restarts the kube-apiserver on all control plane nodes. Services
+  # using NodePorts outside of the new range keep them, but are reported.
   nodePortRange: "{{ .NodePortRange }}"
+  # the size of the pod CIDR allocated to each node by the kube-controller-manager
+  # (default: 24 for IPv4 and 64 for IPv6). The node CIDR must fit into the
+  # podSubnet and provide enough addresses for the kubelet maxPods on all nodes.
+  # Changing it requires an upgrade of the control plane (--force-upgrade).
+  # nodeCIDRMaskSize:
+  #   ipv4: 24
+  #   ipv6: 64
   # kube-proxy configurations
   kubeProxy:
     # skipInstallation will skip the installation of kube-proxy
@@ -553,6 +568,15 @@ clusterNetwork:
       excludeCIDRs: []
     # if mode is by default
     iptables: {}
+  # verify the node-to-node connectivity before provisioning the cluster.
+  # The TCP ports of kube-apiserver, etcd, kubelet and the CNI plugin are
+  # verified depending on the node roles, temporary listeners (python3, ncat
+  # or nc) are started on the ports not used yet. UDP ports (e.g. VXLAN) can't
+  # be verified.
+  # nodeConnectivityCheck:
+  #   # path MTU that must be available between the nodes, the Canal MTU + 50
+  #   # (VXLAN overhead) by default, not verified for other CNI plugins
+  #   mtu: 1500
   # CNI plugin of choice. CNI can not be changed later at upgrade time.
   cni:
     # Only one CNI plugin can be defined at the same time
@@ -581,6 +605,10 @@ clusterNetwork:
     #   # referenced in appropriate manifests. Currently only weave-net
     #   # supports encryption.
     #   encrypted: true
+    # external CNI plugin is not installed or reconciled by KubeOne, it's
+    # expected to be deployed by other means (e.g. by a platform team).
+    # KubeOne waits for nodes to become ready before proceeding. The same can
+    # be achieved using the 'kubeone apply --skip-cni' flag.
     # external: {}
 
 cloudProvider:
@@ -604,6 +632,24 @@ cloudProvider:
   # CSIConfig is configuration passed to the CSI driver.
   # This is currently used only for vSphere clusters.
   csiConfig: ""
+  # Tuning of the cloud-controller-manager deployed for external cloud providers.
+  # Changes roll the cloud-controller-manager pods.
+  # ccm:
+  #   # additional flags, without the leading dashes
+  #   extraArgs:
+  #     concurrent-service-syncs: "5"
+  #   resources:
+  #     requests:
+  #       cpu: 200m
+  #       memory: 128Mi
+  #   # only for Azure, DigitalOcean, EquinixMetal and Hetzner,
+  #   # leader election is enabled for more than one replica
+  #   replicas: 2
+  # Deploy the CSI external snapshot-controller and the VolumeSnapshot CRDs.
+  # Needed only for OpenStack and for the CSI drivers deployed by the user,
+  # other CSI drivers deployed by KubeOne already ship them.
+  # csiSnapshotController:
+  #   enable: false
 
 # Controls which container runtime will be installed on instances.
 # By default:
@@ -639,10 +685,50 @@ containerRuntime:
   #     "*":
   #       mirrors:
   #       - https://secure.tld
+  #   # CRI plugin settings, containerd gets restarted on every node if its
+  #   # config is changed.
+  #   # streaming server (exec, attach, port-forward) settings.
+  #   streamServerAddress: "127.0.0.1"
+  #   streamServerPort: 0
+  #   streamIdleTimeout: "4h"
+  #   # additional runtime handlers registered besides runc, the runtime
+  #   # binaries must be installed on the nodes. containerd is restarted
+  #   # when the runtimes change. With runtimeClass set, a RuntimeClass with
+  #   # the handler name is created in the cluster.
+  #   runtimes:
+  #     gvisor:
+  #       runtimeType: "io.containerd.runsc.v1"
+  #       runtimeClass:
+  #         nodeSelector:
+  #           sandbox: "gvisor"
+  #     crun:
+  #       runtimeType: "io.containerd.runc.v2"
+  #       binaryName: "/usr/local/bin/crun"
   # Installs Docker container runtime.
   # Default for Kubernetes clusters up to 1.20.
   # This option will be removed once Kubernetes 1.23 reaches EOL.
   # docker: {}
+  # docker:
+  #   # dockerd log driver, defaults to "json-file".
+  #   logDriver: "json-file"
+  #   # dockerd log options. The "max-size" and "max-file" options default to
+  #   # loggingConfig.containerLogMaxSize and loggingConfig.containerLogMaxFiles.
+  #   # dockerd gets restarted if its config is changed.
+  #   logOpts:
+  #     max-size: "100m"
+  #     max-file: "5"
+  # The cgroup version required on all nodes, "v1" or "v2". The cgroup
+  # version of each node is verified before provisioning.
+  # cgroups:
+  #   version: "v2"
+  #   # add systemd.unified_cgroup_hierarchy=1 to the kernel command line and
+  #   # reboot the nodes booted with cgroup v1. Only not yet provisioned
+  #   # nodes are rebooted.
+  #   configureKernelCommandLine: false
+  # Images pulled on all nodes once the container runtime is configured.
+  # Images already present on the node are skipped.
+  # preloadImages:
+  # - "docker.io/library/python:3.10"
 
 features:
   # Enable the PodNodeSelector admission plugin in API server.
@@ -655,6 +741,28 @@ features:
       # configFilePath is is a required field.
       # More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#configuration-file-format-1
       configFilePath: ""
+  # Enable the PodTolerationRestriction admission plugin in API server, which
+  # adds default tolerations to pods and rejects pods with tolerations that
+  # are not whitelisted.
+  # More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#podtolerationrestriction
+  podTolerationRestriction:
+    enable: false
+    # Cluster-wide default and whitelisted tolerations, used for namespaces
+    # without own tolerations. Changing them requires running kubeone apply
+    # with --force-upgrade.
+    defaultTolerations: []
+    whitelist: []
+    # Per-namespace default and whitelisted tolerations, reconciled as the
+    # namespace annotations on every kubeone apply.
+    namespaces: []
+    # - name: "gpu-workloads"
+    #   defaultTolerations:
+    #   - key: "nvidia.com/gpu"
+    #     operator: "Exists"
+    #     effect: "NoSchedule"
+    #   whitelist:
+    #   - key: "nvidia.com/gpu"
+    #     operator: "Exists"
   # Enables PodSecurityPolicy admission plugin in API server, as well as creates
   # default 'privileged' PodSecurityPolicy, plus RBAC rules to authorize
   # 'kube-system' namespace pods to 'use' it.
@@ -667,9 +775,15 @@ features:
     config:
       # PolicyFilePath is a path on local file system to the audit policy manifest
       # which defines what events should be recorded and what data they should include.
-      # PolicyFilePath is a required field.
+      # The policy must record at least the Metadata level for all other requests.
       # More info: https://kubernetes.io/docs/tasks/debug-application-cluster/audit/#audit-policy
       policyFilePath: ""
+      # PolicyPreset is the name of the audit policy shipped with KubeOne, used
+      # instead of policyFilePath. Supported values:
+      # * minimal - records all requests at the Metadata level
+      # * compliance - records requests to Secrets and RBAC objects at the
+      #   RequestResponse level and all other requests at the Metadata level
+      # policyPreset: ""
       # LogPath is path on control plane instances where audit log files are stored
       logPath: "/var/log/kubernetes/audit.log"
       # LogMaxAge is maximum number of days to retain old audit log files
@@ -738,11 +852,156 @@ features:
     # inline string
     customEncryptionConfiguration: ""
 
+  # Configure additional static authentication methods for the API server
+  staticAuth:
+    # clientCABundle is a PEM-encoded bundle of additional CA certificates used
+    # by the API server to authenticate clients presenting client certificates.
+    # The bundle is appended to the cluster CA managed by kubeadm.
+    # Enabling or disabling the bundle on an existing cluster requires
+    # 'kubeone apply --force-upgrade', while bundle changes are applied
+    # (and kube-apiserver restarted) on every 'kubeone apply'.
+    clientCABundle: ""
+    # clientCABundleFilePath is a path on the local file system to the client
+    # CA bundle. It's mutually exclusive with clientCABundle.
+    # clientCABundleFilePath: ""
+    # anonymousAuth controls whether the API server accepts anonymous requests
+    # (kube-apiserver default: true). When disabled, the kube-apiserver probes
+    # are switched to TCP probes and nodes are joined using a discovery
+    # kubeconfig. Load balancer health checks must not rely on anonymous
+    # access. Changes restart kube-apiserver on 'kubeone apply'.
+    # anonymousAuth: false
+    # profiling controls the API server profiling endpoints (default: true).
+    # profiling: false
+
+  # Enable the rotation of kubelet serving certificates on control plane and
+  # static worker nodes. Pending kubelet serving CSRs of those nodes are
+  # approved on every 'kubeone apply'.
+  kubeletServingCertRotation:
+    enable: false
+
+  # Expose the etcd metrics over HTTPS on a dedicated port on the control
+  # plane nodes. Scraping requires a client certificate signed by the etcd CA.
+  # serviceMonitor deploys a Service, a Secret with such client certificate,
+  # and a Prometheus Operator ServiceMonitor (requires the Prometheus
+  # Operator CRDs).
+  etcdMetrics:
+    enable: false
+    port: 2382
+    serviceMonitor: false
+
+  # Create PodDisruptionBudgets for the multi-replica system addons without
+  # their own PodDisruptionBudget (e.g. CoreDNS). minAvailable is a number
+  # (e.g. 1) or a percentage (e.g. "50%") capped to the number of replicas
+  # minus one, so node drains are never blocked.
+  podDisruptionBudgets:
+    enable: false
+    minAvailable: 1
+
+  # Deploy the Prometheus adapter serving the custom and external metrics APIs,
+  # so HorizontalPodAutoscalers can scale on Prometheus metrics. The Prometheus
+  # server must be deployed separately. If rules are not set, all pods metrics
+  # are exposed via the custom metrics API.
+  prometheusAdapter:
+    enable: false
+    prometheusURL: "http://prometheus.monitoring.svc:9090"
+    # rules: |
+    #   rules:
+    #   - seriesQuery: 'http_requests_total{namespace!="",pod!=""}'
+    #     resources:
+    #       overrides:
+    #         namespace: {resource: "namespace"}
+    #         pod: {resource: "pod"}
+    #     name:
+    #       matches: "^(.*)_total$"
+    #       as: "${1}_per_second"
+    #     metricsQuery: 'sum(rate(<<.Series>>{<<.LabelMatchers>>}[2m])) by (<<.GroupBy>>)'
+
+  # Periodically backup etcd and the cluster CA certificates using a CronJob
+  # running on the control plane nodes. Backups are encrypted using the
+  # RESTIC_PASSWORD and uploaded to the S3 bucket using restic, keeping the
+  # last keepLast backups. The schedule uses the cron format. RESTIC_PASSWORD
+  # and AWS credentials are taken from the credentials file or the
+  # environment.
+  etcdBackup:
+    enable: false
+    schedule: "@every 30m"
+    s3Bucket: "s3:s3.amazonaws.com/etcd-backups"
+    awsDefaultRegion: "eu-central-1"
+    keepLast: 48
+
+  # Inject the cluster CA certificate into the caBundle of all webhooks in the
+  # listed webhook configurations on every apply, e.g. after the cluster CA is
+  # rotated. The listed webhook configurations must exist in the cluster.
+  webhookCABundleInjection:
+    enable: false
+    validatingWebhookConfigurations: []
+    mutatingWebhookConfigurations: []
+
 ## Bundle of Root CA Certificates extracted from Mozilla
 ## can be found here: https://curl.se/ca/cacert.pem
 ## caBundle should be empty for default root CAs to be used
 caBundle: ""
 
+# certificateAuthority allows using existing certificate authorities instead of
+# generating new ones. It's used only when provisioning the cluster. The CA key
+# is required, as it's used to sign the cluster certificates. Relative paths are
+# relative to this manifest file.
+# certificateAuthority:
+#   kubernetes:
+#     certFilePath: "pki/ca.crt"
+#     keyFilePath: "pki/ca.key"
+#   frontProxy:
+#     certFilePath: "pki/front-proxy-ca.crt"
+#     keyFilePath: "pki/front-proxy-ca.key"
+#   etcd:
+#     certFilePath: "pki/etcd/ca.crt"
+#     keyFilePath: "pki/etcd/ca.key"
+
+# controlPlaneComponents configures the Kubernetes control plane components.
+# Changes are applied on every apply and restart the affected components.
+# controlPlaneComponents:
+#   scheduler:
+#     # percentage of nodes that, once found feasible, makes the scheduler stop
+#     # searching for more feasible nodes, 0 means adaptive to the cluster size
+#     percentageOfNodesToScore: 50
+#   apiServer:
+#     # endpoint reconciler used to manage the kubernetes service endpoints
+#     # (lease, master-count, none), lease by default
+#     endpointReconcilerType: lease
+#     # kube-apiserver feature gates, validated against the Kubernetes version;
+#     # kube-apiserver is restarted node by node when the options change
+#     featureGates:
+#       APIServerIdentity: true
+#       StorageVersionAPI: true
+#     # max validity of the issued service account tokens (at least 1h),
+#     # unlimited by default
+#     serviceAccountMaxTokenExpiration: 24h
+#     # set to false to stop extending the validity of the projected service
+#     # account tokens to 1 year, true by default
+#     serviceAccountExtendTokenExpiration: false
+#   controllerManager:
+#     # max duration of certificates signed by the kube-controller-manager, e.g.
+#     # kubelet client and serving certificates (1 year by default);
+#     # kube-controller-manager is restarted node by node when changed
+#     clusterSigningDuration: 43800h
+
+# postApplyValidation validates the cluster at the end of the apply command.
+# postApplyValidation:
+#   webhook:
+#     # the JSON summary of the cluster (name, versions, nodes and machine
+#     # deployments) is POSTed to the URL. The apply fails unless the webhook
+#     # responds with a 2xx status code and the {"approved": true} body.
+#     url: "https://compliance.example.com/validate"
+#     # maximum duration of the webhook call (default: 30s)
+#     timeout: 30s
+
+# bootstrapToken configures the kubeadm bootstrap tokens used to join the
+# control plane and static worker nodes. Worker nodes created by
+# machine-controller use the tokens managed by machine-controller.
+# bootstrapToken:
+#   # duration after which the token expires, up to 24h (default: 1h)
+#   ttl: 15m
+
 systemPackages:
   # will add Docker and Kubernetes repositories to OS package manager
   configureRepositories: true # it's true by default
@@ -775,9 +1034,15 @@ addons:
   # addons.
   globalParams:
     key: value
+  # fieldManager makes KubeOne apply addons using the server-side apply with
+  # the given field manager name. Fields owned by other field managers (e.g.
+  # policy engines or other controllers) are not overwritten, and applying
+  # such addons fails, unless forceConflicts is enabled for the addon.
+  # Addons are applied using the client-side apply if fieldManager is empty.
+  fieldManager: ""
   # addons is used to enable addons embedded in the KubeOne binary.
-  # Currently backups-restic, default-storage-class, and unattended-upgrades are
-  # available addons.
+  # Currently backups-restic, default-storage-class, node-problem-detector, and
+  # unattended-upgrades are available addons.
   # Check out the documentation to find more information about what are embedded
   # addons and how to use them:
   # https://docs.kubermatic.com/kubeone/v1.4/guides/addons/
@@ -791,6 +1056,14 @@ addons:
       # defined in globalParams.
       params:
         key: value
+      # enabledWhen is a condition that must be satisfied for the addon to be
+      # deployed. Comparisons of provider (==, !=) and k8s version (==, !=, <,
+      # <=, >, >=) can be combined using &&, ||, ! and parentheses.
+      # The addon is always deployed if enabledWhen is empty.
+      enabledWhen: ""
+      # forceConflicts makes the addon take over ownership of fields owned by
+      # other field managers. It requires fieldManager to be set.
+      forceConflicts: false
 
 # The list of nodes can be overwritten by providing Terraform output.
 # You are strongly encouraged to provide an odd number of nodes and
@@ -810,6 +1083,21 @@ addons:
 #     # prefixed with "env:" to refer to an environment variable.
 #     sshPrivateKeyFile: '/home/me/.ssh/id_rsa'
 #     sshAgentSocket: 'env:SSH_AUTH_SOCK'
+#     # operatingSystem overrides the operating system family detected from
+#     # /etc/os-release. Supported values are ubuntu, debian, centos, rhel,
+#     # rocky, amzn and flatcar. Detected automatically if empty.
+#     # operatingSystem: ""
+#     # labels and annotations are set on the Node object of the host. The ones
+#     # removed from the manifest are removed from the Node as well. Run
+#     # 'kubeone apply --reconcile-labels' to reconcile only those and taints.
+#     # labels:
+#     #   example.com/zone: "a"
+#     # annotations:
+#     #   example.com/owner: "team-a"
+#     # dnsName is a stable DNS name of the host resolving to its private
+#     # address. If set, it's used for the etcd peer URL and added to the API
+#     # server and etcd certificates.
+#     # dnsName: ""
 #     # Taints are taints applied to nodes. If not provided (i.e. nil) for control plane nodes,
 #     # it defaults to:
 #     #   * For Kubernetes 1.23 and older: TaintEffectNoSchedule with key node-role.kubernetes.io/master
@@ -830,7 +1118,20 @@ addons:
 #     #     cpu: 200m
 #     #     memory: 300Mi
 #     #   evictionHard: {}
+#     #   # enforcing system-reserved or kube-reserved requires the matching
+#     #   # reserved resources and an existing cgroup
+#     #   # enforceNodeAllocatable: ["pods", "system-reserved", "kube-reserved"]
+#     #   # systemReservedCgroup: /system.slice
+#     #   # kubeReservedCgroup: /kube.slice
 #     #   maxPods: 110
+#     # bindAddresses configures the addresses the control plane components
+#     # listen on. The addresses must be assigned to the host. kube-apiserver
+#     # and etcd can be bound only to the privateAddress of the host.
+#     # bindAddresses:
+#     #   apiServer: '172.18.0.1'
+#     #   etcd: '172.18.0.1'
+#     #   controllerManager: '172.18.0.1'
+#     #   scheduler: '172.18.0.1'
 
 # A list of static workers, not managed by MachineController.
 # The list of nodes can be overwritten by providing Terraform output.
@@ -848,6 +1149,17 @@ addons:
 #     # prefixed with "env:" to refer to an environment variable.
 #     sshPrivateKeyFile: '/home/me/.ssh/id_rsa'
 #     sshAgentSocket: 'env:SSH_AUTH_SOCK'
+#     # operatingSystem overrides the operating system family detected from
+#     # /etc/os-release. Supported values are ubuntu, debian, centos, rhel,
+#     # rocky, amzn and flatcar. Detected automatically if empty.
+#     # operatingSystem: ""
+#     # labels and annotations are set on the Node object of the host. The ones
+#     # removed from the manifest are removed from the Node as well. Run
+#     # 'kubeone apply --reconcile-labels' to reconcile only those and taints.
+#     # labels:
+#     #   example.com/zone: "a"
+#     # annotations:
+#     #   example.com/owner: "team-a"
 #     # Taints is used to apply taints to the node.
 #     # Explicitly empty (i.e. taints: {}) means no taints will be applied.
 #     # taints:
@@ -864,6 +1176,11 @@ addons:
 #     #     cpu: 200m
 #     #     memory: 300Mi
 #     #   evictionHard: {}
+#     #   # enforcing system-reserved or kube-reserved requires the matching
+#     #   # reserved resources and an existing cgroup
+#     #   # enforceNodeAllocatable: ["pods", "system-reserved", "kube-reserved"]
+#     #   # systemReservedCgroup: /system.slice
+#     #   # kubeReservedCgroup: /kube.slice
 #     #   maxPods: 110
 
 # The API server can also be overwritten by Terraform. Provide the
@@ -873,6 +1190,27 @@ addons:
 #   host: '{{ .APIEndpointHost }}'
 #   port: {{ .APIEndpointPort }}
 #   alternativeNames: {{ .APIEndpointAlternativeNames }}
+#   # internal API endpoint, e.g. an internal load balancer, used by
+#   # 'kubeone kubeconfig --endpoint=internal'. The internal host is added
+#   # to the API server certificate, which is not regenerated on existing
+#   # clusters until the certificates are renewed.
+#   internalHost: ''
+#   internalPort: 6443
+#   # standby API endpoints, e.g. load balancers in a disaster recovery region.
+#   # The standby hosts are added to the API server certificate (on existing
+#   # clusters once the certificates are renewed), so the DNS can be failed
+#   # over to them without certificate errors. Use
+#   # 'kubeone kubeconfig --include-standby' to add contexts for the standby
+#   # endpoints to the kubeconfig file. The load balancers are not managed
+#   # by KubeOne.
+#   standbyEndpoints:
+#   - host: ''
+#     port: 6443
+#   # endpoint used by KubeOne to probe the API server instances on the
+#   # control plane nodes, https://<node-private-address>:6443/healthz by default
+#   healthCheck:
+#     port: 6443
+#     path: /healthz
 
 # If the cluster runs on bare metal or an unsupported cloud provider,
 # you can disable the machine-controller deployment entirely. In this
@@ -886,6 +1224,9 @@ machineController:
 # Also worker nodes managed by machine-controller will be configred according to
 # proxy settings here. The caveat is that only proxy.http and proxy.noProxy will
 # be used on worker machines.
+# When provisioning the cluster, KubeOne verifies on each node that the API
+# endpoint, the control plane nodes, the kubernetes service IP and the service
+# domain are excluded from the proxy by noProxy.
 # proxy:
 #  http: '{{ .HTTPProxy }}'
 #  https: '{{ .HTTPSProxy }}'
@@ -897,6 +1238,11 @@ machineController:
 # dynamicWorkers:
 # - name: fra1-a
 #   replicas: 1
+#   # sets the cluster-autoscaler annotations on the MachineDeployment, use
+#   # together with the cluster-autoscaler addon to autoscale the worker pool
+#   # autoscaler:
+#   #   minReplicas: 1
+#   #   maxReplicas: 5
 #   providerSpec:
 #     labels:
 #       mylabel: 'fra1-a'
@@ -904,6 +1250,27 @@ machineController:
 #     # and your tf output contains a "ssh_public_keys" field.
 #     # sshPublicKeys:
 #     # - 'ssh-rsa ......'
+#     # AWS only: IAM instance profile for this worker pool. Overrides the
+#     # instanceProfile from the cloudProviderSpec (or Terraform output).
+#     # instanceProfile: 'fra1-a-workers'
+#     # Overrides networking from the cloudProviderSpec (or Terraform output).
+#     # Supported on AWS, Azure, GCE and OpenStack. Azure accepts only one
+#     # security group, GCE doesn't support security groups and OpenStack
+#     # doesn't support assignPublicIP.
+#     # cloudNetwork:
+#     #   subnetID: 'subnet-0a1b2c3d'
+#     #   securityGroupIDs: ['sg-0a1b2c3d']
+#     #   assignPublicIP: false
+#     # Shell commands injected into the worker user-data, run before the
+#     # node joins the cluster. Requires operating-system-manager.
+#     # bootstrapCommands:
+#     # - 'mkdir -p /mnt/data && mount /dev/sdb /mnt/data'
+#     # Overrides the disk sizes (in GiB) and types from the cloudProviderSpec
+#     # (or Terraform output). Additional data volumes are supported only on
+#     # Azure, which supports a single data disk.
+#     # disks:
+#     #   size: 100
+#     #   type: 'gp3'
 #     # cloudProviderSpec corresponds 'provider.name' config
 #     cloudProviderSpec:
 #       ### the following params could be inferred by kubeone from terraform
@@ -950,4 +1317,34 @@ machineController:
 loggingConfig:
   containerLogMaxSize: "{{ .ContainerLogMaxSize }}"
   containerLogMaxFiles: {{ .ContainerLogMaxFiles }}
+
+# Namespaces to be created and reconciled by KubeOne on every apply.
+# Configured labels and annotations are set on the namespace, while other
+# labels and annotations are kept intact. Namespaces removed from this list
+# are not deleted, KubeOne only warns about them.
+namespaces: []
+# - name: "team-a"
+#   labels:
+#     pod-security.kubernetes.io/enforce: "restricted"
+#   annotations:
+#     example.com/owner: "team-a"
+
+# Manage the host firewall on the control plane and static worker nodes.
+# KubeOne opens the ports required by the cluster (SSH, API server, etcd,
+# kubelet, NodePorts and CNI) and drops all other incoming traffic, except
+# the traffic matching the additional rules below. Supported backends are
+# "nftables" (default) and "firewalld".
+# firewall:
+#   backend: "nftables"
+#   rules:
+#   - ports: "9100"
+#     protocol: "tcp"
+#     sources:
+#     - "10.0.0.0/8"
+#     roles:
+#     - "staticWorker"
+#   # additional sources allowed to reach the kubelets, e.g. the subnet of
+#   # the worker nodes created by machine-controller
+#   kubeletSources:
+#   - "10.0.0.0/16"
 `
@@ -0,0 +1,299 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/tabwriter"
+)
+
+const (
+	defaultFleetConcurrency = 2
+	fleetTerraformExt       = ".tfjson"
+)
+
+type fleetApplyOpts struct {
+	applyOpts
+	Concurrency int `longflag:"concurrency"`
+}
+
+// fleetMember is a single cluster of the fleet
+type fleetMember struct {
+	ManifestFile   string
+	TerraformState string
+}
+
+// fleetResult is an outcome of reconciling a single fleet member
+type fleetResult struct {
+	Member      fleetMember
+	ClusterName string
+	Duration    time.Duration
+	Err         error
+}
+
+// fleetCmd setups the fleet command
+func fleetCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Commands for working with multiple clusters at once",
+	}
+
+	cmd.AddCommand(fleetApplyCmd(rootFlags))
+
+	return cmd
+}
+
+func fleetApplyCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &fleetApplyOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "apply <manifest|directory>...",
+		Short: "Reconcile multiple clusters",
+		Long: heredoc.Doc(`
+			Reconcile multiple clusters, the same way as 'kubeone apply' does for a single cluster.
+
+			Arguments are KubeOne manifests or directories containing KubeOne manifests (files with .yaml and .yml extensions,
+			subdirectories are not traversed). If a file with the same name as the manifest and the .tfjson extension exists
+			(e.g. mycluster.tfjson for mycluster.yaml), it's used as the Terraform output for that cluster.
+
+			Clusters are reconciled concurrently, up to '--concurrency' clusters at a time. Failure to reconcile one cluster
+			doesn't abort reconciliation of other clusters. Once all clusters are processed, a summary report is printed.
+
+			The plan is approved once for the whole fleet, per-cluster plans are approved automatically.
+		`),
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		Example:       `kubeone fleet apply -y --concurrency 4 ./clusters`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return err
+			}
+
+			opts.globalOptions = *gopts
+
+			return runFleetApply(opts, args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&opts.AutoApprove,
+		longFlagName(opts, "AutoApprove"),
+		shortFlagName(opts, "AutoApprove"),
+		false,
+		"auto approve plan")
+
+	cmd.Flags().IntVar(
+		&opts.Concurrency,
+		longFlagName(opts, "Concurrency"),
+		defaultFleetConcurrency,
+		"maximum number of clusters reconciled at the same time")
+
+	cmd.Flags().BoolVar(
+		&opts.ForceUpgrade,
+		longFlagName(opts, "ForceUpgrade"),
+		false,
+		"force start upgrade process")
+
+	cmd.Flags().BoolVar(
+		&opts.UpgradeMachineDeployments,
+		longFlagName(opts, "UpgradeMachineDeployments"),
+		false,
+		"upgrade MachineDeployments objects")
+
+	cmd.Flags().BoolVar(
+		&opts.CreateMachineDeployments,
+		longFlagName(opts, "CreateMachineDeployments"),
+		true,
+		"create MachineDeployments objects")
+
+	return cmd
+}
+
+func runFleetApply(opts *fleetApplyOpts, paths []string) error {
+	if opts.Concurrency < 1 {
+		return fail.ConfigValidation(errors.Errorf("--%s must be at least 1", longFlagName(opts, "Concurrency")))
+	}
+
+	members, err := fleetMembers(paths)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("The following clusters will be reconciled:")
+	for _, member := range members {
+		fmt.Printf("\t~ %s\n", member.ManifestFile)
+	}
+
+	fmt.Println()
+	confirm, err := confirmCommand(opts.AutoApprove)
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		fmt.Println("Operation canceled.")
+
+		return nil
+	}
+
+	results := make([]fleetResult, len(members))
+	sem := make(chan struct{}, opts.Concurrency)
+
+	var wg sync.WaitGroup
+	for i := range members {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = applyFleetMember(opts, members[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	return printFleetSummary(results)
+}
+
+func applyFleetMember(opts *fleetApplyOpts, member fleetMember) fleetResult {
+	result := fleetResult{Member: member}
+	start := time.Now()
+
+	memberOpts := opts.applyOpts
+	memberOpts.ManifestFile = member.ManifestFile
+	memberOpts.TerraformState = member.TerraformState
+	// the plan has already been approved for the whole fleet
+	memberOpts.AutoApprove = true
+
+	s, err := memberOpts.BuildState()
+	if err == nil {
+		result.ClusterName = s.Cluster.Name
+		s.Logger = s.Logger.WithField("cluster", s.Cluster.Name)
+		err = runApplyWithState(s, &memberOpts)
+	}
+
+	result.Duration = time.Since(start).Round(time.Second)
+	result.Err = err
+
+	return result
+}
+
+func printFleetSummary(results []fleetResult) error {
+	fmt.Println()
+	fmt.Println("Fleet summary:")
+
+	var failed int
+	tw := tabwriter.New(os.Stdout)
+	fmt.Fprintf(tw, "CLUSTER\tMANIFEST\tRESULT\tDURATION\tERROR\n")
+	for _, res := range results {
+		status, errMsg := "succeeded", ""
+		if res.Err != nil {
+			failed++
+			status, errMsg = "failed", res.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", res.ClusterName, res.Member.ManifestFile, status, res.Duration, errMsg)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fail.Runtime(err, "printing fleet summary")
+	}
+
+	if failed > 0 {
+		return fail.RuntimeError{
+			Op:  "applying fleet",
+			Err: errors.Errorf("%d out of %d clusters failed to reconcile", failed, len(results)),
+		}
+	}
+
+	return nil
+}
+
+// fleetMembers resolves given manifest files and directories to the list of
+// fleet members, sorted by the manifest path
+func fleetMembers(paths []string) ([]fleetMember, error) {
+	var manifests []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fail.Runtime(err, "checking fleet manifest path")
+		}
+
+		if !info.IsDir() {
+			manifests = append(manifests, path)
+
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fail.Runtime(err, "reading fleet manifests directory")
+		}
+
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+
+			manifests = append(manifests, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	if len(manifests) == 0 {
+		return nil, fail.ConfigValidation(errors.New("no KubeOne manifests found"))
+	}
+
+	sort.Strings(manifests)
+
+	members := []fleetMember{}
+	seen := map[string]bool{}
+	for _, manifest := range manifests {
+		if seen[manifest] {
+			continue
+		}
+		seen[manifest] = true
+
+		member := fleetMember{ManifestFile: manifest}
+
+		tfjson := strings.TrimSuffix(manifest, filepath.Ext(manifest)) + fleetTerraformExt
+		if _, err := os.Stat(tfjson); err == nil {
+			member.TerraformState = tfjson
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
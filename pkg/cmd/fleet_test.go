@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFleetMembers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"b.yaml", "b.tfjson", "a.yml", "README.md", "c.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0600); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "addons.yaml"), 0700); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		paths   []string
+		want    []fleetMember
+		wantErr bool
+	}{
+		{
+			name:  "directory",
+			paths: []string{dir},
+			want: []fleetMember{
+				{ManifestFile: filepath.Join(dir, "a.yml")},
+				{ManifestFile: filepath.Join(dir, "b.yaml"), TerraformState: filepath.Join(dir, "b.tfjson")},
+			},
+		},
+		{
+			name:  "explicit manifest and directory",
+			paths: []string{filepath.Join(dir, "c.json"), dir, filepath.Join(dir, "a.yml")},
+			want: []fleetMember{
+				{ManifestFile: filepath.Join(dir, "a.yml")},
+				{ManifestFile: filepath.Join(dir, "b.yaml"), TerraformState: filepath.Join(dir, "b.tfjson")},
+				{ManifestFile: filepath.Join(dir, "c.json")},
+			},
+		},
+		{
+			name:    "non-existing path",
+			paths:   []string{filepath.Join(dir, "missing.yaml")},
+			wantErr: true,
+		},
+		{
+			name:    "no manifests",
+			paths:   []string{filepath.Join(dir, "addons.yaml")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := fleetMembers(tt.paths)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fleetMembers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fleetMembers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8c.io/kubeone/pkg/addons"
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/tasks"
@@ -146,6 +147,11 @@ func runInstall(opts *installOpts) error {
 		return err
 	}
 
+	// Validate that params required by the addons are provided
+	if err = addons.ValidateRequiredParams(s); err != nil {
+		return err
+	}
+
 	if opts.NoInit {
 		return tasks.WithBinariesOnly(nil).Run(s)
 	}
@@ -23,11 +23,24 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/kubeconfig"
 )
 
+const (
+	kubeconfigEndpointExternal = "external"
+	kubeconfigEndpointInternal = "internal"
+)
+
+type kubeconfigOpts struct {
+	globalOptions
+	Endpoint       string `longflag:"endpoint"`
+	IncludeStandby bool   `longflag:"include-standby"`
+}
+
 // KubeconfigCommand returns the structure for declaring the "install" subcommand.
 func kubeconfigCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &kubeconfigOpts{}
 	cmd := &cobra.Command{
 		Use:   "kubeconfig",
 		Short: "Download the kubeconfig file from master",
@@ -45,25 +58,67 @@ func kubeconfigCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 				return err
 			}
 
-			return runKubeconfig(gopts)
+			opts.globalOptions = *gopts
+
+			return runKubeconfig(opts)
 		},
 	}
 
+	cmd.Flags().StringVar(
+		&opts.Endpoint,
+		longFlagName(opts, "Endpoint"),
+		kubeconfigEndpointExternal,
+		fmt.Sprintf("API endpoint used in the kubeconfig file. Possible values: %q (apiEndpoint.host) or %q (apiEndpoint.internalHost)",
+			kubeconfigEndpointExternal, kubeconfigEndpointInternal))
+
+	cmd.Flags().BoolVar(
+		&opts.IncludeStandby,
+		longFlagName(opts, "IncludeStandby"),
+		false,
+		"add a cluster and a context for each of the apiEndpoint.standbyEndpoints to the kubeconfig file")
+
 	return cmd
 }
 
 // runKubeconfig downloads kubeconfig file
-func runKubeconfig(opts *globalOptions) error {
+func runKubeconfig(opts *kubeconfigOpts) error {
+	if opts.Endpoint != kubeconfigEndpointExternal && opts.Endpoint != kubeconfigEndpointInternal {
+		return fail.ConfigValidation(fmt.Errorf("--endpoint must be either %q or %q", kubeconfigEndpointExternal, kubeconfigEndpointInternal))
+	}
+
 	s, err := opts.BuildState()
 	if err != nil {
 		return err
 	}
 
+	internal := opts.Endpoint == kubeconfigEndpointInternal
+	if internal && s.Cluster.APIEndpoint.InternalHost == "" {
+		return fail.ConfigValidation(fmt.Errorf("--endpoint=%s requires apiEndpoint.internalHost to be configured", kubeconfigEndpointInternal))
+	}
+
 	konfig, err := kubeconfig.Download(s)
 	if err != nil {
 		return err
 	}
 
+	if internal {
+		konfig, err = kubeconfig.SetServer(konfig, s.Cluster.APIEndpoint.ServerURL(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.IncludeStandby {
+		if len(s.Cluster.APIEndpoint.StandbyEndpoints) == 0 {
+			return fail.ConfigValidation(fmt.Errorf("--include-standby requires apiEndpoint.standbyEndpoints to be configured"))
+		}
+
+		konfig, err = kubeconfig.AddStandbyServers(konfig, s.Cluster.APIEndpoint.StandbyServerURLs())
+		if err != nil {
+			return err
+		}
+	}
+
 	fmt.Println(string(konfig))
 
 	return nil
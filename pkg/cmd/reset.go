@@ -33,6 +33,7 @@ import (
 type resetOpts struct {
 	globalOptions
 	AutoApprove    bool `longflag:"auto-approve" shortflag:"y"`
+	CordonNodes    bool `longflag:"cordon-nodes"`
 	DestroyWorkers bool `longflag:"destroy-workers"`
 	RemoveBinaries bool `longflag:"remove-binaries"`
 }
@@ -43,6 +44,7 @@ func (opts *resetOpts) BuildState() (*state.State, error) {
 		return nil, err
 	}
 
+	s.CordonNodes = opts.CordonNodes
 	s.DestroyWorkers = opts.DestroyWorkers
 	s.RemoveBinaries = opts.RemoveBinaries
 
@@ -82,6 +84,12 @@ func resetCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		false,
 		"auto approve reset")
 
+	cmd.Flags().BoolVar(
+		&opts.CordonNodes,
+		longFlagName(opts, "CordonNodes"),
+		true,
+		"cordon all nodes before resetting the cluster, disable for clusters with unreachable API")
+
 	cmd.Flags().BoolVar(
 		&opts.DestroyWorkers,
 		longFlagName(opts, "DestroyWorkers"),
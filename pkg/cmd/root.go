@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/terraform"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 
@@ -78,6 +79,9 @@ func newRoot() *cobra.Command {
 		Short:        "Kubernetes Cluster provisioning and maintaining tool",
 		Long:         "Provision and maintain Kubernetes High-Availability clusters with ease",
 		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return validateExternalKubeconfigFlags(cmd)
+		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
@@ -97,6 +101,16 @@ func newRoot() *cobra.Command {
 		"",
 		"Source for terraform output in JSON - to read from stdin. If path is a file, contents will be used. If path is a dictionary, `terraform output -json` is executed in this path")
 
+	fs.IntVar(&opts.TerraformRetries,
+		longFlagName(opts, "TerraformRetries"),
+		terraform.DefaultRetries,
+		"Number of retries of the failed `terraform output -json` invocation, used if --tfjson is a directory")
+
+	fs.DurationVar(&opts.TerraformTimeout,
+		longFlagName(opts, "TerraformTimeout"),
+		terraform.DefaultTimeout,
+		"Timeout of a single `terraform output -json` invocation, used if --tfjson is a directory (0 means no timeout)")
+
 	fs.StringVarP(&opts.CredentialsFile,
 		longFlagName(opts, "CredentialsFile"),
 		shortFlagName(opts, "CredentialsFile"),
@@ -121,18 +135,31 @@ func newRoot() *cobra.Command {
 		"text",
 		"format for logging")
 
+	fs.StringVar(&opts.Kubeconfig,
+		longFlagName(opts, "Kubeconfig"),
+		"",
+		"Path to the kubeconfig file used instead of the admin kubeconfig fetched over SSH. "+
+			"Supported only by the commands not bootstrapping the cluster (addons, config dump, status, test)")
+
+	fs.StringVar(&opts.KubeContext,
+		longFlagName(opts, "KubeContext"),
+		"",
+		"The kubeconfig context to use instead of the current one. Supported by the same commands as --kubeconfig")
+
 	rootCmd.AddCommand(
 		applyCmd(fs),
 		addonsCmd(fs),
 		completionCmd(rootCmd),
 		configCmd(fs),
 		documentCmd(rootCmd),
+		fleetCmd(fs),
 		installCmd(fs),
 		kubeconfigCmd(fs),
 		migrateCmd(fs),
 		proxyCmd(fs),
 		resetCmd(fs),
 		statusCmd(fs),
+		testCmd(fs),
 		upgradeCmd(fs),
 		versionCmd(),
 	)
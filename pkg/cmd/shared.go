@@ -23,8 +23,10 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/term"
 
@@ -34,17 +36,37 @@ import (
 	"k8c.io/kubeone/pkg/credentials"
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/terraform"
 )
 
-const yes = "yes"
+const (
+	yes = "yes"
+
+	// externalKubeconfigAnnotation marks commands supporting the --kubeconfig
+	// and --context flags
+	externalKubeconfigAnnotation = "kubeone.io/external-kubeconfig"
+)
 
 type globalOptions struct {
-	ManifestFile    string `longflag:"manifest" shortflag:"m"`
-	TerraformState  string `longflag:"tfjson" shortflag:"t"`
-	CredentialsFile string `longflag:"credentials" shortflag:"c"`
-	Verbose         bool   `longflag:"verbose" shortflag:"v"`
-	Debug           bool   `longflag:"debug" shortflag:"d"`
-	LogFormat       string `longflag:"log-format" shortflag:"l"`
+	ManifestFile     string        `longflag:"manifest" shortflag:"m"`
+	TerraformState   string        `longflag:"tfjson" shortflag:"t"`
+	TerraformRetries int           `longflag:"tf-retries"`
+	TerraformTimeout time.Duration `longflag:"tf-timeout"`
+	CredentialsFile  string        `longflag:"credentials" shortflag:"c"`
+	Verbose          bool          `longflag:"verbose" shortflag:"v"`
+	Debug            bool          `longflag:"debug" shortflag:"d"`
+	LogFormat        string        `longflag:"log-format" shortflag:"l"`
+	Kubeconfig       string        `longflag:"kubeconfig"`
+	KubeContext      string        `longflag:"context"`
+}
+
+// TerraformOptions returns options used to invoke terraform
+func (opts *globalOptions) TerraformOptions() terraform.Options {
+	tfOpts := terraform.DefaultOptions()
+	tfOpts.Retries = opts.TerraformRetries
+	tfOpts.Timeout = opts.TerraformTimeout
+
+	return tfOpts
 }
 
 func (opts *globalOptions) BuildState() (*state.State, error) {
@@ -56,7 +78,7 @@ func (opts *globalOptions) BuildState() (*state.State, error) {
 
 	s.Logger = newLogger(opts.Verbose, opts.LogFormat)
 
-	cluster, err := loadClusterConfig(opts.ManifestFile, opts.TerraformState, opts.CredentialsFile, s.Logger)
+	cluster, err := loadClusterConfig(opts.ManifestFile, opts.TerraformState, opts.CredentialsFile, opts.TerraformOptions(), s.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +87,8 @@ func (opts *globalOptions) BuildState() (*state.State, error) {
 	s.ManifestFilePath = opts.ManifestFile
 	s.CredentialsFilePath = opts.CredentialsFile
 	s.Verbose = opts.Verbose
+	s.KubeconfigPath = opts.Kubeconfig
+	s.KubeContext = opts.KubeContext
 
 	// Validate Addons path if provided
 	if s.Cluster.Addons.Enabled() {
@@ -128,6 +152,18 @@ func persistentGlobalOptions(fs *pflag.FlagSet) (*globalOptions, error) {
 	}
 	gf.TerraformState = tfjson
 
+	tfRetries, err := fs.GetInt(longFlagName(gf, "TerraformRetries"))
+	if err != nil {
+		return nil, fail.Runtime(err, "getting global flags")
+	}
+	gf.TerraformRetries = tfRetries
+
+	tfTimeout, err := fs.GetDuration(longFlagName(gf, "TerraformTimeout"))
+	if err != nil {
+		return nil, fail.Runtime(err, "getting global flags")
+	}
+	gf.TerraformTimeout = tfTimeout
+
 	creds, err := fs.GetString(longFlagName(gf, "CredentialsFile"))
 	if err != nil {
 		return nil, fail.Runtime(err, "getting global flags")
@@ -140,9 +176,40 @@ func persistentGlobalOptions(fs *pflag.FlagSet) (*globalOptions, error) {
 	}
 	gf.LogFormat = logFormat
 
+	kubeconfigPath, err := fs.GetString(longFlagName(gf, "Kubeconfig"))
+	if err != nil {
+		return nil, fail.Runtime(err, "getting global flags")
+	}
+	gf.Kubeconfig = kubeconfigPath
+
+	kubeContext, err := fs.GetString(longFlagName(gf, "KubeContext"))
+	if err != nil {
+		return nil, fail.Runtime(err, "getting global flags")
+	}
+	gf.KubeContext = kubeContext
+
 	return gf, nil
 }
 
+// validateExternalKubeconfigFlags makes sure that the --kubeconfig and
+// --context flags are used only with commands that can work with the
+// provided kubeconfig instead of the admin kubeconfig fetched over SSH
+func validateExternalKubeconfigFlags(cmd *cobra.Command) error {
+	gf := &globalOptions{}
+	fs := cmd.Flags()
+
+	if !fs.Changed(longFlagName(gf, "Kubeconfig")) && !fs.Changed(longFlagName(gf, "KubeContext")) {
+		return nil
+	}
+
+	if _, ok := cmd.Annotations[externalKubeconfigAnnotation]; ok {
+		return nil
+	}
+
+	return fail.ConfigValidation(fmt.Errorf("the %q command requires the admin kubeconfig fetched over SSH and doesn't support the --%s and --%s flags",
+		cmd.CommandPath(), longFlagName(gf, "Kubeconfig"), longFlagName(gf, "KubeContext")))
+}
+
 func newLogger(verbose bool, format string) *logrus.Logger {
 	logger := logrus.New()
 
@@ -163,8 +230,8 @@ func newLogger(verbose bool, format string) *logrus.Logger {
 	return logger
 }
 
-func loadClusterConfig(filename, terraformOutputPath, credentialsFilePath string, logger logrus.FieldLogger) (*kubeoneapi.KubeOneCluster, error) {
-	cls, err := config.LoadKubeOneCluster(filename, terraformOutputPath, credentialsFilePath, logger)
+func loadClusterConfig(filename, terraformOutputPath, credentialsFilePath string, tfOpts terraform.Options, logger logrus.FieldLogger) (*kubeoneapi.KubeOneCluster, error) {
+	cls, err := config.LoadKubeOneCluster(filename, terraformOutputPath, credentialsFilePath, tfOpts, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestValidateExternalKubeconfigFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name: "no flags",
+			args: []string{"apply"},
+		},
+		{
+			name: "kubeconfig with status",
+			args: []string{"status", "--kubeconfig", "./kubeconfig"},
+		},
+		{
+			name: "context with addons list",
+			args: []string{"addons", "list", "--context", "staging"},
+		},
+		{
+			name:    "kubeconfig with apply",
+			args:    []string{"apply", "--kubeconfig", "./kubeconfig"},
+			wantErr: true,
+		},
+		{
+			name:    "context with reset",
+			args:    []string{"reset", "--context", "staging"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRoot()
+
+			cmd, args, err := root.Find(tt.args)
+			if err != nil {
+				t.Fatalf("finding command: %v", err)
+			}
+
+			if err = cmd.ParseFlags(args); err != nil {
+				t.Fatalf("parsing flags: %v", err)
+			}
+
+			err = validateExternalKubeconfigFlags(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateExternalKubeconfigFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -37,6 +37,9 @@ func statusCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		`),
 		Example:       `kubeone status -m mycluster.yaml -t terraformoutput.json`,
 		SilenceErrors: true,
+		Annotations: map[string]string{
+			externalKubeconfigAnnotation: "",
+		},
 		RunE: func(_ *cobra.Command, _ []string) error {
 			gopts, err := persistentGlobalOptions(rootFlags)
 			if err != nil {
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/smoketest"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type testOpts struct {
+	globalOptions
+	Timeout time.Duration `longflag:"timeout"`
+}
+
+// testCmd returns the structure for declaring the "test" subcommand.
+func testCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &testOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run smoke tests against the cluster",
+		Long: heredoc.Doc(`
+			Run quick smoke tests against the provisioned cluster.
+
+			The following checks are run:
+			  * a test pod can be scheduled and started
+			  * pods can reach each other over the pod network (CNI)
+			  * cluster DNS (CoreDNS) resolves Service names
+			  * a PersistentVolumeClaim using the default StorageClass gets bound (skipped if there's no default StorageClass)
+			  * a LoadBalancer Service gets provisioned (skipped for providers without LoadBalancer support)
+
+			All objects are created in a temporary namespace which is removed once the checks are done. This is not a
+			replacement for conformance tests, but a fast go/no-go after provisioning the cluster.
+
+			This command takes KubeOne manifest which contains information about hosts. It's possible to source information about
+			hosts from Terraform output, using the '--tfjson' flag.
+		`),
+		Example:       `kubeone test -m mycluster.yaml -t terraformoutput.json`,
+		SilenceErrors: true,
+		Annotations: map[string]string{
+			externalKubeconfigAnnotation: "",
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return err
+			}
+
+			opts.globalOptions = *gopts
+
+			return runTest(opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(
+		&opts.Timeout,
+		longFlagName(opts, "Timeout"),
+		smoketest.DefaultTimeout,
+		"time given to each check to complete")
+
+	return cmd
+}
+
+// runTest runs smoke tests against the cluster
+func runTest(opts *testOpts) error {
+	s, err := opts.BuildState()
+	if err != nil {
+		return err
+	}
+
+	return tasks.WithSmokeTests(nil, opts.Timeout).Run(s)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8c.io/kubeone/pkg/addons"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/tasks"
 )
@@ -98,6 +99,11 @@ func runUpgrade(opts *upgradeOpts) error {
 		return err
 	}
 
+	// Validate that params required by the addons are provided
+	if err = addons.ValidateRequiredParams(s); err != nil {
+		return err
+	}
+
 	// Probe the cluster for the actual state and the needed tasks.
 	probbing := tasks.WithHostnameOS(nil)
 	probbing = tasks.WithProbes(probbing)
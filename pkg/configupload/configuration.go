@@ -51,24 +51,35 @@ func (c *Configuration) AddFile(filename string, content string) {
 
 // AddFilePath saves file contents from a file on filesystem for future references
 func (c *Configuration) AddFilePath(filename, filePath, manifestFilePath string) error {
+	b, err := ReadFile(filePath, manifestFilePath)
+	if err != nil {
+		return err
+	}
+
+	c.AddFile(filename, string(b))
+
+	return nil
+}
+
+// ReadFile reads the file on filesystem, relative paths are relative to the
+// KubeOne configuration file
+func ReadFile(filePath, manifestFilePath string) ([]byte, error) {
 	// Normalize the file path. In the case when the relative path is provided,
 	// the path is relative to the KubeOne configuration file.
 	if !filepath.IsAbs(filePath) && manifestFilePath != "" {
 		manifestAbsPath, err := filepath.Abs(filepath.Dir(manifestFilePath))
 		if err != nil {
-			return fail.Runtime(err, "getting absolut path to the manifest file")
+			return nil, fail.Runtime(err, "getting absolut path to the manifest file")
 		}
 		filePath = filepath.Join(manifestAbsPath, filePath)
 	}
 
 	b, err := os.ReadFile(filePath)
 	if err != nil {
-		return fail.Runtime(err, "reading file")
+		return nil, fail.Runtime(err, "reading file")
 	}
 
-	c.AddFile(filename, string(b))
-
-	return nil
+	return b, nil
 }
 
 // UploadTo directory all the files
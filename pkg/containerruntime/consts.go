@@ -19,4 +19,10 @@ package containerruntime
 const (
 	DefaultContainerLogMaxFiles = 5
 	DefaultContainerLogMaxSize  = "100Mi"
+
+	DockerLogDriverJSONFile = "json-file"
+	DockerLogDriverLocal    = "local"
+
+	// RuncRuntimeType is the containerd shim of the runc-compatible runtimes
+	RuncRuntimeType = "io.containerd.runc.v2"
 )
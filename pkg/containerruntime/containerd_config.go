@@ -18,6 +18,7 @@ package containerruntime
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -37,8 +38,11 @@ type containerdMetrics struct {
 }
 
 type containerdCRIPlugin struct {
-	Containerd *containerdCRISettings `toml:"containerd"`
-	Registry   *containerdCRIRegistry `toml:"registry"`
+	StreamServerAddress string                 `toml:"stream_server_address,omitempty"`
+	StreamServerPort    string                 `toml:"stream_server_port,omitempty"`
+	StreamIdleTimeout   string                 `toml:"stream_idle_timeout,omitempty"`
+	Containerd          *containerdCRISettings `toml:"containerd"`
+	Registry            *containerdCRIRegistry `toml:"registry"`
 }
 
 type containerdCRISettings struct {
@@ -52,6 +56,7 @@ type containerdCRIRuntime struct {
 
 type containerdCRIRuncOptions struct {
 	SystemdCgroup bool
+	BinaryName    string `toml:",omitempty"`
 }
 
 type containerdCRIRegistry struct {
@@ -80,11 +85,15 @@ type containerdRegistryTLSConfig struct {
 }
 
 func marshalContainerdConfig(cluster *kubeoneapi.KubeOneCluster) (string, error) {
+	containerd := cluster.ContainerRuntime.Containerd
+
 	criPlugin := containerdCRIPlugin{
+		StreamServerAddress: containerd.StreamServerAddress,
+		StreamIdleTimeout:   containerd.StreamIdleTimeout,
 		Containerd: &containerdCRISettings{
 			Runtimes: map[string]containerdCRIRuntime{
 				"runc": {
-					RuntimeType: "io.containerd.runc.v2",
+					RuntimeType: RuncRuntimeType,
 					Options: containerdCRIRuncOptions{
 						SystemdCgroup: true,
 					},
@@ -100,6 +109,25 @@ func marshalContainerdConfig(cluster *kubeoneapi.KubeOneCluster) (string, error)
 		},
 	}
 
+	for name, runtime := range containerd.Runtimes {
+		criRuntime := containerdCRIRuntime{
+			RuntimeType: runtime.RuntimeType,
+		}
+
+		if runtime.RuntimeType == RuncRuntimeType {
+			criRuntime.Options = containerdCRIRuncOptions{
+				SystemdCgroup: true,
+				BinaryName:    runtime.BinaryName,
+			}
+		}
+
+		criPlugin.Containerd.Runtimes[name] = criRuntime
+	}
+
+	if containerd.StreamServerPort != 0 {
+		criPlugin.StreamServerPort = strconv.Itoa(containerd.StreamServerPort)
+	}
+
 	if cluster.RegistryConfiguration != nil {
 		insecureRegistry := cluster.RegistryConfiguration.InsecureRegistryAddress()
 		if insecureRegistry != "" {
@@ -109,7 +137,7 @@ func marshalContainerdConfig(cluster *kubeoneapi.KubeOneCluster) (string, error)
 		}
 	}
 
-	if regs := cluster.ContainerRuntime.Containerd.Registries; regs != nil {
+	if regs := containerd.Registries; regs != nil {
 		criPlugin.Registry = &containerdCRIRegistry{
 			Mirrors: map[string]containerdRegistryMirror{},
 			Configs: map[string]containerdRegistryConfig{},
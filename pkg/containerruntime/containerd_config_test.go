@@ -66,6 +66,28 @@ func Test_marshalContainerdConfig(t *testing.T) {
 				},
 			})),
 		},
+		{
+			name: "cri settings",
+			cluster: genCluster(withContainerdCRISettings(kubeoneapi.ContainerRuntimeContainerd{
+				StreamServerAddress: "127.0.0.1",
+				StreamServerPort:    10010,
+				StreamIdleTimeout:   "4h",
+			})),
+		},
+		{
+			name: "additional runtimes",
+			cluster: genCluster(withContainerdCRISettings(kubeoneapi.ContainerRuntimeContainerd{
+				Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+					"gvisor": {
+						RuntimeType: "io.containerd.runsc.v1",
+					},
+					"crun": {
+						RuntimeType: "io.containerd.runc.v2",
+						BinaryName:  "/usr/local/bin/crun",
+					},
+				},
+			})),
+		},
 	}
 
 	for _, tt := range tests {
@@ -109,3 +131,9 @@ func withContainerdRegistry(regCfg map[string]kubeoneapi.ContainerdRegistry) clu
 		cls.ContainerRuntime.Containerd.Registries = regCfg
 	}
 }
+
+func withContainerdCRISettings(containerd kubeoneapi.ContainerRuntimeContainerd) clusterOpts {
+	return func(cls *kubeoneapi.KubeOneCluster) {
+		cls.ContainerRuntime.Containerd = &containerd
+	}
+}
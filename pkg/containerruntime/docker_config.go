@@ -35,20 +35,34 @@ type dockerConfig struct {
 }
 
 func marshalDockerConfig(cluster *kubeoneapi.KubeOneCluster) (string, error) {
-	// Parse log max size to ensure that it has the correct units
-	logSize := strings.ToLower(cluster.LoggingConfig.ContainerLogMaxSize)
-	logSize = strings.ReplaceAll(logSize, "ki", "k")
-	logSize = strings.ReplaceAll(logSize, "mi", "m")
-	logSize = strings.ReplaceAll(logSize, "gi", "g")
+	logDriver := DockerLogDriverJSONFile
+	if cluster.ContainerRuntime.Docker != nil && cluster.ContainerRuntime.Docker.LogDriver != "" {
+		logDriver = cluster.ContainerRuntime.Docker.LogDriver
+	}
+
+	logOpts := map[string]string{}
+
+	// max-size and max-file options are supported only by the log drivers
+	// doing the log rotation on their own
+	if DockerLogDriverSupportsRotation(logDriver) {
+		logOpts["max-size"] = DockerLogSize(cluster.LoggingConfig.ContainerLogMaxSize)
+		logOpts["max-file"] = strconv.Itoa(int(cluster.LoggingConfig.ContainerLogMaxFiles))
+	}
+
+	if cluster.ContainerRuntime.Docker != nil {
+		for k, v := range cluster.ContainerRuntime.Docker.LogOpts {
+			if k == "max-size" {
+				v = DockerLogSize(v)
+			}
+			logOpts[k] = v
+		}
+	}
 
 	cfg := dockerConfig{
 		ExecOpts:      []string{"native.cgroupdriver=systemd"},
 		StorageDriver: "overlay2",
-		LogDriver:     "json-file",
-		LogOpts: map[string]string{
-			"max-size": logSize,
-			"max-file": strconv.Itoa(int(cluster.LoggingConfig.ContainerLogMaxFiles)),
-		},
+		LogDriver:     logDriver,
+		LogOpts:       logOpts,
 	}
 
 	insecureRegistry := cluster.RegistryConfiguration.InsecureRegistryAddress()
@@ -60,3 +74,20 @@ func marshalDockerConfig(cluster *kubeoneapi.KubeOneCluster) (string, error) {
 
 	return string(b), fail.Runtime(err, "encoding docker config")
 }
+
+// DockerLogSize converts Kubernetes-style size units (e.g. 100Mi) to the units
+// understood by the docker log drivers (e.g. 100m)
+func DockerLogSize(size string) string {
+	size = strings.ToLower(size)
+	size = strings.ReplaceAll(size, "ki", "k")
+	size = strings.ReplaceAll(size, "mi", "m")
+	size = strings.ReplaceAll(size, "gi", "g")
+
+	return size
+}
+
+// DockerLogDriverSupportsRotation returns true if given docker log driver
+// supports the "max-size" and "max-file" log options
+func DockerLogDriverSupportsRotation(logDriver string) bool {
+	return logDriver == DockerLogDriverJSONFile || logDriver == DockerLogDriverLocal
+}
@@ -27,11 +27,12 @@ func Test_marshalDockerConfig(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name             string
-		cluster          *kubeoneapi.KubeOneCluster
-		want             string
-		expectedMaxSize  string
-		expectedMaxFiles string
+		name              string
+		cluster           *kubeoneapi.KubeOneCluster
+		want              string
+		expectedLogDriver string
+		expectedMaxSize   string
+		expectedMaxFiles  string
 	}{
 		{
 			name:             "Should be convert 100Mi to 100m",
@@ -57,6 +58,28 @@ func Test_marshalDockerConfig(t *testing.T) {
 			expectedMaxSize:  "100m",
 			expectedMaxFiles: "10",
 		},
+		{
+			name: "Should override max-file and max-size with docker log options",
+			cluster: genCluster(
+				withContainerLogMaxSize("100Mi"),
+				withContainerLogMaxFiles(10),
+				withDockerLogConfig("", map[string]string{"max-size": "50Mi", "max-file": "3"}),
+			),
+			expectedLogDriver: "json-file",
+			expectedMaxSize:   "50m",
+			expectedMaxFiles:  "3",
+		},
+		{
+			name: "Should not set max-file and max-size for journald log driver",
+			cluster: genCluster(
+				withContainerLogMaxSize("100Mi"),
+				withContainerLogMaxFiles(10),
+				withDockerLogConfig("journald", nil),
+			),
+			expectedLogDriver: "journald",
+			expectedMaxSize:   "",
+			expectedMaxFiles:  "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -72,6 +95,10 @@ func Test_marshalDockerConfig(t *testing.T) {
 			if err != nil {
 				t.Errorf("marshalDockerConfig() error = %v,", err)
 			}
+			if tt.expectedLogDriver != "" && cfg.LogDriver != tt.expectedLogDriver {
+				t.Errorf("marshalDockerConfig() got = %v, want %v", got, tt.expectedLogDriver)
+			}
+
 			maxSize := cfg.LogOpts["max-size"]
 			if maxSize != tt.expectedMaxSize {
 				t.Errorf("marshalDockerConfig() got = %v, want %v", got, tt.expectedMaxSize)
@@ -96,3 +123,14 @@ func withContainerLogMaxFiles(logFiles int32) clusterOpts {
 		cls.LoggingConfig.ContainerLogMaxFiles = logFiles
 	}
 }
+
+func withDockerLogConfig(logDriver string, logOpts map[string]string) clusterOpts {
+	return func(cls *kubeoneapi.KubeOneCluster) {
+		cls.ContainerRuntime = kubeoneapi.ContainerRuntimeConfig{
+			Docker: &kubeoneapi.ContainerRuntimeDocker{
+				LogDriver: logDriver,
+				LogOpts:   logOpts,
+			},
+		}
+	}
+}
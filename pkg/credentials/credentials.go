@@ -85,6 +85,8 @@ const (
 	VMwareCloudDirectorURL          = "VCD_URL"
 	VMwareCloudDirectorVDC          = "VCD_VDC"
 	VMwareCloudDirectorSkipTLS      = "VCD_ALLOW_UNVERIFIED_SSL"
+	// etcd backups credentials
+	ResticPassword = "RESTIC_PASSWORD" //nolint:gosec
 
 	// Variables that machine-controller expects
 	AzureClientIDMC           = "AZURE_CLIENT_ID"
@@ -140,6 +142,7 @@ var (
 		VMwareCloudDirectorURL,
 		VMwareCloudDirectorVDC,
 		VMwareCloudDirectorSkipTLS,
+		ResticPassword,
 	}
 )
 
@@ -44,6 +44,14 @@ func Activate(s *state.State) error {
 		return err
 	}
 
+	if err := installPodTolerationRestriction(s.Context, s.DynamicClient, s.Cluster.Features.PodTolerationRestriction); err != nil {
+		return err
+	}
+
+	if err := installSystemAddonsPDBs(s.Cluster.Features.PodDisruptionBudgets, s); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -55,5 +63,8 @@ func UpdateKubeadmClusterConfiguration(featuresCfg kubeoneapi.Features, args *ku
 	activateKubeadmDynamicAuditLogs(featuresCfg.DynamicAuditLog, args)
 	activateKubeadmOIDC(featuresCfg.OpenIDConnect, args)
 	activateKubeadmPodNodeSelector(featuresCfg.PodNodeSelector, args)
+	activateKubeadmPodTolerationRestriction(featuresCfg.PodTolerationRestriction, args)
 	activateEncryptionProviders(featuresCfg.EncryptionProviders, args)
+	activateKubeadmStaticAuth(featuresCfg.StaticAuth, args)
+	activateKubeadmEtcdMetrics(featuresCfg.EtcdMetrics, args)
 }
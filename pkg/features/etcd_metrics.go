@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"fmt"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
+)
+
+const (
+	etcdListenMetricsURLsFlag = "listen-metrics-urls"
+
+	// EtcdLocalMetricsURL is the kubeadm default metrics URL. kubeadm uses
+	// the first listen-metrics-urls entry for the etcd liveness and startup
+	// probes, so it must stay first and plain HTTP.
+	EtcdLocalMetricsURL = "http://127.0.0.1:2381"
+)
+
+func activateKubeadmEtcdMetrics(feature *kubeoneapi.EtcdMetrics, args *kubeadmargs.Args) {
+	if !feature.Enabled() {
+		return
+	}
+
+	// metrics served over HTTPS use the etcd client TLS configuration,
+	// including the client certificate authentication
+	args.Etcd.ExtraArgs[etcdListenMetricsURLsFlag] = fmt.Sprintf("%s,https://0.0.0.0:%d", EtcdLocalMetricsURL, feature.Port)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"github.com/Masterminds/semver/v3"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clientutil"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pdbComponentLabel = "system-addons-pdb"
+)
+
+// pdbDeployments are the multi-replica system addons deployments not
+// shipping their own PodDisruptionBudget
+var pdbDeployments = []string{
+	"coredns",
+}
+
+func installSystemAddonsPDBs(pdbs *kubeoneapi.PodDisruptionBudgets, s *state.State) error {
+	if !pdbs.Enabled() {
+		return nil
+	}
+
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	kubeVer, err := semver.NewVersion(s.Cluster.Versions.Kubernetes)
+	if err != nil {
+		return fail.Config(err, "parsing kubernetes version")
+	}
+
+	for _, name := range pdbDeployments {
+		dep := appsv1.Deployment{}
+		key := client.ObjectKey{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+		}
+
+		if err := s.DynamicClient.Get(s.Context, key, &dep); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return fail.KubeClient(err, "getting %T %s", dep, key)
+		}
+
+		replicas := int32(1)
+		if dep.Spec.Replicas != nil {
+			replicas = *dep.Spec.Replicas
+		}
+
+		minAvailable, maxUnavailable, err := pdbDisruptionsFor(pdbs.MinAvailable, replicas)
+		if err != nil {
+			return fail.Config(err, "calculating PodDisruptionBudget minAvailable")
+		}
+
+		pdb := systemAddonPDB(name, dep.Spec.Selector, minAvailable, maxUnavailable, kubeVer)
+		if err := clientutil.CreateOrReplace(s.Context, s.DynamicClient, pdb, clientutil.WithComponentLabel(pdbComponentLabel)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pdbDisruptionsFor caps minAvailable to replicas-1, so the PodDisruptionBudget
+// always allows evicting at least one pod. Percentages are kept as long as they
+// round up below the number of replicas, otherwise they are replaced with the
+// capped number. Single-replica deployments get maxUnavailable: 1 instead,
+// which never blocks node drains.
+func pdbDisruptionsFor(minAvailable intstr.IntOrString, replicas int32) (*intstr.IntOrString, *intstr.IntOrString, error) {
+	if replicas <= 1 {
+		maxUnavailable := intstr.FromInt(1)
+
+		return nil, &maxUnavailable, nil
+	}
+
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(&minAvailable, int(replicas), true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if scaled > int(replicas)-1 {
+		minAvailable = intstr.FromInt(int(replicas) - 1)
+	}
+
+	return &minAvailable, nil, nil
+}
+
+func systemAddonPDB(name string, selector *metav1.LabelSelector, minAvailable, maxUnavailable *intstr.IntOrString, kubeVer *semver.Version) client.Object {
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: metav1.NamespaceSystem,
+	}
+
+	// policy/v1 is available since Kubernetes 1.21
+	if kubeVer.Minor() < 21 {
+		return &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: meta,
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MinAvailable:   minAvailable,
+				MaxUnavailable: maxUnavailable,
+				Selector:       selector,
+			},
+		}
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: meta,
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+			Selector:       selector,
+		},
+	}
+}
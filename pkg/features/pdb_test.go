@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func intstrPtr(val intstr.IntOrString) *intstr.IntOrString {
+	return &val
+}
+
+func Test_pdbDisruptionsFor(t *testing.T) {
+	type args struct {
+		minAvailable intstr.IntOrString
+		replicas     int32
+	}
+	tests := []struct {
+		name               string
+		args               args
+		wantMinAvailable   *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+		wantErr            bool
+	}{
+		{
+			name: "minAvailable below replicas",
+			args: args{
+				minAvailable: intstr.FromInt(1),
+				replicas:     3,
+			},
+			wantMinAvailable: intstrPtr(intstr.FromInt(1)),
+		},
+		{
+			name: "minAvailable capped to replicas minus one",
+			args: args{
+				minAvailable: intstr.FromInt(5),
+				replicas:     3,
+			},
+			wantMinAvailable: intstrPtr(intstr.FromInt(2)),
+		},
+		{
+			name: "single replica gets maxUnavailable",
+			args: args{
+				minAvailable: intstr.FromInt(1),
+				replicas:     1,
+			},
+			wantMaxUnavailable: intstrPtr(intstr.FromInt(1)),
+		},
+		{
+			name: "single replica with percentage gets maxUnavailable",
+			args: args{
+				minAvailable: intstr.FromString("50%"),
+				replicas:     1,
+			},
+			wantMaxUnavailable: intstrPtr(intstr.FromInt(1)),
+		},
+		{
+			name: "percentage below replicas",
+			args: args{
+				minAvailable: intstr.FromString("50%"),
+				replicas:     4,
+			},
+			wantMinAvailable: intstrPtr(intstr.FromString("50%")),
+		},
+		{
+			name: "percentage rounding up to all replicas is capped",
+			args: args{
+				minAvailable: intstr.FromString("60%"),
+				replicas:     2,
+			},
+			wantMinAvailable: intstrPtr(intstr.FromInt(1)),
+		},
+		{
+			name: "full percentage is capped",
+			args: args{
+				minAvailable: intstr.FromString("100%"),
+				replicas:     3,
+			},
+			wantMinAvailable: intstrPtr(intstr.FromInt(2)),
+		},
+		{
+			name: "malformed percentage",
+			args: args{
+				minAvailable: intstr.FromString("half"),
+				replicas:     3,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			gotMinAvailable, gotMaxUnavailable, err := pdbDisruptionsFor(tt.args.minAvailable, tt.args.replicas)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pdbDisruptionsFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(gotMinAvailable, tt.wantMinAvailable) {
+				t.Errorf("pdbDisruptionsFor() minAvailable = %v, want %v", gotMinAvailable, tt.wantMinAvailable)
+			}
+			if !reflect.DeepEqual(gotMaxUnavailable, tt.wantMaxUnavailable) {
+				t.Errorf("pdbDisruptionsFor() maxUnavailable = %v, want %v", gotMaxUnavailable, tt.wantMaxUnavailable)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"encoding/json"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	podTolerationRestrictionAdmissionPlugin = "PodTolerationRestriction"
+	defaultTolerationsAnnotation            = "scheduler.alpha.kubernetes.io/defaultTolerations"
+	tolerationsWhitelistAnnotation          = "scheduler.alpha.kubernetes.io/tolerationsWhitelist"
+)
+
+func activateKubeadmPodTolerationRestriction(feature *kubeoneapi.PodTolerationRestriction, args *kubeadmargs.Args) {
+	if !feature.Enabled() {
+		return
+	}
+
+	args.APIServer.AppendMapStringStringExtraArg(apiServerAdmissionPluginsFlag, podTolerationRestrictionAdmissionPlugin)
+	args.APIServer.ExtraArgs[apiServerAdmissionControlConfigFlag] = apiServerAdmissionControlConfigPath
+}
+
+// installPodTolerationRestriction reconciles the default and whitelisted
+// tolerations of the configured namespaces
+func installPodTolerationRestriction(ctx context.Context, c client.Client, feature *kubeoneapi.PodTolerationRestriction) error {
+	if !feature.Enabled() {
+		return nil
+	}
+
+	for _, nsTolerations := range feature.Namespaces {
+		if err := annotateNamespaceTolerations(ctx, c, nsTolerations); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// annotateNamespaceTolerations sets the default and whitelisted tolerations
+// annotations on the namespace, creating the namespace if it doesn't exist.
+// Annotations for empty lists are removed, so the cluster-wide tolerations
+// are used instead.
+func annotateNamespaceTolerations(ctx context.Context, c client.Client, nsTolerations kubeoneapi.NamespaceTolerations) error {
+	ns := corev1.Namespace{}
+	key := client.ObjectKey{
+		Name: nsTolerations.Name,
+	}
+
+	err := c.Get(ctx, key, &ns)
+	notFound := k8serrors.IsNotFound(err)
+	switch {
+	case notFound:
+		ns = corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nsTolerations.Name,
+			},
+		}
+	case err != nil:
+		return fail.KubeClient(err, "getting %T %s", ns, key)
+	}
+
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+
+	if err := setTolerationsAnnotation(ns.Annotations, defaultTolerationsAnnotation, nsTolerations.DefaultTolerations); err != nil {
+		return err
+	}
+	if err := setTolerationsAnnotation(ns.Annotations, tolerationsWhitelistAnnotation, nsTolerations.Whitelist); err != nil {
+		return err
+	}
+
+	// Update is used instead of clientutil.CreateOrUpdate, because merging
+	// with the existing object would restore the removed annotations
+	if notFound {
+		return fail.KubeClient(c.Create(ctx, &ns), "creating %T %s", ns, key)
+	}
+
+	return fail.KubeClient(c.Update(ctx, &ns), "updating %T %s", ns, key)
+}
+
+func setTolerationsAnnotation(annotations map[string]string, key string, tolerations []corev1.Toleration) error {
+	if len(tolerations) == 0 {
+		delete(annotations, key)
+
+		return nil
+	}
+
+	buf, err := json.Marshal(tolerations)
+	if err != nil {
+		return fail.Runtime(err, "marshalling %s annotation", key)
+	}
+	annotations[key] = string(buf)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"strconv"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
+)
+
+const (
+	clientCAFileFlag = "client-ca-file"
+	profilingFlag    = "profiling"
+
+	// ClientCABundlePath is a path on control plane nodes to the bundle
+	// containing the cluster CA certificate and additional client CA
+	// certificates provided by the user
+	ClientCABundlePath = "/etc/kubernetes/pki/client-ca-bundle.crt"
+)
+
+func activateKubeadmStaticAuth(feature *kubeoneapi.StaticAuth, args *kubeadmargs.Args) {
+	if feature == nil {
+		return
+	}
+
+	if feature.Profiling != nil {
+		args.APIServer.ExtraArgs[profilingFlag] = strconv.FormatBool(*feature.Profiling)
+	}
+
+	// anonymous-auth is intentionally not passed to kubeadm. The kube-apiserver
+	// probes generated by kubeadm are anonymous HTTP probes, so the flag is
+	// set together with replacing the probes when patching the kube-apiserver
+	// static pods after the cluster is provisioned.
+
+	if !feature.ClientCABundleEnabled() {
+		return
+	}
+
+	args.APIServer.ExtraArgs[clientCAFileFlag] = ClientCABundlePath
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package kubeconfig
 
 import (
+	"fmt"
 	"io/fs"
 	"os"
 
@@ -27,9 +28,20 @@ import (
 
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// DiscoveryFile is the path of the kubeconfig used by kubeadm to discover
+	// the cluster when joining nodes, relative to the KubeOne working directory
+	// on the nodes
+	DiscoveryFile = "cfg/discovery.conf"
+
+	discoveryClusterName = "kubernetes"
+	discoveryUserName    = "tls-bootstrap-token-user"
+)
+
 // Download downloads Kubeconfig over SSH
 func Download(s *state.State) ([]byte, error) {
 	// connect to host
@@ -46,6 +58,83 @@ func Download(s *state.State) ([]byte, error) {
 	return catKubernetesAdminConf(conn)
 }
 
+// SetServer points all clusters in the given kubeconfig to the given API
+// server URL
+func SetServer(kubeconfig []byte, server string) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fail.Runtime(err, "parsing kubeconfig")
+	}
+
+	for _, cluster := range config.Clusters {
+		cluster.Server = server
+	}
+
+	buf, err := clientcmd.Write(*config)
+
+	return buf, fail.Runtime(err, "serializing kubeconfig")
+}
+
+// AddStandbyServers adds a cluster and a context for each of the given standby
+// API server URLs to the given kubeconfig. The standby clusters and contexts
+// are copies of the current ones, named with the "-standby-<n>" suffix.
+func AddStandbyServers(kubeconfig []byte, servers []string) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fail.Runtime(err, "parsing kubeconfig")
+	}
+
+	current, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fail.NewRuntimeError("adding standby servers to kubeconfig", "current context %q not found", config.CurrentContext)
+	}
+
+	cluster, ok := config.Clusters[current.Cluster]
+	if !ok {
+		return nil, fail.NewRuntimeError("adding standby servers to kubeconfig", "cluster %q not found", current.Cluster)
+	}
+
+	for i, server := range servers {
+		suffix := fmt.Sprintf("-standby-%d", i+1)
+
+		standbyCluster := cluster.DeepCopy()
+		standbyCluster.Server = server
+		config.Clusters[current.Cluster+suffix] = standbyCluster
+
+		standbyContext := current.DeepCopy()
+		standbyContext.Cluster = current.Cluster + suffix
+		config.Contexts[config.CurrentContext+suffix] = standbyContext
+	}
+
+	buf, err := clientcmd.Write(*config)
+
+	return buf, fail.Runtime(err, "serializing kubeconfig")
+}
+
+// NewDiscovery returns a kubeconfig for the kubeadm file discovery. Unlike
+// the bootstrap token discovery, it doesn't need to read the cluster-info
+// ConfigMap anonymously, as it contains the cluster CA certificate and
+// authenticates with the bootstrap token.
+func NewDiscovery(server string, caCert []byte, token string) ([]byte, error) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters[discoveryClusterName] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: caCert,
+	}
+	config.AuthInfos[discoveryUserName] = &clientcmdapi.AuthInfo{
+		Token: token,
+	}
+	config.Contexts[discoveryClusterName] = &clientcmdapi.Context{
+		Cluster:  discoveryClusterName,
+		AuthInfo: discoveryUserName,
+	}
+	config.CurrentContext = discoveryClusterName
+
+	buf, err := clientcmd.Write(*config)
+
+	return buf, fail.Runtime(err, "serializing discovery kubeconfig")
+}
+
 func catKubernetesAdminConf(conn ssh.Connection) ([]byte, error) {
 	return fs.ReadFile(sshiofs.New(conn), "/etc/kubernetes/admin.conf")
 }
@@ -54,31 +143,59 @@ func catKubernetesAdminConf(conn ssh.Connection) ([]byte, error) {
 func BuildKubernetesClientset(s *state.State) error {
 	s.Logger.Infoln("Building Kubernetes clientset...")
 
-	kubeconfig, err := Download(s)
-	if err != nil {
-		return err
-	}
-
-	s.RESTConfig, err = clientcmd.RESTConfigFromKubeConfig(kubeconfig)
-	if err != nil {
-		return fail.KubeClient(err, "building config from kubeconfig")
+	var err error
+
+	if s.ExternalKubeconfig() {
+		s.RESTConfig, err = externalRESTConfig(s.KubeconfigPath, s.KubeContext)
+		if err != nil {
+			return err
+		}
+	} else {
+		kubeconfig, err := Download(s)
+		if err != nil {
+			return err
+		}
+
+		s.RESTConfig, err = clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			return fail.KubeClient(err, "building config from kubeconfig")
+		}
+
+		tunn, err := s.Connector.Tunnel(s.Cluster.RandomHost())
+		if err != nil {
+			return fail.KubeClient(err, "getting SSH tunnel")
+		}
+
+		s.RESTConfig.Dial = tunn.TunnelTo
 	}
 
 	s.RESTConfig.WarningHandler = rest.NewWarningWriter(os.Stderr, rest.WarningWriterOptions{
 		Deduplicate: true,
 	})
 
-	tunn, err := s.Connector.Tunnel(s.Cluster.RandomHost())
+	s.DynamicClient, err = client.New(s.RESTConfig, client.Options{})
 	if err != nil {
-		return fail.KubeClient(err, "getting SSH tunnel")
+		return fail.KubeClient(err, "building dynamic kubernetes client")
 	}
 
-	s.RESTConfig.Dial = tunn.TunnelTo
+	return nil
+}
 
-	s.DynamicClient, err = client.New(s.RESTConfig, client.Options{})
+// externalRESTConfig builds the REST config from the user provided kubeconfig.
+// The default kubeconfig loading rules (e.g. $KUBECONFIG) are used if the
+// kubeconfig path is not provided.
+func externalRESTConfig(kubeconfigPath, kubeContext string) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfigPath
+
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: kubeContext,
+	}
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
 	if err != nil {
-		return fail.KubeClient(err, "building dynamic kubernetes client")
+		return nil, fail.KubeClient(err, "building config from the provided kubeconfig")
 	}
 
-	return nil
+	return restConfig, nil
 }
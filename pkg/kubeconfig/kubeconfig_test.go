@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    certificate-authority-data: Y2VydGlmaWNhdGU=
+    server: https://api.example.com:6443
+  name: kubernetes
+contexts:
+- context:
+    cluster: kubernetes
+    user: kubernetes-admin
+  name: kubernetes-admin@kubernetes
+current-context: kubernetes-admin@kubernetes
+users:
+- name: kubernetes-admin
+  user:
+    token: token
+`
+
+func TestSetServer(t *testing.T) {
+	got, err := SetServer([]byte(testKubeconfig), "https://10.0.0.10:6443")
+	if err != nil {
+		t.Fatalf("SetServer() error = %v", err)
+	}
+
+	config, err := clientcmd.Load(got)
+	if err != nil {
+		t.Fatalf("failed to load the resulting kubeconfig: %v", err)
+	}
+
+	cluster, ok := config.Clusters["kubernetes"]
+	if !ok {
+		t.Fatal("expected the kubernetes cluster to be preserved")
+	}
+	if cluster.Server != "https://10.0.0.10:6443" {
+		t.Errorf("expected server https://10.0.0.10:6443, got %q", cluster.Server)
+	}
+	if string(cluster.CertificateAuthorityData) != "certificate" {
+		t.Errorf("expected the certificate authority data to be preserved, got %q", cluster.CertificateAuthorityData)
+	}
+	if config.CurrentContext != "kubernetes-admin@kubernetes" {
+		t.Errorf("expected the current context to be preserved, got %q", config.CurrentContext)
+	}
+}
+
+func TestSetServerInvalidKubeconfig(t *testing.T) {
+	if _, err := SetServer([]byte("clusters: ["), "https://10.0.0.10:6443"); err == nil {
+		t.Error("expected an error for the invalid kubeconfig")
+	}
+}
+
+func TestAddStandbyServers(t *testing.T) {
+	got, err := AddStandbyServers([]byte(testKubeconfig), []string{"https://api-standby.example.com:6443"})
+	if err != nil {
+		t.Fatalf("AddStandbyServers() error = %v", err)
+	}
+
+	config, err := clientcmd.Load(got)
+	if err != nil {
+		t.Fatalf("failed to load the resulting kubeconfig: %v", err)
+	}
+
+	if config.Clusters["kubernetes"].Server != "https://api.example.com:6443" {
+		t.Errorf("expected the primary server to be preserved, got %q", config.Clusters["kubernetes"].Server)
+	}
+
+	standby, ok := config.Clusters["kubernetes-standby-1"]
+	if !ok {
+		t.Fatal("expected the standby cluster to be added")
+	}
+	if standby.Server != "https://api-standby.example.com:6443" {
+		t.Errorf("expected standby server https://api-standby.example.com:6443, got %q", standby.Server)
+	}
+	if string(standby.CertificateAuthorityData) != "certificate" {
+		t.Errorf("expected the certificate authority data to be copied, got %q", standby.CertificateAuthorityData)
+	}
+
+	context, ok := config.Contexts["kubernetes-admin@kubernetes-standby-1"]
+	if !ok {
+		t.Fatal("expected the standby context to be added")
+	}
+	if context.Cluster != "kubernetes-standby-1" || context.AuthInfo != "kubernetes-admin" {
+		t.Errorf("unexpected standby context %+v", context)
+	}
+	if config.CurrentContext != "kubernetes-admin@kubernetes" {
+		t.Errorf("expected the current context to be preserved, got %q", config.CurrentContext)
+	}
+}
+
+func TestNewDiscovery(t *testing.T) {
+	got, err := NewDiscovery("https://api.example.com:6443", []byte("certificate"), "abcdef.0123456789abcdef")
+	if err != nil {
+		t.Fatalf("NewDiscovery() error = %v", err)
+	}
+
+	config, err := clientcmd.Load(got)
+	if err != nil {
+		t.Fatalf("failed to load the resulting kubeconfig: %v", err)
+	}
+
+	context, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		t.Fatalf("expected the current context %q to exist", config.CurrentContext)
+	}
+
+	cluster := config.Clusters[context.Cluster]
+	if cluster == nil || cluster.Server != "https://api.example.com:6443" || string(cluster.CertificateAuthorityData) != "certificate" {
+		t.Errorf("unexpected cluster %+v", cluster)
+	}
+
+	user := config.AuthInfos[context.AuthInfo]
+	if user == nil || user.Token != "abcdef.0123456789abcdef" {
+		t.Errorf("expected the user to authenticate with the bootstrap token, got %+v", user)
+	}
+}
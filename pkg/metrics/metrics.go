@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8c.io/kubeone/pkg/fail"
+)
+
+// Stable metric names pushed to the Pushgateway. All metrics are grouped by
+// the job, cluster and command grouping labels.
+const (
+	OperationDurationMetric  = "kubeone_operation_duration_seconds"
+	OperationSuccessMetric   = "kubeone_operation_success"
+	OperationTimestampMetric = "kubeone_operation_last_run_timestamp_seconds"
+	PhaseDurationMetric      = "kubeone_phase_duration_seconds"
+	PhaseSuccessMetric       = "kubeone_phase_success"
+	NodesMetric              = "kubeone_nodes"
+
+	pushJob         = "kubeone"
+	pushContentType = "text/plain; version=0.0.4"
+	pushTimeout     = 30 * time.Second
+)
+
+// NodeCounts are the numbers of nodes per role reported by the NodesMetric
+type NodeCounts struct {
+	ControlPlane   int
+	StaticWorkers  int
+	DynamicWorkers int
+}
+
+type phase struct {
+	name     string
+	duration time.Duration
+	failed   bool
+}
+
+// Recorder records the duration and the result of the phases of a single
+// KubeOne operation. A nil Recorder is valid and records nothing.
+type Recorder struct {
+	lock    sync.Mutex
+	command string
+	started time.Time
+	phases  []*phase
+}
+
+// NewRecorder returns a Recorder for the given command started now
+func NewRecorder(command string) *Recorder {
+	return &Recorder{
+		command: command,
+		started: time.Now(),
+	}
+}
+
+// ObservePhase records a finished phase. Durations of the phases with the
+// same name are summed up and the phase is failed if any of its runs failed.
+func (r *Recorder) ObservePhase(name string, duration time.Duration, err error) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, p := range r.phases {
+		if p.name == name {
+			p.duration += duration
+			p.failed = p.failed || err != nil
+
+			return
+		}
+	}
+
+	r.phases = append(r.phases, &phase{name: name, duration: duration, failed: err != nil})
+}
+
+// Render returns the recorded metrics in the Prometheus text exposition
+// format, finishing the operation at the given time with the given result
+func (r *Recorder) Render(finished time.Time, opErr error, nodes NodeCounts) []byte {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var buf bytes.Buffer
+
+	writeGauge(&buf, OperationDurationMetric, "Duration of the KubeOne operation.")
+	writeSample(&buf, OperationDurationMetric, nil, finished.Sub(r.started).Seconds())
+
+	writeGauge(&buf, OperationSuccessMetric, "Whether the KubeOne operation succeeded.")
+	writeSample(&buf, OperationSuccessMetric, nil, boolValue(opErr == nil))
+
+	writeGauge(&buf, OperationTimestampMetric, "Unix time when the KubeOne operation finished.")
+	writeSample(&buf, OperationTimestampMetric, nil, float64(finished.Unix()))
+
+	if len(r.phases) > 0 {
+		writeGauge(&buf, PhaseDurationMetric, "Duration of the KubeOne operation phase.")
+		for _, p := range r.phases {
+			writeSample(&buf, PhaseDurationMetric, []string{"phase", p.name}, p.duration.Seconds())
+		}
+
+		writeGauge(&buf, PhaseSuccessMetric, "Whether the KubeOne operation phase succeeded.")
+		for _, p := range r.phases {
+			writeSample(&buf, PhaseSuccessMetric, []string{"phase", p.name}, boolValue(!p.failed))
+		}
+	}
+
+	writeGauge(&buf, NodesMetric, "Number of the cluster nodes by role.")
+	writeSample(&buf, NodesMetric, []string{"role", "control-plane"}, float64(nodes.ControlPlane))
+	writeSample(&buf, NodesMetric, []string{"role", "static-worker"}, float64(nodes.StaticWorkers))
+	writeSample(&buf, NodesMetric, []string{"role", "dynamic-worker"}, float64(nodes.DynamicWorkers))
+
+	return buf.Bytes()
+}
+
+// Push renders the recorded metrics and replaces the metrics group of the
+// cluster and command on the Pushgateway with them
+func (r *Recorder) Push(ctx context.Context, gatewayURL, cluster string, opErr error, nodes NodeCounts) error {
+	if r == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+
+	pushURL := fmt.Sprintf("%s/metrics/job/%s/cluster/%s/command/%s",
+		strings.TrimSuffix(gatewayURL, "/"),
+		pushJob,
+		url.PathEscape(cluster),
+		url.PathEscape(r.command),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, pushURL, bytes.NewReader(r.Render(time.Now(), opErr, nodes)))
+	if err != nil {
+		return fail.Runtime(err, "creating metrics push request")
+	}
+	req.Header.Set("Content-Type", pushContentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fail.Runtime(err, "pushing metrics to %s", gatewayURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return fail.Runtime(fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body))), "pushing metrics to %s", gatewayURL)
+	}
+
+	return nil
+}
+
+func writeGauge(buf *bytes.Buffer, name, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// writeSample writes a single sample, labels are given as name/value pairs
+func writeSample(buf *bytes.Buffer, name string, labels []string, value float64) {
+	buf.WriteString(name)
+
+	if len(labels) > 0 {
+		pairs := []string{}
+		for i := 0; i+1 < len(labels); i += 2 {
+			pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labels[i], escapeLabelValue(labels[i+1])))
+		}
+		fmt.Fprintf(buf, "{%s}", strings.Join(pairs, ","))
+	}
+
+	fmt.Fprintf(buf, " %s\n", strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
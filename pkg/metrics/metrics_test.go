@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+)
+
+func TestRender(t *testing.T) {
+	started := time.Unix(1650000000, 0)
+
+	r := &Recorder{command: "apply", started: started}
+	r.ObservePhase("installing binaries", 10*time.Second, nil)
+	r.ObservePhase(`patching "kube-apiserver"`, 2*time.Second, nil)
+	r.ObservePhase("installing binaries", 5*time.Second, errors.New("timeout"))
+
+	got := string(r.Render(started.Add(90*time.Second), nil, NodeCounts{ControlPlane: 3, StaticWorkers: 1, DynamicWorkers: 4}))
+	want := heredoc.Doc(`
+		# HELP kubeone_operation_duration_seconds Duration of the KubeOne operation.
+		# TYPE kubeone_operation_duration_seconds gauge
+		kubeone_operation_duration_seconds 90
+		# HELP kubeone_operation_success Whether the KubeOne operation succeeded.
+		# TYPE kubeone_operation_success gauge
+		kubeone_operation_success 1
+		# HELP kubeone_operation_last_run_timestamp_seconds Unix time when the KubeOne operation finished.
+		# TYPE kubeone_operation_last_run_timestamp_seconds gauge
+		kubeone_operation_last_run_timestamp_seconds 1650000090
+		# HELP kubeone_phase_duration_seconds Duration of the KubeOne operation phase.
+		# TYPE kubeone_phase_duration_seconds gauge
+		kubeone_phase_duration_seconds{phase="installing binaries"} 15
+		kubeone_phase_duration_seconds{phase="patching \"kube-apiserver\""} 2
+		# HELP kubeone_phase_success Whether the KubeOne operation phase succeeded.
+		# TYPE kubeone_phase_success gauge
+		kubeone_phase_success{phase="installing binaries"} 0
+		kubeone_phase_success{phase="patching \"kube-apiserver\""} 1
+		# HELP kubeone_nodes Number of the cluster nodes by role.
+		# TYPE kubeone_nodes gauge
+		kubeone_nodes{role="control-plane"} 3
+		kubeone_nodes{role="static-worker"} 1
+		kubeone_nodes{role="dynamic-worker"} 4
+	`)
+
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPush(t *testing.T) {
+	var gotMethod, gotPath, gotContentType string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotMethod = req.Method
+		gotPath = req.URL.Path
+		gotContentType = req.Header.Get("Content-Type")
+		_, _ = io.Copy(io.Discard, req.Body)
+
+		if req.URL.Path == "/metrics/job/kubeone/cluster/broken/command/apply" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	r := NewRecorder("apply")
+	if err := r.Push(context.Background(), server.URL+"/", "demo", nil, NodeCounts{}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	if gotMethod != http.MethodPut || gotPath != "/metrics/job/kubeone/cluster/demo/command/apply" || gotContentType != pushContentType {
+		t.Errorf("Push() sent %s %s (%s)", gotMethod, gotPath, gotContentType)
+	}
+
+	if err := r.Push(context.Background(), server.URL, "broken", nil, NodeCounts{}); err == nil {
+		t.Error("Push() expected error on non-2xx response")
+	}
+
+	var nilRecorder *Recorder
+	nilRecorder.ObservePhase("noop", time.Second, nil)
+	if err := nilRecorder.Push(context.Background(), server.URL, "demo", nil, NodeCounts{}); err != nil {
+		t.Errorf("Push() on nil Recorder error = %v", err)
+	}
+}
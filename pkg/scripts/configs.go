@@ -21,6 +21,7 @@ import (
 
 	"k8c.io/kubeone/pkg/certificate/cabundle"
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/templates/schedulerconfig"
 )
 
 var (
@@ -39,13 +40,23 @@ var (
 		fi
 	`)
 
-	podNodeSelectorConfigTemplate = heredoc.Doc(`
-		if sudo test -f "{{ .WORK_DIR }}/cfg/podnodeselector.yaml"; then
+	admissionConfigTemplate = heredoc.Doc(`
+		if sudo test -f "{{ .WORK_DIR }}/cfg/admission-config.yaml"; then
 			sudo mkdir -p /etc/kubernetes/admission
-			sudo mv {{ .WORK_DIR }}/cfg/podnodeselector.yaml /etc/kubernetes/admission/podnodeselector.yaml
-			sudo mv {{ .WORK_DIR }}/cfg/admission-config.yaml /etc/kubernetes/admission/admission-config.yaml
-			sudo chown root:root /etc/kubernetes/admission/podnodeselector.yaml
-			sudo chown root:root /etc/kubernetes/admission/admission-config.yaml
+			for config in admission-config podnodeselector podtolerationrestriction; do
+				if sudo test -f "{{ .WORK_DIR }}/cfg/${config}.yaml"; then
+					sudo mv {{ .WORK_DIR }}/cfg/${config}.yaml /etc/kubernetes/admission/${config}.yaml
+					sudo chown root:root /etc/kubernetes/admission/${config}.yaml
+				fi
+			done
+		fi
+	`)
+
+	schedulerConfigTemplate = heredoc.Doc(`
+		if sudo test -f "{{ .WORK_DIR }}/cfg/scheduler-config.yaml"; then
+			sudo mkdir -p {{ .CONFIG_DIR }}
+			sudo mv {{ .WORK_DIR }}/cfg/scheduler-config.yaml {{ .CONFIG_PATH }}
+			sudo chown root:root {{ .CONFIG_PATH }}
 		fi
 	`)
 
@@ -55,6 +66,32 @@ var (
 		sudo chown -R root:root {{ .CA_CERTS_DIR }}
 	`)
 
+	clientCABundleTemplate = heredoc.Doc(`
+		sudo mkdir -p /etc/kubernetes/static-auth
+		sudo mv {{ .WORK_DIR }}/cfg/client-ca-bundle.crt /etc/kubernetes/static-auth/client-ca.crt
+		sudo chown root:root /etc/kubernetes/static-auth/client-ca.crt
+
+		# Preserve the kubeadm-managed cluster CA, so clients authenticated by
+		# it (e.g. kubelets and admin kubeconfig) keep working
+		sudo cat /etc/kubernetes/pki/ca.crt /etc/kubernetes/static-auth/client-ca.crt \
+			| sudo tee {{ .CLIENT_CA_BUNDLE }}.new > /dev/null
+
+		if sudo cmp -s {{ .CLIENT_CA_BUNDLE }}.new {{ .CLIENT_CA_BUNDLE }}; then
+			sudo rm -f {{ .CLIENT_CA_BUNDLE }}.new
+			exit 0
+		fi
+
+		sudo mv {{ .CLIENT_CA_BUNDLE }}.new {{ .CLIENT_CA_BUNDLE }}
+		sudo chown root:root {{ .CLIENT_CA_BUNDLE }}
+
+		# Restart kube-apiserver (if running) to pick up the new bundle
+		apiserver_id=$(sudo crictl ps --name=kube-apiserver -q)
+		if [ -n "$apiserver_id" ]; then
+			sudo crictl stop "$apiserver_id"
+			sudo crictl rm "$apiserver_id"
+		fi
+	`)
+
 	encryptionProvidersConfigTemplate = heredoc.Doc(`
 		if sudo test -f "{{ .WORK_DIR }}/cfg/{{ .FILE_NAME }}"; then
 			sudo mkdir -p /etc/kubernetes/encryption-providers/
@@ -85,14 +122,24 @@ func SaveAuditPolicyConfig(workdir string) (string, error) {
 	return result, fail.Runtime(err, "rendering auditPolicyScriptTemplate script")
 }
 
-func SavePodNodeSelectorConfig(workdir string) (string, error) {
-	result, err := Render(podNodeSelectorConfigTemplate, Data{
+func SaveAdmissionConfig(workdir string) (string, error) {
+	result, err := Render(admissionConfigTemplate, Data{
 		"WORK_DIR": workdir,
 	})
 
 	return result, fail.Runtime(err, "rendering script")
 }
 
+func SaveSchedulerConfig(workdir string) (string, error) {
+	result, err := Render(schedulerConfigTemplate, Data{
+		"WORK_DIR":    workdir,
+		"CONFIG_DIR":  schedulerconfig.ConfigDir,
+		"CONFIG_PATH": schedulerconfig.ConfigPath,
+	})
+
+	return result, fail.Runtime(err, "rendering schedulerConfigTemplate script")
+}
+
 func SaveEncryptionProvidersConfig(workdir, fileName string) (string, error) {
 	result, err := Render(encryptionProvidersConfigTemplate, Data{
 		"WORK_DIR":  workdir,
@@ -106,6 +153,15 @@ func DeleteEncryptionProvidersConfig(fileName string) string {
 	return deleteEncryptionProvidersConfigTemplate
 }
 
+func SaveClientCABundle(workdir, clientCABundlePath string) (string, error) {
+	result, err := Render(clientCABundleTemplate, Data{
+		"WORK_DIR":         workdir,
+		"CLIENT_CA_BUNDLE": clientCABundlePath,
+	})
+
+	return result, fail.Runtime(err, "rendering clientCABundleTemplate script")
+}
+
 func SaveCABundle(workdir string) (string, error) {
 	result, err := Render(caBundleTemplate, Data{
 		"CA_BUNDLE_FILENAME": cabundle.FileName,
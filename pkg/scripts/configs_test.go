@@ -78,3 +78,44 @@ func TestSaveAuditPolicyConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveSchedulerConfig(t *testing.T) {
+	t.Parallel()
+
+	got, err := SaveSchedulerConfig("test-dir")
+	if err != nil {
+		t.Errorf("SaveSchedulerConfig() error = %v", err)
+
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestSaveClientCABundle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		workdir string
+		err     error
+	}{
+		{name: "kubeone1", workdir: "test-dir1"},
+		{name: "kubeone2", workdir: "./subdir/test"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := SaveClientCABundle(tt.workdir, "/etc/kubernetes/pki/client-ca-bundle.crt")
+			if !errors.Is(err, tt.err) {
+				t.Errorf("SaveClientCABundle() error = %v, wantErr %v", err, tt.err)
+
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"k8c.io/kubeone/pkg/fail"
+)
+
+const (
+	connectivityCheckPIDsFile = "/tmp/kubeone-connectivity-check.pids"
+
+	connectivityListenersScriptTemplate = `
+start_listener() {
+	local port=$1
+	if command -v python3 &>/dev/null; then
+		sudo nohup timeout {{ .TIMEOUT }} python3 -c '
+import socket, sys
+port = int(sys.argv[1])
+if socket.has_dualstack_ipv6():
+    server = socket.create_server(("", port), family=socket.AF_INET6, dualstack_ipv6=True)
+else:
+    server = socket.create_server(("", port))
+while True:
+    conn, _ = server.accept()
+    conn.close()
+' "$port" &>/dev/null &
+	elif command -v ncat &>/dev/null; then
+		sudo nohup timeout {{ .TIMEOUT }} ncat -lk "$port" &>/dev/null &
+	elif command -v nc &>/dev/null; then
+		sudo nohup timeout {{ .TIMEOUT }} nc -lk "$port" &>/dev/null &
+	else
+		echo "python3, ncat or nc is required to verify the connectivity" >&2
+		exit 1
+	fi
+	echo $! >> {{ .PIDS_FILE }}
+}
+
+{{- range .PORTS }}
+# ports already used (e.g. by the running control plane components) are not
+# taken over by the temporary listeners
+if ! sudo ss -Hltn "sport = :{{ . }}" | grep -q .; then
+	start_listener {{ . }}
+fi
+{{- end }}
+# give the listeners time to bind the ports
+sleep 1
+`
+
+	connectivityProbeScriptTemplate = `
+{{- range .TARGETS }}
+if timeout 3 bash -c "</dev/tcp/{{ .Address }}/{{ .Port }}" &>/dev/null; then
+	echo "{{ .Address }} {{ .Port }} ok"
+else
+	echo "{{ .Address }} {{ .Port }} blocked"
+fi
+{{- end }}
+{{- if .MTU }}
+{{- range .MTU_TARGETS }}
+{{- /* the ICMP payload is the MTU without the IP and ICMP headers */}}
+{{- if contains ":" . }}
+if ping -6 -c 1 -W 2 -M do -s {{ sub $.MTU 48 }} {{ . }} &>/dev/null; then
+{{- else }}
+if ping -c 1 -W 2 -M do -s {{ sub $.MTU 28 }} {{ . }} &>/dev/null; then
+{{- end }}
+	echo "{{ . }} mtu ok"
+else
+	echo "{{ . }} mtu blocked"
+fi
+{{- end }}
+{{- end }}
+`
+
+	connectivityListenersCleanupScriptTemplate = `
+if [[ -f {{ .PIDS_FILE }} ]]; then
+	sudo kill $(cat {{ .PIDS_FILE }}) &>/dev/null || true
+	sudo rm -f {{ .PIDS_FILE }}
+fi
+`
+)
+
+// ConnectivityTarget is a TCP port on a node verified by the ConnectivityProbe
+type ConnectivityTarget struct {
+	Address string
+	Port    int
+}
+
+// ConnectivityListeners starts temporary TCP listeners on the given ports not
+// used yet. The listeners are stopped after the timeout (in seconds) or by the
+// ConnectivityListenersCleanup script.
+func ConnectivityListeners(ports []int, timeout int) (string, error) {
+	result, err := Render(connectivityListenersScriptTemplate, Data{
+		"PORTS":     ports,
+		"TIMEOUT":   timeout,
+		"PIDS_FILE": connectivityCheckPIDsFile,
+	})
+
+	return result, fail.Runtime(err, "rendering connectivityListenersScriptTemplate script")
+}
+
+// ConnectivityListenersCleanup stops the listeners started by the
+// ConnectivityListeners script
+func ConnectivityListenersCleanup() (string, error) {
+	result, err := Render(connectivityListenersCleanupScriptTemplate, Data{
+		"PIDS_FILE": connectivityCheckPIDsFile,
+	})
+
+	return result, fail.Runtime(err, "rendering connectivityListenersCleanupScriptTemplate script")
+}
+
+// ConnectivityProbe connects to the given TCP targets and, if the MTU is not
+// zero, sends non-fragmented ICMP echo requests of the MTU size to the given
+// addresses. For every target it prints the address, the port (or "mtu") and
+// the result ("ok" or "blocked").
+func ConnectivityProbe(targets []ConnectivityTarget, mtu int, mtuTargets []string) (string, error) {
+	result, err := Render(connectivityProbeScriptTemplate, Data{
+		"TARGETS":     targets,
+		"MTU":         mtu,
+		"MTU_TARGETS": mtuTargets,
+	})
+
+	return result, fail.Runtime(err, "rendering connectivityProbeScriptTemplate script")
+}
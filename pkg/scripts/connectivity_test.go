@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestConnectivityListeners(t *testing.T) {
+	t.Parallel()
+
+	got, err := ConnectivityListeners([]int{2379, 2380, 6443, 10250}, 300)
+	if err != nil {
+		t.Errorf("ConnectivityListeners() error = %v", err)
+
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestConnectivityListenersCleanup(t *testing.T) {
+	t.Parallel()
+
+	got, err := ConnectivityListenersCleanup()
+	if err != nil {
+		t.Errorf("ConnectivityListenersCleanup() error = %v", err)
+
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestConnectivityProbe(t *testing.T) {
+	t.Parallel()
+
+	got, err := ConnectivityProbe(
+		[]ConnectivityTarget{
+			{Address: "10.0.0.1", Port: 6443},
+			{Address: "fd00::1", Port: 10250},
+		},
+		1500,
+		[]string{"10.0.0.1", "fd00::1"},
+	)
+	if err != nil {
+		t.Errorf("ConnectivityProbe() error = %v", err)
+
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"k8c.io/kubeone/pkg/fail"
+)
+
+const (
+	nftablesRulesetFile    = "/etc/kubeone/firewall.nft"
+	nftablesServiceFile    = "/etc/systemd/system/kubeone-firewall.service"
+	firewalldServiceFile   = "/etc/firewalld/services/kubeone.xml"
+	firewalldRichRulesFile = "/etc/kubeone/firewalld-rich-rules"
+
+	firewalldRemoveRichRulesTemplate = `
+{{- define "remove-rich-rules" -}}
+if [[ -f {{ .RICH_RULES_FILE }} ]]; then
+	while read -r rule; do
+		if [[ -n "${rule}" ]]; then
+			sudo firewall-cmd --permanent --remove-rich-rule="${rule}"
+		fi
+	done < {{ .RICH_RULES_FILE }}
+fi
+{{- end -}}
+`
+
+	nftablesFirewallScriptTemplate = `
+NFT=$(command -v nft || true)
+if [[ -z "${NFT}" ]]; then
+	echo "nft is required to manage the firewall using nftables" >&2
+	exit 1
+fi
+
+sudo mkdir -p /etc/kubeone
+cat <<EOF | sudo tee {{ .RULESET_FILE }}
+{{ .RULESET }}
+EOF
+sudo "${NFT}" -c -f {{ .RULESET_FILE }}
+sudo "${NFT}" -f {{ .RULESET_FILE }}
+
+# restore the ruleset on boot
+cat <<EOF | sudo tee {{ .SERVICE_FILE }}
+[Unit]
+Description=Host firewall managed by KubeOne
+Wants=network-pre.target
+Before=network-pre.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=${NFT} -f {{ .RULESET_FILE }}
+
+[Install]
+WantedBy=multi-user.target
+EOF
+sudo systemctl daemon-reload
+sudo systemctl enable kubeone-firewall.service
+`
+
+	firewalldFirewallScriptTemplate = `
+if ! sudo firewall-cmd --state &>/dev/null; then
+	echo "firewalld must be running to manage the firewall using firewalld" >&2
+	exit 1
+fi
+
+cat <<EOF | sudo tee {{ .SERVICE_FILE }}
+{{ .SERVICE }}
+EOF
+sudo firewall-cmd --reload
+sudo firewall-cmd --permanent --add-service=kubeone
+
+# rich rules added previously are replaced, so the removed rules don't linger
+{{ template "remove-rich-rules" . }}
+sudo mkdir -p /etc/kubeone
+cat <<'EOF' | sudo tee {{ .RICH_RULES_FILE }}
+{{- range .RICH_RULES }}
+{{ . }}
+{{- end }}
+EOF
+while read -r rule; do
+	if [[ -n "${rule}" ]]; then
+		sudo firewall-cmd --permanent --add-rich-rule="${rule}"
+	fi
+done < {{ .RICH_RULES_FILE }}
+sudo firewall-cmd --reload
+`
+
+	firewallResetScriptTemplate = `
+{{ if .FIREWALLD -}}
+if sudo firewall-cmd --state &>/dev/null; then
+	{{ template "remove-rich-rules" . }}
+	sudo firewall-cmd --permanent --remove-service=kubeone
+	sudo rm -f {{ .SERVICE_FILE }} {{ .RICH_RULES_FILE }}
+	sudo firewall-cmd --reload
+fi
+{{- else -}}
+if [[ -f {{ .SERVICE_FILE }} ]]; then
+	sudo systemctl disable kubeone-firewall.service
+	sudo rm -f {{ .SERVICE_FILE }} {{ .RULESET_FILE }}
+	sudo systemctl daemon-reload
+fi
+if command -v nft &>/dev/null && sudo nft list table inet kubeone &>/dev/null; then
+	sudo nft delete table inet kubeone
+fi
+{{- end }}
+`
+)
+
+// NftablesFirewall applies the nftables ruleset and enables the systemd
+// service restoring it on boot
+func NftablesFirewall(ruleset string) (string, error) {
+	result, err := Render(nftablesFirewallScriptTemplate, Data{
+		"RULESET":      ruleset,
+		"RULESET_FILE": nftablesRulesetFile,
+		"SERVICE_FILE": nftablesServiceFile,
+	})
+
+	return result, fail.Runtime(err, "rendering nftablesFirewallScriptTemplate script")
+}
+
+// FirewalldFirewall adds the kubeone service and the rich rules to the
+// default firewalld zone, replacing the previously added rich rules
+func FirewalldFirewall(service string, richRules []string) (string, error) {
+	result, err := Render(firewalldRemoveRichRulesTemplate+firewalldFirewallScriptTemplate, Data{
+		"SERVICE":         service,
+		"SERVICE_FILE":    firewalldServiceFile,
+		"RICH_RULES":      richRules,
+		"RICH_RULES_FILE": firewalldRichRulesFile,
+	})
+
+	return result, fail.Runtime(err, "rendering firewalldFirewallScriptTemplate script")
+}
+
+// FirewallReset removes the firewall rules managed by KubeOne
+func FirewallReset(firewalld bool) (string, error) {
+	data := Data{
+		"FIREWALLD":       firewalld,
+		"RULESET_FILE":    nftablesRulesetFile,
+		"RICH_RULES_FILE": firewalldRichRulesFile,
+		"SERVICE_FILE":    nftablesServiceFile,
+	}
+	if firewalld {
+		data["SERVICE_FILE"] = firewalldServiceFile
+	}
+
+	result, err := Render(firewalldRemoveRichRulesTemplate+firewallResetScriptTemplate, data)
+
+	return result, fail.Runtime(err, "rendering firewallResetScriptTemplate script")
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestNftablesFirewall(t *testing.T) {
+	t.Parallel()
+
+	got, err := NftablesFirewall("table inet kubeone {\n\tchain input {\n\t\ttype filter hook input priority 0; policy drop;\n\t\ttcp dport 22 accept\n\t}\n}")
+	if err != nil {
+		t.Errorf("NftablesFirewall() error = %v", err)
+
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestFirewalldFirewall(t *testing.T) {
+	t.Parallel()
+
+	got, err := FirewalldFirewall(
+		"<service>\n  <port protocol=\"tcp\" port=\"6443\"/>\n</service>",
+		[]string{`rule family="ipv4" source address="10.0.0.1/32" port port="2379-2380" protocol="tcp" accept`},
+	)
+	if err != nil {
+		t.Errorf("FirewalldFirewall() error = %v", err)
+
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestFirewallReset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		firewalld bool
+	}{
+		{
+			name: "nftables",
+		},
+		{
+			name:      "firewalld",
+			firewalld: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FirewallReset(tt.firewalld)
+			if err != nil {
+				t.Errorf("FirewallReset() error = %v", err)
+
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
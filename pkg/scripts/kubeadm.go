@@ -42,6 +42,13 @@ var (
 			--config={{ .WORK_DIR }}/cfg/master_{{ .NODE_ID }}.yaml
 	`)
 
+	kubeadmCertAPIServerScriptTemplate = heredoc.Doc(`
+		sudo mv -f /etc/kubernetes/pki/apiserver.crt /etc/kubernetes/pki/apiserver.crt.old
+		sudo mv -f /etc/kubernetes/pki/apiserver.key /etc/kubernetes/pki/apiserver.key.old
+		sudo kubeadm {{ .VERBOSE }} init phase certs apiserver \
+			--config={{ .WORK_DIR }}/cfg/master_{{ .NODE_ID }}.yaml
+	`)
+
 	kubeadmInitScriptTemplate = heredoc.Doc(`
 		if [[ -f /etc/kubernetes/admin.conf ]]; then
 			sudo kubeadm {{ .VERBOSE }} token create {{ .TOKEN }} --ttl {{ .TOKEN_DURATION }}
@@ -56,6 +63,7 @@ var (
 		sudo rm -f /etc/kubernetes/cloud-config
 		sudo rm -rf /etc/kubernetes/admission
 		sudo rm -rf /etc/kubernetes/encryption-providers
+		sudo rm -rf /etc/kubernetes/static-auth
 		sudo rm -rf /var/lib/etcd/
 		sudo rm -rf "{{ .WORK_DIR }}"
 		sudo rm -rf /etc/kubeone
@@ -102,6 +110,16 @@ func KubeadmCert(workdir string, nodeID int, verboseFlag string) (string, error)
 	return result, fail.Runtime(err, "rendering kubeadmCertScriptTemplate script")
 }
 
+func KubeadmCertAPIServer(workdir string, nodeID int, verboseFlag string) (string, error) {
+	result, err := Render(kubeadmCertAPIServerScriptTemplate, Data{
+		"WORK_DIR": workdir,
+		"NODE_ID":  nodeID,
+		"VERBOSE":  verboseFlag,
+	})
+
+	return result, fail.Runtime(err, "rendering kubeadmCertAPIServerScriptTemplate script")
+}
+
 func KubeadmInit(workdir string, nodeID int, verboseFlag, token, tokenTTL string, skipPhases string) (string, error) {
 	result, err := Render(kubeadmInitScriptTemplate, Data{
 		"WORK_DIR":       workdir,
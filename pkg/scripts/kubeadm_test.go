@@ -161,6 +161,52 @@ func TestKubeadmCert(t *testing.T) {
 	}
 }
 
+func TestKubeadmCertAPIServer(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		workdir     string
+		nodeID      int
+		verboseFlag string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "verbose",
+			args: args{
+				workdir:     "test-wd",
+				nodeID:      0,
+				verboseFlag: "--v=6",
+			},
+		},
+		{
+			name: "not-verbose",
+			args: args{
+				workdir: "test-wd",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := KubeadmCertAPIServer(tt.args.workdir, tt.args.nodeID, tt.args.verboseFlag)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("KubeadmCertAPIServer() error = %v, wantErr %v", err, tt.err)
+
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
+
 func TestKubeadmInit(t *testing.T) {
 	t.Parallel()
 
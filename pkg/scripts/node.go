@@ -29,6 +29,57 @@ var (
 		echo "$fqdn"
 	`)
 
+	restartKubeletScript = heredoc.Doc(`
+		sudo systemctl restart kubelet
+	`)
+
+	verifyCgroupExistsTemplate = heredoc.Doc(`
+		cgroup="{{ .CGROUP }}"
+		# cgroup v2 unified hierarchy or the cgroup v1 systemd hierarchy
+		if [ -d "/sys/fs/cgroup${cgroup}" ] || [ -d "/sys/fs/cgroup/systemd${cgroup}" ]; then
+			exit 0
+		fi
+		echo "cgroup ${cgroup} does not exist" >&2
+		exit 1
+	`)
+
+	// cgroup2fs is mounted at /sys/fs/cgroup with the unified hierarchy, and
+	// tmpfs with the legacy or the hybrid hierarchy
+	detectCgroupVersionScript = heredoc.Doc(`
+		stat -fc %T /sys/fs/cgroup/
+	`)
+
+	enableCgroupV2Script = heredoc.Doc(`
+		cmdline_arg="systemd.unified_cgroup_hierarchy=1"
+		if command -v grubby &> /dev/null; then
+			sudo grubby --update-kernel=ALL --args="${cmdline_arg}"
+		elif [ -f /etc/default/grub ] && command -v update-grub &> /dev/null; then
+			if ! grep -q "${cmdline_arg}" /etc/default/grub; then
+				sudo sed -i "s/^GRUB_CMDLINE_LINUX=\"\(.*\)\"/GRUB_CMDLINE_LINUX=\"\1 ${cmdline_arg}\"/" /etc/default/grub
+			fi
+			sudo update-grub
+		else
+			echo "neither grubby nor update-grub found, can't configure the kernel command line" >&2
+			exit 1
+		fi
+	`)
+
+	rebootScript = heredoc.Doc(`
+		sudo reboot
+	`)
+
+	preloadImageTemplate = heredoc.Doc(`
+		{{ if .DOCKER -}}
+		if ! sudo docker image inspect {{ .IMAGE }} &>/dev/null; then
+			sudo docker pull {{ .IMAGE }}
+		fi
+		{{- else -}}
+		if ! sudo crictl inspecti {{ .IMAGE }} &>/dev/null; then
+			sudo crictl pull {{ .IMAGE }}
+		fi
+		{{- end }}
+	`)
+
 	restartKubeAPIServerCrictlTemplate = heredoc.Doc(`
 		# Disable exit immediately if a command in a pipeline fails.
 		# crictl logs can fail if kubelet fails to set up symlink for the API
@@ -64,3 +115,36 @@ func RestartKubeAPIServerCrictl(ensure bool) (string, error) {
 
 	return result, fail.Runtime(err, "rendering restartKubeAPIServerCrictlTemplate script")
 }
+
+func RestartKubelet() string {
+	return restartKubeletScript
+}
+
+func DetectCgroupVersion() string {
+	return detectCgroupVersionScript
+}
+
+func EnableCgroupV2() string {
+	return enableCgroupV2Script
+}
+
+func Reboot() string {
+	return rebootScript
+}
+
+func VerifyCgroupExists(cgroup string) (string, error) {
+	result, err := Render(verifyCgroupExistsTemplate, Data{
+		"CGROUP": cgroup,
+	})
+
+	return result, fail.Runtime(err, "rendering verifyCgroupExistsTemplate script")
+}
+
+func PreloadImage(image string, docker bool) (string, error) {
+	result, err := Render(preloadImageTemplate, Data{
+		"IMAGE":  image,
+		"DOCKER": docker,
+	})
+
+	return result, fail.Runtime(err, "rendering preloadImageTemplate script")
+}
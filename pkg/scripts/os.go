@@ -50,6 +50,16 @@ var migrateToContainerdScriptTemplate = heredoc.Doc(`
 	sudo systemctl restart kubelet
 `)
 
+var containerdConfigScriptTemplate = heredoc.Doc(`
+	CONTAINERD_CONFIG_SHA_OLD=$(sudo sha256sum {{ .CONTAINER_RUNTIME_CONFIG_PATH }} 2>/dev/null | cut -d" " -f1 || true)
+	{{ template "container-runtime-daemon-config" . }}
+	# restart containerd only if the config has changed, running containers
+	# are kept alive by their shims
+	if [[ "${CONTAINERD_CONFIG_SHA_OLD}" != "$(sudo sha256sum {{ .CONTAINER_RUNTIME_CONFIG_PATH }} | cut -d" " -f1)" ]]; then
+		sudo systemctl try-restart containerd
+	fi
+`)
+
 func MigrateToContainerd(cluster *kubeoneapi.KubeOneCluster, node *kubeoneapi.HostConfig) (string, error) {
 	data := Data{
 		"IS_FLATCAR": node.OperatingSystem == kubeoneapi.OperatingSystemNameFlatcar,
@@ -64,6 +74,20 @@ func MigrateToContainerd(cluster *kubeoneapi.KubeOneCluster, node *kubeoneapi.Ho
 	return result, fail.Runtime(err, "rendering migrateToContainerdScriptTemplate script")
 }
 
+// ContainerdConfig renders the script that writes the containerd config and
+// restarts containerd if the config has been changed
+func ContainerdConfig(cluster *kubeoneapi.KubeOneCluster) (string, error) {
+	data := Data{}
+
+	if err := containerruntime.UpdateDataMap(cluster, data); err != nil {
+		return "", err
+	}
+
+	result, err := Render(containerdConfigScriptTemplate, data)
+
+	return result, fail.Runtime(err, "rendering containerdConfigScriptTemplate script")
+}
+
 func installISCSIAndNFS(cluster *kubeoneapi.KubeOneCluster) bool {
 	return cluster.CloudProvider.Nutanix != nil
 }
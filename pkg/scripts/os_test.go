@@ -236,6 +236,19 @@ func TestMigrateToContainerd(t *testing.T) {
 	}
 }
 
+func TestContainerdConfig(t *testing.T) {
+	t.Parallel()
+
+	cls := genCluster(withContainerd)
+
+	got, err := ContainerdConfig(&cls)
+	if err != nil {
+		t.Fatalf("ContainerdConfig() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
 func TestKubeadmCentOS(t *testing.T) {
 	t.Parallel()
 
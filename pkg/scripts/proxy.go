@@ -63,6 +63,14 @@ grep -v '#kubeone$' /etc/environment > $envtmp || true
 set +o pipefail # grep exits non-zero without match
 grep = /etc/kubeone/proxy-env | sed 's/$/#kubeone/' >> $envtmp
 sudo tee /etc/environment < $envtmp
+`
+
+	proxyProbeScriptTemplate = `
+{{- range .TARGETS }}
+env_ip=$(env -i PATH="$PATH" {{ range $.PROXY_ENV }}{{ . | squote }} {{ end }}curl -sk --max-time 5 -o /dev/null -w '%{remote_ip}' {{ . | squote }} || true)
+direct_ip=$(curl -sk --noproxy '*' --max-time 5 -o /dev/null -w '%{remote_ip}' {{ . | squote }} || true)
+echo "{{ . }} ${env_ip:--} ${direct_ip:--}"
+{{- end }}
 `
 )
 
@@ -83,3 +91,15 @@ func DaemonsEnvironmentDropIn(daemons ...string) (string, error) {
 
 	return result, fail.Runtime(err, "rendering daemonsEnvironmentScriptTemplate script")
 }
+
+// ProxyProbe connects to the targets once using the given proxy environment
+// and once bypassing the proxy, and prints the remote IP of both connections
+// for every target
+func ProxyProbe(proxyEnv []string, targets []string) (string, error) {
+	result, err := Render(proxyProbeScriptTemplate, Data{
+		"PROXY_ENV": proxyEnv,
+		"TARGETS":   targets,
+	})
+
+	return result, fail.Runtime(err, "rendering proxyProbeScriptTemplate script")
+}
@@ -96,3 +96,19 @@ func TestTestDaemonsProxy(t *testing.T) {
 
 	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
 }
+
+func TestProxyProbe(t *testing.T) {
+	t.Parallel()
+
+	got, err := ProxyProbe(
+		[]string{"HTTPS_PROXY=http://https.proxy", "NO_PROXY=.local,10.96.0.0/12"},
+		[]string{"https://10.0.0.1:6443", "https://10.96.0.1:443"},
+	)
+	if err != nil {
+		t.Errorf("ProxyProbe() error = %v", err)
+
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
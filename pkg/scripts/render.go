@@ -45,6 +45,19 @@ var (
 			{{- end }}
 		`),
 
+		"docker-daemon-config": heredoc.Doc(`
+			{{- if .CONTAINER_RUNTIME_CONFIG_PATH }}
+			DOCKER_CONFIG_SHA_OLD=$(sudo sha256sum {{ .CONTAINER_RUNTIME_CONFIG_PATH }} 2>/dev/null | cut -d" " -f1 || true)
+			{{- end }}
+			{{ template "container-runtime-daemon-config" . }}
+			{{- if .CONTAINER_RUNTIME_CONFIG_PATH }}
+			# restart already running dockerd to pick up changes to the config (e.g. log options)
+			if [[ "${DOCKER_CONFIG_SHA_OLD}" != "$(sudo sha256sum {{ .CONTAINER_RUNTIME_CONFIG_PATH }} | cut -d" " -f1)" ]]; then
+				sudo systemctl try-restart docker
+			fi
+			{{- end }}
+		`),
+
 		"containerd-systemd-setup": heredoc.Doc(`
 			sudo systemctl daemon-reload
 			sudo systemctl enable containerd
@@ -80,7 +93,7 @@ var (
 				docker-ce-cli=5:{{ $DOCKER_VERSION_TO_INSTALL }} \
 				containerd.io=%s
 			sudo apt-mark hold docker-ce docker-ce-cli containerd.io
-			{{ template "container-runtime-daemon-config" . }}
+			{{ template "docker-daemon-config" . }}
 			{{ template "containerd-systemd-setup" . -}}
 			sudo systemctl enable --now docker
 			if systemctl status kubelet 2>&1 > /dev/null; then
@@ -105,7 +118,7 @@ var (
 				docker-{{ $DOCKER_VERSION_TO_INSTALL }} \
 				containerd.io-%s
 			sudo yum versionlock add docker containerd
-			{{ template "container-runtime-daemon-config" . }}
+			{{ template "docker-daemon-config" . }}
 			{{ template "containerd-systemd-setup" . -}}
 			sudo systemctl enable --now docker
 			if systemctl status kubelet 2>&1 > /dev/null; then
@@ -137,7 +150,7 @@ var (
 				docker-ce-cli-{{ $DOCKER_VERSION_TO_INSTALL }} \
 				containerd.io-%s
 			sudo yum versionlock add docker-ce docker-ce-cli containerd.io
-			{{ template "container-runtime-daemon-config" . }}
+			{{ template "docker-daemon-config" . }}
 			{{ template "containerd-systemd-setup" . -}}
 			sudo systemctl enable --now docker
 			if systemctl status kubelet 2>&1 > /dev/null; then
@@ -0,0 +1,454 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smoketest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	namespacePrefix = "kubeone-smoke-test-"
+	serverPort      = 8080
+	pollInterval    = 2 * time.Second
+
+	// isDefaultStorageClassAnnotation marks the default StorageClass
+	isDefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+	resultPassed  = "passed"
+	resultFailed  = "failed"
+	resultSkipped = "skipped"
+)
+
+// DefaultTimeout is a default time given to each check to complete
+const DefaultTimeout = 3 * time.Minute
+
+type checkResult struct {
+	name    string
+	result  string
+	message string
+}
+
+type tester struct {
+	s         *state.State
+	client    dynclient.Client
+	namespace string
+	image     string
+	timeout   time.Duration
+	results   []checkResult
+}
+
+type check struct {
+	name string
+	fn   func(ctx context.Context) (skipReason string, err error)
+}
+
+// Run runs smoke tests against the cluster, prints per-check results and
+// returns an error if any of the checks has failed. All objects created by the
+// smoke tests are removed at the end.
+func Run(s *state.State, timeout time.Duration) error {
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	return newTester(s, timeout).run()
+}
+
+func newTester(s *state.State, timeout time.Duration) *tester {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &tester{
+		s:       s,
+		client:  s.DynamicClient,
+		image:   s.Cluster.RegistryConfiguration.ImageRegistry("docker.io") + "/library/busybox:1.35",
+		timeout: timeout,
+	}
+}
+
+func (t *tester) run() error {
+	s := t.s
+
+	if err := t.createNamespace(); err != nil {
+		return err
+	}
+	defer t.cleanup()
+
+	checks := []check{
+		{name: "pod scheduling", fn: t.checkPodScheduling},
+		{name: "pod-to-pod connectivity", fn: t.checkPodToPod},
+		{name: "DNS resolution", fn: t.checkDNS},
+		{name: "persistent volume provisioning", fn: t.checkPersistentVolume},
+		{name: "LoadBalancer provisioning", fn: t.checkLoadBalancer},
+	}
+
+	for _, c := range checks {
+		s.Logger.Infof("Running %q smoke test...", c.name)
+
+		ctx, cancel := context.WithTimeout(s.Context, t.timeout)
+		skipReason, err := c.fn(ctx)
+		cancel()
+
+		switch {
+		case err != nil:
+			t.results = append(t.results, checkResult{name: c.name, result: resultFailed, message: err.Error()})
+		case skipReason != "":
+			t.results = append(t.results, checkResult{name: c.name, result: resultSkipped, message: skipReason})
+		default:
+			t.results = append(t.results, checkResult{name: c.name, result: resultPassed})
+		}
+	}
+
+	t.print()
+
+	var failed []string
+	for _, r := range t.results {
+		if r.result == resultFailed {
+			failed = append(failed, r.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fail.RuntimeError{
+			Op:  "running smoke tests",
+			Err: errors.Errorf("failed checks: %s", strings.Join(failed, ", ")),
+		}
+	}
+
+	return nil
+}
+
+func (t *tester) print() {
+	printer := tabwriter.New(os.Stdout)
+	defer printer.Flush()
+
+	fmt.Fprintln(printer, "CHECK\tRESULT\tMESSAGE\t")
+	for _, r := range t.results {
+		fmt.Fprintf(printer, "%s\t%s\t%s\t\n", r.name, r.result, r.message)
+	}
+}
+
+func (t *tester) createNamespace() error {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: namespacePrefix,
+		},
+	}
+
+	if err := t.client.Create(t.s.Context, ns); err != nil {
+		return fail.KubeClient(err, "creating smoke tests namespace")
+	}
+
+	t.namespace = ns.Name
+
+	return nil
+}
+
+func (t *tester) cleanup() {
+	t.s.Logger.Infof("Cleaning up smoke tests namespace %q...", t.namespace)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: t.namespace,
+		},
+	}
+
+	// Deleting the namespace deletes all objects created by smoke tests,
+	// including PVCs and LoadBalancer Services (which in turn deletes
+	// cloud resources)
+	if err := dynclient.IgnoreNotFound(t.client.Delete(t.s.Context, ns)); err != nil {
+		t.s.Logger.Warnf("Failed to delete smoke tests namespace %q: %v", t.namespace, err)
+	}
+}
+
+func (t *tester) checkPodScheduling(ctx context.Context) (string, error) {
+	pod := t.pod("scheduling", []string{"sleep", "3600"})
+	if err := t.client.Create(ctx, pod); err != nil {
+		return "", fail.KubeClient(err, "creating pod")
+	}
+
+	_, err := t.waitForPodPhase(ctx, pod.Name, corev1.PodRunning)
+
+	return "", err
+}
+
+func (t *tester) checkPodToPod(ctx context.Context) (string, error) {
+	server, err := t.ensureServer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	client := t.pod("connectivity-client", []string{
+		"wget", "-q", "-O", "-", "-T", "10", fmt.Sprintf("http://%s:%d/", server.Status.PodIP, serverPort),
+	})
+	// Try to place the client on another node than the server, so the
+	// traffic goes over the overlay network
+	client.Spec.Affinity = &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey: corev1.LabelHostname,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "server"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err = t.client.Create(ctx, client); err != nil {
+		return "", fail.KubeClient(err, "creating pod")
+	}
+
+	_, err = t.waitForPodPhase(ctx, client.Name, corev1.PodSucceeded)
+
+	return "", err
+}
+
+func (t *tester) checkDNS(ctx context.Context) (string, error) {
+	name := fmt.Sprintf("kubernetes.default.svc.%s", t.s.Cluster.ClusterNetwork.ServiceDomainName)
+	pod := t.pod("dns", []string{"nslookup", name})
+	if err := t.client.Create(ctx, pod); err != nil {
+		return "", fail.KubeClient(err, "creating pod")
+	}
+
+	_, err := t.waitForPodPhase(ctx, pod.Name, corev1.PodSucceeded)
+
+	return "", err
+}
+
+func (t *tester) checkPersistentVolume(ctx context.Context) (string, error) {
+	scList := storagev1.StorageClassList{}
+	if err := t.client.List(ctx, &scList); err != nil {
+		return "", fail.KubeClient(err, "listing StorageClasses")
+	}
+
+	var defaultSC string
+	for _, sc := range scList.Items {
+		if sc.Annotations[isDefaultStorageClassAnnotation] == "true" {
+			defaultSC = sc.Name
+
+			break
+		}
+	}
+	if defaultSC == "" {
+		return "no default StorageClass found", nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pvc",
+			Namespace: t.namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+	if err := t.client.Create(ctx, pvc); err != nil {
+		return "", fail.KubeClient(err, "creating PersistentVolumeClaim")
+	}
+
+	// A pod is needed to trigger provisioning for StorageClasses with the
+	// WaitForFirstConsumer volume binding mode
+	pod := t.pod("pvc", []string{"sh", "-c", "echo ok > /data/smoke-test && cat /data/smoke-test"})
+	pod.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.Name,
+				},
+			},
+		},
+	}
+	pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      "data",
+			MountPath: "/data",
+		},
+	}
+	if err := t.client.Create(ctx, pod); err != nil {
+		return "", fail.KubeClient(err, "creating pod")
+	}
+
+	err := wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		key := dynclient.ObjectKeyFromObject(pvc)
+		if err := t.client.Get(ctx, key, pvc); err != nil {
+			return false, nil
+		}
+
+		return pvc.Status.Phase == corev1.ClaimBound, nil
+	}, ctx.Done())
+	if err != nil {
+		return "", errors.Errorf("PersistentVolumeClaim using StorageClass %q is not bound (phase: %q)", defaultSC, pvc.Status.Phase)
+	}
+
+	_, err = t.waitForPodPhase(ctx, pod.Name, corev1.PodSucceeded)
+
+	return "", err
+}
+
+func (t *tester) checkLoadBalancer(ctx context.Context) (string, error) {
+	if skipReason := t.loadBalancerSkipReason(); skipReason != "" {
+		return skipReason, nil
+	}
+
+	if _, err := t.ensureServer(ctx); err != nil {
+		return "", err
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "loadbalancer",
+			Namespace: t.namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: map[string]string{"app": "server"},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(serverPort),
+				},
+			},
+		},
+	}
+	if err := t.client.Create(ctx, svc); err != nil {
+		return "", fail.KubeClient(err, "creating LoadBalancer Service")
+	}
+
+	err := wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		key := dynclient.ObjectKeyFromObject(svc)
+		if err := t.client.Get(ctx, key, svc); err != nil {
+			return false, nil
+		}
+
+		return len(svc.Status.LoadBalancer.Ingress) > 0, nil
+	}, ctx.Done())
+	if err != nil {
+		return "", errors.New("LoadBalancer Service didn't get an ingress address")
+	}
+
+	return "", nil
+}
+
+func (t *tester) loadBalancerSkipReason() string {
+	cp := t.s.Cluster.CloudProvider
+	switch {
+	case cp.AWS != nil, cp.Azure != nil, cp.DigitalOcean != nil, cp.GCE != nil, cp.Openstack != nil, cp.EquinixMetal != nil:
+		return ""
+	case cp.Hetzner != nil:
+		return "Hetzner LoadBalancers require a location annotation"
+	default:
+		return fmt.Sprintf("LoadBalancers are not supported by the %q cloud provider", cp.CloudProviderName())
+	}
+}
+
+// ensureServer creates (if not already created) a pod serving HTTP on the
+// serverPort and waits for it to be running
+func (t *tester) ensureServer(ctx context.Context) (*corev1.Pod, error) {
+	server := t.pod("server", []string{
+		"sh", "-c", fmt.Sprintf("mkdir -p /www && echo ok > /www/index.html && httpd -f -p %d -h /www", serverPort),
+	})
+	server.Labels = map[string]string{"app": "server"}
+	server.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	server.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{
+			Name:          "http",
+			ContainerPort: serverPort,
+		},
+	}
+
+	if err := t.client.Create(ctx, server); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, fail.KubeClient(err, "creating server pod")
+	}
+
+	return t.waitForPodPhase(ctx, server.Name, corev1.PodRunning)
+}
+
+func (t *tester) pod(name string, command []string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: t.namespace,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:    name,
+					Image:   t.image,
+					Command: command,
+				},
+			},
+		},
+	}
+}
+
+func (t *tester) waitForPodPhase(ctx context.Context, name string, phase corev1.PodPhase) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	key := dynclient.ObjectKey{Namespace: t.namespace, Name: name}
+
+	err := wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		if err := t.client.Get(ctx, key, pod); err != nil {
+			return false, nil
+		}
+
+		if pod.Status.Phase == corev1.PodFailed && phase != corev1.PodFailed {
+			return false, errors.Errorf("pod %q has failed", name)
+		}
+
+		return pod.Status.Phase == phase, nil
+	}, ctx.Done())
+	if err != nil {
+		if errors.Is(err, wait.ErrWaitTimeout) {
+			return nil, errors.Errorf("timed out waiting for pod %q to be %s (phase: %q)", name, phase, pod.Status.Phase)
+		}
+
+		return nil, err
+	}
+
+	return pod, nil
+}
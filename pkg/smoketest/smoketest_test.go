@@ -0,0 +1,279 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smoketest
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeCluster plays the kubelet, the volume provisioner and the cloud
+// controller manager by setting the status of objects as they are created
+type fakeCluster struct {
+	dynclient.Client
+
+	// failedPods makes pods with the given names end up in the Failed phase
+	failedPods map[string]bool
+	// unboundPVCs leaves PersistentVolumeClaims pending
+	unboundPVCs bool
+	// noLoadBalancers leaves LoadBalancer Services without an ingress address
+	noLoadBalancers bool
+
+	created []dynclient.Object
+}
+
+func (f *fakeCluster) Create(ctx context.Context, obj dynclient.Object, opts ...dynclient.CreateOption) error {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		o.Status.PodIP = "172.25.0.10"
+		switch {
+		case f.failedPods[o.Name]:
+			o.Status.Phase = corev1.PodFailed
+		case o.Name == "scheduling", o.Spec.RestartPolicy == corev1.RestartPolicyAlways:
+			o.Status.Phase = corev1.PodRunning
+		default:
+			o.Status.Phase = corev1.PodSucceeded
+		}
+	case *corev1.PersistentVolumeClaim:
+		if !f.unboundPVCs {
+			o.Status.Phase = corev1.ClaimBound
+		}
+	case *corev1.Service:
+		if !f.noLoadBalancers {
+			o.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "192.0.2.10"}}
+		}
+	}
+
+	if err := f.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	f.created = append(f.created, obj)
+
+	return nil
+}
+
+func TestTester_run(t *testing.T) {
+	t.Parallel()
+
+	defaultStorageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "standard",
+			Annotations: map[string]string{
+				isDefaultStorageClassAnnotation: "true",
+			},
+		},
+		Provisioner: "ebs.csi.aws.com",
+	}
+	aws := kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}}
+
+	tests := []struct {
+		name            string
+		cloudProvider   kubeoneapi.CloudProviderSpec
+		objects         []dynclient.Object
+		fakeCluster     fakeCluster
+		expectedResults []checkResult
+		expectedErr     string
+	}{
+		{
+			name:          "all checks passed",
+			cloudProvider: aws,
+			objects:       []dynclient.Object{defaultStorageClass},
+			expectedResults: []checkResult{
+				{name: "pod scheduling", result: resultPassed},
+				{name: "pod-to-pod connectivity", result: resultPassed},
+				{name: "DNS resolution", result: resultPassed},
+				{name: "persistent volume provisioning", result: resultPassed},
+				{name: "LoadBalancer provisioning", result: resultPassed},
+			},
+		},
+		{
+			name:          "no cloud provider and no default StorageClass",
+			cloudProvider: kubeoneapi.CloudProviderSpec{None: &kubeoneapi.NoneSpec{}},
+			expectedResults: []checkResult{
+				{name: "pod scheduling", result: resultPassed},
+				{name: "pod-to-pod connectivity", result: resultPassed},
+				{name: "DNS resolution", result: resultPassed},
+				{name: "persistent volume provisioning", result: resultSkipped, message: "no default StorageClass found"},
+				{name: "LoadBalancer provisioning", result: resultSkipped, message: `LoadBalancers are not supported by the "none" cloud provider`},
+			},
+		},
+		{
+			name:          "hetzner LoadBalancers skipped",
+			cloudProvider: kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			objects:       []dynclient.Object{defaultStorageClass},
+			expectedResults: []checkResult{
+				{name: "pod scheduling", result: resultPassed},
+				{name: "pod-to-pod connectivity", result: resultPassed},
+				{name: "DNS resolution", result: resultPassed},
+				{name: "persistent volume provisioning", result: resultPassed},
+				{name: "LoadBalancer provisioning", result: resultSkipped, message: "Hetzner LoadBalancers require a location annotation"},
+			},
+		},
+		{
+			name:          "DNS resolution failed",
+			cloudProvider: aws,
+			objects:       []dynclient.Object{defaultStorageClass},
+			fakeCluster: fakeCluster{
+				failedPods: map[string]bool{"dns": true},
+			},
+			expectedResults: []checkResult{
+				{name: "pod scheduling", result: resultPassed},
+				{name: "pod-to-pod connectivity", result: resultPassed},
+				{name: "DNS resolution", result: resultFailed, message: `pod "dns" has failed`},
+				{name: "persistent volume provisioning", result: resultPassed},
+				{name: "LoadBalancer provisioning", result: resultPassed},
+			},
+			expectedErr: "failed checks: DNS resolution",
+		},
+		{
+			name:          "volume and LoadBalancer not provisioned",
+			cloudProvider: aws,
+			objects:       []dynclient.Object{defaultStorageClass},
+			fakeCluster: fakeCluster{
+				unboundPVCs:     true,
+				noLoadBalancers: true,
+			},
+			expectedResults: []checkResult{
+				{name: "pod scheduling", result: resultPassed},
+				{name: "pod-to-pod connectivity", result: resultPassed},
+				{name: "DNS resolution", result: resultPassed},
+				{name: "persistent volume provisioning", result: resultFailed, message: `PersistentVolumeClaim using StorageClass "standard" is not bound (phase: "")`},
+				{name: "LoadBalancer provisioning", result: resultFailed, message: "LoadBalancer Service didn't get an ingress address"},
+			},
+			expectedErr: "failed checks: persistent volume provisioning, LoadBalancer provisioning",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := tt.fakeCluster
+			cluster.Client = fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			s := &state.State{
+				Context:       context.Background(),
+				Logger:        logger,
+				DynamicClient: &cluster,
+				Cluster: &kubeoneapi.KubeOneCluster{
+					CloudProvider: tt.cloudProvider,
+					ClusterNetwork: kubeoneapi.ClusterNetworkConfig{
+						ServiceDomainName: "cluster.local",
+					},
+				},
+			}
+
+			tester := newTester(s, 100*time.Millisecond)
+			err := tester.run()
+			if tt.expectedErr == "" && err != nil {
+				t.Errorf("run() unexpected error = %v", err)
+			}
+			if tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("run() error = %v, expectedErr %q", err, tt.expectedErr)
+			}
+
+			if len(tester.results) != len(tt.expectedResults) {
+				t.Fatalf("run() results = %+v, expected %+v", tester.results, tt.expectedResults)
+			}
+			for i, r := range tester.results {
+				if r != tt.expectedResults[i] {
+					t.Errorf("run() result = %+v, expected %+v", r, tt.expectedResults[i])
+				}
+			}
+
+			assertCleanedUp(t, &cluster, tester.namespace, tt.expectedResults)
+		})
+	}
+}
+
+// assertCleanedUp checks that all objects were created in the smoke tests
+// namespace and that the namespace (and with it the test pods, PVC and
+// LoadBalancer Service) was deleted
+func assertCleanedUp(t *testing.T, cluster *fakeCluster, namespace string, results []checkResult) {
+	t.Helper()
+
+	if !strings.HasPrefix(namespace, namespacePrefix) {
+		t.Fatalf("smoke tests namespace %q doesn't have the %q prefix", namespace, namespacePrefix)
+	}
+
+	err := cluster.Get(context.Background(), dynclient.ObjectKey{Name: namespace}, &corev1.Namespace{})
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("smoke tests namespace %q is not deleted: %v", namespace, err)
+	}
+
+	var pvcCreated, svcCreated bool
+	for _, obj := range cluster.created {
+		switch obj.(type) {
+		case *corev1.Namespace:
+			continue
+		case *corev1.PersistentVolumeClaim:
+			pvcCreated = true
+		case *corev1.Service:
+			svcCreated = true
+		}
+
+		if obj.GetNamespace() != namespace {
+			t.Errorf("%T %q is created outside of the smoke tests namespace %q", obj, obj.GetName(), namespace)
+		}
+	}
+
+	for _, r := range results {
+		switch r.name {
+		case "persistent volume provisioning":
+			if expected := r.result != resultSkipped; pvcCreated != expected {
+				t.Errorf("PersistentVolumeClaim created = %v, expected %v", pvcCreated, expected)
+			}
+		case "LoadBalancer provisioning":
+			if expected := r.result != resultSkipped; svcCreated != expected {
+				t.Errorf("LoadBalancer Service created = %v, expected %v", svcCreated, expected)
+			}
+		}
+	}
+}
+
+func TestRunWithoutKubeClient(t *testing.T) {
+	t.Parallel()
+
+	err := Run(&state.State{Cluster: &kubeoneapi.KubeOneCluster{}}, time.Second)
+	if err == nil {
+		t.Error("Run() expected an error without the kube client")
+	}
+}
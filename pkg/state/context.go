@@ -26,6 +26,7 @@ import (
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/configupload"
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/metrics"
 	"k8c.io/kubeone/pkg/runner"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/templates/images"
@@ -94,6 +95,7 @@ type State struct {
 	Verbose                   bool
 	BackupFile                string
 	DestroyWorkers            bool
+	CordonNodes               bool
 	RemoveBinaries            bool
 	ForceUpgrade              bool
 	ForceInstall              bool
@@ -104,6 +106,16 @@ type State struct {
 	CredentialsFilePath       string
 	ManifestFilePath          string
 	PauseImage                string
+	KubeconfigPath            string
+	KubeContext               string
+	Metrics                   *metrics.Recorder
+}
+
+// ExternalKubeconfig returns true if the user provided a kubeconfig (or
+// kubeconfig context) to be used instead of the admin kubeconfig fetched
+// from the control plane nodes over SSH
+func (s *State) ExternalKubeconfig() bool {
+	return s.KubeconfigPath != "" || s.KubeContext != ""
 }
 
 func (s *State) KubeadmVerboseFlag() string {
@@ -0,0 +1,260 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/ssh/sshiofs"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	apiServerManifestPath = "/etc/kubernetes/manifests/kube-apiserver.yaml"
+)
+
+// ensureAPIServerConfig applies the kube-apiserver options configured via the
+// control plane components, the cluster network (service node port range) and
+// the static auth feature to the kube-apiserver static pods. The control
+// plane nodes are processed one by one and the task waits for the restarted
+// kube-apiserver to become healthy before moving to the next node.
+func ensureAPIServerConfig(s *state.State) error {
+	if err := warnNodePortsOutOfRange(s); err != nil {
+		return err
+	}
+
+	return s.RunTaskOnControlPlane(ensureAPIServerConfigOnNode, state.RunSequentially)
+}
+
+// warnNodePortsOutOfRange warns about Services using NodePorts outside of the
+// configured service node port range. Such Services keep their NodePorts after
+// the range is changed, but the NodePorts can't be allocated again.
+func warnNodePortsOutOfRange(s *state.State) error {
+	if s.Cluster.ClusterNetwork.NodePortRange == "" {
+		return nil
+	}
+
+	low, high, err := s.Cluster.ClusterNetwork.NodePortRangeBounds()
+	if err != nil {
+		return err
+	}
+
+	services := corev1.ServiceList{}
+	if err = s.DynamicClient.List(s.Context, &services); err != nil {
+		return fail.KubeClient(err, "listing services")
+	}
+
+	for _, svc := range nodePortsOutOfRange(services.Items, low, high) {
+		s.Logger.Warnf("Service %s uses NodePort(s) outside of the service node port range %q", svc, s.Cluster.ClusterNetwork.NodePortRange)
+	}
+
+	return nil
+}
+
+// nodePortsOutOfRange returns namespaced names of the Services having at
+// least one NodePort outside of the given range
+func nodePortsOutOfRange(services []corev1.Service, low, high int) []string {
+	var names []string
+
+	for _, svc := range services {
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort != 0 && (int(port.NodePort) < low || int(port.NodePort) > high) {
+				names = append(names, fmt.Sprintf("%s/%s", svc.Namespace, svc.Name))
+
+				break
+			}
+		}
+	}
+
+	return names
+}
+
+func ensureAPIServerConfigOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	return updateStaticPod(s, node, apiServerManifestPath, "kube-apiserver", func(pod *corev1.Pod) bool {
+		return patchAPIServerPod(pod, s.Cluster)
+	})
+}
+
+// updateStaticPod patches the static pod manifest of the given control plane
+// component on the node. If the manifest has been modified, it waits for the
+// Kubelet to restart the component and for the component to become ready.
+func updateStaticPod(s *state.State, node *kubeoneapi.HostConfig, manifestPath, component string, patch func(*corev1.Pod) bool) error {
+	logger := s.Logger.WithField("node", node.PublicAddress)
+
+	sshfs := s.Runner.NewFS()
+	f, err := sshfs.Open(manifestPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	manifest, _ := f.(sshiofs.ExtendedFile)
+
+	buf, err := io.ReadAll(manifest)
+	if err != nil {
+		return err
+	}
+
+	pod := corev1.Pod{}
+	if err = yaml.Unmarshal(buf, &pod); err != nil {
+		return fail.Runtime(err, "unmarshalling %s", manifestPath)
+	}
+
+	if !patch(&pod) {
+		return nil
+	}
+
+	logger.Infof("Restarting %s to apply the configuration...", component)
+
+	buf, err = yaml.Marshal(&pod)
+	if err != nil {
+		return fail.Runtime(err, "marshalling %s", manifestPath)
+	}
+
+	if err = manifest.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err = manifest.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(manifest, bytes.NewBuffer(buf)); err != nil {
+		return fail.Runtime(err, "writing %s", manifestPath)
+	}
+
+	timeout := 30 * time.Second
+	logger.Infof("Waiting %s for Kubelet to roll-out static pods...", timeout)
+	time.Sleep(timeout)
+
+	timeout = 2 * time.Minute
+	logger.Infof("Waiting up to %s for %s to become healthy...", timeout, component)
+
+	return waitForStaticPodReady(s, timeout, fmt.Sprintf("%s-%s", component, node.Hostname), metav1.NamespaceSystem)
+}
+
+// patchAPIServerPod sets the kube-apiserver flags configured via the control
+// plane components, the cluster network and the static auth feature in the
+// kube-apiserver static pod.
+// It returns true if the pod has been modified.
+func patchAPIServerPod(pod *corev1.Pod, cluster *kubeoneapi.KubeOneCluster) bool {
+	if len(pod.Spec.Containers) == 0 {
+		return false
+	}
+
+	container := &pod.Spec.Containers[0]
+	changed := false
+
+	if cluster.ClusterNetwork.NodePortRange != "" {
+		changed = setContainerCommandFlag(container, "service-node-port-range", cluster.ClusterNetwork.NodePortRange) || changed
+	}
+
+	staticAuth := cluster.Features.StaticAuth
+	for flag, value := range staticAuth.APIServerFlags() {
+		changed = setContainerCommandFlag(container, flag, value) || changed
+	}
+
+	if staticAuth.AnonymousAuthDisabled() {
+		changed = useTCPProbes(container) || changed
+	}
+
+	if !cluster.APIServerConfigEnabled() {
+		return changed
+	}
+
+	config := cluster.ControlPlaneComponents.APIServer
+
+	for flag, value := range cluster.APIServerFlags() {
+		changed = setContainerCommandFlag(container, flag, value) || changed
+	}
+
+	if len(config.FeatureGates) > 0 {
+		featureGates, _ := containerCommandFlag(container, "feature-gates")
+		featureGates = kubeadmargs.MergeFeatureGatesFlag(featureGates, config.FeatureGates)
+		changed = setContainerCommandFlag(container, "feature-gates", featureGates) || changed
+	}
+
+	return changed
+}
+
+// useTCPProbes replaces the HTTP probes of the container with TCP probes on
+// the same port. The kube-apiserver probes generated by kubeadm are anonymous
+// HTTP requests, which are rejected when anonymous auth is disabled. It
+// returns true if any probe has been modified.
+func useTCPProbes(container *corev1.Container) bool {
+	changed := false
+
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+		if probe == nil || probe.HTTPGet == nil {
+			continue
+		}
+
+		probe.TCPSocket = &corev1.TCPSocketAction{
+			Host: probe.HTTPGet.Host,
+			Port: probe.HTTPGet.Port,
+		}
+		probe.HTTPGet = nil
+		changed = true
+	}
+
+	return changed
+}
+
+// containerCommandFlag returns the value of the given flag from the container command
+func containerCommandFlag(container *corev1.Container, name string) (string, bool) {
+	prefix := fmt.Sprintf("--%s=", name)
+	for _, arg := range container.Command {
+		if strings.HasPrefix(arg, prefix) {
+			return strings.TrimPrefix(arg, prefix), true
+		}
+	}
+
+	return "", false
+}
+
+// setContainerCommandFlag sets the given flag in the container command. It
+// returns true if the command has been modified.
+func setContainerCommandFlag(container *corev1.Container, name, value string) bool {
+	prefix := fmt.Sprintf("--%s=", name)
+	flag := prefix + value
+
+	for i, arg := range container.Command {
+		if strings.HasPrefix(arg, prefix) {
+			if arg == flag {
+				return false
+			}
+			container.Command[i] = flag
+
+			return true
+		}
+	}
+
+	container.Command = append(container.Command, flag)
+
+	return true
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func genAPIServerPod() corev1.Pod {
+	return corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "kube-apiserver",
+					Command: []string{
+						"kube-apiserver",
+						"--endpoint-reconciler-type=lease",
+						"--feature-gates=CSIMigration=true",
+						"--secure-port=6443",
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_patchAPIServerPod(t *testing.T) {
+	pod := genAPIServerPod()
+	cluster := &kubeoneapi.KubeOneCluster{}
+
+	if patchAPIServerPod(&pod, cluster) {
+		t.Error("expected the pod not to be patched without configuration")
+	}
+
+	config := &kubeoneapi.APIServerConfig{
+		EndpointReconcilerType: "none",
+		FeatureGates: map[string]bool{
+			"APIServerIdentity": true,
+		},
+	}
+	cluster.ControlPlaneComponents = &kubeoneapi.ControlPlaneComponents{APIServer: config}
+
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Fatal("expected the pod to be patched")
+	}
+
+	expected := []string{
+		"kube-apiserver",
+		"--endpoint-reconciler-type=none",
+		"--feature-gates=CSIMigration=true,APIServerIdentity=true",
+		"--secure-port=6443",
+	}
+	if got := pod.Spec.Containers[0].Command; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected command %v, got %v", expected, got)
+	}
+
+	if patchAPIServerPod(&pod, cluster) {
+		t.Error("expected the already patched pod not to be changed")
+	}
+
+	config.FeatureGates["APIServerIdentity"] = false
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Error("expected the pod to be patched on configuration change")
+	}
+	if got, _ := containerCommandFlag(&pod.Spec.Containers[0], "feature-gates"); got != "CSIMigration=true,APIServerIdentity=false" {
+		t.Errorf("expected the feature gate to be updated, got %q", got)
+	}
+}
+
+func Test_patchAPIServerPodNodePortRange(t *testing.T) {
+	pod := genAPIServerPod()
+	cluster := &kubeoneapi.KubeOneCluster{
+		ClusterNetwork: kubeoneapi.ClusterNetworkConfig{
+			NodePortRange: "30000-32767",
+		},
+	}
+
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Fatal("expected the service node port range to be set")
+	}
+	if patchAPIServerPod(&pod, cluster) {
+		t.Error("expected the unchanged service node port range not to modify the pod")
+	}
+
+	cluster.ClusterNetwork.NodePortRange = "20000-22767"
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Fatal("expected the pod to be patched on service node port range change")
+	}
+	if got, _ := containerCommandFlag(&pod.Spec.Containers[0], "service-node-port-range"); got != "20000-22767" {
+		t.Errorf("expected the service node port range to be updated, got %q", got)
+	}
+}
+
+func Test_patchAPIServerPodServiceAccountTokens(t *testing.T) {
+	pod := genAPIServerPod()
+	extend := false
+	config := &kubeoneapi.APIServerConfig{
+		ServiceAccountMaxTokenExpiration:    metav1.Duration{Duration: 2 * time.Hour},
+		ServiceAccountExtendTokenExpiration: &extend,
+	}
+	cluster := &kubeoneapi.KubeOneCluster{
+		ControlPlaneComponents: &kubeoneapi.ControlPlaneComponents{APIServer: config},
+	}
+
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Fatal("expected the service account token flags to be set")
+	}
+	if patchAPIServerPod(&pod, cluster) {
+		t.Error("expected the unchanged service account token flags not to modify the pod")
+	}
+
+	container := &pod.Spec.Containers[0]
+	if got, _ := containerCommandFlag(container, "service-account-max-token-expiration"); got != "2h0m0s" {
+		t.Errorf("expected the max token expiration to be set, got %q", got)
+	}
+	if got, _ := containerCommandFlag(container, "service-account-extend-token-expiration"); got != "false" {
+		t.Errorf("expected the token expiration not to be extended, got %q", got)
+	}
+
+	config.ServiceAccountMaxTokenExpiration.Duration = time.Hour
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Fatal("expected the pod to be patched on max token expiration change")
+	}
+	if got, _ := containerCommandFlag(container, "service-account-max-token-expiration"); got != "1h0m0s" {
+		t.Errorf("expected the max token expiration to be updated, got %q", got)
+	}
+}
+
+func Test_patchAPIServerPodStaticAuth(t *testing.T) {
+	pod := genAPIServerPod()
+	httpProbe := func(path string) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Host:   "10.0.0.1",
+					Path:   path,
+					Port:   intstr.FromInt(6443),
+					Scheme: corev1.URISchemeHTTPS,
+				},
+			},
+		}
+	}
+	container := &pod.Spec.Containers[0]
+	container.LivenessProbe = httpProbe("/livez")
+	container.ReadinessProbe = httpProbe("/readyz")
+	container.StartupProbe = httpProbe("/livez")
+
+	anonymousAuth, profiling := true, false
+	cluster := &kubeoneapi.KubeOneCluster{
+		Features: kubeoneapi.Features{
+			StaticAuth: &kubeoneapi.StaticAuth{
+				AnonymousAuth: &anonymousAuth,
+				Profiling:     &profiling,
+			},
+		},
+	}
+
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Fatal("expected the static auth flags to be set")
+	}
+	if got, _ := containerCommandFlag(container, "profiling"); got != "false" {
+		t.Errorf("expected profiling to be disabled, got %q", got)
+	}
+	if container.LivenessProbe.HTTPGet == nil {
+		t.Error("expected the HTTP probes to be kept with anonymous auth enabled")
+	}
+
+	anonymousAuth = false
+	if !patchAPIServerPod(&pod, cluster) {
+		t.Fatal("expected the pod to be patched when disabling anonymous auth")
+	}
+	if got, _ := containerCommandFlag(container, "anonymous-auth"); got != "false" {
+		t.Errorf("expected anonymous auth to be disabled, got %q", got)
+	}
+
+	expectedProbe := &corev1.TCPSocketAction{Host: "10.0.0.1", Port: intstr.FromInt(6443)}
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+		if probe.HTTPGet != nil || !reflect.DeepEqual(probe.TCPSocket, expectedProbe) {
+			t.Errorf("expected TCP probe %v, got %+v", expectedProbe, probe.ProbeHandler)
+		}
+	}
+
+	if patchAPIServerPod(&pod, cluster) {
+		t.Error("expected the already patched pod not to be changed")
+	}
+}
+
+func Test_nodePortsOutOfRange(t *testing.T) {
+	genService := func(name string, nodePorts ...int32) corev1.Service {
+		svc := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+		for _, nodePort := range nodePorts {
+			svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{NodePort: nodePort})
+		}
+
+		return svc
+	}
+
+	services := []corev1.Service{
+		genService("cluster-ip"),
+		genService("in-range", 30000, 32767),
+		genService("below-range", 29999),
+		genService("partially-above-range", 31000, 32768),
+	}
+
+	expected := []string{"default/below-range", "default/partially-above-range"}
+	if got := nodePortsOutOfRange(services, 30000, 32767); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func Test_setContainerCommandFlag(t *testing.T) {
+	container := &corev1.Container{
+		Command: []string{"kube-apiserver"},
+	}
+
+	if !setContainerCommandFlag(container, "endpoint-reconciler-type", "lease") {
+		t.Fatal("expected the flag to be appended")
+	}
+	if setContainerCommandFlag(container, "endpoint-reconciler-type", "lease") {
+		t.Error("expected the unchanged flag not to modify the command")
+	}
+	if len(container.Command) != 2 || container.Command[1] != "--endpoint-reconciler-type=lease" {
+		t.Errorf("unexpected command %v", container.Command)
+	}
+}
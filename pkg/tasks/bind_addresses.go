@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/features"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	etcdManifestPath = "/etc/kubernetes/manifests/etcd.yaml"
+
+	// listNodeAddressesCommand prints all addresses assigned to the node
+	// interfaces, one per line
+	listNodeAddressesCommand = "ip -o addr show"
+
+	etcdLocalClientURL = "https://127.0.0.1:2379"
+)
+
+// verifyBindAddresses verifies that the configured bind addresses are
+// assigned to the node interfaces
+func verifyBindAddresses(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	addrs := node.BindAddresses.Addresses()
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	stdout, _, err := s.Runner.RunRaw(listNodeAddressesCommand)
+	if err != nil {
+		return fail.SSH(err, "listing addresses of %q", node.Hostname)
+	}
+
+	if missing := missingAddresses(stdout, addrs); len(missing) > 0 {
+		return fail.ConfigValidation(fmt.Errorf("bind addresses %s are not assigned to any interface of %q", strings.Join(missing, ", "), node.Hostname))
+	}
+
+	return nil
+}
+
+// missingAddresses returns the addresses not found in the output of the
+// `ip -o addr show` command. The unspecified addresses (0.0.0.0 and ::) are
+// never missing.
+func missingAddresses(ipAddrOutput string, addrs []string) []string {
+	assigned := map[string]bool{}
+	for _, line := range strings.Split(ipAddrOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || (fields[2] != "inet" && fields[2] != "inet6") {
+			continue
+		}
+		if ip, _, err := net.ParseCIDR(fields[3]); err == nil {
+			assigned[ip.String()] = true
+		}
+	}
+
+	var missing []string
+	for _, addr := range addrs {
+		ip := net.ParseIP(addr)
+		if ip == nil || (!ip.IsUnspecified() && !assigned[ip.String()]) {
+			missing = append(missing, addr)
+		}
+	}
+
+	return missing
+}
+
+// ensureBindAddresses makes the control plane components listen on the
+// configured bind addresses. The control plane nodes are processed one by one
+// and the task waits for each restarted component to become healthy.
+func ensureBindAddresses(s *state.State) error {
+	return s.RunTaskOnControlPlane(ensureBindAddressesOnNode, state.RunSequentially)
+}
+
+func ensureBindAddressesOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	ba := node.BindAddresses
+	if len(ba.Addresses()) == 0 {
+		return nil
+	}
+
+	if err := verifyBindAddresses(s, node, conn); err != nil {
+		return err
+	}
+
+	if ba.Etcd != "" {
+		err := updateStaticPod(s, node, etcdManifestPath, "etcd", func(pod *corev1.Pod) bool {
+			return patchEtcdBindAddress(pod, ba.Etcd, s.Cluster.Features.EtcdMetrics)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	components := []struct {
+		manifestPath string
+		name         string
+		address      string
+	}{
+		{manifestPath: apiServerManifestPath, name: "kube-apiserver", address: ba.APIServer},
+		{manifestPath: controllerManagerManifestPath, name: "kube-controller-manager", address: ba.ControllerManager},
+		{manifestPath: schedulerManifestPath, name: "kube-scheduler", address: ba.Scheduler},
+	}
+
+	for _, component := range components {
+		if component.address == "" {
+			continue
+		}
+
+		address := component.address
+		err := updateStaticPod(s, node, component.manifestPath, component.name, func(pod *corev1.Pod) bool {
+			return patchBindAddress(pod, address)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// patchEtcdBindAddress makes etcd listen for the client, peer and metrics
+// connections on the given address. The loopback client and metrics URLs are
+// kept, because they're used by the local kube-apiserver and the etcd probes.
+// It returns true if the pod has been modified.
+func patchEtcdBindAddress(pod *corev1.Pod, address string, metrics *kubeoneapi.EtcdMetrics) bool {
+	if len(pod.Spec.Containers) == 0 {
+		return false
+	}
+
+	container := &pod.Spec.Containers[0]
+	changed := false
+
+	changed = setContainerCommandFlag(container, "listen-client-urls",
+		fmt.Sprintf("%s,https://%s", etcdLocalClientURL, net.JoinHostPort(address, "2379"))) || changed
+	changed = setContainerCommandFlag(container, "listen-peer-urls",
+		fmt.Sprintf("https://%s", net.JoinHostPort(address, "2380"))) || changed
+
+	if metrics.Enabled() {
+		changed = setContainerCommandFlag(container, "listen-metrics-urls",
+			fmt.Sprintf("%s,https://%s", features.EtcdLocalMetricsURL, net.JoinHostPort(address, strconv.Itoa(metrics.Port)))) || changed
+	}
+
+	return changed
+}
+
+// patchBindAddress sets the --bind-address flag of the control plane
+// component and points its HTTP probes to the same address. It returns true
+// if the pod has been modified.
+func patchBindAddress(pod *corev1.Pod, address string) bool {
+	if len(pod.Spec.Containers) == 0 {
+		return false
+	}
+
+	container := &pod.Spec.Containers[0]
+	changed := setContainerCommandFlag(container, "bind-address", address)
+
+	// probes can't connect to the unspecified address, so the probe host
+	// generated by kubeadm is kept in that case
+	if ip := net.ParseIP(address); ip == nil || ip.IsUnspecified() {
+		return changed
+	}
+
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+		if probe == nil || probe.HTTPGet == nil || probe.HTTPGet.Host == address {
+			continue
+		}
+
+		probe.HTTPGet.Host = address
+		changed = true
+	}
+
+	return changed
+}
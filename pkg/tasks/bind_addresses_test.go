@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_missingAddresses(t *testing.T) {
+	ipAddrOutput := heredoc.Doc(`
+		1: lo    inet 127.0.0.1/8 scope host lo\       valid_lft forever preferred_lft forever
+		1: lo    inet6 ::1/128 scope host \       valid_lft forever preferred_lft forever
+		2: eth0    inet 10.0.0.5/24 brd 10.0.0.255 scope global eth0\       valid_lft forever preferred_lft forever
+		2: eth0    inet6 fd00::5/64 scope global \       valid_lft forever preferred_lft forever
+	`)
+
+	tests := []struct {
+		name  string
+		addrs []string
+		want  []string
+	}{
+		{
+			name:  "all addresses assigned",
+			addrs: []string{"10.0.0.5", "127.0.0.1", "fd00::5"},
+		},
+		{
+			name:  "unspecified addresses",
+			addrs: []string{"0.0.0.0", "::"},
+		},
+		{
+			name:  "missing addresses",
+			addrs: []string{"10.0.0.5", "10.0.0.6", "192.168.1.5"},
+			want:  []string{"10.0.0.6", "192.168.1.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingAddresses(ipAddrOutput, tt.addrs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingAddresses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_patchEtcdBindAddress(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "etcd",
+					Command: []string{
+						"etcd",
+						"--listen-client-urls=https://127.0.0.1:2379,https://10.0.0.5:2379",
+						"--listen-metrics-urls=http://127.0.0.1:2381,https://0.0.0.0:2378",
+						"--listen-peer-urls=https://10.0.0.5:2380",
+					},
+				},
+			},
+		},
+	}
+
+	if patchEtcdBindAddress(&pod, "10.0.0.5", nil) {
+		t.Error("expected the pod not to be patched when listening on the advertised address")
+	}
+
+	if !patchEtcdBindAddress(&pod, "10.0.0.5", &kubeoneapi.EtcdMetrics{Enable: true, Port: 2378}) {
+		t.Fatal("expected the pod to be patched")
+	}
+
+	want := []string{
+		"etcd",
+		"--listen-client-urls=https://127.0.0.1:2379,https://10.0.0.5:2379",
+		"--listen-metrics-urls=http://127.0.0.1:2381,https://10.0.0.5:2378",
+		"--listen-peer-urls=https://10.0.0.5:2380",
+	}
+	if !reflect.DeepEqual(pod.Spec.Containers[0].Command, want) {
+		t.Errorf("got command %v, want %v", pod.Spec.Containers[0].Command, want)
+	}
+}
+
+func Test_patchBindAddress(t *testing.T) {
+	newPod := func() corev1.Pod {
+		return corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "kube-scheduler",
+						Command: []string{
+							"kube-scheduler",
+							"--bind-address=127.0.0.1",
+						},
+						LivenessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Host:   "127.0.0.1",
+									Path:   "/healthz",
+									Port:   intstr.FromInt(10259),
+									Scheme: corev1.URISchemeHTTPS,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	pod := newPod()
+	if patchBindAddress(&pod, "127.0.0.1") {
+		t.Error("expected the pod not to be patched with the same address")
+	}
+
+	pod = newPod()
+	if !patchBindAddress(&pod, "10.0.0.5") {
+		t.Fatal("expected the pod to be patched")
+	}
+	container := pod.Spec.Containers[0]
+	if container.Command[1] != "--bind-address=10.0.0.5" {
+		t.Errorf("got bind address flag %q", container.Command[1])
+	}
+	if container.LivenessProbe.HTTPGet.Host != "10.0.0.5" {
+		t.Errorf("got liveness probe host %q, want 10.0.0.5", container.LivenessProbe.HTTPGet.Host)
+	}
+
+	pod = newPod()
+	if !patchBindAddress(&pod, "0.0.0.0") {
+		t.Fatal("expected the pod to be patched")
+	}
+	if host := pod.Spec.Containers[0].LivenessProbe.HTTPGet.Host; host != "127.0.0.1" {
+		t.Errorf("expected the probe host to be kept for the unspecified address, got %q", host)
+	}
+}
@@ -21,15 +21,19 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/fs"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/certificate"
 	"k8c.io/kubeone/pkg/certificate/cabundle"
 	"k8c.io/kubeone/pkg/clientutil"
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/features"
 	"k8c.io/kubeone/pkg/kubeconfig"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
@@ -44,6 +48,8 @@ import (
 )
 
 const (
+	apiServerCertPath = "/etc/kubernetes/pki/apiserver.crt"
+
 	nodeUser = "system:node"
 
 	groupNodes         = "system:nodes"
@@ -93,6 +99,102 @@ func renewControlPlaneCerts(s *state.State) error {
 	return kubeconfig.BuildKubernetesClientset(s)
 }
 
+// ensureAPIServerCertSANs regenerates the kube-apiserver serving certificate
+// on the control plane nodes where it doesn't include all configured SANs,
+// e.g. after the internal or standby API endpoints have been added to an
+// existing cluster. `kubeadm certs renew` keeps the SANs of the existing
+// certificate, so renewing doesn't add them.
+func ensureAPIServerCertSANs(s *state.State) error {
+	sans := apiServerCertSANs(s.Cluster)
+	kubeadmConfigGenerated := false
+
+	return s.RunTaskOnControlPlane(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		logger := s.Logger.WithField("node", node.PublicAddress)
+
+		cert, err := fetchCert(s.Runner.NewFS(), apiServerCertPath)
+		if err != nil {
+			return err
+		}
+
+		missing := missingCertSANs(cert, sans)
+		if len(missing) == 0 {
+			return nil
+		}
+
+		// the kubeadm configuration on the nodes might be generated by the
+		// older KubeOne run, not including the missing SANs
+		if !kubeadmConfigGenerated {
+			if err = generateKubeadm(s); err != nil {
+				return err
+			}
+			kubeadmConfigGenerated = true
+		}
+
+		logger.Infof("Regenerating kube-apiserver certificate to add %s SANs...", strings.Join(missing, ", "))
+
+		cmd, err := scripts.KubeadmCertAPIServer(s.WorkDir, node.ID, s.KubeadmVerboseFlag())
+		if err != nil {
+			return err
+		}
+
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			return fail.SSH(err, "regenerating kube-apiserver certificate")
+		}
+
+		if err = ensureRestartKubeAPIServerOnOS(s, *node); err != nil {
+			return err
+		}
+
+		timeout := 2 * time.Minute
+		logger.Infof("Waiting up to %s for kube-apiserver to become healthy...", timeout)
+
+		return waitForStaticPodReady(s, timeout, fmt.Sprintf("kube-apiserver-%s", node.Hostname), metav1.NamespaceSystem)
+	}, state.RunSequentially)
+}
+
+// apiServerCertSANs returns the SANs KubeOne configures kubeadm to add to the
+// kube-apiserver serving certificate
+func apiServerCertSANs(cluster *kubeoneapi.KubeOneCluster) []string {
+	sans := certificate.GetCertificateSANs(cluster.APIEndpoint.Host, cluster.APIEndpoint.CertificateAlternativeNames())
+
+	return append(sans, cluster.ControlPlaneDNSNames()...)
+}
+
+// missingCertSANs returns the SANs not included in the certificate
+func missingCertSANs(cert *x509.Certificate, sans []string) []string {
+	dnsNames := sets.NewString()
+	for _, name := range cert.DNSNames {
+		dnsNames.Insert(strings.ToLower(name))
+	}
+
+	missing := []string{}
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			if !containsIP(cert.IPAddresses, ip) {
+				missing = append(missing, san)
+			}
+
+			continue
+		}
+
+		if !dnsNames.Has(strings.ToLower(san)) {
+			missing = append(missing, san)
+		}
+	}
+
+	return missing
+}
+
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, i := range ips {
+		if i.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func fetchCert(sshfs fs.FS, filename string) (*x509.Certificate, error) {
 	buf, err := fs.ReadFile(sshfs, filename)
 	if err != nil {
@@ -185,6 +287,45 @@ func saveCABundleOnControlPlane(s *state.State, _ *kubeoneapi.HostConfig, conn s
 	return fail.SSH(err, "save CABundle")
 }
 
+func saveClientCABundle(s *state.State) error {
+	const clientCABundleFile = "cfg/client-ca-bundle.crt"
+
+	staticAuth := s.Cluster.Features.StaticAuth
+	if staticAuth.ClientCABundleFilePath != "" {
+		if err := s.Configuration.AddFilePath(clientCABundleFile, staticAuth.ClientCABundleFilePath, s.ManifestFilePath); err != nil {
+			return err
+		}
+	} else {
+		s.Configuration.AddFile(clientCABundleFile, staticAuth.ClientCABundle)
+	}
+
+	bundle, err := s.Configuration.Get(clientCABundleFile)
+	if err != nil {
+		return err
+	}
+
+	if ok := x509.NewCertPool().AppendCertsFromPEM([]byte(bundle)); !ok {
+		return fail.ConfigValidation(errors.New("can't parse .features.staticAuth client CA bundle, a valid PEM-encoded bundle is required"))
+	}
+
+	return s.RunTaskOnControlPlane(saveClientCABundleOnControlPlane, state.RunSequentially)
+}
+
+func saveClientCABundleOnControlPlane(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	if err := s.Configuration.UploadTo(conn, s.WorkDir); err != nil {
+		return err
+	}
+
+	cmd, err := scripts.SaveClientCABundle(s.WorkDir, features.ClientCABundlePath)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.Runner.RunRaw(cmd)
+
+	return fail.SSH(err, "save client CA bundle")
+}
+
 func approvePendingCSR(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 	var csrFound bool
 	sleepTime := 20 * time.Second
@@ -212,35 +353,17 @@ func approvePendingCSR(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Con
 			continue
 		}
 
-		var approved bool
-		for _, cond := range csr.Status.Conditions {
-			if cond.Type == certificatesv1.CertificateApproved && cond.Status == corev1.ConditionTrue {
-				approved = true
-			}
-		}
-		if approved {
+		if isCSRApproved(csr) {
 			// CSR matched but it's already approved
 			csrFound = true
 
 			continue
 		}
 
-		if err := validateCSR(csr.Spec); err != nil {
-			return err
-		}
 		csrFound = true
 
-		csr := csr.DeepCopy()
-		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
-			Type:   certificatesv1.CertificateApproved,
-			Reason: "kubeone approved node serving cert",
-			Status: corev1.ConditionTrue,
-		})
-
-		s.Logger.Infof("Approve pending CSR %q for username %q", csr.Name, csr.Spec.Username)
-		_, err := certClient.UpdateApproval(s.Context, csr.Name, csr, metav1.UpdateOptions{})
-		if err != nil {
-			return fail.KubeClient(err, "approving CSR %q", csr.Name)
+		if err := approveCSR(s, certClient, csr); err != nil {
+			return err
 		}
 	}
 
@@ -251,6 +374,82 @@ func approvePendingCSR(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Con
 	return nil
 }
 
+// approvePendingServingCSRs approves all pending kubelet serving CSRs of the
+// control plane and static worker nodes, including CSRs created by kubelets
+// when rotating their serving certificates
+func approvePendingServingCSRs(s *state.State) error {
+	usernames := sets.NewString()
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		usernames.Insert(fmt.Sprintf("%s:%s", nodeUser, host.Hostname))
+	}
+	for _, host := range s.Cluster.StaticWorkers.Hosts {
+		usernames.Insert(fmt.Sprintf("%s:%s", nodeUser, host.Hostname))
+	}
+
+	csrList := certificatesv1.CertificateSigningRequestList{}
+	if err := s.DynamicClient.List(s.Context, &csrList); err != nil {
+		return fail.KubeClient(err, "getting %T", csrList)
+	}
+
+	certv1Client, err := certificatesv1client.NewForConfig(s.RESTConfig)
+	if err != nil {
+		return fail.KubeClient(err, "creating certificates v1client")
+	}
+	certClient := certv1Client.CertificateSigningRequests()
+
+	for _, csr := range csrList.Items {
+		if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName || !usernames.Has(csr.Spec.Username) {
+			continue
+		}
+
+		if isCSRApproved(csr) || isCSRDenied(csr) {
+			continue
+		}
+
+		if err := approveCSR(s, certClient, csr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func approveCSR(s *state.State, certClient certificatesv1client.CertificateSigningRequestInterface, csr certificatesv1.CertificateSigningRequest) error {
+	if err := validateCSR(csr.Spec); err != nil {
+		return err
+	}
+
+	approvedCSR := csr.DeepCopy()
+	approvedCSR.Status.Conditions = append(approvedCSR.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:   certificatesv1.CertificateApproved,
+		Reason: "kubeone approved node serving cert",
+		Status: corev1.ConditionTrue,
+	})
+
+	s.Logger.Infof("Approve pending CSR %q for username %q", approvedCSR.Name, approvedCSR.Spec.Username)
+	_, err := certClient.UpdateApproval(s.Context, approvedCSR.Name, approvedCSR, metav1.UpdateOptions{})
+
+	return fail.KubeClient(err, "approving CSR %q", approvedCSR.Name)
+}
+
+func isCSRApproved(csr certificatesv1.CertificateSigningRequest) bool {
+	return hasCSRCondition(csr, certificatesv1.CertificateApproved)
+}
+
+func isCSRDenied(csr certificatesv1.CertificateSigningRequest) bool {
+	return hasCSRCondition(csr, certificatesv1.CertificateDenied)
+}
+
+func hasCSRCondition(csr certificatesv1.CertificateSigningRequest, condType certificatesv1.RequestConditionType) bool {
+	for _, cond := range csr.Status.Conditions {
+		if cond.Type == condType && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validateCSR(spec certificatesv1.CertificateSigningRequestSpec) error {
 	if !sets.NewString(spec.Groups...).HasAll(groupNodes, groupAuthenticated) {
 		return fail.Runtime(errors.New("CSR groups is expecter to be an authenticated node"), "")
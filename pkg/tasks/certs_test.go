@@ -17,8 +17,13 @@ limitations under the License.
 package tasks
 
 import (
+	"crypto/x509"
+	"net"
+	"reflect"
 	"testing"
 	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 )
 
 func Test_timeBefore(t *testing.T) {
@@ -65,3 +70,63 @@ func Test_timeBefore(t *testing.T) {
 		})
 	}
 }
+
+func Test_missingCertSANs(t *testing.T) {
+	cert := &x509.Certificate{
+		DNSNames:    []string{"kubernetes", "API.example.com", "api-internal.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.96.0.1"), net.ParseIP("192.168.1.10")},
+	}
+
+	tests := []struct {
+		name string
+		sans []string
+		want []string
+	}{
+		{
+			name: "all included",
+			sans: []string{"api.example.com", "api-internal.example.com", "192.168.1.10"},
+			want: []string{},
+		},
+		{
+			name: "missing standby host",
+			sans: []string{"api.example.com", "api-standby.example.com"},
+			want: []string{"api-standby.example.com"},
+		},
+		{
+			name: "missing IP",
+			sans: []string{"api.example.com", "192.168.1.20", "fd00::20"},
+			want: []string{"192.168.1.20", "fd00::20"},
+		},
+		{
+			name: "IP is not matched as DNS name",
+			sans: []string{"kubernetes", "10.96.0.1", "10.96.0.10"},
+			want: []string{"10.96.0.10"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingCertSANs(cert, tt.sans); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingCertSANs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiServerCertSANs(t *testing.T) {
+	cluster := &kubeoneapi.KubeOneCluster{
+		APIEndpoint: kubeoneapi.APIEndpoint{
+			Host:             "API.example.com",
+			AlternativeNames: []string{"192.168.1.10"},
+			InternalHost:     "api-internal.example.com",
+			StandbyEndpoints: []kubeoneapi.APIEndpointStandby{{Host: "api-standby.example.com"}},
+		},
+	}
+
+	got := apiServerCertSANs(cluster)
+	want := []string{"api.example.com", "192.168.1.10", "api-internal.example.com", "api-standby.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("apiServerCertSANs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// verifyCgroupVersion detects the cgroup version of every node and verifies
+// that it's the configured one. If enabled, cgroup v2 is enabled on the nodes
+// not provisioned yet by configuring the kernel command line and rebooting
+// the nodes.
+func verifyCgroupVersion(s *state.State) error {
+	return s.RunTaskOnAllNodes(verifyCgroupVersionOnNode, state.RunParallel)
+}
+
+func verifyCgroupVersionOnNode(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	cgroups := s.Cluster.ContainerRuntime.Cgroups
+
+	version, err := detectCgroupVersion(s, node)
+	if err != nil {
+		return err
+	}
+
+	s.Logger.Infof("Detected cgroup %s", version)
+
+	if version == cgroups.Version {
+		return nil
+	}
+
+	mismatchErr := fail.ConfigValidation(fmt.Errorf("node %q uses cgroup %s, but cgroup %s is required", node.Hostname, version, cgroups.Version))
+
+	if !cgroups.ConfigureKernelCommandLine || nodeProvisioned(s, node) {
+		return mismatchErr
+	}
+
+	s.Logger.Infoln("Enabling cgroup v2... the node will be rebooted...")
+	if _, _, err = s.Runner.RunRaw(scripts.EnableCgroupV2()); err != nil {
+		return fail.SSH(err, "configuring kernel command line on %q", node.Hostname)
+	}
+
+	// Intentionally ignore error because restarting machines causes
+	// the connection to error
+	_, _, _ = s.Runner.RunRaw(scripts.Reboot())
+
+	timeout := 1 * time.Minute
+	s.Logger.Infof("Waiting for %s before proceeding to give machine time to boot up...", timeout)
+	time.Sleep(timeout)
+
+	// The connection is closed, so it's reinitialized when verifying the
+	// cgroup version after the reboot
+	s.Runner.Conn.Close()
+
+	return s.RunTaskOnNodes([]kubeoneapi.HostConfig{*node}, func(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		version, err := detectCgroupVersion(s, node)
+		if err != nil {
+			return err
+		}
+
+		s.Logger.Infof("Detected cgroup %s after reboot", version)
+		if version != cgroups.Version {
+			return mismatchErr
+		}
+
+		return nil
+	}, state.RunSequentially)
+}
+
+// detectCgroupVersion returns the cgroup version the node is booted with
+func detectCgroupVersion(s *state.State, node *kubeoneapi.HostConfig) (string, error) {
+	stdout, _, err := s.Runner.RunRaw(scripts.DetectCgroupVersion())
+	if err != nil {
+		return "", fail.SSH(err, "detecting cgroup version on %q", node.Hostname)
+	}
+
+	return cgroupVersionFromFSType(stdout)
+}
+
+// cgroupVersionFromFSType returns the cgroup version based on the type of
+// the filesystem mounted at /sys/fs/cgroup
+func cgroupVersionFromFSType(fsType string) (string, error) {
+	switch strings.TrimSpace(fsType) {
+	case "cgroup2fs":
+		return kubeoneapi.CgroupVersionV2, nil
+	case "tmpfs":
+		return kubeoneapi.CgroupVersionV1, nil
+	default:
+		return "", fail.Runtime(fmt.Errorf("unknown filesystem type %q", strings.TrimSpace(fsType)), "detecting cgroup version")
+	}
+}
+
+// nodeProvisioned reports whether kubelet is already initialized on the node
+func nodeProvisioned(s *state.State, node *kubeoneapi.HostConfig) bool {
+	hosts := append(append([]state.Host{}, s.LiveCluster.ControlPlane...), s.LiveCluster.StaticWorkers...)
+	for _, host := range hosts {
+		if host.Config.ID == node.ID {
+			return host.Initialized()
+		}
+	}
+
+	return false
+}
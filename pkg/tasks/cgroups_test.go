@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func Test_cgroupVersionFromFSType(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsType  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "unified hierarchy",
+			fsType: "cgroup2fs\n",
+			want:   kubeoneapi.CgroupVersionV2,
+		},
+		{
+			name:   "legacy hierarchy",
+			fsType: "tmpfs\n",
+			want:   kubeoneapi.CgroupVersionV1,
+		},
+		{
+			name:    "unknown filesystem",
+			fsType:  "ext4\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cgroupVersionFromFSType(tt.fsType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cgroupVersionFromFSType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("cgroupVersionFromFSType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,14 +17,26 @@ limitations under the License.
 package tasks
 
 import (
+	"sort"
+	"time"
+
 	"github.com/pkg/errors"
 
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/state"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// externalCNINodesReadyTimeout is how long we wait for the externally
+	// managed CNI to make nodes ready
+	externalCNINodesReadyTimeout = 15 * time.Minute
+)
+
 func ensureCNI(s *state.State) error {
 	var err error
 	if s.Cluster.ClusterNetwork.CNI.External != nil {
@@ -45,3 +57,64 @@ func ensureCNI(s *state.State) error {
 
 	return nil
 }
+
+// waitForNodesReady waits for all control plane and static worker nodes to
+// become Ready. It's used when the CNI plugin is managed outside of KubeOne,
+// in which case nodes are NotReady until the external CNI gets deployed.
+func waitForNodesReady(s *state.State) error {
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	hostnames := sets.NewString()
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		hostnames.Insert(host.Hostname)
+	}
+	for _, host := range s.Cluster.StaticWorkers.Hosts {
+		hostnames.Insert(host.Hostname)
+	}
+
+	s.Logger.Infoln("Waiting for nodes to become ready, the external CNI plugin must provide networking...")
+
+	var notReady []string
+	err := wait.PollImmediate(10*time.Second, externalCNINodesReadyTimeout, func() (bool, error) {
+		nodes := corev1.NodeList{}
+		if err := s.DynamicClient.List(s.Context, &nodes); err != nil {
+			s.Logger.Debugf("Failed to list nodes: %v", err)
+
+			return false, nil
+		}
+
+		notReady = nodesNotReady(nodes.Items, hostnames)
+		if len(notReady) > 0 {
+			s.Logger.Debugf("Nodes not ready yet: %v", notReady)
+
+			return false, nil
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return fail.KubeClient(err, "waiting for nodes %v to become ready, make sure the external CNI plugin is deployed", notReady)
+	}
+
+	return nil
+}
+
+// nodesNotReady returns sorted names of expected nodes that are either missing
+// or don't have the Ready condition set to true
+func nodesNotReady(nodes []corev1.Node, expected sets.String) []string {
+	ready := sets.NewString()
+	for _, node := range nodes {
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+				ready.Insert(node.Name)
+			}
+		}
+	}
+
+	notReady := expected.Difference(ready).UnsortedList()
+	sort.Strings(notReady)
+
+	return notReady
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func genNode(name string, status corev1.ConditionStatus) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+				{Type: corev1.NodeReady, Status: status},
+			},
+		},
+	}
+}
+
+func Test_nodesNotReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodes    []corev1.Node
+		expected sets.String
+		want     []string
+	}{
+		{
+			name: "all nodes ready",
+			nodes: []corev1.Node{
+				genNode("cp-1", corev1.ConditionTrue),
+				genNode("cp-2", corev1.ConditionTrue),
+			},
+			expected: sets.NewString("cp-1", "cp-2"),
+			want:     []string{},
+		},
+		{
+			name: "node not ready",
+			nodes: []corev1.Node{
+				genNode("cp-1", corev1.ConditionTrue),
+				genNode("cp-2", corev1.ConditionFalse),
+				genNode("cp-3", corev1.ConditionUnknown),
+			},
+			expected: sets.NewString("cp-1", "cp-2", "cp-3"),
+			want:     []string{"cp-2", "cp-3"},
+		},
+		{
+			name: "node not registered yet",
+			nodes: []corev1.Node{
+				genNode("cp-1", corev1.ConditionTrue),
+			},
+			expected: sets.NewString("cp-1", "worker-1"),
+			want:     []string{"worker-1"},
+		},
+		{
+			name: "unexpected nodes are ignored",
+			nodes: []corev1.Node{
+				genNode("cp-1", corev1.ConditionTrue),
+				genNode("md-worker-1", corev1.ConditionFalse),
+			},
+			expected: sets.NewString("cp-1"),
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodesNotReady(tt.nodes, tt.expected); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nodesNotReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
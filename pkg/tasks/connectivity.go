@@ -0,0 +1,292 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+const (
+	// connectivityListenersTimeout is the time in seconds after which the
+	// temporary listeners are stopped even if the cleanup fails
+	connectivityListenersTimeout = 300
+
+	// vxlanOverhead is the overhead of the VXLAN encapsulation used by Canal
+	vxlanOverhead = 50
+
+	connectivityResultMTU = "mtu"
+)
+
+// connectivityRequirement is a TCP port on the target node that must be
+// reachable from the source node
+type connectivityRequirement struct {
+	source  kubeoneapi.HostConfig
+	target  kubeoneapi.HostConfig
+	port    int
+	purpose string
+}
+
+func (r connectivityRequirement) String() string {
+	return fmt.Sprintf("%s -> %s:%d (%s)", r.source.PrivateAddress, r.target.PrivateAddress, r.port, r.purpose)
+}
+
+// verifyNodeConnectivity verifies that the ports required by the cluster
+// components are reachable between the nodes and that the path MTU is
+// sufficient. Temporary listeners are started on the ports not used yet.
+func verifyNodeConnectivity(s *state.State) error {
+	s.Logger.Infoln("Verifying node-to-node connectivity...")
+
+	requirements := connectivityRequirements(s.Cluster)
+	mtu := connectivityCheckMTU(s.Cluster)
+
+	allNodes := append(append([]kubeoneapi.HostConfig{}, s.Cluster.ControlPlane.Hosts...), s.Cluster.StaticWorkers.Hosts...)
+
+	targetPorts := map[string][]int{}
+	for _, r := range requirements {
+		targetPorts[r.target.PrivateAddress] = append(targetPorts[r.target.PrivateAddress], r.port)
+	}
+
+	defer func() {
+		if err := s.RunTaskOnAllNodes(stopConnectivityListeners, state.RunParallel); err != nil {
+			s.Logger.Warnf("Failed to stop the connectivity check listeners: %v", err)
+		}
+	}()
+
+	err := s.RunTaskOnAllNodes(func(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		ports := uniqueSortedPorts(targetPorts[node.PrivateAddress])
+		if len(ports) == 0 {
+			return nil
+		}
+
+		cmd, err := scripts.ConnectivityListeners(ports, connectivityListenersTimeout)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return fail.SSH(err, "starting connectivity check listeners")
+	}, state.RunParallel)
+	if err != nil {
+		return err
+	}
+
+	var (
+		blockedLock sync.Mutex
+		blocked     []string
+	)
+
+	err = s.RunTaskOnAllNodes(func(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		var (
+			sourceRequirements []connectivityRequirement
+			targets            []scripts.ConnectivityTarget
+			mtuTargets         []string
+		)
+
+		for _, r := range requirements {
+			if r.source.PrivateAddress != node.PrivateAddress {
+				continue
+			}
+			sourceRequirements = append(sourceRequirements, r)
+			targets = append(targets, scripts.ConnectivityTarget{Address: r.target.PrivateAddress, Port: r.port})
+		}
+
+		for _, host := range allNodes {
+			if host.PrivateAddress != node.PrivateAddress {
+				mtuTargets = append(mtuTargets, host.PrivateAddress)
+			}
+		}
+
+		cmd, err := scripts.ConnectivityProbe(targets, mtu, mtuTargets)
+		if err != nil {
+			return err
+		}
+
+		stdout, _, err := s.Runner.RunRaw(cmd)
+		if err != nil {
+			return fail.SSH(err, "probing node-to-node connectivity")
+		}
+
+		results := parseConnectivityProbe(stdout)
+
+		blockedLock.Lock()
+		defer blockedLock.Unlock()
+
+		for _, r := range sourceRequirements {
+			if !results[connectivityResultKey(r.target.PrivateAddress, strconv.Itoa(r.port))] {
+				blocked = append(blocked, r.String())
+			}
+		}
+
+		if mtu == 0 {
+			return nil
+		}
+
+		for _, target := range mtuTargets {
+			if !results[connectivityResultKey(target, connectivityResultMTU)] {
+				blocked = append(blocked, fmt.Sprintf("%s -> %s (MTU %d)", node.PrivateAddress, target, mtu))
+			}
+		}
+
+		return nil
+	}, state.RunParallel)
+	if err != nil {
+		return err
+	}
+
+	if len(blocked) == 0 {
+		return nil
+	}
+
+	sort.Strings(blocked)
+	for _, path := range blocked {
+		s.Logger.Errorf("Blocked path: %s", path)
+	}
+
+	return fail.ConfigValidation(errors.Errorf("node-to-node connectivity is blocked on %d path(s), verify the firewall and security group rules", len(blocked)))
+}
+
+func stopConnectivityListeners(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	cmd, err := scripts.ConnectivityListenersCleanup()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.Runner.RunRaw(cmd)
+
+	return fail.SSH(err, "stopping connectivity check listeners")
+}
+
+// connectivityRequirements returns the TCP ports that must be reachable
+// between the nodes depending on the node roles and the CNI plugin
+func connectivityRequirements(cluster *kubeoneapi.KubeOneCluster) []connectivityRequirement {
+	var requirements []connectivityRequirement
+
+	controlPlane := cluster.ControlPlane.Hosts
+	allNodes := append(append([]kubeoneapi.HostConfig{}, controlPlane...), cluster.StaticWorkers.Hosts...)
+	isControlPlane := map[string]bool{}
+	for _, host := range controlPlane {
+		isControlPlane[host.PrivateAddress] = true
+	}
+
+	var cniPorts []connectivityRequirement
+	if cni := cluster.ClusterNetwork.CNI; cni != nil {
+		switch {
+		case cni.Cilium != nil:
+			cniPorts = append(cniPorts, connectivityRequirement{port: 4240, purpose: "cilium health checks"})
+			if cni.Cilium.EnableHubble {
+				cniPorts = append(cniPorts, connectivityRequirement{port: 4244, purpose: "hubble server"})
+			}
+		case cni.WeaveNet != nil:
+			cniPorts = append(cniPorts, connectivityRequirement{port: 6783, purpose: "weave-net control"})
+		}
+	}
+
+	for _, source := range allNodes {
+		for _, target := range allNodes {
+			if source.PrivateAddress == target.PrivateAddress {
+				continue
+			}
+
+			var ports []connectivityRequirement
+			if isControlPlane[target.PrivateAddress] {
+				ports = append(ports, connectivityRequirement{port: 6443, purpose: "kube-apiserver"})
+			}
+			if isControlPlane[source.PrivateAddress] {
+				if isControlPlane[target.PrivateAddress] {
+					ports = append(ports,
+						connectivityRequirement{port: 2379, purpose: "etcd client"},
+						connectivityRequirement{port: 2380, purpose: "etcd peer"},
+					)
+				}
+				ports = append(ports, connectivityRequirement{port: 10250, purpose: "kubelet"})
+			}
+			ports = append(ports, cniPorts...)
+
+			for _, p := range ports {
+				p.source = source
+				p.target = target
+				requirements = append(requirements, p)
+			}
+		}
+	}
+
+	return requirements
+}
+
+// connectivityCheckMTU returns the path MTU that must be available between
+// the nodes, or 0 if the MTU shouldn't be verified
+func connectivityCheckMTU(cluster *kubeoneapi.KubeOneCluster) int {
+	check := cluster.ClusterNetwork.NodeConnectivityCheck
+	if check != nil && check.MTU != 0 {
+		return check.MTU
+	}
+
+	if cni := cluster.ClusterNetwork.CNI; cni != nil && cni.Canal != nil && cni.Canal.MTU != 0 {
+		return cni.Canal.MTU + vxlanOverhead
+	}
+
+	return 0
+}
+
+// parseConnectivityProbe parses the ConnectivityProbe script output and
+// returns the results keyed by the address and the port (or "mtu")
+func parseConnectivityProbe(output string) map[string]bool {
+	results := map[string]bool{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+
+		results[connectivityResultKey(fields[0], fields[1])] = fields[2] == "ok"
+	}
+
+	return results
+}
+
+func connectivityResultKey(address, port string) string {
+	return address + " " + port
+}
+
+func uniqueSortedPorts(ports []int) []int {
+	seen := map[int]bool{}
+	var unique []int
+
+	for _, port := range ports {
+		if !seen[port] {
+			seen[port] = true
+			unique = append(unique, port)
+		}
+	}
+	sort.Ints(unique)
+
+	return unique
+}
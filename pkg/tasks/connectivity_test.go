@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func Test_connectivityRequirements(t *testing.T) {
+	cluster := &kubeoneapi.KubeOneCluster{
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{PrivateAddress: "10.0.0.1"},
+				{PrivateAddress: "10.0.0.2"},
+			},
+		},
+		StaticWorkers: kubeoneapi.StaticWorkersConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{PrivateAddress: "10.0.1.1"},
+			},
+		},
+		ClusterNetwork: kubeoneapi.ClusterNetworkConfig{
+			CNI: &kubeoneapi.CNI{Cilium: &kubeoneapi.CiliumSpec{}},
+		},
+	}
+
+	var got []string
+	for _, r := range connectivityRequirements(cluster) {
+		got = append(got, r.String())
+	}
+
+	expected := []string{
+		"10.0.0.1 -> 10.0.0.2:6443 (kube-apiserver)",
+		"10.0.0.1 -> 10.0.0.2:2379 (etcd client)",
+		"10.0.0.1 -> 10.0.0.2:2380 (etcd peer)",
+		"10.0.0.1 -> 10.0.0.2:10250 (kubelet)",
+		"10.0.0.1 -> 10.0.0.2:4240 (cilium health checks)",
+		"10.0.0.1 -> 10.0.1.1:10250 (kubelet)",
+		"10.0.0.1 -> 10.0.1.1:4240 (cilium health checks)",
+		"10.0.0.2 -> 10.0.0.1:6443 (kube-apiserver)",
+		"10.0.0.2 -> 10.0.0.1:2379 (etcd client)",
+		"10.0.0.2 -> 10.0.0.1:2380 (etcd peer)",
+		"10.0.0.2 -> 10.0.0.1:10250 (kubelet)",
+		"10.0.0.2 -> 10.0.0.1:4240 (cilium health checks)",
+		"10.0.0.2 -> 10.0.1.1:10250 (kubelet)",
+		"10.0.0.2 -> 10.0.1.1:4240 (cilium health checks)",
+		"10.0.1.1 -> 10.0.0.1:6443 (kube-apiserver)",
+		"10.0.1.1 -> 10.0.0.1:4240 (cilium health checks)",
+		"10.0.1.1 -> 10.0.0.2:6443 (kube-apiserver)",
+		"10.0.1.1 -> 10.0.0.2:4240 (cilium health checks)",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected requirements %v, got %v", expected, got)
+	}
+}
+
+func Test_connectivityCheckMTU(t *testing.T) {
+	tests := []struct {
+		name           string
+		clusterNetwork kubeoneapi.ClusterNetworkConfig
+		expected       int
+	}{
+		{
+			name:           "not verified",
+			clusterNetwork: kubeoneapi.ClusterNetworkConfig{CNI: &kubeoneapi.CNI{Cilium: &kubeoneapi.CiliumSpec{}}},
+			expected:       0,
+		},
+		{
+			name:           "derived from canal",
+			clusterNetwork: kubeoneapi.ClusterNetworkConfig{CNI: &kubeoneapi.CNI{Canal: &kubeoneapi.CanalSpec{MTU: 1450}}},
+			expected:       1500,
+		},
+		{
+			name: "configured",
+			clusterNetwork: kubeoneapi.ClusterNetworkConfig{
+				CNI:                   &kubeoneapi.CNI{Canal: &kubeoneapi.CanalSpec{MTU: 1450}},
+				NodeConnectivityCheck: &kubeoneapi.NodeConnectivityCheck{MTU: 9001},
+			},
+			expected: 9001,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := connectivityCheckMTU(&kubeoneapi.KubeOneCluster{ClusterNetwork: tc.clusterNetwork}); got != tc.expected {
+				t.Errorf("expected MTU %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func Test_parseConnectivityProbe(t *testing.T) {
+	output := "+ timeout 3 bash -c </dev/tcp/10.0.0.2/6443\n10.0.0.2 6443 ok\n10.0.0.2 2380 blocked\n10.0.0.2 mtu blocked\n"
+
+	expected := map[string]bool{
+		"10.0.0.2 6443": true,
+		"10.0.0.2 2380": false,
+		"10.0.0.2 mtu":  false,
+	}
+	if got := parseConnectivityProbe(output); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected results %v, got %v", expected, got)
+	}
+}
@@ -18,15 +18,20 @@ package tasks
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clientutil"
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
 
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -78,6 +83,107 @@ func patchCRISocketAnnotation(s *state.State) error {
 	return nil
 }
 
+func ensureContainerdConfig(s *state.State) error {
+	return s.RunTaskOnAllNodes(ensureContainerdConfigTask, state.RunParallel)
+}
+
+func ensureContainerdConfigTask(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	usesContainerd, err := kubeletUsesContainerd(s)
+	if err != nil {
+		return err
+	}
+
+	// nodes still running docker get the containerd config once they are
+	// migrated to containerd
+	if !usesContainerd {
+		s.Logger.Debugf("Skipping containerd config on %q, kubelet doesn't use containerd", node.Hostname)
+
+		return nil
+	}
+
+	s.Logger.Info("Ensuring containerd config...")
+
+	cmd, err := scripts.ContainerdConfig(s.Cluster)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.Runner.RunRaw(cmd)
+
+	return fail.SSH(err, "ensuring containerd config")
+}
+
+// ensureRuntimeClasses creates the RuntimeClass objects for the additional
+// containerd runtimes with the runtimeClass configured
+func ensureRuntimeClasses(s *state.State) error {
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	s.Logger.Infoln("Ensuring RuntimeClasses...")
+
+	for _, rc := range runtimeClasses(s.Cluster.ContainerRuntime.Containerd) {
+		rc := rc
+		if err := clientutil.CreateOrUpdate(s.Context, s.DynamicClient, &rc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runtimeClasses returns the RuntimeClass objects for the additional
+// containerd runtimes with the runtimeClass configured
+func runtimeClasses(containerd *kubeoneapi.ContainerRuntimeContainerd) []nodev1.RuntimeClass {
+	names := []string{}
+	for name, runtime := range containerd.Runtimes {
+		if runtime.RuntimeClass != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	classes := []nodev1.RuntimeClass{}
+	for _, name := range names {
+		rc := nodev1.RuntimeClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Handler: name,
+		}
+
+		if nodeSelector := containerd.Runtimes[name].RuntimeClass.NodeSelector; len(nodeSelector) > 0 {
+			rc.Scheduling = &nodev1.Scheduling{
+				NodeSelector: nodeSelector,
+			}
+		}
+
+		classes = append(classes, rc)
+	}
+
+	return classes
+}
+
+func kubeletUsesContainerd(s *state.State) (bool, error) {
+	stdout, _, err := s.Runner.RunRaw(fmt.Sprintf("sudo cat %s 2>/dev/null || true", kubeadmEnvFlagsFile))
+	if err != nil {
+		return false, fail.SSH(err, "reading %q file", kubeadmEnvFlagsFile)
+	}
+
+	// node is not provisioned yet, containerd config is written while
+	// installing prerequisites
+	if strings.TrimSpace(stdout) == "" {
+		return false, nil
+	}
+
+	kubeletFlags, err := unmarshalKubeletFlags([]byte(stdout))
+	if err != nil {
+		return false, err
+	}
+
+	return strings.Contains(kubeletFlags["--container-runtime-endpoint"], "containerd"), nil
+}
+
 func migrateToContainerd(s *state.State) error {
 	return s.RunTaskOnAllNodes(migrateToContainerdTask, state.RunSequentially)
 }
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func Test_runtimeClasses(t *testing.T) {
+	containerd := &kubeoneapi.ContainerRuntimeContainerd{
+		Runtimes: map[string]kubeoneapi.ContainerdRuntime{
+			"kata": {
+				RuntimeType:  "io.containerd.kata.v2",
+				RuntimeClass: &kubeoneapi.ContainerdRuntimeClass{},
+			},
+			"gvisor": {
+				RuntimeType: "io.containerd.runsc.v1",
+				RuntimeClass: &kubeoneapi.ContainerdRuntimeClass{
+					NodeSelector: map[string]string{"sandbox": "gvisor"},
+				},
+			},
+			"crun": {
+				RuntimeType: "io.containerd.runc.v2",
+				BinaryName:  "/usr/local/bin/crun",
+			},
+		},
+	}
+
+	classes := runtimeClasses(containerd)
+	if len(classes) != 2 {
+		t.Fatalf("expected 2 RuntimeClasses, got %d", len(classes))
+	}
+
+	if classes[0].Name != "gvisor" || classes[0].Handler != "gvisor" {
+		t.Errorf("expected gvisor RuntimeClass, got %q with handler %q", classes[0].Name, classes[0].Handler)
+	}
+	if classes[0].Scheduling == nil || !reflect.DeepEqual(classes[0].Scheduling.NodeSelector, map[string]string{"sandbox": "gvisor"}) {
+		t.Errorf("expected gvisor RuntimeClass node selector, got %v", classes[0].Scheduling)
+	}
+
+	if classes[1].Name != "kata" || classes[1].Scheduling != nil {
+		t.Errorf("expected kata RuntimeClass without scheduling, got %+v", classes[1])
+	}
+}
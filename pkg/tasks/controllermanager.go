@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	controllerManagerManifestPath = "/etc/kubernetes/manifests/kube-controller-manager.yaml"
+)
+
+// ensureControllerManagerConfig applies the kube-controller-manager options
+// configured via the control plane components to the kube-controller-manager
+// static pods. The control plane nodes are processed one by one.
+func ensureControllerManagerConfig(s *state.State) error {
+	return s.RunTaskOnControlPlane(ensureControllerManagerConfigOnNode, state.RunSequentially)
+}
+
+func ensureControllerManagerConfigOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	return updateStaticPod(s, node, controllerManagerManifestPath, "kube-controller-manager", func(pod *corev1.Pod) bool {
+		return patchControllerManagerPod(pod, s.Cluster)
+	})
+}
+
+// patchControllerManagerPod sets the kube-controller-manager flags configured
+// via the control plane components in the kube-controller-manager static pod.
+// It returns true if the pod has been modified.
+func patchControllerManagerPod(pod *corev1.Pod, cluster *kubeoneapi.KubeOneCluster) bool {
+	if len(pod.Spec.Containers) == 0 {
+		return false
+	}
+
+	container := &pod.Spec.Containers[0]
+	changed := false
+
+	for name, value := range cluster.ControllerManagerFlags() {
+		changed = setContainerCommandFlag(container, name, value) || changed
+	}
+
+	return changed
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_patchControllerManagerPod(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "kube-controller-manager",
+					Command: []string{
+						"kube-controller-manager",
+						"--cluster-signing-duration=8760h0m0s",
+						"--use-service-account-credentials=true",
+					},
+				},
+			},
+		},
+	}
+	cluster := &kubeoneapi.KubeOneCluster{}
+
+	if patchControllerManagerPod(&pod, cluster) {
+		t.Error("expected the pod not to be patched without configuration")
+	}
+
+	cluster.ControlPlaneComponents = &kubeoneapi.ControlPlaneComponents{
+		ControllerManager: &kubeoneapi.ControllerManagerConfig{
+			ClusterSigningDuration: metav1.Duration{Duration: 5 * 8760 * time.Hour},
+		},
+	}
+
+	if !patchControllerManagerPod(&pod, cluster) {
+		t.Fatal("expected the pod to be patched")
+	}
+
+	expected := []string{
+		"kube-controller-manager",
+		"--cluster-signing-duration=43800h0m0s",
+		"--use-service-account-credentials=true",
+	}
+	if got := pod.Spec.Containers[0].Command; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected command %v, got %v", expected, got)
+	}
+
+	if patchControllerManagerPod(&pod, cluster) {
+		t.Error("expected the already patched pod not to be changed")
+	}
+}
@@ -78,7 +78,7 @@ func initKubernetesLeader(s *state.State) error {
 
 		s.Logger.Infoln("Running kubeadm...")
 
-		cmd, err := scripts.KubeadmInit(s.WorkDir, node.ID, s.KubeadmVerboseFlag(), s.JoinToken, time.Hour.String(), skipPhase)
+		cmd, err := scripts.KubeadmInit(s.WorkDir, node.ID, s.KubeadmVerboseFlag(), s.JoinToken, s.Cluster.BootstrapTokenTTL().String(), skipPhase)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+const (
+	etcdPeerCertPath = "/etc/kubernetes/pki/etcd/peer.crt"
+
+	// resolveHostCommand prints the addresses the given name resolves to on
+	// the node, one per line
+	resolveHostCommand = "getent ahosts %s"
+)
+
+// etcdDNSPeerURL returns the etcd peer URL of the node using its stable DNS
+// name
+func etcdDNSPeerURL(node kubeoneapi.HostConfig) string {
+	return fmt.Sprintf("https://%s", net.JoinHostPort(strings.ToLower(node.DNSName), "2380"))
+}
+
+// verifyDNSNames verifies that the stable DNS names of all control plane
+// hosts resolve to the addresses of those hosts on the node
+func verifyDNSNames(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		if host.DNSName == "" {
+			continue
+		}
+
+		stdout, _, err := s.Runner.RunRaw(fmt.Sprintf(resolveHostCommand, host.DNSName))
+		if err != nil {
+			return fail.ConfigValidation(fmt.Errorf("dnsName %q doesn't resolve on %q", host.DNSName, node.Hostname))
+		}
+
+		addrs := resolvedAddresses(stdout)
+		if !containsAddress(addrs, host.NodeIP()) {
+			return fail.ConfigValidation(fmt.Errorf("dnsName %q resolves to %s on %q, but it must resolve to %q",
+				host.DNSName, strings.Join(addrs, ", "), node.Hostname, host.NodeIP()))
+		}
+	}
+
+	return nil
+}
+
+// resolvedAddresses returns the unique addresses found in the output of the
+// `getent ahosts` command
+func resolvedAddresses(getentOutput string) []string {
+	seen := map[string]bool{}
+	addrs := []string{}
+
+	for _, line := range strings.Split(getentOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		ip := net.ParseIP(fields[0])
+		if ip == nil || seen[ip.String()] {
+			continue
+		}
+
+		seen[ip.String()] = true
+		addrs = append(addrs, ip.String())
+	}
+
+	return addrs
+}
+
+func containsAddress(addrs []string, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, a := range addrs {
+		if ip.Equal(net.ParseIP(a)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensureDNSNames makes the etcd members use the stable DNS names of the
+// control plane hosts as their peer URLs. kubeadm always adds the members
+// using the advertise addresses, so the peer URLs are updated afterwards.
+//
+// All members connect to the peer URLs, so the names are verified to
+// resolve on every control plane node first. A name not resolving on any
+// member would break the peer connectivity and could lose the quorum.
+func ensureDNSNames(s *state.State) error {
+	if err := s.RunTaskOnControlPlane(verifyDNSNames, state.RunParallel); err != nil {
+		return err
+	}
+
+	return s.RunTaskOnControlPlane(ensureDNSNameOnNode, state.RunSequentially)
+}
+
+func ensureDNSNameOnNode(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	if node.DNSName == "" {
+		return nil
+	}
+
+	logger := s.Logger.WithField("node", node.PublicAddress)
+	peerURL := etcdDNSPeerURL(*node)
+
+	// the members connect to the peer URL over TLS, so the name must be
+	// present in the peer certificate. Certificates generated before the
+	// dnsName has been configured don't include it.
+	cert, err := fetchCert(s.Runner.NewFS(), etcdPeerCertPath)
+	if err != nil {
+		return err
+	}
+
+	if err = cert.VerifyHostname(node.DNSName); err != nil {
+		logger.Warnf("etcd peer certificate doesn't include %q, regenerate the etcd certificates to use it in the etcd peer URL", node.DNSName)
+
+		return nil
+	}
+
+	etcdcli, err := newLeaderEtcdClient(s)
+	if err != nil {
+		return err
+	}
+	defer etcdcli.Close()
+
+	etcdRing, err := etcdcli.MemberList(s.Context)
+	if err != nil {
+		return fail.Etcd(err, "getting members list")
+	}
+
+	member := etcdMemberByAnyPeerURL(etcdRing.Members, etcdPeerURL(*node), peerURL)
+	if member == nil {
+		return fail.EtcdError{
+			Op:  "checking etcd members",
+			Err: fmt.Errorf("etcd member of %q not found", node.Hostname),
+		}
+	}
+
+	if len(member.PeerURLs) == 1 && member.PeerURLs[0] == peerURL {
+		return nil
+	}
+
+	logger.Infof("Updating etcd member %x peer URL to %q...", member.ID, peerURL)
+
+	_, err = etcdcli.MemberUpdate(s.Context, member.ID, []string{peerURL})
+
+	return fail.Etcd(err, "updating %x member peer URL", member.ID)
+}
+
+// etcdMemberByAnyPeerURL returns the member having any of the given peer
+// URLs, or nil if there is no such member
+func etcdMemberByAnyPeerURL(members []*etcdserverpb.Member, peerURLs ...string) *etcdserverpb.Member {
+	for _, member := range members {
+		for _, u := range member.PeerURLs {
+			for _, peerURL := range peerURLs {
+				if u == peerURL {
+					return member
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func Test_resolvedAddresses(t *testing.T) {
+	getentOutput := heredoc.Doc(`
+		10.0.0.5        STREAM cp-1.example.com
+		10.0.0.5        DGRAM
+		10.0.0.5        RAW
+		fd00::5         STREAM
+		fd00::5         DGRAM
+	`)
+
+	want := []string{"10.0.0.5", "fd00::5"}
+	if got := resolvedAddresses(getentOutput); !reflect.DeepEqual(got, want) {
+		t.Errorf("resolvedAddresses() = %v, want %v", got, want)
+	}
+
+	if !containsAddress(want, "fd00:0::5") {
+		t.Errorf("containsAddress() = false, want true")
+	}
+
+	if containsAddress(want, "10.0.0.6") {
+		t.Errorf("containsAddress() = true, want false")
+	}
+}
+
+func Test_etcdDNSPeerURL(t *testing.T) {
+	node := kubeoneapi.HostConfig{PrivateAddress: "10.0.0.5", DNSName: "CP-1.example.com"}
+
+	if got, want := etcdDNSPeerURL(node), "https://cp-1.example.com:2380"; got != want {
+		t.Errorf("etcdDNSPeerURL() = %q, want %q", got, want)
+	}
+}
+
+func Test_etcdMemberByAnyPeerURL(t *testing.T) {
+	members := []*etcdserverpb.Member{
+		{ID: 1, PeerURLs: []string{"https://10.0.0.5:2380"}},
+		{ID: 2, PeerURLs: []string{"https://cp-2.example.com:2380"}},
+	}
+
+	tests := []struct {
+		name     string
+		peerURLs []string
+		wantID   uint64
+	}{
+		{
+			name:     "found by the address peer URL",
+			peerURLs: []string{"https://10.0.0.5:2380", "https://cp-1.example.com:2380"},
+			wantID:   1,
+		},
+		{
+			name:     "found by the DNS peer URL",
+			peerURLs: []string{"https://10.0.0.6:2380", "https://cp-2.example.com:2380"},
+			wantID:   2,
+		},
+		{
+			name:     "not found",
+			peerURLs: []string{"https://10.0.0.7:2380", "https://cp-3.example.com:2380"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := etcdMemberByAnyPeerURL(members, tt.peerURLs...)
+			switch {
+			case got == nil && tt.wantID != 0:
+				t.Errorf("etcdMemberByAnyPeerURL() = nil, want member %d", tt.wantID)
+			case got != nil && got.ID != tt.wantID:
+				t.Errorf("etcdMemberByAnyPeerURL() = member %d, want member %d", got.ID, tt.wantID)
+			}
+		})
+	}
+}
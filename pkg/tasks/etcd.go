@@ -17,10 +17,13 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
+	"net"
 	"net/url"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/clusterstatus/preflightstatus"
 	"k8c.io/kubeone/pkg/etcdutil"
 	"k8c.io/kubeone/pkg/fail"
@@ -32,6 +35,38 @@ import (
 	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// etcdAddress returns the address etcd on the node is advertised on, the same
+// way as the kubeadm advertise address is chosen
+func etcdAddress(node kubeoneapi.HostConfig) string {
+	if node.PrivateAddress != "" {
+		return node.PrivateAddress
+	}
+
+	return node.PublicAddress
+}
+
+// etcdPeerURL returns the peer URL kubeadm uses when adding the node to the
+// etcd cluster
+func etcdPeerURL(node kubeoneapi.HostConfig) string {
+	return fmt.Sprintf("https://%s", net.JoinHostPort(etcdAddress(node), "2380"))
+}
+
+func newLeaderEtcdClient(s *state.State) (*clientv3.Client, error) {
+	leader, err := s.Cluster.Leader()
+	if err != nil {
+		return nil, err
+	}
+
+	etcdcfg, err := etcdutil.NewClientConfig(s, leader)
+	if err != nil {
+		return nil, err
+	}
+
+	etcdcli, err := clientv3.New(*etcdcfg)
+
+	return etcdcli, fail.Etcd(err, "initializing new clientv3")
+}
+
 func repairClusterIfNeeded(s *state.State) error {
 	s.Logger.Info("Check if cluster needs any repairs...")
 
@@ -0,0 +1,286 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	defaultNodePortRange = "30000-32767"
+	vxlanPort            = "8472"
+)
+
+// ensureFirewall applies the managed and the additional firewall rules on
+// the control plane and static worker nodes
+func ensureFirewall(s *state.State) error {
+	s.Logger.Infoln("Ensuring firewall rules...")
+
+	if err := s.RunTaskOnControlPlane(ensureFirewallTask(kubeoneapi.FirewallRoleControlPlane), state.RunParallel); err != nil {
+		return err
+	}
+
+	return s.RunTaskOnStaticWorkers(ensureFirewallTask(kubeoneapi.FirewallRoleStaticWorker), state.RunParallel)
+}
+
+func ensureFirewallTask(role string) state.NodeTask {
+	return func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		rules := firewallRules(s.Cluster, *node, role)
+		trusted := firewallTrustedSources(s.Cluster)
+
+		var (
+			cmd string
+			err error
+		)
+		switch s.Cluster.Firewall.Backend {
+		case kubeoneapi.FirewallBackendFirewalld:
+			cmd, err = scripts.FirewalldFirewall(firewalldService(rules), firewalldRichRules(rules, trusted))
+		default:
+			cmd, err = scripts.NftablesFirewall(nftablesRuleset(rules, trusted))
+		}
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return fail.SSH(err, "ensuring firewall rules")
+	}
+}
+
+// firewallRules returns the rules managed by KubeOne, which open the ports
+// required by the cluster, followed by the additional rules applicable to the
+// node role
+func firewallRules(cluster *kubeoneapi.KubeOneCluster, node kubeoneapi.HostConfig, role string) []kubeoneapi.FirewallRule {
+	var controlPlaneSources []string
+	for _, host := range cluster.ControlPlane.Hosts {
+		controlPlaneSources = append(controlPlaneSources, hostCIDR(host.PrivateAddress))
+	}
+
+	// the pods reaching the kubelets on other nodes are masqueraded to the
+	// address of their node, so all nodes must be allowed
+	kubeletSources := append([]string{}, controlPlaneSources...)
+	for _, host := range cluster.StaticWorkers.Hosts {
+		kubeletSources = append(kubeletSources, hostCIDR(host.PrivateAddress))
+	}
+	kubeletSources = append(kubeletSources, cluster.Firewall.KubeletSources...)
+
+	sshPort := node.SSHPort
+	if sshPort == 0 {
+		sshPort = 22
+	}
+
+	nodePortRange := cluster.ClusterNetwork.NodePortRange
+	if nodePortRange == "" {
+		nodePortRange = defaultNodePortRange
+	}
+
+	rules := []kubeoneapi.FirewallRule{
+		{Ports: strconv.Itoa(sshPort), Protocol: "tcp"},
+		{Ports: "10250", Protocol: "tcp", Sources: kubeletSources},
+		{Ports: nodePortRange, Protocol: "tcp"},
+		{Ports: nodePortRange, Protocol: "udp"},
+	}
+
+	// the CNI ports are open to all sources, as the nodes created by
+	// machine-controller are not known upfront
+	if cni := cluster.ClusterNetwork.CNI; cni != nil {
+		switch {
+		case cni.Canal != nil:
+			rules = append(rules, kubeoneapi.FirewallRule{Ports: vxlanPort, Protocol: "udp"})
+		case cni.Cilium != nil:
+			rules = append(rules,
+				kubeoneapi.FirewallRule{Ports: vxlanPort, Protocol: "udp"},
+				kubeoneapi.FirewallRule{Ports: "4240", Protocol: "tcp"},
+			)
+			if cni.Cilium.EnableHubble {
+				rules = append(rules, kubeoneapi.FirewallRule{Ports: "4244", Protocol: "tcp"})
+			}
+		case cni.WeaveNet != nil:
+			rules = append(rules,
+				kubeoneapi.FirewallRule{Ports: "6783", Protocol: "tcp"},
+				kubeoneapi.FirewallRule{Ports: "6783-6784", Protocol: "udp"},
+			)
+		}
+	}
+
+	if role == kubeoneapi.FirewallRoleControlPlane {
+		rules = append(rules,
+			kubeoneapi.FirewallRule{Ports: "6443", Protocol: "tcp"},
+			kubeoneapi.FirewallRule{Ports: "2379-2380", Protocol: "tcp", Sources: controlPlaneSources},
+		)
+		if cluster.Features.EtcdMetrics.Enabled() {
+			rules = append(rules, kubeoneapi.FirewallRule{Ports: strconv.Itoa(cluster.Features.EtcdMetrics.Port), Protocol: "tcp"})
+		}
+	}
+
+	for _, rule := range cluster.Firewall.Rules {
+		if len(rule.Roles) > 0 && !sets.NewString(rule.Roles...).Has(role) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// firewallTrustedSources returns the CIDRs all incoming traffic is allowed
+// from, i.e. the pods reaching the host network services
+func firewallTrustedSources(cluster *kubeoneapi.KubeOneCluster) []string {
+	if cluster.ClusterNetwork.PodSubnet == "" {
+		return nil
+	}
+
+	return []string{cluster.ClusterNetwork.PodSubnet}
+}
+
+// nftablesRuleset renders the ruleset of the kubeone table. The table is
+// deleted and created again atomically, so applying the ruleset is idempotent.
+func nftablesRuleset(rules []kubeoneapi.FirewallRule, trusted []string) string {
+	var b strings.Builder
+
+	b.WriteString("table inet kubeone\n")
+	b.WriteString("delete table inet kubeone\n")
+	b.WriteString("table inet kubeone {\n")
+	b.WriteString("\tchain input {\n")
+	b.WriteString("\t\ttype filter hook input priority 0; policy drop;\n")
+	b.WriteString("\t\tiif \"lo\" accept\n")
+	b.WriteString("\t\tct state established,related accept\n")
+	b.WriteString("\t\tct state invalid drop\n")
+	b.WriteString("\t\tmeta l4proto { icmp, ipv6-icmp } accept\n")
+	b.WriteString("\t\tip6 saddr fe80::/10 udp dport 546 accept\n")
+
+	for _, match := range nftablesSourceMatches(trusted) {
+		fmt.Fprintf(&b, "\t\t%s accept\n", match)
+	}
+
+	for _, rule := range rules {
+		portMatch := fmt.Sprintf("%s dport %s", rule.Protocol, rule.Ports)
+		if len(rule.Sources) == 0 {
+			fmt.Fprintf(&b, "\t\t%s accept\n", portMatch)
+
+			continue
+		}
+		for _, match := range nftablesSourceMatches(rule.Sources) {
+			fmt.Fprintf(&b, "\t\t%s %s accept\n", match, portMatch)
+		}
+	}
+
+	b.WriteString("\t}\n")
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// nftablesSourceMatches returns the source address matches grouped by the
+// address family
+func nftablesSourceMatches(sources []string) []string {
+	var ipv4, ipv6 []string
+	for _, source := range sources {
+		if isIPv6CIDR(source) {
+			ipv6 = append(ipv6, source)
+		} else {
+			ipv4 = append(ipv4, source)
+		}
+	}
+
+	var matches []string
+	if len(ipv4) > 0 {
+		matches = append(matches, fmt.Sprintf("ip saddr { %s }", strings.Join(ipv4, ", ")))
+	}
+	if len(ipv6) > 0 {
+		matches = append(matches, fmt.Sprintf("ip6 saddr { %s }", strings.Join(ipv6, ", ")))
+	}
+
+	return matches
+}
+
+// firewalldService renders the kubeone firewalld service with the ports of
+// the rules allowing traffic from all sources
+func firewalldService(rules []kubeoneapi.FirewallRule) string {
+	var b strings.Builder
+
+	b.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	b.WriteString("<service>\n")
+	b.WriteString("  <short>kubeone</short>\n")
+	b.WriteString("  <description>Ports required by the Kubernetes cluster managed by KubeOne</description>\n")
+
+	seen := map[string]bool{}
+	for _, rule := range rules {
+		key := rule.Protocol + "/" + rule.Ports
+		if len(rule.Sources) > 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		fmt.Fprintf(&b, "  <port protocol=%q port=%q/>\n", rule.Protocol, rule.Ports)
+	}
+
+	b.WriteString("</service>")
+
+	return b.String()
+}
+
+// firewalldRichRules returns the rich rules allowing traffic from the trusted
+// sources and the rules restricted to the given sources
+func firewalldRichRules(rules []kubeoneapi.FirewallRule, trusted []string) []string {
+	var richRules []string
+
+	for _, source := range trusted {
+		richRules = append(richRules, fmt.Sprintf("rule family=%q source address=%q accept", cidrFamily(source), source))
+	}
+
+	for _, rule := range rules {
+		for _, source := range rule.Sources {
+			richRules = append(richRules, fmt.Sprintf("rule family=%q source address=%q port port=%q protocol=%q accept", cidrFamily(source), source, rule.Ports, rule.Protocol))
+		}
+	}
+
+	return richRules
+}
+
+func hostCIDR(address string) string {
+	if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+		return address + "/128"
+	}
+
+	return address + "/32"
+}
+
+func isIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+
+	return err == nil && ip.To4() == nil
+}
+
+func cidrFamily(cidr string) string {
+	if isIPv6CIDR(cidr) {
+		return "ipv6"
+	}
+
+	return "ipv4"
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func Test_firewallRules(t *testing.T) {
+	cluster := &kubeoneapi.KubeOneCluster{
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{PrivateAddress: "10.0.0.1"},
+				{PrivateAddress: "fd00::2"},
+			},
+		},
+		StaticWorkers: kubeoneapi.StaticWorkersConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{PrivateAddress: "10.0.1.1"},
+			},
+		},
+		ClusterNetwork: kubeoneapi.ClusterNetworkConfig{
+			CNI: &kubeoneapi.CNI{
+				Canal: &kubeoneapi.CanalSpec{},
+			},
+		},
+		Firewall: &kubeoneapi.FirewallConfig{
+			KubeletSources: []string{"192.168.0.0/16"},
+			Rules: []kubeoneapi.FirewallRule{
+				{Ports: "80", Protocol: "tcp"},
+				{Ports: "9100", Protocol: "tcp", Sources: []string{"192.168.0.0/24"}, Roles: []string{kubeoneapi.FirewallRoleStaticWorker}},
+			},
+		},
+	}
+	controlPlaneSources := []string{"10.0.0.1/32", "fd00::2/128"}
+	kubeletSources := []string{"10.0.0.1/32", "fd00::2/128", "10.0.1.1/32", "192.168.0.0/16"}
+
+	tests := []struct {
+		name string
+		node kubeoneapi.HostConfig
+		role string
+		want []kubeoneapi.FirewallRule
+	}{
+		{
+			name: "control plane",
+			node: kubeoneapi.HostConfig{SSHPort: 2222},
+			role: kubeoneapi.FirewallRoleControlPlane,
+			want: []kubeoneapi.FirewallRule{
+				{Ports: "2222", Protocol: "tcp"},
+				{Ports: "10250", Protocol: "tcp", Sources: kubeletSources},
+				{Ports: defaultNodePortRange, Protocol: "tcp"},
+				{Ports: defaultNodePortRange, Protocol: "udp"},
+				{Ports: vxlanPort, Protocol: "udp"},
+				{Ports: "6443", Protocol: "tcp"},
+				{Ports: "2379-2380", Protocol: "tcp", Sources: controlPlaneSources},
+				{Ports: "80", Protocol: "tcp"},
+			},
+		},
+		{
+			name: "static worker",
+			role: kubeoneapi.FirewallRoleStaticWorker,
+			want: []kubeoneapi.FirewallRule{
+				{Ports: "22", Protocol: "tcp"},
+				{Ports: "10250", Protocol: "tcp", Sources: kubeletSources},
+				{Ports: defaultNodePortRange, Protocol: "tcp"},
+				{Ports: defaultNodePortRange, Protocol: "udp"},
+				{Ports: vxlanPort, Protocol: "udp"},
+				{Ports: "80", Protocol: "tcp"},
+				{Ports: "9100", Protocol: "tcp", Sources: []string{"192.168.0.0/24"}, Roles: []string{kubeoneapi.FirewallRoleStaticWorker}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firewallRules(cluster, tt.node, tt.role); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("firewallRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nftablesRuleset(t *testing.T) {
+	rules := []kubeoneapi.FirewallRule{
+		{Ports: "22", Protocol: "tcp"},
+		{Ports: "2379-2380", Protocol: "tcp", Sources: []string{"10.0.0.1/32", "fd00::2/128"}},
+	}
+
+	want := `table inet kubeone
+delete table inet kubeone
+table inet kubeone {
+	chain input {
+		type filter hook input priority 0; policy drop;
+		iif "lo" accept
+		ct state established,related accept
+		ct state invalid drop
+		meta l4proto { icmp, ipv6-icmp } accept
+		ip6 saddr fe80::/10 udp dport 546 accept
+		ip saddr { 172.25.0.0/16 } accept
+		tcp dport 22 accept
+		ip saddr { 10.0.0.1/32 } tcp dport 2379-2380 accept
+		ip6 saddr { fd00::2/128 } tcp dport 2379-2380 accept
+	}
+}`
+
+	if got := nftablesRuleset(rules, []string{"172.25.0.0/16"}); got != want {
+		t.Errorf("nftablesRuleset() = %v, want %v", got, want)
+	}
+}
+
+func Test_firewalldRichRules(t *testing.T) {
+	rules := []kubeoneapi.FirewallRule{
+		{Ports: "22", Protocol: "tcp"},
+		{Ports: "2379-2380", Protocol: "tcp", Sources: []string{"10.0.0.1/32", "fd00::2/128"}},
+	}
+
+	want := []string{
+		`rule family="ipv4" source address="172.25.0.0/16" accept`,
+		`rule family="ipv4" source address="10.0.0.1/32" port port="2379-2380" protocol="tcp" accept`,
+		`rule family="ipv6" source address="fd00::2/128" port port="2379-2380" protocol="tcp" accept`,
+	}
+
+	if got := firewalldRichRules(rules, []string{"172.25.0.0/16"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("firewalldRichRules() = %v, want %v", got, want)
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"fmt"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/certificate"
 	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/kubeconfig"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
@@ -60,7 +62,7 @@ func generateKubeadm(s *state.State) error {
 		return err
 	}
 
-	kubeadmProvider, err := kubeadm.New(s.Cluster.Versions.Kubernetes)
+	kubeadmProvider, err := kubeadm.New(s.Cluster.Versions)
 	if err != nil {
 		return err
 	}
@@ -88,6 +90,25 @@ func generateKubeadm(s *state.State) error {
 	return s.RunTaskOnAllNodes(uploadKubeadmToNode, state.RunParallel)
 }
 
+// generateDiscoveryKubeconfig generates the kubeconfig used by kubeadm to
+// discover the cluster when joining nodes with anonymous auth disabled, and
+// uploads it to all nodes
+func generateDiscoveryKubeconfig(s *state.State) error {
+	caCert, found := s.Configuration.KubernetesPKI[certificate.KubernetesCACertPath]
+	if !found {
+		return fail.NewRuntimeError("generating discovery kubeconfig", "%s not found in the downloaded PKI", certificate.KubernetesCACertPath)
+	}
+
+	discovery, err := kubeconfig.NewDiscovery(s.Cluster.APIEndpoint.ServerURL(false), caCert, s.JoinToken)
+	if err != nil {
+		return err
+	}
+
+	s.Configuration.AddFile(kubeconfig.DiscoveryFile, string(discovery))
+
+	return s.RunTaskOnAllNodes(uploadKubeadmToNode, state.RunParallel)
+}
+
 func uploadKubeadmToNode(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
 	return s.Configuration.UploadTo(conn, s.WorkDir)
 }
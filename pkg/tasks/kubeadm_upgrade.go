@@ -24,7 +24,7 @@ import (
 )
 
 func upgradeLeaderControlPlane(s *state.State, nodeID int) error {
-	kadm, err := kubeadm.New(s.Cluster.Versions.Kubernetes)
+	kadm, err := kubeadm.New(s.Cluster.Versions)
 	if err != nil {
 		return err
 	}
@@ -40,7 +40,7 @@ func upgradeLeaderControlPlane(s *state.State, nodeID int) error {
 }
 
 func upgradeFollowerControlPlane(s *state.State, nodeID int) error {
-	kadm, err := kubeadm.New(s.Cluster.Versions.Kubernetes)
+	kadm, err := kubeadm.New(s.Cluster.Versions)
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,7 @@ func upgradeFollowerControlPlane(s *state.State, nodeID int) error {
 }
 
 func upgradeStaticWorker(s *state.State) error {
-	kadm, err := kubeadm.New(s.Cluster.Versions.Kubernetes)
+	kadm, err := kubeadm.New(s.Cluster.Versions)
 	if err != nil {
 		return err
 	}
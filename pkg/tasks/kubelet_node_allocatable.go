@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// nodeAllocatableKubeletFlags are kubelet flags managed by KubeOne based on
+// the node allocatable options of the host's kubelet config
+var nodeAllocatableKubeletFlags = []string{
+	"system-reserved",
+	"kube-reserved",
+	"eviction-hard",
+	"enforce-node-allocatable",
+	"system-reserved-cgroup",
+	"kube-reserved-cgroup",
+}
+
+// verifyKubeletCgroups verifies that cgroups referenced by the host's kubelet
+// config exist, otherwise kubelet refuses to start when enforcing them.
+func verifyKubeletCgroups(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	for _, cgroup := range []string{node.Kubelet.SystemReservedCgroup, node.Kubelet.KubeReservedCgroup} {
+		if cgroup == "" {
+			continue
+		}
+
+		cmd, err := scripts.VerifyCgroupExists(cgroup)
+		if err != nil {
+			return err
+		}
+
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			return fail.SSH(err, "verifying that cgroup %q exists on %q", cgroup, node.Hostname)
+		}
+	}
+
+	return nil
+}
+
+// ensureKubeletNodeAllocatable updates kubelet flags related to reserved
+// resources and node allocatable enforcement on already provisioned nodes and
+// restarts kubelet if any of them have been changed.
+func ensureKubeletNodeAllocatable(s *state.State) error {
+	return s.RunTaskOnAllNodes(ensureKubeletNodeAllocatableOnNode, state.RunSequentially)
+}
+
+func ensureKubeletNodeAllocatableOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	logger := s.Logger.WithField("node", node.PublicAddress)
+
+	if err := verifyKubeletCgroups(s, node, conn); err != nil {
+		return err
+	}
+
+	changed := false
+	err := updateRemoteFile(s, kubeadmEnvFlagsFile, func(content []byte) ([]byte, error) {
+		kubeletFlags, err := unmarshalKubeletFlags(bytes.TrimSpace(content))
+		if err != nil {
+			return nil, err
+		}
+
+		changed = setNodeAllocatableFlags(kubeletFlags, node.Kubelet.NodeAllocatableFlags())
+		if !changed {
+			return content, nil
+		}
+
+		return marshalKubeletFlags(kubeletFlags), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+
+	logger.Info("Restarting Kubelet to apply node allocatable changes...")
+	if _, _, err = s.Runner.RunRaw(scripts.RestartKubelet()); err != nil {
+		return fail.SSH(err, "restarting kubelet")
+	}
+
+	timeout := 2 * time.Minute
+	logger.Debugf("Waiting up to %s for Kubelet to become running...", timeout)
+
+	return waitForKubeletReady(conn, timeout)
+}
+
+// setNodeAllocatableFlags reconciles the node allocatable flags in the given
+// kubelet flags map with the desired flags and reports if anything changed.
+func setNodeAllocatableFlags(kubeletFlags, desired map[string]string) bool {
+	changed := false
+
+	for _, name := range nodeAllocatableKubeletFlags {
+		flagName := "--" + name
+		current, exists := kubeletFlags[flagName]
+		want, wanted := desired[name]
+
+		switch {
+		case wanted && (!exists || current != want):
+			kubeletFlags[flagName] = want
+			changed = true
+		case !wanted && exists:
+			delete(kubeletFlags, flagName)
+			changed = true
+		}
+	}
+
+	return changed
+}
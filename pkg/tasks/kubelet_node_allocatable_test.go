@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetNodeAllocatableFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flags       map[string]string
+		desired     map[string]string
+		wantFlags   map[string]string
+		wantChanged bool
+	}{
+		{
+			name:        "nothing configured",
+			flags:       map[string]string{"--node-ip": "10.0.0.1"},
+			desired:     map[string]string{},
+			wantFlags:   map[string]string{"--node-ip": "10.0.0.1"},
+			wantChanged: false,
+		},
+		{
+			name:    "add flags",
+			flags:   map[string]string{"--node-ip": "10.0.0.1"},
+			desired: map[string]string{"system-reserved": "cpu=200m", "enforce-node-allocatable": "pods,system-reserved", "system-reserved-cgroup": "/system.slice"},
+			wantFlags: map[string]string{
+				"--node-ip":                  "10.0.0.1",
+				"--system-reserved":          "cpu=200m",
+				"--enforce-node-allocatable": "pods,system-reserved",
+				"--system-reserved-cgroup":   "/system.slice",
+			},
+			wantChanged: true,
+		},
+		{
+			name:        "unchanged flags",
+			flags:       map[string]string{"--node-ip": "10.0.0.1", "--kube-reserved": "memory=300Mi"},
+			desired:     map[string]string{"kube-reserved": "memory=300Mi"},
+			wantFlags:   map[string]string{"--node-ip": "10.0.0.1", "--kube-reserved": "memory=300Mi"},
+			wantChanged: false,
+		},
+		{
+			name:        "update and remove flags",
+			flags:       map[string]string{"--node-ip": "10.0.0.1", "--kube-reserved": "memory=300Mi", "--eviction-hard": "memory.available<100Mi"},
+			desired:     map[string]string{"kube-reserved": "memory=500Mi"},
+			wantFlags:   map[string]string{"--node-ip": "10.0.0.1", "--kube-reserved": "memory=500Mi"},
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changed := setNodeAllocatableFlags(tt.flags, tt.desired)
+			if changed != tt.wantChanged {
+				t.Errorf("setNodeAllocatableFlags() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(tt.flags, tt.wantFlags) {
+				t.Errorf("setNodeAllocatableFlags() flags = %v, want %v", tt.flags, tt.wantFlags)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ func upgradeKubeletAndKubectlBinaries(s *state.State, node kubeoneapi.HostConfig
 		kubeoneapi.OperatingSystemNameDebian:  upgradeKubeletAndKubectlBinariesDebian,
 		kubeoneapi.OperatingSystemNameFlatcar: upgradeKubeletAndKubectlBinariesFlatcar,
 		kubeoneapi.OperatingSystemNameRHEL:    upgradeKubeletAndKubectlBinariesCentOS,
+		kubeoneapi.OperatingSystemNameRocky:   upgradeKubeletAndKubectlBinariesCentOS,
 		kubeoneapi.OperatingSystemNameUbuntu:  upgradeKubeletAndKubectlBinariesDebian,
 	})
 }
@@ -41,6 +42,7 @@ func upgradeKubeadmAndCNIBinaries(s *state.State, node kubeoneapi.HostConfig) er
 		kubeoneapi.OperatingSystemNameDebian:  upgradeKubeadmAndCNIBinariesDebian,
 		kubeoneapi.OperatingSystemNameFlatcar: upgradeKubeadmAndCNIBinariesFlatcar,
 		kubeoneapi.OperatingSystemNameRHEL:    upgradeKubeadmAndCNIBinariesCentOS,
+		kubeoneapi.OperatingSystemNameRocky:   upgradeKubeadmAndCNIBinariesCentOS,
 		kubeoneapi.OperatingSystemNameUbuntu:  upgradeKubeadmAndCNIBinariesDebian,
 	})
 }
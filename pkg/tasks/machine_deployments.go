@@ -20,6 +20,7 @@ import (
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates/machinecontroller"
+	"k8c.io/kubeone/pkg/templates/operatingsystemmanager"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 
@@ -47,6 +48,10 @@ func createMachineDeployments(s *state.State) error {
 	s.Logger.Warnln("KubeOne will not manage MachineDeployments objects besides initially creating them and optionally upgrading them...")
 	s.Logger.Warnf("For more info about MachineDeployments see: %s", machineDeploymentsDocsLink)
 
+	if err := operatingsystemmanager.EnsureBootstrapProfiles(s); err != nil {
+		return err
+	}
+
 	return machinecontroller.CreateMachineDeployments(s)
 }
 
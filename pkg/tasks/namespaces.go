@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"sort"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// managedNamespaceLabel marks namespaces created or reconciled by KubeOne
+	managedNamespaceLabel = "kubeone.io/managed-namespace"
+)
+
+// ensureNamespaces creates the namespaces from the manifest and reconciles
+// their labels and annotations. Namespaces that were previously managed by
+// KubeOne, but were removed from the manifest, are never deleted.
+func ensureNamespaces(s *state.State) error {
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	ctx := context.Background()
+
+	if len(s.Cluster.Namespaces) > 0 {
+		s.Logger.Infoln("Ensuring namespaces...")
+	}
+
+	for _, nsConfig := range s.Cluster.Namespaces {
+		if err := ensureNamespace(ctx, s.DynamicClient, nsConfig); err != nil {
+			return err
+		}
+	}
+
+	managedNamespaces := corev1.NamespaceList{}
+	if err := s.DynamicClient.List(ctx, &managedNamespaces, client.HasLabels{managedNamespaceLabel}); err != nil {
+		return fail.KubeClient(err, "listing managed namespaces")
+	}
+
+	for _, name := range unlistedNamespaces(managedNamespaces.Items, s.Cluster.Namespaces) {
+		s.Logger.Warnf("Namespace %q is not listed in the manifest anymore, it will not be deleted", name)
+	}
+
+	return nil
+}
+
+func ensureNamespace(ctx context.Context, c client.Client, nsConfig kubeoneapi.NamespaceConfig) error {
+	ns := corev1.Namespace{}
+	key := client.ObjectKey{
+		Name: nsConfig.Name,
+	}
+
+	err := c.Get(ctx, key, &ns)
+	switch {
+	case k8serrors.IsNotFound(err):
+		ns = corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nsConfig.Name,
+			},
+		}
+		reconcileNamespaceMeta(&ns.ObjectMeta, nsConfig)
+
+		return fail.KubeClient(c.Create(ctx, &ns), "creating %T %s", ns, key)
+	case err != nil:
+		return fail.KubeClient(err, "getting %T %s", ns, key)
+	}
+
+	if !reconcileNamespaceMeta(&ns.ObjectMeta, nsConfig) {
+		return nil
+	}
+
+	return fail.KubeClient(c.Update(ctx, &ns), "updating %T %s", ns, key)
+}
+
+// reconcileNamespaceMeta sets the configured labels and annotations, along
+// with the managed namespace label, while keeping all other keys intact.
+// It reports whether the object has been changed.
+func reconcileNamespaceMeta(meta *metav1.ObjectMeta, nsConfig kubeoneapi.NamespaceConfig) bool {
+	labels := map[string]string{managedNamespaceLabel: "true"}
+	for k, v := range nsConfig.Labels {
+		labels[k] = v
+	}
+
+	changed := mergeStringMap(&meta.Labels, labels)
+
+	return mergeStringMap(&meta.Annotations, nsConfig.Annotations) || changed
+}
+
+func mergeStringMap(dst *map[string]string, src map[string]string) bool {
+	changed := false
+
+	for k, v := range src {
+		if *dst == nil {
+			*dst = map[string]string{}
+		}
+		if current, ok := (*dst)[k]; ok && current == v {
+			continue
+		}
+		(*dst)[k] = v
+		changed = true
+	}
+
+	return changed
+}
+
+// unlistedNamespaces returns sorted names of the managed namespaces that are
+// not listed in the manifest
+func unlistedNamespaces(managed []corev1.Namespace, configured []kubeoneapi.NamespaceConfig) []string {
+	listed := map[string]struct{}{}
+	for _, nsConfig := range configured {
+		listed[nsConfig.Name] = struct{}{}
+	}
+
+	unlisted := []string{}
+	for _, ns := range managed {
+		if _, ok := listed[ns.Name]; !ok {
+			unlisted = append(unlisted, ns.Name)
+		}
+	}
+	sort.Strings(unlisted)
+
+	return unlisted
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_reconcileNamespaceMeta(t *testing.T) {
+	tests := []struct {
+		name        string
+		meta        metav1.ObjectMeta
+		nsConfig    kubeoneapi.NamespaceConfig
+		wantMeta    metav1.ObjectMeta
+		wantChanged bool
+	}{
+		{
+			name: "new namespace",
+			nsConfig: kubeoneapi.NamespaceConfig{
+				Name:        "team-a",
+				Labels:      map[string]string{"team": "a"},
+				Annotations: map[string]string{"owner": "team-a"},
+			},
+			wantMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "a", managedNamespaceLabel: "true"},
+				Annotations: map[string]string{"owner": "team-a"},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "up to date namespace",
+			meta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "a", managedNamespaceLabel: "true"},
+				Annotations: map[string]string{"owner": "team-a"},
+			},
+			nsConfig: kubeoneapi.NamespaceConfig{
+				Name:        "team-a",
+				Labels:      map[string]string{"team": "a"},
+				Annotations: map[string]string{"owner": "team-a"},
+			},
+			wantMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "a", managedNamespaceLabel: "true"},
+				Annotations: map[string]string{"owner": "team-a"},
+			},
+			wantChanged: false,
+		},
+		{
+			name: "changed label keeps unmanaged keys",
+			meta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "b", "kubernetes.io/metadata.name": "team-a", managedNamespaceLabel: "true"},
+				Annotations: map[string]string{"other": "value"},
+			},
+			nsConfig: kubeoneapi.NamespaceConfig{
+				Name:   "team-a",
+				Labels: map[string]string{"team": "a"},
+			},
+			wantMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "a", "kubernetes.io/metadata.name": "team-a", managedNamespaceLabel: "true"},
+				Annotations: map[string]string{"other": "value"},
+			},
+			wantChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			meta := tt.meta
+			if got := reconcileNamespaceMeta(&meta, tt.nsConfig); got != tt.wantChanged {
+				t.Errorf("reconcileNamespaceMeta() = %v, want %v", got, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(meta, tt.wantMeta) {
+				t.Errorf("reconcileNamespaceMeta() meta = %v, want %v", meta, tt.wantMeta)
+			}
+		})
+	}
+}
+
+func Test_unlistedNamespaces(t *testing.T) {
+	managed := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-c"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+	}
+	configured := []kubeoneapi.NamespaceConfig{
+		{Name: "team-a"},
+		{Name: "team-d"},
+	}
+
+	want := []string{"team-b", "team-c"}
+	if got := unlistedNamespaces(managed, configured); !reflect.DeepEqual(got, want) {
+		t.Errorf("unlistedNamespaces() = %v, want %v", got, want)
+	}
+}
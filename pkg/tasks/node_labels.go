@@ -0,0 +1,236 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+)
+
+// The annotations below keep track of the labels, annotations and taints
+// set by KubeOne, so they can be removed from the Node once they're removed
+// from the manifest, without touching the ones set by anyone else
+const (
+	annotationManagedLabels      = "v1.kubeone.io/managed-labels"
+	annotationManagedAnnotations = "v1.kubeone.io/managed-annotations"
+	annotationManagedTaints      = "v1.kubeone.io/managed-taints"
+)
+
+// reconcileNodeLabels reconciles the labels, annotations and taints declared
+// for the control plane and static worker hosts on their Node objects
+func reconcileNodeLabels(s *state.State) error {
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	nodeList := corev1.NodeList{}
+	if err := s.DynamicClient.List(s.Context, &nodeList); err != nil {
+		return fail.KubeClient(err, "getting %T", nodeList)
+	}
+
+	var hosts []kubeoneapi.HostConfig
+	hosts = append(hosts, s.Cluster.ControlPlane.Hosts...)
+	hosts = append(hosts, s.Cluster.StaticWorkers.Hosts...)
+
+	for nodeName, host := range hostNodes(hosts, nodeList.Items) {
+		nodeName := nodeName
+		host := host
+
+		updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			var node corev1.Node
+
+			if err := s.DynamicClient.Get(s.Context, types.NamespacedName{Name: nodeName}, &node); err != nil {
+				return err
+			}
+
+			if !reconcileNodeMetadata(&node, host) {
+				return nil
+			}
+
+			s.Logger.Infof("Updating labels, annotations and taints of node %q...", nodeName)
+
+			return s.DynamicClient.Update(s.Context, &node)
+		})
+		if updateErr != nil {
+			return fail.KubeClient(updateErr, "updating labels, annotations and taints of node %q", nodeName)
+		}
+	}
+
+	return nil
+}
+
+// hostNodes returns the hosts by the name of their Node. The hosts are
+// matched by the hostname or by any of their addresses, so the hostnames
+// don't have to be detected over SSH.
+func hostNodes(hosts []kubeoneapi.HostConfig, nodes []corev1.Node) map[string]kubeoneapi.HostConfig {
+	matched := map[string]kubeoneapi.HostConfig{}
+
+	for _, node := range nodes {
+		candidates := sets.NewString(node.Name)
+		for _, addr := range node.Status.Addresses {
+			candidates.Insert(addr.Address)
+		}
+		candidates.Delete("")
+
+		for _, host := range hosts {
+			if candidates.HasAny(host.Hostname, host.PrivateAddress, host.PublicAddress) {
+				matched[node.Name] = host
+
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+// reconcileNodeMetadata sets the labels, annotations and taints declared for
+// the host on the Node, and removes the ones which are not declared anymore.
+// It returns true if the Node has been modified.
+func reconcileNodeMetadata(node *corev1.Node, host kubeoneapi.HostConfig) bool {
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+
+	changed := reconcileManagedKeys(node.Labels, host.Labels, node.Annotations, annotationManagedLabels)
+	changed = reconcileManagedKeys(node.Annotations, host.Annotations, node.Annotations, annotationManagedAnnotations) || changed
+	changed = reconcileTaints(node, host.Taints) || changed
+
+	return changed
+}
+
+// reconcileManagedKeys makes current contain the desired key-values, and
+// removes the keys previously recorded in the managed annotation which are
+// not desired anymore
+func reconcileManagedKeys(current, desired, annotations map[string]string, managedAnnotation string) bool {
+	changed := false
+
+	for _, key := range managedKeys(annotations[managedAnnotation]) {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if _, ok := current[key]; ok {
+			delete(current, key)
+			changed = true
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+	for k, v := range desired {
+		keys = append(keys, k)
+		if cur, ok := current[k]; !ok || cur != v {
+			current[k] = v
+			changed = true
+		}
+	}
+	sort.Strings(keys)
+
+	return setManagedKeys(annotations, managedAnnotation, keys) || changed
+}
+
+// reconcileTaints adds the desired taints to the Node, updates their values
+// and removes the taints previously set by KubeOne which are not desired
+// anymore. The taints are identified by their key and effect.
+func reconcileTaints(node *corev1.Node, desired []corev1.Taint) bool {
+	desiredIDs := sets.NewString()
+	for _, t := range desired {
+		desiredIDs.Insert(taintID(t))
+	}
+
+	managed := sets.NewString(managedKeys(node.Annotations[annotationManagedTaints])...)
+	changed := false
+
+	taints := []corev1.Taint{}
+	for _, t := range node.Spec.Taints {
+		if managed.Has(taintID(t)) && !desiredIDs.Has(taintID(t)) {
+			changed = true
+
+			continue
+		}
+		taints = append(taints, t)
+	}
+
+	for _, d := range desired {
+		found := false
+		for i := range taints {
+			if taintID(taints[i]) != taintID(d) {
+				continue
+			}
+			found = true
+			if taints[i].Value != d.Value {
+				taints[i].Value = d.Value
+				changed = true
+			}
+		}
+		if !found {
+			taints = append(taints, d)
+			changed = true
+		}
+	}
+
+	if changed {
+		node.Spec.Taints = taints
+	}
+
+	return setManagedKeys(node.Annotations, annotationManagedTaints, desiredIDs.List()) || changed
+}
+
+func taintID(t corev1.Taint) string {
+	return t.Key + ":" + string(t.Effect)
+}
+
+func managedKeys(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+// setManagedKeys records the keys in the managed annotation, or removes the
+// annotation if there are no keys. It returns true if the annotation changed.
+func setManagedKeys(annotations map[string]string, managedAnnotation string, keys []string) bool {
+	if len(keys) == 0 {
+		if _, ok := annotations[managedAnnotation]; ok {
+			delete(annotations, managedAnnotation)
+
+			return true
+		}
+
+		return false
+	}
+
+	value := strings.Join(keys, ",")
+	if annotations[managedAnnotation] == value {
+		return false
+	}
+	annotations[managedAnnotation] = value
+
+	return true
+}
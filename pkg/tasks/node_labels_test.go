@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_hostNodes(t *testing.T) {
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ip-10-0-0-5"},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.5"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "dynamic-worker"},
+		},
+	}
+
+	hosts := []kubeoneapi.HostConfig{
+		{Hostname: "cp-1", PrivateAddress: "10.0.0.1"},
+		{PrivateAddress: "10.0.0.5"},
+		{PrivateAddress: "10.0.0.6"},
+	}
+
+	want := map[string]kubeoneapi.HostConfig{
+		"cp-1":        hosts[0],
+		"ip-10-0-0-5": hosts[1],
+	}
+
+	if got := hostNodes(hosts, nodes); !reflect.DeepEqual(got, want) {
+		t.Errorf("hostNodes() = %v, want %v", got, want)
+	}
+}
+
+func Test_reconcileNodeMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		node        corev1.Node
+		host        kubeoneapi.HostConfig
+		wantChanged bool
+		wantNode    corev1.Node
+	}{
+		{
+			name: "set declared labels, annotations and taints",
+			node: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"kubernetes.io/hostname": "worker-1"},
+				},
+			},
+			host: kubeoneapi.HostConfig{
+				Labels:      map[string]string{"zone": "a", "gpu": "true"},
+				Annotations: map[string]string{"owner": "team-a"},
+				Taints:      []corev1.Taint{{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+			},
+			wantChanged: true,
+			wantNode: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"kubernetes.io/hostname": "worker-1", "zone": "a", "gpu": "true"},
+					Annotations: map[string]string{
+						"owner":                      "team-a",
+						annotationManagedLabels:      "gpu,zone",
+						annotationManagedAnnotations: "owner",
+						annotationManagedTaints:      "gpu:NoSchedule",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+				},
+			},
+		},
+		{
+			name: "remove only previously managed",
+			node: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"zone": "a", "gpu": "true", "other": "x"},
+					Annotations: map[string]string{
+						"owner":                      "team-a",
+						"node.alpha.kubernetes.io/x": "y",
+						annotationManagedLabels:      "gpu,zone",
+						annotationManagedAnnotations: "owner",
+						annotationManagedTaints:      "gpu:NoSchedule",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+						{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+					},
+				},
+			},
+			host: kubeoneapi.HostConfig{
+				Labels: map[string]string{"zone": "b"},
+			},
+			wantChanged: true,
+			wantNode: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"zone": "b", "other": "x"},
+					Annotations: map[string]string{
+						"node.alpha.kubernetes.io/x": "y",
+						annotationManagedLabels:      "zone",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+					},
+				},
+			},
+		},
+		{
+			name: "in sync",
+			node: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"zone": "a"},
+					Annotations: map[string]string{
+						annotationManagedLabels: "zone",
+						annotationManagedTaints: "node-role.kubernetes.io/control-plane:NoSchedule",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}},
+				},
+			},
+			host: kubeoneapi.HostConfig{
+				Labels: map[string]string{"zone": "a"},
+				Taints: []corev1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}},
+			},
+			wantNode: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"zone": "a"},
+					Annotations: map[string]string{
+						annotationManagedLabels: "zone",
+						annotationManagedTaints: "node-role.kubernetes.io/control-plane:NoSchedule",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			node := tt.node
+			if got := reconcileNodeMetadata(&node, tt.host); got != tt.wantChanged {
+				t.Errorf("reconcileNodeMetadata() = %v, want %v", got, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(node, tt.wantNode) {
+				t.Errorf("reconcileNodeMetadata() node = %+v, want %+v", node, tt.wantNode)
+			}
+		})
+	}
+}
@@ -59,6 +59,7 @@ func restartKubeAPIServerOnOS(s *state.State, node kubeoneapi.HostConfig) error
 		kubeoneapi.OperatingSystemNameDebian:  restartKubeAPIServerCrictl,
 		kubeoneapi.OperatingSystemNameFlatcar: restartKubeAPIServerCrictl,
 		kubeoneapi.OperatingSystemNameRHEL:    restartKubeAPIServerCrictl,
+		kubeoneapi.OperatingSystemNameRocky:   restartKubeAPIServerCrictl,
 		kubeoneapi.OperatingSystemNameUbuntu:  restartKubeAPIServerCrictl,
 	})
 }
@@ -70,6 +71,7 @@ func ensureRestartKubeAPIServerOnOS(s *state.State, node kubeoneapi.HostConfig)
 		kubeoneapi.OperatingSystemNameDebian:  ensureRestartKubeAPIServerCrictl,
 		kubeoneapi.OperatingSystemNameFlatcar: ensureRestartKubeAPIServerCrictl,
 		kubeoneapi.OperatingSystemNameRHEL:    ensureRestartKubeAPIServerCrictl,
+		kubeoneapi.OperatingSystemNameRocky:   ensureRestartKubeAPIServerCrictl,
 		kubeoneapi.OperatingSystemNameUbuntu:  ensureRestartKubeAPIServerCrictl,
 	})
 }
@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	nodeRoleControlPlane = "control-plane"
+	nodeRoleWorker       = "worker"
+)
+
+// clusterSummary is the payload sent to the post-apply validation webhook
+type clusterSummary struct {
+	Name               string                     `json:"name"`
+	KubernetesVersion  string                     `json:"kubernetesVersion"`
+	APIEndpoint        string                     `json:"apiEndpoint"`
+	CloudProvider      string                     `json:"cloudProvider"`
+	Nodes              []nodeSummary              `json:"nodes"`
+	MachineDeployments []machineDeploymentSummary `json:"machineDeployments"`
+}
+
+type nodeSummary struct {
+	Name                    string `json:"name"`
+	Role                    string `json:"role"`
+	Ready                   bool   `json:"ready"`
+	KubeletVersion          string `json:"kubeletVersion"`
+	OSImage                 string `json:"osImage"`
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion"`
+}
+
+type machineDeploymentSummary struct {
+	Name     string `json:"name"`
+	Replicas int    `json:"replicas"`
+}
+
+// validationWebhookResponse is the response expected from the post-apply
+// validation webhook
+type validationWebhookResponse struct {
+	Approved bool   `json:"approved"`
+	Message  string `json:"message,omitempty"`
+}
+
+// runPostApplyValidation sends the summary of the cluster to the post-apply
+// validation webhook and fails unless the webhook approves the cluster
+func runPostApplyValidation(s *state.State) error {
+	webhook := s.Cluster.PostApplyValidation.Webhook
+
+	nodes := corev1.NodeList{}
+	if err := s.DynamicClient.List(s.Context, &nodes); err != nil {
+		return fail.KubeClient(err, "listing nodes")
+	}
+
+	summary := buildClusterSummary(s.Cluster, nodes.Items)
+
+	s.Logger.Infof("Validating the cluster using the webhook %s...", webhook.URL)
+
+	ctx, cancel := context.WithTimeout(s.Context, webhook.Timeout.Duration)
+	defer cancel()
+
+	message, err := callValidationWebhook(ctx, webhook.URL, summary)
+	if err != nil {
+		return err
+	}
+
+	if message != "" {
+		s.Logger.Infof("Cluster approved by the validation webhook: %s", message)
+	} else {
+		s.Logger.Info("Cluster approved by the validation webhook")
+	}
+
+	return nil
+}
+
+func buildClusterSummary(cluster *kubeoneapi.KubeOneCluster, nodes []corev1.Node) clusterSummary {
+	controlPlane := map[string]bool{}
+	for _, host := range cluster.ControlPlane.Hosts {
+		controlPlane[host.Hostname] = true
+	}
+
+	summary := clusterSummary{
+		Name:               cluster.Name,
+		KubernetesVersion:  cluster.Versions.Kubernetes,
+		APIEndpoint:        cluster.APIEndpoint.ServerURL(false),
+		CloudProvider:      cluster.CloudProvider.CloudProviderName(),
+		Nodes:              []nodeSummary{},
+		MachineDeployments: []machineDeploymentSummary{},
+	}
+
+	for _, node := range nodes {
+		role := nodeRoleWorker
+		if controlPlane[node.Name] {
+			role = nodeRoleControlPlane
+		}
+
+		ready := false
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady {
+				ready = cond.Status == corev1.ConditionTrue
+			}
+		}
+
+		summary.Nodes = append(summary.Nodes, nodeSummary{
+			Name:                    node.Name,
+			Role:                    role,
+			Ready:                   ready,
+			KubeletVersion:          node.Status.NodeInfo.KubeletVersion,
+			OSImage:                 node.Status.NodeInfo.OSImage,
+			ContainerRuntimeVersion: node.Status.NodeInfo.ContainerRuntimeVersion,
+		})
+	}
+
+	for _, worker := range cluster.DynamicWorkers {
+		replicas := 0
+		if worker.Replicas != nil {
+			replicas = *worker.Replicas
+		}
+
+		summary.MachineDeployments = append(summary.MachineDeployments, machineDeploymentSummary{
+			Name:     worker.Name,
+			Replicas: replicas,
+		})
+	}
+
+	return summary
+}
+
+// callValidationWebhook POSTs the cluster summary to the webhook and returns
+// the message of the webhook if the cluster has been approved
+func callValidationWebhook(ctx context.Context, url string, summary clusterSummary) (string, error) {
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return "", fail.Runtime(err, "marshalling cluster summary")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return "", fail.Runtime(err, "post-apply validation webhook request")
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", fail.Runtime(err, "post-apply validation webhook request")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fail.Runtime(err, "post-apply validation webhook response body read")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fail.NewRuntimeError("post-apply validation", "webhook responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	response := validationWebhookResponse{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return "", fail.Runtime(err, "unmarshalling post-apply validation webhook response")
+	}
+
+	if !response.Approved {
+		return "", fail.NewRuntimeError("post-apply validation", "cluster rejected by the webhook: %s", response.Message)
+	}
+
+	return response.Message, nil
+}
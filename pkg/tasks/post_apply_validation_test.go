@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_buildClusterSummary(t *testing.T) {
+	replicas := 3
+	cluster := &kubeoneapi.KubeOneCluster{
+		Name: "test",
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{{Hostname: "cp-0"}},
+		},
+		APIEndpoint: kubeoneapi.APIEndpoint{Host: "api.example.com", Port: 6443},
+		Versions:    kubeoneapi.VersionConfig{Kubernetes: "1.24.2"},
+		DynamicWorkers: []kubeoneapi.DynamicWorkerConfig{
+			{Name: "pool1", Replicas: &replicas},
+		},
+	}
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-0"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.24.2"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-0"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+				NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.24.2"},
+			},
+		},
+	}
+
+	expected := clusterSummary{
+		Name:              "test",
+		KubernetesVersion: "1.24.2",
+		APIEndpoint:       "https://api.example.com:6443",
+		Nodes: []nodeSummary{
+			{Name: "cp-0", Role: nodeRoleControlPlane, Ready: true, KubeletVersion: "v1.24.2"},
+			{Name: "worker-0", Role: nodeRoleWorker, Ready: false, KubeletVersion: "v1.24.2"},
+		},
+		MachineDeployments: []machineDeploymentSummary{
+			{Name: "pool1", Replicas: 3},
+		},
+	}
+
+	if got := buildClusterSummary(cluster, nodes); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected summary %+v, got %+v", expected, got)
+	}
+}
+
+func Test_callValidationWebhook(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		response        string
+		expectedMessage string
+		expectedError   bool
+	}{
+		{
+			name:            "approved",
+			status:          http.StatusOK,
+			response:        `{"approved": true, "message": "all checks passed"}`,
+			expectedMessage: "all checks passed",
+		},
+		{
+			name:          "rejected",
+			status:        http.StatusOK,
+			response:      `{"approved": false, "message": "audit logging is disabled"}`,
+			expectedError: true,
+		},
+		{
+			name:          "approval missing in the response",
+			status:        http.StatusOK,
+			response:      `{}`,
+			expectedError: true,
+		},
+		{
+			name:          "error status code",
+			status:        http.StatusInternalServerError,
+			response:      `{"approved": true}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid response",
+			status:        http.StatusOK,
+			response:      `approved`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				summary := clusterSummary{}
+				if err := json.NewDecoder(r.Body).Decode(&summary); err != nil || summary.Name != "test" {
+					t.Errorf("unexpected request payload: %v", err)
+				}
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			message, err := callValidationWebhook(context.Background(), server.URL, clusterSummary{Name: "test"})
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v", tc.expectedError, err)
+			}
+			if message != tc.expectedMessage {
+				t.Errorf("expected message %q, got %q", tc.expectedMessage, message)
+			}
+		})
+	}
+}
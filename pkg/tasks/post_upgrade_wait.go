@@ -0,0 +1,227 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"k8c.io/kubeone/pkg/clusterstatus/etcdstatus"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const crashLoopBackOffReason = "CrashLoopBackOff"
+
+// waitForClusterStabilization waits for the system workloads and etcd to
+// become stable after the upgrade. On timeout, it fails listing the workloads
+// that are still unstable.
+func waitForClusterStabilization(s *state.State, timeout time.Duration) error {
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	s.Logger.Infof("Waiting up to %s for the cluster to stabilize...", timeout)
+
+	var unstable []string
+	err := wait.PollImmediate(10*time.Second, timeout, func() (bool, error) {
+		var err error
+
+		unstable, err = unstableSystemWorkloads(s)
+		if err != nil {
+			s.Logger.Debugf("Failed to check system workloads: %v", err)
+			unstable = []string{err.Error()}
+
+			return false, nil
+		}
+
+		unstable = append(unstable, unhealthyEtcdMembers(s)...)
+		if len(unstable) > 0 {
+			s.Logger.Debugf("Unstable workloads: %v", unstable)
+
+			return false, nil
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		for _, u := range unstable {
+			s.Logger.Errorf("Still unstable: %s", u)
+		}
+
+		return fail.KubeClient(err, "waiting for the cluster to stabilize, unstable workloads: %v", unstable)
+	}
+
+	return nil
+}
+
+// unstableSystemWorkloads returns the kube-system pods, DaemonSets and
+// Deployments that are not stable yet
+func unstableSystemWorkloads(s *state.State) ([]string, error) {
+	inKubeSystem := dynclient.InNamespace(metav1.NamespaceSystem)
+
+	pods := corev1.PodList{}
+	if err := s.DynamicClient.List(s.Context, &pods, inKubeSystem); err != nil {
+		return nil, fail.KubeClient(err, "listing pods")
+	}
+
+	daemonSets := appsv1.DaemonSetList{}
+	if err := s.DynamicClient.List(s.Context, &daemonSets, inKubeSystem); err != nil {
+		return nil, fail.KubeClient(err, "listing daemonsets")
+	}
+
+	deployments := appsv1.DeploymentList{}
+	if err := s.DynamicClient.List(s.Context, &deployments, inKubeSystem); err != nil {
+		return nil, fail.KubeClient(err, "listing deployments")
+	}
+
+	unstable := unstablePods(pods.Items)
+	unstable = append(unstable, unstableDaemonSets(daemonSets.Items)...)
+	unstable = append(unstable, unstableDeployments(deployments.Items)...)
+
+	return unstable, nil
+}
+
+// unstablePods returns sorted descriptions of pods that are crash-looping,
+// not running or not ready. Completed pods are considered stable.
+func unstablePods(pods []corev1.Pod) []string {
+	var unstable []string
+
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+
+		reason := ""
+		switch {
+		case podCrashLooping(pod):
+			reason = "crash-looping"
+		case pod.Status.Phase != corev1.PodRunning:
+			reason = fmt.Sprintf("in phase %s", pod.Status.Phase)
+		case !podReady(pod):
+			reason = "not ready"
+		default:
+			continue
+		}
+
+		unstable = append(unstable, fmt.Sprintf("pod %s/%s is %s", pod.Namespace, pod.Name, reason))
+	}
+
+	sort.Strings(unstable)
+
+	return unstable
+}
+
+func podCrashLooping(pod corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+			return true
+		}
+	}
+
+	return false
+}
+
+func podReady(pod corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// unstableDaemonSets returns sorted descriptions of DaemonSets that don't
+// have the desired number of updated and ready pods
+func unstableDaemonSets(daemonSets []appsv1.DaemonSet) []string {
+	var unstable []string
+
+	for _, ds := range daemonSets {
+		status := ds.Status
+		if status.ObservedGeneration >= ds.Generation &&
+			status.UpdatedNumberScheduled == status.DesiredNumberScheduled &&
+			status.NumberReady == status.DesiredNumberScheduled {
+			continue
+		}
+
+		unstable = append(unstable, fmt.Sprintf("daemonset %s/%s has %d/%d pods ready and %d/%d updated",
+			ds.Namespace, ds.Name, status.NumberReady, status.DesiredNumberScheduled, status.UpdatedNumberScheduled, status.DesiredNumberScheduled))
+	}
+
+	sort.Strings(unstable)
+
+	return unstable
+}
+
+// unstableDeployments returns sorted descriptions of Deployments that don't
+// have the desired number of updated and ready replicas
+func unstableDeployments(deployments []appsv1.Deployment) []string {
+	var unstable []string
+
+	for _, deploy := range deployments {
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+
+		status := deploy.Status
+		if status.ObservedGeneration >= deploy.Generation &&
+			status.UpdatedReplicas == replicas &&
+			status.ReadyReplicas == replicas {
+			continue
+		}
+
+		unstable = append(unstable, fmt.Sprintf("deployment %s/%s has %d/%d replicas ready and %d/%d updated",
+			deploy.Namespace, deploy.Name, status.ReadyReplicas, replicas, status.UpdatedReplicas, replicas))
+	}
+
+	sort.Strings(unstable)
+
+	return unstable
+}
+
+// unhealthyEtcdMembers returns descriptions of the control plane hosts with
+// unhealthy etcd members
+func unhealthyEtcdMembers(s *state.State) []string {
+	etcdRing, err := etcdstatus.MemberList(s)
+	if err != nil {
+		return []string{fmt.Sprintf("etcd members can't be listed: %v", err)}
+	}
+
+	var unhealthy []string
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		status, err := etcdstatus.Get(s, host, etcdRing)
+		switch {
+		case err != nil:
+			unhealthy = append(unhealthy, fmt.Sprintf("etcd member %s: %v", host.Hostname, err))
+		case !status.Member:
+			unhealthy = append(unhealthy, fmt.Sprintf("etcd member %s is not in the etcd cluster", host.Hostname))
+		case !status.Health:
+			unhealthy = append(unhealthy, fmt.Sprintf("etcd member %s is not healthy", host.Hostname))
+		}
+	}
+
+	return unhealthy
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func readyPod(name string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func Test_unstablePods(t *testing.T) {
+	crashLooping := readyPod("crash-looping")
+	crashLooping.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: crashLoopBackOffReason}}},
+	}
+
+	pending := readyPod("pending")
+	pending.Status.Phase = corev1.PodPending
+
+	notReady := readyPod("not-ready")
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	completed := readyPod("completed")
+	completed.Status.Phase = corev1.PodSucceeded
+	completed.Status.Conditions = nil
+
+	got := unstablePods([]corev1.Pod{readyPod("ready"), pending, crashLooping, completed, notReady})
+	want := []string{
+		"pod kube-system/crash-looping is crash-looping",
+		"pod kube-system/not-ready is not ready",
+		"pod kube-system/pending is in phase Pending",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unstablePods() = %v, want %v", got, want)
+	}
+}
+
+func Test_unstableDaemonSets(t *testing.T) {
+	stable := appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "stable", Namespace: metav1.NamespaceSystem, Generation: 2},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 3,
+			UpdatedNumberScheduled: 3,
+			NumberReady:            3,
+		},
+	}
+
+	rollingOut := stable
+	rollingOut.Name = "rolling-out"
+	rollingOut.Status.UpdatedNumberScheduled = 1
+	rollingOut.Status.NumberReady = 2
+
+	notObserved := stable
+	notObserved.Name = "not-observed"
+	notObserved.Generation = 3
+
+	got := unstableDaemonSets([]appsv1.DaemonSet{stable, rollingOut, notObserved})
+	want := []string{
+		"daemonset kube-system/not-observed has 3/3 pods ready and 3/3 updated",
+		"daemonset kube-system/rolling-out has 2/3 pods ready and 1/3 updated",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unstableDaemonSets() = %v, want %v", got, want)
+	}
+}
+
+func Test_unstableDeployments(t *testing.T) {
+	replicas := int32(2)
+
+	stable := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stable", Namespace: metav1.NamespaceSystem},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas: 2,
+			ReadyReplicas:   2,
+		},
+	}
+
+	notReady := stable
+	notReady.Name = "not-ready"
+	notReady.Status.ReadyReplicas = 1
+
+	defaultReplicas := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-replicas", Namespace: metav1.NamespaceSystem},
+	}
+
+	got := unstableDeployments([]appsv1.Deployment{stable, notReady, defaultReplicas})
+	want := []string{
+		"deployment kube-system/default-replicas has 0/1 replicas ready and 0/1 updated",
+		"deployment kube-system/not-ready has 1/2 replicas ready and 2/2 updated",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unstableDeployments() = %v, want %v", got, want)
+	}
+}
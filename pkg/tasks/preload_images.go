@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+func preloadImages(s *state.State) error {
+	return s.RunTaskOnAllNodes(preloadImagesOnNode, state.RunParallel)
+}
+
+// preloadImagesOnNode pulls the configured images that are not present on the
+// node yet. All images are attempted, failures are reported per image.
+func preloadImagesOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	s.Logger.Info("Preloading images...")
+
+	var failed []string
+	for _, image := range s.Cluster.ContainerRuntime.PreloadImages {
+		cmd, err := scripts.PreloadImage(image, s.Cluster.ContainerRuntime.Docker != nil)
+		if err != nil {
+			return err
+		}
+
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			s.Logger.Errorf("Failed to preload image %q on %q: %v", image, node.Hostname, err)
+			failed = append(failed, image)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fail.Runtime(fmt.Errorf("failed to pull %s", strings.Join(failed, ", ")), "preloading images on %q", node.Hostname)
+	}
+
+	return nil
+}
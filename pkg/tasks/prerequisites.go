@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/configupload"
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/runner"
 	"k8c.io/kubeone/pkg/scripts"
@@ -31,7 +32,9 @@ import (
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates"
 	"k8c.io/kubeone/pkg/templates/admissionconfig"
+	"k8c.io/kubeone/pkg/templates/auditpolicy"
 	encryptionproviders "k8c.io/kubeone/pkg/templates/encryptionproviders"
+	"k8c.io/kubeone/pkg/templates/schedulerconfig"
 
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -63,21 +66,39 @@ func generateConfigurationFiles(s *state.State) error {
 	s.Configuration.AddFile("cfg/cloud-config", s.Cluster.CloudProvider.CloudConfig)
 
 	if s.Cluster.Features.StaticAuditLog != nil && s.Cluster.Features.StaticAuditLog.Enable {
-		if err := s.Configuration.AddFilePath("cfg/audit-policy.yaml", s.Cluster.Features.StaticAuditLog.Config.PolicyFilePath, s.ManifestFilePath); err != nil {
+		auditPolicy, err := auditPolicyManifest(s)
+		if err != nil {
 			return err
 		}
+		s.Configuration.AddFile("cfg/audit-policy.yaml", auditPolicy)
 	}
-	if s.Cluster.Features.PodNodeSelector != nil && s.Cluster.Features.PodNodeSelector.Enable {
-		admissionCfg, err := admissionconfig.NewAdmissionConfig(s.Cluster.Versions.Kubernetes, s.Cluster.Features.PodNodeSelector)
+	if s.Cluster.AdmissionConfigEnabled() {
+		admissionCfg, err := admissionconfig.NewAdmissionConfig(s.Cluster.Versions.Kubernetes, s.Cluster.Features)
 		if err != nil {
 			return err
 		}
 		s.Configuration.AddFile("cfg/admission-config.yaml", admissionCfg)
-
+	}
+	if s.Cluster.Features.PodNodeSelector != nil && s.Cluster.Features.PodNodeSelector.Enable {
 		if err := s.Configuration.AddFilePath("cfg/podnodeselector.yaml", s.Cluster.Features.PodNodeSelector.Config.ConfigFilePath, s.ManifestFilePath); err != nil {
 			return err
 		}
 	}
+	if s.Cluster.Features.PodTolerationRestriction.Enabled() {
+		ptrCfg, err := admissionconfig.NewPodTolerationRestrictionConfig(s.Cluster.Features.PodTolerationRestriction)
+		if err != nil {
+			return err
+		}
+		s.Configuration.AddFile("cfg/podtolerationrestriction.yaml", ptrCfg)
+	}
+
+	if s.Cluster.SchedulerConfigEnabled() {
+		schedulerConfig, err := schedulerconfig.NewConfig(s.Cluster)
+		if err != nil {
+			return err
+		}
+		s.Configuration.AddFile(schedulerConfigFile, schedulerConfig)
+	}
 
 	if s.ShouldEnableEncryption() || s.EncryptionEnabled() {
 		configFileName := s.GetEncryptionProviderConfigName()
@@ -180,6 +201,7 @@ func installKubeadm(s *state.State, node kubeoneapi.HostConfig) error {
 		kubeoneapi.OperatingSystemNameDebian:  installKubeadmDebian,
 		kubeoneapi.OperatingSystemNameFlatcar: installKubeadmFlatcar,
 		kubeoneapi.OperatingSystemNameRHEL:    installKubeadmCentOS,
+		kubeoneapi.OperatingSystemNameRocky:   installKubeadmCentOS,
 		kubeoneapi.OperatingSystemNameUbuntu:  installKubeadmDebian,
 	})
 }
@@ -259,13 +281,22 @@ func uploadConfigurationFilesToNode(s *state.State, _ *kubeoneapi.HostConfig, co
 		return fail.SSH(err, "saving audit-policy")
 	}
 
-	cmd, err = scripts.SavePodNodeSelectorConfig(s.WorkDir)
+	cmd, err = scripts.SaveAdmissionConfig(s.WorkDir)
 	if err != nil {
 		return err
 	}
 	_, _, err = s.Runner.RunRaw(cmd)
 	if err != nil {
-		return fail.SSH(err, "saving podnodeselector config")
+		return fail.SSH(err, "saving admission config")
+	}
+
+	cmd, err = scripts.SaveSchedulerConfig(s.WorkDir)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.Runner.RunRaw(cmd)
+	if err != nil {
+		return fail.SSH(err, "saving kube-scheduler config")
 	}
 
 	cmd, err = scripts.SaveEncryptionProvidersConfig(s.WorkDir, s.GetEncryptionProviderConfigName())
@@ -295,3 +326,22 @@ func containerRuntimeEnvironment(s *state.State) error {
 
 	return fail.SSH(err, "configuring systemd environment drop-ins")
 }
+
+func auditPolicyManifest(s *state.State) (string, error) {
+	auditLogConfig := s.Cluster.Features.StaticAuditLog.Config
+
+	if auditLogConfig.PolicyPreset != "" {
+		return auditpolicy.NewPolicy(auditLogConfig.PolicyPreset)
+	}
+
+	policy, err := configupload.ReadFile(auditLogConfig.PolicyFilePath, s.ManifestFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	if err = auditpolicy.ValidatePolicy(policy); err != nil {
+		return "", err
+	}
+
+	return string(policy), nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	netutils "k8s.io/utils/net"
+)
+
+var proxyEnvNames = []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy"}
+
+func verifyProxyExclusion(s *state.State) error {
+	s.Logger.Infoln("Verifying proxy exclusions...")
+
+	return s.RunTaskOnAllNodes(verifyProxyExclusionOnNode, state.RunParallel)
+}
+
+// verifyProxyExclusionOnNode verifies that the effective proxy environment on
+// the node doesn't route the requests to the API server and the services
+// through the proxy, and confirms it by connecting to them from the node
+func verifyProxyExclusionOnNode(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	logger := s.Logger.WithField("node", node.PublicAddress)
+
+	stdout, _, err := s.Runner.RunRaw("sudo cat /etc/environment 2>/dev/null || true")
+	if err != nil {
+		return fail.SSH(err, "reading /etc/environment")
+	}
+	env := parseEnvironmentFile(stdout)
+
+	targets, err := proxyExclusionTargets(s.Cluster)
+	if err != nil {
+		return err
+	}
+
+	violations, err := proxiedTargets(env, targets)
+	if err != nil {
+		return err
+	}
+
+	var proxyEnv []string
+	for _, name := range proxyEnvNames {
+		if value, ok := env[name]; ok {
+			proxyEnv = append(proxyEnv, name+"="+value)
+		}
+	}
+
+	cmd, err := scripts.ProxyProbe(proxyEnv, targets)
+	if err != nil {
+		return err
+	}
+
+	stdout, _, err = s.Runner.RunRaw(cmd)
+	if err != nil {
+		return fail.SSH(err, "probing proxy exclusions")
+	}
+
+	// curl doesn't support all NO_PROXY formats supported by Kubernetes
+	// components (e.g. CIDRs in older versions), so the probe only warns
+	for _, target := range probedProxiedTargets(stdout) {
+		logger.Warnf("Connection to %s is routed through the proxy", target)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	for _, target := range violations {
+		logger.Errorf("%s is not excluded from the proxy, add it to the .proxy.noProxy", target)
+	}
+
+	return fail.ConfigValidation(errors.Errorf("requests from the node %q to %s would be routed through the proxy", node.PublicAddress, strings.Join(violations, ", ")))
+}
+
+// parseEnvironmentFile parses KEY="value" lines of the /etc/environment file,
+// including the entries managed by KubeOne suffixed with the #kubeone marker
+func parseEnvironmentFile(content string) map[string]string {
+	env := map[string]string{}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "#kubeone"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		env[strings.TrimSpace(name)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return env
+}
+
+// proxyExclusionTargets returns URLs of the API server endpoint, control plane
+// nodes, and the kubernetes service that must bypass the proxy
+func proxyExclusionTargets(cluster *kubeoneapi.KubeOneCluster) ([]string, error) {
+	targets := []string{
+		"https://" + net.JoinHostPort(cluster.APIEndpoint.Host, strconv.Itoa(cluster.APIEndpoint.Port)),
+	}
+
+	for _, host := range cluster.ControlPlane.Hosts {
+		targets = append(targets, "https://"+net.JoinHostPort(host.PrivateAddress, "6443"))
+		if host.DNSName != "" {
+			targets = append(targets, "https://"+net.JoinHostPort(host.DNSName, "6443"))
+		}
+	}
+
+	// the first service subnet is used for the kubernetes service IP
+	serviceSubnet := strings.Split(cluster.ClusterNetwork.ServiceSubnet, ",")[0]
+	_, serviceNet, err := net.ParseCIDR(strings.TrimSpace(serviceSubnet))
+	if err != nil {
+		return nil, fail.ConfigValidation(errors.Wrapf(err, "parsing service subnet %q", serviceSubnet))
+	}
+
+	kubernetesServiceIP, err := netutils.GetIndexedIP(serviceNet, 1)
+	if err != nil {
+		return nil, fail.ConfigValidation(err)
+	}
+
+	targets = append(targets,
+		"https://"+net.JoinHostPort(kubernetesServiceIP.String(), "443"),
+		fmt.Sprintf("https://kubernetes.default.svc.%s:443", cluster.ClusterNetwork.ServiceDomainName),
+	)
+
+	return targets, nil
+}
+
+// proxiedTargets returns targets that would be routed through the proxy
+// according to the given environment
+func proxiedTargets(env map[string]string, targets []string) ([]string, error) {
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  envValue(env, "HTTP_PROXY"),
+		HTTPSProxy: envValue(env, "HTTPS_PROXY"),
+		NoProxy:    envValue(env, "NO_PROXY"),
+	}).ProxyFunc()
+
+	var proxied []string
+	for _, target := range targets {
+		targetURL, err := url.Parse(target)
+		if err != nil {
+			return nil, fail.Runtime(err, "parsing URL %q", target)
+		}
+
+		proxyURL, err := proxyFunc(targetURL)
+		if err != nil {
+			return nil, fail.ConfigValidation(errors.Wrap(err, "parsing proxy URL"))
+		}
+
+		if proxyURL != nil {
+			proxied = append(proxied, target)
+		}
+	}
+
+	return proxied, nil
+}
+
+// probedProxiedTargets parses the ProxyProbe script output and returns targets
+// connected via a different remote IP when using the proxy environment
+func probedProxiedTargets(output string) []string {
+	var proxied []string
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+
+		target, envIP, directIP := fields[0], fields[1], fields[2]
+		if envIP != "-" && envIP != directIP {
+			proxied = append(proxied, target)
+		}
+	}
+
+	return proxied
+}
+
+func envValue(env map[string]string, name string) string {
+	if value := env[name]; value != "" {
+		return value
+	}
+
+	return env[strings.ToLower(name)]
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func Test_parseEnvironmentFile(t *testing.T) {
+	content := `PATH="/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin"
+# comment
+HTTPS_PROXY="http://proxy:3128"#kubeone
+https_proxy="http://proxy:3128"#kubeone
+NO_PROXY=".svc,10.0.0.0/8"#kubeone
+`
+	want := map[string]string{
+		"PATH":        "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin",
+		"HTTPS_PROXY": "http://proxy:3128",
+		"https_proxy": "http://proxy:3128",
+		"NO_PROXY":    ".svc,10.0.0.0/8",
+	}
+
+	if got := parseEnvironmentFile(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEnvironmentFile() = %v, want %v", got, want)
+	}
+}
+
+func Test_proxiedTargets(t *testing.T) {
+	cluster := &kubeoneapi.KubeOneCluster{
+		APIEndpoint: kubeoneapi.APIEndpoint{Host: "api.example.com", Port: 6443},
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{{PrivateAddress: "10.0.0.10"}},
+		},
+		ClusterNetwork: kubeoneapi.ClusterNetworkConfig{
+			ServiceSubnet:     "10.96.0.0/12",
+			ServiceDomainName: "cluster.local",
+		},
+	}
+
+	targets, err := proxyExclusionTargets(cluster)
+	if err != nil {
+		t.Fatalf("proxyExclusionTargets() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want []string
+	}{
+		{
+			name: "everything excluded",
+			env: map[string]string{
+				"HTTPS_PROXY": "http://proxy:3128",
+				"NO_PROXY":    "api.example.com,10.0.0.0/8,10.96.0.0/12,.svc,.cluster.local",
+			},
+		},
+		{
+			name: "lowercase variables",
+			env: map[string]string{
+				"https_proxy": "http://proxy:3128",
+				"no_proxy":    ".example.com,10.0.0.10,10.96.0.1,.svc.cluster.local",
+			},
+		},
+		{
+			name: "service subnet and domain missing",
+			env: map[string]string{
+				"HTTPS_PROXY": "http://proxy:3128",
+				"NO_PROXY":    "api.example.com,10.0.0.10",
+			},
+			want: []string{
+				"https://10.96.0.1:443",
+				"https://kubernetes.default.svc.cluster.local:443",
+			},
+		},
+		{
+			name: "only http proxy",
+			env: map[string]string{
+				"HTTP_PROXY": "http://proxy:3128",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := proxiedTargets(tt.env, targets)
+			if err != nil {
+				t.Fatalf("proxiedTargets() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("proxiedTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_probedProxiedTargets(t *testing.T) {
+	output := `https://10.0.0.10:6443 - -
+https://10.96.0.1:443 192.168.1.1 -
+https://api.example.com:6443 1.2.3.4 1.2.3.4
+`
+	want := []string{"https://10.96.0.1:443"}
+
+	if got := probedProxiedTargets(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("probedProxiedTargets() = %v, want %v", got, want)
+	}
+}
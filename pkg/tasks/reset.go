@@ -26,16 +26,13 @@ import (
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates/machinecontroller"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func destroyWorkers(s *state.State) error {
-	if !s.DestroyWorkers {
-		return nil
-	}
-
+func ensureResetKubernetesClientset(s *state.State) error {
 	var lastErr error
-	s.Logger.Infoln("Destroying worker nodes...")
 
 	_ = wait.ExponentialBackoff(defaultRetryBackoff(3), func() (bool, error) {
 		if s.DynamicClient != nil {
@@ -51,6 +48,53 @@ func destroyWorkers(s *state.State) error {
 
 		return true, nil
 	})
+
+	return lastErr
+}
+
+func cordonAllNodes(s *state.State) error {
+	if !s.CordonNodes {
+		return nil
+	}
+
+	s.Logger.Infoln("Cordoning all nodes...")
+
+	if err := ensureResetKubernetesClientset(s); err != nil {
+		s.Logger.Warn("Unable to connect to the control plane API and cordon nodes")
+		s.Logger.Warn("You can skip cordoning nodes using `--cordon-nodes=false`")
+
+		return err
+	}
+
+	nodeList := corev1.NodeList{}
+	if err := s.DynamicClient.List(s.Context, &nodeList); err != nil {
+		return fail.KubeClient(err, "getting %T", nodeList)
+	}
+
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if node.Spec.Unschedulable {
+			continue
+		}
+
+		oldNode := node.DeepCopy()
+		node.Spec.Unschedulable = true
+		if err := s.DynamicClient.Patch(s.Context, node, dynclient.MergeFrom(oldNode)); err != nil {
+			return fail.KubeClient(err, "cordoning Node %s", node.Name)
+		}
+	}
+
+	return nil
+}
+
+func destroyWorkers(s *state.State) error {
+	if !s.DestroyWorkers {
+		return nil
+	}
+
+	s.Logger.Infoln("Destroying worker nodes...")
+
+	lastErr := ensureResetKubernetesClientset(s)
 	if lastErr != nil {
 		s.Logger.Warn("Unable to connect to the control plane API and destroy worker nodes")
 		s.Logger.Warn("You can skip destroying worker nodes and destroy them manually using `--destroy-workers=false`")
@@ -106,6 +150,17 @@ func resetAllNodes(s *state.State) error {
 func resetNode(s *state.State, host *kubeoneapi.HostConfig, conn ssh.Connection) error {
 	s.Logger.Infoln("Resetting node...")
 
+	if s.Cluster.Firewall != nil {
+		cmd, err := scripts.FirewallReset(s.Cluster.Firewall.Backend == kubeoneapi.FirewallBackendFirewalld)
+		if err != nil {
+			return err
+		}
+
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			return fail.SSH(err, "removing firewall rules")
+		}
+	}
+
 	cmd, err := scripts.KubeadmReset(s.KubeadmVerboseFlag(), s.WorkDir)
 	if err != nil {
 		return err
@@ -141,6 +196,7 @@ func removeBinaries(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connec
 		kubeoneapi.OperatingSystemNameDebian:  removeBinariesDebian,
 		kubeoneapi.OperatingSystemNameFlatcar: removeBinariesFlatcar,
 		kubeoneapi.OperatingSystemNameRHEL:    removeBinariesCentOS,
+		kubeoneapi.OperatingSystemNameRocky:   removeBinariesCentOS,
 		kubeoneapi.OperatingSystemNameUbuntu:  removeBinariesDebian,
 	})
 }
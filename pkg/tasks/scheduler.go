@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/schedulerconfig"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	schedulerConfigFile           = "cfg/scheduler-config.yaml"
+	schedulerManifestPath         = "/etc/kubernetes/manifests/kube-scheduler.yaml"
+	schedulerConfigVolumeName     = "scheduler-conf"
+	schedulerConfigHashAnnotation = "kubeone.io/scheduler-config-hash"
+)
+
+// ensureSchedulerConfig uploads the kube-scheduler configuration file and
+// makes the kube-scheduler static pods use it. The configuration hash is
+// stored in the static pod annotations, so kubelet restarts the kube-scheduler
+// when the configuration changes.
+func ensureSchedulerConfig(s *state.State) error {
+	config, err := schedulerconfig.NewConfig(s.Cluster)
+	if err != nil {
+		return err
+	}
+
+	s.Configuration.AddFile(schedulerConfigFile, config)
+	configHash := schedulerconfig.ConfigHash(config)
+
+	return s.RunTaskOnControlPlane(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		return ensureSchedulerConfigOnNode(s, node, conn, configHash)
+	}, state.RunSequentially)
+}
+
+func ensureSchedulerConfigOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection, configHash string) error {
+	if err := s.Configuration.UploadTo(conn, s.WorkDir); err != nil {
+		return err
+	}
+
+	cmd, err := scripts.SaveSchedulerConfig(s.WorkDir)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+		return fail.SSH(err, "saving kube-scheduler config")
+	}
+
+	return updateStaticPod(s, node, schedulerManifestPath, "kube-scheduler", func(pod *corev1.Pod) bool {
+		return patchSchedulerPod(pod, configHash)
+	})
+}
+
+// patchSchedulerPod makes the kube-scheduler static pod use the configuration
+// file and sets the configuration hash annotation. It returns true if the pod
+// has been modified.
+func patchSchedulerPod(pod *corev1.Pod, configHash string) bool {
+	if len(pod.Spec.Containers) == 0 {
+		return false
+	}
+
+	changed := false
+
+	if pod.Annotations[schedulerConfigHashAnnotation] != configHash {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[schedulerConfigHashAnnotation] = configHash
+		changed = true
+	}
+
+	container := &pod.Spec.Containers[0]
+	configFlag := "--config=" + schedulerconfig.ConfigPath
+	foundFlag := false
+	for i, arg := range container.Command {
+		if strings.HasPrefix(arg, "--config=") {
+			foundFlag = true
+			if arg != configFlag {
+				container.Command[i] = configFlag
+				changed = true
+			}
+		}
+	}
+	if !foundFlag {
+		container.Command = append(container.Command, configFlag)
+		changed = true
+	}
+
+	foundVolume := false
+	for _, vol := range pod.Spec.Volumes {
+		if vol.Name == schedulerConfigVolumeName {
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: schedulerConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: schedulerconfig.ConfigDir,
+					Type: &hostPathType,
+				},
+			},
+		})
+		changed = true
+	}
+
+	foundMount := false
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == schedulerConfigVolumeName {
+			foundMount = true
+		}
+	}
+	if !foundMount {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      schedulerConfigVolumeName,
+			MountPath: schedulerconfig.ConfigDir,
+			ReadOnly:  true,
+		})
+		changed = true
+	}
+
+	return changed
+}
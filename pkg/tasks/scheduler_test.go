@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/templates/schedulerconfig"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func genSchedulerPod() corev1.Pod {
+	return corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "kube-scheduler",
+					Command: []string{
+						"kube-scheduler",
+						"--kubeconfig=/etc/kubernetes/scheduler.conf",
+						"--leader-elect=true",
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_patchSchedulerPod(t *testing.T) {
+	pod := genSchedulerPod()
+
+	if !patchSchedulerPod(&pod, "hash1") {
+		t.Fatal("expected the pod to be patched")
+	}
+
+	container := pod.Spec.Containers[0]
+	if got := container.Command[len(container.Command)-1]; got != "--config="+schedulerconfig.ConfigPath {
+		t.Errorf("expected --config flag to be appended, got %q", got)
+	}
+	if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].HostPath.Path != schedulerconfig.ConfigDir {
+		t.Errorf("expected the config volume to be added, got %v", pod.Spec.Volumes)
+	}
+	if len(container.VolumeMounts) != 1 || !container.VolumeMounts[0].ReadOnly {
+		t.Errorf("expected the read-only config volume mount to be added, got %v", container.VolumeMounts)
+	}
+	if got := pod.Annotations[schedulerConfigHashAnnotation]; got != "hash1" {
+		t.Errorf("expected config hash annotation hash1, got %q", got)
+	}
+
+	if patchSchedulerPod(&pod, "hash1") {
+		t.Error("expected the already patched pod not to be changed")
+	}
+	if len(pod.Spec.Containers[0].Command) != 4 {
+		t.Errorf("expected --config flag not to be duplicated, got %v", pod.Spec.Containers[0].Command)
+	}
+
+	if !patchSchedulerPod(&pod, "hash2") {
+		t.Error("expected the pod to be patched on configuration change")
+	}
+	if got := pod.Annotations[schedulerConfigHashAnnotation]; got != "hash2" {
+		t.Errorf("expected config hash annotation hash2, got %q", got)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package tasks
 
 import (
+	"time"
+
 	"k8c.io/kubeone/pkg/addons"
 	"k8c.io/kubeone/pkg/certificate"
 	"k8c.io/kubeone/pkg/clusterstatus"
@@ -24,6 +26,7 @@ import (
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/features"
 	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/smoketest"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates/externalccm"
 	"k8c.io/kubeone/pkg/templates/machinecontroller"
@@ -37,7 +40,10 @@ func (t Tasks) Run(s *state.State) error {
 		if step.Predicate != nil && !step.Predicate(s) {
 			continue
 		}
-		if err := step.Run(s); err != nil {
+		started := time.Now()
+		err := step.Run(s)
+		s.Metrics.ObservePhase(step.Operation, time.Since(started), err)
+		if err != nil {
 			return fail.Runtime(err, step.Operation)
 		}
 	}
@@ -110,6 +116,16 @@ func WithHostnameOSAndProbes(t Tasks) Tasks {
 // orchestrate complete cluster init
 func WithFullInstall(t Tasks) Tasks {
 	return WithHostnameOSAndProbes(t).append(Tasks{
+		{
+			Fn:        verifyCgroupVersion,
+			Operation: "verifying cgroup version",
+			Predicate: func(s *state.State) bool { return s.Cluster.ContainerRuntime.Cgroups != nil },
+		},
+		{
+			Fn:        certificate.LoadCertificateAuthorities,
+			Operation: "loading certificate authorities",
+			Predicate: func(s *state.State) bool { return s.Cluster.CertificateAuthority != nil },
+		},
 		{
 			Fn: func(s *state.State) error {
 				return s.RunTaskOnAllNodes(disableNMCloudSetup, state.RunParallel)
@@ -120,10 +136,47 @@ func WithFullInstall(t Tasks) Tasks {
 			Fn:        installPrerequisites,
 			Operation: "installing prerequisites",
 		},
+		{
+			Fn: func(s *state.State) error {
+				return s.RunTaskOnAllNodes(verifyKubeletCgroups, state.RunParallel)
+			},
+			Operation: "verifying kubelet reserved cgroups",
+		},
+		{
+			Fn: func(s *state.State) error {
+				return s.RunTaskOnControlPlane(verifyBindAddresses, state.RunParallel)
+			},
+			Operation: "verifying control plane bind addresses",
+			Predicate: func(s *state.State) bool { return s.Cluster.BindAddressesConfigured() },
+		},
+		{
+			Fn: func(s *state.State) error {
+				return s.RunTaskOnControlPlane(verifyDNSNames, state.RunParallel)
+			},
+			Operation: "verifying control plane DNS names",
+			Predicate: func(s *state.State) bool { return len(s.Cluster.ControlPlaneDNSNames()) > 0 },
+		},
+		{
+			Fn:        verifyProxyExclusion,
+			Operation: "verifying proxy exclusions",
+			Predicate: func(s *state.State) bool { return s.Cluster.Proxy.HTTP != "" || s.Cluster.Proxy.HTTPS != "" },
+		},
+		{
+			Fn:        verifyNodeConnectivity,
+			Operation: "verifying node-to-node connectivity",
+			Predicate: func(s *state.State) bool { return s.Cluster.ClusterNetwork.NodeConnectivityCheck != nil },
+		},
 	}...).
 		append(kubernetesConfigFiles()...).
 		append(Tasks{
 			{Fn: prePullImages, Operation: "pre-pull images"},
+			{
+				Fn: func(s *state.State) error {
+					return s.RunTaskOnLeader(certificate.UploadCertificateAuthorities)
+				},
+				Operation: "uploading certificate authorities",
+				Predicate: func(s *state.State) bool { return s.Cluster.CertificateAuthority != nil },
+			},
 			{
 				Fn: func(s *state.State) error {
 					s.Logger.Infoln("Configuring certs and etcd on control plane node...")
@@ -156,6 +209,11 @@ func WithFullInstall(t Tasks) Tasks {
 				},
 				Operation: "provisioning certificates on the followers",
 			},
+			{
+				Fn:        saveClientCABundle,
+				Operation: "saving client CA bundle",
+				Predicate: func(s *state.State) bool { return s.Cluster.Features.StaticAuth.ClientCABundleEnabled() },
+			},
 			{Fn: initKubernetesLeader, Operation: "initializing kubernetes on leader"},
 			{Fn: kubeconfig.BuildKubernetesClientset, Operation: "building kubernetes clientset"},
 			{
@@ -165,6 +223,11 @@ func WithFullInstall(t Tasks) Tasks {
 				Operation: "approving leader's kubelet CSR",
 			},
 			{Fn: repairClusterIfNeeded, Operation: "repairing cluster"},
+			{
+				Fn:        generateDiscoveryKubeconfig,
+				Operation: "generating discovery kubeconfig",
+				Predicate: func(s *state.State) bool { return s.Cluster.Features.StaticAuth.AnonymousAuthDisabled() },
+			},
 			{Fn: joinControlplaneNode, Operation: "joining followers control plane nodes"},
 			{Fn: restartKubeAPIServer, Operation: "restarting unhealthy kube-apiserver"},
 		}...).
@@ -187,10 +250,50 @@ func WithResources(t Tasks) Tasks {
 					return s.Cluster.CABundle != ""
 				},
 			},
+			{
+				Fn:          saveClientCABundle,
+				Operation:   "saving client CA bundle",
+				Description: "ensure client CA bundle",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Features.StaticAuth.ClientCABundleEnabled() },
+			},
 			{
 				Fn:        patchStaticPods,
 				Operation: "patching static pods",
 			},
+			{
+				Fn:          ensureSchedulerConfig,
+				Operation:   "ensuring kube-scheduler config",
+				Description: "ensure kube-scheduler configuration",
+				Predicate:   func(s *state.State) bool { return s.Cluster.SchedulerConfigEnabled() },
+			},
+			{
+				Fn:          ensureAPIServerConfig,
+				Operation:   "ensuring kube-apiserver config",
+				Description: "ensure kube-apiserver configuration",
+			},
+			{
+				Fn:          ensureControllerManagerConfig,
+				Operation:   "ensuring kube-controller-manager config",
+				Description: "ensure kube-controller-manager configuration",
+				Predicate:   func(s *state.State) bool { return s.Cluster.ControllerManagerConfigEnabled() },
+			},
+			{
+				Fn:          ensureBindAddresses,
+				Operation:   "ensuring control plane bind addresses",
+				Description: "ensure control plane components bind addresses",
+				Predicate:   func(s *state.State) bool { return s.Cluster.BindAddressesConfigured() },
+			},
+			{
+				Fn:          ensureDNSNames,
+				Operation:   "ensuring etcd peer DNS names",
+				Description: "ensure etcd members use the control plane DNS names",
+				Predicate:   func(s *state.State) bool { return len(s.Cluster.ControlPlaneDNSNames()) > 0 },
+			},
+			{
+				Fn:          ensureAPIServerCertSANs,
+				Operation:   "ensuring kube-apiserver certificate SANs",
+				Description: "ensure kube-apiserver certificate includes all configured SANs",
+			},
 			{
 				Fn:          renewControlPlaneCerts,
 				Operation:   "renewing certificates",
@@ -211,6 +314,11 @@ func WithResources(t Tasks) Tasks {
 				},
 				Operation: "downloading Kubernetes PKI from the leader",
 			},
+			{
+				Fn:          ensureNamespaces,
+				Operation:   "ensuring namespaces",
+				Description: "ensure namespaces",
+			},
 			{
 				Fn:        features.Activate,
 				Operation: "activating features",
@@ -247,20 +355,72 @@ func WithResources(t Tasks) Tasks {
 				Description: "ensure custom addons",
 				Predicate:   func(s *state.State) bool { return s.Cluster.Addons != nil && s.Cluster.Addons.Enable },
 			},
+			{
+				Fn:          ensureWebhookCABundles,
+				Operation:   "injecting webhook caBundles",
+				Description: "ensure caBundle of the webhook configurations",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Features.WebhookCABundleInjection.Enabled() },
+			},
 			{
 				Fn:          externalccm.Ensure,
 				Operation:   "ensuring external CCM",
 				Description: "ensure external CCM",
 				Predicate:   func(s *state.State) bool { return s.Cluster.CloudProvider.External },
 			},
+			{
+				Fn:        generateDiscoveryKubeconfig,
+				Operation: "generating discovery kubeconfig",
+				Predicate: func(s *state.State) bool { return s.Cluster.Features.StaticAuth.AnonymousAuthDisabled() },
+			},
 			{
 				Fn:        joinStaticWorkerNodes,
 				Operation: "joining static worker nodes to the cluster",
 			},
+			{
+				Fn:          ensureKubeletNodeAllocatable,
+				Operation:   "ensuring kubelet node allocatable",
+				Description: "ensure kubelet reserved resources and node allocatable enforcement",
+			},
+			{
+				Fn:          ensureFirewall,
+				Operation:   "ensuring firewall rules",
+				Description: "ensure host firewall rules",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Firewall != nil },
+			},
+			{
+				Fn:          ensureContainerdConfig,
+				Operation:   "ensuring containerd config",
+				Description: "ensure containerd config",
+				Predicate:   func(s *state.State) bool { return s.Cluster.ContainerRuntime.Containerd != nil },
+			},
+			{
+				Fn:          ensureRuntimeClasses,
+				Operation:   "ensuring RuntimeClasses",
+				Description: "ensure RuntimeClasses for additional containerd runtimes",
+				Predicate:   func(s *state.State) bool { return s.Cluster.ContainerRuntime.Containerd.RuntimeClassesEnabled() },
+			},
+			{
+				Fn:          preloadImages,
+				Operation:   "preloading images",
+				Description: "ensure preloaded images on all nodes",
+				Predicate:   func(s *state.State) bool { return len(s.Cluster.ContainerRuntime.PreloadImages) > 0 },
+			},
+			{
+				Fn:          approvePendingServingCSRs,
+				Operation:   "approving kubelet serving CSRs",
+				Description: "approve pending kubelet serving CSRs",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Features.KubeletServingCertRotation.Enabled() },
+			},
 			{
 				Fn:        labelNodeOSes,
 				Operation: "labelling nodes with their OS",
 			},
+			{
+				Fn:          waitForNodesReady,
+				Operation:   "waiting for nodes to become ready",
+				Description: "wait for the external CNI to make nodes ready",
+				Predicate:   func(s *state.State) bool { return s.Cluster.ClusterNetwork.CNI.External != nil },
+			},
 			{
 				Fn:        machinecontroller.WaitReady,
 				Operation: "waiting for machine-controller",
@@ -280,6 +440,16 @@ func WithUpgrade(t Tasks) Tasks {
 		append(Tasks{
 			{Fn: kubeconfig.BuildKubernetesClientset, Operation: "building kubernetes clientset"},
 			{Fn: runPreflightChecks, Operation: "checking preflight safetynet", Retries: 1},
+			{
+				Fn:        verifyCgroupVersion,
+				Operation: "verifying cgroup version",
+				Predicate: func(s *state.State) bool { return s.Cluster.ContainerRuntime.Cgroups != nil },
+			},
+			{
+				Fn:        saveClientCABundle,
+				Operation: "saving client CA bundle",
+				Predicate: func(s *state.State) bool { return s.Cluster.Features.StaticAuth.ClientCABundleEnabled() },
+			},
 			{Fn: upgradeLeader, Operation: "upgrading leader control plane"},
 			{Fn: upgradeFollower, Operation: "upgrading follower control plane"},
 			{
@@ -306,6 +476,7 @@ func WithUpgrade(t Tasks) Tasks {
 
 func WithReset(t Tasks) Tasks {
 	return t.append(Tasks{
+		{Fn: cordonAllNodes, Operation: "cordoning all nodes"},
 		{Fn: destroyWorkers, Operation: "destroying workers"},
 		{Fn: resetAllNodes, Operation: "resetting all nodes"},
 		{Fn: removeBinariesAllNodes, Operation: "removing kubernetes binaries from nodes"},
@@ -353,6 +524,61 @@ func WithClusterStatus(t Tasks) Tasks {
 		}...)
 }
 
+// WithSmokeTests runs smoke tests against the cluster
+func WithSmokeTests(t Tasks, timeout time.Duration) Tasks {
+	// smoke tests need only the API access, so the hosts are not contacted
+	// if the user provided the kubeconfig to use
+	viaSSH := func(s *state.State) bool { return !s.ExternalKubeconfig() }
+
+	return t.append(Tasks{
+		{Fn: determineHostname, Operation: "detecting hostname", Predicate: viaSSH},
+		{Fn: determineOS, Operation: "detecting OS", Predicate: viaSSH},
+		{Fn: kubeconfig.BuildKubernetesClientset, Operation: "building kubernetes clientset"},
+		{
+			Fn: func(s *state.State) error {
+				return smoketest.Run(s, timeout)
+			},
+			Operation: "running smoke tests",
+			Retries:   1,
+		},
+	}...)
+}
+
+// WithNodeLabels reconciles only the labels, annotations and taints of the
+// control plane and static worker nodes, without provisioning any host
+func WithNodeLabels(t Tasks) Tasks {
+	return t.append(Tasks{
+		{Fn: kubeconfig.BuildKubernetesClientset, Operation: "building kubernetes clientset"},
+		{Fn: reconcileNodeLabels, Operation: "reconciling nodes labels, annotations and taints"},
+	}...)
+}
+
+// WithPostUpgradeWait waits for the system workloads and etcd to stabilize
+// after the upgrade
+func WithPostUpgradeWait(t Tasks, timeout time.Duration) Tasks {
+	return t.append(Task{
+		Fn: func(s *state.State) error {
+			return waitForClusterStabilization(s, timeout)
+		},
+		Operation:   "waiting for the cluster to stabilize",
+		Description: "wait for the system workloads and etcd to stabilize",
+		Retries:     1,
+	})
+}
+
+// WithPostApplyValidation validates the cluster using the post-apply
+// validation webhook if configured
+func WithPostApplyValidation(t Tasks) Tasks {
+	return t.append(Task{
+		Fn:          runPostApplyValidation,
+		Operation:   "validating the cluster using the post-apply validation webhook",
+		Description: "validate the cluster using the post-apply validation webhook",
+		Predicate: func(s *state.State) bool {
+			return s.Cluster.PostApplyValidation != nil && s.Cluster.PostApplyValidation.Webhook != nil
+		},
+	})
+}
+
 func kubernetesConfigFiles() Tasks {
 	return Tasks{
 		{Fn: generateKubeadm, Operation: "generating kubeadm config files"},
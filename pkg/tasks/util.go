@@ -80,7 +80,7 @@ func determineOS(s *state.State) error {
 
 	return s.RunTaskOnAllNodes(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 		if node.OperatingSystem != kubeoneapi.OperatingSystemNameUnknown {
-			s.Logger.Debugf("Operating system is already set to %q", node.OperatingSystem)
+			s.Logger.Debugf("Operating system is set to %q in the configuration, skipping detection", node.OperatingSystem)
 
 			return nil
 		}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"k8c.io/kubeone/pkg/certificate"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ensureWebhookCABundles injects the cluster CA certificate into the caBundle
+// of all webhooks in the configured webhook configurations. All configurations
+// are fetched before any of them is updated, so missing configurations fail
+// the task without modifying the cluster.
+func ensureWebhookCABundles(s *state.State) error {
+	if s.DynamicClient == nil {
+		return fail.NoKubeClient()
+	}
+
+	caBundle, ok := s.Configuration.KubernetesPKI[certificate.KubernetesCACertPath]
+	if !ok {
+		return fail.Runtime(fmt.Errorf("%s not found", certificate.KubernetesCACertPath), "loading cluster CA certificate")
+	}
+
+	s.Logger.Infoln("Injecting caBundle into webhook configurations...")
+
+	ctx := context.Background()
+	feature := s.Cluster.Features.WebhookCABundleInjection
+
+	var (
+		objects []client.Object
+		missing []string
+	)
+
+	for _, name := range feature.ValidatingWebhookConfigurations {
+		obj := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		found, err := getWebhookConfiguration(ctx, s.DynamicClient, name, obj)
+		if err != nil {
+			return err
+		}
+		if !found {
+			missing = append(missing, "ValidatingWebhookConfiguration "+name)
+
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	for _, name := range feature.MutatingWebhookConfigurations {
+		obj := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		found, err := getWebhookConfiguration(ctx, s.DynamicClient, name, obj)
+		if err != nil {
+			return err
+		}
+		if !found {
+			missing = append(missing, "MutatingWebhookConfiguration "+name)
+
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	if len(missing) > 0 {
+		return fail.Runtime(fmt.Errorf("not found: %s", strings.Join(missing, ", ")), "getting webhook configurations")
+	}
+
+	for _, obj := range objects {
+		if !injectCABundle(obj, caBundle) {
+			continue
+		}
+
+		if err := s.DynamicClient.Update(ctx, obj); err != nil {
+			return fail.KubeClient(err, "updating %T %s", obj, obj.GetName())
+		}
+	}
+
+	return nil
+}
+
+func getWebhookConfiguration(ctx context.Context, c client.Client, name string, obj client.Object) (bool, error) {
+	err := c.Get(ctx, client.ObjectKey{Name: name}, obj)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fail.KubeClient(err, "getting %T %s", obj, name)
+	}
+
+	return true, nil
+}
+
+// injectCABundle sets the caBundle of all webhooks in the validating or
+// mutating webhook configuration. It reports whether the object has been
+// changed.
+func injectCABundle(obj client.Object, caBundle []byte) bool {
+	var clientConfigs []*admissionregistrationv1.WebhookClientConfig
+
+	switch cfg := obj.(type) {
+	case *admissionregistrationv1.ValidatingWebhookConfiguration:
+		for i := range cfg.Webhooks {
+			clientConfigs = append(clientConfigs, &cfg.Webhooks[i].ClientConfig)
+		}
+	case *admissionregistrationv1.MutatingWebhookConfiguration:
+		for i := range cfg.Webhooks {
+			clientConfigs = append(clientConfigs, &cfg.Webhooks[i].ClientConfig)
+		}
+	}
+
+	changed := false
+	for _, clientConfig := range clientConfigs {
+		if bytes.Equal(clientConfig.CABundle, caBundle) {
+			continue
+		}
+		clientConfig.CABundle = caBundle
+		changed = true
+	}
+
+	return changed
+}
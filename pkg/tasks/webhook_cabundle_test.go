@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_injectCABundle(t *testing.T) {
+	caBundle := []byte("new-ca")
+
+	tests := []struct {
+		name        string
+		obj         client.Object
+		wantChanged bool
+	}{
+		{
+			name: "validating webhooks with outdated caBundle",
+			obj: &admissionregistrationv1.ValidatingWebhookConfiguration{
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{
+					{Name: "a.example.com", ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte("old-ca")}},
+					{Name: "b.example.com", ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: caBundle}},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "mutating webhooks without caBundle",
+			obj: &admissionregistrationv1.MutatingWebhookConfiguration{
+				Webhooks: []admissionregistrationv1.MutatingWebhook{
+					{Name: "a.example.com"},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "up to date caBundle",
+			obj: &admissionregistrationv1.MutatingWebhookConfiguration{
+				Webhooks: []admissionregistrationv1.MutatingWebhook{
+					{Name: "a.example.com", ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: caBundle}},
+				},
+			},
+			wantChanged: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := injectCABundle(tt.obj, caBundle); got != tt.wantChanged {
+				t.Errorf("injectCABundle() = %v, want %v", got, tt.wantChanged)
+			}
+
+			var clientConfigs []admissionregistrationv1.WebhookClientConfig
+			switch cfg := tt.obj.(type) {
+			case *admissionregistrationv1.ValidatingWebhookConfiguration:
+				for _, w := range cfg.Webhooks {
+					clientConfigs = append(clientConfigs, w.ClientConfig)
+				}
+			case *admissionregistrationv1.MutatingWebhookConfiguration:
+				for _, w := range cfg.Webhooks {
+					clientConfigs = append(clientConfigs, w.ClientConfig)
+				}
+			}
+			for _, clientConfig := range clientConfigs {
+				if !bytes.Equal(clientConfig.CABundle, caBundle) {
+					t.Errorf("injectCABundle() caBundle = %q, want %q", clientConfig.CABundle, caBundle)
+				}
+			}
+		})
+	}
+}
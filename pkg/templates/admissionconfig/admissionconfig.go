@@ -25,12 +25,27 @@ import (
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/templates"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
 )
 
+// PodTolerationRestrictionConfigPath is the path of the PodTolerationRestriction
+// plugin configuration file on the control plane nodes
+const PodTolerationRestrictionConfigPath = "/etc/kubernetes/admission/podtolerationrestriction.yaml"
+
+// podTolerationRestrictionConfiguration is the configuration file of the
+// PodTolerationRestriction admission plugin
+type podTolerationRestrictionConfiguration struct {
+	APIVersion string              `json:"apiVersion"`
+	Kind       string              `json:"kind"`
+	Default    []corev1.Toleration `json:"default,omitempty"`
+	Whitelist  []corev1.Toleration `json:"whitelist,omitempty"`
+}
+
 // NewAdmissionConfig generates the AdmissionConfiguration manifest
-func NewAdmissionConfig(k8sVersion string, podNodeSelectorFeature *kubeoneapi.PodNodeSelector) (string, error) {
+func NewAdmissionConfig(k8sVersion string, features kubeoneapi.Features) (string, error) {
 	sver, err := semver.NewVersion(k8sVersion)
 	if err != nil {
 		return "", fail.Runtime(err, "parsing kubernetes semver")
@@ -43,15 +58,37 @@ func NewAdmissionConfig(k8sVersion string, podNodeSelectorFeature *kubeoneapi.Po
 	var admissionCfg []runtime.Object
 	switch {
 	case c.Check(sver):
-		admissionCfg = admissionConfigV1alpha1(podNodeSelectorFeature)
+		admissionCfg = admissionConfigV1alpha1(features)
 	default:
-		admissionCfg = admissionConfigV1(podNodeSelectorFeature)
+		admissionCfg = admissionConfigV1(features)
 	}
 
 	return templates.KubernetesToYAML(admissionCfg)
 }
 
-func admissionConfigV1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector) []runtime.Object {
+// NewPodTolerationRestrictionConfig generates the PodTolerationRestriction
+// plugin configuration file with the cluster-wide default and whitelisted
+// tolerations
+func NewPodTolerationRestrictionConfig(feature *kubeoneapi.PodTolerationRestriction) (string, error) {
+	config := podTolerationRestrictionConfiguration{
+		APIVersion: "podtolerationrestriction.admission.k8s.io/v1alpha1",
+		Kind:       "Configuration",
+	}
+
+	if feature != nil {
+		config.Default = feature.DefaultTolerations
+		config.Whitelist = feature.Whitelist
+	}
+
+	buf, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fail.Runtime(err, "marshalling PodTolerationRestriction configuration")
+	}
+
+	return string(buf), nil
+}
+
+func admissionConfigV1(features kubeoneapi.Features) []runtime.Object {
 	admissionConfig := &apiserverv1.AdmissionConfiguration{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apiserver.config.k8s.io/v1",
@@ -59,7 +96,7 @@ func admissionConfigV1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector) []run
 		},
 	}
 
-	if podNodeSelectorFeature != nil && podNodeSelectorFeature.Enable {
+	if features.PodNodeSelector != nil && features.PodNodeSelector.Enable {
 		pnsPlugin := apiserverv1.AdmissionPluginConfiguration{
 			Name: "PodNodeSelector",
 			Path: "/etc/kubernetes/admission/podnodeselector.yaml",
@@ -67,10 +104,18 @@ func admissionConfigV1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector) []run
 		admissionConfig.Plugins = append(admissionConfig.Plugins, pnsPlugin)
 	}
 
+	if features.PodTolerationRestriction.Enabled() {
+		ptrPlugin := apiserverv1.AdmissionPluginConfiguration{
+			Name: "PodTolerationRestriction",
+			Path: PodTolerationRestrictionConfigPath,
+		}
+		admissionConfig.Plugins = append(admissionConfig.Plugins, ptrPlugin)
+	}
+
 	return []runtime.Object{admissionConfig}
 }
 
-func admissionConfigV1alpha1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector) []runtime.Object {
+func admissionConfigV1alpha1(features kubeoneapi.Features) []runtime.Object {
 	admissionConfig := &apiserverv1alpha1.AdmissionConfiguration{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apiserver.k8s.io/v1alpha1",
@@ -78,7 +123,7 @@ func admissionConfigV1alpha1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector)
 		},
 	}
 
-	if podNodeSelectorFeature != nil && podNodeSelectorFeature.Enable {
+	if features.PodNodeSelector != nil && features.PodNodeSelector.Enable {
 		pnsPlugin := apiserverv1alpha1.AdmissionPluginConfiguration{
 			Name: "PodNodeSelector",
 			Path: "/etc/kubernetes/admission/podnodeselector.yaml",
@@ -86,5 +131,13 @@ func admissionConfigV1alpha1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector)
 		admissionConfig.Plugins = append(admissionConfig.Plugins, pnsPlugin)
 	}
 
+	if features.PodTolerationRestriction.Enabled() {
+		ptrPlugin := apiserverv1alpha1.AdmissionPluginConfiguration{
+			Name: "PodTolerationRestriction",
+			Path: PodTolerationRestrictionConfigPath,
+		}
+		admissionConfig.Plugins = append(admissionConfig.Plugins, ptrPlugin)
+	}
+
 	return []runtime.Object{admissionConfig}
 }
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissionconfig
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewAdmissionConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		features kubeoneapi.Features
+		want     string
+	}{
+		{
+			name: "PodNodeSelector and PodTolerationRestriction",
+			features: kubeoneapi.Features{
+				PodNodeSelector:          &kubeoneapi.PodNodeSelector{Enable: true},
+				PodTolerationRestriction: &kubeoneapi.PodTolerationRestriction{Enable: true},
+			},
+			want: heredoc.Doc(`
+				apiVersion: apiserver.config.k8s.io/v1
+				kind: AdmissionConfiguration
+				plugins:
+				- configuration: null
+				  name: PodNodeSelector
+				  path: /etc/kubernetes/admission/podnodeselector.yaml
+				- configuration: null
+				  name: PodTolerationRestriction
+				  path: /etc/kubernetes/admission/podtolerationrestriction.yaml
+
+				---
+			`),
+		},
+		{
+			name: "PodTolerationRestriction only",
+			features: kubeoneapi.Features{
+				PodNodeSelector:          &kubeoneapi.PodNodeSelector{Enable: false},
+				PodTolerationRestriction: &kubeoneapi.PodTolerationRestriction{Enable: true},
+			},
+			want: heredoc.Doc(`
+				apiVersion: apiserver.config.k8s.io/v1
+				kind: AdmissionConfiguration
+				plugins:
+				- configuration: null
+				  name: PodTolerationRestriction
+				  path: /etc/kubernetes/admission/podtolerationrestriction.yaml
+
+				---
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAdmissionConfig("1.24.0", tt.features)
+			if err != nil {
+				t.Fatalf("NewAdmissionConfig() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewAdmissionConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPodTolerationRestrictionConfig(t *testing.T) {
+	feature := &kubeoneapi.PodTolerationRestriction{
+		Enable: true,
+		DefaultTolerations: []corev1.Toleration{
+			{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+		Whitelist: []corev1.Toleration{
+			{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists},
+		},
+	}
+
+	want := heredoc.Doc(`
+		apiVersion: podtolerationrestriction.admission.k8s.io/v1alpha1
+		default:
+		- effect: NoSchedule
+		  key: nvidia.com/gpu
+		  operator: Exists
+		kind: Configuration
+		whitelist:
+		- key: nvidia.com/gpu
+		  operator: Exists
+	`)
+
+	got, err := NewPodTolerationRestrictionConfig(feature)
+	if err != nil {
+		t.Fatalf("NewPodTolerationRestrictionConfig() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("NewPodTolerationRestrictionConfig() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditpolicy
+
+import (
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/templates"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// PresetMinimal records all requests at the Metadata level
+	PresetMinimal = "minimal"
+	// PresetCompliance records requests to Secrets and RBAC objects at the
+	// RequestResponse level and all other requests at the Metadata level
+	PresetCompliance = "compliance"
+)
+
+// Presets returns names of the supported audit policy presets
+func Presets() []string {
+	return []string{PresetMinimal, PresetCompliance}
+}
+
+// NewPolicy generates the audit policy manifest for the given preset
+func NewPolicy(preset string) (string, error) {
+	var rules []auditv1.PolicyRule
+
+	switch preset {
+	case PresetMinimal:
+		rules = append(noiseRules(), metadataRule())
+	case PresetCompliance:
+		rules = append(noiseRules(),
+			auditv1.PolicyRule{
+				Level: auditv1.LevelRequestResponse,
+				Resources: []auditv1.GroupResources{
+					{Group: "", Resources: []string{"secrets"}},
+					{
+						Group:     "rbac.authorization.k8s.io",
+						Resources: []string{"roles", "rolebindings", "clusterroles", "clusterrolebindings"},
+					},
+				},
+			},
+			metadataRule(),
+		)
+	default:
+		return "", fail.ConfigValidation(errors.Errorf("unknown audit policy preset %q", preset))
+	}
+
+	policy := &auditv1.Policy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: auditv1.SchemeGroupVersion.String(),
+			Kind:       "Policy",
+		},
+		OmitStages: []auditv1.Stage{auditv1.StageRequestReceived},
+		Rules:      rules,
+	}
+
+	return templates.KubernetesToYAML([]runtime.Object{policy})
+}
+
+// ValidatePolicy validates that the audit policy manifest records at least
+// the Metadata level for all requests not matched by more specific rules
+func ValidatePolicy(manifest []byte) error {
+	var policy auditv1.Policy
+	if err := yaml.UnmarshalStrict(manifest, &policy); err != nil {
+		return fail.ConfigValidation(errors.Wrap(err, "parsing audit policy"))
+	}
+
+	// Rules are evaluated in order and the first matching rule sets the
+	// audit level, so the first catch-all rule is the global audit level
+	for _, rule := range policy.Rules {
+		if !catchAllRule(rule) {
+			continue
+		}
+
+		if rule.Level == auditv1.LevelNone {
+			return fail.ConfigValidation(errors.New("audit policy must record at least the Metadata level globally, but the catch-all rule has the None level"))
+		}
+
+		return nil
+	}
+
+	return fail.ConfigValidation(errors.New("audit policy must record at least the Metadata level globally, but it has no catch-all rule"))
+}
+
+func catchAllRule(rule auditv1.PolicyRule) bool {
+	return len(rule.Users) == 0 &&
+		len(rule.UserGroups) == 0 &&
+		len(rule.Verbs) == 0 &&
+		len(rule.Resources) == 0 &&
+		len(rule.Namespaces) == 0 &&
+		len(rule.NonResourceURLs) == 0
+}
+
+// noiseRules skips the high-volume requests without any audit value
+func noiseRules() []auditv1.PolicyRule {
+	return []auditv1.PolicyRule{
+		{
+			Level:           auditv1.LevelNone,
+			NonResourceURLs: []string{"/healthz*", "/livez*", "/readyz*", "/version"},
+		},
+		{
+			Level: auditv1.LevelNone,
+			Resources: []auditv1.GroupResources{
+				{Group: "", Resources: []string{"events"}},
+				{Group: "events.k8s.io", Resources: []string{"events"}},
+			},
+		},
+	}
+}
+
+func metadataRule() auditv1.PolicyRule {
+	return auditv1.PolicyRule{
+		Level: auditv1.LevelMetadata,
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditpolicy
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	for _, preset := range Presets() {
+		policy, err := NewPolicy(preset)
+		if err != nil {
+			t.Fatalf("generating %q preset: %v", preset, err)
+		}
+
+		if err = ValidatePolicy([]byte(policy)); err != nil {
+			t.Errorf("%q preset is not valid: %v", preset, err)
+		}
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{
+			name: "request response for secrets and metadata globally",
+			policy: heredoc.Doc(`
+				apiVersion: audit.k8s.io/v1
+				kind: Policy
+				rules:
+				- level: None
+				  resources:
+				  - group: ""
+				    resources: ["events"]
+				- level: RequestResponse
+				  resources:
+				  - group: ""
+				    resources: ["secrets"]
+				- level: Metadata
+			`),
+		},
+		{
+			name: "no catch-all rule",
+			policy: heredoc.Doc(`
+				apiVersion: audit.k8s.io/v1
+				kind: Policy
+				rules:
+				- level: RequestResponse
+				  resources:
+				  - group: ""
+				    resources: ["secrets"]
+			`),
+			wantErr: true,
+		},
+		{
+			name: "catch-all rule with None level",
+			policy: heredoc.Doc(`
+				apiVersion: audit.k8s.io/v1
+				kind: Policy
+				rules:
+				- level: RequestResponse
+				  resources:
+				  - group: ""
+				    resources: ["secrets"]
+				- level: None
+				- level: Metadata
+			`),
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			policy: heredoc.Doc(`
+				apiVersion: audit.k8s.io/v1
+				kind: Policy
+				rules:
+				- level: Metadata
+				  resource: secrets
+			`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePolicy([]byte(tt.policy)); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -74,6 +74,10 @@ const (
 
 	// Addons
 	ClusterAutoscaler
+	PrometheusAdapter
+	EtcdBackupSnapshotter
+	EtcdBackupRestic
+	CSISnapshotController
 
 	// General CSI images (to be removed)
 	CSIAttacher
@@ -374,6 +378,17 @@ func optionalResources() map[Resource]map[string]string {
 			"1.23.x":    "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.23.0",
 			">= 1.24.0": "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.24.0",
 		},
+		// prometheus-adapter addon
+		PrometheusAdapter: {"*": "k8s.gcr.io/prometheus-adapter/prometheus-adapter:v0.9.1"},
+
+		// etcd-backup addon
+		EtcdBackupSnapshotter: {"*": "gcr.io/etcd-development/etcd:v3.4.3"},
+		EtcdBackupRestic:      {"*": "docker.io/restic/restic:0.9.6"},
+
+		// csi-snapshot-controller addon, the version has to match the
+		// VolumeSnapshot CRDs shipped with the addon
+		CSISnapshotController: {"*": "k8s.gcr.io/sig-storage/snapshot-controller:v5.0.1"},
+
 		// operating-system-manager addon
 		OperatingSystemManager: {"*": "quay.io/kubermatic/operating-system-manager:v0.4.2"},
 	}
@@ -26,88 +26,92 @@ func _() {
 	_ = x[MetricsServer-16]
 	_ = x[OperatingSystemManager-17]
 	_ = x[ClusterAutoscaler-18]
-	_ = x[CSIAttacher-19]
-	_ = x[CSINodeDriverRegistar-20]
-	_ = x[CSIProvisioner-21]
-	_ = x[CSISnapshotter-22]
-	_ = x[CSIResizer-23]
-	_ = x[CSILivenessProbe-24]
-	_ = x[AwsCCM-25]
-	_ = x[AzureCCM-26]
-	_ = x[AzureCNM-27]
-	_ = x[AwsEbsCSI-28]
-	_ = x[AwsEbsCSIAttacher-29]
-	_ = x[AwsEbsCSILivenessProbe-30]
-	_ = x[AwsEbsCSINodeDriverRegistrar-31]
-	_ = x[AwsEbsCSIProvisioner-32]
-	_ = x[AwsEbsCSIResizer-33]
-	_ = x[AwsEbsCSISnapshotter-34]
-	_ = x[AwsEbsCSISnapshotController-35]
-	_ = x[AzureFileCSI-36]
-	_ = x[AzureFileCSIAttacher-37]
-	_ = x[AzureFileCSILivenessProbe-38]
-	_ = x[AzureFileCSINodeDriverRegistar-39]
-	_ = x[AzureFileCSIProvisioner-40]
-	_ = x[AzureFileCSIResizer-41]
-	_ = x[AzureFileCSISnapshotter-42]
-	_ = x[AzureFileCSISnapshotterController-43]
-	_ = x[AzureDiskCSI-44]
-	_ = x[AzureDiskCSIAttacher-45]
-	_ = x[AzureDiskCSILivenessProbe-46]
-	_ = x[AzureDiskCSINodeDriverRegistar-47]
-	_ = x[AzureDiskCSIProvisioner-48]
-	_ = x[AzureDiskCSIResizer-49]
-	_ = x[AzureDiskCSISnapshotter-50]
-	_ = x[AzureDiskCSISnapshotterController-51]
-	_ = x[NutanixCSILivenessProbe-52]
-	_ = x[NutanixCSI-53]
-	_ = x[NutanixCSIProvisioner-54]
-	_ = x[NutanixCSIRegistrar-55]
-	_ = x[NutanixCSIResizer-56]
-	_ = x[NutanixCSISnapshotter-57]
-	_ = x[NutanixCSISnapshotController-58]
-	_ = x[NutanixCSISnapshotValidationWebhook-59]
-	_ = x[DigitalOceanCSI-60]
-	_ = x[DigitalOceanCSIAlpine-61]
-	_ = x[DigitalOceanCSIAttacher-62]
-	_ = x[DigitalOceanCSINodeDriverRegistar-63]
-	_ = x[DigitalOceanCSIProvisioner-64]
-	_ = x[DigitalOceanCSIResizer-65]
-	_ = x[DigitalOceanCSISnapshotController-66]
-	_ = x[DigitalOceanCSISnapshotValidationWebhook-67]
-	_ = x[DigitalOceanCSISnapshotter-68]
-	_ = x[OpenstackCSI-69]
-	_ = x[OpenstackCSINodeDriverRegistar-70]
-	_ = x[OpenstackCSILivenessProbe-71]
-	_ = x[OpenstackCSIAttacher-72]
-	_ = x[OpenstackCSIProvisioner-73]
-	_ = x[OpenstackCSIResizer-74]
-	_ = x[OpenstackCSISnapshotter-75]
-	_ = x[DigitaloceanCCM-76]
-	_ = x[HetznerCCM-77]
-	_ = x[HetznerCSI-78]
-	_ = x[OpenstackCCM-79]
-	_ = x[EquinixMetalCCM-80]
-	_ = x[VsphereCCM-81]
-	_ = x[VMwareCloudDirectorCSI-82]
-	_ = x[VsphereCSIDriver-83]
-	_ = x[VsphereCSISyncer-84]
-	_ = x[VsphereCSIAttacher-85]
-	_ = x[VsphereCSILivenessProbe-86]
-	_ = x[VsphereCSINodeDriverRegistar-87]
-	_ = x[VsphereCSIProvisioner-88]
-	_ = x[VsphereCSIResizer-89]
-	_ = x[VsphereCSISnapshotter-90]
-	_ = x[VsphereCSISnapshotController-91]
-	_ = x[VsphereCSISnapshotValidationWebhook-92]
-	_ = x[CalicoVXLANCNI-93]
-	_ = x[CalicoVXLANController-94]
-	_ = x[CalicoVXLANNode-95]
+	_ = x[PrometheusAdapter-19]
+	_ = x[EtcdBackupSnapshotter-20]
+	_ = x[EtcdBackupRestic-21]
+	_ = x[CSISnapshotController-22]
+	_ = x[CSIAttacher-23]
+	_ = x[CSINodeDriverRegistar-24]
+	_ = x[CSIProvisioner-25]
+	_ = x[CSISnapshotter-26]
+	_ = x[CSIResizer-27]
+	_ = x[CSILivenessProbe-28]
+	_ = x[AwsCCM-29]
+	_ = x[AzureCCM-30]
+	_ = x[AzureCNM-31]
+	_ = x[AwsEbsCSI-32]
+	_ = x[AwsEbsCSIAttacher-33]
+	_ = x[AwsEbsCSILivenessProbe-34]
+	_ = x[AwsEbsCSINodeDriverRegistrar-35]
+	_ = x[AwsEbsCSIProvisioner-36]
+	_ = x[AwsEbsCSIResizer-37]
+	_ = x[AwsEbsCSISnapshotter-38]
+	_ = x[AwsEbsCSISnapshotController-39]
+	_ = x[AzureFileCSI-40]
+	_ = x[AzureFileCSIAttacher-41]
+	_ = x[AzureFileCSILivenessProbe-42]
+	_ = x[AzureFileCSINodeDriverRegistar-43]
+	_ = x[AzureFileCSIProvisioner-44]
+	_ = x[AzureFileCSIResizer-45]
+	_ = x[AzureFileCSISnapshotter-46]
+	_ = x[AzureFileCSISnapshotterController-47]
+	_ = x[AzureDiskCSI-48]
+	_ = x[AzureDiskCSIAttacher-49]
+	_ = x[AzureDiskCSILivenessProbe-50]
+	_ = x[AzureDiskCSINodeDriverRegistar-51]
+	_ = x[AzureDiskCSIProvisioner-52]
+	_ = x[AzureDiskCSIResizer-53]
+	_ = x[AzureDiskCSISnapshotter-54]
+	_ = x[AzureDiskCSISnapshotterController-55]
+	_ = x[NutanixCSILivenessProbe-56]
+	_ = x[NutanixCSI-57]
+	_ = x[NutanixCSIProvisioner-58]
+	_ = x[NutanixCSIRegistrar-59]
+	_ = x[NutanixCSIResizer-60]
+	_ = x[NutanixCSISnapshotter-61]
+	_ = x[NutanixCSISnapshotController-62]
+	_ = x[NutanixCSISnapshotValidationWebhook-63]
+	_ = x[DigitalOceanCSI-64]
+	_ = x[DigitalOceanCSIAlpine-65]
+	_ = x[DigitalOceanCSIAttacher-66]
+	_ = x[DigitalOceanCSINodeDriverRegistar-67]
+	_ = x[DigitalOceanCSIProvisioner-68]
+	_ = x[DigitalOceanCSIResizer-69]
+	_ = x[DigitalOceanCSISnapshotController-70]
+	_ = x[DigitalOceanCSISnapshotValidationWebhook-71]
+	_ = x[DigitalOceanCSISnapshotter-72]
+	_ = x[OpenstackCSI-73]
+	_ = x[OpenstackCSINodeDriverRegistar-74]
+	_ = x[OpenstackCSILivenessProbe-75]
+	_ = x[OpenstackCSIAttacher-76]
+	_ = x[OpenstackCSIProvisioner-77]
+	_ = x[OpenstackCSIResizer-78]
+	_ = x[OpenstackCSISnapshotter-79]
+	_ = x[DigitaloceanCCM-80]
+	_ = x[HetznerCCM-81]
+	_ = x[HetznerCSI-82]
+	_ = x[OpenstackCCM-83]
+	_ = x[EquinixMetalCCM-84]
+	_ = x[VsphereCCM-85]
+	_ = x[VMwareCloudDirectorCSI-86]
+	_ = x[VsphereCSIDriver-87]
+	_ = x[VsphereCSISyncer-88]
+	_ = x[VsphereCSIAttacher-89]
+	_ = x[VsphereCSILivenessProbe-90]
+	_ = x[VsphereCSINodeDriverRegistar-91]
+	_ = x[VsphereCSIProvisioner-92]
+	_ = x[VsphereCSIResizer-93]
+	_ = x[VsphereCSISnapshotter-94]
+	_ = x[VsphereCSISnapshotController-95]
+	_ = x[VsphereCSISnapshotValidationWebhook-96]
+	_ = x[CalicoVXLANCNI-97]
+	_ = x[CalicoVXLANController-98]
+	_ = x[CalicoVXLANNode-99]
 }
 
-const _Resource_name = "CalicoCNICalicoControllerCalicoNodeFlannelCiliumCiliumOperatorHubbleRelayHubbleUIHubbleUIBackendHubbleProxyCiliumCertGenWeaveNetCNIKubeWeaveNetCNINPCDNSNodeCacheMachineControllerMetricsServerOperatingSystemManagerClusterAutoscalerCSIAttacherCSINodeDriverRegistarCSIProvisionerCSISnapshotterCSIResizerCSILivenessProbeAwsCCMAzureCCMAzureCNMAwsEbsCSIAwsEbsCSIAttacherAwsEbsCSILivenessProbeAwsEbsCSINodeDriverRegistrarAwsEbsCSIProvisionerAwsEbsCSIResizerAwsEbsCSISnapshotterAwsEbsCSISnapshotControllerAzureFileCSIAzureFileCSIAttacherAzureFileCSILivenessProbeAzureFileCSINodeDriverRegistarAzureFileCSIProvisionerAzureFileCSIResizerAzureFileCSISnapshotterAzureFileCSISnapshotterControllerAzureDiskCSIAzureDiskCSIAttacherAzureDiskCSILivenessProbeAzureDiskCSINodeDriverRegistarAzureDiskCSIProvisionerAzureDiskCSIResizerAzureDiskCSISnapshotterAzureDiskCSISnapshotterControllerNutanixCSILivenessProbeNutanixCSINutanixCSIProvisionerNutanixCSIRegistrarNutanixCSIResizerNutanixCSISnapshotterNutanixCSISnapshotControllerNutanixCSISnapshotValidationWebhookDigitalOceanCSIDigitalOceanCSIAlpineDigitalOceanCSIAttacherDigitalOceanCSINodeDriverRegistarDigitalOceanCSIProvisionerDigitalOceanCSIResizerDigitalOceanCSISnapshotControllerDigitalOceanCSISnapshotValidationWebhookDigitalOceanCSISnapshotterOpenstackCSIOpenstackCSINodeDriverRegistarOpenstackCSILivenessProbeOpenstackCSIAttacherOpenstackCSIProvisionerOpenstackCSIResizerOpenstackCSISnapshotterDigitaloceanCCMHetznerCCMHetznerCSIOpenstackCCMEquinixMetalCCMVsphereCCMVMwareCloudDirectorCSIVsphereCSIDriverVsphereCSISyncerVsphereCSIAttacherVsphereCSILivenessProbeVsphereCSINodeDriverRegistarVsphereCSIProvisionerVsphereCSIResizerVsphereCSISnapshotterVsphereCSISnapshotControllerVsphereCSISnapshotValidationWebhookCalicoVXLANCNICalicoVXLANControllerCalicoVXLANNode"
+const _Resource_name = "CalicoCNICalicoControllerCalicoNodeFlannelCiliumCiliumOperatorHubbleRelayHubbleUIHubbleUIBackendHubbleProxyCiliumCertGenWeaveNetCNIKubeWeaveNetCNINPCDNSNodeCacheMachineControllerMetricsServerOperatingSystemManagerClusterAutoscalerPrometheusAdapterEtcdBackupSnapshotterEtcdBackupResticCSISnapshotControllerCSIAttacherCSINodeDriverRegistarCSIProvisionerCSISnapshotterCSIResizerCSILivenessProbeAwsCCMAzureCCMAzureCNMAwsEbsCSIAwsEbsCSIAttacherAwsEbsCSILivenessProbeAwsEbsCSINodeDriverRegistrarAwsEbsCSIProvisionerAwsEbsCSIResizerAwsEbsCSISnapshotterAwsEbsCSISnapshotControllerAzureFileCSIAzureFileCSIAttacherAzureFileCSILivenessProbeAzureFileCSINodeDriverRegistarAzureFileCSIProvisionerAzureFileCSIResizerAzureFileCSISnapshotterAzureFileCSISnapshotterControllerAzureDiskCSIAzureDiskCSIAttacherAzureDiskCSILivenessProbeAzureDiskCSINodeDriverRegistarAzureDiskCSIProvisionerAzureDiskCSIResizerAzureDiskCSISnapshotterAzureDiskCSISnapshotterControllerNutanixCSILivenessProbeNutanixCSINutanixCSIProvisionerNutanixCSIRegistrarNutanixCSIResizerNutanixCSISnapshotterNutanixCSISnapshotControllerNutanixCSISnapshotValidationWebhookDigitalOceanCSIDigitalOceanCSIAlpineDigitalOceanCSIAttacherDigitalOceanCSINodeDriverRegistarDigitalOceanCSIProvisionerDigitalOceanCSIResizerDigitalOceanCSISnapshotControllerDigitalOceanCSISnapshotValidationWebhookDigitalOceanCSISnapshotterOpenstackCSIOpenstackCSINodeDriverRegistarOpenstackCSILivenessProbeOpenstackCSIAttacherOpenstackCSIProvisionerOpenstackCSIResizerOpenstackCSISnapshotterDigitaloceanCCMHetznerCCMHetznerCSIOpenstackCCMEquinixMetalCCMVsphereCCMVMwareCloudDirectorCSIVsphereCSIDriverVsphereCSISyncerVsphereCSIAttacherVsphereCSILivenessProbeVsphereCSINodeDriverRegistarVsphereCSIProvisionerVsphereCSIResizerVsphereCSISnapshotterVsphereCSISnapshotControllerVsphereCSISnapshotValidationWebhookCalicoVXLANCNICalicoVXLANControllerCalicoVXLANNode"
 
-var _Resource_index = [...]uint16{0, 9, 25, 35, 42, 48, 62, 73, 81, 96, 107, 120, 135, 149, 161, 178, 191, 213, 230, 241, 262, 276, 290, 300, 316, 322, 330, 338, 347, 364, 386, 414, 434, 450, 470, 497, 509, 529, 554, 584, 607, 626, 649, 682, 694, 714, 739, 769, 792, 811, 834, 867, 890, 900, 921, 940, 957, 978, 1006, 1041, 1056, 1077, 1100, 1133, 1159, 1181, 1214, 1254, 1280, 1292, 1322, 1347, 1367, 1390, 1409, 1432, 1447, 1457, 1467, 1479, 1494, 1504, 1526, 1542, 1558, 1576, 1599, 1627, 1648, 1665, 1686, 1714, 1749, 1763, 1784, 1799}
+var _Resource_index = [...]uint16{0, 9, 25, 35, 42, 48, 62, 73, 81, 96, 107, 120, 135, 149, 161, 178, 191, 213, 230, 247, 268, 284, 305, 316, 337, 351, 365, 375, 391, 397, 405, 413, 422, 439, 461, 489, 509, 525, 545, 572, 584, 604, 629, 659, 682, 701, 724, 757, 769, 789, 814, 844, 867, 886, 909, 942, 965, 975, 996, 1015, 1032, 1053, 1081, 1116, 1131, 1152, 1175, 1208, 1234, 1256, 1289, 1329, 1355, 1367, 1397, 1422, 1442, 1465, 1484, 1507, 1522, 1532, 1542, 1554, 1569, 1579, 1601, 1617, 1633, 1651, 1674, 1702, 1723, 1740, 1761, 1789, 1824, 1838, 1859, 1874}
 
 func (i Resource) String() string {
 	i -= 1
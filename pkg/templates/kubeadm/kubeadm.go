@@ -17,6 +17,8 @@ limitations under the License.
 package kubeadm
 
 import (
+	"fmt"
+
 	"github.com/Masterminds/semver/v3"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
@@ -38,16 +40,40 @@ type Kubedm interface {
 }
 
 // New constructor
-func New(ver string) (Kubedm, error) {
-	sver, err := semver.NewVersion(ver)
+func New(versions kubeoneapi.VersionConfig) (Kubedm, error) {
+	ver := versions.Kubernetes
+
+	apiVersion, err := ConfigAPIVersion(versions)
 	if err != nil {
-		return nil, fail.Config(err, "parsing kubeadm semver")
+		return nil, err
 	}
 
-	switch {
-	case sver.Minor() < 22:
+	switch apiVersion {
+	case kubeoneapi.KubeadmConfigAPIVersionV1Beta2:
 		return &kubeadmv1beta2{version: ver}, nil
-	default:
+	case kubeoneapi.KubeadmConfigAPIVersionV1Beta3:
 		return &kubeadmv1beta3{version: ver}, nil
+	default:
+		return nil, fail.ConfigValidation(fmt.Errorf("unsupported kubeadm configuration API version %q", apiVersion))
+	}
+}
+
+// ConfigAPIVersion returns the kubeadm configuration API version to be used,
+// which is either the explicitly requested one or the one matching the
+// Kubernetes version
+func ConfigAPIVersion(versions kubeoneapi.VersionConfig) (string, error) {
+	if versions.KubeadmConfigAPIVersion != "" {
+		return versions.KubeadmConfigAPIVersion, nil
 	}
+
+	sver, err := semver.NewVersion(versions.Kubernetes)
+	if err != nil {
+		return "", fail.Config(err, "parsing kubeadm semver")
+	}
+
+	if sver.Minor() < 22 {
+		return kubeoneapi.KubeadmConfigAPIVersionV1Beta2, nil
+	}
+
+	return kubeoneapi.KubeadmConfigAPIVersionV1Beta3, nil
 }
@@ -16,9 +16,20 @@ limitations under the License.
 
 package kubeadmargs
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	featureGatesFlag = "feature-gates"
+)
+
 // Args is a wrapper abstract type on top of kubeadm
 type Args struct {
 	APIServer    APIServer
+	Etcd         Etcd
 	FeatureGates map[string]bool
 }
 
@@ -27,6 +38,11 @@ type APIServer struct {
 	ExtraArgs map[string]string
 }
 
+// Etcd arguments
+type Etcd struct {
+	ExtraArgs map[string]string
+}
+
 // AppendMapStringStringExtraArg appends to CLI mapStringString additional flag
 func (apiserver *APIServer) AppendMapStringStringExtraArg(k, v string) {
 	value := v
@@ -36,6 +52,48 @@ func (apiserver *APIServer) AppendMapStringStringExtraArg(k, v string) {
 	apiserver.ExtraArgs[k] = value
 }
 
+// MergeFeatureGates merges the given feature gates into the feature-gates flag
+func (apiserver *APIServer) MergeFeatureGates(featureGates map[string]bool) {
+	if len(featureGates) == 0 {
+		return
+	}
+	apiserver.ExtraArgs[featureGatesFlag] = MergeFeatureGatesFlag(apiserver.ExtraArgs[featureGatesFlag], featureGates)
+}
+
+// MergeFeatureGatesFlag merges the given feature gates into the value of the
+// feature-gates flag. Feature gates already present in the flag are updated in
+// place, while the new feature gates are appended in the alphabetical order.
+func MergeFeatureGatesFlag(flag string, featureGates map[string]bool) string {
+	merged := []string{}
+	seen := map[string]bool{}
+
+	for _, gate := range strings.Split(flag, ",") {
+		if gate == "" {
+			continue
+		}
+		name := strings.SplitN(gate, "=", 2)[0]
+		if enabled, ok := featureGates[name]; ok {
+			gate = fmt.Sprintf("%s=%t", name, enabled)
+		}
+		seen[name] = true
+		merged = append(merged, gate)
+	}
+
+	names := []string{}
+	for name := range featureGates {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		merged = append(merged, fmt.Sprintf("%s=%t", name, featureGates[name]))
+	}
+
+	return strings.Join(merged, ",")
+}
+
 // New init empty Args
 func New() *Args {
 	return NewFrom(nil)
@@ -51,6 +109,9 @@ func NewFrom(apiServerExtraArgs map[string]string) *Args {
 		APIServer: APIServer{
 			ExtraArgs: apiServerExtraArgs,
 		},
+		Etcd: Etcd{
+			ExtraArgs: map[string]string{},
+		},
 		FeatureGates: map[string]bool{},
 	}
 }
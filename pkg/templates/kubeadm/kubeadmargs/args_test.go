@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadmargs
+
+import (
+	"testing"
+)
+
+func TestMergeFeatureGatesFlag(t *testing.T) {
+	tests := []struct {
+		name         string
+		flag         string
+		featureGates map[string]bool
+		expected     string
+	}{
+		{
+			name:     "no feature gates",
+			flag:     "CSIMigration=true",
+			expected: "CSIMigration=true",
+		},
+		{
+			name: "empty flag",
+			featureGates: map[string]bool{
+				"StorageVersionAPI": true,
+				"APIServerIdentity": true,
+			},
+			expected: "APIServerIdentity=true,StorageVersionAPI=true",
+		},
+		{
+			name: "append to existing flag",
+			flag: "CSIMigration=true,CSIMigrationAWS=true",
+			featureGates: map[string]bool{
+				"APIServerIdentity": true,
+			},
+			expected: "CSIMigration=true,CSIMigrationAWS=true,APIServerIdentity=true",
+		},
+		{
+			name: "override existing feature gate",
+			flag: "APIServerIdentity=true,CSIMigration=true",
+			featureGates: map[string]bool{
+				"APIServerIdentity": false,
+			},
+			expected: "APIServerIdentity=false,CSIMigration=true",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeFeatureGatesFlag(tt.flag, tt.featureGates)
+			if got != tt.expected {
+				t.Errorf("MergeFeatureGatesFlag() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
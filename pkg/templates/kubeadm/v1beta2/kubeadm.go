@@ -19,7 +19,6 @@ package v1beta2
 import (
 	"fmt"
 	"path/filepath"
-	"time"
 
 	"github.com/Masterminds/semver/v3"
 
@@ -28,10 +27,13 @@ import (
 	"k8c.io/kubeone/pkg/certificate"
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/features"
+	"k8c.io/kubeone/pkg/kubeconfig"
 	"k8c.io/kubeone/pkg/kubeflags"
+	"k8c.io/kubeone/pkg/semverutil"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
 	"k8c.io/kubeone/pkg/templates/resources"
+	"k8c.io/kubeone/pkg/templates/schedulerconfig"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,7 +44,12 @@ import (
 )
 
 const (
-	bootstrapTokenTTL = 60 * time.Minute
+	// greaterOrEqualThan122 defines a version constraint for the Kubernetes 1.22+ clusters
+	greaterOrEqualThan122 = ">= 1.22.0"
+)
+
+var (
+	etcdIntegrityCheckConstraint = semverutil.MustParseConstraint(greaterOrEqualThan122)
 )
 
 // NewConfig returns all required configs to init a cluster via a set of v1beta2 configs
@@ -53,6 +60,11 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		return nil, fail.Config(err, "parsing kubernetes semver")
 	}
 
+	// Kubernetes 1.22+ clusters use the v1beta2 kubeadm configuration API
+	// only if explicitly requested, but they still need the same etcd
+	// configuration as with the v1beta3 API
+	etcdImageTag, etcdExtraArgs := etcdVersionCorruptCheckExtraArgs(kubeSemVer, cluster.AssetConfiguration.Etcd.ImageTag)
+
 	nodeRegistration := newNodeRegistration(s, host)
 	nodeRegistration.IgnorePreflightErrors = []string{
 		"DirAvailable--var-lib-etcd",
@@ -79,7 +91,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 					"system:bootstrappers:kubeadm:default-node-token",
 				},
 				TTL: &metav1.Duration{
-					Duration: bootstrapTokenTTL,
+					Duration: cluster.BootstrapTokenTTL(),
 				},
 				Usages: []string{
 					"signing",
@@ -102,16 +114,11 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 				AdvertiseAddress: newNodeIP(host),
 			},
 		},
-		Discovery: kubeadmv1beta2.Discovery{
-			BootstrapToken: &kubeadmv1beta2.BootstrapTokenDiscovery{
-				Token:                    s.JoinToken,
-				APIServerEndpoint:        controlPlaneEndpoint,
-				UnsafeSkipCAVerification: true,
-			},
-		},
+		Discovery: newDiscovery(s, controlPlaneEndpoint),
 	}
 
-	certSANS := certificate.GetCertificateSANs(cluster.APIEndpoint.Host, cluster.APIEndpoint.AlternativeNames)
+	certSANS := certificate.GetCertificateSANs(cluster.APIEndpoint.Host, cluster.APIEndpoint.CertificateAlternativeNames())
+	certSANS = append(certSANS, cluster.ControlPlaneDNSNames()...)
 
 	clusterConfig := &kubeadmv1beta2.ClusterConfiguration{
 		TypeMeta: metav1.TypeMeta{
@@ -148,8 +155,11 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 			Local: &kubeadmv1beta2.LocalEtcd{
 				ImageMeta: kubeadmv1beta2.ImageMeta{
 					ImageRepository: cluster.AssetConfiguration.Etcd.ImageRepository,
-					ImageTag:        cluster.AssetConfiguration.Etcd.ImageTag,
+					ImageTag:        etcdImageTag,
 				},
+				ExtraArgs:      etcdExtraArgs,
+				ServerCertSANs: cluster.ControlPlaneDNSNames(),
+				PeerCertSANs:   cluster.ControlPlaneDNSNames(),
 			},
 		},
 		DNS: kubeadmv1beta2.DNS{
@@ -189,6 +199,14 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		nodeRegistration.KubeletExtraArgs["pod-infra-container-image"] = cluster.AssetConfiguration.Pause.ImageRepository + "/pause:" + cluster.AssetConfiguration.Pause.ImageTag
 	}
 
+	for k, v := range cluster.ClusterNetwork.NodeCIDRMaskSizeFlags() {
+		clusterConfig.ControllerManager.ExtraArgs[k] = v
+	}
+
+	for k, v := range cluster.ControllerManagerFlags() {
+		clusterConfig.ControllerManager.ExtraArgs[k] = v
+	}
+
 	if s.ShouldEnableInTreeCloudProvider() {
 		renderedCloudConfig := "/etc/kubernetes/cloud-config"
 		cloudConfigVol := kubeadmv1beta2.HostPathMount{
@@ -279,7 +297,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, logVol)
 	}
 
-	if cluster.Features.PodNodeSelector != nil && cluster.Features.PodNodeSelector.Enable {
+	if cluster.AdmissionConfigEnabled() {
 		admissionVol := kubeadmv1beta2.HostPathMount{
 			Name:      "admission-conf",
 			HostPath:  "/etc/kubernetes/admission",
@@ -289,6 +307,18 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		}
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, admissionVol)
 	}
+	if cluster.SchedulerConfigEnabled() {
+		clusterConfig.Scheduler.ExtraArgs = map[string]string{
+			"config": schedulerconfig.ConfigPath,
+		}
+		clusterConfig.Scheduler.ExtraVolumes = append(clusterConfig.Scheduler.ExtraVolumes, kubeadmv1beta2.HostPathMount{
+			Name:      "scheduler-conf",
+			HostPath:  schedulerconfig.ConfigDir,
+			MountPath: schedulerconfig.ConfigDir,
+			ReadOnly:  true,
+			PathType:  corev1.HostPathDirectoryOrCreate,
+		})
+	}
 	// this is not exactly as s.EncryptionEnabled(). We need this to be true during the enable/disable or disable/enable transition.
 	if (cluster.Features.EncryptionProviders != nil && cluster.Features.EncryptionProviders.Enable) ||
 		s.LiveCluster.EncryptionConfiguration.Enable {
@@ -322,8 +352,18 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 	args := kubeadmargs.NewFrom(clusterConfig.APIServer.ExtraArgs)
 	features.UpdateKubeadmClusterConfiguration(cluster.Features, args)
 
+	if cluster.APIServerConfigEnabled() {
+		for k, v := range cluster.APIServerFlags() {
+			args.APIServer.ExtraArgs[k] = v
+		}
+		args.APIServer.MergeFeatureGates(cluster.ControlPlaneComponents.APIServer.FeatureGates)
+	}
+
 	clusterConfig.APIServer.ExtraArgs = args.APIServer.ExtraArgs
 	clusterConfig.FeatureGates = args.FeatureGates
+	for k, v := range args.Etcd.ExtraArgs {
+		clusterConfig.Etcd.Local.ExtraArgs[k] = v
+	}
 
 	initConfig.NodeRegistration = nodeRegistration
 	joinConfig.NodeRegistration = nodeRegistration
@@ -349,13 +389,7 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 			APIVersion: "kubeadm.k8s.io/v1beta2",
 			Kind:       "JoinConfiguration",
 		},
-		Discovery: kubeadmv1beta2.Discovery{
-			BootstrapToken: &kubeadmv1beta2.BootstrapTokenDiscovery{
-				Token:                    s.JoinToken,
-				APIServerEndpoint:        controlPlaneEndpoint,
-				UnsafeSkipCAVerification: true,
-			},
-		},
+		Discovery: newDiscovery(s, controlPlaneEndpoint),
 	}
 
 	bfalse := false
@@ -431,16 +465,8 @@ func newNodeRegistration(s *state.State, host kubeoneapi.HostConfig) kubeadmv1be
 		"volume-plugin-dir": "/var/lib/kubelet/volumeplugins",
 	}
 
-	if m := host.Kubelet.SystemReserved; m != nil {
-		kubeletCLIFlags["system-reserved"] = kubeoneapi.MapStringStringToString(m, "=")
-	}
-
-	if m := host.Kubelet.KubeReserved; m != nil {
-		kubeletCLIFlags["kube-reserved"] = kubeoneapi.MapStringStringToString(m, "=")
-	}
-
-	if m := host.Kubelet.EvictionHard; m != nil {
-		kubeletCLIFlags["eviction-hard"] = kubeoneapi.MapStringStringToString(m, "<")
+	for k, v := range host.Kubelet.NodeAllocatableFlags() {
+		kubeletCLIFlags[k] = v
 	}
 
 	return kubeadmv1beta2.NodeRegistrationOptions{
@@ -482,3 +508,42 @@ func kubeProxyConfiguration(s *state.State) *kubeproxyv1alpha1.KubeProxyConfigur
 
 	return kubeProxyConfig
 }
+
+func etcdVersionCorruptCheckExtraArgs(kubeSemVer *semver.Version, etcdImageTag string) (string, map[string]string) {
+	etcdExtraArgs := map[string]string{}
+	if etcdIntegrityCheckConstraint.Check(kubeSemVer) {
+		// This is required because etcd v3.5-[0-2] (used for Kubernetes 1.22+)
+		// has an issue with the data integrity.
+		// See https://groups.google.com/a/kubernetes.io/g/dev/c/B7gJs88XtQc/m/rSgNOzV2BwAJ
+		// for more details.
+		if etcdImageTag == "" {
+			etcdImageTag = "3.5.3-0"
+		}
+		etcdExtraArgs["experimental-initial-corrupt-check"] = "true"
+		etcdExtraArgs["experimental-corrupt-check-time"] = "240m"
+	}
+
+	return etcdImageTag, etcdExtraArgs
+}
+
+// newDiscovery returns the kubeadm discovery used to join nodes. If anonymous
+// auth is disabled, the cluster-info ConfigMap can't be read anonymously and
+// the file discovery with the kubeconfig uploaded by KubeOne is used instead.
+func newDiscovery(s *state.State, controlPlaneEndpoint string) kubeadmv1beta2.Discovery {
+	if s.Cluster.Features.StaticAuth.AnonymousAuthDisabled() {
+		return kubeadmv1beta2.Discovery{
+			File: &kubeadmv1beta2.FileDiscovery{
+				KubeConfigPath: filepath.Join(s.WorkDir, kubeconfig.DiscoveryFile),
+			},
+			TLSBootstrapToken: s.JoinToken,
+		}
+	}
+
+	return kubeadmv1beta2.Discovery{
+		BootstrapToken: &kubeadmv1beta2.BootstrapTokenDiscovery{
+			Token:                    s.JoinToken,
+			APIServerEndpoint:        controlPlaneEndpoint,
+			UnsafeSkipCAVerification: true,
+		},
+	}
+}
@@ -19,7 +19,6 @@ package v1beta3
 import (
 	"fmt"
 	"path/filepath"
-	"time"
 
 	"github.com/Masterminds/semver/v3"
 
@@ -29,11 +28,13 @@ import (
 	"k8c.io/kubeone/pkg/certificate"
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/features"
+	"k8c.io/kubeone/pkg/kubeconfig"
 	"k8c.io/kubeone/pkg/kubeflags"
 	"k8c.io/kubeone/pkg/semverutil"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
 	"k8c.io/kubeone/pkg/templates/resources"
+	"k8c.io/kubeone/pkg/templates/schedulerconfig"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,10 +44,6 @@ import (
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 )
 
-const (
-	bootstrapTokenTTL = 60 * time.Minute
-)
-
 const (
 	// greaterOrEqualThan122 defines a version constraint for the Kubernetes 1.22+ clusters
 	greaterOrEqualThan122 = ">= 1.22.0"
@@ -92,7 +89,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 					"system:bootstrappers:kubeadm:default-node-token",
 				},
 				TTL: &metav1.Duration{
-					Duration: bootstrapTokenTTL,
+					Duration: cluster.BootstrapTokenTTL(),
 				},
 				Usages: []string{
 					"signing",
@@ -115,16 +112,11 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 				AdvertiseAddress: newNodeIP(host),
 			},
 		},
-		Discovery: kubeadmv1beta3.Discovery{
-			BootstrapToken: &kubeadmv1beta3.BootstrapTokenDiscovery{
-				Token:                    s.JoinToken,
-				APIServerEndpoint:        controlPlaneEndpoint,
-				UnsafeSkipCAVerification: true,
-			},
-		},
+		Discovery: newDiscovery(s, controlPlaneEndpoint),
 	}
 
-	certSANS := certificate.GetCertificateSANs(cluster.APIEndpoint.Host, cluster.APIEndpoint.AlternativeNames)
+	certSANS := certificate.GetCertificateSANs(cluster.APIEndpoint.Host, cluster.APIEndpoint.CertificateAlternativeNames())
+	certSANS = append(certSANS, cluster.ControlPlaneDNSNames()...)
 	clusterConfig := &kubeadmv1beta3.ClusterConfiguration{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "kubeadm.k8s.io/v1beta3",
@@ -162,7 +154,9 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 					ImageRepository: cluster.AssetConfiguration.Etcd.ImageRepository,
 					ImageTag:        etcdImageTag,
 				},
-				ExtraArgs: etcdExtraArgs,
+				ExtraArgs:      etcdExtraArgs,
+				ServerCertSANs: cluster.ControlPlaneDNSNames(),
+				PeerCertSANs:   cluster.ControlPlaneDNSNames(),
 			},
 		},
 		DNS: kubeadmv1beta3.DNS{
@@ -202,6 +196,14 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		nodeRegistration.KubeletExtraArgs["pod-infra-container-image"] = cluster.AssetConfiguration.Pause.ImageRepository + "/pause:" + cluster.AssetConfiguration.Pause.ImageTag
 	}
 
+	for k, v := range cluster.ClusterNetwork.NodeCIDRMaskSizeFlags() {
+		clusterConfig.ControllerManager.ExtraArgs[k] = v
+	}
+
+	for k, v := range cluster.ControllerManagerFlags() {
+		clusterConfig.ControllerManager.ExtraArgs[k] = v
+	}
+
 	if s.ShouldEnableInTreeCloudProvider() {
 		renderedCloudConfig := "/etc/kubernetes/cloud-config"
 		cloudConfigVol := kubeadmv1beta3.HostPathMount{
@@ -292,7 +294,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, logVol)
 	}
 
-	if cluster.Features.PodNodeSelector != nil && cluster.Features.PodNodeSelector.Enable {
+	if cluster.AdmissionConfigEnabled() {
 		admissionVol := kubeadmv1beta3.HostPathMount{
 			Name:      "admission-conf",
 			HostPath:  "/etc/kubernetes/admission",
@@ -302,6 +304,18 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		}
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, admissionVol)
 	}
+	if cluster.SchedulerConfigEnabled() {
+		clusterConfig.Scheduler.ExtraArgs = map[string]string{
+			"config": schedulerconfig.ConfigPath,
+		}
+		clusterConfig.Scheduler.ExtraVolumes = append(clusterConfig.Scheduler.ExtraVolumes, kubeadmv1beta3.HostPathMount{
+			Name:      "scheduler-conf",
+			HostPath:  schedulerconfig.ConfigDir,
+			MountPath: schedulerconfig.ConfigDir,
+			ReadOnly:  true,
+			PathType:  corev1.HostPathDirectoryOrCreate,
+		})
+	}
 	// this is not exactly as s.EncryptionEnabled(). We need this to be true during the enable/disable or disable/enable transition.
 	if (cluster.Features.EncryptionProviders != nil && cluster.Features.EncryptionProviders.Enable) ||
 		s.LiveCluster.EncryptionConfiguration.Enable {
@@ -335,8 +349,18 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 	args := kubeadmargs.NewFrom(clusterConfig.APIServer.ExtraArgs)
 	features.UpdateKubeadmClusterConfiguration(cluster.Features, args)
 
+	if cluster.APIServerConfigEnabled() {
+		for k, v := range cluster.APIServerFlags() {
+			args.APIServer.ExtraArgs[k] = v
+		}
+		args.APIServer.MergeFeatureGates(cluster.ControlPlaneComponents.APIServer.FeatureGates)
+	}
+
 	clusterConfig.APIServer.ExtraArgs = args.APIServer.ExtraArgs
 	clusterConfig.FeatureGates = args.FeatureGates
+	for k, v := range args.Etcd.ExtraArgs {
+		clusterConfig.Etcd.Local.ExtraArgs[k] = v
+	}
 
 	initConfig.NodeRegistration = nodeRegistration
 	joinConfig.NodeRegistration = nodeRegistration
@@ -362,13 +386,7 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 			APIVersion: "kubeadm.k8s.io/v1beta3",
 			Kind:       "JoinConfiguration",
 		},
-		Discovery: kubeadmv1beta3.Discovery{
-			BootstrapToken: &kubeadmv1beta3.BootstrapTokenDiscovery{
-				Token:                    s.JoinToken,
-				APIServerEndpoint:        controlPlaneEndpoint,
-				UnsafeSkipCAVerification: true,
-			},
-		},
+		Discovery: newDiscovery(s, controlPlaneEndpoint),
 	}
 
 	bfalse := false
@@ -444,16 +462,8 @@ func newNodeRegistration(s *state.State, host kubeoneapi.HostConfig) kubeadmv1be
 		"volume-plugin-dir": "/var/lib/kubelet/volumeplugins",
 	}
 
-	if m := host.Kubelet.SystemReserved; m != nil {
-		kubeletCLIFlags["system-reserved"] = kubeoneapi.MapStringStringToString(m, "=")
-	}
-
-	if m := host.Kubelet.KubeReserved; m != nil {
-		kubeletCLIFlags["kube-reserved"] = kubeoneapi.MapStringStringToString(m, "=")
-	}
-
-	if m := host.Kubelet.EvictionHard; m != nil {
-		kubeletCLIFlags["eviction-hard"] = kubeoneapi.MapStringStringToString(m, "<")
+	for k, v := range host.Kubelet.NodeAllocatableFlags() {
+		kubeletCLIFlags[k] = v
 	}
 
 	return kubeadmv1beta3.NodeRegistrationOptions{
@@ -512,3 +522,25 @@ func etcdVersionCorruptCheckExtraArgs(kubeSemVer *semver.Version, etcdImageTag s
 
 	return etcdImageTag, etcdExtraArgs
 }
+
+// newDiscovery returns the kubeadm discovery used to join nodes. If anonymous
+// auth is disabled, the cluster-info ConfigMap can't be read anonymously and
+// the file discovery with the kubeconfig uploaded by KubeOne is used instead.
+func newDiscovery(s *state.State, controlPlaneEndpoint string) kubeadmv1beta3.Discovery {
+	if s.Cluster.Features.StaticAuth.AnonymousAuthDisabled() {
+		return kubeadmv1beta3.Discovery{
+			File: &kubeadmv1beta3.FileDiscovery{
+				KubeConfigPath: filepath.Join(s.WorkDir, kubeconfig.DiscoveryFile),
+			},
+			TLSBootstrapToken: s.JoinToken,
+		}
+	}
+
+	return kubeadmv1beta3.Discovery{
+		BootstrapToken: &kubeadmv1beta3.BootstrapTokenDiscovery{
+			Token:                    s.JoinToken,
+			APIServerEndpoint:        controlPlaneEndpoint,
+			UnsafeSkipCAVerification: true,
+		},
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"k8c.io/kubeone/pkg/fail"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates"
+	"k8c.io/kubeone/pkg/templates/operatingsystemmanager"
 
 	clustercommon "github.com/kubermatic/machine-controller/pkg/apis/cluster/common"
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
@@ -40,6 +41,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const (
+	autoscalerMinSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	autoscalerMaxSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
 // CreateMachineDeployments creates MachineDeployments that create appropriate
 // worker machines
 func CreateMachineDeployments(s *state.State) error {
@@ -114,6 +120,10 @@ func createMachineDeployment(cluster *kubeoneapi.KubeOneCluster, workerset kubeo
 		MachineObjectAnnotations bool `json:"machineObjectAnnotations,omitempty"`
 		Labels                   bool `json:"labels,omitempty"`
 		Taints                   bool `json:"taints,omitempty"`
+		InstanceProfile          bool `json:"instanceProfile,omitempty"`
+		CloudNetwork             bool `json:"cloudNetwork,omitempty"`
+		BootstrapCommands        bool `json:"bootstrapCommands,omitempty"`
+		Disks                    bool `json:"disks,omitempty"`
 	}{
 		ProviderSpec:  workerset.Config,
 		CloudProvider: cluster.CloudProvider.MachineControllerCloudProvider(),
@@ -137,10 +147,21 @@ func createMachineDeployment(cluster *kubeoneapi.KubeOneCluster, workerset kubeo
 	}
 
 	machineAnnotations := getKubeletConfigurationAnnotations(cluster)
+	machineDeploymentAnnotations := labels.Merge(workerset.Config.Annotations, machineAnnotations)
+
+	if workerset.Autoscaler != nil {
+		machineDeploymentAnnotations = labels.Merge(machineDeploymentAnnotations, getAutoscalerAnnotations(workerset.Autoscaler))
+	}
+
+	if len(workerset.Config.BootstrapCommands) > 0 {
+		machineDeploymentAnnotations = labels.Merge(machineDeploymentAnnotations, map[string]string{
+			operatingsystemmanager.ProfileAnnotation: operatingsystemmanager.BootstrapProfileName(workerset),
+		})
+	}
 
 	return &clusterv1alpha1.MachineDeployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Annotations: labels.Merge(workerset.Config.Annotations, machineAnnotations),
+			Annotations: machineDeploymentAnnotations,
 			Namespace:   metav1.NamespaceSystem,
 			Name:        workerset.Name,
 		},
@@ -182,6 +203,15 @@ func createMachineDeployment(cluster *kubeoneapi.KubeOneCluster, workerset kubeo
 	}, nil
 }
 
+// getAutoscalerAnnotations returns the annotations used by the cluster-autoscaler
+// Cluster-API provider to discover the MachineDeployment and its size limits
+func getAutoscalerAnnotations(autoscaler *kubeoneapi.DynamicWorkerAutoscaler) map[string]string {
+	return map[string]string{
+		autoscalerMinSizeAnnotation: strconv.Itoa(autoscaler.MinReplicas),
+		autoscalerMaxSizeAnnotation: strconv.Itoa(autoscaler.MaxReplicas),
+	}
+}
+
 func getKubeletConfigurationAnnotations(cluster *kubeoneapi.KubeOneCluster) map[string]string {
 	annotations := make(map[string]string)
 
@@ -221,6 +251,10 @@ func machineSpec(cluster *kubeoneapi.KubeOneCluster, workerset kubeoneapi.Dynami
 		}
 		awsSpec.Tags[tagName] = tagValue
 
+		if workerset.Config.InstanceProfile != "" {
+			awsSpec.InstanceProfile = workerset.Config.InstanceProfile
+		}
+
 		// effectively overwrite specRaw retrieved earlier
 		specRaw, err = json.Marshal(awsSpec)
 		if err != nil {
@@ -234,5 +268,80 @@ func machineSpec(cluster *kubeoneapi.KubeOneCluster, workerset kubeoneapi.Dynami
 		return nil, fail.Runtime(err, "unmarshalling machineSpec")
 	}
 
+	if workerset.Config.CloudNetwork != nil {
+		setCloudNetwork(spec, workerset.Config.CloudNetwork, provider)
+	}
+
+	if workerset.Config.Disks != nil {
+		setDisks(spec, workerset.Config.Disks, provider)
+	}
+
 	return spec, nil
 }
+
+// setCloudNetwork overrides the provider specific networking fields of the
+// machine spec
+func setCloudNetwork(spec map[string]interface{}, cloudNetwork *kubeoneapi.CloudNetworkConfig, provider kubeoneapi.CloudProviderSpec) {
+	var subnetKey, securityGroupsKey, publicIPKey string
+
+	switch {
+	case provider.AWS != nil:
+		subnetKey, securityGroupsKey, publicIPKey = "subnetId", "securityGroupIDs", "assignPublicIP"
+	case provider.Azure != nil:
+		subnetKey, publicIPKey = "subnetName", "assignPublicIP"
+		if len(cloudNetwork.SecurityGroupIDs) > 0 {
+			spec["securityGroupName"] = cloudNetwork.SecurityGroupIDs[0]
+		}
+	case provider.GCE != nil:
+		subnetKey, publicIPKey = "subnetwork", "assignPublicIPAddress"
+	case provider.Openstack != nil:
+		subnetKey, securityGroupsKey = "subnet", "securityGroups"
+	default:
+		return
+	}
+
+	if cloudNetwork.SubnetID != "" {
+		spec[subnetKey] = cloudNetwork.SubnetID
+	}
+	if securityGroupsKey != "" && len(cloudNetwork.SecurityGroupIDs) > 0 {
+		spec[securityGroupsKey] = cloudNetwork.SecurityGroupIDs
+	}
+	if publicIPKey != "" && cloudNetwork.AssignPublicIP != nil {
+		spec[publicIPKey] = *cloudNetwork.AssignPublicIP
+	}
+}
+
+// setDisks overrides the provider specific disk fields of the machine spec
+func setDisks(spec map[string]interface{}, disks *kubeoneapi.DiskConfig, provider kubeoneapi.CloudProviderSpec) {
+	var sizeKey, typeKey string
+
+	switch {
+	case provider.AWS != nil, provider.GCE != nil:
+		sizeKey, typeKey = "diskSize", "diskType"
+	case provider.Azure != nil:
+		sizeKey, typeKey = "osDiskSize", "osDiskSKU"
+		if len(disks.DataVolumes) > 0 {
+			spec["dataDiskSize"] = disks.DataVolumes[0].Size
+			if disks.DataVolumes[0].Type != "" {
+				spec["dataDiskSKU"] = disks.DataVolumes[0].Type
+			}
+		}
+	case provider.Nutanix != nil:
+		sizeKey = "diskSize"
+	case provider.Openstack != nil:
+		sizeKey, typeKey = "rootDiskSizeGB", "rootDiskVolumeType"
+	case provider.VMwareCloudDirector != nil:
+		sizeKey, typeKey = "diskSizeGB", "storageProfile"
+	case provider.Vsphere != nil:
+		sizeKey = "diskSizeGB"
+	default:
+		return
+	}
+
+	if disks.Size > 0 {
+		spec[sizeKey] = disks.Size
+	}
+	if typeKey != "" && disks.Type != "" {
+		spec[typeKey] = disks.Type
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinecontroller
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func boolPtr(val bool) *bool {
+	return &val
+}
+
+func Test_setCloudNetwork(t *testing.T) {
+	fullNetwork := &kubeoneapi.CloudNetworkConfig{
+		SubnetID:         "subnet-1",
+		SecurityGroupIDs: []string{"sg-1", "sg-2"},
+		AssignPublicIP:   boolPtr(false),
+	}
+
+	tests := []struct {
+		name         string
+		spec         map[string]interface{}
+		cloudNetwork *kubeoneapi.CloudNetworkConfig
+		provider     kubeoneapi.CloudProviderSpec
+		want         map[string]interface{}
+	}{
+		{
+			name:         "aws",
+			spec:         map[string]interface{}{"subnetId": "subnet-0", "instanceType": "t3.medium"},
+			cloudNetwork: fullNetwork,
+			provider:     kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}},
+			want: map[string]interface{}{
+				"subnetId":         "subnet-1",
+				"securityGroupIDs": []string{"sg-1", "sg-2"},
+				"assignPublicIP":   false,
+				"instanceType":     "t3.medium",
+			},
+		},
+		{
+			name:         "azure uses the first security group",
+			spec:         map[string]interface{}{},
+			cloudNetwork: fullNetwork,
+			provider:     kubeoneapi.CloudProviderSpec{Azure: &kubeoneapi.AzureSpec{}},
+			want: map[string]interface{}{
+				"subnetName":        "subnet-1",
+				"securityGroupName": "sg-1",
+				"assignPublicIP":    false,
+			},
+		},
+		{
+			name:         "gce ignores security groups",
+			spec:         map[string]interface{}{},
+			cloudNetwork: fullNetwork,
+			provider:     kubeoneapi.CloudProviderSpec{GCE: &kubeoneapi.GCESpec{}},
+			want: map[string]interface{}{
+				"subnetwork":            "subnet-1",
+				"assignPublicIPAddress": false,
+			},
+		},
+		{
+			name:         "openstack ignores public IP",
+			spec:         map[string]interface{}{},
+			cloudNetwork: fullNetwork,
+			provider:     kubeoneapi.CloudProviderSpec{Openstack: &kubeoneapi.OpenstackSpec{}},
+			want: map[string]interface{}{
+				"subnet":         "subnet-1",
+				"securityGroups": []string{"sg-1", "sg-2"},
+			},
+		},
+		{
+			name:         "empty fields keep the spec",
+			spec:         map[string]interface{}{"subnetId": "subnet-0"},
+			cloudNetwork: &kubeoneapi.CloudNetworkConfig{},
+			provider:     kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}},
+			want:         map[string]interface{}{"subnetId": "subnet-0"},
+		},
+		{
+			name:         "unsupported provider",
+			spec:         map[string]interface{}{},
+			cloudNetwork: fullNetwork,
+			provider:     kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			want:         map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setCloudNetwork(tt.spec, tt.cloudNetwork, tt.provider)
+			if !reflect.DeepEqual(tt.spec, tt.want) {
+				t.Errorf("setCloudNetwork() = %v, want %v", tt.spec, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setDisks(t *testing.T) {
+	rootDisk := &kubeoneapi.DiskConfig{
+		Size: 50,
+		Type: "gp3",
+	}
+
+	tests := []struct {
+		name     string
+		spec     map[string]interface{}
+		disks    *kubeoneapi.DiskConfig
+		provider kubeoneapi.CloudProviderSpec
+		want     map[string]interface{}
+	}{
+		{
+			name:     "aws",
+			spec:     map[string]interface{}{"diskSize": 25, "instanceType": "t3.medium"},
+			disks:    rootDisk,
+			provider: kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}},
+			want: map[string]interface{}{
+				"diskSize":     50,
+				"diskType":     "gp3",
+				"instanceType": "t3.medium",
+			},
+		},
+		{
+			name:     "gce",
+			spec:     map[string]interface{}{},
+			disks:    &kubeoneapi.DiskConfig{Size: 50, Type: "pd-ssd"},
+			provider: kubeoneapi.CloudProviderSpec{GCE: &kubeoneapi.GCESpec{}},
+			want: map[string]interface{}{
+				"diskSize": 50,
+				"diskType": "pd-ssd",
+			},
+		},
+		{
+			name: "azure with data disk",
+			spec: map[string]interface{}{},
+			disks: &kubeoneapi.DiskConfig{
+				Size: 50,
+				Type: "Premium_LRS",
+				DataVolumes: []kubeoneapi.DataVolumeConfig{
+					{Size: 100, Type: "StandardSSD_LRS"},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{Azure: &kubeoneapi.AzureSpec{}},
+			want: map[string]interface{}{
+				"osDiskSize":   50,
+				"osDiskSKU":    "Premium_LRS",
+				"dataDiskSize": 100,
+				"dataDiskSKU":  "StandardSSD_LRS",
+			},
+		},
+		{
+			name: "azure data disk without type",
+			spec: map[string]interface{}{},
+			disks: &kubeoneapi.DiskConfig{
+				DataVolumes: []kubeoneapi.DataVolumeConfig{
+					{Size: 100},
+				},
+			},
+			provider: kubeoneapi.CloudProviderSpec{Azure: &kubeoneapi.AzureSpec{}},
+			want: map[string]interface{}{
+				"dataDiskSize": 100,
+			},
+		},
+		{
+			name:     "nutanix ignores type",
+			spec:     map[string]interface{}{},
+			disks:    rootDisk,
+			provider: kubeoneapi.CloudProviderSpec{Nutanix: &kubeoneapi.NutanixSpec{}},
+			want: map[string]interface{}{
+				"diskSize": 50,
+			},
+		},
+		{
+			name:     "openstack",
+			spec:     map[string]interface{}{},
+			disks:    &kubeoneapi.DiskConfig{Size: 50, Type: "ssd"},
+			provider: kubeoneapi.CloudProviderSpec{Openstack: &kubeoneapi.OpenstackSpec{}},
+			want: map[string]interface{}{
+				"rootDiskSizeGB":     50,
+				"rootDiskVolumeType": "ssd",
+			},
+		},
+		{
+			name:     "vmware cloud director",
+			spec:     map[string]interface{}{},
+			disks:    &kubeoneapi.DiskConfig{Size: 50, Type: "*"},
+			provider: kubeoneapi.CloudProviderSpec{VMwareCloudDirector: &kubeoneapi.VMwareCloudDirectorSpec{}},
+			want: map[string]interface{}{
+				"diskSizeGB":     50,
+				"storageProfile": "*",
+			},
+		},
+		{
+			name:     "vsphere ignores type",
+			spec:     map[string]interface{}{},
+			disks:    rootDisk,
+			provider: kubeoneapi.CloudProviderSpec{Vsphere: &kubeoneapi.VsphereSpec{}},
+			want: map[string]interface{}{
+				"diskSizeGB": 50,
+			},
+		},
+		{
+			name:     "empty fields keep the spec",
+			spec:     map[string]interface{}{"diskSize": 25, "diskType": "gp2"},
+			disks:    &kubeoneapi.DiskConfig{},
+			provider: kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}},
+			want:     map[string]interface{}{"diskSize": 25, "diskType": "gp2"},
+		},
+		{
+			name:     "unsupported provider",
+			spec:     map[string]interface{}{},
+			disks:    rootDisk,
+			provider: kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			want:     map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDisks(tt.spec, tt.disks, tt.provider)
+			if !reflect.DeepEqual(tt.spec, tt.want) {
+				t.Errorf("setDisks() = %v, want %v", tt.spec, tt.want)
+			}
+		})
+	}
+}
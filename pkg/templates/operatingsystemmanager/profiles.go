@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatingsystemmanager
+
+import (
+	"fmt"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clientutil"
+	"k8c.io/kubeone/pkg/fail"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/resources"
+
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ProfileAnnotation is the MachineDeployment annotation used by
+	// operating-system-manager to select the OperatingSystemProfile
+	ProfileAnnotation = "k8c.io/operating-system-profile"
+)
+
+var profileGVK = schema.GroupVersionKind{
+	Group:   "operatingsystemmanager.k8c.io",
+	Version: "v1alpha1",
+	Kind:    "OperatingSystemProfile",
+}
+
+// BootstrapProfileName returns the name of the OperatingSystemProfile
+// carrying the bootstrap commands of the given worker pool
+func BootstrapProfileName(workerset kubeoneapi.DynamicWorkerConfig) string {
+	return fmt.Sprintf("%s-%s", defaultProfileName(workerset.Config.OperatingSystem), workerset.Name)
+}
+
+// defaultProfileName returns the name of the default OperatingSystemProfile
+// shipped by operating-system-manager for the given operating system
+func defaultProfileName(operatingSystem string) string {
+	return fmt.Sprintf("osp-%s", operatingSystem)
+}
+
+// EnsureBootstrapProfiles creates an OperatingSystemProfile for every worker
+// pool with bootstrap commands. The profile is a copy of the default profile
+// for the pool's operating system with the bootstrap commands prepended to
+// the bootcmd cloud-init module, so they run before the node joins the
+// cluster.
+func EnsureBootstrapProfiles(s *state.State) error {
+	if !s.Cluster.OperatingSystemManagerEnabled() {
+		return nil
+	}
+
+	for _, workerset := range s.Cluster.DynamicWorkers {
+		if len(workerset.Config.BootstrapCommands) == 0 {
+			continue
+		}
+
+		base, err := waitForDefaultProfile(s, workerset.Config.OperatingSystem)
+		if err != nil {
+			return err
+		}
+
+		profile, err := bootstrapProfile(base, BootstrapProfileName(workerset), workerset.Config.BootstrapCommands)
+		if err != nil {
+			return err
+		}
+
+		if err = clientutil.CreateOrUpdate(s.Context, s.DynamicClient, profile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitForDefaultProfile waits for operating-system-manager to create the
+// default OperatingSystemProfile for the given operating system
+func waitForDefaultProfile(s *state.State, operatingSystem string) (*metav1unstructured.Unstructured, error) {
+	profile := &metav1unstructured.Unstructured{}
+	profile.SetGroupVersionKind(profileGVK)
+
+	key := dynclient.ObjectKey{
+		Namespace: resources.OperatingSystemManagerNamespace,
+		Name:      defaultProfileName(operatingSystem),
+	}
+
+	err := wait.Poll(5*time.Second, 3*time.Minute, func() (bool, error) {
+		return s.DynamicClient.Get(s.Context, key, profile) == nil, nil
+	})
+
+	return profile, fail.KubeClient(err, "waiting for %s OperatingSystemProfile", key.Name)
+}
+
+// bootstrapProfile copies the spec of the base OperatingSystemProfile and
+// prepends the commands to its bootcmd module
+func bootstrapProfile(base *metav1unstructured.Unstructured, name string, commands []string) (*metav1unstructured.Unstructured, error) {
+	spec, _, err := metav1unstructured.NestedMap(base.Object, "spec")
+	if err != nil {
+		return nil, fail.Runtime(err, "reading %s OperatingSystemProfile spec", base.GetName())
+	}
+
+	existing, _, err := metav1unstructured.NestedStringSlice(spec, "modules", "bootcmd")
+	if err != nil {
+		return nil, fail.Runtime(err, "reading %s OperatingSystemProfile bootcmd", base.GetName())
+	}
+
+	bootcmd := append(append([]string{}, commands...), existing...)
+	if err = metav1unstructured.SetNestedStringSlice(spec, bootcmd, "modules", "bootcmd"); err != nil {
+		return nil, fail.Runtime(err, "setting %s OperatingSystemProfile bootcmd", name)
+	}
+
+	profile := &metav1unstructured.Unstructured{}
+	profile.SetGroupVersionKind(profileGVK)
+	profile.SetNamespace(resources.OperatingSystemManagerNamespace)
+	profile.SetName(name)
+
+	if err = metav1unstructured.SetNestedMap(profile.Object, spec, "spec"); err != nil {
+		return nil, fail.Runtime(err, "setting %s OperatingSystemProfile spec", name)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatingsystemmanager
+
+import (
+	"reflect"
+	"testing"
+
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBootstrapProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		modules  map[string]interface{}
+		commands []string
+		want     []string
+	}{
+		{
+			name:     "no modules in the base profile",
+			commands: []string{"mount /dev/sdb /mnt/data"},
+			want:     []string{"mount /dev/sdb /mnt/data"},
+		},
+		{
+			name: "commands prepended to the existing bootcmd",
+			modules: map[string]interface{}{
+				"bootcmd": []interface{}{"modprobe br_netfilter"},
+				"runcmd":  []interface{}{"systemctl restart setup.service"},
+			},
+			commands: []string{"mount /dev/sdb /mnt/data", "/opt/agent/register"},
+			want:     []string{"mount /dev/sdb /mnt/data", "/opt/agent/register", "modprobe br_netfilter"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := map[string]interface{}{
+				"osName":  "ubuntu",
+				"version": "v1.0.0",
+			}
+			if tt.modules != nil {
+				spec["modules"] = tt.modules
+			}
+
+			base := &metav1unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+			base.SetGroupVersionKind(profileGVK)
+			base.SetName("osp-ubuntu")
+			base.SetResourceVersion("42")
+
+			got, err := bootstrapProfile(base, "osp-ubuntu-workers", tt.commands)
+			if err != nil {
+				t.Fatalf("bootstrapProfile() error = %v", err)
+			}
+
+			if got.GetName() != "osp-ubuntu-workers" || got.GetResourceVersion() != "" {
+				t.Errorf("bootstrapProfile() metadata = %v", got.Object["metadata"])
+			}
+
+			bootcmd, _, _ := metav1unstructured.NestedStringSlice(got.Object, "spec", "modules", "bootcmd")
+			if !reflect.DeepEqual(bootcmd, tt.want) {
+				t.Errorf("bootstrapProfile() bootcmd = %v, want %v", bootcmd, tt.want)
+			}
+
+			if osName, _, _ := metav1unstructured.NestedString(got.Object, "spec", "osName"); osName != "ubuntu" {
+				t.Errorf("bootstrapProfile() osName = %q, want %q", osName, "ubuntu")
+			}
+		})
+	}
+}
@@ -39,15 +39,26 @@ const (
 	AddonCSIHetzner             = "csi-hetzner"
 	AddonCSINutanix             = "csi-nutanix"
 	AddonCSIOpenStackCinder     = "csi-openstack-cinder"
+	AddonCSISnapshotController  = "csi-snapshot-controller"
 	AddonCSIVMwareCloudDirector = "csi-vmware-cloud-director"
 	AddonCSIVsphere             = "csi-vsphere"
 	AddonCNICanal               = "cni-canal"
 	AddonCNICilium              = "cni-cilium"
 	AddonCNIWeavenet            = "cni-weavenet"
+	AddonEtcdBackup             = "etcd-backup"
+	AddonEtcdMetrics            = "etcd-metrics"
 	AddonMachineController      = "machinecontroller"
 	AddonOperatingSystemManager = "operating-system-manager"
 	AddonMetricsServer          = "metrics-server"
 	AddonNodeLocalDNS           = "nodelocaldns"
+	AddonNodeProblemDetector    = "node-problem-detector"
+	AddonPrometheusAdapter      = "prometheus-adapter"
+)
+
+const (
+	// NodeProblemDetectorCustomMonitorConfigParam is the name of the addon
+	// param used to provide a custom node-problem-detector monitor config
+	NodeProblemDetectorCustomMonitorConfigParam = "NPDCustomMonitorConfig"
 )
 
 const (
@@ -67,6 +78,11 @@ const (
 	MetricsServerName      = "metrics-server"
 	MetricsServerNamespace = metav1.NamespaceSystem
 
+	EtcdMetricsClientName = "etcd-metrics-client"
+
+	PrometheusAdapterName      = "prometheus-adapter"
+	PrometheusAdapterNamespace = metav1.NamespaceSystem
+
 	VsphereCSIWebhookName      = "vsphere-webhook-svc"
 	VsphereCSIWebhookNamespace = metav1.NamespaceSystem
 
@@ -101,5 +117,8 @@ func All() map[string]string {
 		"KubeletImageRepository":            KubeletImageRepository,
 		"NodeLocalDNSVirtualIP":             NodeLocalDNSVirtualIP,
 		"CABundleSSLCertFilePath":           cabundle.SSLCertFilePath,
+		"EtcdMetricsClientName":             EtcdMetricsClientName,
+		"PrometheusAdapterName":             PrometheusAdapterName,
+		"PrometheusAdapterNamespace":        PrometheusAdapterNamespace,
 	}
 }
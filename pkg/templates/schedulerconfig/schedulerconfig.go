@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulerconfig
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/fail"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigDir is the directory on the control plane nodes containing the
+	// kube-scheduler configuration file
+	ConfigDir = "/etc/kubernetes/scheduler"
+	// ConfigPath is the path of the kube-scheduler configuration file on the
+	// control plane nodes
+	ConfigPath = ConfigDir + "/config.yaml"
+
+	// kubeconfigPath is the kube-scheduler kubeconfig generated by kubeadm.
+	// It must be set in the configuration file, because the --kubeconfig flag
+	// is ignored when --config is used.
+	kubeconfigPath = "/etc/kubernetes/scheduler.conf"
+)
+
+// kubeSchedulerConfiguration is the subset of the KubeSchedulerConfiguration
+// API managed by KubeOne, which is the same in all supported API versions
+type kubeSchedulerConfiguration struct {
+	APIVersion               string                 `json:"apiVersion"`
+	Kind                     string                 `json:"kind"`
+	ClientConnection         clientConnectionConfig `json:"clientConnection"`
+	LeaderElection           leaderElectionConfig   `json:"leaderElection"`
+	PercentageOfNodesToScore *int32                 `json:"percentageOfNodesToScore,omitempty"`
+}
+
+type clientConnectionConfig struct {
+	Kubeconfig string `json:"kubeconfig"`
+}
+
+type leaderElectionConfig struct {
+	LeaderElect bool `json:"leaderElect"`
+}
+
+// NewConfig generates the KubeSchedulerConfiguration manifest
+func NewConfig(cluster *kubeoneapi.KubeOneCluster) (string, error) {
+	sver, err := semver.NewVersion(cluster.Versions.Kubernetes)
+	if err != nil {
+		return "", fail.Config(err, "parsing kubernetes semver")
+	}
+
+	// v1beta2 is available since Kubernetes 1.22, while v1beta1 is removed
+	// in Kubernetes 1.23
+	apiVersion := "kubescheduler.config.k8s.io/v1beta2"
+	if sver.Minor() < 22 {
+		apiVersion = "kubescheduler.config.k8s.io/v1beta1"
+	}
+
+	config := kubeSchedulerConfiguration{
+		APIVersion: apiVersion,
+		Kind:       "KubeSchedulerConfiguration",
+		ClientConnection: clientConnectionConfig{
+			Kubeconfig: kubeconfigPath,
+		},
+		LeaderElection: leaderElectionConfig{
+			LeaderElect: true,
+		},
+	}
+
+	if cluster.SchedulerConfigEnabled() {
+		config.PercentageOfNodesToScore = cluster.ControlPlaneComponents.Scheduler.PercentageOfNodesToScore
+	}
+
+	buf, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fail.Runtime(err, "marshalling KubeSchedulerConfiguration")
+	}
+
+	return string(buf), nil
+}
+
+// ConfigHash returns the short hash of the configuration used to restart the
+// kube-scheduler when the configuration changes
+func ConfigHash(config string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(config)))[:16]
+}
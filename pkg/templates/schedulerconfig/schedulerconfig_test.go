@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulerconfig
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestNewConfig(t *testing.T) {
+	percentage := int32(30)
+
+	tests := []struct {
+		name    string
+		cluster *kubeoneapi.KubeOneCluster
+		want    string
+	}{
+		{
+			name: "v1beta1 on Kubernetes 1.21",
+			cluster: &kubeoneapi.KubeOneCluster{
+				Versions: kubeoneapi.VersionConfig{Kubernetes: "1.21.12"},
+				ControlPlaneComponents: &kubeoneapi.ControlPlaneComponents{
+					Scheduler: &kubeoneapi.SchedulerConfig{PercentageOfNodesToScore: &percentage},
+				},
+			},
+			want: heredoc.Doc(`
+				apiVersion: kubescheduler.config.k8s.io/v1beta1
+				clientConnection:
+				  kubeconfig: /etc/kubernetes/scheduler.conf
+				kind: KubeSchedulerConfiguration
+				leaderElection:
+				  leaderElect: true
+				percentageOfNodesToScore: 30
+			`),
+		},
+		{
+			name: "v1beta2 on Kubernetes 1.24",
+			cluster: &kubeoneapi.KubeOneCluster{
+				Versions: kubeoneapi.VersionConfig{Kubernetes: "1.24.0"},
+				ControlPlaneComponents: &kubeoneapi.ControlPlaneComponents{
+					Scheduler: &kubeoneapi.SchedulerConfig{PercentageOfNodesToScore: &percentage},
+				},
+			},
+			want: heredoc.Doc(`
+				apiVersion: kubescheduler.config.k8s.io/v1beta2
+				clientConnection:
+				  kubeconfig: /etc/kubernetes/scheduler.conf
+				kind: KubeSchedulerConfiguration
+				leaderElection:
+				  leaderElect: true
+				percentageOfNodesToScore: 30
+			`),
+		},
+		{
+			name: "scheduler defaults",
+			cluster: &kubeoneapi.KubeOneCluster{
+				Versions: kubeoneapi.VersionConfig{Kubernetes: "1.23.6"},
+				ControlPlaneComponents: &kubeoneapi.ControlPlaneComponents{
+					Scheduler: &kubeoneapi.SchedulerConfig{},
+				},
+			},
+			want: heredoc.Doc(`
+				apiVersion: kubescheduler.config.k8s.io/v1beta2
+				clientConnection:
+				  kubeconfig: /etc/kubernetes/scheduler.conf
+				kind: KubeSchedulerConfiguration
+				leaderElection:
+				  leaderElect: true
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfig(tt.cluster)
+			if err != nil {
+				t.Fatalf("NewConfig() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8c.io/kubeone/pkg/fail"
+)
+
+const (
+	// DefaultRetries is the default number of retries of the failed terraform invocation
+	DefaultRetries = 3
+	// DefaultTimeout is the default timeout of a single terraform invocation
+	DefaultTimeout = 5 * time.Minute
+	// DefaultBackoff is the default delay before the first retry of the failed terraform invocation
+	DefaultBackoff = 5 * time.Second
+)
+
+// Options configures how terraform commands are invoked
+type Options struct {
+	// Retries is the number of times the failed terraform invocation is retried
+	Retries int
+	// Timeout is the timeout of a single terraform invocation, 0 means no timeout
+	Timeout time.Duration
+	// Backoff is the delay before the first retry, doubled after each next retry
+	Backoff time.Duration
+}
+
+// DefaultOptions returns the default terraform invocation options
+func DefaultOptions() Options {
+	return Options{
+		Retries: DefaultRetries,
+		Timeout: DefaultTimeout,
+		Backoff: DefaultBackoff,
+	}
+}
+
+// runFunc runs the terraform command with the given arguments in the given
+// directory and returns its stdout and stderr
+type runFunc func(ctx context.Context, dir string, args ...string) (stdout, stderr []byte, err error)
+
+// Output runs `terraform output -json` in the given directory and returns its
+// output. Failed invocations are retried with the exponential backoff.
+func Output(ctx context.Context, dir string, opts Options, logger logrus.FieldLogger) ([]byte, error) {
+	return output(ctx, dir, opts, logger, runTerraform)
+}
+
+func output(ctx context.Context, dir string, opts Options, logger logrus.FieldLogger, run runFunc) ([]byte, error) {
+	args := []string{"output", "-json"}
+	backoff := opts.Backoff
+
+	for attempt := 0; ; attempt++ {
+		stdout, stderr, err := runWithTimeout(ctx, dir, opts.Timeout, run, args...)
+		if err == nil {
+			return stdout, nil
+		}
+
+		if errors.Is(err, exec.ErrNotFound) || attempt >= opts.Retries {
+			return nil, fail.Runtime(withStderr(err, stderr), "running terraform %s", strings.Join(args, " "))
+		}
+
+		logger.Warnf("Running terraform %s failed (attempt %d/%d), retrying in %s: %v",
+			strings.Join(args, " "), attempt+1, opts.Retries+1, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fail.Runtime(withStderr(ctx.Err(), stderr), "running terraform %s", strings.Join(args, " "))
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+func runWithTimeout(ctx context.Context, dir string, timeout time.Duration, run runFunc, args ...string) ([]byte, []byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	stdout, stderr, err := run(ctx, dir, args...)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+
+	return stdout, stderr, err
+}
+
+func runTerraform(ctx context.Context, dir string, args ...string) ([]byte, []byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "terraform", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// withStderr appends the terraform stderr to the error
+func withStderr(err error, stderr []byte) error {
+	if msg := strings.TrimSpace(string(stderr)); msg != "" {
+		return fmt.Errorf("%w\n%s", err, msg)
+	}
+
+	return err
+}
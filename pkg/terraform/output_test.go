@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeTerraform fails the given number of invocations before succeeding
+type fakeTerraform struct {
+	failures int
+	calls    int
+	err      error
+	hang     bool
+}
+
+func (f *fakeTerraform) run(ctx context.Context, _ string, _ ...string) ([]byte, []byte, error) {
+	f.calls++
+
+	if f.hang {
+		<-ctx.Done()
+
+		return nil, []byte("interrupted"), ctx.Err()
+	}
+
+	if f.calls <= f.failures {
+		err := f.err
+		if err == nil {
+			err = fmt.Errorf("exit status 1")
+		}
+
+		return nil, []byte(fmt.Sprintf("Error: transient provider error %d\n", f.calls)), err
+	}
+
+	return []byte(`{"kubeone_api": {}}`), nil, nil
+}
+
+func TestOutput(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	tests := []struct {
+		name          string
+		tf            *fakeTerraform
+		opts          Options
+		expectedCalls int
+		expectedErr   string
+	}{
+		{
+			name:          "success on first attempt",
+			tf:            &fakeTerraform{},
+			opts:          Options{Retries: 3, Backoff: time.Millisecond},
+			expectedCalls: 1,
+		},
+		{
+			name:          "success after transient failures",
+			tf:            &fakeTerraform{failures: 2},
+			opts:          Options{Retries: 3, Backoff: time.Millisecond},
+			expectedCalls: 3,
+		},
+		{
+			name:          "retries exhausted",
+			tf:            &fakeTerraform{failures: 10},
+			opts:          Options{Retries: 2, Backoff: time.Millisecond},
+			expectedCalls: 3,
+			expectedErr:   "transient provider error 3",
+		},
+		{
+			name:          "no retries",
+			tf:            &fakeTerraform{failures: 1},
+			opts:          Options{Retries: 0, Backoff: time.Millisecond},
+			expectedCalls: 1,
+			expectedErr:   "transient provider error 1",
+		},
+		{
+			name:          "terraform not installed",
+			tf:            &fakeTerraform{failures: 10, err: exec.ErrNotFound},
+			opts:          Options{Retries: 3, Backoff: time.Millisecond},
+			expectedCalls: 1,
+			expectedErr:   "executable file not found",
+		},
+		{
+			name:          "timeout",
+			tf:            &fakeTerraform{hang: true},
+			opts:          Options{Retries: 1, Backoff: time.Millisecond, Timeout: 10 * time.Millisecond},
+			expectedCalls: 2,
+			expectedErr:   "timed out after 10ms",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := output(context.Background(), "tf-dir", tt.opts, logger, tt.tf.run)
+			if tt.tf.calls != tt.expectedCalls {
+				t.Errorf("expected %d terraform calls, got %d", tt.expectedCalls, tt.tf.calls)
+			}
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if string(out) != `{"kubeone_api": {}}` {
+					t.Errorf("unexpected output: %s", out)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.expectedErr)
+			}
+			if !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("expected error containing %q, got %q", tt.expectedErr, err.Error())
+			}
+		})
+	}
+}
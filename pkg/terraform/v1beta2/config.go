@@ -39,6 +39,7 @@ type Config struct {
 
 		Value struct {
 			Endpoint                  string   `json:"endpoint"`
+			InternalEndpoint          string   `json:"internal_endpoint"`
 			APIServerAlternativeNames []string `json:"apiserver_alternative_names"`
 		} `json:"value"`
 	} `json:"kubeone_api"`
@@ -214,6 +215,10 @@ func (output *Config) Apply(cluster *kubeonev1beta2.KubeOneCluster) error {
 		cluster.APIEndpoint.Host = output.KubeOneAPI.Value.Endpoint
 	}
 
+	if output.KubeOneAPI.Value.InternalEndpoint != "" {
+		cluster.APIEndpoint.InternalHost = output.KubeOneAPI.Value.InternalEndpoint
+	}
+
 	if len(output.KubeOneAPI.Value.APIServerAlternativeNames) > 0 {
 		cluster.APIEndpoint.AlternativeNames = output.KubeOneAPI.Value.APIServerAlternativeNames
 	}
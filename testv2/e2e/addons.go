@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ccmWorkloads are the kube-system workloads of the external cloud controller
+// managers deployed by kubeone
+var ccmWorkloads = map[string][]string{
+	"aws":          {"aws-cloud-controller-manager"},
+	"azure":        {"azure-cloud-controller-manager", "cloud-node-manager"},
+	"digitalocean": {"digitalocean-cloud-controller-manager"},
+	"equinixmetal": {"equinixmetal-cloud-controller-manager"},
+	"hetzner":      {"hcloud-cloud-controller-manager"},
+	"openstack":    {"openstack-cloud-controller-manager"},
+	"vsphere":      {"vsphere-cloud-controller-manager"},
+}
+
+// defaultAddonWorkloads returns the names of the kube-system Deployments and
+// DaemonSets kubeone deploys for the given cluster
+func defaultAddonWorkloads(cluster *kubeoneapi.KubeOneCluster) []string {
+	workloads := []string{"coredns", "kube-proxy"}
+
+	if cni := cluster.ClusterNetwork.CNI; cni != nil {
+		switch {
+		case cni.Canal != nil:
+			workloads = append(workloads, "canal", "calico-kube-controllers")
+		case cni.Cilium != nil:
+			workloads = append(workloads, "cilium", "cilium-operator")
+		case cni.WeaveNet != nil:
+			workloads = append(workloads, "weave-net")
+		}
+	}
+
+	if ms := cluster.Features.MetricsServer; ms != nil && ms.Enable {
+		workloads = append(workloads, "metrics-server")
+	}
+
+	if mc := cluster.MachineController; mc != nil && mc.Deploy {
+		workloads = append(workloads, "machine-controller", "machine-controller-webhook")
+	}
+
+	if cluster.CloudProvider.External {
+		workloads = append(workloads, ccmWorkloads[cluster.CloudProvider.CloudProviderName()]...)
+	}
+
+	return workloads
+}
+
+// verifyAddonsReady waits for each of the expected kube-system Deployments or
+// DaemonSets to have all replicas ready. The returned error lists the workloads
+// which are not ready along with the last status of their containers.
+func verifyAddonsReady(t *testing.T, client ctrlruntimeclient.Client, expected []string, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, defaultNodesReadyTimeout, opts...)
+
+	var notReady map[string]*metav1.LabelSelector
+	var notReadyMsgs []string
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		notReady = map[string]*metav1.LabelSelector{}
+		notReadyMsgs = nil
+
+		for _, name := range expected {
+			selector, msg, err := workloadReadiness(client, name)
+			if err != nil {
+				t.Logf("error: %v", err)
+
+				return false, nil
+			}
+
+			if msg != "" {
+				notReady[name] = selector
+				notReadyMsgs = append(notReadyMsgs, msg)
+			}
+		}
+
+		return len(notReady) == 0, nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	msg := fmt.Sprintf("addons not ready: %s", strings.Join(notReadyMsgs, ", "))
+	for _, name := range expected {
+		selector, ok := notReady[name]
+		if !ok || selector == nil {
+			continue
+		}
+
+		if statuses := containerStatusMessages(client, selector); len(statuses) > 0 {
+			msg = fmt.Sprintf("%s\n%s containers:\n%s", msg, name, strings.Join(statuses, "\n"))
+		}
+	}
+
+	return fmt.Errorf("%w: %s", err, msg)
+}
+
+// workloadReadiness looks up the kube-system Deployment or DaemonSet with the
+// given name and returns its pod selector and a message describing why it's not
+// ready, or an empty message when it's ready
+func workloadReadiness(client ctrlruntimeclient.Client, name string) (*metav1.LabelSelector, string, error) {
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}
+
+	deploy := appsv1.Deployment{}
+	err := client.Get(context.Background(), key, &deploy)
+	switch {
+	case err == nil:
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+
+		if deploy.Status.ReadyReplicas < replicas {
+			return deploy.Spec.Selector, fmt.Sprintf("deployment/%s (%d/%d ready)", name, deploy.Status.ReadyReplicas, replicas), nil
+		}
+
+		return deploy.Spec.Selector, "", nil
+	case !k8serrors.IsNotFound(err):
+		return nil, "", err
+	}
+
+	ds := appsv1.DaemonSet{}
+	err = client.Get(context.Background(), key, &ds)
+	switch {
+	case err == nil:
+		if ds.Status.ObservedGeneration < ds.Generation || ds.Status.NumberReady < ds.Status.DesiredNumberScheduled {
+			return ds.Spec.Selector, fmt.Sprintf("daemonset/%s (%d/%d ready)", name, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled), nil
+		}
+
+		return ds.Spec.Selector, "", nil
+	case !k8serrors.IsNotFound(err):
+		return nil, "", err
+	}
+
+	return nil, fmt.Sprintf("%s (not found)", name), nil
+}
+
+// containerStatusMessages describes the last status of the not ready
+// containers of the kube-system pods matching the selector
+func containerStatusMessages(client ctrlruntimeclient.Client, selector *metav1.LabelSelector) []string {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return []string{fmt.Sprintf("invalid selector: %v", err)}
+	}
+
+	pods := corev1.PodList{}
+	if err = client.List(context.Background(), &pods,
+		ctrlruntimeclient.InNamespace(metav1.NamespaceSystem),
+		ctrlruntimeclient.MatchingLabelsSelector{Selector: labelSelector},
+	); err != nil {
+		return []string{fmt.Sprintf("failed to list pods: %v", err)}
+	}
+
+	var messages []string
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				continue
+			}
+
+			status := "not ready"
+			if state := containerStateMessage(cs.State); state != "" {
+				status = state
+			}
+
+			if cs.LastTerminationState.Terminated != nil {
+				status = fmt.Sprintf("%s, last %s", status, containerStateMessage(cs.LastTerminationState))
+			}
+
+			messages = append(messages, fmt.Sprintf("%s/%s: %s", pod.Name, cs.Name, status))
+		}
+	}
+
+	return messages
+}
+
+// containerStateMessage describes the waiting or terminated container state
+func containerStateMessage(state corev1.ContainerState) string {
+	var parts []string
+
+	switch {
+	case state.Waiting != nil:
+		parts = []string{"waiting:", state.Waiting.Reason, state.Waiting.Message}
+	case state.Terminated != nil:
+		parts = []string{
+			"terminated:", state.Terminated.Reason,
+			fmt.Sprintf("(exit code %d)", state.Terminated.ExitCode),
+			state.Terminated.Message,
+		}
+	}
+
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+
+	return strings.Join(nonEmpty, " ")
+}
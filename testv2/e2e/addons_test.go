@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDefaultAddonWorkloads(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *kubeoneapi.KubeOneCluster
+		want    []string
+	}{
+		{
+			name: "canal with metrics-server and machine-controller",
+			cluster: &kubeoneapi.KubeOneCluster{
+				ClusterNetwork:    kubeoneapi.ClusterNetworkConfig{CNI: &kubeoneapi.CNI{Canal: &kubeoneapi.CanalSpec{}}},
+				Features:          kubeoneapi.Features{MetricsServer: &kubeoneapi.MetricsServer{Enable: true}},
+				MachineController: &kubeoneapi.MachineControllerConfig{Deploy: true},
+				CloudProvider:     kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}},
+			},
+			want: []string{"coredns", "kube-proxy", "canal", "calico-kube-controllers", "metrics-server", "machine-controller", "machine-controller-webhook"},
+		},
+		{
+			name: "external CNI and external AWS CCM",
+			cluster: &kubeoneapi.KubeOneCluster{
+				ClusterNetwork: kubeoneapi.ClusterNetworkConfig{CNI: &kubeoneapi.CNI{External: &kubeoneapi.ExternalCNISpec{}}},
+				CloudProvider:  kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}, External: true},
+			},
+			want: []string{"coredns", "kube-proxy", "aws-cloud-controller-manager"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultAddonWorkloads(tt.cluster); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaultAddonWorkloads() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyAddonsReady(t *testing.T) {
+	t.Parallel()
+
+	selector := func(app string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}
+	}
+
+	deployment := func(name string, replicas, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(replicas), Selector: selector(name)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+
+	daemonSet := func(name string, desired, ready int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+			Spec:       appsv1.DaemonSetSpec{Selector: selector(name)},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberReady: ready},
+		}
+	}
+
+	crashingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-cloud-controller-manager-abcde",
+			Namespace: metav1.NamespaceSystem,
+			Labels:    map[string]string{"app": "aws-cloud-controller-manager"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "aws-cloud-controller-manager",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		expected       []string
+		objects        []client.Object
+		expectedErr    error
+		expectedErrMsg string
+	}{
+		{
+			name:     "all ready",
+			expected: []string{"coredns", "canal"},
+			objects:  []client.Object{deployment("coredns", 2, 2), daemonSet("canal", 3, 3)},
+		},
+		{
+			name:           "deployment not ready",
+			expected:       []string{"coredns", "aws-cloud-controller-manager"},
+			objects:        []client.Object{deployment("coredns", 2, 2), deployment("aws-cloud-controller-manager", 1, 0), crashingPod},
+			expectedErr:    wait.ErrWaitTimeout,
+			expectedErrMsg: "aws-cloud-controller-manager-abcde/aws-cloud-controller-manager: waiting: CrashLoopBackOff, last terminated: Error (exit code 1)",
+		},
+		{
+			name:           "daemonset not ready",
+			expected:       []string{"canal"},
+			objects:        []client.Object{daemonSet("canal", 3, 2)},
+			expectedErr:    wait.ErrWaitTimeout,
+			expectedErrMsg: "daemonset/canal (2/3 ready)",
+		},
+		{
+			name:           "workload not found",
+			expected:       []string{"metrics-server"},
+			objects:        []client.Object{},
+			expectedErr:    wait.ErrWaitTimeout,
+			expectedErrMsg: "metrics-server (not found)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientScheme, err := newClientScheme()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := fake.NewClientBuilder().WithScheme(clientScheme).WithObjects(tt.objects...).Build()
+
+			err = verifyAddonsReady(t, c, tt.expected,
+				withInterval(10*time.Millisecond),
+				withTimeout(100*time.Millisecond),
+			)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("verifyAddonsReady() error = %v, expected %v", err, tt.expectedErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.expectedErrMsg) {
+				t.Errorf("verifyAddonsReady() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+		})
+	}
+}
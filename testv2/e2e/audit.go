@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	auditCheckPrefix = "kubeone-e2e-audit-check-"
+
+	// auditLogTailLines is how many of the latest audit log lines are
+	// searched for the audit event
+	auditLogTailLines = 10000
+)
+
+// hostExecFunc runs the shell command on the host and returns its stdout
+type hostExecFunc func(host kubeoneapi.HostConfig, cmd string) (string, error)
+
+// newSSHHostExec returns hostExecFunc running the commands over SSH, the
+// agent socket overrides the one from the manifest when set
+func newSSHHostExec(sshAgentSocket string) hostExecFunc {
+	return func(host kubeoneapi.HostConfig, cmd string) (string, error) {
+		if sshAgentSocket != "" {
+			host.SSHAgentSocket = sshAgentSocket
+		}
+
+		return sshExec(host, cmd)
+	}
+}
+
+// staticAuditLogEnabled tells if the API servers are configured to write
+// the audit log
+func staticAuditLogEnabled(cluster *kubeoneapi.KubeOneCluster) bool {
+	return cluster.Features.StaticAuditLog != nil && cluster.Features.StaticAuditLog.Enable
+}
+
+// verifyAuditLogging creates and reads back a ConfigMap and waits for the
+// audit event of reading it to show up in the audit log on the control plane
+// hosts. The request goes through the load balancer, so the audit log of
+// every control plane host is searched.
+func verifyAuditLogging(t *testing.T, client ctrlruntimeclient.Client, cluster *kubeoneapi.KubeOneCluster, exec hostExecFunc, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, defaultNodesReadyTimeout, opts...)
+
+	ctx := context.Background()
+	logPath := cluster.Features.StaticAuditLog.Config.LogPath
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      auditCheckPrefix + rand.String(5),
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	if err := client.Create(ctx, cm); err != nil {
+		return fmt.Errorf("creating configmap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+
+	defer func() {
+		if err := client.Delete(ctx, cm); err != nil && !k8serrors.IsNotFound(err) {
+			t.Logf("failed to delete configmap %s/%s: %v", cm.Namespace, cm.Name, err)
+		}
+	}()
+
+	// the create event carries the object name only when the request body
+	// is logged, so the ConfigMap is read back by its name
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cm), &corev1.ConfigMap{}); err != nil {
+		return fmt.Errorf("getting configmap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+
+	cmd := auditLogSearchCommand(logPath, cm.Name)
+
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		for _, host := range cluster.ControlPlane.Hosts {
+			out, err := exec(host, cmd)
+			if err != nil {
+				t.Logf("error: searching audit log on %s: %v", hostDisplayName(host), err)
+
+				continue
+			}
+
+			if event := findAuditEvent(out, "configmaps", cm); event != nil {
+				t.Logf("audit event %s for %s %s/%s recorded on %s at the %s level",
+					event.AuditID, event.Verb, cm.Namespace, cm.Name, hostDisplayName(host), event.Level)
+
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("no audit event for configmap %s/%s found in %s on control plane hosts: %w", cm.Namespace, cm.Name, logPath, err)
+	}
+
+	return nil
+}
+
+// auditLogSearchCommand returns the command printing the latest audit log
+// lines mentioning the name. grep exits non-zero when nothing matches, so
+// only the missing audit log fails the command.
+func auditLogSearchCommand(logPath, name string) string {
+	return fmt.Sprintf("sudo test -f '%[1]s' || { echo 'audit log %[1]s not found' >&2; exit 1; }; sudo tail -n %[2]d '%[1]s' | grep -F -- '%[3]s' || true",
+		logPath, auditLogTailLines, name)
+}
+
+// findAuditEvent returns the first audit event in the JSON lines referring to
+// the object of the resource, lines that aren't audit events are skipped
+func findAuditEvent(lines, resource string, obj ctrlruntimeclient.Object) *auditv1.Event {
+	scanner := bufio.NewScanner(strings.NewReader(lines))
+	scanner.Buffer(nil, 1024*1024)
+
+	for scanner.Scan() {
+		var event auditv1.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+
+		ref := event.ObjectRef
+		if ref != nil && ref.Resource == resource && ref.Namespace == obj.GetNamespace() && ref.Name == obj.GetName() {
+			return &event
+		}
+	}
+
+	return nil
+}
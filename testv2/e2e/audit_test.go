@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVerifyAuditLogging(t *testing.T) {
+	t.Parallel()
+
+	auditEvent := func(verb, namespace, name string) string {
+		return fmt.Sprintf(`{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"a1","stage":"ResponseComplete",`+
+			`"verb":%q,"objectRef":{"resource":"configmaps","namespace":%q,"name":%q,"apiVersion":"v1"}}`, verb, namespace, name)
+	}
+
+	tests := []struct {
+		name           string
+		logs           func(host, name string) (string, error)
+		expectedErrMsg string
+		expectTimeout  bool
+	}{
+		{
+			name: "event recorded",
+			logs: func(_, name string) (string, error) {
+				return auditEvent("get", "default", name) + "\n", nil
+			},
+		},
+		{
+			name: "event recorded on another control plane host",
+			logs: func(host, name string) (string, error) {
+				if host != "cp-3" {
+					return "", errors.New("connection refused")
+				}
+
+				return "not an audit event " + name + "\n" + auditEvent("get", "default", name) + "\n", nil
+			},
+		},
+		{
+			name: "no event recorded",
+			logs: func(_, name string) (string, error) {
+				return auditEvent("get", "kube-system", name) + "\n" + auditEvent("get", "default", name+"-other") + "\n", nil
+			},
+			expectedErrMsg: "no audit event for configmap default/" + auditCheckPrefix,
+			expectTimeout:  true,
+		},
+		{
+			name: "audit log missing",
+			logs: func(string, string) (string, error) {
+				return "", errors.New("audit log /var/log/kubernetes/audit.log not found")
+			},
+			expectedErrMsg: "found in /var/log/kubernetes/audit.log on control plane hosts",
+			expectTimeout:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().Build()
+
+			cluster := &kubeoneapi.KubeOneCluster{
+				ControlPlane: kubeoneapi.ControlPlaneConfig{
+					Hosts: []kubeoneapi.HostConfig{{Hostname: "cp-1"}, {Hostname: "cp-2"}, {Hostname: "cp-3"}},
+				},
+				Features: kubeoneapi.Features{
+					StaticAuditLog: &kubeoneapi.StaticAuditLog{
+						Enable: true,
+						Config: kubeoneapi.StaticAuditLogConfig{LogPath: "/var/log/kubernetes/audit.log"},
+					},
+				},
+			}
+
+			exec := func(host kubeoneapi.HostConfig, cmd string) (string, error) {
+				cms := corev1.ConfigMapList{}
+				if err := c.List(context.Background(), &cms); err != nil || len(cms.Items) != 1 {
+					t.Fatalf("expected the configmap to exist, got %v: %v", cms.Items, err)
+				}
+
+				name := cms.Items[0].Name
+				if !strings.Contains(cmd, "'/var/log/kubernetes/audit.log'") || !strings.Contains(cmd, name) {
+					t.Errorf("command %q doesn't search the audit log for %s", cmd, name)
+				}
+
+				return tt.logs(host.Hostname, name)
+			}
+
+			err := verifyAuditLogging(t, c, cluster, exec, withInterval(time.Millisecond), withTimeout(50*time.Millisecond))
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("verifyAuditLogging() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("verifyAuditLogging() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+
+			if tt.expectTimeout && !errors.Is(err, wait.ErrWaitTimeout) {
+				t.Errorf("verifyAuditLogging() error = %v, expected wait timeout", err)
+			}
+
+			cms := corev1.ConfigMapList{}
+			if err = c.List(context.Background(), &cms); err != nil {
+				t.Fatal(err)
+			}
+			if len(cms.Items) != 0 {
+				t.Errorf("audit check configmap was not deleted")
+			}
+		})
+	}
+}
+
+func TestStaticAuditLogEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		features kubeoneapi.Features
+		want     bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:     "disabled",
+			features: kubeoneapi.Features{StaticAuditLog: &kubeoneapi.StaticAuditLog{}},
+		},
+		{
+			name:     "enabled",
+			features: kubeoneapi.Features{StaticAuditLog: &kubeoneapi.StaticAuditLog{Enable: true}},
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := staticAuditLogEnabled(&kubeoneapi.KubeOneCluster{Features: tt.features}); got != tt.want {
+				t.Errorf("staticAuditLogEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
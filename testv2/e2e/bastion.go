@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultSSHPort           = 22
+	bastionDialTimeout       = 10 * time.Second
+	bastionReachableInterval = 5 * time.Second
+	bastionReachableTimeout  = 2 * time.Minute
+)
+
+// verifyBastionReachable verifies that the bastions used to reach the cluster
+// nodes accept SSH connections, so the unreachable bastion is reported
+// clearly instead of failing in the middle of kubeone apply. The bastions are
+// taken from the hosts config, usually sourced from the terraform output. If
+// the SSH agent socket is set explicitly, it's verified as well.
+func verifyBastionReachable(k1 *kubeoneBin) error {
+	if k1.sshAgentSocket != "" {
+		info, err := os.Stat(k1.sshAgentSocket)
+		if err != nil {
+			return fmt.Errorf("ssh agent socket: %w", err)
+		}
+		if info.Mode()&os.ModeSocket == 0 {
+			return fmt.Errorf("ssh agent socket %s is not a socket", k1.sshAgentSocket)
+		}
+	}
+
+	cluster, err := k1.Manifest()
+	if err != nil {
+		return err
+	}
+
+	return waitForBastions(bastionAddresses(cluster))
+}
+
+// waitForBastions waits until all bastions respond with the SSH banner
+func waitForBastions(addrs []string) error {
+	for _, addr := range addrs {
+		var lastErr error
+		err := wait.PollImmediate(bastionReachableInterval, bastionReachableTimeout, func() (bool, error) {
+			lastErr = sshBanner(addr, bastionDialTimeout)
+
+			return lastErr == nil, nil
+		})
+		if err != nil {
+			return fmt.Errorf("bastion %s is not reachable: %w", addr, lastErr)
+		}
+	}
+
+	return nil
+}
+
+// bastionAddresses returns the host:port addresses of the bastions of the
+// control plane and static worker hosts
+func bastionAddresses(cluster *kubeoneapi.KubeOneCluster) []string {
+	addrs := sets.NewString()
+	for _, host := range append(cluster.ControlPlane.Hosts, cluster.StaticWorkers.Hosts...) {
+		if host.Bastion == "" {
+			continue
+		}
+
+		port := host.BastionPort
+		if port == 0 {
+			port = defaultSSHPort
+		}
+
+		addrs.Insert(net.JoinHostPort(host.Bastion, strconv.Itoa(port)))
+	}
+
+	return addrs.List()
+}
+
+// sshBanner connects to the address and verifies it responds with the SSH
+// protocol identification
+func sshBanner(addr string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	banner, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("reading ssh banner: %w", err)
+	}
+
+	if !strings.HasPrefix(banner, "SSH-") {
+		return fmt.Errorf("unexpected ssh banner %q", strings.TrimSpace(banner))
+	}
+
+	return nil
+}
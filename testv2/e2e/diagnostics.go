@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// artifactsEnv is set by Prow to the directory uploaded with the results
+	// of the job
+	artifactsEnv = "ARTIFACTS"
+
+	diagnosticsDir          = "diagnostics"
+	diagnosticsTimeout      = 5 * time.Minute
+	diagnosticsLogTailLines = int64(1000)
+	diagnosticsEventsLimit  = 500
+)
+
+// diagnosticsRegistered prevents dumping the diagnostics of the same test
+// more than once
+var diagnosticsRegistered sync.Map
+
+// registerDiagnostics dumps the diagnostics of the cluster into the artifacts
+// directory when the test fails. Dumping is best-effort, the errors are only
+// logged.
+func registerDiagnostics(t *testing.T, k1 *kubeoneBin) {
+	artifacts := os.Getenv(artifactsEnv)
+	if artifacts == "" {
+		return
+	}
+
+	if _, registered := diagnosticsRegistered.LoadOrStore(t, struct{}{}); registered {
+		return
+	}
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		dir := filepath.Join(artifacts, diagnosticsDir, strings.ReplaceAll(t.Name(), "/", "_"))
+		t.Logf("test failed, dumping cluster diagnostics to %s", dir)
+
+		clientset, err := diagnosticsClientset(k1)
+		if err != nil {
+			t.Logf("failed to dump cluster diagnostics: %v", err)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+		defer cancel()
+
+		if err = dumpDiagnostics(ctx, clientset, dir); err != nil {
+			t.Logf("failed to dump some cluster diagnostics: %v", err)
+		}
+	})
+}
+
+// diagnosticsClientset builds the clientset without failing the test, see
+// newRESTConfig
+func diagnosticsClientset(k1 *kubeoneBin) (kubernetes.Interface, error) {
+	restConfig, err := newRESTConfig(k1)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// dumpDiagnostics writes the Nodes, the recent Events and the logs of the
+// kube-system pods into the directory. It continues past the failures and
+// returns all of them.
+func dumpDiagnostics(ctx context.Context, clientset kubernetes.Interface, dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, "logs"), 0750); err != nil {
+		return err
+	}
+
+	var errs []error
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err == nil {
+		err = writeDiagnosticsYAML(filepath.Join(dir, "nodes.yaml"), nodes)
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("nodes: %w", err))
+	}
+
+	events, err := clientset.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err == nil {
+		events.Items = recentEvents(events.Items, diagnosticsEventsLimit)
+		err = writeDiagnosticsYAML(filepath.Join(dir, "events.yaml"), events)
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("events: %w", err))
+	}
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("kube-system pods: %w", err))
+	} else {
+		if err = writeDiagnosticsYAML(filepath.Join(dir, "kube-system-pods.yaml"), pods); err != nil {
+			errs = append(errs, fmt.Errorf("kube-system pods: %w", err))
+		}
+
+		for _, pod := range pods.Items {
+			errs = append(errs, dumpPodLogs(ctx, clientset, pod, filepath.Join(dir, "logs"))...)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// dumpPodLogs writes the logs of every container of the pod, including the
+// logs of the previous instance of the restarted containers
+func dumpPodLogs(ctx context.Context, clientset kubernetes.Interface, pod corev1.Pod, dir string) []error {
+	restarted := map[string]bool{}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		restarted[status.Name] = status.RestartCount > 0
+	}
+
+	var errs []error
+
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		previous := []bool{false}
+		if restarted[container.Name] {
+			previous = append(previous, true)
+		}
+
+		for _, prev := range previous {
+			name := fmt.Sprintf("%s_%s.log", pod.Name, container.Name)
+			if prev {
+				name = fmt.Sprintf("%s_%s.previous.log", pod.Name, container.Name)
+			}
+
+			tailLines := diagnosticsLogTailLines
+			logs, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: container.Name,
+				Previous:  prev,
+				TailLines: &tailLines,
+			}).DoRaw(ctx)
+			if err == nil {
+				err = os.WriteFile(filepath.Join(dir, name), logs, 0600)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("logs of %s/%s container %s: %w", pod.Namespace, pod.Name, container.Name, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// recentEvents returns up to the limit of the latest events, oldest first
+func recentEvents(events []corev1.Event, limit int) []corev1.Event {
+	eventTime := func(ev corev1.Event) time.Time {
+		switch {
+		case !ev.LastTimestamp.IsZero():
+			return ev.LastTimestamp.Time
+		case !ev.EventTime.IsZero():
+			return ev.EventTime.Time
+		}
+
+		return ev.CreationTimestamp.Time
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+
+	if len(events) > limit {
+		events = events[len(events)-limit:]
+	}
+
+	return events
+}
+
+func writeDiagnosticsYAML(path string, obj interface{}) error {
+	buf, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf, 0600)
+}
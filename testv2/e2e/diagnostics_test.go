@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDumpDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cp-1"}},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-1.reboot", Namespace: metav1.NamespaceDefault},
+			Reason:     "Rebooted",
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy-abcde", Namespace: metav1.NamespaceSystem},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "kube-proxy"}, {Name: "sidecar"}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "kube-proxy", RestartCount: 2},
+					{Name: "sidecar"},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: metav1.NamespaceDefault},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "workload"}}},
+		},
+	)
+
+	dir := filepath.Join(t.TempDir(), "diagnostics", "TestCluster")
+	if err := dumpDiagnostics(context.Background(), clientset, dir); err != nil {
+		t.Fatalf("dumpDiagnostics() unexpected error: %v", err)
+	}
+
+	expectedContent := map[string]string{
+		"nodes.yaml":            "name: cp-1",
+		"events.yaml":           "reason: Rebooted",
+		"kube-system-pods.yaml": "name: kube-proxy-abcde",
+	}
+
+	for file, content := range expectedContent {
+		buf, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Errorf("reading %s: %v", file, err)
+
+			continue
+		}
+
+		if !strings.Contains(string(buf), content) {
+			t.Errorf("%s doesn't contain %q:\n%s", file, content, buf)
+		}
+	}
+
+	logs, err := filepath.Glob(filepath.Join(dir, "logs", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range logs {
+		logs[i] = filepath.Base(logs[i])
+	}
+
+	expectedLogs := []string{
+		"kube-proxy-abcde_init.log",
+		"kube-proxy-abcde_kube-proxy.log",
+		"kube-proxy-abcde_kube-proxy.previous.log",
+		"kube-proxy-abcde_sidecar.log",
+	}
+
+	if strings.Join(logs, ",") != strings.Join(expectedLogs, ",") {
+		t.Errorf("dumped logs %v, expected %v", logs, expectedLogs)
+	}
+}
+
+func TestRecentEvents(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	event := func(name string, ago time.Duration) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{Name: name},
+			LastTimestamp: metav1.NewTime(now.Add(-ago)),
+		}
+	}
+
+	events := []corev1.Event{
+		event("newest", 0),
+		event("oldest", time.Hour),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "event-time"},
+			EventTime:  metav1.NewMicroTime(now.Add(-time.Minute)),
+		},
+		event("older", 30*time.Minute),
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "sorted oldest first",
+			limit: 10,
+			want:  []string{"oldest", "older", "event-time", "newest"},
+		},
+		{
+			name:  "limited to the latest",
+			limit: 2,
+			want:  []string{"event-time", "newest"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := []string{}
+			for _, ev := range recentEvents(append([]corev1.Event{}, events...), tt.limit) {
+				got = append(got, ev.Name)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("recentEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
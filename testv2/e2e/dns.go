@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8c.io/kubeone/pkg/templates/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	dnsCheckImage        = "k8s.gcr.io/e2e-test-images/jessie-dnsutils:1.3"
+	dnsCheckInternalName = "kubernetes.default"
+	dnsCheckExternalName = "kubernetes.io"
+	dnsCheckTimeout      = 5 * time.Minute
+
+	nodeLocalDNSName = "node-local-dns"
+)
+
+// dnsCheckPod returns the pod resolving the in-cluster and the external name
+// with the cluster DNS
+func dnsCheckPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kubeone-e2e-dns-check-" + rand.String(5),
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:  "dns-check",
+					Image: dnsCheckImage,
+					Command: []string{
+						"sh", "-c",
+						fmt.Sprintf("nslookup %s && nslookup %s", dnsCheckInternalName, dnsCheckExternalName),
+					},
+				},
+			},
+		},
+	}
+}
+
+// verifyClusterDNS runs the pod resolving the in-cluster and the external name
+// and waits for it to succeed. The pod is deleted afterwards, even on failure.
+func verifyClusterDNS(t *testing.T, client ctrlruntimeclient.Client, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, dnsCheckTimeout, opts...)
+
+	if err := runDNSCheckPod(t, client, dnsCheckPod(), w); err != nil {
+		return fmt.Errorf("resolving %s and %s: %w", dnsCheckInternalName, dnsCheckExternalName, err)
+	}
+
+	return nil
+}
+
+// nodeLocalDNSCheckPod returns the pod verifying it's configured to use the
+// node-local DNS cache and resolving the in-cluster name through it
+func nodeLocalDNSCheckPod() *corev1.Pod {
+	pod := dnsCheckPod()
+	pod.Name = "kubeone-e2e-node-local-dns-check-" + rand.String(5)
+
+	container := &pod.Spec.Containers[0]
+	container.TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+	container.Command = []string{
+		"sh", "-c",
+		fmt.Sprintf("grep -q '^nameserver %[1]s' /etc/resolv.conf || { cat /etc/resolv.conf; exit 1; }; nslookup %[2]s %[1]s",
+			resources.NodeLocalDNSVirtualIP, dnsCheckInternalName),
+	}
+
+	return pod
+}
+
+// verifyNodeLocalDNS waits for the node-local-dns DaemonSet to be ready on
+// every node and runs the pod resolving the in-cluster name through the
+// node-local DNS cache listener
+func verifyNodeLocalDNS(t *testing.T, client ctrlruntimeclient.Client, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, dnsCheckTimeout, opts...)
+
+	var notReady string
+	key := ctrlruntimeclient.ObjectKey{Namespace: metav1.NamespaceSystem, Name: nodeLocalDNSName}
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		nodes := corev1.NodeList{}
+		if err := client.List(context.Background(), &nodes); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		ds := appsv1.DaemonSet{}
+		if err := client.Get(context.Background(), key, &ds); err != nil {
+			t.Logf("error: %v", err)
+			notReady = err.Error()
+
+			return false, nil
+		}
+
+		notReady = daemonSetRolloutStatus(&ds)
+		if notReady == "" && int(ds.Status.NumberReady) < len(nodes.Items) {
+			notReady = fmt.Sprintf("%d/%d nodes have a ready pod", ds.Status.NumberReady, len(nodes.Items))
+		}
+
+		return notReady == "", nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: daemonset/%s is not ready on every node: %s", err, nodeLocalDNSName, notReady)
+	}
+
+	if err = runDNSCheckPod(t, client, nodeLocalDNSCheckPod(), w); err != nil {
+		return fmt.Errorf("resolving %s through %s: %w", dnsCheckInternalName, resources.NodeLocalDNSVirtualIP, err)
+	}
+
+	return nil
+}
+
+// runDNSCheckPod runs the pod and waits for it to succeed. The pod is deleted
+// afterwards, even on failure.
+func runDNSCheckPod(t *testing.T, client ctrlruntimeclient.Client, pod *corev1.Pod, w *pollConfig) error {
+	if err := client.Create(context.Background(), pod); err != nil {
+		return fmt.Errorf("creating dns check pod: %w", err)
+	}
+
+	defer func() {
+		if err := client.Delete(context.Background(), pod); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			t.Logf("failed to delete dns check pod %s: %v", pod.Name, err)
+		}
+	}()
+
+	key := ctrlruntimeclient.ObjectKeyFromObject(pod)
+
+	return wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		if err := client.Get(context.Background(), key, pod); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			return true, nil
+		case corev1.PodFailed:
+			return false, fmt.Errorf("dns check pod %s failed: %s", pod.Name, podTerminationMessage(pod))
+		}
+
+		return false, nil
+	})
+}
+
+// podTerminationMessage describes the state of the pod's first container
+func podTerminationMessage(pod *corev1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if msg := containerStateMessage(cs.State); msg != "" {
+			return msg
+		}
+	}
+
+	return string(pod.Status.Phase)
+}
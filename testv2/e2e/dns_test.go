@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVerifyClusterDNS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		status         corev1.PodStatus
+		expectedErrMsg string
+	}{
+		{
+			name:   "dns resolves",
+			status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+		{
+			name: "dns broken",
+			status: corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "dns-check",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+						},
+					},
+				},
+			},
+			expectedErrMsg: "failed: terminated: Error (exit code 1)",
+		},
+		{
+			name:           "pod never completes",
+			status:         corev1.PodStatus{Phase: corev1.PodRunning},
+			expectedErrMsg: "timed out waiting for the condition",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().Build()
+
+			// act as kubelet, setting the status of the created pod
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				for ctx.Err() == nil {
+					pods := corev1.PodList{}
+					if err := c.List(ctx, &pods, client.InNamespace(metav1.NamespaceDefault)); err == nil {
+						for i := range pods.Items {
+							pods.Items[i].Status = tt.status
+							_ = c.Status().Update(ctx, &pods.Items[i])
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			}()
+
+			err := verifyClusterDNS(t, c,
+				withInterval(10*time.Millisecond),
+				withTimeout(200*time.Millisecond),
+			)
+			cancel()
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("verifyClusterDNS() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("verifyClusterDNS() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+
+			pods := corev1.PodList{}
+			if err = c.List(context.Background(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			if len(pods.Items) != 0 {
+				t.Errorf("dns check pod was not deleted")
+			}
+		})
+	}
+}
+
+func TestVerifyNodeLocalDNS(t *testing.T) {
+	t.Parallel()
+
+	nodes := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cp-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+	}
+
+	daemonSet := func(desired, ready int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeLocalDNSName, Namespace: metav1.NamespaceSystem},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				UpdatedNumberScheduled: desired,
+				NumberAvailable:        ready,
+				NumberReady:            ready,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		daemonSet      *appsv1.DaemonSet
+		podPhase       corev1.PodPhase
+		expectedErrMsg string
+		expectPod      bool
+	}{
+		{
+			name:      "ready on every node and resolving",
+			daemonSet: daemonSet(2, 2),
+			podPhase:  corev1.PodSucceeded,
+			expectPod: true,
+		},
+		{
+			name:           "daemonset missing",
+			expectedErrMsg: `daemonset/node-local-dns is not ready on every node: daemonsets.apps "node-local-dns" not found`,
+		},
+		{
+			name:           "not ready on every node",
+			daemonSet:      daemonSet(2, 1),
+			expectedErrMsg: "daemonset/node-local-dns is not ready on every node: 1/2 pods available",
+		},
+		{
+			name:           "not scheduled on every node",
+			daemonSet:      daemonSet(1, 1),
+			expectedErrMsg: "daemonset/node-local-dns is not ready on every node: 1/2 nodes have a ready pod",
+		},
+		{
+			name:           "not resolving through the listener",
+			daemonSet:      daemonSet(2, 2),
+			podPhase:       corev1.PodFailed,
+			expectedErrMsg: "resolving kubernetes.default through 169.254.20.10: dns check pod",
+			expectPod:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			objects := append([]client.Object{}, nodes...)
+			if tt.daemonSet != nil {
+				objects = append(objects, tt.daemonSet)
+			}
+			c := fake.NewClientBuilder().WithObjects(objects...).Build()
+
+			// act as kubelet, setting the phase of the created pod
+			var sawPod int32
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				for ctx.Err() == nil {
+					pods := corev1.PodList{}
+					if err := c.List(ctx, &pods, client.InNamespace(metav1.NamespaceDefault)); err == nil {
+						for i := range pods.Items {
+							if !strings.Contains(strings.Join(pods.Items[i].Spec.Containers[0].Command, " "), "nslookup kubernetes.default 169.254.20.10") {
+								t.Errorf("pod %s doesn't resolve through the node-local listener", pods.Items[i].Name)
+							}
+
+							atomic.StoreInt32(&sawPod, 1)
+							pods.Items[i].Status.Phase = tt.podPhase
+							_ = c.Status().Update(ctx, &pods.Items[i])
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			}()
+
+			err := verifyNodeLocalDNS(t, c,
+				withInterval(10*time.Millisecond),
+				withTimeout(200*time.Millisecond),
+			)
+			cancel()
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("verifyNodeLocalDNS() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("verifyNodeLocalDNS() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+
+			if (atomic.LoadInt32(&sawPod) == 1) != tt.expectPod {
+				t.Errorf("dns check pod created = %v, expected %v", atomic.LoadInt32(&sawPod) == 1, tt.expectPod)
+			}
+
+			pods := corev1.PodList{}
+			if err = c.List(context.Background(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			if len(pods.Items) != 0 {
+				t.Errorf("dns check pod was not deleted")
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	encryptionCheckPrefix = "kubeone-e2e-encryption-check-"
+	encryptionCheckValue  = "kubeone-e2e-plaintext-secret-value"
+
+	// encryptedValuePrefix prefixes the values stored in etcd by the
+	// encryption providers, e.g. k8s:enc:aescbc:v1:<key name>:<ciphertext>
+	encryptedValuePrefix = "k8s:enc:"
+	etcdRegistryPrefix   = "/registry"
+	etcdPKIDir           = "/etc/kubernetes/pki/etcd"
+)
+
+// podExecFunc runs the command in the container of the pod, returning the
+// stdout and the stderr of the command
+type podExecFunc func(namespace, pod, container string, command []string) ([]byte, []byte, error)
+
+// newPodExec returns podExecFunc executing the commands through the API server
+func newPodExec(restConfig *rest.Config) (podExecFunc, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(namespace, pod, container string, command []string) ([]byte, []byte, error) {
+		req := clientset.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(namespace).
+			Name(pod).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: container,
+				Command:   command,
+				Stdout:    true,
+				Stderr:    true,
+			}, scheme.ParameterCodec)
+
+		executor, err := remotecommand.NewSPDYExecutor(restConfig, http.MethodPost, req.URL())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var stdout, stderr bytes.Buffer
+		err = executor.Stream(remotecommand.StreamOptions{
+			Stdout: &stdout,
+			Stderr: &stderr,
+		})
+
+		return stdout.Bytes(), stderr.Bytes(), err
+	}, nil
+}
+
+// encryptionProvidersEnabled reports whether the cluster is configured to
+// encrypt the secrets at rest
+func encryptionProvidersEnabled(cluster *kubeoneapi.KubeOneCluster) bool {
+	ep := cluster.Features.EncryptionProviders
+
+	return ep != nil && ep.Enable
+}
+
+// verifySecretsEncrypted creates the Secret and reads its value stored in
+// etcd with etcdctl in the etcd pod, verifying the value is encrypted by the
+// encryption provider instead of stored in plaintext. The Secret is deleted
+// afterwards.
+func verifySecretsEncrypted(t *testing.T, client ctrlruntimeclient.Client, exec podExecFunc) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      encryptionCheckPrefix + rand.String(5),
+			Namespace: metav1.NamespaceDefault,
+		},
+		StringData: map[string]string{"value": encryptionCheckValue},
+	}
+
+	if err := client.Create(context.Background(), secret); err != nil {
+		return fmt.Errorf("creating secret: %w", err)
+	}
+
+	defer func() {
+		if err := client.Delete(context.Background(), secret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			t.Logf("failed to delete secret %s: %v", secret.Name, err)
+		}
+	}()
+
+	etcdPod, err := runningEtcdPod(client)
+	if err != nil {
+		return err
+	}
+
+	key := path.Join(etcdRegistryPrefix, "secrets", secret.Namespace, secret.Name)
+	stdout, stderr, err := exec(etcdPod.Namespace, etcdPod.Name, "etcd", etcdctlGetCommand(key))
+	if err != nil {
+		return fmt.Errorf("reading %s from etcd in pod %s: %w: %s", key, etcdPod.Name, err, stderr)
+	}
+
+	value := strings.TrimSpace(string(stdout))
+	switch {
+	case value == "":
+		return fmt.Errorf("%s not found in etcd", key)
+	case strings.Contains(value, encryptionCheckValue):
+		return fmt.Errorf("secret %s is stored in etcd in plaintext", secret.Name)
+	case !strings.HasPrefix(value, encryptedValuePrefix):
+		return fmt.Errorf("secret %s is not encrypted in etcd, the value is not prefixed with %q", secret.Name, encryptedValuePrefix)
+	}
+
+	t.Logf("secret %s is encrypted in etcd by %s provider", secret.Name, encryptionProviderName(value))
+
+	return nil
+}
+
+func runningEtcdPod(client ctrlruntimeclient.Client) (*corev1.Pod, error) {
+	pods := corev1.PodList{}
+	listOpts := ctrlruntimeclient.ListOptions{
+		Namespace:     metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(map[string]string{"component": "etcd"}),
+	}
+
+	if err := client.List(context.Background(), &pods, &listOpts); err != nil {
+		return nil, fmt.Errorf("unable to list etcd pods: %w", err)
+	}
+
+	for i, p := range pods.Items {
+		if p.Status.Phase == corev1.PodRunning {
+			return &pods.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no running etcd pod found")
+}
+
+// etcdctlGetCommand returns the etcdctl command printing the raw value of the
+// key, authenticated with the etcd healthcheck client certificate
+func etcdctlGetCommand(key string) []string {
+	return []string{
+		"etcdctl",
+		"--endpoints=https://127.0.0.1:2379",
+		"--cacert=" + path.Join(etcdPKIDir, "ca.crt"),
+		"--cert=" + path.Join(etcdPKIDir, "healthcheck-client.crt"),
+		"--key=" + path.Join(etcdPKIDir, "healthcheck-client.key"),
+		"get", key,
+		"--print-value-only",
+	}
+}
+
+// encryptionProviderName returns the provider name from the encrypted value,
+// e.g. aescbc from k8s:enc:aescbc:v1:key:<ciphertext>
+func encryptionProviderName(value string) string {
+	provider := strings.TrimPrefix(value, encryptedValuePrefix)
+	if i := strings.Index(provider, ":"); i >= 0 {
+		return provider[:i]
+	}
+
+	return "unknown"
+}
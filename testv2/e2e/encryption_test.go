@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVerifySecretsEncrypted(t *testing.T) {
+	t.Parallel()
+
+	etcdPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etcd-cp-1",
+			Namespace: metav1.NamespaceSystem,
+			Labels:    map[string]string{"component": "etcd"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		stored         func(c client.Client) string
+		execErr        error
+		expectedErrMsg string
+	}{
+		{
+			name:    "encrypted",
+			objects: []client.Object{etcdPod},
+			stored: func(client.Client) string {
+				return "k8s:enc:aescbc:v1:kubeone-0:\x8f\x01\xa3ciphertext"
+			},
+		},
+		{
+			name:    "plaintext",
+			objects: []client.Object{etcdPod},
+			stored: func(client.Client) string {
+				return "k8s\x00\n\x0c\n\x02v1\x12\x06Secret" + encryptionCheckValue
+			},
+			expectedErrMsg: "is stored in etcd in plaintext",
+		},
+		{
+			name:    "identity provider",
+			objects: []client.Object{etcdPod},
+			stored: func(client.Client) string {
+				return "k8s\x00\n\x0c\n\x02v1\x12\x06Secret"
+			},
+			expectedErrMsg: `is not encrypted in etcd, the value is not prefixed with "k8s:enc:"`,
+		},
+		{
+			name:           "key not found",
+			objects:        []client.Object{etcdPod},
+			stored:         func(client.Client) string { return "" },
+			expectedErrMsg: "not found in etcd",
+		},
+		{
+			name:           "etcdctl fails",
+			objects:        []client.Object{etcdPod},
+			execErr:        errors.New("command terminated with exit code 1"),
+			expectedErrMsg: "command terminated with exit code 1: etcdctl error",
+		},
+		{
+			name:           "no etcd pod",
+			expectedErrMsg: "no running etcd pod found",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().WithObjects(tt.objects...).Build()
+
+			exec := func(namespace, pod, container string, command []string) ([]byte, []byte, error) {
+				if namespace != metav1.NamespaceSystem || pod != "etcd-cp-1" || container != "etcd" {
+					t.Errorf("exec in %s/%s container %s, expected kube-system/etcd-cp-1 container etcd", namespace, pod, container)
+				}
+
+				secrets := corev1.SecretList{}
+				if err := c.List(context.Background(), &secrets); err != nil || len(secrets.Items) != 1 {
+					t.Fatalf("expected the secret to exist, got %v: %v", secrets.Items, err)
+				}
+
+				key := "/registry/secrets/default/" + secrets.Items[0].Name
+				if !strings.Contains(strings.Join(command, " "), "get "+key+" ") {
+					t.Errorf("command %q doesn't read the key %s", command, key)
+				}
+
+				if tt.execErr != nil {
+					return nil, []byte("etcdctl error"), tt.execErr
+				}
+
+				return []byte(tt.stored(c) + "\n"), nil, nil
+			}
+
+			err := verifySecretsEncrypted(t, c, exec)
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("verifySecretsEncrypted() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("verifySecretsEncrypted() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+
+			secrets := corev1.SecretList{}
+			if err = c.List(context.Background(), &secrets); err != nil {
+				t.Fatal(err)
+			}
+			if len(secrets.Items) != 0 {
+				t.Errorf("encryption check secret was not deleted")
+			}
+		})
+	}
+}
+
+func TestEncryptionProviderName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"k8s:enc:aescbc:v1:kubeone-0:data": "aescbc",
+		"k8s:enc:kms:v1:vault:data":        "kms",
+		"k8s:enc:":                         "unknown",
+	}
+
+	for value, want := range tests {
+		if got := encryptionProviderName(value); got != want {
+			t.Errorf("encryptionProviderName(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
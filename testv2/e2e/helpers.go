@@ -20,13 +20,22 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"text/template"
 	"time"
@@ -35,18 +44,27 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/test/e2e/testutil"
 
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/retry"
-	k8spath "k8s.io/utils/path"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -54,6 +72,11 @@ const (
 	prowImage             = "kubermatic/kubeone-e2e:v0.1.22"
 	k1CloneURI            = "ssh://git@github.com/kubermatic/kubeone.git"
 	kubeoneDistPath       = "../../dist/kubeone"
+
+	// prowImageEnv and k1CloneURIEnv override prowImage and k1CloneURI in
+	// the generated ProwJobs, e.g. for forks and staging Prow instances
+	prowImageEnv  = "KUBEONE_E2E_IMAGE"
+	k1CloneURIEnv = "KUBEONE_CLONE_URI"
 )
 
 func titleize(s string) string {
@@ -64,13 +87,54 @@ func titleize(s string) string {
 	return strings.ReplaceAll(s, " ", "")
 }
 
-func clusterName() string {
-	name, found := os.LookupEnv("BUILD_ID")
-	if !found {
-		name = rand.String(10)
+// maxClusterNameLength keeps the names of the cloud resources derived from the
+// cluster name within provider limits, e.g. "<cluster name>-api-lb" AWS load
+// balancer name must not be longer than 32 characters
+const maxClusterNameLength = 25
+
+var (
+	clusterNameInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+	multipleDashes          = regexp.MustCompile(`-{2,}`)
+)
+
+// randomClusterNameID is used instead of BUILD_ID when it's not set, it's
+// generated once so all clusterName calls within the process are stable
+var randomClusterNameID = rand.String(10)
+
+// clusterName returns the name of the e2e cluster based on the BUILD_ID
+// environment variable, or a random one if BUILD_ID is not set. A non-empty
+// seed, e.g. t.Name(), adds its short hash as a suffix, so parallel tests get
+// distinct names which are stable within the test. The result is always a
+// valid DNS-1123 label prefixed with "k1-"
+func clusterName(seed string) string {
+	const prefix = "k1-"
+
+	var suffix string
+	if seed != "" {
+		sum := sha256.Sum256([]byte(seed))
+		suffix = "-" + hex.EncodeToString(sum[:])[:6]
+	}
+
+	id := sanitizeClusterNameID(os.Getenv("BUILD_ID"), maxClusterNameLength-len(prefix)-len(suffix))
+	if id == "" {
+		id = randomClusterNameID
+	}
+
+	return prefix + id + suffix
+}
+
+// sanitizeClusterNameID lowercases the id, replaces runs of characters
+// invalid in DNS-1123 labels with a single "-" and truncates it to maxLen
+func sanitizeClusterNameID(id string, maxLen int) string {
+	id = clusterNameInvalidChars.ReplaceAllString(strings.ToLower(id), "-")
+	id = multipleDashes.ReplaceAllString(id, "-")
+	id = strings.Trim(id, "-")
+
+	if len(id) > maxLen {
+		id = strings.TrimRight(id[:maxLen], "-")
 	}
 
-	return fmt.Sprintf("k1-%s", name)
+	return id
 }
 
 func trueRetriable(error) bool {
@@ -91,168 +155,1098 @@ func requiredTemplateFunc(warn string, input interface{}) (interface{}, error) {
 		}
 	}
 
-	return input, nil
-}
+	return input, nil
+}
+
+func makeBin(args ...string) *testutil.Exec {
+	return testutil.NewExec("make",
+		testutil.WithArgs(args...),
+		testutil.WithEnv(os.Environ()),
+		testutil.InDir(filepath.Clean("../../")),
+		testutil.StdoutDebug,
+	)
+}
+
+const (
+	kubeoneReleasesURL = "https://github.com/kubermatic/kubeone/releases/download"
+
+	// kubeoneDownloadBaseURLEnv overrides kubeoneReleasesURL, e.g. to download
+	// the releases from a mirror in the air-gapped environment. The mirror must
+	// keep the same path structure, i.e. <base URL>/v<version>/<file>.
+	kubeoneDownloadBaseURLEnv = "KUBEONE_DOWNLOAD_BASE_URL"
+)
+
+// kubeoneDownloadBaseURL returns the base URL of the kubeone releases
+func kubeoneDownloadBaseURL() string {
+	if baseURL := os.Getenv(kubeoneDownloadBaseURLEnv); baseURL != "" {
+		return strings.TrimRight(baseURL, "/")
+	}
+
+	return kubeoneReleasesURL
+}
+
+// kubeoneArchiveName returns the name of the kubeone linux release archive for
+// the given version and architecture
+func kubeoneArchiveName(version, arch string) (string, error) {
+	switch arch {
+	case "amd64", "arm64":
+	default:
+		return "", fmt.Errorf("kubeone is not released for the linux/%s platform", arch)
+	}
+
+	return fmt.Sprintf("kubeone_%s_linux_%s.zip", version, arch), nil
+}
+
+// kubeoneReleaseURL returns the URL of the given file of the kubeone release
+func kubeoneReleaseURL(baseURL, version, file string) string {
+	if baseURL == "" {
+		baseURL = kubeoneReleasesURL
+	}
+
+	return fmt.Sprintf("%s/v%s/%s", baseURL, version, file)
+}
+
+// kubeoneChecksumsName returns the name of the file with the SHA-256
+// checksums of all archives of the kubeone release
+func kubeoneChecksumsName(version string) string {
+	return fmt.Sprintf("kubeone_%s_checksums.txt", version)
+}
+
+// kubeoneRelease describes the kubeone release to download
+type kubeoneRelease struct {
+	version string
+	arch    string
+	// skipChecksum disables the verification of the archive checksum,
+	// e.g. when downloading from a local mirror
+	skipChecksum bool
+	// baseURL is the base URL of the releases, defaults to kubeoneReleasesURL
+	baseURL string
+}
+
+// caBundleEnv points to the PEM file with the CA certificates trusted in
+// addition to the system ones, e.g. the CA of the TLS-intercepting proxy
+const caBundleEnv = "KUBEONE_E2E_CA_BUNDLE"
+
+// readCABundle reads the CA bundle configured by caBundleEnv, nil is returned
+// when the CA bundle is not configured
+func readCABundle() ([]byte, error) {
+	path := os.Getenv(caBundleEnv)
+	if path == "" {
+		return nil, nil
+	}
+
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", path)
+	}
+
+	return bundle, nil
+}
+
+// newHTTPClient returns the HTTP client trusting the CA bundle configured by
+// caBundleEnv in addition to the system CAs, http.DefaultClient is returned
+// when the CA bundle is not configured
+func newHTTPClient() (*http.Client, error) {
+	bundle, err := readCABundle()
+	if err != nil || bundle == nil {
+		return http.DefaultClient, err
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	rootCAs.AppendCertsFromPEM(bundle)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// appendCABundle makes the REST config trust the CA bundle configured by
+// caBundleEnv in addition to the cluster CA
+func appendCABundle(restConfig *rest.Config) error {
+	bundle, err := readCABundle()
+	if err != nil || bundle == nil {
+		return err
+	}
+
+	// the CA file is ignored once the CA data is set
+	if len(restConfig.CAData) == 0 && restConfig.CAFile != "" {
+		if restConfig.CAData, err = os.ReadFile(restConfig.CAFile); err != nil {
+			return fmt.Errorf("reading cluster CA: %w", err)
+		}
+		restConfig.CAFile = ""
+	}
+
+	restConfig.CAData = append(append(restConfig.CAData, '\n'), bundle...)
+
+	return nil
+}
+
+func downloadKubeone(t *testing.T, version string) string {
+	client, err := newHTTPClient()
+	if err != nil {
+		t.Fatalf("failed to init HTTP client: %v", err)
+	}
+
+	return downloadKubeoneRelease(t, kubeoneReleases, client, kubeoneRelease{
+		version:      version,
+		arch:         runtime.GOARCH,
+		skipChecksum: *kubeoneSkipChecksumFlag,
+		baseURL:      kubeoneDownloadBaseURL(),
+	})
+}
+
+// kubeoneReleaseCache keeps the downloaded kubeone binaries per version and
+// arch, so the tests don't download the same release again
+type kubeoneReleaseCache struct {
+	dirOnce sync.Once
+	// dir defaults to a new temporary directory
+	dir    string
+	dirErr error
+
+	mu sync.Mutex
+	// locks serialize the downloads of the same version and arch
+	locks map[string]*sync.Mutex
+}
+
+// kubeoneReleases is the cache shared by all tests
+var kubeoneReleases = &kubeoneReleaseCache{}
+
+func (c *kubeoneReleaseCache) cacheDir() (string, error) {
+	c.dirOnce.Do(func() {
+		if c.dir == "" {
+			c.dir, c.dirErr = os.MkdirTemp("", "kubeone-releases-")
+		}
+	})
+
+	return c.dir, c.dirErr
+}
+
+func (c *kubeoneReleaseCache) lock(key string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.locks == nil {
+		c.locks = map[string]*sync.Mutex{}
+	}
+
+	if _, ok := c.locks[key]; !ok {
+		c.locks[key] = &sync.Mutex{}
+	}
+
+	return c.locks[key]
+}
+
+// downloadKubeoneRelease returns the path of the kubeone binary of the
+// release, downloading it only if it's not in the cache yet
+func downloadKubeoneRelease(t *testing.T, cache *kubeoneReleaseCache, client *http.Client, release kubeoneRelease) string {
+	dir, err := cache.cacheDir()
+	if err != nil {
+		t.Fatalf("creating kubeone releases cache directory: %v", err)
+	}
+
+	binName := fmt.Sprintf("kubeone-%s-%s", release.version, release.arch)
+	binPath := filepath.Join(dir, binName)
+
+	// t.Fatalf unlocks the mutex as well, it runs the deferred calls
+	mu := cache.lock(binName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err = os.Stat(binPath); err == nil {
+		return binPath
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("checking if kubeone already downloaded: %v", err)
+	}
+
+	archiveName, err := kubeoneArchiveName(release.version, release.arch)
+	if err != nil {
+		t.Fatalf("building kubeone download URL: %v", err)
+	}
+
+	archive, err := httpGetWithRetry(client, kubeoneReleaseURL(release.baseURL, release.version, archiveName), httpGetBackoff)
+	if err != nil {
+		t.Fatalf("downloading kubeone: %v", err)
+	}
+
+	if !release.skipChecksum {
+		checksums, errGet := httpGetWithRetry(client, kubeoneReleaseURL(release.baseURL, release.version, kubeoneChecksumsName(release.version)), httpGetBackoff)
+		if errGet != nil {
+			t.Fatalf("downloading kubeone checksums: %v", errGet)
+		}
+
+		if err = verifyChecksum(archive, checksums, archiveName); err != nil {
+			t.Fatalf("verifying kubeone archive: %v", err)
+		}
+	}
+
+	unzip, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("opening zip file for reading: %v", err)
+	}
+
+	unzipK1Bin, err := unzip.Open("kubeone")
+	if err != nil {
+		t.Fatalf("opening kubeone file from zip archive: %v", err)
+	}
+	defer unzipK1Bin.Close()
+
+	// the binary is extracted next to the cached one and renamed once it's
+	// complete, so the failed extraction doesn't leave the broken binary in
+	// the cache
+	k1Bin, err := os.CreateTemp(dir, binName+"-")
+	if err != nil {
+		t.Fatalf("open kubeone destination file: %v", err)
+	}
+	defer os.Remove(k1Bin.Name())
+	defer k1Bin.Close()
+
+	if _, err = io.Copy(k1Bin, unzipK1Bin); err != nil {
+		t.Fatalf("extracting kubeone from zip: %v", err)
+	}
+
+	if err = k1Bin.Chmod(0750); err != nil {
+		t.Fatalf("making kubeone executable: %v", err)
+	}
+
+	if err = os.Rename(k1Bin.Name(), binPath); err != nil {
+		t.Fatalf("moving kubeone to the cache: %v", err)
+	}
+
+	return binPath
+}
+
+// httpGetBackoff is used to retry failed kubeone release downloads, at most 5
+// attempts are made
+var httpGetBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 2 * time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// httpStatusError is returned by httpGet when the response status is not 200 OK
+type httpStatusError struct {
+	url        string
+	statusCode int
+	status     string
+}
+
+func (e *httpStatusError) Error() string {
+	if e.statusCode == http.StatusNotFound {
+		return fmt.Sprintf("GET %s: not found (%s)", e.url, e.status)
+	}
+
+	return fmt.Sprintf("GET %s: unexpected status %s", e.url, e.status)
+}
+
+// httpRetriable reports whether the httpGet error is transient, i.e. network
+// errors, server errors and rate limiting
+func httpRetriable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= http.StatusInternalServerError ||
+			statusErr.statusCode == http.StatusTooManyRequests
+	}
+
+	return true
+}
+
+// httpGetWithRetry returns the body of the given URL, retrying transient
+// errors with the given backoff. Non-transient errors, e.g. 404 Not Found, are
+// returned immediately
+func httpGetWithRetry(client *http.Client, url string, backoff wait.Backoff) ([]byte, error) {
+	var (
+		body     []byte
+		attempts int
+	)
+
+	err := retry.OnError(backoff, httpRetriable, func() error {
+		attempts++
+
+		var errGet error
+		body, errGet = httpGet(client, url)
+
+		return errGet
+	})
+	if err != nil && httpRetriable(err) {
+		return nil, fmt.Errorf("transient error persisted after %d attempts: %w", attempts, err)
+	}
+
+	return body, err
+}
+
+// httpGet returns the body of the given URL
+func httpGet(client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building http request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpStatusError{url: url, statusCode: resp.StatusCode, status: resp.Status}
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// verifyChecksum verifies the SHA-256 checksum of the archive against the
+// checksums file in the "<checksum>  <file name>" format
+func verifyChecksum(archive, checksums []byte, archiveName string) error {
+	var expected string
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == archiveName {
+			expected = fields[0]
+
+			break
+		}
+	}
+
+	if expected == "" {
+		return fmt.Errorf("checksum of %s not found", archiveName)
+	}
+
+	sum := sha256.Sum256(archive)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archiveName, expected, actual)
+	}
+
+	return nil
+}
+
+type kubeoneBinOpts func(*kubeoneBin)
+
+// withTerraformWorkspace sets the terraform workspace kubeone reads the
+// terraform output from, it defaults to the cluster name of the test
+func withTerraformWorkspace(workspace string) kubeoneBinOpts {
+	return func(kb *kubeoneBin) {
+		kb.terraformWorkspace = workspace
+	}
+}
+
+// withSSHAgentSocket sets the SSH agent socket used by kubeone
+func withSSHAgentSocket(socket string) kubeoneBinOpts {
+	return func(kb *kubeoneBin) {
+		kb.sshAgentSocket = socket
+	}
+}
+
+// withKubeoneBin sets the kubeone binary to use, an empty bin falls back to
+// the default, see resolveKubeoneBin
+func withKubeoneBin(bin string) kubeoneBinOpts {
+	return func(kb *kubeoneBin) {
+		kb.bin = bin
+	}
+}
+
+func newKubeoneBin(t *testing.T, terraformPath, manifestPath string, opts ...kubeoneBinOpts) *kubeoneBin {
+	k1 := &kubeoneBin{
+		dir:          terraformPath,
+		tfjsonPath:   ".",
+		manifestPath: manifestPath,
+	}
+
+	for _, mod := range opts {
+		mod(k1)
+	}
+
+	if k1.bin == "" {
+		k1.bin = resolveKubeoneBin()
+	}
+
+	if k1.terraformWorkspace == "" {
+		k1.terraformWorkspace = clusterName(t.Name())
+	}
+
+	if k1.sshAgentSocket == "" {
+		k1.sshAgentSocket = *sshAgentSocketFlag
+	}
+
+	if err := verifyExecutable(k1.bin); err != nil {
+		t.Fatalf("invalid kubeone binary: %v", err)
+	}
+
+	return k1
+}
+
+// kubeoneBinEnv is the environment variable pointing to the kubeone binary to
+// test, e.g. one already built outside of the repository
+const kubeoneBinEnv = "KUBEONE_BIN"
+
+// resolveKubeoneBin returns the kubeone binary set by the KUBEONE_BIN
+// environment variable, falling back to the binary built in the dist directory
+func resolveKubeoneBin() string {
+	if bin := os.Getenv(kubeoneBinEnv); bin != "" {
+		return bin
+	}
+
+	return filepath.Clean(kubeoneDistPath)
+}
+
+// verifyExecutable verifies that the path is an executable regular file
+func verifyExecutable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%s (set %s to use a different binary): %w", path, kubeoneBinEnv, err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", path)
+	}
+
+	if info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%s is not executable", path)
+	}
+
+	return nil
+}
+
+// manifestData is the data used to render the manifest templates, fields which
+// are not known are rendered as empty strings
+type manifestData struct {
+	VERSION             string
+	PROVIDER            string
+	REGION              string
+	INSTANCE_TYPE       string //nolint:revive,stylecheck
+	SSH_PUBLIC_KEY_FILE string //nolint:revive,stylecheck
+	OPERATING_SYSTEM    string //nolint:revive,stylecheck
+}
+
+// providerTerraformVars are the names of the terraform variables holding the
+// region and the control plane instance type of each provider. Providers
+// without such variables are not listed, their REGION and INSTANCE_TYPE are
+// rendered as empty strings.
+var providerTerraformVars = map[string]struct {
+	region       string
+	instanceType string
+}{
+	"aws":          {region: "aws_region", instanceType: "control_plane_type"},
+	"azure":        {region: "location", instanceType: "control_plane_vm_size"},
+	"digitalocean": {region: "region", instanceType: "control_plane_size"},
+	"equinixmetal": {region: "facility", instanceType: "device_type"},
+	"gce":          {region: "region", instanceType: "control_plane_type"},
+	"hetzner":      {region: "datacenter", instanceType: "control_plane_type"},
+	"openstack":    {instanceType: "control_plane_flavor"},
+}
+
+// manifestData returns the manifest templates data for the given Kubernetes
+// version, filling the rest of the fields from the infrastructure terraform
+func (infra Infra) manifestData(version string) manifestData {
+	provider := filepath.Base(infra.terraform.path)
+	data := manifestData{
+		VERSION:             version,
+		PROVIDER:            provider,
+		SSH_PUBLIC_KEY_FILE: infra.terraform.varValue("ssh_public_key_file"),
+		OPERATING_SYSTEM:    infra.terraform.varValue("os"),
+	}
+
+	if vars, ok := providerTerraformVars[provider]; ok {
+		if vars.region != "" {
+			data.REGION = infra.terraform.varValue(vars.region)
+		}
+		data.INSTANCE_TYPE = infra.terraform.varValue(vars.instanceType)
+	}
+
+	return data
+}
+
+// renderManifestFile reads the template from the templatePath file and renders
+// it to the temporary manifest file, returning path to the rendered manifest
+func renderManifestFile(t *testing.T, templatePath string, data manifestData) string {
+	tplSource, err := os.ReadFile(templatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return renderManifestString(t, string(tplSource), data)
+}
+
+// renderManifestString renders the inline template to the temporary manifest
+// file, returning path to the rendered manifest
+func renderManifestString(t *testing.T, tplSource string, data manifestData) string {
+	return writeManifest(t, renderManifestTemplate(t, tplSource, data))
+}
+
+// renderManifestLayered renders the base template and the overlay templates
+// with the same data and merges them in the given order into the temporary
+// manifest file, returning path to the rendered manifest. Maps are merged
+// recursively, any other value of the overlay (including lists) replaces the
+// value of the base.
+func renderManifestLayered(t *testing.T, basePath string, overlayPaths []string, data manifestData) string {
+	merged := map[string]interface{}{}
+
+	for _, path := range append([]string{basePath}, overlayPaths...) {
+		tplSource, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		layer := map[string]interface{}{}
+		if err = yaml.Unmarshal(renderManifestTemplate(t, string(tplSource), data), &layer); err != nil {
+			t.Fatalf("parsing rendered manifest %s: %v", path, err)
+		}
+
+		mergeManifestLayer(merged, layer)
+	}
+
+	buf, err := yaml.Marshal(merged)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return writeManifest(t, buf)
+}
+
+// mergeManifestLayer merges the overlay into the base, see
+// renderManifestLayered for the merge semantics
+func mergeManifestLayer(base, overlay map[string]interface{}) {
+	for key, overlayValue := range overlay {
+		baseMap, baseIsMap := base[key].(map[string]interface{})
+		overlayMap, overlayIsMap := overlayValue.(map[string]interface{})
+
+		if baseIsMap && overlayIsMap {
+			mergeManifestLayer(baseMap, overlayMap)
+
+			continue
+		}
+
+		base[key] = overlayValue
+	}
+}
+
+func renderManifestTemplate(t *testing.T, tplSource string, data manifestData) []byte {
+	var buf bytes.Buffer
+
+	tpl, err := template.New("").Funcs(manifestTemplateFuncs()).Parse(tplSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = tpl.Execute(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// writeManifest writes the manifest to the temporary file, returning its path
+func writeManifest(t *testing.T, manifest []byte) string {
+	f, err := os.CreateTemp(t.TempDir(), "kubeone-*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	manifestPath := f.Name()
+	if err := os.WriteFile(manifestPath, manifest, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return manifestPath
+}
+
+// manifestTemplateFuncs returns functions available in the manifest templates:
+//   - required MSG VALUE: fails rendering with MSG when VALUE is nil or empty string
+//   - default DEFAULT VALUE: returns DEFAULT when VALUE is nil or empty string
+//   - env NAME: returns value of the NAME environment variable
+//   - lower, upper and trim: strings.ToLower, strings.ToUpper and strings.TrimSpace
+//   - semverMajorMinor VERSION: returns MAJOR.MINOR part of the semver VERSION
+func manifestTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"required":         requiredTemplateFunc,
+		"default":          defaultTemplateFunc,
+		"env":              os.Getenv,
+		"lower":            strings.ToLower,
+		"upper":            strings.ToUpper,
+		"trim":             strings.TrimSpace,
+		"semverMajorMinor": semverMajorMinorTemplateFunc,
+	}
+}
+
+func defaultTemplateFunc(def interface{}, input interface{}) interface{} {
+	switch val := input.(type) {
+	case nil:
+		return def
+	case string:
+		if val == "" {
+			return def
+		}
+	}
+
+	return input
+}
+
+func semverMajorMinorTemplateFunc(version string) (string, error) {
+	ver, err := semver.NewVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d.%d", ver.Major(), ver.Minor()), nil
+}
+
+const (
+	defaultNodesReadyInterval = 5 * time.Second
+	defaultNodesReadyTimeout  = 10 * time.Minute
+)
+
+type pollConfig struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+type pollOpts func(*pollConfig)
+
+// newPollConfig returns the poll config with the given default interval and
+// timeout, overridden by the opts
+func newPollConfig(interval, timeout time.Duration, opts ...pollOpts) *pollConfig {
+	w := &pollConfig{
+		interval: interval,
+		timeout:  timeout,
+	}
+
+	for _, mod := range opts {
+		mod(w)
+	}
+
+	return w
+}
+
+// withTimeout overrides how long the poll waits for the condition
+func withTimeout(timeout time.Duration) pollOpts {
+	return func(w *pollConfig) {
+		w.timeout = timeout
+	}
+}
+
+// withInterval overrides how often the poll checks the condition
+func withInterval(interval time.Duration) pollOpts {
+	return func(w *pollConfig) {
+		w.interval = interval
+	}
+}
+
+func waitForNodesReady(t *testing.T, client ctrlruntimeclient.Client, expectedNumberOfNodes int, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, defaultNodesReadyTimeout, opts...)
+
+	return wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		nodes := corev1.NodeList{}
+
+		if err := client.List(context.Background(), &nodes); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		if len(nodes.Items) != expectedNumberOfNodes {
+			return false, nil
+		}
+
+		for _, n := range nodes.Items {
+			for _, c := range n.Status.Conditions {
+				if c.Type == corev1.NodeReady && c.Status != corev1.ConditionTrue {
+					return false, nil
+				}
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// waitForMachineDeploymentsReady waits for all MachineDeployments in the
+// kube-system namespace to have all replicas ready. Nodes can be Ready while
+// some Machines are stuck, so the error messages of the Machines are included
+// in the returned error to make the failure debuggable from the test logs.
+func waitForMachineDeploymentsReady(t *testing.T, client ctrlruntimeclient.Client, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, defaultNodesReadyTimeout, opts...)
+
+	var notReady []string
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		machineDeployments := clusterv1alpha1.MachineDeploymentList{}
+
+		if err := client.List(context.Background(), &machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		notReady = nil
+		for _, md := range machineDeployments.Items {
+			replicas := int32(1)
+			if md.Spec.Replicas != nil {
+				replicas = *md.Spec.Replicas
+			}
+
+			if md.Status.ReadyReplicas != replicas {
+				notReady = append(notReady, fmt.Sprintf("%s (%d/%d ready)", md.Name, md.Status.ReadyReplicas, replicas))
+			}
+		}
+
+		return len(notReady) == 0, nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	msg := fmt.Sprintf("MachineDeployments not ready: %s", strings.Join(notReady, ", "))
+	if machineErrors := machineErrorMessages(client); len(machineErrors) > 0 {
+		msg = fmt.Sprintf("%s\nMachine errors:\n%s", msg, strings.Join(machineErrors, "\n"))
+	}
+
+	return fmt.Errorf("%w: %s", err, msg)
+}
+
+// machineErrorMessages returns the error messages of the Machines in the
+// kube-system namespace
+func machineErrorMessages(client ctrlruntimeclient.Client) []string {
+	machines := clusterv1alpha1.MachineList{}
+	if err := client.List(context.Background(), &machines, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return []string{fmt.Sprintf("failed to list machines: %v", err)}
+	}
+
+	var messages []string
+	for _, m := range machines.Items {
+		if m.Status.ErrorMessage != nil && *m.Status.ErrorMessage != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", m.Name, *m.Status.ErrorMessage))
+		}
+	}
+
+	return messages
+}
+
+// newClientScheme returns the scheme of the client used to verify the
+// cluster, including the machine-controller types
+func newClientScheme() (*kruntime.Scheme, error) {
+	clientScheme := kruntime.NewScheme()
+
+	if err := scheme.AddToScheme(clientScheme); err != nil {
+		return nil, err
+	}
+
+	if err := clusterv1alpha1.AddToScheme(clientScheme); err != nil {
+		return nil, err
+	}
+
+	return clientScheme, nil
+}
+
+// verifyWorkerNodeVersions verifies kubelet versions of the worker nodes, i.e.
+// the nodes without the control plane label. Up to allowedOutdated workers
+// can still run an older kubelet, to tolerate MachineDeployments which are
+// in the middle of the rotation.
+func verifyWorkerNodeVersions(client ctrlruntimeclient.Client, targetVersion string, allowedOutdated int) error {
+	reqVer, err := semver.NewVersion(targetVersion)
+	if err != nil {
+		return fmt.Errorf("desired version is invalid: %w", err)
+	}
+
+	notControlPlane, err := labels.NewRequirement(labelControlPlaneNode, selection.DoesNotExist, nil)
+	if err != nil {
+		return err
+	}
+
+	nodes := corev1.NodeList{}
+	nodeListOpts := ctrlruntimeclient.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*notControlPlane),
+	}
+
+	if err = client.List(context.Background(), &nodes, &nodeListOpts); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var outdated []string
+	for _, n := range nodes.Items {
+		kubeletVer, errSemver := semver.NewVersion(n.Status.NodeInfo.KubeletVersion)
+		if errSemver != nil {
+			return errSemver
+		}
+
+		switch {
+		case kubeletVer.GreaterThan(reqVer):
+			return fmt.Errorf("worker %s kubelet version mismatch: expected %v, got %v", n.Name, reqVer.String(), kubeletVer.String())
+		case kubeletVer.LessThan(reqVer):
+			outdated = append(outdated, fmt.Sprintf("%s (%v)", n.Name, kubeletVer.String()))
+		}
+	}
+
+	if len(outdated) > allowedOutdated {
+		return fmt.Errorf("%d workers are not running kubelet %v, at most %d allowed: %s",
+			len(outdated), reqVer.String(), allowedOutdated, strings.Join(outdated, ", "))
+	}
 
-func makeBin(args ...string) *testutil.Exec {
-	return testutil.NewExec("make",
-		testutil.WithArgs(args...),
-		testutil.WithEnv(os.Environ()),
-		testutil.InDir(filepath.Clean("../../")),
-		testutil.StdoutDebug,
-	)
+	return nil
 }
 
-func downloadKubeone(t *testing.T, version string) string {
-	binPath := filepath.Join(t.TempDir(), fmt.Sprintf("kubeone-%s", version))
-	zipPath := fmt.Sprintf("%s.zip", binPath)
+// controlPlaneTaintKeys are the keys of the taints which keep the workloads
+// off the control plane nodes
+var controlPlaneTaintKeys = sets.NewString(
+	labelControlPlaneNode,
+	"node-role.kubernetes.io/master",
+)
 
-	exists, err := k8spath.Exists(k8spath.CheckSymlinkOnly, binPath)
-	if err != nil {
-		t.Fatalf("checking if kubeone already downloaded: %v", err)
+// verifyNodeRoles verifies that exactly the control plane hosts of the
+// cluster carry the control plane label, and that the rest of the nodes don't
+// carry the control plane taints. The names of the offending nodes are
+// reported in the error.
+func verifyNodeRoles(client ctrlruntimeclient.Client, cluster *kubeoneapi.KubeOneCluster) error {
+	nodes := corev1.NodeList{}
+	if err := client.List(context.Background(), &nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
 	}
 
-	if exists {
-		return binPath
-	}
+	var controlPlane, taintedWorkers []string
+	for _, n := range nodes.Items {
+		if _, ok := n.Labels[labelControlPlaneNode]; ok {
+			controlPlane = append(controlPlane, n.Name)
 
-	const urlTemplate = "https://github.com/kubermatic/kubeone/releases/download/v%s/kubeone_%s_linux_amd64.zip"
-	downloadURL := fmt.Sprintf(urlTemplate, version, version)
+			continue
+		}
 
-	req, err := http.NewRequest("GET", downloadURL, nil)
-	if err != nil {
-		t.Fatalf("building http request to download kubeone: %v", err)
-	}
+		for _, taint := range n.Spec.Taints {
+			if controlPlaneTaintKeys.Has(taint.Key) {
+				taintedWorkers = append(taintedWorkers, n.Name)
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		t.Fatalf("http request to download kubeone: %v", err)
+				break
+			}
+		}
 	}
-	defer resp.Body.Close()
 
-	zipBin, err := os.OpenFile(zipPath, os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		t.Fatalf("open kubeone destination file: %v", err)
+	var errs []string
+	if expected := cluster.ControlPlaneCount(); len(controlPlane) != expected {
+		errs = append(errs, fmt.Sprintf("expected %d nodes with the %s label, got %d: %s",
+			expected, labelControlPlaneNode, len(controlPlane), strings.Join(controlPlane, ", ")))
 	}
-	defer zipBin.Close()
 
-	_, err = io.Copy(zipBin, resp.Body)
-	if err != nil {
-		t.Fatalf("downloading kubeone: %v", err)
+	if len(taintedWorkers) > 0 {
+		errs = append(errs, fmt.Sprintf("worker nodes with the control plane taint: %s", strings.Join(taintedWorkers, ", ")))
 	}
 
-	fi, err := zipBin.Stat()
-	if err != nil {
-		t.Fatalf("file stat: %v", err)
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
 	}
 
-	unzip, err := zip.NewReader(zipBin, fi.Size())
-	if err != nil {
-		t.Fatalf("opening zip file for reading: %v", err)
+	return nil
+}
+
+// verifyStaticWorkers verifies that every static worker host of the cluster
+// is registered as a Ready node carrying the labels and the taints configured
+// for the host. The hosts which never registered are reported in the error.
+func verifyStaticWorkers(client ctrlruntimeclient.Client, cluster *kubeoneapi.KubeOneCluster) error {
+	nodes := corev1.NodeList{}
+	if err := client.List(context.Background(), &nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
 	}
 
-	unzipK1Bin, err := unzip.Open("kubeone")
-	if err != nil {
-		t.Fatalf("opening kubeone file from zip archive: %v", err)
+	var missing, errs []string
+	for _, host := range cluster.StaticWorkers.Hosts {
+		node := findHostNode(nodes.Items, host)
+		if node == nil {
+			missing = append(missing, hostDisplayName(host))
+
+			continue
+		}
+
+		if !nodeReady(node) {
+			errs = append(errs, fmt.Sprintf("static worker node %s is not Ready", node.Name))
+		}
+
+		for _, k := range sets.StringKeySet(host.Labels).List() {
+			if got, ok := node.Labels[k]; !ok || got != host.Labels[k] {
+				errs = append(errs, fmt.Sprintf("static worker node %s is missing label %s=%s", node.Name, k, host.Labels[k]))
+			}
+		}
+
+		for _, taint := range host.Taints {
+			if !nodeHasTaint(node, taint) {
+				errs = append(errs, fmt.Sprintf("static worker node %s is missing taint %s", node.Name, taint.ToString()))
+			}
+		}
 	}
-	defer unzipK1Bin.Close()
 
-	k1Bin, err := os.OpenFile(binPath, os.O_CREATE|os.O_WRONLY, 0750)
-	if err != nil {
-		t.Fatalf("open kubeone destination file: %v", err)
+	if len(missing) > 0 {
+		errs = append([]string{fmt.Sprintf("static workers never registered as nodes: %s", strings.Join(missing, ", "))}, errs...)
 	}
-	defer k1Bin.Close()
 
-	_, err = io.Copy(k1Bin, unzipK1Bin)
-	if err != nil {
-		t.Fatalf("extracting kubeone from zip: %v", err)
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
 	}
 
-	return binPath
+	return nil
 }
 
-type kubeoneBinOpts func(*kubeoneBin)
+// findHostNode finds the node of the host by the hostname, the internal IP
+// (private address) or the external IP (public address)
+func findHostNode(nodes []corev1.Node, host kubeoneapi.HostConfig) *corev1.Node {
+	for i, node := range nodes {
+		if host.Hostname != "" && node.Name == host.Hostname {
+			return &nodes[i]
+		}
 
-func withKubeoneBin(bin string) kubeoneBinOpts {
-	return func(kb *kubeoneBin) {
-		kb.bin = bin
+		for _, addr := range node.Status.Addresses {
+			switch {
+			case addr.Type == corev1.NodeInternalIP && addr.Address != "" && addr.Address == host.PrivateAddress:
+			case addr.Type == corev1.NodeExternalIP && addr.Address != "" && addr.Address == host.PublicAddress:
+			default:
+				continue
+			}
+
+			return &nodes[i]
+		}
 	}
+
+	return nil
 }
 
-func newKubeoneBin(terraformPath, manifestPath string, opts ...kubeoneBinOpts) *kubeoneBin {
-	k1 := &kubeoneBin{
-		bin:          filepath.Clean(kubeoneDistPath),
-		dir:          terraformPath,
-		tfjsonPath:   ".",
-		manifestPath: manifestPath,
+func hostDisplayName(host kubeoneapi.HostConfig) string {
+	for _, name := range []string{host.Hostname, host.PrivateAddress, host.PublicAddress} {
+		if name != "" {
+			return name
+		}
 	}
 
-	for _, mod := range opts {
-		mod(k1)
+	return fmt.Sprintf("host #%d", host.ID)
+}
+
+func nodeHasTaint(node *corev1.Node, taint corev1.Taint) bool {
+	for _, t := range node.Spec.Taints {
+		if t.Key == taint.Key && t.Value == taint.Value && t.Effect == taint.Effect {
+			return true
+		}
 	}
 
-	return k1
+	return false
 }
 
-type manifestData struct {
-	VERSION string
-}
+func nodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
 
-func renderManifest(t *testing.T, templatePath string, data manifestData) string {
-	tmpDir := t.TempDir()
+	return false
+}
 
-	var buf bytes.Buffer
+// expectedEtcdVersions maps the Kubernetes minor version to the etcd version
+// deployed by kubeone. The versions follow the kubeadm defaults, except for
+// Kubernetes 1.22+ where kubeone pins etcd 3.5.3 to avoid the data
+// inconsistency issue in the older etcd 3.5 releases.
+var expectedEtcdVersions = map[string]string{
+	"1.20": "3.4.13",
+	"1.21": "3.4.13",
+	"1.22": "3.5.3",
+	"1.23": "3.5.3",
+	"1.24": "3.5.3",
+}
 
-	tpl, err := template.New("").Parse(templatePath)
+// verifyEtcd verifies that the etcd static pods run the etcd version pinned by
+// the etcdImageTag, or expected for the Kubernetes version when the tag is not
+// pinned, and are Running and Ready
+func verifyEtcd(client ctrlruntimeclient.Client, kubernetesVersion, etcdImageTag string) error {
+	expected, err := expectedEtcdVersion(kubernetesVersion, etcdImageTag)
 	if err != nil {
-		t.Fatal(err)
+		return err
 	}
-	tpl.Funcs(template.FuncMap{
-		"required": requiredTemplateFunc,
-	})
 
-	if err = tpl.Execute(&buf, data); err != nil {
-		t.Fatal(err)
+	pods := corev1.PodList{}
+	podsListOpts := ctrlruntimeclient.ListOptions{
+		Namespace: metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			"component": "etcd",
+		}),
 	}
 
-	manifest, err := os.CreateTemp(tmpDir, "kubeone-*.yaml")
-	if err != nil {
-		t.Fatal(err)
+	if err = client.List(context.Background(), &pods, &podsListOpts); err != nil {
+		return fmt.Errorf("unable to list etcd pods: %w", err)
 	}
-	defer manifest.Close()
 
-	manifestPath := manifest.Name()
-	if err := os.WriteFile(manifestPath, buf.Bytes(), 0600); err != nil {
-		t.Fatal(err)
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no etcd pods found")
 	}
 
-	return manifestPath
-}
+	for _, p := range pods.Items {
+		etcdVer, err := parseContainerImageVersion(p.Spec.Containers[0].Image)
+		if err != nil {
+			return fmt.Errorf("unable to parse etcd version: %w", err)
+		}
 
-func waitForNodesReady(t *testing.T, client ctrlruntimeclient.Client, expectedNumberOfNodes int) error {
-	return wait.Poll(5*time.Second, 10*time.Minute, func() (bool, error) {
-		nodes := corev1.NodeList{}
+		// etcd images are tagged as <version>-<build>, e.g. 3.5.3-0
+		if got := fmt.Sprintf("%d.%d.%d", etcdVer.Major(), etcdVer.Minor(), etcdVer.Patch()); got != expected {
+			return fmt.Errorf("etcd version mismatch in pod %s: expected %s, got %s", p.Name, expected, got)
+		}
 
-		if err := client.List(context.Background(), &nodes); err != nil {
-			t.Logf("error: %v", err)
+		if p.Status.Phase != corev1.PodRunning {
+			return fmt.Errorf("etcd pod %s is not running, phase %s", p.Name, p.Status.Phase)
+		}
 
-			return false, nil
+		if !podReady(p.Status.Conditions) {
+			return fmt.Errorf("etcd pod %s is not ready", p.Name)
 		}
+	}
 
-		if len(nodes.Items) != expectedNumberOfNodes {
-			return false, nil
+	return nil
+}
+
+// expectedEtcdVersion returns the etcd version of the pinned etcdImageTag,
+// falling back to expectedEtcdVersions for the Kubernetes version
+func expectedEtcdVersion(kubernetesVersion, etcdImageTag string) (string, error) {
+	if etcdImageTag != "" {
+		etcdVer, err := semver.NewVersion(etcdImageTag)
+		if err != nil {
+			return "", fmt.Errorf("pinned etcd image tag %q is invalid: %w", etcdImageTag, err)
 		}
 
-		for _, n := range nodes.Items {
-			for _, c := range n.Status.Conditions {
-				if c.Type == corev1.NodeReady && c.Status != corev1.ConditionTrue {
-					return false, nil
-				}
-			}
+		return fmt.Sprintf("%d.%d.%d", etcdVer.Major(), etcdVer.Minor(), etcdVer.Patch()), nil
+	}
+
+	k8sVer, err := semver.NewVersion(kubernetesVersion)
+	if err != nil {
+		return "", fmt.Errorf("kubernetes version is invalid: %w", err)
+	}
+
+	minor := fmt.Sprintf("%d.%d", k8sVer.Major(), k8sVer.Minor())
+	expected, ok := expectedEtcdVersions[minor]
+	if !ok {
+		return "", fmt.Errorf("expected etcd version for kubernetes %s is not known", minor)
+	}
+
+	return expected, nil
+}
+
+func podReady(conditions []corev1.PodCondition) bool {
+	for _, c := range conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
 		}
+	}
 
-		return true, nil
-	})
+	return false
+}
+
+// controlPlaneComponents are values of the component label of the control
+// plane static pods which versions are verified by verifyVersion
+var controlPlaneComponents = []string{
+	"kube-apiserver",
+	"kube-controller-manager",
+	"kube-scheduler",
 }
 
 func verifyVersion(client ctrlruntimeclient.Client, namespace string, targetVersion string) error {
@@ -283,39 +1277,62 @@ func verifyVersion(client ctrlruntimeclient.Client, namespace string, targetVers
 		}
 	}
 
-	apiserverPods := corev1.PodList{}
-	podsListOpts := ctrlruntimeclient.ListOptions{
-		Namespace: namespace,
-		LabelSelector: labels.SelectorFromSet(map[string]string{
-			"component": "kube-apiserver",
-		}),
-	}
-
-	if err = client.List(context.Background(), &apiserverPods, &podsListOpts); err != nil {
-		return fmt.Errorf("unable to list apiserver pods: %w", err)
-	}
+	// Control plane components version check
+	for _, component := range controlPlaneComponents {
+		pods := corev1.PodList{}
+		podsListOpts := ctrlruntimeclient.ListOptions{
+			Namespace: namespace,
+			LabelSelector: labels.SelectorFromSet(map[string]string{
+				"component": component,
+			}),
+		}
 
-	for _, p := range apiserverPods.Items {
-		apiserverVer, err := parseContainerImageVersion(p.Spec.Containers[0].Image)
-		if err != nil {
-			return fmt.Errorf("unable to parse apiserver version: %w", err)
+		if err = client.List(context.Background(), &pods, &podsListOpts); err != nil {
+			return fmt.Errorf("unable to list %s pods: %w", component, err)
 		}
 
-		if reqVer.Compare(apiserverVer) != 0 {
-			return fmt.Errorf("apiserver version mismatch: expected %v, got %v", reqVer.String(), apiserverVer.String())
+		for _, p := range pods.Items {
+			componentVer, err := parseContainerImageVersion(p.Spec.Containers[0].Image)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s version: %w", component, err)
+			}
+
+			if reqVer.Compare(componentVer) != 0 {
+				return fmt.Errorf("%s version mismatch in pod %s: expected %v, got %v", component, p.Name, reqVer.String(), componentVer.String())
+			}
 		}
 	}
 
 	return nil
 }
 
+// parseContainerImageVersion parses the version from the image tag. The image
+// can contain a registry with a port and can be pinned by a digest as well,
+// e.g. registry:5000/kube-apiserver:v1.24.2@sha256:...
 func parseContainerImageVersion(image string) (*semver.Version, error) {
-	ver := strings.Split(image, ":")
-	if len(ver) != 2 {
-		return nil, fmt.Errorf("invalid container image format: %s", image)
+	name, digest, hasDigest := strings.Cut(image, "@")
+	if hasDigest && digest == "" {
+		return nil, fmt.Errorf("invalid container image format, empty digest: %s", image)
+	}
+
+	// The tag follows the last colon after the last slash, colons before the
+	// last slash separate the registry host and port
+	tagSep := strings.LastIndex(name, ":")
+	if tagSep <= strings.LastIndex(name, "/") {
+		if hasDigest {
+			return nil, fmt.Errorf("container image %s is pinned only by digest, the version can't be parsed without a tag", image)
+		}
+
+		return nil, fmt.Errorf("container image %s has no tag", image)
+	}
+
+	tag := name[tagSep+1:]
+	ver, err := semver.NewVersion(tag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container image tag %q: %w", tag, err)
 	}
 
-	return semver.NewVersion(ver[1])
+	return ver, nil
 }
 
 type ProwJob struct {
@@ -329,18 +1346,30 @@ type ProwJob struct {
 	Spec      *corev1.PodSpec   `json:"spec"`
 }
 
+// envOrDefault returns the value of the environment variable, or the default
+// value when the variable is unset or empty
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
 func newProwJob(prowJobName string, labels map[string]string, testTitle string, settings ProwConfig) ProwJob {
 	return ProwJob{
 		Name:      prowJobName,
 		AlwaysRun: settings.AlwaysRun,
 		Optional:  settings.Optional,
 		Decorate:  true,
-		CloneURI:  k1CloneURI,
+		CloneURI:  envOrDefault(k1CloneURIEnv, k1CloneURI),
 		Labels:    labels,
 		Spec: &corev1.PodSpec{
+			NodeSelector: settings.NodeSelector,
+			Tolerations:  settings.Tolerations,
 			Containers: []corev1.Container{
 				{
-					Image:           prowImage,
+					Image:           envOrDefault(prowImageEnv, prowImage),
 					ImagePullPolicy: corev1.PullAlways,
 					Command: []string{
 						"go", "test", "-v",
@@ -348,86 +1377,292 @@ func newProwJob(prowJobName string, labels map[string]string, testTitle string,
 						"-tags", "e2e",
 						"-run", fmt.Sprintf("^%s$", testTitle),
 					},
-					Resources: corev1.ResourceRequirements{
-						Requests: corev1.ResourceList{
-							corev1.ResourceCPU: resource.MustParse("1"),
-						},
-					},
+					Resources: prowJobResources(settings),
 				},
 			},
 		},
 	}
 }
 
+// prowJobResources returns resource requirements of the ProwJob container,
+// falling back to the 1 CPU request when CPU request is not configured. The
+// default request is clamped to the CPU limit, as the request can't exceed it.
+func prowJobResources(settings ProwConfig) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1"),
+		},
+	}
+
+	switch {
+	case settings.CPURequest != nil:
+		resources.Requests[corev1.ResourceCPU] = *settings.CPURequest
+	case settings.CPULimit != nil && settings.CPULimit.Cmp(resources.Requests[corev1.ResourceCPU]) < 0:
+		resources.Requests[corev1.ResourceCPU] = *settings.CPULimit
+	}
+	if settings.MemoryRequest != nil {
+		resources.Requests[corev1.ResourceMemory] = *settings.MemoryRequest
+	}
+
+	limits := corev1.ResourceList{}
+	if settings.CPULimit != nil {
+		limits[corev1.ResourceCPU] = *settings.CPULimit
+	}
+	if settings.MemoryLimit != nil {
+		limits[corev1.ResourceMemory] = *settings.MemoryLimit
+	}
+	if len(limits) > 0 {
+		resources.Limits = limits
+	}
+
+	return resources
+}
+
 func pullProwJobName(in ...string) string {
 	return fmt.Sprintf("pull-kubeone-e2e-%s", strings.ReplaceAll(strings.Join(in, "-"), "_", "-"))
 }
 
-func basicTest(t *testing.T, k1 *kubeoneBin, data manifestData) {
-	kubeoneManifest, err := k1.Manifest()
+type basicTestConfig struct {
+	verifyWorkerVersions   bool
+	workersAllowedOutdated int
+	verifyClusterDNS       bool
+	verifyNodeLocalDNS     bool
+	verifyLoadBalancer     bool
+	verifyVolumes          bool
+	probeLoadBalancer      bool
+	rebootControlPlane     bool
+}
+
+type basicTestOpts func(*basicTestConfig)
+
+// withWorkerNodeVersions makes basicTest verify kubelet versions of the
+// worker nodes as well, see verifyWorkerNodeVersions
+func withWorkerNodeVersions(allowedOutdated int) basicTestOpts {
+	return func(cfg *basicTestConfig) {
+		cfg.verifyWorkerVersions = true
+		cfg.workersAllowedOutdated = allowedOutdated
+	}
+}
+
+// kubeClient returns the client of the cluster managed by the kubeone binary
+func kubeClient(t *testing.T, k1 *kubeoneBin) ctrlruntimeclient.Client {
+	clientScheme, err := newClientScheme()
 	if err != nil {
-		t.Fatalf("failed to get manifest API")
+		t.Fatalf("failed to build client scheme: %v", err)
 	}
 
-	numberOfNodesToWait := len(kubeoneManifest.ControlPlane.Hosts) + len(kubeoneManifest.StaticWorkers.Hosts)
-	for _, worker := range kubeoneManifest.DynamicWorkers {
-		if worker.Replicas != nil {
-			numberOfNodesToWait += *worker.Replicas
-		}
+	client, err := ctrlruntimeclient.New(kubeRESTConfig(t, k1), ctrlruntimeclient.Options{Scheme: clientScheme})
+	if err != nil {
+		t.Fatalf("failed to init dynamic client: %s", err)
 	}
 
-	var kubeconfig []byte
-	fetchKubeconfig := func() error {
-		kubeconfig, err = k1.Kubeconfig()
-		if err != nil {
-			return err
-		}
+	return client
+}
 
-		return nil
+// kubeRESTConfig returns the REST config of the cluster managed by the
+// kubeone binary
+func kubeRESTConfig(t *testing.T, k1 *kubeoneBin) *rest.Config {
+	var restConfig *rest.Config
+	buildRESTConfig := func() error {
+		var err error
+		restConfig, err = newRESTConfig(k1)
+
+		return err
+	}
+
+	if err := retryFn(buildRESTConfig); err != nil {
+		t.Fatalf("unable to build REST config: %v", err)
 	}
 
-	if err = retryFn(fetchKubeconfig); err != nil {
-		t.Fatalf("kubeone kubeconfig failed: %v", err)
+	return restConfig
+}
+
+// newRESTConfig returns the REST config of the cluster managed by the kubeone
+// binary, trusting the CA bundle configured by caBundleEnv. Unlike
+// kubeRESTConfig, it returns the error instead of failing the test, so it can
+// be used where the cluster may already be gone, e.g. in the cleanups.
+func newRESTConfig(k1 *kubeoneBin) (*rest.Config, error) {
+	kubeconfig, err := k1.Kubeconfig()
+	if err != nil {
+		return nil, fmt.Errorf("kubeone kubeconfig failed: %w", err)
 	}
 
 	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
 	if err != nil {
-		t.Fatalf("unable to build clientset from kubeconfig bytes: %v", err)
+		return nil, fmt.Errorf("unable to build REST config from kubeconfig bytes: %w", err)
+	}
+
+	if err = appendCABundle(restConfig); err != nil {
+		return nil, fmt.Errorf("unable to configure CA bundle: %w", err)
+	}
+
+	return restConfig, nil
+}
+
+// apiServerReadyz returns the function requesting the /readyz endpoint of the
+// API server, failing when the API server is unreachable or not ready
+func apiServerReadyz(restConfig *rest.Config) (func(ctx context.Context) error, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		return clientset.Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).Error()
+	}, nil
+}
+
+func basicTest(t *testing.T, k1 *kubeoneBin, data manifestData, opts ...basicTestOpts) {
+	cfg := &basicTestConfig{
+		verifyClusterDNS:   *verifyClusterDNSFlag,
+		verifyNodeLocalDNS: *verifyNodeLocalDNSFlag,
+		verifyLoadBalancer: *verifyLoadBalancerFlag,
+		verifyVolumes:      *verifyVolumesFlag,
+		probeLoadBalancer:  *probeLoadBalancerFlag,
+		rebootControlPlane: *rebootControlPlaneFlag,
+	}
+	for _, mod := range opts {
+		mod(cfg)
 	}
 
-	client, err := ctrlruntimeclient.New(restConfig, ctrlruntimeclient.Options{})
+	registerDiagnostics(t, k1)
+
+	kubeoneManifest, err := k1.Manifest()
 	if err != nil {
-		t.Fatalf("failed to init dynamic client: %s", err)
+		t.Fatalf("failed to get manifest API")
 	}
 
-	if err = waitForNodesReady(t, client, numberOfNodesToWait); err != nil {
+	client := kubeClient(t, k1)
+
+	if err = waitForNodesReady(t, client, kubeoneManifest.ExpectedNodeCount()); err != nil {
+		// tell the static workers failing to join from the dynamic workers
+		// failing to scale up, both show up as the wrong number of nodes
+		if staticErr := verifyStaticWorkers(client, kubeoneManifest); staticErr != nil {
+			t.Fatalf("failed to bring up all nodes up: %v: %v", err, staticErr)
+		}
 		t.Fatalf("failed to bring up all nodes up: %v", err)
 	}
 
+	if err = verifyStaticWorkers(client, kubeoneManifest); err != nil {
+		t.Fatalf("static workers verification failed: %v", err)
+	}
+
+	if err = verifyNodeRoles(client, kubeoneManifest); err != nil {
+		t.Fatalf("node roles mismatch: %v", err)
+	}
+
+	if len(kubeoneManifest.DynamicWorkers) > 0 {
+		if err = waitForMachineDeploymentsReady(t, client); err != nil {
+			t.Fatalf("failed to bring up all MachineDeployments: %v", err)
+		}
+	}
+
+	if err = verifyAddonsReady(t, client, defaultAddonWorkloads(kubeoneManifest)); err != nil {
+		t.Fatalf("addons failed to become ready: %v", err)
+	}
+
 	if err = verifyVersion(client, metav1.NamespaceSystem, data.VERSION); err != nil {
 		t.Fatalf("version mismatch: %v", err)
 	}
+
+	if err = verifyEtcd(client, data.VERSION, kubeoneManifest.AssetConfiguration.Etcd.ImageTag); err != nil {
+		t.Fatalf("etcd verification failed: %v", err)
+	}
+
+	if kubeProxyMode := expectedKubeProxyMode(kubeoneManifest); kubeProxyMode != "" {
+		if err = verifyKubeProxyMode(t, client, kubeProxyMode); err != nil {
+			t.Fatalf("kube-proxy verification failed: %v", err)
+		}
+	}
+
+	if encryptionProvidersEnabled(kubeoneManifest) {
+		exec, err := newPodExec(kubeRESTConfig(t, k1))
+		if err != nil {
+			t.Fatalf("failed to init pod exec: %v", err)
+		}
+
+		if err = verifySecretsEncrypted(t, client, exec); err != nil {
+			t.Fatalf("encryption at rest verification failed: %v", err)
+		}
+	}
+
+	if staticAuditLogEnabled(kubeoneManifest) {
+		if err = verifyAuditLogging(t, client, kubeoneManifest, newSSHHostExec(k1.sshAgentSocket)); err != nil {
+			t.Fatalf("audit logging verification failed: %v", err)
+		}
+	}
+
+	if cfg.verifyClusterDNS {
+		if err = verifyClusterDNS(t, client); err != nil {
+			t.Fatalf("cluster DNS is broken: %v", err)
+		}
+	}
+
+	if cfg.verifyNodeLocalDNS {
+		if err = verifyNodeLocalDNS(t, client); err != nil {
+			t.Fatalf("node-local DNS cache is broken: %v", err)
+		}
+	}
+
+	if cfg.verifyVolumes {
+		if err = verifyVolumePersistence(t, client, kubeoneManifest); err != nil {
+			t.Fatalf("volume persistence verification failed: %v", err)
+		}
+	}
+
+	if cfg.verifyLoadBalancer {
+		if err = verifyLoadBalancer(t, client, kubeoneManifest, cfg.probeLoadBalancer); err != nil {
+			t.Fatalf("LoadBalancer service verification failed: %v", err)
+		}
+	}
+
+	if cfg.verifyWorkerVersions {
+		if err = verifyWorkerNodeVersions(client, data.VERSION, cfg.workersAllowedOutdated); err != nil {
+			t.Fatalf("worker version mismatch: %v", err)
+		}
+	}
+
+	// the reboot is destructive, so it goes last
+	if cfg.rebootControlPlane {
+		rc, err := newRebootCheck(client, kubeRESTConfig(t, k1), k1.sshAgentSocket)
+		if err != nil {
+			t.Fatalf("failed to init control plane reboot check: %v", err)
+		}
+
+		if err = rc.Run(t, kubeoneManifest); err != nil {
+			t.Fatalf("cluster didn't survive control plane node reboot: %v", err)
+		}
+	}
 }
 
 func sonobuoyRun(t *testing.T, k1 *kubeoneBin, mode sonobuoyMode) {
+	registerDiagnostics(t, k1)
+
 	kubeconfigPath, err := k1.KubeconfigPath(t.TempDir())
 	if err != nil {
 		t.Fatalf("fetching kubeconfig failed")
 	}
 
 	sb := sonobuoyBin{
-		kubeconfig: kubeconfigPath,
+		kubeconfig:  kubeconfigPath,
+		e2eFocus:    *sonobuoyE2EFocusFlag,
+		e2eSkip:     *sonobuoyE2ESkipFlag,
+		e2eParallel: *sonobuoyE2EParallelFlag,
 	}
 
-	if err = sb.Run(mode); err != nil {
+	if err = sb.Run(mode, sonobuoyPluginE2E, sonobuoyPluginSystemdLogs); err != nil {
 		t.Fatalf("sonobuoy run failed: %v", err)
 	}
 
-	if err = sb.Wait(); err != nil {
+	if err = sb.Wait(t.Logf); err != nil {
 		t.Fatalf("sonobuoy wait failed: %v", err)
 	}
 
-	if err = sb.Retrieve(); err != nil {
+	// retrieving the results is flaky while the aggregator is under load
+	if err = retryFn(sb.Retrieve); err != nil {
+		if errors.Is(err, errSonobuoyNoResults) {
+			t.Fatalf("sonobuoy has no results: %v", err)
+		}
 		t.Fatalf("sonobuoy retrieve failed: %v", err)
 	}
 
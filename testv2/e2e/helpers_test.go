@@ -0,0 +1,1904 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const releasesURL = "https://github.com/kubermatic/kubeone/releases/download/v1.4.0/"
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// fakeReleaseClient returns a HTTP client serving the given files and
+// recording the requested URLs
+func fakeReleaseClient(files map[string][]byte, requestedURLs *[]string) *http.Client {
+	return &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			*requestedURLs = append(*requestedURLs, req.URL.String())
+
+			body, ok := files[req.URL.String()]
+			if !ok {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Status:     http.StatusText(http.StatusNotFound),
+					Body:       io.NopCloser(bytes.NewReader(nil)),
+				}, nil
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		}),
+	}
+}
+
+func buildKubeoneArchive(t *testing.T, content string) []byte {
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("kubeone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func checksumLine(data []byte, name string) string {
+	return fmt.Sprintf("%x  %s\n", sha256.Sum256(data), name)
+}
+
+func TestDownloadKubeoneRelease(t *testing.T) {
+	tests := []struct {
+		name         string
+		arch         string
+		skipChecksum bool
+		wantURLs     []string
+	}{
+		{
+			name: "amd64",
+			arch: "amd64",
+			wantURLs: []string{
+				releasesURL + "kubeone_1.4.0_linux_amd64.zip",
+				releasesURL + "kubeone_1.4.0_checksums.txt",
+			},
+		},
+		{
+			name: "arm64",
+			arch: "arm64",
+			wantURLs: []string{
+				releasesURL + "kubeone_1.4.0_linux_arm64.zip",
+				releasesURL + "kubeone_1.4.0_checksums.txt",
+			},
+		},
+		{
+			name:         "skip checksum",
+			arch:         "amd64",
+			skipChecksum: true,
+			wantURLs: []string{
+				releasesURL + "kubeone_1.4.0_linux_amd64.zip",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			archiveName := fmt.Sprintf("kubeone_1.4.0_linux_%s.zip", tt.arch)
+			archive := buildKubeoneArchive(t, "kubeone-"+tt.arch)
+
+			var requestedURLs []string
+			client := fakeReleaseClient(map[string][]byte{
+				releasesURL + archiveName:                   archive,
+				releasesURL + "kubeone_1.4.0_checksums.txt": []byte(checksumLine(archive, archiveName)),
+			}, &requestedURLs)
+
+			binPath := downloadKubeoneRelease(t, &kubeoneReleaseCache{dir: t.TempDir()}, client, kubeoneRelease{
+				version:      "1.4.0",
+				arch:         tt.arch,
+				skipChecksum: tt.skipChecksum,
+			})
+
+			if !reflect.DeepEqual(requestedURLs, tt.wantURLs) {
+				t.Errorf("downloadKubeoneRelease() requested %v, want %v", requestedURLs, tt.wantURLs)
+			}
+
+			got, err := os.ReadFile(binPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != "kubeone-"+tt.arch {
+				t.Errorf("downloadKubeoneRelease() extracted %q, want %q", got, "kubeone-"+tt.arch)
+			}
+		})
+	}
+}
+
+func TestDownloadKubeoneReleaseMirror(t *testing.T) {
+	const mirrorURL = "https://artifacts.internal.example.com/kubeone/releases/"
+
+	t.Setenv(kubeoneDownloadBaseURLEnv, mirrorURL)
+
+	archive := buildKubeoneArchive(t, "kubeone-mirror")
+	mirrorReleaseURL := "https://artifacts.internal.example.com/kubeone/releases/v1.4.0/"
+
+	var requestedURLs []string
+	client := fakeReleaseClient(map[string][]byte{
+		mirrorReleaseURL + "kubeone_1.4.0_linux_amd64.zip": archive,
+		mirrorReleaseURL + "kubeone_1.4.0_checksums.txt":   []byte(checksumLine(archive, "kubeone_1.4.0_linux_amd64.zip")),
+	}, &requestedURLs)
+
+	downloadKubeoneRelease(t, &kubeoneReleaseCache{dir: t.TempDir()}, client, kubeoneRelease{
+		version: "1.4.0",
+		arch:    "amd64",
+		baseURL: kubeoneDownloadBaseURL(),
+	})
+
+	wantURLs := []string{
+		mirrorReleaseURL + "kubeone_1.4.0_linux_amd64.zip",
+		mirrorReleaseURL + "kubeone_1.4.0_checksums.txt",
+	}
+	if !reflect.DeepEqual(requestedURLs, wantURLs) {
+		t.Errorf("downloadKubeoneRelease() requested %v, want %v", requestedURLs, wantURLs)
+	}
+}
+
+func TestDownloadKubeoneReleaseCached(t *testing.T) {
+	archives := map[string][]byte{
+		"amd64": buildKubeoneArchive(t, "kubeone-amd64"),
+		"arm64": buildKubeoneArchive(t, "kubeone-arm64"),
+	}
+
+	files := map[string][]byte{}
+	for arch, archive := range archives {
+		archiveName := fmt.Sprintf("kubeone_1.4.0_linux_%s.zip", arch)
+		files[releasesURL+archiveName] = archive
+	}
+
+	// the downloads of the same release are serialized by the cache, so the
+	// requested URLs are recorded by one download at the time
+	var requestedURLs []string
+	client := fakeReleaseClient(files, &requestedURLs)
+
+	cache := &kubeoneReleaseCache{dir: t.TempDir()}
+	download := func(arch string) string {
+		return downloadKubeoneRelease(t, cache, client, kubeoneRelease{
+			version:      "1.4.0",
+			arch:         arch,
+			skipChecksum: true,
+		})
+	}
+
+	// the concurrent downloads of the same release wait for the first one
+	var wg sync.WaitGroup
+	paths := make([]string, 4)
+	for i := range paths {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			paths[i] = download("amd64")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, path := range paths[1:] {
+		if path != paths[0] {
+			t.Errorf("downloadKubeoneRelease() returned %q and %q for the same release", paths[0], path)
+		}
+	}
+
+	if path := download("amd64"); path != paths[0] {
+		t.Errorf("downloadKubeoneRelease() returned %q, want the cached %q", path, paths[0])
+	}
+
+	armPath := download("arm64")
+	got, err := os.ReadFile(armPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "kubeone-arm64" {
+		t.Errorf("downloadKubeoneRelease() extracted %q, want %q", got, "kubeone-arm64")
+	}
+
+	wantURLs := []string{
+		releasesURL + "kubeone_1.4.0_linux_amd64.zip",
+		releasesURL + "kubeone_1.4.0_linux_arm64.zip",
+	}
+	if !reflect.DeepEqual(requestedURLs, wantURLs) {
+		t.Errorf("downloadKubeoneRelease() requested %v, want %v", requestedURLs, wantURLs)
+	}
+}
+
+func TestKubeoneDownloadBaseURL(t *testing.T) {
+	t.Setenv(kubeoneDownloadBaseURLEnv, "")
+	if got := kubeoneDownloadBaseURL(); got != kubeoneReleasesURL {
+		t.Errorf("kubeoneDownloadBaseURL() = %q, want %q", got, kubeoneReleasesURL)
+	}
+
+	t.Setenv(kubeoneDownloadBaseURLEnv, "http://mirror.local/kubeone/")
+	if got := kubeoneReleaseURL(kubeoneDownloadBaseURL(), "1.4.0", "kubeone_1.4.0_checksums.txt"); got != "http://mirror.local/kubeone/v1.4.0/kubeone_1.4.0_checksums.txt" {
+		t.Errorf("kubeoneReleaseURL() = %q", got)
+	}
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "kubeone")
+	}))
+	defer srv.Close()
+
+	t.Setenv(caBundleEnv, "")
+	client, err := newHTTPClient()
+	if err != nil {
+		t.Fatalf("newHTTPClient() unexpected error: %v", err)
+	}
+	if client != http.DefaultClient {
+		t.Errorf("newHTTPClient() without CA bundle should return http.DefaultClient")
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "ca-bundle.pem")
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err = os.WriteFile(bundlePath, bundle, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(caBundleEnv, bundlePath)
+	client, err = newHTTPClient()
+	if err != nil {
+		t.Fatalf("newHTTPClient() unexpected error: %v", err)
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+		t.Fatalf("newHTTPClient() transport has no root CAs configured")
+	}
+
+	if _, err = srv.Certificate().Verify(x509.VerifyOptions{Roots: transport.TLSClientConfig.RootCAs}); err != nil {
+		t.Errorf("root CAs don't contain the CA bundle certificate: %v", err)
+	}
+
+	body, err := httpGet(client, srv.URL)
+	if err != nil {
+		t.Fatalf("httpGet() with CA bundle unexpected error: %v", err)
+	}
+	if string(body) != "kubeone" {
+		t.Errorf("httpGet() = %q, want %q", body, "kubeone")
+	}
+}
+
+func TestReadCABundleErrors(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca-bundle.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedErrMsg string
+	}{
+		{
+			name:           "missing file",
+			path:           filepath.Join(t.TempDir(), "missing.pem"),
+			expectedErrMsg: "reading CA bundle",
+		},
+		{
+			name:           "no certificates",
+			path:           notPEM,
+			expectedErrMsg: "no PEM certificates found in CA bundle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(caBundleEnv, tt.path)
+
+			if _, err := newHTTPClient(); err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg) {
+				t.Errorf("newHTTPClient() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+
+			if err := appendCABundle(&rest.Config{}); err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg) {
+				t.Errorf("appendCABundle() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+		})
+	}
+}
+
+// selfSignedCA returns the new self-signed CA certificate
+func selfSignedCA(t *testing.T, commonName string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func TestAppendCABundle(t *testing.T) {
+	clusterCA := selfSignedCA(t, "kubernetes")
+	proxyCA := selfSignedCA(t, "proxy")
+
+	encode := func(cert *x509.Certificate) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "ca-bundle.pem")
+	if err := os.WriteFile(bundlePath, encode(proxyCA), 0600); err != nil {
+		t.Fatal(err)
+	}
+	clusterCAPath := filepath.Join(dir, "cluster-ca.pem")
+	if err := os.WriteFile(clusterCAPath, encode(clusterCA), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(caBundleEnv, "")
+	unchanged := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAData: encode(clusterCA)}}
+	if err := appendCABundle(unchanged); err != nil {
+		t.Fatalf("appendCABundle() unexpected error: %v", err)
+	}
+	if !bytes.Equal(unchanged.CAData, encode(clusterCA)) {
+		t.Errorf("appendCABundle() without CA bundle changed the CA data")
+	}
+
+	t.Setenv(caBundleEnv, bundlePath)
+
+	for name, restConfig := range map[string]*rest.Config{
+		"CA data": {TLSClientConfig: rest.TLSClientConfig{CAData: encode(clusterCA)}},
+		"CA file": {TLSClientConfig: rest.TLSClientConfig{CAFile: clusterCAPath}},
+	} {
+		if err := appendCABundle(restConfig); err != nil {
+			t.Fatalf("%s: appendCABundle() unexpected error: %v", name, err)
+		}
+
+		if restConfig.CAFile != "" {
+			t.Errorf("%s: CA file %q would be ignored in favor of the CA data", name, restConfig.CAFile)
+		}
+
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM(restConfig.CAData)
+
+		for _, cert := range []*x509.Certificate{clusterCA, proxyCA} {
+			if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+				t.Errorf("%s: CA data doesn't contain %s: %v", name, cert.Subject, err)
+			}
+		}
+	}
+}
+
+func TestHTTPGetWithRetry(t *testing.T) {
+	backoff := wait.Backoff{Steps: 5, Duration: time.Millisecond}
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantRequests int
+		wantBody     string
+		wantErr      string
+	}{
+		{
+			name:         "succeeds after transient errors",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			wantRequests: 3,
+			wantBody:     "kubeone",
+		},
+		{
+			name:         "not found is not retried",
+			statuses:     []int{http.StatusNotFound},
+			wantRequests: 1,
+			wantErr:      "not found",
+		},
+		{
+			name:         "gives up after 5 attempts",
+			statuses:     []int{http.StatusInternalServerError},
+			wantRequests: 5,
+			wantErr:      "transient error persisted after 5 attempts",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[len(tt.statuses)-1]
+				if requests < len(tt.statuses) {
+					status = tt.statuses[requests]
+				}
+				requests++
+
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					_, _ = w.Write([]byte("kubeone"))
+				}
+			}))
+			defer srv.Close()
+
+			body, err := httpGetWithRetry(srv.Client(), srv.URL, backoff)
+
+			if requests != tt.wantRequests {
+				t.Errorf("httpGetWithRetry() made %d requests, want %d", requests, tt.wantRequests)
+			}
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("httpGetWithRetry() error = %v, want error containing %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("httpGetWithRetry() unexpected error: %v", err)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("httpGetWithRetry() = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestClusterName(t *testing.T) {
+	tests := []struct {
+		name    string
+		buildID string
+		want    string
+	}{
+		{
+			name:    "simple",
+			buildID: "1234567890",
+			want:    "k1-1234567890",
+		},
+		{
+			name:    "uppercase and slashes",
+			buildID: "KubeOne/PR/1234",
+			want:    "k1-kubeone-pr-1234",
+		},
+		{
+			name:    "repeated invalid characters",
+			buildID: "__pull--request__#42//",
+			want:    "k1-pull-request-42",
+		},
+		{
+			name:    "too long",
+			buildID: "a2f9c8d1-7b6e-4f3a-9c2d-1e0b8a7f6c5d",
+			want:    "k1-a2f9c8d1-7b6e-4f3a-9c2",
+		},
+		{
+			name:    "truncated at dash",
+			buildID: "abcdefghijklmnopqrstu-vwxyz",
+			want:    "k1-abcdefghijklmnopqrstu",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BUILD_ID", tt.buildID)
+
+			got := clusterName("")
+			if got != tt.want {
+				t.Errorf("clusterName() = %q, want %q", got, tt.want)
+			}
+			if errs := validation.IsDNS1123Label(got); len(errs) > 0 {
+				t.Errorf("clusterName() = %q is not a valid DNS-1123 label: %v", got, errs)
+			}
+		})
+	}
+}
+
+func TestClusterNameSeed(t *testing.T) {
+	for _, buildID := range []string{"", "1234567890", "a2f9c8d1-7b6e-4f3a-9c2d-1e0b8a7f6c5d"} {
+		t.Setenv("BUILD_ID", buildID)
+
+		names := map[string]string{}
+		for _, subtest := range []string{"aws", "azure"} {
+			t.Run(subtest, func(t *testing.T) {
+				name := clusterName(t.Name())
+				if again := clusterName(t.Name()); again != name {
+					t.Errorf("clusterName() is not stable: %q != %q", name, again)
+				}
+				if errs := validation.IsDNS1123Label(name); len(errs) > 0 || len(name) > maxClusterNameLength {
+					t.Errorf("clusterName() = %q is not a valid cluster name: %v", name, errs)
+				}
+
+				names[subtest] = name
+			})
+		}
+
+		if names["aws"] == names["azure"] {
+			t.Errorf("subtests with BUILD_ID=%q got the same cluster name %q", buildID, names["aws"])
+		}
+	}
+}
+
+func TestClusterNameRandom(t *testing.T) {
+	for _, buildID := range []string{"", "///", "___"} {
+		t.Setenv("BUILD_ID", buildID)
+
+		got := clusterName("")
+		if !strings.HasPrefix(got, "k1-") || len(got) != len("k1-")+10 {
+			t.Errorf("clusterName() with BUILD_ID=%q = %q, want random name", buildID, got)
+		}
+		if errs := validation.IsDNS1123Label(got); len(errs) > 0 {
+			t.Errorf("clusterName() = %q is not a valid DNS-1123 label: %v", got, errs)
+		}
+	}
+}
+
+func TestNewKubeoneBin(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	executable := filepath.Join(tmpDir, "kubeone")
+	if err := os.WriteFile(executable, []byte("#!/bin/sh\n"), 0700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	explicit := filepath.Join(tmpDir, "kubeone-explicit")
+	if err := os.WriteFile(explicit, []byte("#!/bin/sh\n"), 0700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	t.Setenv(kubeoneBinEnv, executable)
+
+	if got := newKubeoneBin(t, "", "").bin; got != executable {
+		t.Errorf("newKubeoneBin() with %s set = %q, want %q", kubeoneBinEnv, got, executable)
+	}
+
+	if got := newKubeoneBin(t, "", "", withKubeoneBin(explicit)).bin; got != explicit {
+		t.Errorf("newKubeoneBin() withKubeoneBin = %q, want %q", got, explicit)
+	}
+}
+
+func TestVerifyExecutable(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	notExecutable := filepath.Join(tmpDir, "not-executable")
+	if err := os.WriteFile(notExecutable, []byte("kubeone"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name:    "missing",
+			path:    filepath.Join(tmpDir, "missing"),
+			wantErr: "set KUBEONE_BIN to use a different binary",
+		},
+		{
+			name:    "directory",
+			path:    tmpDir,
+			wantErr: "is not a regular file",
+		},
+		{
+			name:    "not executable",
+			path:    notExecutable,
+			wantErr: "is not executable",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyExecutable(tt.path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verifyExecutable() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKubeoneArchiveName(t *testing.T) {
+	for _, arch := range []string{"arm", "386"} {
+		if _, err := kubeoneArchiveName("1.4.0", arch); err == nil {
+			t.Errorf("kubeoneArchiveName() expected error for %s", arch)
+		}
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	const archiveName = "kubeone_1.4.0_linux_amd64.zip"
+	archive := []byte("archive")
+
+	tests := []struct {
+		name      string
+		checksums string
+		wantErr   bool
+	}{
+		{
+			name:      "matching checksum",
+			checksums: checksumLine([]byte("other"), "kubeone_1.4.0_linux_arm64.zip") + checksumLine(archive, archiveName),
+		},
+		{
+			name:      "mismatched checksum",
+			checksums: checksumLine([]byte("truncated"), archiveName),
+			wantErr:   true,
+		},
+		{
+			name:      "missing checksum",
+			checksums: checksumLine(archive, "kubeone_1.4.0_linux_arm64.zip"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyChecksum(archive, []byte(tt.checksums), archiveName); (err != nil) != tt.wantErr {
+				t.Errorf("verifyChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitForNodesReady(t *testing.T) {
+	t.Parallel()
+
+	node := func(name string, status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: status},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		nodes         []*corev1.Node
+		expectedNodes int
+		expectedErr   error
+	}{
+		{
+			name:          "all nodes ready",
+			nodes:         []*corev1.Node{node("node-1", corev1.ConditionTrue), node("node-2", corev1.ConditionTrue)},
+			expectedNodes: 2,
+		},
+		{
+			name:          "node not ready",
+			nodes:         []*corev1.Node{node("node-1", corev1.ConditionTrue), node("node-2", corev1.ConditionFalse)},
+			expectedNodes: 2,
+			expectedErr:   wait.ErrWaitTimeout,
+		},
+		{
+			name:          "nodes missing",
+			nodes:         []*corev1.Node{node("node-1", corev1.ConditionTrue)},
+			expectedNodes: 2,
+			expectedErr:   wait.ErrWaitTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder()
+			for _, n := range tt.nodes {
+				builder = builder.WithObjects(n)
+			}
+
+			err := waitForNodesReady(t, builder.Build(), tt.expectedNodes,
+				withInterval(10*time.Millisecond),
+				withTimeout(100*time.Millisecond),
+			)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("waitForNodesReady() error = %v, expected %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestWaitForMachineDeploymentsReady(t *testing.T) {
+	t.Parallel()
+
+	machineDeployment := func(name string, replicas, ready int32) *clusterv1alpha1.MachineDeployment {
+		return &clusterv1alpha1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+			Spec:       clusterv1alpha1.MachineDeploymentSpec{Replicas: pointer.Int32(replicas)},
+			Status:     clusterv1alpha1.MachineDeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+
+	stuckMachine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool-2-abcde", Namespace: metav1.NamespaceSystem},
+		Status: clusterv1alpha1.MachineStatus{
+			ErrorMessage: pointer.String("failed to create instance: quota exceeded"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		expectedErr    error
+		expectedErrMsg string
+	}{
+		{
+			name:    "all replicas ready",
+			objects: []client.Object{machineDeployment("pool-1", 2, 2), machineDeployment("pool-2", 1, 1)},
+		},
+		{
+			name:    "no MachineDeployments",
+			objects: []client.Object{},
+		},
+		{
+			name:           "replicas not ready",
+			objects:        []client.Object{machineDeployment("pool-1", 2, 2), machineDeployment("pool-2", 3, 2), stuckMachine},
+			expectedErr:    wait.ErrWaitTimeout,
+			expectedErrMsg: "pool-2-abcde: failed to create instance: quota exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientScheme, err := newClientScheme()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := fake.NewClientBuilder().WithScheme(clientScheme).WithObjects(tt.objects...).Build()
+
+			err = waitForMachineDeploymentsReady(t, c,
+				withInterval(10*time.Millisecond),
+				withTimeout(100*time.Millisecond),
+			)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("waitForMachineDeploymentsReady() error = %v, expected %v", err, tt.expectedErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.expectedErrMsg) {
+				t.Errorf("waitForMachineDeploymentsReady() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestProwJobResources(t *testing.T) {
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+
+		return &q
+	}
+
+	tests := []struct {
+		name     string
+		settings ProwConfig
+		want     corev1.ResourceRequirements
+	}{
+		{
+			name:     "default",
+			settings: ProwConfig{},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+			},
+		},
+		{
+			name: "requests and limits",
+			settings: ProwConfig{
+				CPURequest:    quantity("2"),
+				MemoryRequest: quantity("4Gi"),
+				CPULimit:      quantity("3"),
+				MemoryLimit:   quantity("6Gi"),
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3"),
+					corev1.ResourceMemory: resource.MustParse("6Gi"),
+				},
+			},
+		},
+		{
+			name: "memory only",
+			settings: ProwConfig{
+				MemoryRequest: quantity("1Gi"),
+				MemoryLimit:   quantity("2Gi"),
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		{
+			name: "cpu limit below default request",
+			settings: ProwConfig{
+				CPULimit: quantity("500m"),
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		},
+		{
+			name: "cpu limit above default request",
+			settings: ProwConfig{
+				CPULimit: quantity("2"),
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			job := newProwJob("pull-test", nil, "TestTitle", tt.settings)
+			got := job.Spec.Containers[0].Resources
+
+			if !equality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("resources = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProwJobImageAndCloneURI(t *testing.T) {
+	t.Setenv(prowImageEnv, "")
+	t.Setenv(k1CloneURIEnv, "")
+
+	job := newProwJob("pull-test", nil, "TestTitle", ProwConfig{})
+	if got := job.Spec.Containers[0].Image; got != prowImage {
+		t.Errorf("image = %q, want %q", got, prowImage)
+	}
+	if job.CloneURI != k1CloneURI {
+		t.Errorf("cloneURI = %q, want %q", job.CloneURI, k1CloneURI)
+	}
+
+	const (
+		image    = "registry.example.com/kubeone-e2e:v0.2.0"
+		cloneURI = "https://github.com/example/kubeone.git"
+	)
+	t.Setenv(prowImageEnv, image)
+	t.Setenv(k1CloneURIEnv, cloneURI)
+
+	job = newProwJob("pull-test", nil, "TestTitle", ProwConfig{})
+	if got := job.Spec.Containers[0].Image; got != image {
+		t.Errorf("image = %q, want %q", got, image)
+	}
+	if job.CloneURI != cloneURI {
+		t.Errorf("cloneURI = %q, want %q", job.CloneURI, cloneURI)
+	}
+}
+
+func TestNewProwJobScheduling(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ProwConfig
+	}{
+		{
+			name:     "default",
+			settings: ProwConfig{},
+		},
+		{
+			name: "node selector and tolerations",
+			settings: ProwConfig{
+				NodeSelector: map[string]string{
+					"kubernetes.io/arch": "arm64",
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "arm64",
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			job := newProwJob("pull-test", nil, "TestTitle", tt.settings)
+
+			if !reflect.DeepEqual(job.Spec.NodeSelector, tt.settings.NodeSelector) {
+				t.Errorf("nodeSelector = %v, want %v", job.Spec.NodeSelector, tt.settings.NodeSelector)
+			}
+			if !reflect.DeepEqual(job.Spec.Tolerations, tt.settings.Tolerations) {
+				t.Errorf("tolerations = %v, want %v", job.Spec.Tolerations, tt.settings.Tolerations)
+			}
+		})
+	}
+}
+
+func TestRenderManifestString(t *testing.T) {
+	manifestPath := renderManifestString(t,
+		`kubernetes: "{{ required "VERSION is required" .VERSION }}"`,
+		manifestData{VERSION: "v1.23.6"},
+	)
+
+	got, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `kubernetes: "v1.23.6"`; string(got) != want {
+		t.Errorf("rendered manifest = %q, want %q", got, want)
+	}
+}
+
+func TestRenderManifestTemplateFuncs(t *testing.T) {
+	t.Setenv("KUBEONE_TEST_CLUSTER_NAME", "  E2E-Cluster  ")
+
+	tests := []struct {
+		name     string
+		template string
+		data     manifestData
+		want     string
+	}{
+		{
+			name:     "default with value set",
+			template: `{{ default "v1.22.9" .VERSION }}`,
+			data:     manifestData{VERSION: "v1.23.6"},
+			want:     "v1.23.6",
+		},
+		{
+			name:     "default with empty value",
+			template: `{{ default "v1.22.9" .VERSION }}`,
+			data:     manifestData{},
+			want:     "v1.22.9",
+		},
+		{
+			name:     "env",
+			template: `{{ env "KUBEONE_TEST_CLUSTER_NAME" | trim | lower }}-{{ .VERSION }}`,
+			data:     manifestData{VERSION: "v1.23.6"},
+			want:     "e2e-cluster-v1.23.6",
+		},
+		{
+			name:     "env with default",
+			template: `{{ env "KUBEONE_TEST_UNSET_VARIABLE" | default "fallback" | upper }}`,
+			data:     manifestData{},
+			want:     "FALLBACK",
+		},
+		{
+			name:     "semverMajorMinor",
+			template: `{{ semverMajorMinor .VERSION }}`,
+			data:     manifestData{VERSION: "v1.23.6"},
+			want:     "1.23",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			manifestPath := renderManifestString(t, tt.template, tt.data)
+
+			got, err := os.ReadFile(manifestPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("rendered manifest = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderManifestLayered(t *testing.T) {
+	t.Parallel()
+
+	base := `apiVersion: kubeone.k8c.io/v1beta2
+kind: KubeOneCluster
+versions:
+  kubernetes: "{{ .VERSION }}"
+containerRuntime:
+  containerd: {}
+machineController:
+  deploy: true
+addons:
+  enable: true
+  addons:
+  - name: default-storage-class
+`
+
+	tests := []struct {
+		name     string
+		overlays []string
+		want     string
+	}{
+		{
+			name: "base only",
+			want: `apiVersion: kubeone.k8c.io/v1beta2
+kind: KubeOneCluster
+versions: {kubernetes: "v1.23.6"}
+containerRuntime: {containerd: {}}
+machineController: {deploy: true}
+addons: {enable: true, addons: [{name: default-storage-class}]}
+`,
+		},
+		{
+			name: "scalar override",
+			overlays: []string{`versions:
+  kubernetes: "{{ .VERSION }}-rc.0"
+machineController:
+  deploy: false
+`},
+			want: `apiVersion: kubeone.k8c.io/v1beta2
+kind: KubeOneCluster
+versions: {kubernetes: "v1.23.6-rc.0"}
+containerRuntime: {containerd: {}}
+machineController: {deploy: false}
+addons: {enable: true, addons: [{name: default-storage-class}]}
+`,
+		},
+		{
+			name: "map merge",
+			overlays: []string{`containerRuntime:
+  containerd:
+    registries:
+      docker.io:
+        mirrors: ["https://mirror.local"]
+clusterNetwork:
+  cni:
+    cilium: {}
+`},
+			want: `apiVersion: kubeone.k8c.io/v1beta2
+kind: KubeOneCluster
+versions: {kubernetes: "v1.23.6"}
+containerRuntime: {containerd: {registries: {docker.io: {mirrors: ["https://mirror.local"]}}}}
+machineController: {deploy: true}
+addons: {enable: true, addons: [{name: default-storage-class}]}
+clusterNetwork: {cni: {cilium: {}}}
+`,
+		},
+		{
+			name: "list replacement and overlays order",
+			overlays: []string{
+				`addons:
+  addons:
+  - name: backups-restic
+  - name: node-problem-detector
+`,
+				`addons:
+  addons:
+  - name: unattended-upgrades
+`,
+			},
+			want: `apiVersion: kubeone.k8c.io/v1beta2
+kind: KubeOneCluster
+versions: {kubernetes: "v1.23.6"}
+containerRuntime: {containerd: {}}
+machineController: {deploy: true}
+addons: {enable: true, addons: [{name: unattended-upgrades}]}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			basePath := filepath.Join(dir, "base.yaml")
+			if err := os.WriteFile(basePath, []byte(base), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			overlayPaths := []string{}
+			for i, overlay := range tt.overlays {
+				path := filepath.Join(dir, fmt.Sprintf("overlay-%d.yaml", i))
+				if err := os.WriteFile(path, []byte(overlay), 0600); err != nil {
+					t.Fatal(err)
+				}
+				overlayPaths = append(overlayPaths, path)
+			}
+
+			manifestPath := renderManifestLayered(t, basePath, overlayPaths, manifestData{VERSION: "v1.23.6"})
+
+			buf, err := os.ReadFile(manifestPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got, want map[string]interface{}
+			if err = yaml.Unmarshal(buf, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err = yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("rendered manifest:\n%s\nwant:\n%s", buf, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfraManifestData(t *testing.T) {
+	infra := Infra{
+		name: "aws_centos",
+		terraform: terraformBin{
+			path: "../../examples/terraform/aws",
+			vars: []string{
+				"subnets_cidr=27",
+				"os=centos",
+				"aws_region=eu-central-1",
+			},
+		},
+	}
+
+	want := manifestData{
+		VERSION:          "v1.23.6",
+		PROVIDER:         "aws",
+		REGION:           "eu-central-1",
+		OPERATING_SYSTEM: "centos",
+	}
+
+	if got := infra.manifestData("v1.23.6"); got != want {
+		t.Errorf("manifestData() = %+v, want %+v", got, want)
+	}
+
+	manifestPath := renderManifestString(t,
+		`{{ .PROVIDER }}/{{ .OPERATING_SYSTEM }}/{{ .INSTANCE_TYPE }}`,
+		want,
+	)
+
+	got, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "aws/centos/" {
+		t.Errorf("rendered manifest = %q, want %q", got, "aws/centos/")
+	}
+}
+
+func TestInfraManifestDataProviders(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		vars       []string
+		wantRegion string
+		wantType   string
+	}{
+		{
+			name:       "azure",
+			path:       "../../examples/terraform/azure",
+			vars:       []string{"location=westeurope", "control_plane_vm_size=Standard_F2", "aws_region=eu-central-1"},
+			wantRegion: "westeurope",
+			wantType:   "Standard_F2",
+		},
+		{
+			name:       "gce",
+			path:       "../../examples/terraform/gce",
+			vars:       []string{"region=europe-west3", "control_plane_type=n1-standard-2"},
+			wantRegion: "europe-west3",
+			wantType:   "n1-standard-2",
+		},
+		{
+			name:     "openstack has no region",
+			path:     "../../examples/terraform/openstack",
+			vars:     []string{"region=RegionOne", "control_plane_flavor=m1.small"},
+			wantType: "m1.small",
+		},
+		{
+			name: "vsphere has neither",
+			path: "../../examples/terraform/vsphere",
+			vars: []string{"aws_region=eu-central-1", "control_plane_type=t3.medium"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			infra := Infra{
+				terraform: terraformBin{
+					path: tt.path,
+					vars: tt.vars,
+				},
+			}
+
+			got := infra.manifestData("v1.23.6")
+			if got.REGION != tt.wantRegion {
+				t.Errorf("REGION = %q, want %q", got.REGION, tt.wantRegion)
+			}
+			if got.INSTANCE_TYPE != tt.wantType {
+				t.Errorf("INSTANCE_TYPE = %q, want %q", got.INSTANCE_TYPE, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestRenderManifestFile(t *testing.T) {
+	manifestPath := renderManifestFile(t,
+		"testdata/containerd_simple.yaml",
+		manifestData{VERSION: "v1.23.6"},
+	)
+
+	got, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(got), `kubernetes: "v1.23.6"`) {
+		t.Errorf("rendered manifest doesn't contain the kubernetes version:\n%s", got)
+	}
+	if strings.Contains(string(got), "{{") {
+		t.Errorf("rendered manifest contains unrendered template actions:\n%s", got)
+	}
+}
+
+func TestVerifyVersion(t *testing.T) {
+	t.Parallel()
+
+	controlPlaneNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cp-1",
+			Labels: map[string]string{labelControlPlaneNode: ""},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.24.2"},
+		},
+	}
+
+	componentPod := func(component, version string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      component + "-cp-1",
+				Namespace: metav1.NamespaceSystem,
+				Labels:    map[string]string{"component": component},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: component, Image: "k8s.gcr.io/" + component + ":" + version},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		pods        []*corev1.Pod
+		expectedErr string
+	}{
+		{
+			name: "all components upgraded",
+			pods: []*corev1.Pod{
+				componentPod("kube-apiserver", "v1.24.2"),
+				componentPod("kube-controller-manager", "v1.24.2"),
+				componentPod("kube-scheduler", "v1.24.2"),
+			},
+		},
+		{
+			name: "controller-manager not upgraded",
+			pods: []*corev1.Pod{
+				componentPod("kube-apiserver", "v1.24.2"),
+				componentPod("kube-controller-manager", "v1.23.8"),
+				componentPod("kube-scheduler", "v1.24.2"),
+			},
+			expectedErr: "kube-controller-manager version mismatch in pod kube-controller-manager-cp-1: expected 1.24.2, got 1.23.8",
+		},
+		{
+			name: "scheduler not upgraded",
+			pods: []*corev1.Pod{
+				componentPod("kube-apiserver", "v1.24.2"),
+				componentPod("kube-controller-manager", "v1.24.2"),
+				componentPod("kube-scheduler", "v1.23.8"),
+			},
+			expectedErr: "kube-scheduler version mismatch in pod kube-scheduler-cp-1: expected 1.24.2, got 1.23.8",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder().WithObjects(controlPlaneNode.DeepCopy())
+			for _, p := range tt.pods {
+				builder = builder.WithObjects(p)
+			}
+
+			err := verifyVersion(builder.Build(), metav1.NamespaceSystem, "1.24.2")
+
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+
+			if gotErr != tt.expectedErr {
+				t.Errorf("verifyVersion() error = %q, expected %q", gotErr, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestVerifyNodeRoles(t *testing.T) {
+	t.Parallel()
+
+	node := func(name string, controlPlane bool, taints ...corev1.Taint) *corev1.Node {
+		n := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+			Spec:       corev1.NodeSpec{Taints: taints},
+		}
+		if controlPlane {
+			n.Labels[labelControlPlaneNode] = ""
+		}
+
+		return n
+	}
+
+	controlPlaneTaint := corev1.Taint{Key: labelControlPlaneNode, Effect: corev1.TaintEffectNoSchedule}
+	masterTaint := corev1.Taint{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule}
+	dedicatedTaint := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+
+	cluster := &kubeoneapi.KubeOneCluster{
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{{Hostname: "cp-1"}, {Hostname: "cp-2"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		nodes       []*corev1.Node
+		expectedErr string
+	}{
+		{
+			name: "roles as expected",
+			nodes: []*corev1.Node{
+				node("cp-1", true, controlPlaneTaint),
+				node("cp-2", true, controlPlaneTaint, masterTaint),
+				node("worker-1", false),
+				node("worker-2", false, dedicatedTaint),
+			},
+		},
+		{
+			name: "missing control plane label",
+			nodes: []*corev1.Node{
+				node("cp-1", true, controlPlaneTaint),
+				node("cp-2", false, controlPlaneTaint),
+				node("worker-1", false),
+			},
+			expectedErr: "expected 2 nodes with the node-role.kubernetes.io/control-plane label, got 1: cp-1; " +
+				"worker nodes with the control plane taint: cp-2",
+		},
+		{
+			name: "worker labeled as control plane",
+			nodes: []*corev1.Node{
+				node("cp-1", true),
+				node("cp-2", true),
+				node("worker-1", true),
+			},
+			expectedErr: "expected 2 nodes with the node-role.kubernetes.io/control-plane label, got 3: cp-1, cp-2, worker-1",
+		},
+		{
+			name: "tainted worker",
+			nodes: []*corev1.Node{
+				node("cp-1", true),
+				node("cp-2", true),
+				node("worker-1", false, masterTaint),
+			},
+			expectedErr: "worker nodes with the control plane taint: worker-1",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder()
+			for _, n := range tt.nodes {
+				builder = builder.WithObjects(n)
+			}
+
+			err := verifyNodeRoles(builder.Build(), cluster)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("verifyNodeRoles() unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("verifyNodeRoles() error = %v, expected %q", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestVerifyStaticWorkers(t *testing.T) {
+	t.Parallel()
+
+	gpuTaint := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+
+	node := func(name, internalIP string, ready bool, labels map[string]string, taints ...corev1.Taint) *corev1.Node {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: internalIP}},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			},
+		}
+	}
+
+	cluster := &kubeoneapi.KubeOneCluster{
+		StaticWorkers: kubeoneapi.StaticWorkersConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{
+					Hostname: "worker-1",
+					Labels:   map[string]string{"tier": "frontend", "zone": "a"},
+				},
+				{
+					PrivateAddress: "10.0.0.2",
+					Taints:         []corev1.Taint{gpuTaint},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		nodes       []*corev1.Node
+		expectedErr string
+	}{
+		{
+			name: "static workers joined",
+			nodes: []*corev1.Node{
+				node("worker-1", "10.0.0.1", true, map[string]string{"tier": "frontend", "zone": "a", "extra": "label"}),
+				node("ip-10-0-0-2", "10.0.0.2", true, nil, gpuTaint),
+				node("dynamic-worker", "10.0.0.3", true, nil),
+			},
+		},
+		{
+			name: "static worker never joined",
+			nodes: []*corev1.Node{
+				node("worker-1", "10.0.0.1", true, map[string]string{"tier": "frontend", "zone": "a"}),
+				node("dynamic-worker", "10.0.0.3", true, nil),
+			},
+			expectedErr: "static workers never registered as nodes: 10.0.0.2",
+		},
+		{
+			name: "static worker not ready",
+			nodes: []*corev1.Node{
+				node("worker-1", "10.0.0.1", false, map[string]string{"tier": "frontend", "zone": "a"}),
+				node("ip-10-0-0-2", "10.0.0.2", true, nil, gpuTaint),
+			},
+			expectedErr: "static worker node worker-1 is not Ready",
+		},
+		{
+			name: "labels and taints missing",
+			nodes: []*corev1.Node{
+				node("worker-1", "10.0.0.1", true, map[string]string{"tier": "backend"}),
+				node("ip-10-0-0-2", "10.0.0.2", true, nil),
+			},
+			expectedErr: "static worker node worker-1 is missing label tier=frontend; " +
+				"static worker node worker-1 is missing label zone=a; " +
+				"static worker node ip-10-0-0-2 is missing taint dedicated=gpu:NoSchedule",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder()
+			for _, n := range tt.nodes {
+				builder = builder.WithObjects(n)
+			}
+
+			err := verifyStaticWorkers(builder.Build(), cluster)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("verifyStaticWorkers() unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("verifyStaticWorkers() error = %v, expected %q", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestVerifyEtcd(t *testing.T) {
+	t.Parallel()
+
+	etcdPod := func(name, tag string, phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceSystem,
+				Labels:    map[string]string{"component": "etcd"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "etcd", Image: "k8s.gcr.io/etcd:" + tag},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: ready},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		kubernetesVersion string
+		etcdImageTag      string
+		pods              []*corev1.Pod
+		expectedErr       string
+	}{
+		{
+			name:              "healthy etcd",
+			kubernetesVersion: "v1.23.6",
+			pods: []*corev1.Pod{
+				etcdPod("etcd-cp-1", "3.5.3-0", corev1.PodRunning, corev1.ConditionTrue),
+				etcdPod("etcd-cp-2", "3.5.3-0", corev1.PodRunning, corev1.ConditionTrue),
+			},
+		},
+		{
+			name:              "etcd not upgraded",
+			kubernetesVersion: "v1.22.9",
+			pods: []*corev1.Pod{
+				etcdPod("etcd-cp-1", "3.5.3-0", corev1.PodRunning, corev1.ConditionTrue),
+				etcdPod("etcd-cp-2", "3.4.13-0", corev1.PodRunning, corev1.ConditionTrue),
+			},
+			expectedErr: "etcd version mismatch in pod etcd-cp-2: expected 3.5.3, got 3.4.13",
+		},
+		{
+			name:              "etcd not ready",
+			kubernetesVersion: "v1.21.12",
+			pods: []*corev1.Pod{
+				etcdPod("etcd-cp-1", "3.4.13-0", corev1.PodRunning, corev1.ConditionFalse),
+			},
+			expectedErr: "etcd pod etcd-cp-1 is not ready",
+		},
+		{
+			name:              "etcd pending",
+			kubernetesVersion: "v1.21.12",
+			pods: []*corev1.Pod{
+				etcdPod("etcd-cp-1", "3.4.13-0", corev1.PodPending, corev1.ConditionFalse),
+			},
+			expectedErr: "etcd pod etcd-cp-1 is not running, phase Pending",
+		},
+		{
+			name:              "pinned etcd version",
+			kubernetesVersion: "v1.23.6",
+			etcdImageTag:      "3.5.4-0",
+			pods: []*corev1.Pod{
+				etcdPod("etcd-cp-1", "3.5.4-0", corev1.PodRunning, corev1.ConditionTrue),
+			},
+		},
+		{
+			name:              "pinned etcd version not deployed",
+			kubernetesVersion: "v1.23.6",
+			etcdImageTag:      "3.5.4-0",
+			pods: []*corev1.Pod{
+				etcdPod("etcd-cp-1", "3.5.3-0", corev1.PodRunning, corev1.ConditionTrue),
+			},
+			expectedErr: "etcd version mismatch in pod etcd-cp-1: expected 3.5.4, got 3.5.3",
+		},
+		{
+			name:              "pinned etcd version with unknown kubernetes version",
+			kubernetesVersion: "v1.30.0",
+			etcdImageTag:      "3.5.6-0",
+			pods: []*corev1.Pod{
+				etcdPod("etcd-cp-1", "3.5.6-0", corev1.PodRunning, corev1.ConditionTrue),
+			},
+		},
+		{
+			name:              "no etcd pods",
+			kubernetesVersion: "v1.23.6",
+			expectedErr:       "no etcd pods found",
+		},
+		{
+			name:              "unknown kubernetes version",
+			kubernetesVersion: "v1.30.0",
+			expectedErr:       "expected etcd version for kubernetes 1.30 is not known",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder()
+			for _, pod := range tt.pods {
+				builder = builder.WithObjects(pod)
+			}
+
+			err := verifyEtcd(builder.Build(), tt.kubernetesVersion, tt.etcdImageTag)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("verifyEtcd() unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("verifyEtcd() error = %v, expected %q", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestParseContainerImageVersion(t *testing.T) {
+	t.Parallel()
+
+	const digest = "sha256:4c1ee4a1c8e2ff28b3e6c2d1e6a1b7a5b3ef7e3b2a0b1a6e2c4e5f6a7b8c9d0e"
+
+	tests := []struct {
+		name        string
+		image       string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "tag",
+			image:    "k8s.gcr.io/kube-apiserver:v1.24.2",
+			expected: "1.24.2",
+		},
+		{
+			name:     "registry with port",
+			image:    "registry:5000/kube-apiserver:v1.24.2",
+			expected: "1.24.2",
+		},
+		{
+			name:     "tag and digest",
+			image:    "registry.k8s.io/kube-apiserver:v1.24.2@" + digest,
+			expected: "1.24.2",
+		},
+		{
+			name:     "registry with port, tag and digest",
+			image:    "registry:5000/k8s/kube-apiserver:v1.24.2@" + digest,
+			expected: "1.24.2",
+		},
+		{
+			name:        "digest only",
+			image:       "registry.k8s.io/kube-apiserver@" + digest,
+			expectedErr: true,
+		},
+		{
+			name:        "registry with port and digest only",
+			image:       "registry:5000/kube-apiserver@" + digest,
+			expectedErr: true,
+		},
+		{
+			name:        "no tag",
+			image:       "registry:5000/kube-apiserver",
+			expectedErr: true,
+		},
+		{
+			name:        "tag not a version",
+			image:       "registry.k8s.io/kube-apiserver:latest",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseContainerImageVersion(tt.image)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("parseContainerImageVersion() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+
+			if err == nil && got.String() != tt.expected {
+				t.Errorf("parseContainerImageVersion() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVerifyWorkerNodeVersions(t *testing.T) {
+	t.Parallel()
+
+	node := func(name, version string, controlPlane bool) *corev1.Node {
+		n := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{KubeletVersion: version},
+			},
+		}
+		if controlPlane {
+			n.Labels = map[string]string{labelControlPlaneNode: ""}
+		}
+
+		return n
+	}
+
+	tests := []struct {
+		name            string
+		nodes           []*corev1.Node
+		allowedOutdated int
+		expectedErr     bool
+	}{
+		{
+			name: "all workers upgraded",
+			nodes: []*corev1.Node{
+				node("worker-1", "v1.24.2", false),
+				node("worker-2", "v1.24.2", false),
+			},
+		},
+		{
+			name: "control plane nodes are ignored",
+			nodes: []*corev1.Node{
+				node("cp-1", "v1.23.8", true),
+				node("worker-1", "v1.24.2", false),
+			},
+		},
+		{
+			name: "outdated worker not allowed",
+			nodes: []*corev1.Node{
+				node("worker-1", "v1.24.2", false),
+				node("worker-2", "v1.23.8", false),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "outdated worker mid-rotation allowed",
+			nodes: []*corev1.Node{
+				node("worker-1", "v1.24.2", false),
+				node("worker-2", "v1.23.8", false),
+			},
+			allowedOutdated: 1,
+		},
+		{
+			name: "too many outdated workers",
+			nodes: []*corev1.Node{
+				node("worker-1", "v1.23.8", false),
+				node("worker-2", "v1.23.8", false),
+			},
+			allowedOutdated: 1,
+			expectedErr:     true,
+		},
+		{
+			name: "newer worker never allowed",
+			nodes: []*corev1.Node{
+				node("worker-1", "v1.25.0", false),
+			},
+			allowedOutdated: 1,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder()
+			for _, n := range tt.nodes {
+				builder = builder.WithObjects(n)
+			}
+
+			err := verifyWorkerNodeVersions(builder.Build(), "1.24.2", tt.allowedOutdated)
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("verifyWorkerNodeVersions() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
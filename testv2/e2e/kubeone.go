@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"sync"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/test/e2e/testutil"
@@ -33,6 +34,21 @@ type kubeoneBin struct {
 	tfjsonPath      string
 	manifestPath    string
 	credentialsPath string
+	// terraformWorkspace is the terraform workspace holding the state of the
+	// test cluster, see terraformBin.init
+	terraformWorkspace string
+	// sshAgentSocket is the SSH agent socket used by kubeone to connect to the
+	// hosts and the bastion. It's passed as SSH_AUTH_SOCK, which is the
+	// default sshAgentSocket of the hosts. In CI the agent is started by the
+	// e2e image entrypoint, which exports SSH_AUTH_SOCK, so it's inherited
+	// when not set.
+	sshAgentSocket string
+
+	// kubeconfigMu guards kubeconfig and kubeconfigPath caching the result of
+	// the kubeone kubeconfig command, see InvalidateKubeconfig
+	kubeconfigMu   sync.Mutex
+	kubeconfig     []byte
+	kubeconfigPath string
 }
 
 func (k1 *kubeoneBin) globalFlags() []string {
@@ -53,11 +69,24 @@ func (k1 *kubeoneBin) globalFlags() []string {
 	return args
 }
 
-func (k1 *kubeoneBin) Apply() error {
-	return k1.run("apply", "--auto-approve")
+func (k1 *kubeoneBin) Apply(flags ...string) error {
+	return k1.run(append([]string{"apply", "--auto-approve"}, flags...)...)
 }
 
+// Kubeconfig returns the admin kubeconfig of the cluster. The kubeconfig is
+// fetched only once and cached for the subsequent calls.
 func (k1 *kubeoneBin) Kubeconfig() ([]byte, error) {
+	k1.kubeconfigMu.Lock()
+	defer k1.kubeconfigMu.Unlock()
+
+	return k1.cachedKubeconfig()
+}
+
+func (k1 *kubeoneBin) cachedKubeconfig() ([]byte, error) {
+	if k1.kubeconfig != nil {
+		return k1.kubeconfig, nil
+	}
+
 	var buf bytes.Buffer
 
 	args := k1.globalFlags()
@@ -68,26 +97,52 @@ func (k1 *kubeoneBin) Kubeconfig() ([]byte, error) {
 		return nil, fmt.Errorf("fetching kubeconfig failed: %w", err)
 	}
 
-	return buf.Bytes(), nil
+	k1.kubeconfig = buf.Bytes()
+
+	return k1.kubeconfig, nil
 }
 
+// KubeconfigPath writes the kubeconfig to a file in tmpDir and returns its
+// path. The same path is returned by the subsequent calls as long as the file
+// exists.
 func (k1 *kubeoneBin) KubeconfigPath(tmpDir string) (string, error) {
-	kubeconfig, err := os.CreateTemp(tmpDir, "kubeconfig-*")
+	k1.kubeconfigMu.Lock()
+	defer k1.kubeconfigMu.Unlock()
+
+	if k1.kubeconfigPath != "" {
+		if _, err := os.Stat(k1.kubeconfigPath); err == nil {
+			return k1.kubeconfigPath, nil
+		}
+	}
+
+	buf, err := k1.cachedKubeconfig()
 	if err != nil {
 		return "", err
 	}
-	defer kubeconfig.Close()
 
-	buf, err := k1.Kubeconfig()
+	kubeconfig, err := os.CreateTemp(tmpDir, "kubeconfig-*")
 	if err != nil {
 		return "", err
 	}
+	defer kubeconfig.Close()
 
 	if err := os.WriteFile(kubeconfig.Name(), buf, 0600); err != nil {
 		return "", err
 	}
 
-	return kubeconfig.Name(), nil
+	k1.kubeconfigPath = kubeconfig.Name()
+
+	return k1.kubeconfigPath, nil
+}
+
+// InvalidateKubeconfig drops the cached kubeconfig, so it's fetched again by
+// the next Kubeconfig or KubeconfigPath call, e.g. after rotating credentials
+func (k1 *kubeoneBin) InvalidateKubeconfig() {
+	k1.kubeconfigMu.Lock()
+	defer k1.kubeconfigMu.Unlock()
+
+	k1.kubeconfig = nil
+	k1.kubeconfigPath = ""
 }
 
 func (k1 *kubeoneBin) Reset() error {
@@ -121,9 +176,18 @@ func (k1 *kubeoneBin) build(args ...string) *testutil.Exec {
 		bin = k1.bin
 	}
 
+	env := os.Environ()
+	if k1.terraformWorkspace != "" {
+		env = append(env, terraformWorkspaceEnv+"="+k1.terraformWorkspace)
+	}
+
+	if k1.sshAgentSocket != "" {
+		env = append(env, "SSH_AUTH_SOCK="+k1.sshAgentSocket)
+	}
+
 	return testutil.NewExec(bin,
 		testutil.WithArgs(args...),
-		testutil.WithEnv(os.Environ()),
+		testutil.WithEnv(env),
 		testutil.InDir(k1.dir),
 		testutil.StdoutDebug,
 	)
@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// fakeKubeoneBin writes a fake kubeone binary printing the kubeconfig and
+// recording each invocation to the returned calls file
+func fakeKubeoneBin(t *testing.T) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "kubeone")
+	calls := filepath.Join(dir, "calls")
+
+	script := "#!/bin/sh\necho \"$@\" >> " + calls + "\necho 'apiVersion: v1'\n"
+	if err := os.WriteFile(bin, []byte(script), 0700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	return bin, calls
+}
+
+func countCalls(t *testing.T, calls string) int {
+	t.Helper()
+
+	buf, err := os.ReadFile(calls)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.Count(string(buf), "\n")
+}
+
+func TestKubeoneBinKubeconfigCache(t *testing.T) {
+	bin, calls := fakeKubeoneBin(t)
+	k1 := newKubeoneBin(t, t.TempDir(), "", withKubeoneBin(bin))
+
+	kubeconfig, err := k1.Kubeconfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := k1.KubeconfigPath(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samePath, err := k1.KubeconfigPath(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := countCalls(t, calls); got != 1 {
+		t.Errorf("kubeone executed %d times, expected 1", got)
+	}
+
+	if path != samePath {
+		t.Errorf("KubeconfigPath() = %q, expected cached %q", samePath, path)
+	}
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != string(kubeconfig) || string(kubeconfig) != "apiVersion: v1\n" {
+		t.Errorf("KubeconfigPath() wrote %q, expected %q", written, kubeconfig)
+	}
+
+	k1.InvalidateKubeconfig()
+
+	if _, err = k1.Kubeconfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := countCalls(t, calls); got != 2 {
+		t.Errorf("kubeone executed %d times after invalidation, expected 2", got)
+	}
+}
+
+func TestKubeoneBinSSHAgentSocket(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "kubeone")
+
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\necho \"$SSH_AUTH_SOCK\"\n"), 0700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	k1 := newKubeoneBin(t, dir, "",
+		withKubeoneBin(bin),
+		withSSHAgentSocket("/run/ssh-agent.sock"),
+	)
+
+	out, err := k1.Kubeconfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.TrimSpace(string(out)); got != "/run/ssh-agent.sock" {
+		t.Errorf("kubeone executed with SSH_AUTH_SOCK=%q, expected %q", got, "/run/ssh-agent.sock")
+	}
+}
+
+// fakeBastion starts a TCP server responding with the given banner and
+// returns its address
+func fakeBastion(t *testing.T, banner string) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, errAccept := ln.Accept()
+			if errAccept != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(banner))
+			conn.Close()
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestBastionAddresses(t *testing.T) {
+	cluster := &kubeoneapi.KubeOneCluster{
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{PublicAddress: "10.0.0.1", Bastion: "bastion.example.com"},
+				{PublicAddress: "10.0.0.2", Bastion: "bastion.example.com"},
+				{PublicAddress: "10.0.0.3"},
+			},
+		},
+		StaticWorkers: kubeoneapi.StaticWorkersConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{PublicAddress: "10.0.1.1", Bastion: "fd00::1", BastionPort: 2222},
+			},
+		},
+	}
+
+	expected := []string{"[fd00::1]:2222", "bastion.example.com:22"}
+	if got := bastionAddresses(cluster); !reflect.DeepEqual(got, expected) {
+		t.Errorf("bastionAddresses() = %v, expected %v", got, expected)
+	}
+}
+
+func TestWaitForBastions(t *testing.T) {
+	addr := fakeBastion(t, "SSH-2.0-OpenSSH_8.9\r\n")
+
+	if err := waitForBastions([]string{addr}); err != nil {
+		t.Errorf("waitForBastions() unexpected error: %v", err)
+	}
+}
+
+func TestSSHBanner(t *testing.T) {
+	if err := sshBanner(fakeBastion(t, "SSH-2.0-OpenSSH_8.9\r\n"), time.Second); err != nil {
+		t.Errorf("sshBanner() unexpected error: %v", err)
+	}
+
+	if err := sshBanner(fakeBastion(t, "HTTP/1.1 400 Bad Request\r\n"), time.Second); err == nil {
+		t.Error("sshBanner() expected error for non-ssh server")
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kubeProxyName       = "kube-proxy"
+	kubeProxyConfigKey  = "config.conf"
+	kubeProxyIPTables   = "iptables"
+	kubeProxyIPVS       = "ipvs"
+	kubeProxyRolloutMax = 5 * time.Minute
+)
+
+// expectedKubeProxyMode returns the kube-proxy mode configured in the
+// manifest, or an empty string when kube-proxy is not installed
+func expectedKubeProxyMode(cluster *kubeoneapi.KubeOneCluster) string {
+	kp := cluster.ClusterNetwork.KubeProxy
+	switch {
+	case kp == nil:
+		return kubeProxyIPTables
+	case kp.SkipInstallation:
+		return ""
+	case kp.IPVS != nil:
+		return kubeProxyIPVS
+	}
+
+	return kubeProxyIPTables
+}
+
+// verifyKubeProxyMode waits for the kube-proxy DaemonSet to be rolled out and
+// verifies kube-proxy is configured to run in the expected mode
+func verifyKubeProxyMode(t *testing.T, client ctrlruntimeclient.Client, expected string, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, kubeProxyRolloutMax, opts...)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: kubeProxyName}
+
+	var notRolledOut string
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		ds := appsv1.DaemonSet{}
+		if err := client.Get(context.Background(), key, &ds); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		notRolledOut = daemonSetRolloutStatus(&ds)
+
+		return notRolledOut == "", nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: daemonset/%s is not rolled out: %s", err, kubeProxyName, notRolledOut)
+	}
+
+	cm := corev1.ConfigMap{}
+	if err = client.Get(context.Background(), key, &cm); err != nil {
+		return fmt.Errorf("getting %s configmap: %w", kubeProxyName, err)
+	}
+
+	mode, err := kubeProxyMode(cm.Data[kubeProxyConfigKey])
+	if err != nil {
+		return err
+	}
+
+	if mode != expected {
+		return fmt.Errorf("kube-proxy is running in %q mode, expected %q", mode, expected)
+	}
+
+	return nil
+}
+
+// daemonSetRolloutStatus describes why the DaemonSet is not rolled out, or
+// returns an empty string when it is
+func daemonSetRolloutStatus(ds *appsv1.DaemonSet) string {
+	switch {
+	case ds.Status.ObservedGeneration < ds.Generation:
+		return fmt.Sprintf("generation %d not observed yet", ds.Generation)
+	case ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled:
+		return fmt.Sprintf("%d/%d pods updated", ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled)
+	case ds.Status.NumberAvailable < ds.Status.DesiredNumberScheduled:
+		return fmt.Sprintf("%d/%d pods available", ds.Status.NumberAvailable, ds.Status.DesiredNumberScheduled)
+	}
+
+	return ""
+}
+
+// kubeProxyMode parses the mode out of the KubeProxyConfiguration, the empty
+// mode defaults to iptables on linux
+func kubeProxyMode(config string) (string, error) {
+	if config == "" {
+		return "", fmt.Errorf("%s configmap has no %s key", kubeProxyName, kubeProxyConfigKey)
+	}
+
+	cfg := struct {
+		Mode string `json:"mode"`
+	}{}
+	if err := yaml.Unmarshal([]byte(config), &cfg); err != nil {
+		return "", fmt.Errorf("parsing kube-proxy configuration: %w", err)
+	}
+
+	if cfg.Mode == "" {
+		return kubeProxyIPTables, nil
+	}
+
+	return cfg.Mode, nil
+}
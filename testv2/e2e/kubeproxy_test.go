@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExpectedKubeProxyMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		kubeProxy *kubeoneapi.KubeProxyConfig
+		want      string
+	}{
+		{
+			name: "default",
+			want: "iptables",
+		},
+		{
+			name:      "iptables",
+			kubeProxy: &kubeoneapi.KubeProxyConfig{IPTables: &kubeoneapi.IPTables{}},
+			want:      "iptables",
+		},
+		{
+			name:      "ipvs",
+			kubeProxy: &kubeoneapi.KubeProxyConfig{IPVS: &kubeoneapi.IPVSConfig{}},
+			want:      "ipvs",
+		},
+		{
+			name:      "not installed",
+			kubeProxy: &kubeoneapi.KubeProxyConfig{SkipInstallation: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := &kubeoneapi.KubeOneCluster{
+				ClusterNetwork: kubeoneapi.ClusterNetworkConfig{KubeProxy: tt.kubeProxy},
+			}
+
+			if got := expectedKubeProxyMode(cluster); got != tt.want {
+				t.Errorf("expectedKubeProxyMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyKubeProxyMode(t *testing.T) {
+	t.Parallel()
+
+	rolledOut := appsv1.DaemonSetStatus{
+		ObservedGeneration:     1,
+		DesiredNumberScheduled: 3,
+		UpdatedNumberScheduled: 3,
+		NumberAvailable:        3,
+	}
+
+	tests := []struct {
+		name           string
+		config         string
+		status         appsv1.DaemonSetStatus
+		expected       string
+		expectedErrMsg string
+		expectTimeout  bool
+	}{
+		{
+			name:     "ipvs",
+			config:   "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\nmode: ipvs\n",
+			status:   rolledOut,
+			expected: "ipvs",
+		},
+		{
+			name:     "empty mode defaults to iptables",
+			config:   "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\nmode: \"\"\n",
+			status:   rolledOut,
+			expected: "iptables",
+		},
+		{
+			name:           "ipvs requested, iptables running",
+			config:         "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\nmode: iptables\n",
+			status:         rolledOut,
+			expected:       "ipvs",
+			expectedErrMsg: `kube-proxy is running in "iptables" mode, expected "ipvs"`,
+		},
+		{
+			name:   "not rolled out",
+			config: "mode: ipvs\n",
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     1,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 1,
+				NumberAvailable:        3,
+			},
+			expected:       "ipvs",
+			expectedErrMsg: "daemonset/kube-proxy is not rolled out: 1/3 pods updated",
+			expectTimeout:  true,
+		},
+		{
+			name:           "missing configuration",
+			status:         rolledOut,
+			expected:       "ipvs",
+			expectedErrMsg: "kube-proxy configmap has no config.conf key",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			objs := []client.Object{
+				&appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: metav1.NamespaceSystem, Generation: 1},
+					Status:     tt.status,
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: metav1.NamespaceSystem},
+					Data:       map[string]string{},
+				},
+			}
+			if tt.config != "" {
+				objs[1].(*corev1.ConfigMap).Data["config.conf"] = tt.config
+			}
+
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+			err := verifyKubeProxyMode(t, c, tt.expected,
+				withInterval(10*time.Millisecond),
+				withTimeout(50*time.Millisecond),
+			)
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("verifyKubeProxyMode() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("verifyKubeProxyMode() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			case errors.Is(err, wait.ErrWaitTimeout) != tt.expectTimeout:
+				t.Errorf("verifyKubeProxyMode() error = %v, expected timeout %v", err, tt.expectTimeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	lbCheckName  = "kubeone-e2e-lb-check"
+	lbCheckImage = "k8s.gcr.io/e2e-test-images/agnhost:2.39"
+	lbCheckPort  = 8080
+
+	defaultLoadBalancerTimeout = 10 * time.Minute
+)
+
+// loadBalancerTimeouts are the provider specific timeouts of provisioning and
+// tearing down the cloud load balancers. The providers which are not listed
+// don't support the LoadBalancer services out of the box.
+var loadBalancerTimeouts = map[string]time.Duration{
+	"aws":          15 * time.Minute,
+	"azure":        defaultLoadBalancerTimeout,
+	"digitalocean": defaultLoadBalancerTimeout,
+	"gce":          defaultLoadBalancerTimeout,
+	"hetzner":      defaultLoadBalancerTimeout,
+	"openstack":    defaultLoadBalancerTimeout,
+}
+
+// verifyLoadBalancer verifies that the cloud provider integration provisions
+// the LoadBalancer service and tears it down after the service is deleted.
+// With probe the service is requested over its external address as well. The
+// check is skipped on the providers without the load balancers support.
+func verifyLoadBalancer(t *testing.T, client ctrlruntimeclient.Client, cluster *kubeoneapi.KubeOneCluster, probe bool, opts ...pollOpts) error {
+	provider := cluster.CloudProvider.CloudProviderName()
+
+	timeout, ok := loadBalancerTimeouts[provider]
+	if !ok {
+		t.Logf("skipping LoadBalancer check, %q provider doesn't support LoadBalancer services", provider)
+
+		return nil
+	}
+
+	w := newPollConfig(defaultNodesReadyInterval, timeout, opts...)
+
+	deploy, svc := lbCheckObjects()
+	if err := client.Create(context.Background(), deploy); err != nil {
+		return fmt.Errorf("creating %s deployment: %w", lbCheckName, err)
+	}
+
+	defer func() {
+		if err := client.Delete(context.Background(), deploy); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			t.Logf("failed to delete %s deployment: %v", lbCheckName, err)
+		}
+	}()
+
+	if err := client.Create(context.Background(), svc); err != nil {
+		return fmt.Errorf("creating %s service: %w", lbCheckName, err)
+	}
+
+	address, err := waitForLoadBalancerAddress(t, client, svc, w)
+	if err != nil {
+		// best effort cleanup, the LB might still be provisioned
+		_ = client.Delete(context.Background(), svc)
+
+		return fmt.Errorf("waiting for %s service address: %w", lbCheckName, err)
+	}
+
+	t.Logf("%s service is exposed on %s", lbCheckName, address)
+
+	if probe {
+		if err = probeLoadBalancer(address, w); err != nil {
+			_ = client.Delete(context.Background(), svc)
+
+			return fmt.Errorf("requesting %s service on %s: %w", lbCheckName, address, err)
+		}
+	}
+
+	if err = client.Delete(context.Background(), svc); err != nil {
+		return fmt.Errorf("deleting %s service: %w", lbCheckName, err)
+	}
+
+	// the cloud provider removes the load balancer cleanup finalizer once the
+	// load balancer is deleted, so the service is gone only after the tear down
+	key := ctrlruntimeclient.ObjectKeyFromObject(svc)
+	err = wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		err := client.Get(context.Background(), key, &corev1.Service{})
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			t.Logf("error: %v", err)
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for %s load balancer to be torn down: %w", lbCheckName, err)
+	}
+
+	return nil
+}
+
+func lbCheckObjects() (*appsv1.Deployment, *corev1.Service) {
+	labels := map[string]string{"app": lbCheckName}
+	meta := metav1.ObjectMeta{Name: lbCheckName, Namespace: metav1.NamespaceDefault}
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "netexec",
+							Image: lbCheckImage,
+							Args:  []string{"netexec", fmt.Sprintf("--http-port=%d", lbCheckPort)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: meta,
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(lbCheckPort),
+				},
+			},
+		},
+	}
+
+	return deploy, svc
+}
+
+func waitForLoadBalancerAddress(t *testing.T, client ctrlruntimeclient.Client, svc *corev1.Service, w *pollConfig) (string, error) {
+	var address string
+
+	key := ctrlruntimeclient.ObjectKeyFromObject(svc)
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		current := corev1.Service{}
+		if err := client.Get(context.Background(), key, &current); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		for _, ingress := range current.Status.LoadBalancer.Ingress {
+			switch {
+			case ingress.Hostname != "":
+				address = ingress.Hostname
+			case ingress.IP != "":
+				address = ingress.IP
+			}
+		}
+
+		return address != "", nil
+	})
+
+	return address, err
+}
+
+// probeLoadBalancer requests the load balancer until it responds, the DNS
+// names of the load balancers might take a while to propagate
+func probeLoadBalancer(address string, w *pollConfig) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	url := fmt.Sprintf("http://%s/", net.JoinHostPort(address, "80"))
+
+	var lastErr error
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		_, lastErr = httpGet(client, url)
+
+		return lastErr == nil, nil
+	})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%w: %v", err, lastErr)
+	}
+
+	return err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const lbCleanupFinalizer = "service.kubernetes.io/load-balancer-cleanup"
+
+func TestVerifyLoadBalancer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		cloudProvider  kubeoneapi.CloudProviderSpec
+		ingress        []corev1.LoadBalancerIngress
+		keepFinalizer  bool
+		expectedErrMsg string
+	}{
+		{
+			name:          "load balancer provisioned and torn down",
+			cloudProvider: kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			ingress:       []corev1.LoadBalancerIngress{{IP: "192.0.2.10"}},
+		},
+		{
+			name:          "load balancer with hostname",
+			cloudProvider: kubeoneapi.CloudProviderSpec{AWS: &kubeoneapi.AWSSpec{}},
+			ingress:       []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+		},
+		{
+			name:           "load balancer never provisioned",
+			cloudProvider:  kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			expectedErrMsg: "waiting for kubeone-e2e-lb-check service address",
+		},
+		{
+			name:           "load balancer never torn down",
+			cloudProvider:  kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			ingress:        []corev1.LoadBalancerIngress{{IP: "192.0.2.10"}},
+			keepFinalizer:  true,
+			expectedErrMsg: "load balancer to be torn down",
+		},
+		{
+			name:          "provider without load balancers",
+			cloudProvider: kubeoneapi.CloudProviderSpec{None: &kubeoneapi.NoneSpec{}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().Build()
+			cluster := &kubeoneapi.KubeOneCluster{CloudProvider: tt.cloudProvider}
+
+			// act as the cloud controller manager, provisioning the load
+			// balancer and removing the cleanup finalizer on deletion
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				for ctx.Err() == nil {
+					svc := corev1.Service{}
+					if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: lbCheckName}, &svc); err == nil {
+						switch {
+						case svc.DeletionTimestamp != nil && !tt.keepFinalizer:
+							svc.Finalizers = nil
+							_ = c.Update(ctx, &svc)
+						case svc.DeletionTimestamp == nil && len(svc.Finalizers) == 0:
+							svc.Finalizers = []string{lbCleanupFinalizer}
+							_ = c.Update(ctx, &svc)
+						case svc.DeletionTimestamp == nil && len(svc.Status.LoadBalancer.Ingress) == 0 && tt.ingress != nil:
+							svc.Status.LoadBalancer.Ingress = tt.ingress
+							_ = c.Status().Update(ctx, &svc)
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			}()
+
+			err := verifyLoadBalancer(t, c, cluster, false,
+				withInterval(10*time.Millisecond),
+				withTimeout(300*time.Millisecond),
+			)
+			cancel()
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("verifyLoadBalancer() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (!errors.Is(err, wait.ErrWaitTimeout) || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("verifyLoadBalancer() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			}
+
+			deployments := appsv1.DeploymentList{}
+			if err = c.List(context.Background(), &deployments); err != nil {
+				t.Fatal(err)
+			}
+			if len(deployments.Items) != 0 {
+				t.Errorf("load balancer backend deployment was not deleted")
+			}
+
+			services := corev1.ServiceList{}
+			if err = c.List(context.Background(), &services); err != nil {
+				t.Fatal(err)
+			}
+			// the service might still wait for the load balancer tear down
+			for _, svc := range services.Items {
+				if svc.DeletionTimestamp == nil {
+					t.Errorf("load balancer service %q was not deleted", svc.Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pdbCheckName        = "kubeone-e2e-pdb-check"
+	pdbCheckImage       = "k8s.gcr.io/pause:3.7"
+	pdbCheckMaxReplicas = 3
+	pdbCheckInterval    = 2 * time.Second
+)
+
+// pdbCheck deploys a Deployment protected by a PodDisruptionBudget and records
+// the lowest number of available replicas observed while it's running, e.g.
+// during the upgrade, to verify the disruption budget was respected
+type pdbCheck struct {
+	client       ctrlruntimeclient.Client
+	namespace    string
+	replicas     int32
+	minAvailable int32
+	interval     time.Duration
+
+	mu          sync.Mutex
+	minObserved int32
+	errs        []error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newPDBCheck returns the check sized for the number of worker nodes, one
+// replica per worker up to pdbCheckMaxReplicas, allowing one of them to be
+// disrupted at a time. At least two workers are needed, otherwise draining the
+// only worker would be blocked by the disruption budget.
+func newPDBCheck(client ctrlruntimeclient.Client, workers int) (*pdbCheck, error) {
+	if workers < 2 {
+		return nil, fmt.Errorf("at least 2 worker nodes are needed, got %d", workers)
+	}
+
+	replicas := int32(workers)
+	if replicas > pdbCheckMaxReplicas {
+		replicas = pdbCheckMaxReplicas
+	}
+
+	return &pdbCheck{
+		client:       client,
+		namespace:    metav1.NamespaceDefault,
+		replicas:     replicas,
+		minAvailable: replicas - 1,
+		interval:     pdbCheckInterval,
+		minObserved:  replicas,
+	}, nil
+}
+
+// countWorkerNodes returns the number of nodes without the control plane label
+func countWorkerNodes(client ctrlruntimeclient.Client) (int, error) {
+	nodes := corev1.NodeList{}
+	if err := client.List(context.Background(), &nodes); err != nil {
+		return 0, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	workers := 0
+	for _, n := range nodes.Items {
+		if _, ok := n.Labels[labelControlPlaneNode]; !ok {
+			workers++
+		}
+	}
+
+	return workers, nil
+}
+
+func (pc *pdbCheck) objects() (*appsv1.Deployment, *policyv1.PodDisruptionBudget) {
+	labels := map[string]string{"app": pdbCheckName}
+	meta := metav1.ObjectMeta{Name: pdbCheckName, Namespace: pc.namespace}
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(pc.replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "pause", Image: pdbCheckImage},
+					},
+				},
+			},
+		},
+	}
+
+	minAvailable := intstr.FromInt(int(pc.minAvailable))
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: meta,
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: labels},
+		},
+	}
+
+	return deploy, pdb
+}
+
+// Deploy creates the Deployment and the PodDisruptionBudget and waits for all
+// replicas to become available. The objects are deleted when the test is done.
+func (pc *pdbCheck) Deploy(t *testing.T, opts ...pollOpts) error {
+	w := newPollConfig(defaultNodesReadyInterval, defaultNodesReadyTimeout, opts...)
+
+	deploy, pdb := pc.objects()
+	for _, obj := range []ctrlruntimeclient.Object{deploy, pdb} {
+		if err := pc.client.Create(context.Background(), obj); err != nil {
+			return fmt.Errorf("creating %s: %w", pdbCheckName, err)
+		}
+	}
+
+	t.Cleanup(func() {
+		for _, obj := range []ctrlruntimeclient.Object{pdb, deploy} {
+			if err := pc.client.Delete(context.Background(), obj); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				t.Logf("failed to delete %s: %v", pdbCheckName, err)
+			}
+		}
+	})
+
+	return wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		available, err := pc.available()
+		if err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		return available == pc.replicas, nil
+	})
+}
+
+func (pc *pdbCheck) available() (int32, error) {
+	deploy := appsv1.Deployment{}
+	key := ctrlruntimeclient.ObjectKey{Namespace: pc.namespace, Name: pdbCheckName}
+	if err := pc.client.Get(context.Background(), key, &deploy); err != nil {
+		return 0, err
+	}
+
+	return deploy.Status.AvailableReplicas, nil
+}
+
+// Start starts polling the available replicas of the Deployment in the
+// background until Stop is called
+func (pc *pdbCheck) Start() {
+	pc.stop = make(chan struct{})
+	pc.done = make(chan struct{})
+
+	go func() {
+		defer close(pc.done)
+
+		wait.Until(pc.observe, pc.interval, pc.stop)
+	}()
+}
+
+func (pc *pdbCheck) observe() {
+	available, err := pc.available()
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if err != nil {
+		// the API server is expected to be briefly unavailable during the
+		// control plane upgrade
+		if !transientAPIError(err) {
+			pc.errs = append(pc.errs, err)
+		}
+
+		return
+	}
+
+	if available < pc.minObserved {
+		pc.minObserved = available
+	}
+}
+
+// Stop stops polling and returns the minimum observed availability, with an
+// error if it dropped below the disruption budget or if the Deployment could
+// not be observed for other reasons than the API server being unavailable
+func (pc *pdbCheck) Stop() (int32, error) {
+	close(pc.stop)
+	<-pc.done
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	errs := pc.errs
+	if pc.minObserved < pc.minAvailable {
+		errs = append(errs, fmt.Errorf("%s availability dropped to %d replicas, below the disruption budget minAvailable=%d",
+			pdbCheckName, pc.minObserved, pc.minAvailable))
+	}
+
+	return pc.minObserved, utilerrors.NewAggregate(errs)
+}
+
+// transientAPIError returns whether the error is caused by the API server
+// being unreachable or temporarily unavailable
+func transientAPIError(err error) bool {
+	var netErr net.Error
+
+	return errors.As(err, &netErr) ||
+		k8serrors.IsServiceUnavailable(err) ||
+		k8serrors.IsServerTimeout(err) ||
+		k8serrors.IsTimeout(err) ||
+		k8serrors.IsTooManyRequests(err)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func setAvailableReplicas(t *testing.T, c client.Client, available int32) {
+	t.Helper()
+
+	deploy := appsv1.Deployment{}
+	key := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: pdbCheckName}
+	if err := c.Get(context.Background(), key, &deploy); err != nil {
+		t.Fatal(err)
+	}
+
+	deploy.Status.AvailableReplicas = available
+	if err := c.Status().Update(context.Background(), &deploy); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deployPDBCheck deploys the check, marking the Deployment as available right
+// after Deploy creates it
+func deployPDBCheck(t *testing.T, c client.Client, pc *pdbCheck) {
+	t.Helper()
+
+	go func() {
+		for {
+			deploy := appsv1.Deployment{}
+			key := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: pdbCheckName}
+			if c.Get(context.Background(), key, &deploy) == nil {
+				deploy.Status.AvailableReplicas = pc.replicas
+				if c.Status().Update(context.Background(), &deploy) == nil {
+					return
+				}
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	if err := pc.Deploy(t, withInterval(5*time.Millisecond), withTimeout(time.Second)); err != nil {
+		t.Fatalf("pdbCheck.Deploy() unexpected error: %v", err)
+	}
+}
+
+func TestPDBCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		workers              int
+		availability         []int32
+		expectedMinAvailable int
+		expectedMin          int32
+		expectedErr          bool
+	}{
+		{
+			name:                 "budget respected",
+			workers:              3,
+			availability:         []int32{3, 2, 3, 2, 3},
+			expectedMinAvailable: 2,
+			expectedMin:          2,
+		},
+		{
+			name:                 "budget violated",
+			workers:              3,
+			availability:         []int32{3, 2, 1, 3},
+			expectedMinAvailable: 2,
+			expectedMin:          1,
+			expectedErr:          true,
+		},
+		{
+			name:                 "replicas capped",
+			workers:              5,
+			availability:         []int32{3, 2, 3},
+			expectedMinAvailable: 2,
+			expectedMin:          2,
+		},
+		{
+			name:                 "two workers",
+			workers:              2,
+			availability:         []int32{2, 1, 2},
+			expectedMinAvailable: 1,
+			expectedMin:          1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientScheme, err := newClientScheme()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := fake.NewClientBuilder().WithScheme(clientScheme).Build()
+
+			pc, err := newPDBCheck(c, tt.workers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pc.interval = time.Millisecond
+
+			deployPDBCheck(t, c, pc)
+
+			pdb := policyv1.PodDisruptionBudget{}
+			if err = c.Get(context.Background(), client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: pdbCheckName}, &pdb); err != nil {
+				t.Fatalf("PodDisruptionBudget not created: %v", err)
+			}
+			if pdb.Spec.MinAvailable.IntValue() != tt.expectedMinAvailable {
+				t.Errorf("PodDisruptionBudget minAvailable = %s, expected %d", pdb.Spec.MinAvailable.String(), tt.expectedMinAvailable)
+			}
+
+			pc.Start()
+			for _, available := range tt.availability {
+				setAvailableReplicas(t, c, available)
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			minObserved, err := pc.Stop()
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("pdbCheck.Stop() error = %v, expected error %v", err, tt.expectedErr)
+			}
+			if minObserved != tt.expectedMin {
+				t.Errorf("pdbCheck.Stop() minimum = %d, expected %d", minObserved, tt.expectedMin)
+			}
+		})
+	}
+}
+
+func TestPDBCheckObserveErrors(t *testing.T) {
+	t.Parallel()
+
+	clientScheme, err := newClientScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(clientScheme).Build()
+
+	pc, err := newPDBCheck(c, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc.interval = time.Millisecond
+
+	deployPDBCheck(t, c, pc)
+
+	pc.Start()
+
+	deploy := appsv1.Deployment{}
+	if err = c.Get(context.Background(), client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: pdbCheckName}, &deploy); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Delete(context.Background(), &deploy); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err = pc.Stop(); err == nil {
+		t.Error("pdbCheck.Stop() expected error for the missing Deployment")
+	}
+}
+
+func TestNewPDBCheckSingleWorker(t *testing.T) {
+	if _, err := newPDBCheck(fake.NewClientBuilder().Build(), 1); err == nil {
+		t.Error("newPDBCheck() expected error for a single worker")
+	}
+}
+
+func TestCountWorkerNodes(t *testing.T) {
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	c := fake.NewClientBuilder().WithObjects(
+		node("cp-1", map[string]string{labelControlPlaneNode: ""}),
+		node("worker-1", nil),
+		node("worker-2", map[string]string{"kubernetes.io/os": "linux"}),
+	).Build()
+
+	workers, err := countWorkerNodes(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workers != 2 {
+		t.Errorf("countWorkerNodes() = %d, expected 2", workers)
+	}
+}
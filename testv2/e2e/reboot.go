@@ -0,0 +1,319 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/ssh"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// rebootMinControlPlaneHosts is the smallest control plane keeping the
+	// etcd quorum while one of the members is down
+	rebootMinControlPlaneHosts = 3
+	rebootProbeInterval        = time.Second
+	// rebootMaxAPIDowntime tolerates the load balancer health checks taking
+	// the rebooted node out of the rotation
+	rebootMaxAPIDowntime = 30 * time.Second
+	rebootCommand        = "sudo systemd-run --on-active=2 systemctl reboot"
+)
+
+// rebootCheck reboots one of the control plane nodes and verifies the node
+// recovers, while the API server stays reachable through the rest of the
+// control plane
+type rebootCheck struct {
+	client ctrlruntimeclient.Client
+	// reboot reboots the given host, by default over SSH
+	reboot func(host kubeoneapi.HostConfig) error
+	// probeAPI checks the API server is reachable and ready
+	probeAPI func(ctx context.Context) error
+
+	probeInterval time.Duration
+	maxDowntime   time.Duration
+}
+
+func newRebootCheck(client ctrlruntimeclient.Client, restConfig *rest.Config, sshAgentSocket string) (*rebootCheck, error) {
+	probeAPI, err := apiServerReadyz(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rebootCheck{
+		client: client,
+		reboot: func(host kubeoneapi.HostConfig) error {
+			if sshAgentSocket != "" {
+				host.SSHAgentSocket = sshAgentSocket
+			}
+
+			return sshReboot(host)
+		},
+		probeAPI:      probeAPI,
+		probeInterval: rebootProbeInterval,
+		maxDowntime:   rebootMaxAPIDowntime,
+	}, nil
+}
+
+// sshReboot schedules the reboot of the host, so the command returns before
+// the SSH connection is dropped
+func sshReboot(host kubeoneapi.HostConfig) error {
+	if _, err := sshExec(host, rebootCommand); err != nil {
+		return fmt.Errorf("rebooting %s: %w", host.PublicAddress, err)
+	}
+
+	return nil
+}
+
+// sshExec runs the command on the host over SSH, the same way kubeone
+// connects to the hosts, and returns its stdout
+func sshExec(host kubeoneapi.HostConfig, cmd string) (string, error) {
+	conn, err := ssh.NewConnector(context.Background()).Connect(host)
+	if err != nil {
+		return "", fmt.Errorf("connecting to %s: %w", host.PublicAddress, err)
+	}
+	defer conn.Close()
+
+	stdout, stderr, _, err := conn.Exec(cmd)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, stderr)
+	}
+
+	return stdout, nil
+}
+
+// Run cordons and reboots the last control plane node, waits for it to come
+// back Ready with all etcd members ready and uncordons it. The check is
+// skipped on clusters which can't keep the etcd quorum without one node.
+func (rc *rebootCheck) Run(t *testing.T, cluster *kubeoneapi.KubeOneCluster, opts ...pollOpts) error {
+	hosts := cluster.ControlPlane.Hosts
+	if len(hosts) < rebootMinControlPlaneHosts {
+		t.Logf("skipping control plane reboot check, %d control plane hosts can't keep etcd quorum during the reboot", len(hosts))
+
+		return nil
+	}
+
+	w := newPollConfig(defaultNodesReadyInterval, defaultNodesReadyTimeout, opts...)
+
+	host := hosts[len(hosts)-1]
+	node, err := nodeForHost(rc.client, host)
+	if err != nil {
+		return err
+	}
+
+	bootID := node.Status.NodeInfo.BootID
+
+	if err = rc.setUnschedulable(node.Name, true); err != nil {
+		return fmt.Errorf("cordoning node %s: %w", node.Name, err)
+	}
+
+	defer func() {
+		if err := rc.setUnschedulable(node.Name, false); err != nil {
+			t.Logf("failed to uncordon node %s: %v", node.Name, err)
+		}
+	}()
+
+	monitor := newAPIAvailability(rc.probeAPI, rc.probeInterval)
+	monitor.Start()
+
+	if err = rc.reboot(host); err != nil {
+		monitor.Stop()
+
+		return err
+	}
+
+	t.Logf("control plane node %s is rebooting", node.Name)
+
+	err = wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		current := corev1.Node{}
+		if err := rc.client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: node.Name}, &current); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		// the new boot ID proves the node was rebooted and its kubelet is back
+		rebooted := current.Status.NodeInfo.BootID != "" && current.Status.NodeInfo.BootID != bootID
+
+		return rebooted && nodeReady(&current), nil
+	})
+	if err != nil {
+		monitor.Stop()
+
+		return fmt.Errorf("waiting for control plane node %s to recover after the reboot: %w", node.Name, err)
+	}
+
+	var ready, total int
+	err = wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		ready, total, err = etcdMembersReady(rc.client)
+		if err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		return ready == len(hosts) && total == len(hosts), nil
+	})
+	downtime := monitor.Stop()
+	if err != nil {
+		return fmt.Errorf("waiting for etcd members to recover (%d/%d ready): %w", ready, len(hosts), err)
+	}
+
+	if downtime > rc.maxDowntime {
+		return fmt.Errorf("API server was unreachable for %s (more than %s) while node %s was rebooting, etcd quorum might have been lost",
+			downtime, rc.maxDowntime, node.Name)
+	}
+
+	t.Logf("control plane node %s recovered, the longest API server downtime was %s", node.Name, downtime)
+
+	return nil
+}
+
+func (rc *rebootCheck) setUnschedulable(name string, unschedulable bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node := corev1.Node{}
+		if err := rc.client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: name}, &node); err != nil {
+			return err
+		}
+
+		node.Spec.Unschedulable = unschedulable
+
+		return rc.client.Update(context.Background(), &node)
+	})
+}
+
+// nodeForHost finds the node of the host
+func nodeForHost(client ctrlruntimeclient.Client, host kubeoneapi.HostConfig) (*corev1.Node, error) {
+	nodes := corev1.NodeList{}
+	if err := client.List(context.Background(), &nodes); err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+
+	node := findHostNode(nodes.Items, host)
+	if node == nil {
+		return nil, fmt.Errorf("no node found for the control plane host %s", host.PublicAddress)
+	}
+
+	return node, nil
+}
+
+// etcdMembersReady returns the number of ready and all etcd pods
+func etcdMembersReady(client ctrlruntimeclient.Client) (int, int, error) {
+	pods := corev1.PodList{}
+	listOpts := ctrlruntimeclient.ListOptions{
+		Namespace:     metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(map[string]string{"component": "etcd"}),
+	}
+
+	if err := client.List(context.Background(), &pods, &listOpts); err != nil {
+		return 0, 0, fmt.Errorf("unable to list etcd pods: %w", err)
+	}
+
+	ready := 0
+	for _, p := range pods.Items {
+		if p.Status.Phase == corev1.PodRunning && podReady(p.Status.Conditions) {
+			ready++
+		}
+	}
+
+	return ready, len(pods.Items), nil
+}
+
+// apiAvailability probes the API server in the background and records the
+// longest period it was unreachable
+type apiAvailability struct {
+	probe    func(ctx context.Context) error
+	interval time.Duration
+
+	mu        sync.Mutex
+	downSince time.Time
+	longest   time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newAPIAvailability(probe func(ctx context.Context) error, interval time.Duration) *apiAvailability {
+	return &apiAvailability{
+		probe:    probe,
+		interval: interval,
+	}
+}
+
+// Start starts probing the API server until Stop is called
+func (aa *apiAvailability) Start() {
+	aa.stop = make(chan struct{})
+	aa.done = make(chan struct{})
+
+	go func() {
+		defer close(aa.done)
+
+		wait.Until(aa.observe, aa.interval, aa.stop)
+	}()
+}
+
+func (aa *apiAvailability) observe() {
+	ctx, cancel := context.WithTimeout(context.Background(), aa.interval)
+	defer cancel()
+
+	err := aa.probe(ctx)
+	now := time.Now()
+
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+
+	switch {
+	case err != nil && aa.downSince.IsZero():
+		aa.downSince = now
+	case err == nil && !aa.downSince.IsZero():
+		aa.recordDowntime(now)
+	}
+}
+
+func (aa *apiAvailability) recordDowntime(now time.Time) {
+	if downtime := now.Sub(aa.downSince); downtime > aa.longest {
+		aa.longest = downtime
+	}
+	aa.downSince = time.Time{}
+}
+
+// Stop stops probing and returns the longest observed downtime
+func (aa *apiAvailability) Stop() time.Duration {
+	close(aa.stop)
+	<-aa.done
+
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+
+	if !aa.downSince.IsZero() {
+		aa.recordDowntime(time.Now())
+	}
+
+	return aa.longest
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func rebootTestObjects(etcdReady int) []client.Object {
+	objs := []client.Object{}
+
+	for i := 0; i < 3; i++ {
+		objs = append(objs, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cp-%d", i)},
+			Status: corev1.NodeStatus{
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: fmt.Sprintf("10.0.0.%d", i)}},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				NodeInfo:   corev1.NodeSystemInfo{BootID: "boot-1"},
+			},
+		})
+
+		phase := corev1.PodRunning
+		if i >= etcdReady {
+			phase = corev1.PodPending
+		}
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("etcd-cp-%d", i),
+				Namespace: metav1.NamespaceSystem,
+				Labels:    map[string]string{"component": "etcd"},
+			},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		})
+	}
+
+	return objs
+}
+
+func rebootTestHosts(n int) []kubeoneapi.HostConfig {
+	hosts := []kubeoneapi.HostConfig{}
+	for i := 0; i < n; i++ {
+		hosts = append(hosts, kubeoneapi.HostConfig{
+			PublicAddress:  fmt.Sprintf("192.0.2.%d", i),
+			PrivateAddress: fmt.Sprintf("10.0.0.%d", i),
+		})
+	}
+
+	return hosts
+}
+
+func TestRebootCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		hosts          int
+		etcdReady      int
+		rebootErr      error
+		noReboot       bool
+		apiDown        bool
+		expectedReboot string
+		expectedErrMsg string
+		expectTimeout  bool
+	}{
+		{
+			name:           "node recovers",
+			hosts:          3,
+			etcdReady:      3,
+			expectedReboot: "192.0.2.2",
+		},
+		{
+			name:           "node never recovers",
+			hosts:          3,
+			etcdReady:      3,
+			noReboot:       true,
+			expectedReboot: "192.0.2.2",
+			expectedErrMsg: "waiting for control plane node cp-2 to recover after the reboot",
+			expectTimeout:  true,
+		},
+		{
+			name:           "etcd member doesn't recover",
+			hosts:          3,
+			etcdReady:      2,
+			expectedReboot: "192.0.2.2",
+			expectedErrMsg: "waiting for etcd members to recover (2/3 ready)",
+			expectTimeout:  true,
+		},
+		{
+			name:           "API server unreachable",
+			hosts:          3,
+			etcdReady:      3,
+			apiDown:        true,
+			expectedReboot: "192.0.2.2",
+			expectedErrMsg: "API server was unreachable",
+		},
+		{
+			name:           "reboot fails",
+			hosts:          3,
+			etcdReady:      3,
+			rebootErr:      errors.New("connection refused"),
+			expectedReboot: "192.0.2.2",
+			expectedErrMsg: "connection refused",
+		},
+		{
+			name:      "not enough control plane hosts",
+			hosts:     1,
+			etcdReady: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().WithObjects(rebootTestObjects(tt.etcdReady)...).Build()
+
+			var (
+				rebooted string
+				cordoned bool
+				probeErr error
+			)
+			if tt.apiDown {
+				probeErr = errors.New("connection refused")
+			}
+
+			rc := &rebootCheck{
+				client: c,
+				reboot: func(host kubeoneapi.HostConfig) error {
+					rebooted = host.PublicAddress
+
+					node := corev1.Node{}
+					if err := c.Get(context.Background(), client.ObjectKey{Name: "cp-2"}, &node); err != nil {
+						return err
+					}
+					cordoned = node.Spec.Unschedulable
+
+					if tt.rebootErr != nil || tt.noReboot {
+						return tt.rebootErr
+					}
+
+					node.Status.NodeInfo.BootID = "boot-2"
+
+					return c.Status().Update(context.Background(), &node)
+				},
+				probeAPI: func(context.Context) error {
+					return probeErr
+				},
+				probeInterval: 5 * time.Millisecond,
+				maxDowntime:   5 * time.Millisecond,
+			}
+
+			cluster := &kubeoneapi.KubeOneCluster{
+				ControlPlane: kubeoneapi.ControlPlaneConfig{Hosts: rebootTestHosts(tt.hosts)},
+			}
+
+			err := rc.Run(t, cluster,
+				withInterval(10*time.Millisecond),
+				withTimeout(50*time.Millisecond),
+			)
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("rebootCheck.Run() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("rebootCheck.Run() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			case errors.Is(err, wait.ErrWaitTimeout) != tt.expectTimeout:
+				t.Errorf("rebootCheck.Run() error = %v, expected timeout %v", err, tt.expectTimeout)
+			}
+
+			if rebooted != tt.expectedReboot {
+				t.Errorf("rebooted host = %q, expected %q", rebooted, tt.expectedReboot)
+			}
+
+			if tt.expectedReboot != "" && !cordoned {
+				t.Errorf("node was not cordoned before the reboot")
+			}
+
+			nodes := corev1.NodeList{}
+			if err = c.List(context.Background(), &nodes); err != nil {
+				t.Fatal(err)
+			}
+			for _, node := range nodes.Items {
+				if node.Spec.Unschedulable {
+					t.Errorf("node %s was left cordoned", node.Name)
+				}
+			}
+		})
+	}
+}
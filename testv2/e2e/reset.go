@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	resetMachinesInterval = time.Second
+	resetAPIProbeTimeout  = 10 * time.Second
+	resetAPIDownInterval  = 5 * time.Second
+	resetAPIDownTimeout   = 5 * time.Minute
+)
+
+// resetCheck runs kubeone reset twice and verifies the cluster is torn down:
+// the Machines are deleted before the control plane goes away and the API
+// server is unreachable afterwards
+type resetCheck struct {
+	client ctrlruntimeclient.Client
+	// reset runs kubeone reset
+	reset func() error
+	// probeAPI checks the API server is reachable and ready
+	probeAPI func(ctx context.Context) error
+
+	machinesInterval time.Duration
+
+	mu sync.Mutex
+	// lastMachines are the Machines seen by the last successful list, nil if
+	// the list never succeeded
+	lastMachines []string
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newResetCheck returns the reset check of the cluster managed by the kubeone
+// binary. It returns the error instead of failing the test, so the caller can
+// fall back to the plain reset.
+func newResetCheck(k1 *kubeoneBin) (*resetCheck, error) {
+	restConfig, err := newRESTConfig(k1)
+	if err != nil {
+		return nil, err
+	}
+
+	clientScheme, err := newClientScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ctrlruntimeclient.New(restConfig, ctrlruntimeclient.Options{Scheme: clientScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	probeAPI, err := apiServerReadyz(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resetCheck{
+		client:           client,
+		reset:            k1.Reset,
+		probeAPI:         probeAPI,
+		machinesInterval: resetMachinesInterval,
+	}, nil
+}
+
+// Run resets the cluster and verifies the teardown. The first reset is
+// retried, the second one verifies that reset is idempotent.
+func (rc *resetCheck) Run(t *testing.T, opts ...pollOpts) error {
+	w := newPollConfig(resetAPIDownInterval, resetAPIDownTimeout, opts...)
+
+	// the Machines can't be listed once the control plane is gone, so they
+	// are observed while reset is running
+	rc.startObservingMachines()
+
+	err := retryFn(rc.reset)
+	machines, observed := rc.stopObservingMachines()
+	if err != nil {
+		return fmt.Errorf("kubeone reset failed: %w", err)
+	}
+
+	switch {
+	case !observed:
+		t.Logf("Machines were never listed during reset, skipping the Machines cleanup check")
+	case len(machines) > 0:
+		return fmt.Errorf("machines left behind by kubeone reset: %s", strings.Join(machines, ", "))
+	}
+
+	err = wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), resetAPIProbeTimeout)
+		defer cancel()
+
+		return apiUnreachable(rc.probeAPI(ctx)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("API server is still reachable after kubeone reset: %w", err)
+	}
+
+	if err = rc.reset(); err != nil {
+		return fmt.Errorf("kubeone reset is not idempotent, the second reset failed: %w", err)
+	}
+
+	return nil
+}
+
+// apiUnreachable returns whether the error is a connection-level error, i.e.
+// the connection to the API server can't be established. Any other error, e.g.
+// the API server not being ready, means the API server is still running.
+func apiUnreachable(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return utilnet.IsConnectionRefused(err)
+}
+
+// startObservingMachines lists the Machines once before returning, so they
+// are observed even if reset takes the API server down right away
+func (rc *resetCheck) startObservingMachines() {
+	rc.observeMachines()
+
+	rc.stop = make(chan struct{})
+	rc.done = make(chan struct{})
+
+	go func() {
+		defer close(rc.done)
+
+		wait.Until(rc.observeMachines, rc.machinesInterval, rc.stop)
+	}()
+}
+
+func (rc *resetCheck) observeMachines() {
+	machines := clusterv1alpha1.MachineList{}
+	if err := rc.client.List(context.Background(), &machines, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		// the API server is expected to go away during reset
+		return
+	}
+
+	names := []string{}
+	for _, m := range machines.Items {
+		names = append(names, m.Name)
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.lastMachines = names
+}
+
+// stopObservingMachines stops observing and returns the Machines seen by the
+// last successful list, and whether the list ever succeeded
+func (rc *resetCheck) stopObservingMachines() ([]string, bool) {
+	close(rc.stop)
+	<-rc.done
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.lastMachines, rc.lastMachines != nil
+}
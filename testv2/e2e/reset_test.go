@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var errAPIDown = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+// downClient fails all List calls once the API server is down
+type downClient struct {
+	client.Client
+	down *int32
+}
+
+func (c downClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if atomic.LoadInt32(c.down) == 1 {
+		return errAPIDown
+	}
+
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestResetCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		keepMachines   bool
+		keepAPI        bool
+		probeErr       error
+		failReset      func(call int) bool
+		expectedCalls  int
+		expectedErrMsg string
+		expectTimeout  bool
+	}{
+		{
+			name:          "cluster torn down",
+			expectedCalls: 2,
+		},
+		{
+			name:           "machines left behind",
+			keepMachines:   true,
+			expectedCalls:  1,
+			expectedErrMsg: "machines left behind by kubeone reset: worker-1, worker-2",
+		},
+		{
+			name:           "API server still reachable",
+			keepAPI:        true,
+			expectedCalls:  1,
+			expectedErrMsg: "API server is still reachable after kubeone reset",
+			expectTimeout:  true,
+		},
+		{
+			name:           "API server not ready",
+			probeErr:       k8serrors.NewInternalError(errors.New("etcd failed")),
+			expectedCalls:  1,
+			expectedErrMsg: "API server is still reachable after kubeone reset",
+			expectTimeout:  true,
+		},
+		{
+			name:          "first reset retried",
+			failReset:     func(call int) bool { return call == 1 },
+			expectedCalls: 3,
+		},
+		{
+			name:           "second reset fails",
+			failReset:      func(call int) bool { return call == 2 },
+			expectedCalls:  2,
+			expectedErrMsg: "kubeone reset is not idempotent, the second reset failed",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			machine := func(name string) client.Object {
+				return &clusterv1alpha1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+				}
+			}
+
+			scheme, err := newClientScheme()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var down int32
+			c := downClient{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine("worker-1"), machine("worker-2")).Build(),
+				down:   &down,
+			}
+
+			calls := 0
+			rc := &resetCheck{
+				client: c,
+				reset: func() error {
+					calls++
+
+					if tt.failReset != nil && tt.failReset(calls) {
+						return errors.New("reset failed")
+					}
+
+					if atomic.LoadInt32(&down) == 1 {
+						// the cluster is already gone
+						return nil
+					}
+
+					if !tt.keepMachines {
+						for _, name := range []string{"worker-1", "worker-2"} {
+							m := &clusterv1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem}}
+							if err := c.Delete(context.Background(), m); err != nil {
+								return err
+							}
+						}
+
+						// let the Machines be observed deleted
+						time.Sleep(30 * time.Millisecond)
+					}
+
+					if !tt.keepAPI {
+						atomic.StoreInt32(&down, 1)
+					}
+
+					return nil
+				},
+				probeAPI: func(context.Context) error {
+					if tt.probeErr != nil {
+						return tt.probeErr
+					}
+
+					if atomic.LoadInt32(&down) == 1 {
+						return errAPIDown
+					}
+
+					return nil
+				},
+				machinesInterval: 5 * time.Millisecond,
+			}
+
+			err = rc.Run(t,
+				withInterval(10*time.Millisecond),
+				withTimeout(50*time.Millisecond),
+			)
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("resetCheck.Run() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("resetCheck.Run() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			case errors.Is(err, wait.ErrWaitTimeout) != tt.expectTimeout:
+				t.Errorf("resetCheck.Run() error = %v, expected timeout %v", err, tt.expectTimeout)
+			}
+
+			if calls != tt.expectedCalls {
+				t.Errorf("kubeone reset called %d times, expected %d", calls, tt.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestAPIUnreachable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "connection refused",
+			err:      &url.Error{Op: "Get", URL: "https://10.0.0.1:6443/readyz", Err: errAPIDown},
+			expected: true,
+		},
+		{
+			name:     "dial timeout",
+			err:      &url.Error{Op: "Get", URL: "https://10.0.0.1:6443/readyz", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("i/o timeout")}},
+			expected: true,
+		},
+		{
+			name:     "read error",
+			err:      &url.Error{Op: "Get", URL: "https://10.0.0.1:6443/readyz", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}},
+			expected: false,
+		},
+		{
+			name:     "not ready",
+			err:      k8serrors.NewInternalError(errors.New("etcd failed")),
+			expected: false,
+		},
+		{
+			name:     "reachable",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := apiUnreachable(tt.err); got != tt.expected {
+			t.Errorf("%s: apiUnreachable() = %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
@@ -60,14 +60,13 @@ func (scenario *scenarioConformance) Run(t *testing.T) {
 }
 
 func (scenario *scenarioConformance) test(t *testing.T) {
-	data := manifestData{VERSION: scenario.versions[0]}
+	data := scenario.infra.manifestData(scenario.versions[0])
 	k1 := newKubeoneBin(
+		t,
 		scenario.infra.terraform.path,
-		renderManifest(t,
+		renderManifestFile(t,
 			scenario.manifestTemplatePath,
-			manifestData{
-				VERSION: scenario.versions[0],
-			},
+			scenario.infra.manifestData(scenario.versions[0]),
 		),
 	)
 
@@ -33,12 +33,10 @@ type scenarioInstall struct {
 	kubeonePath          string
 }
 
+// KubeonePath returns the kubeone binary used to install the cluster, an
+// empty path means the default binary, see resolveKubeoneBin
 func (scenario scenarioInstall) KubeonePath() string {
-	if scenario.kubeonePath != "" {
-		return scenario.kubeonePath
-	}
-
-	return kubeoneDistPath
+	return scenario.kubeonePath
 }
 
 func (scenario scenarioInstall) Title() string { return titleize(scenario.name) }
@@ -65,7 +63,7 @@ func (scenario *scenarioInstall) install(t *testing.T) {
 		t.Fatalf("only 1 version is expected to be set, got %v", scenario.versions)
 	}
 
-	clusterName := clusterName()
+	clusterName := clusterName(t.Name())
 
 	if err := scenario.infra.terraform.init(clusterName); err != nil {
 		t.Fatalf("terraform init failed: %v", err)
@@ -84,34 +82,51 @@ func (scenario *scenarioInstall) install(t *testing.T) {
 	})
 
 	k1 := newKubeoneBin(
+		t,
 		scenario.infra.terraform.path,
-		renderManifest(t,
+		renderManifestFile(t,
 			scenario.manifestTemplatePath,
-			manifestData{
-				VERSION: scenario.versions[0],
-			},
+			scenario.infra.manifestData(scenario.versions[0]),
 		),
 		withKubeoneBin(scenario.KubeonePath()),
 	)
 
+	if err := verifyBastionReachable(k1); err != nil {
+		t.Fatalf("ssh preflight failed: %v", err)
+	}
+
 	if err := k1.Apply(); err != nil {
 		t.Fatalf("kubeone apply failed: %v", err)
 	}
 
 	t.Cleanup(func() {
+		if *verifyResetFlag {
+			rc, err := newResetCheck(k1)
+			if err == nil {
+				if err = rc.Run(t); err != nil {
+					t.Fatalf("kubeone reset verification failed: %v", err)
+				}
+
+				return
+			}
+
+			t.Logf("skipping kubeone reset verification: %v", err)
+		}
+
 		if err := retryFn(func() error {
 			return k1.Reset()
 		}); err != nil {
-			t.Fatalf("terraform destroy failed: %v", err)
+			t.Fatalf("kubeone reset failed: %v", err)
 		}
 	})
 }
 
 func (scenario *scenarioInstall) test(t *testing.T) {
-	data := manifestData{VERSION: scenario.versions[0]}
+	data := scenario.infra.manifestData(scenario.versions[0])
 	k1 := newKubeoneBin(
+		t,
 		scenario.infra.terraform.path,
-		renderManifest(t,
+		renderManifestFile(t,
 			scenario.manifestTemplatePath,
 			data,
 		),
@@ -25,13 +25,24 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-const kubeoneVersionToInit = "1.4.4"
+const (
+	kubeoneVersionToInit = "1.4.4"
+
+	// upgradeWorkersAllowedOutdated is the number of workers which can still
+	// be in the middle of the rotation when verifying the worker versions
+	upgradeWorkersAllowedOutdated = 1
+)
 
 type scenarioUpgrade struct {
 	name                 string
 	manifestTemplatePath string
 	versions             []string
 	infra                Infra
+
+	// forceUpgrade runs the upgrade with --force-upgrade and forces another
+	// upgrade to the same version, which the version skew preflight check
+	// rejects unless forced
+	forceUpgrade bool
 }
 
 func (scenario scenarioUpgrade) Title() string { return titleize(scenario.name) }
@@ -64,33 +75,79 @@ func (scenario *scenarioUpgrade) Run(t *testing.T) {
 
 func (scenario *scenarioUpgrade) upgrade(t *testing.T) {
 	k1 := newKubeoneBin(
+		t,
 		scenario.infra.terraform.path,
-		renderManifest(t,
+		renderManifestFile(t,
 			scenario.manifestTemplatePath,
-			manifestData{
-				VERSION: scenario.versions[1],
-			},
+			scenario.infra.manifestData(scenario.versions[1]),
 		),
 	)
 
-	if err := k1.Apply(); err != nil {
+	client := kubeClient(t, k1)
+	workers, err := countWorkerNodes(client)
+	if err != nil {
+		t.Fatalf("counting worker nodes failed: %v", err)
+	}
+
+	pdb, err := newPDBCheck(client, workers)
+	if err != nil {
+		t.Logf("skipping PodDisruptionBudget check: %v", err)
+	} else {
+		if err = pdb.Deploy(t); err != nil {
+			t.Fatalf("deploying PodDisruptionBudget check failed: %v", err)
+		}
+
+		pdb.Start()
+	}
+
+	var flags []string
+	if *verifyWorkerVersionsFlag {
+		flags = append(flags, "--upgrade-machine-deployments")
+	}
+
+	if scenario.forceUpgrade {
+		flags = append(flags, "--force-upgrade")
+	}
+
+	if err := k1.Apply(flags...); err != nil {
 		t.Fatalf("kubeone apply failed: %v", err)
 	}
+
+	if scenario.forceUpgrade {
+		if err := k1.Apply(flags...); err != nil {
+			t.Fatalf("forced kubeone apply to the same version failed: %v", err)
+		}
+	}
+
+	if pdb == nil {
+		return
+	}
+
+	minAvailable, err := pdb.Stop()
+	if err != nil {
+		t.Fatalf("PodDisruptionBudget check failed during upgrade: %v", err)
+	}
+
+	t.Logf("minimum %s availability during upgrade: %d replicas", pdbCheckName, minAvailable)
 }
 
 func (scenario *scenarioUpgrade) test(t *testing.T) {
-	data := manifestData{
-		VERSION: scenario.versions[1],
-	}
+	data := scenario.infra.manifestData(scenario.versions[1])
 	k1 := newKubeoneBin(
+		t,
 		scenario.infra.terraform.path,
-		renderManifest(t,
+		renderManifestFile(t,
 			scenario.manifestTemplatePath,
 			data,
 		),
 	)
 
-	basicTest(t, k1, data)
+	var opts []basicTestOpts
+	if *verifyWorkerVersionsFlag {
+		opts = append(opts, withWorkerNodeVersions(upgradeWorkersAllowedOutdated))
+	}
+
+	basicTest(t, k1, data, opts...)
 	sonobuoyRun(t, k1, sonobuoyConformanceLite)
 }
 
@@ -17,14 +17,20 @@ limitations under the License.
 package e2e
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"k8c.io/kubeone/test/e2e/testutil"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type sonobuoyReport struct {
@@ -46,25 +52,195 @@ const (
 	sonobuoyConformanceLite sonobuoyMode = "conformance-lite"
 )
 
+type sonobuoyPlugin string
+
+const (
+	sonobuoyPluginE2E         sonobuoyPlugin = "e2e"
+	sonobuoyPluginSystemdLogs sonobuoyPlugin = "systemd-logs"
+)
+
 const (
-	sonobuoyResultsFile = "results.tar.gz"
+	sonobuoyResultsFile    = "results.tar.gz"
+	sonobuoyStatusInterval = time.Minute
+	sonobuoyWaitTimeout    = 4 * time.Hour
 )
 
+type sonobuoyStatus struct {
+	Status  string                 `json:"status"`
+	Plugins []sonobuoyPluginStatus `json:"plugins"`
+}
+
+type sonobuoyPluginStatus struct {
+	Plugin       string                  `json:"plugin"`
+	Node         string                  `json:"node"`
+	Status       string                  `json:"status"`
+	ResultStatus string                  `json:"result-status"`
+	Progress     *sonobuoyPluginProgress `json:"progress,omitempty"`
+}
+
+type sonobuoyPluginProgress struct {
+	Message   string   `json:"msg"`
+	Total     int      `json:"total"`
+	Completed int      `json:"completed"`
+	Failures  []string `json:"failures,omitempty"`
+}
+
+// Summary returns one line summary of the plugins progress. Plugins running
+// on every node (e.g. systemd-logs) are summarized as the number of nodes
+// where the plugin is complete.
+func (st sonobuoyStatus) Summary() string {
+	var (
+		names  []string
+		byName = map[string][]sonobuoyPluginStatus{}
+	)
+
+	for _, p := range st.Plugins {
+		if _, ok := byName[p.Plugin]; !ok {
+			names = append(names, p.Plugin)
+		}
+		byName[p.Plugin] = append(byName[p.Plugin], p)
+	}
+
+	summaries := []string{}
+	for _, name := range names {
+		plugins := byName[name]
+
+		if len(plugins) > 1 {
+			complete := 0
+			for _, p := range plugins {
+				if p.Status == "complete" {
+					complete++
+				}
+			}
+			summaries = append(summaries, fmt.Sprintf("%s: complete on %d/%d nodes", name, complete, len(plugins)))
+
+			continue
+		}
+
+		summary := fmt.Sprintf("%s: %s", name, plugins[0].Status)
+		if progress := plugins[0].Progress; progress != nil && progress.Total > 0 {
+			summary += fmt.Sprintf(" %d/%d", progress.Completed, progress.Total)
+			if len(progress.Failures) > 0 {
+				summary += fmt.Sprintf(" (%d failed)", len(progress.Failures))
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return strings.Join(summaries, ", ")
+}
+
 type sonobuoyBin struct {
 	dir        string
 	kubeconfig string
+
+	// e2eFocus and e2eSkip are regexes selecting the e2e tests to run, they
+	// override the focus and skip of the mode when set
+	e2eFocus string
+	e2eSkip  string
+	// e2eParallel runs the e2e tests in parallel when set, e.g. "true"
+	e2eParallel string
 }
 
-func (sbb *sonobuoyBin) Run(mode sonobuoyMode) error {
-	return sbb.run("run", fmt.Sprintf("-mode=%s", mode))
+// Run starts the given plugins in the given mode. The plugins are running in
+// parallel.
+func (sbb *sonobuoyBin) Run(mode sonobuoyMode, plugins ...sonobuoyPlugin) error {
+	return sbb.run(sbb.runArgs(mode, plugins...)...)
 }
 
-func (sbb *sonobuoyBin) Wait() error {
-	return sbb.run("wait")
+func (sbb *sonobuoyBin) runArgs(mode sonobuoyMode, plugins ...sonobuoyPlugin) []string {
+	args := []string{"run", fmt.Sprintf("-mode=%s", mode)}
+	for _, plugin := range plugins {
+		args = append(args, "--plugin", string(plugin))
+	}
+
+	if sbb.e2eFocus != "" {
+		args = append(args, "--e2e-focus", sbb.e2eFocus)
+	}
+
+	if sbb.e2eSkip != "" {
+		args = append(args, "--e2e-skip", sbb.e2eSkip)
+	}
+
+	if sbb.e2eParallel != "" {
+		args = append(args, "--e2e-parallel", sbb.e2eParallel)
+	}
+
+	return args
 }
 
+// Wait polls the sonobuoy status until all plugins are done, logging the
+// progress of the plugins on every poll to show the tests are still running.
+// Failures of the plugins are reported by the Results.
+func (sbb *sonobuoyBin) Wait(logf func(string, ...interface{})) error {
+	start := time.Now()
+
+	return wait.PollImmediate(sonobuoyStatusInterval, sonobuoyWaitTimeout, func() (bool, error) {
+		elapsed := time.Since(start).Round(time.Second)
+
+		status, err := sbb.Status()
+		if err != nil {
+			logf("sonobuoy status failed after %s: %v", elapsed, err)
+
+			return false, nil
+		}
+
+		logf("sonobuoy is %s after %s: %s", status.Status, elapsed, status.Summary())
+
+		switch status.Status {
+		case "complete", "failed":
+			return true, nil
+		}
+
+		return false, nil
+	})
+}
+
+func (sbb *sonobuoyBin) Status() (*sonobuoyStatus, error) {
+	var buf bytes.Buffer
+
+	exe := sbb.build("status", "--json")
+	testutil.StdoutTo(&buf)(exe)
+
+	if err := exe.Run(); err != nil {
+		return nil, err
+	}
+
+	status := &sonobuoyStatus{}
+	if err := json.Unmarshal(buf.Bytes(), status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// errSonobuoyNoResults is returned by Retrieve when sonobuoy succeeded, but
+// the results tarball is not there (yet)
+var errSonobuoyNoResults = errors.New("sonobuoy results are not available yet")
+
+// Retrieve downloads the results tarball from the sonobuoy aggregator
 func (sbb *sonobuoyBin) Retrieve() error {
-	return sbb.run("retrieve", "--filename", sonobuoyResultsFile)
+	if err := sbb.run("retrieve", "--filename", sonobuoyResultsFile); err != nil {
+		return fmt.Errorf("retrieving sonobuoy results: %w", err)
+	}
+
+	info, err := os.Stat(sbb.resultsPath())
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.Size() == 0) {
+		return errSonobuoyNoResults
+	}
+
+	return err
+}
+
+// resultsPath returns the absolute path of the results tarball, so it can be
+// found among the CI artifacts
+func (sbb *sonobuoyBin) resultsPath() string {
+	path, err := filepath.Abs(filepath.Join(sbb.dir, sonobuoyResultsFile))
+	if err != nil {
+		return filepath.Join(sbb.dir, sonobuoyResultsFile)
+	}
+
+	return path
 }
 
 func (sbb *sonobuoyBin) Results() ([]sonobuoyReport, error) {
@@ -75,20 +251,20 @@ func (sbb *sonobuoyBin) Results() ([]sonobuoyReport, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer rpipe.Close()
 
-	exe := sbb.build("results", sonobuoyResultsFile, "--mode", "detailed", "--plugin", "e2e")
-	if sbb.kubeconfig != "" {
-		testutil.WithEnvs(fmt.Sprintf("KUBECONFIG=%s", sbb.kubeconfig))(exe)
-	}
+	exe := sbb.build("results", sonobuoyResultsFile, "--mode", "detailed", "--plugin", string(sonobuoyPluginE2E))
 	cmd := exe.BuildCmd(ctx)
 	cmd.Stdout = wpipe
-	if err := cmd.Start(); err != nil {
+	if err = cmd.Start(); err != nil {
+		_ = wpipe.Close()
+
 		return nil, err
 	}
 
-	var waitErr error
+	waitErrCh := make(chan error, 1)
 	go func() {
-		waitErr = cmd.Wait()
+		waitErrCh <- cmd.Wait()
 		_ = wpipe.Close() // send EOF to break the reading loop (with EOF), ignore the error
 	}()
 
@@ -96,10 +272,16 @@ func (sbb *sonobuoyBin) Results() ([]sonobuoyReport, error) {
 	failedCases := []sonobuoyReport{}
 	for {
 		var rep sonobuoyReport
-		if err := dec.Decode(&rep); errors.Is(err, io.EOF) {
+		if err = dec.Decode(&rep); errors.Is(err, io.EOF) {
 			break
 		} else if err != nil {
-			return nil, err
+			// stop sonobuoy, the closed pipe unblocks its writes, and wait
+			// for it to exit
+			cancel()
+			_ = rpipe.Close()
+			<-waitErrCh
+
+			return nil, fmt.Errorf("parsing sonobuoy results tarball %s: %w", sbb.resultsPath(), err)
 		}
 		if rep.Status == "failed" {
 			// we are interested only in failed test cases
@@ -107,7 +289,11 @@ func (sbb *sonobuoyBin) Results() ([]sonobuoyReport, error) {
 		}
 	}
 
-	return failedCases, waitErr
+	if waitErr := <-waitErrCh; waitErr != nil {
+		return nil, fmt.Errorf("reading sonobuoy results tarball %s: %w", sbb.resultsPath(), waitErr)
+	}
+
+	return failedCases, nil
 }
 
 func (sbb *sonobuoyBin) run(args ...string) error {
@@ -115,10 +301,16 @@ func (sbb *sonobuoyBin) run(args ...string) error {
 }
 
 func (sbb *sonobuoyBin) build(args ...string) *testutil.Exec {
-	return testutil.NewExec("sonobuoy",
+	exe := testutil.NewExec("sonobuoy",
 		testutil.WithArgs(args...),
 		testutil.WithEnv(os.Environ()),
 		testutil.InDir(sbb.dir),
 		testutil.StdoutDebug,
 	)
+
+	if sbb.kubeconfig != "" {
+		testutil.WithEnvs(fmt.Sprintf("KUBECONFIG=%s", sbb.kubeconfig))(exe)
+	}
+
+	return exe
 }
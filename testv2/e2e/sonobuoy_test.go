@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSonobuoyStatusSummary(t *testing.T) {
+	t.Parallel()
+
+	out := `{
+		"plugins": [
+			{"plugin": "e2e", "node": "global", "status": "running", "result-status": "",
+			 "progress": {"name": "e2e", "node": "global", "msg": "PASSED", "total": 350, "completed": 120, "failures": ["[sig-network] test"]}},
+			{"plugin": "systemd-logs", "node": "cp-1", "status": "complete", "result-status": "passed"},
+			{"plugin": "systemd-logs", "node": "cp-2", "status": "complete", "result-status": "passed"},
+			{"plugin": "systemd-logs", "node": "worker-1", "status": "running", "result-status": ""}
+		],
+		"status": "running"
+	}`
+
+	status := sonobuoyStatus{}
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "e2e: running 120/350 (1 failed), systemd-logs: complete on 2/3 nodes"
+	if got := status.Summary(); got != expected {
+		t.Errorf("sonobuoyStatus.Summary() = %q, expected %q", got, expected)
+	}
+}
+
+func TestSonobuoyRunArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		sb       sonobuoyBin
+		expected []string
+	}{
+		{
+			name: "defaults",
+			sb:   sonobuoyBin{},
+			expected: []string{
+				"run", "-mode=conformance-lite",
+				"--plugin", "e2e",
+				"--plugin", "systemd-logs",
+			},
+		},
+		{
+			name: "focus, skip and parallel",
+			sb: sonobuoyBin{
+				e2eFocus:    `\[sig-network\]`,
+				e2eSkip:     `\[Serial\]|\[Disruptive\]`,
+				e2eParallel: "true",
+			},
+			expected: []string{
+				"run", "-mode=conformance-lite",
+				"--plugin", "e2e",
+				"--plugin", "systemd-logs",
+				"--e2e-focus", `\[sig-network\]`,
+				"--e2e-skip", `\[Serial\]|\[Disruptive\]`,
+				"--e2e-parallel", "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.sb.runArgs(sonobuoyConformanceLite, sonobuoyPluginE2E, sonobuoyPluginSystemdLogs)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("sonobuoyBin.runArgs() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// fakeSonobuoyBin puts the fake sonobuoy binary in the PATH. The fake retrieve
+// runs the given script, the fake results prints the content of the tarball.
+func fakeSonobuoyBin(t *testing.T, retrieve string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1\" in\nretrieve) " + retrieve + " ;;\nresults) tar -xzOf \"$2\" ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(dir, "sonobuoy"), []byte(script), 0700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func writeResultsTarball(t *testing.T, dir string, content string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	if err := tw.WriteHeader(&tar.Header{Name: "e2e.json", Mode: 0600, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, sonobuoyResultsFile), buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSonobuoyResults(t *testing.T) {
+	fakeSonobuoyBin(t, "true")
+
+	tests := []struct {
+		name           string
+		tarball        func(t *testing.T, dir string)
+		expectedErr    string
+		expectedReport []sonobuoyReport
+	}{
+		{
+			name: "failed test cases",
+			tarball: func(t *testing.T, dir string) {
+				writeResultsTarball(t, dir, `{"name": "passing", "status": "passed"}
+{"name": "failing", "status": "failed"}`)
+			},
+			expectedReport: []sonobuoyReport{{Name: "failing", Status: "failed"}},
+		},
+		{
+			name: "not a tarball",
+			tarball: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, sonobuoyResultsFile), []byte("<html>503</html>"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedErr: "reading sonobuoy results tarball",
+		},
+		{
+			name: "invalid report",
+			tarball: func(t *testing.T, dir string) {
+				writeResultsTarball(t, dir, "not a json")
+			},
+			expectedErr: "parsing sonobuoy results tarball",
+		},
+		{
+			// sonobuoy is blocked writing the rest of the output into the
+			// pipe nobody reads from anymore
+			name: "invalid report followed by more output than the pipe buffers",
+			tarball: func(t *testing.T, dir string) {
+				writeResultsTarball(t, dir, "not a json\n"+strings.Repeat(`{"name": "passing", "status": "passed"}`+"\n", 1<<16))
+			},
+			expectedErr: "parsing sonobuoy results tarball",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := sonobuoyBin{dir: t.TempDir()}
+			tt.tarball(t, sb.dir)
+
+			report, err := sb.Results()
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("sonobuoyBin.Results() unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(report, tt.expectedReport) {
+					t.Errorf("sonobuoyBin.Results() = %+v, expected %+v", report, tt.expectedReport)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("sonobuoyBin.Results() error = %v, expected to contain %q", err, tt.expectedErr)
+			}
+
+			// the path of the tarball is reported to find it among the CI artifacts
+			path := filepath.Join(sb.dir, sonobuoyResultsFile)
+			if !strings.Contains(err.Error(), path) {
+				t.Errorf("sonobuoyBin.Results() error = %v, expected to contain the tarball path %q", err, path)
+			}
+		})
+	}
+}
+
+func TestSonobuoyRetrieve(t *testing.T) {
+	tests := []struct {
+		name          string
+		retrieve      string
+		expectedErr   bool
+		expectedNoRes bool
+	}{
+		{
+			name:     "retrieved",
+			retrieve: `echo results > "$3"`,
+		},
+		{
+			name:          "no results yet",
+			retrieve:      "true",
+			expectedErr:   true,
+			expectedNoRes: true,
+		},
+		{
+			name:        "retrieval failed",
+			retrieve:    "exit 1",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeSonobuoyBin(t, tt.retrieve)
+			sb := sonobuoyBin{dir: t.TempDir()}
+
+			err := sb.Retrieve()
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("sonobuoyBin.Retrieve() error = %v, expected error %v", err, tt.expectedErr)
+			}
+			if got := errors.Is(err, errSonobuoyNoResults); got != tt.expectedNoRes {
+				t.Errorf("sonobuoyBin.Retrieve() error = %v, expected no results %v", err, tt.expectedNoRes)
+			}
+		})
+	}
+}
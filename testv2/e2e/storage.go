@@ -0,0 +1,239 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	storageCheckName    = "kubeone-e2e-storage-check"
+	storageCheckImage   = "busybox:1.35"
+	storageCheckFile    = "/data/kubeone-e2e"
+	storageCheckContent = "kubeone-e2e-storage-check"
+	storageCheckTimeout = 10 * time.Minute
+)
+
+// storageClassProviders are the providers for which the default-storage-class
+// addon creates the default StorageClass backed by the CSI driver
+var storageClassProviders = sets.NewString(
+	"aws",
+	"azure",
+	"digitalocean",
+	"gce",
+	"hetzner",
+	"nutanix",
+	"openstack",
+	"vmwareCloudDirector",
+	"vsphere",
+)
+
+// verifyVolumePersistence provisions the volume using the default
+// StorageClass, writes to it from one pod and reads it back from the recreated
+// pod. The PersistentVolumeClaim and PersistentVolume events are reported on
+// failure. The check is skipped on the providers without the default
+// StorageClass.
+func verifyVolumePersistence(t *testing.T, client ctrlruntimeclient.Client, cluster *kubeoneapi.KubeOneCluster, opts ...pollOpts) error {
+	provider := cluster.CloudProvider.CloudProviderName()
+	if !storageClassProviders.Has(provider) {
+		t.Logf("skipping volume persistence check, no default StorageClass is expected on %q provider", provider)
+
+		return nil
+	}
+
+	w := newPollConfig(defaultNodesReadyInterval, storageCheckTimeout, opts...)
+
+	pvc := storageCheckPVC()
+	if err := client.Create(context.Background(), pvc); err != nil {
+		return fmt.Errorf("creating %s PersistentVolumeClaim: %w", pvc.Name, err)
+	}
+
+	defer func() {
+		if err := client.Delete(context.Background(), pvc); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			t.Logf("failed to delete %s PersistentVolumeClaim: %v", pvc.Name, err)
+		}
+	}()
+
+	writer := storageCheckPod(fmt.Sprintf("echo %s > %s && sleep 3600", storageCheckContent, storageCheckFile))
+	writer.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"cat", storageCheckFile}},
+		},
+	}
+
+	err := runStorageCheckPod(t, client, writer, w, func(pod *corev1.Pod) (bool, error) {
+		if pod.Status.Phase == corev1.PodFailed {
+			return false, fmt.Errorf("writing to the volume failed: %s", podTerminationMessage(pod))
+		}
+
+		return pod.Status.Phase == corev1.PodRunning && podReady(pod.Status.Conditions), nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, volumeEvents(client, pvc))
+	}
+
+	reader := storageCheckPod(fmt.Sprintf("grep -qx %s %s", storageCheckContent, storageCheckFile))
+
+	err = runStorageCheckPod(t, client, reader, w, func(pod *corev1.Pod) (bool, error) {
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			return true, nil
+		case corev1.PodFailed:
+			return false, fmt.Errorf("data written to the volume didn't persist: %s", podTerminationMessage(pod))
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, volumeEvents(client, pvc))
+	}
+
+	return nil
+}
+
+func storageCheckPVC() *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      storageCheckName,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+}
+
+func storageCheckPod(script string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      storageCheckName,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:    "storage-check",
+					Image:   storageCheckImage,
+					Command: []string{"sh", "-c", script},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "data",
+							MountPath: "/data",
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: storageCheckName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// runStorageCheckPod creates the pod, waits for the condition and deletes the
+// pod, waiting for it to be gone so the volume is released for the next pod
+func runStorageCheckPod(t *testing.T, client ctrlruntimeclient.Client, pod *corev1.Pod, w *pollConfig, condition func(*corev1.Pod) (bool, error)) error {
+	if err := client.Create(context.Background(), pod); err != nil {
+		return fmt.Errorf("creating %s pod: %w", pod.Name, err)
+	}
+
+	key := ctrlruntimeclient.ObjectKeyFromObject(pod)
+	waitErr := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		if err := client.Get(context.Background(), key, pod); err != nil {
+			t.Logf("error: %v", err)
+
+			return false, nil
+		}
+
+		return condition(pod)
+	})
+	if waitErr != nil {
+		waitErr = fmt.Errorf("waiting for %s pod (phase %q): %w", pod.Name, pod.Status.Phase, waitErr)
+	}
+
+	if err := client.Delete(context.Background(), pod); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		t.Logf("failed to delete %s pod: %v", pod.Name, err)
+	}
+
+	err := wait.Poll(w.interval, w.timeout, func() (bool, error) {
+		err := client.Get(context.Background(), key, &corev1.Pod{})
+
+		return k8serrors.IsNotFound(err), nil
+	})
+	if waitErr == nil && err != nil {
+		waitErr = fmt.Errorf("waiting for %s pod to be deleted: %w", pod.Name, err)
+	}
+
+	return waitErr
+}
+
+// volumeEvents describes the events of the PersistentVolumeClaim and of the
+// PersistentVolume bound to it
+func volumeEvents(client ctrlruntimeclient.Client, pvc *corev1.PersistentVolumeClaim) string {
+	key := ctrlruntimeclient.ObjectKeyFromObject(pvc)
+	if err := client.Get(context.Background(), key, pvc); err != nil {
+		return fmt.Sprintf("failed to get %s PersistentVolumeClaim: %v", pvc.Name, err)
+	}
+
+	events := corev1.EventList{}
+	if err := client.List(context.Background(), &events, ctrlruntimeclient.InNamespace(pvc.Namespace)); err != nil {
+		return fmt.Sprintf("failed to list events: %v", err)
+	}
+
+	msgs := []string{fmt.Sprintf("PersistentVolumeClaim %s is %s", pvc.Name, pvc.Status.Phase)}
+	for _, event := range events.Items {
+		obj := event.InvolvedObject
+
+		switch {
+		case obj.Kind == "PersistentVolumeClaim" && obj.Name == pvc.Name:
+		case obj.Kind == "PersistentVolume" && obj.Name != "" && obj.Name == pvc.Spec.VolumeName:
+		default:
+			continue
+		}
+
+		msgs = append(msgs, fmt.Sprintf("%s %s: %s: %s", obj.Kind, obj.Name, event.Reason, event.Message))
+	}
+
+	return strings.Join(msgs, "\n")
+}
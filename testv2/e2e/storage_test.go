@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVerifyVolumePersistence(t *testing.T) {
+	t.Parallel()
+
+	failedProvisioning := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-event", Namespace: metav1.NamespaceDefault},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: storageCheckName,
+		},
+		Reason:  "ProvisioningFailed",
+		Message: "no volume plugin matched",
+	}
+
+	tests := []struct {
+		name           string
+		cloudProvider  kubeoneapi.CloudProviderSpec
+		objects        []client.Object
+		provisioned    bool
+		persisted      bool
+		expectedErrMsg string
+	}{
+		{
+			name:          "data persisted",
+			cloudProvider: kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			provisioned:   true,
+			persisted:     true,
+		},
+		{
+			name:          "data lost",
+			cloudProvider: kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			provisioned:   true,
+			// the reader pod fails immediately, no wait timeout expected
+			expectedErrMsg: "data written to the volume didn't persist: terminated: Error (exit code 1)",
+		},
+		{
+			name:           "volume not provisioned",
+			cloudProvider:  kubeoneapi.CloudProviderSpec{Hetzner: &kubeoneapi.HetznerSpec{}},
+			objects:        []client.Object{failedProvisioning},
+			expectedErrMsg: "PersistentVolumeClaim kubeone-e2e-storage-check: ProvisioningFailed: no volume plugin matched",
+		},
+		{
+			name:          "provider without default StorageClass",
+			cloudProvider: kubeoneapi.CloudProviderSpec{None: &kubeoneapi.NoneSpec{}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().WithObjects(tt.objects...).Build()
+			cluster := &kubeoneapi.KubeOneCluster{CloudProvider: tt.cloudProvider}
+
+			// act as kubelet, running the writer and then the reader pod
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				for ctx.Err() == nil {
+					pod := corev1.Pod{}
+					err := c.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: storageCheckName}, &pod)
+					if err == nil && tt.provisioned && pod.Status.Phase == "" {
+						switch {
+						case pod.Spec.Containers[0].ReadinessProbe != nil:
+							pod.Status.Phase = corev1.PodRunning
+							pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+						case tt.persisted:
+							pod.Status.Phase = corev1.PodSucceeded
+						default:
+							pod.Status.Phase = corev1.PodFailed
+							pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+								{
+									Name: "storage-check",
+									State: corev1.ContainerState{
+										Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+									},
+								},
+							}
+						}
+						_ = c.Status().Update(ctx, &pod)
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			}()
+
+			err := verifyVolumePersistence(t, c, cluster,
+				withInterval(10*time.Millisecond),
+				withTimeout(200*time.Millisecond),
+			)
+			cancel()
+
+			switch {
+			case tt.expectedErrMsg == "" && err != nil:
+				t.Errorf("verifyVolumePersistence() unexpected error: %v", err)
+			case tt.expectedErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg)):
+				t.Errorf("verifyVolumePersistence() error = %v, expected to contain %q", err, tt.expectedErrMsg)
+			case !tt.provisioned && tt.expectedErrMsg != "" && !errors.Is(err, wait.ErrWaitTimeout):
+				t.Errorf("verifyVolumePersistence() error = %v, expected timeout", err)
+			}
+
+			pods := corev1.PodList{}
+			if err = c.List(context.Background(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			if len(pods.Items) != 0 {
+				t.Errorf("storage check pod was not deleted")
+			}
+
+			pvcs := corev1.PersistentVolumeClaimList{}
+			if err = c.List(context.Background(), &pvcs); err != nil {
+				t.Fatal(err)
+			}
+			if len(pvcs.Items) != 0 {
+				t.Errorf("storage check PersistentVolumeClaim was not deleted")
+			}
+		})
+	}
+}
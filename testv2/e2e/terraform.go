@@ -19,6 +19,7 @@ package e2e
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"k8c.io/kubeone/test/e2e/testutil"
 )
@@ -30,16 +31,45 @@ var (
 	}
 )
 
+// terraformWorkspaceEnv selects the terraform workspace, each test cluster has
+// its own workspace so tests using the same terraform configs can run in
+// parallel without sharing the state
+const terraformWorkspaceEnv = "TF_WORKSPACE"
+
 type terraformBin struct {
 	name string
 	path string
 	vars []string
 }
 
+// varValue returns value of the terraform variable as set in the vars, or an
+// empty string if the variable is not set
+func (tf *terraformBin) varValue(name string) string {
+	for _, v := range tf.vars {
+		if key, val, ok := strings.Cut(v, "="); ok && key == name {
+			return val
+		}
+	}
+
+	return ""
+}
+
+// init initializes the terraform configs and creates the workspace for the
+// cluster with the given name, or selects it if it already exists
 func (tf *terraformBin) init(name string) error {
+	if err := tf.run("init"); err != nil {
+		return err
+	}
+
+	if err := tf.run("workspace", "new", name); err != nil {
+		if errSelect := tf.run("workspace", "select", name); errSelect != nil {
+			return fmt.Errorf("creating terraform workspace %s: %w", name, err)
+		}
+	}
+
 	tf.name = name
 
-	return tf.run("init")
+	return nil
 }
 
 func (tf *terraformBin) apply() error {
@@ -60,10 +90,19 @@ func (tf *terraformBin) run(args ...string) error {
 	return tf.build(args...).Run()
 }
 
+func (tf *terraformBin) environment() []string {
+	env := append([]string{}, defaultTFEnvironment...)
+	if tf.name != "" {
+		env = append(env, terraformWorkspaceEnv+"="+tf.name)
+	}
+
+	return env
+}
+
 func (tf *terraformBin) build(args ...string) *testutil.Exec {
 	return testutil.NewExec("terraform",
 		testutil.WithArgs(args...),
-		testutil.WithEnv(append(os.Environ(), defaultTFEnvironment...)),
+		testutil.WithEnv(append(os.Environ(), tf.environment()...)),
 		testutil.InDir(tf.path),
 		testutil.StdoutDebug,
 	)
@@ -23,6 +23,9 @@ import (
 	"flag"
 	"io"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var (
@@ -127,6 +130,11 @@ var (
 			name:                 "upgrade_containerd",
 			manifestTemplatePath: "testdata/containerd_simple.yaml",
 		},
+		"upgrade_force_containerd": &scenarioUpgrade{
+			name:                 "upgrade_force_containerd",
+			manifestTemplatePath: "testdata/containerd_simple.yaml",
+			forceUpgrade:         true,
+		},
 		"conformance_containerd": &scenarioConformance{
 			name:                 "conformance_containerd",
 			manifestTemplatePath: "testdata/containerd_simple.yaml",
@@ -151,7 +159,20 @@ var (
 )
 
 var (
-	kubeoneVerboseFlag = flag.Bool("kubeone-verbose", false, "run kubeone actions with --verbose flag")
+	kubeoneVerboseFlag       = flag.Bool("kubeone-verbose", false, "run kubeone actions with --verbose flag")
+	kubeoneSkipChecksumFlag  = flag.Bool("kubeone-skip-checksum", false, "don't verify the checksum of the downloaded kubeone release, e.g. when using a local mirror")
+	verifyWorkerVersionsFlag = flag.Bool("verify-worker-versions", false, "upgrade MachineDeployments in the upgrade tests and verify kubelet versions of the worker nodes")
+	verifyClusterDNSFlag     = flag.Bool("verify-cluster-dns", true, "verify the in-cluster and the external names are resolved by the cluster DNS")
+	verifyNodeLocalDNSFlag   = flag.Bool("verify-node-local-dns", true, "verify the node-local-dns DaemonSet is ready on every node and pods resolve names through it")
+	verifyLoadBalancerFlag   = flag.Bool("verify-load-balancer", true, "verify LoadBalancer services are provisioned and torn down by the cloud provider")
+	probeLoadBalancerFlag    = flag.Bool("probe-load-balancer", true, "request the LoadBalancer service over its external address when verifying it")
+	verifyVolumesFlag        = flag.Bool("verify-volumes", true, "verify volumes are provisioned by the default StorageClass and the written data persists")
+	rebootControlPlaneFlag   = flag.Bool("reboot-control-plane", false, "reboot one of the control plane nodes and verify the cluster survives it, destructive")
+	verifyResetFlag          = flag.Bool("verify-reset", true, "verify kubeone reset tears the cluster down and is idempotent")
+	sonobuoyE2EFocusFlag     = flag.String("sonobuoy-e2e-focus", "", "regex of the e2e tests run by sonobuoy, overrides the focus of the sonobuoy mode")
+	sonobuoyE2ESkipFlag      = flag.String("sonobuoy-e2e-skip", "", "regex of the e2e tests skipped by sonobuoy, overrides the skip of the sonobuoy mode")
+	sonobuoyE2EParallelFlag  = flag.String("sonobuoy-e2e-parallel", "", "run the sonobuoy e2e tests in parallel, e.g. true")
+	sshAgentSocketFlag       = flag.String("ssh-agent-socket", "", "SSH agent socket used by kubeone, defaults to SSH_AUTH_SOCK exported by the e2e image entrypoint")
 )
 
 type Infra struct {
@@ -177,4 +198,16 @@ type Scenario interface {
 type ProwConfig struct {
 	AlwaysRun bool
 	Optional  bool
+
+	// CPURequest, MemoryRequest, CPULimit and MemoryLimit override resources
+	// of the ProwJob container, CPU request defaults to 1 CPU when not set
+	CPURequest    *resource.Quantity
+	MemoryRequest *resource.Quantity
+	CPULimit      *resource.Quantity
+	MemoryLimit   *resource.Quantity
+
+	// NodeSelector and Tolerations are used to schedule the ProwJob to the
+	// dedicated nodes
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
 }
@@ -0,0 +1,34 @@
+//go:build e2e && e2e_force_upgrade
+
+/*
+Copyright 2022 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+)
+
+// The forced upgrade tests are not generated from tests.yml and don't run in
+// the default ProwJobs matrix, run them with -tags e2e,e2e_force_upgrade
+
+func TestAwsDefaultsUpgradeForceContainerdFromV1_22_9_ToV1_23_6(t *testing.T) {
+	infra := Infrastructures["aws_defaults"]
+	scenario := Scenarios["upgrade_force_containerd"]
+	scenario.SetInfra(infra)
+	scenario.SetVersions("v1.22.9", "v1.23.6")
+	scenario.Run(t)
+}
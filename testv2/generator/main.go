@@ -29,13 +29,21 @@ import (
 
 	"k8c.io/kubeone/testv2/e2e"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/yaml"
 )
 
 type Infrastructure struct {
-	Name      string `json:"name"`
-	AlwaysRun bool   `json:"alwaysRun"`
-	Optional  bool   `json:"optional"`
+	Name          string              `json:"name"`
+	AlwaysRun     bool                `json:"alwaysRun"`
+	Optional      bool                `json:"optional"`
+	CPURequest    *resource.Quantity  `json:"cpuRequest,omitempty"`
+	MemoryRequest *resource.Quantity  `json:"memoryRequest,omitempty"`
+	CPULimit      *resource.Quantity  `json:"cpuLimit,omitempty"`
+	MemoryLimit   *resource.Quantity  `json:"memoryLimit,omitempty"`
+	NodeSelector  map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations   []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 type KubeoneTest struct {
@@ -143,8 +151,14 @@ func main() {
 			scenario.SetVersions(versions...)
 
 			cfg := e2e.ProwConfig{
-				AlwaysRun: genInfra.AlwaysRun,
-				Optional:  genInfra.Optional,
+				AlwaysRun:     genInfra.AlwaysRun,
+				Optional:      genInfra.Optional,
+				CPURequest:    genInfra.CPURequest,
+				MemoryRequest: genInfra.MemoryRequest,
+				CPULimit:      genInfra.CPULimit,
+				MemoryLimit:   genInfra.MemoryLimit,
+				NodeSelector:  genInfra.NodeSelector,
+				Tolerations:   genInfra.Tolerations,
 			}
 
 			if err = scenario.GenerateTests(outputBuf, generatorType, cfg); err != nil {